@@ -0,0 +1,152 @@
+// Package api is the stability layer for go-pflow. It re-exports the core
+// types and entry points of the petri, solver, mining, eventlog, and
+// monitoring packages as aliases, forming the surface we commit to keeping
+// backward compatible across minor releases.
+//
+// Downstream code that imports only this package gets a dependable upgrade
+// path: anything reachable from here follows semantic versioning, while the
+// underlying packages remain importable directly for the experimental or
+// fast-moving parts of their APIs. The compile-time assertions in this file
+// double as a compatibility gate — renaming or re-signaturing a stable
+// symbol breaks the build here before it breaks a downstream user.
+//
+// Scope note: the unstable packages deliberately do NOT live under
+// internal/. Existing consumers import them directly, and moving them
+// would break every such import for a boundary the compiler enforces only
+// against new code. The stability promise is therefore advisory outside
+// this package: importing anything else in the module is supported but
+// carries no compatibility guarantee across minor releases.
+package api
+
+import (
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/mining"
+	"github.com/pflow-xyz/go-pflow/monitoring"
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// --- Petri net core ---
+
+type (
+	// PetriNet is the core model type: places, transitions, and arcs.
+	PetriNet = petri.PetriNet
+	// Place holds tokens in a PetriNet.
+	Place = petri.Place
+	// Transition consumes and produces tokens when fired.
+	Transition = petri.Transition
+	// Arc connects a place and a transition with a weight.
+	Arc = petri.Arc
+	// Builder is the fluent net construction API returned by Build.
+	Builder = petri.Builder
+)
+
+// Build starts a fluent Petri net builder.
+func Build() *Builder { return petri.Build() }
+
+// NewPetriNet creates an empty Petri net.
+func NewPetriNet() *PetriNet { return petri.NewPetriNet() }
+
+// --- ODE solver ---
+
+type (
+	// Problem describes an ODE initial value problem derived from a net.
+	Problem = solver.Problem
+	// Solution holds solver output: time points and state trajectories.
+	Solution = solver.Solution
+	// Options configures solver tolerances and step sizes.
+	Options = solver.Options
+	// Solver is an integration method (Tsit5, RK45, ...).
+	Solver = solver.Solver
+)
+
+// NewProblem builds an ODE problem from a net, initial state, time span,
+// and transition rates.
+func NewProblem(net *PetriNet, initialState map[string]float64, tspan [2]float64, rates map[string]float64) *Problem {
+	return solver.NewProblem(net, initialState, tspan, rates)
+}
+
+// Solve integrates a problem with the given method and options.
+func Solve(prob *Problem, method *Solver, opts *Options) *Solution {
+	return solver.Solve(prob, method, opts)
+}
+
+// FindEquilibrium runs a problem until the state stops changing.
+func FindEquilibrium(prob *Problem) (map[string]float64, bool) {
+	return solver.FindEquilibrium(prob)
+}
+
+// Tsit5 returns the default high-quality explicit method.
+func Tsit5() *Solver { return solver.Tsit5() }
+
+// RK45 returns the classic Dormand-Prince method.
+func RK45() *Solver { return solver.RK45() }
+
+// DefaultOptions returns general-purpose solver options.
+func DefaultOptions() *Options { return solver.DefaultOptions() }
+
+// FastOptions returns options tuned for interactive use.
+func FastOptions() *Options { return solver.FastOptions() }
+
+// AccurateOptions returns options tuned for research-grade accuracy.
+func AccurateOptions() *Options { return solver.AccurateOptions() }
+
+// --- Event logs and process mining ---
+
+type (
+	// EventLog is a parsed collection of traces for process mining.
+	EventLog = eventlog.EventLog
+	// CSVConfig maps CSV columns onto event fields.
+	CSVConfig = eventlog.CSVConfig
+	// DiscoveryResult is a discovered model plus diagnostics.
+	DiscoveryResult = mining.DiscoveryResult
+	// ConformanceResult reports how well a log fits a model.
+	ConformanceResult = mining.ConformanceResult
+)
+
+// ParseCSV reads an event log from a CSV file.
+func ParseCSV(filename string, config CSVConfig) (*EventLog, error) {
+	return eventlog.ParseCSV(filename, config)
+}
+
+// DefaultCSVConfig returns the standard case/activity/timestamp mapping.
+func DefaultCSVConfig() CSVConfig { return eventlog.DefaultCSVConfig() }
+
+// Discover mines a Petri net from an event log. Supported methods:
+// "common-path", "sequential", "alpha", "heuristic".
+func Discover(log *EventLog, method string) (*DiscoveryResult, error) {
+	return mining.Discover(log, method)
+}
+
+// LearnRatesFromLog estimates transition rates from observed durations.
+func LearnRatesFromLog(log *EventLog, net *PetriNet) map[string]float64 {
+	return mining.LearnRatesFromLog(log, net)
+}
+
+// CheckConformance replays a log against a model and reports fitness.
+func CheckConformance(log *EventLog, net *PetriNet) *ConformanceResult {
+	return mining.CheckConformance(log, net)
+}
+
+// --- Monitoring ---
+
+type (
+	// Monitor tracks active cases and predicts completions.
+	Monitor = monitoring.Monitor
+	// MonitorConfig configures prediction intervals and SLA thresholds.
+	MonitorConfig = monitoring.MonitorConfig
+	// MonitorCase is one tracked process instance.
+	MonitorCase = monitoring.Case
+	// MonitorAlert is a triggered alert condition.
+	MonitorAlert = monitoring.Alert
+	// MonitorPrediction holds predicted outcomes for a case.
+	MonitorPrediction = monitoring.Prediction
+)
+
+// NewMonitor creates a process monitor from a model and learned rates.
+func NewMonitor(net *PetriNet, rates map[string]float64, config MonitorConfig) *Monitor {
+	return monitoring.NewMonitor(net, rates, config)
+}
+
+// DefaultMonitorConfig returns sensible monitoring defaults.
+func DefaultMonitorConfig() MonitorConfig { return monitoring.DefaultMonitorConfig() }
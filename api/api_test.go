@@ -0,0 +1,28 @@
+package api
+
+import "testing"
+
+// TestStableSurfaceEndToEnd drives a model through the stability layer
+// only, proving the re-exported surface is self-sufficient for the core
+// build-simulate loop.
+func TestStableSurfaceEndToEnd(t *testing.T) {
+	net := Build().
+		Place("S", 99).Place("I", 1).Place("R", 0).
+		Transition("infect").Transition("recover").
+		Arc("S", "infect", 1).Arc("I", "infect", 1).Arc("infect", "I", 2).
+		Arc("I", "recover", 1).Arc("recover", "R", 1).
+		Done()
+
+	rates := map[string]float64{"infect": 0.02, "recover": 0.5}
+	prob := NewProblem(net, net.SetState(nil), [2]float64{0, 100}, rates)
+	sol := Solve(prob, Tsit5(), DefaultOptions())
+
+	final := sol.GetFinalState()
+	total := final["S"] + final["I"] + final["R"]
+	if total < 99.9 || total > 100.1 {
+		t.Errorf("Population not conserved: %v", final)
+	}
+	if final["R"] <= 0 {
+		t.Errorf("Expected recoveries, got %v", final)
+	}
+}
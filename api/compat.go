@@ -0,0 +1,17 @@
+package api
+
+// Compile-time compatibility gates for the stable surface. Each assertion
+// pins the signature of a stable entry point; a breaking change in an
+// underlying package fails this build before it reaches a downstream user.
+// Widening a signature here is a semver-major event and needs a matching
+// changelog entry.
+var (
+	_ func() *Builder                                                              = Build
+	_ func(*PetriNet, map[string]float64, [2]float64, map[string]float64) *Problem = NewProblem
+	_ func(*Problem, *Solver, *Options) *Solution                                  = Solve
+	_ func(*Problem) (map[string]float64, bool)                                    = FindEquilibrium
+	_ func(*EventLog, string) (*DiscoveryResult, error)                            = Discover
+	_ func(*EventLog, *PetriNet) map[string]float64                                = LearnRatesFromLog
+	_ func(*EventLog, *PetriNet) *ConformanceResult                                = CheckConformance
+	_ func(*PetriNet, map[string]float64, MonitorConfig) *Monitor                  = NewMonitor
+)
@@ -0,0 +1,146 @@
+package batch
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// arrowChunkSize is the number of results per Arrow record batch.
+const arrowChunkSize = 4096
+
+// WriteArrowIPC streams results as an Apache Arrow IPC stream: fixed
+// columns (job_id, scenario, seed, worker, elapsed_ns) plus one float64
+// column per place observed in any final state, named final_<place>.
+// Python and R readers (pyarrow.ipc, arrow::read_ipc_stream) consume the
+// stream without parsing overhead; results are chunked into record batches
+// so multi-gigabyte sets never materialize in one allocation.
+func WriteArrowIPC(w io.Writer, results []Result) error {
+	places := finalPlaces(results)
+
+	fields := []arrow.Field{
+		{Name: "job_id", Type: arrow.BinaryTypes.String},
+		{Name: "scenario", Type: arrow.BinaryTypes.String},
+		{Name: "seed", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "worker", Type: arrow.BinaryTypes.String},
+		{Name: "elapsed_ns", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "error", Type: arrow.BinaryTypes.String},
+	}
+	for _, place := range places {
+		fields = append(fields, arrow.Field{Name: "final_" + place, Type: arrow.PrimitiveTypes.Float64})
+	}
+	schema := arrow.NewSchema(fields, nil)
+
+	alloc := memory.NewGoAllocator()
+	writer := ipc.NewWriter(w, ipc.WithSchema(schema), ipc.WithAllocator(alloc))
+	defer writer.Close()
+
+	for start := 0; start < len(results); start += arrowChunkSize {
+		end := start + arrowChunkSize
+		if end > len(results) {
+			end = len(results)
+		}
+		record := buildRecord(schema, alloc, results[start:end], places)
+		err := writer.Write(record)
+		record.Release()
+		if err != nil {
+			return fmt.Errorf("failed to write record batch: %w", err)
+		}
+	}
+	return writer.Close()
+}
+
+// buildRecord assembles one record batch from a slice of results.
+func buildRecord(schema *arrow.Schema, alloc memory.Allocator, chunk []Result, places []string) arrow.Record {
+	builder := array.NewRecordBuilder(alloc, schema)
+	defer builder.Release()
+
+	jobID := builder.Field(0).(*array.StringBuilder)
+	scenario := builder.Field(1).(*array.StringBuilder)
+	seed := builder.Field(2).(*array.Int64Builder)
+	worker := builder.Field(3).(*array.StringBuilder)
+	elapsed := builder.Field(4).(*array.Int64Builder)
+	errCol := builder.Field(5).(*array.StringBuilder)
+
+	for _, result := range chunk {
+		jobID.Append(result.JobID)
+		scenario.Append(result.Scenario)
+		seed.Append(result.Seed)
+		worker.Append(result.Worker)
+		elapsed.Append(int64(result.Elapsed))
+		errCol.Append(result.Error)
+	}
+	for i, place := range places {
+		col := builder.Field(6 + i).(*array.Float64Builder)
+		for _, result := range chunk {
+			value, ok := result.Final[place]
+			if !ok {
+				col.AppendNull()
+				continue
+			}
+			col.Append(value)
+		}
+	}
+	return builder.NewRecord()
+}
+
+// WriteCSV writes results with the same column layout as WriteArrowIPC,
+// for consumers that prefer plain text.
+func WriteCSV(w io.Writer, results []Result) error {
+	places := finalPlaces(results)
+
+	writer := csv.NewWriter(w)
+	header := []string{"job_id", "scenario", "seed", "worker", "elapsed_ns", "error"}
+	for _, place := range places {
+		header = append(header, "final_"+place)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		row := []string{
+			result.JobID,
+			result.Scenario,
+			strconv.FormatInt(result.Seed, 10),
+			result.Worker,
+			strconv.FormatInt(int64(result.Elapsed), 10),
+			result.Error,
+		}
+		for _, place := range places {
+			if value, ok := result.Final[place]; ok {
+				row = append(row, strconv.FormatFloat(value, 'g', -1, 64))
+			} else {
+				row = append(row, "")
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// finalPlaces returns the sorted union of place names across all results.
+func finalPlaces(results []Result) []string {
+	seen := make(map[string]bool)
+	for _, result := range results {
+		for place := range result.Final {
+			seen[place] = true
+		}
+	}
+	places := make([]string, 0, len(seen))
+	for place := range seen {
+		places = append(places, place)
+	}
+	sort.Strings(places)
+	return places
+}
@@ -0,0 +1,87 @@
+package batch
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+)
+
+func sampleResults() []Result {
+	return []Result{
+		{JobID: "j-0", Scenario: "base", Seed: 0, Worker: "w1",
+			Elapsed: time.Millisecond, Final: map[string]float64{"a": 1.5, "b": 8.5}},
+		{JobID: "j-1", Scenario: "base", Seed: 1, Worker: "w2",
+			Elapsed: 2 * time.Millisecond, Final: map[string]float64{"a": 0.5, "b": 9.5}},
+		{JobID: "j-2", Scenario: "slow", Seed: 2, Worker: "w1",
+			Elapsed: time.Millisecond, Final: map[string]float64{"a": 10}},
+	}
+}
+
+func TestWriteArrowIPCRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteArrowIPC(&buf, sampleResults()); err != nil {
+		t.Fatalf("WriteArrowIPC: %v", err)
+	}
+
+	reader, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Release()
+
+	schema := reader.Schema()
+	for _, name := range []string{"job_id", "seed", "final_a", "final_b"} {
+		if !schema.HasField(name) {
+			t.Errorf("Schema missing field %s: %v", name, schema)
+		}
+	}
+
+	rows := 0
+	for reader.Next() {
+		record := reader.Record()
+		rows += int(record.NumRows())
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatalf("Reading stream: %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("Expected 3 rows, got %d", rows)
+	}
+}
+
+func TestWriteCSVMatchesLayout(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, sampleResults()); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected header + 3 rows, got %d lines", len(lines))
+	}
+	if lines[0] != "job_id,scenario,seed,worker,elapsed_ns,error,final_a,final_b" {
+		t.Errorf("Unexpected header: %s", lines[0])
+	}
+	// The slow scenario has no b place; its cell must be empty, not zero.
+	if !strings.HasSuffix(lines[3], ",10,") {
+		t.Errorf("Expected trailing empty cell for missing place: %s", lines[3])
+	}
+}
+
+func TestArrowEmptyResults(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteArrowIPC(&buf, nil); err != nil {
+		t.Fatalf("WriteArrowIPC(nil): %v", err)
+	}
+	reader, err := ipc.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer reader.Release()
+	if reader.Next() {
+		t.Error("Expected no record batches")
+	}
+}
@@ -0,0 +1,111 @@
+package batch
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func decayJob(id string, seed int64) *Job {
+	net := petri.Build().
+		Place("a", 10).Place("b", 0).
+		Transition("decay").
+		Arc("a", "decay", 1).Arc("decay", "b", 1).
+		Done()
+	return &Job{
+		ID:    id,
+		Net:   net,
+		Rates: map[string]float64{"decay": 1.0},
+		TSpan: [2]float64{0, 20},
+		Seed:  seed,
+	}
+}
+
+func TestRunJobAppliesScenarioOverrides(t *testing.T) {
+	job := decayJob("j1", 0)
+	job.Scenario = Scenario{Name: "slow", Rates: map[string]float64{"decay": 0.0}}
+
+	result := RunJob(job)
+	if result.Scenario != "slow" {
+		t.Errorf("Scenario name lost: %+v", result)
+	}
+	if result.Final["a"] < 9.9 {
+		t.Errorf("Zero-rate scenario should not decay: %v", result.Final)
+	}
+
+	base := RunJob(decayJob("j2", 0))
+	if base.Final["b"] < 9.0 {
+		t.Errorf("Base job should decay fully: %v", base.Final)
+	}
+}
+
+func TestCoordinatorWorkerRoundTrip(t *testing.T) {
+	coord := NewCoordinator()
+	template := *decayJob("study", 0)
+	coord.SubmitSeedRange(template, 0, 8)
+
+	server := httptest.NewServer(coord.Handler())
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Two workers race for the same queue.
+	for i := 0; i < 2; i++ {
+		worker := &Worker{
+			CoordinatorURL: server.URL,
+			Name:           "w",
+			Concurrency:    2,
+			PollInterval:   5 * time.Millisecond,
+		}
+		go worker.Run(ctx)
+	}
+
+	results, err := coord.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if len(results) != 8 {
+		t.Fatalf("Expected 8 results, got %d", len(results))
+	}
+
+	seeds := make(map[int64]bool)
+	for _, result := range results {
+		seeds[result.Seed] = true
+		if result.Error != "" {
+			t.Errorf("Job %s failed: %s", result.JobID, result.Error)
+		}
+		if result.Final["b"] < 9.0 {
+			t.Errorf("Job %s incomplete decay: %v", result.JobID, result.Final)
+		}
+		if result.Worker == "" {
+			t.Error("Result missing worker name")
+		}
+	}
+	if len(seeds) != 8 {
+		t.Errorf("Expected 8 distinct seeds, got %d", len(seeds))
+	}
+}
+
+func TestRequeueAbandonedJobs(t *testing.T) {
+	coord := NewCoordinator()
+	coord.RequeueAfter = 10 * time.Millisecond
+	coord.Submit(decayJob("j1", 0))
+
+	// First lease is abandoned (no result posted).
+	if job := coord.next(); job == nil {
+		t.Fatal("Expected a job")
+	}
+	if job := coord.next(); job != nil {
+		t.Fatal("Queue should be empty while leased")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	job := coord.next()
+	if job == nil || job.ID != "j1" {
+		t.Fatalf("Abandoned job not re-queued: %+v", job)
+	}
+}
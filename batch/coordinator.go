@@ -0,0 +1,162 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Coordinator queues jobs and collects results. Serve its Handler on any
+// address workers can reach:
+//
+//	coord := batch.NewCoordinator()
+//	coord.Submit(jobs...)
+//	http.ListenAndServe(":8080", coord.Handler())
+//	results, _ := coord.Wait(ctx)
+//
+// Workers that pull a job and never report back hold it forever by default;
+// set a RequeueAfter to hand abandoned jobs to another worker.
+type Coordinator struct {
+	mu        sync.Mutex
+	queue     []*Job
+	pending   map[string]pendingJob // leased to a worker, awaiting result
+	results   []Result
+	submitted int
+
+	// RequeueAfter re-queues a leased job if no result arrives within the
+	// window (0 = never; suits long simulations on reliable workers).
+	RequeueAfter time.Duration
+}
+
+type pendingJob struct {
+	job      *Job
+	leasedAt time.Time
+}
+
+// NewCoordinator creates an empty coordinator.
+func NewCoordinator() *Coordinator {
+	return &Coordinator{pending: make(map[string]pendingJob)}
+}
+
+// Submit queues jobs for workers to pull.
+func (c *Coordinator) Submit(jobs ...*Job) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queue = append(c.queue, jobs...)
+	c.submitted += len(jobs)
+}
+
+// SubmitSeedRange queues one copy of the job per seed in [from, to).
+func (c *Coordinator) SubmitSeedRange(template Job, from, to int64) {
+	jobs := make([]*Job, 0, to-from)
+	for seed := from; seed < to; seed++ {
+		job := template
+		job.Seed = seed
+		job.ID = fmt.Sprintf("%s-%d", template.ID, seed)
+		jobs = append(jobs, &job)
+	}
+	c.Submit(jobs...)
+}
+
+// next leases the next job to a worker.
+func (c *Coordinator) next() *Job {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Reclaim abandoned leases first.
+	if c.RequeueAfter > 0 {
+		for id, lease := range c.pending {
+			if time.Since(lease.leasedAt) > c.RequeueAfter {
+				c.queue = append(c.queue, lease.job)
+				delete(c.pending, id)
+			}
+		}
+	}
+
+	if len(c.queue) == 0 {
+		return nil
+	}
+	job := c.queue[0]
+	c.queue = c.queue[1:]
+	c.pending[job.ID] = pendingJob{job: job, leasedAt: time.Now()}
+	return job
+}
+
+// complete records a worker's result.
+func (c *Coordinator) complete(result Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, leased := c.pending[result.JobID]; !leased {
+		return // duplicate or unknown; first result wins
+	}
+	delete(c.pending, result.JobID)
+	c.results = append(c.results, result)
+}
+
+// Results returns a copy of the results collected so far.
+func (c *Coordinator) Results() []Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Result(nil), c.results...)
+}
+
+// Done reports whether every submitted job has a result.
+func (c *Coordinator) Done() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.results) == c.submitted
+}
+
+// Wait blocks until all submitted jobs have results or the context ends.
+func (c *Coordinator) Wait(ctx context.Context) ([]Result, error) {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		if c.Done() {
+			return c.Results(), nil
+		}
+		select {
+		case <-ctx.Done():
+			return c.Results(), ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Handler serves the worker protocol:
+//
+//	GET  /jobs/next — lease the next job (204 when the queue is empty)
+//	POST /results   — report a finished job
+func (c *Coordinator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jobs/next", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		job := c.next()
+		if job == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(job)
+	})
+	mux.HandleFunc("/results", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var result Result
+		if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+			http.Error(w, fmt.Sprintf("bad result: %v", err), http.StatusBadRequest)
+			return
+		}
+		c.complete(result)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	return mux
+}
@@ -0,0 +1,154 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Worker pulls jobs from a coordinator, runs them locally, and pushes the
+// results back. Run several workers (or one with Concurrency > 1) against
+// the same coordinator to spread a batch across machines.
+type Worker struct {
+	// CoordinatorURL is the base URL of the coordinator's Handler.
+	CoordinatorURL string
+
+	// Name identifies this worker in results (default: hostname).
+	Name string
+
+	// Concurrency is the number of jobs run in parallel (default 1).
+	Concurrency int
+
+	// PollInterval is the wait between polls when the queue is empty
+	// (default 500ms).
+	PollInterval time.Duration
+
+	// Client overrides http.DefaultClient.
+	Client *http.Client
+}
+
+// Run pulls and executes jobs until the context is cancelled. An empty
+// queue is not an error — the worker keeps polling so it picks up jobs
+// submitted later.
+func (w *Worker) Run(ctx context.Context) error {
+	name := w.Name
+	if name == "" {
+		name, _ = os.Hostname()
+	}
+	concurrency := w.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	interval := w.PollInterval
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				job, err := w.pull(ctx, client)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					errCh <- err
+					return
+				}
+				if job == nil {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(interval):
+					}
+					continue
+				}
+
+				result := RunJob(job)
+				result.Worker = name
+				if err := w.push(ctx, client, result); err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					errCh <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
+
+// pull leases the next job; nil means the queue is empty.
+func (w *Worker) pull(ctx context.Context, client *http.Client) (*Job, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, w.CoordinatorURL+"/jobs/next", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil, nil
+	case http.StatusOK:
+		var job Job
+		if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+			return nil, fmt.Errorf("bad job from coordinator: %w", err)
+		}
+		return &job, nil
+	default:
+		return nil, fmt.Errorf("coordinator returned %s", resp.Status)
+	}
+}
+
+// push reports a finished job.
+func (w *Worker) push(ctx context.Context, client *http.Client, result Result) error {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.CoordinatorURL+"/results", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("coordinator rejected result: %s", resp.Status)
+	}
+	return nil
+}
@@ -65,6 +65,21 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+	case "render":
+		if err := render(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "mine":
+		if err := mine(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case "serve":
+		if err := serve(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	case "help", "-h", "--help":
 		printUsage()
 	case "version", "-v", "--version":
@@ -89,6 +104,9 @@ Commands:
   analyze    Compute insights from simulation results
   plot       Generate SVG visualization from simulation results
   visualize  Generate SVG visualization of Petri net structure
+  render     Render model to SVG, DOT, PNG, or PDF
+  mine       Discover a model from a CSV/JSONL event log
+  serve      Serve a model over GraphQL
   summary    Display quick summary of results
   compare    Compare two simulation results
   sweep      Parameter sweep and optimization
@@ -109,6 +127,12 @@ Examples:
   # Compare two runs
   pflow compare baseline.json variant.json
 
+  # Discover a model from an event log
+  pflow mine events.csv --output model.json
+
+  # Serve a model over GraphQL
+  pflow serve model.json --addr :8080
+
 For command-specific help, run:
   pflow <command> --help`)
 }
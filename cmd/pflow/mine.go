@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/mining"
+	"github.com/pflow-xyz/go-pflow/parser"
+)
+
+func mine(args []string) error {
+	fs := flag.NewFlagSet("mine", flag.ExitOnError)
+	method := fs.String("method", "heuristic", "Discovery method: heuristic, alpha, sequential, common-path")
+	output := fs.String("output", "", "Output model JSON file")
+	learnRates := fs.Bool("rates", false, "Learn transition rates from event timing")
+	conformance := fs.Bool("conformance", false, "Check conformance of the log against the discovered model")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: pflow mine <events.csv|events.jsonl> [options]
+
+Discover a Petri net model from an event log.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Examples:
+  # Discover with the heuristic miner and save the model
+  pflow mine events.csv --output model.json
+
+  # Alpha miner with conformance report
+  pflow mine events.csv --method alpha --conformance
+
+  # Learn rates for simulation
+  pflow mine events.jsonl --output model.json --rates
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return fmt.Errorf("event log file required")
+	}
+
+	logFile := fs.Arg(0)
+	log, err := loadEventLog(logFile)
+	if err != nil {
+		return fmt.Errorf("parse event log: %w", err)
+	}
+
+	fmt.Printf("Parsed %d events in %d cases (%d activities)\n",
+		log.NumEvents(), log.NumCases(), len(log.GetActivities()))
+
+	result, err := mining.Discover(log, *method)
+	if err != nil {
+		return fmt.Errorf("discover: %w", err)
+	}
+
+	fmt.Printf("Discovered model (%s): %d places, %d transitions, %d arcs\n",
+		result.Method, len(result.Net.Places), len(result.Net.Transitions), len(result.Net.Arcs))
+
+	if *output != "" {
+		data, err := parser.ToJSON(result.Net)
+		if err != nil {
+			return fmt.Errorf("serialize model: %w", err)
+		}
+		if err := os.WriteFile(*output, data, 0644); err != nil {
+			return fmt.Errorf("save model: %w", err)
+		}
+		fmt.Printf("✓ Model saved to %s\n", *output)
+	}
+
+	if *learnRates {
+		rates := mining.LearnRatesFromLog(log, result.Net)
+		fmt.Println("Learned rates:")
+		for transition, rate := range rates {
+			fmt.Printf("  %s = %.4f\n", transition, rate)
+		}
+	}
+
+	if *conformance {
+		conf := mining.CheckConformance(log, result.Net)
+		fmt.Printf("Conformance: fitness %.2f (%d/%d traces fit)\n",
+			conf.Fitness, conf.FittingTraces, conf.TotalTraces)
+	}
+
+	return nil
+}
+
+// loadEventLog parses a CSV or JSONL event log based on file extension.
+func loadEventLog(filename string) (*eventlog.EventLog, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".jsonl":
+		return eventlog.ParseJSONL(filename, eventlog.DefaultJSONLConfig())
+	case ".csv":
+		return eventlog.ParseCSV(filename, eventlog.DefaultCSVConfig())
+	default:
+		return nil, fmt.Errorf("unsupported event log format %q (want .csv or .jsonl)", filepath.Ext(filename))
+	}
+}
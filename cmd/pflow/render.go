@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/parser"
+	"github.com/pflow-xyz/go-pflow/visualization"
+)
+
+func render(args []string) error {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	output := fs.String("output", "", "Output file (required); format from extension")
+	format := fs.String("format", "", "Override format: svg, dot, png, pdf")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: pflow render <model.json> [options]
+
+Render a Petri net model to SVG, Graphviz DOT, PNG, or PDF.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Examples:
+  # SVG diagram
+  pflow render model.json --output model.svg
+
+  # Graphviz source for custom layout
+  pflow render model.json --output model.dot
+
+  # Raster image for slides
+  pflow render model.json --output model.png
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return fmt.Errorf("model file required")
+	}
+	if *output == "" {
+		fs.Usage()
+		return fmt.Errorf("--output required")
+	}
+
+	jsonData, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("read model: %w", err)
+	}
+	net, err := parser.FromJSON(jsonData)
+	if err != nil {
+		return fmt.Errorf("parse model: %w", err)
+	}
+
+	kind := *format
+	if kind == "" {
+		kind = strings.TrimPrefix(strings.ToLower(filepath.Ext(*output)), ".")
+	}
+	switch kind {
+	case "svg":
+		err = visualization.SaveSVG(net, *output)
+	case "dot":
+		err = os.WriteFile(*output, []byte(visualization.ToDOT(net)), 0644)
+	case "png":
+		err = visualization.SavePNG(net, *output)
+	case "pdf":
+		err = visualization.SavePDF(net, *output)
+	default:
+		return fmt.Errorf("unsupported format %q (want svg, dot, png, or pdf)", kind)
+	}
+	if err != nil {
+		return fmt.Errorf("render %s: %w", kind, err)
+	}
+
+	fmt.Printf("✓ Rendered %s to %s\n", kind, *output)
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/graphql"
+	"github.com/pflow-xyz/go-pflow/parser"
+)
+
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "Listen address")
+	name := fs.String("name", "", "Model name in the schema (default: file name without extension)")
+	playground := fs.String("playground", "/graphql/i", "Playground path, empty to disable")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, `Usage: pflow serve <model.json> [options]
+
+Serve a model over GraphQL with an in-memory event-sourced store.
+
+Options:
+`)
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, `
+Examples:
+  # Serve on the default port with a playground
+  pflow serve model.json
+
+  # Custom address and model name
+  pflow serve model.json --addr :9090 --name approval
+`)
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return fmt.Errorf("model file required")
+	}
+
+	modelFile := fs.Arg(0)
+	jsonData, err := os.ReadFile(modelFile)
+	if err != nil {
+		return fmt.Errorf("read model: %w", err)
+	}
+	net, err := parser.FromJSON(jsonData)
+	if err != nil {
+		return fmt.Errorf("parse model: %w", err)
+	}
+
+	modelName := *name
+	if modelName == "" {
+		base := filepath.Base(modelFile)
+		modelName = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	eventStore := eventsource.NewMemoryStore()
+	defer eventStore.Close()
+	store := graphql.NewEventSourceStore(eventStore, net, modelName)
+
+	opts := []graphql.Option{graphql.WithModel(modelName, net, store)}
+	if *playground != "" {
+		opts = append(opts, graphql.WithPlayground(*playground))
+	}
+	server := graphql.NewServer(opts...)
+
+	fmt.Printf("Serving %s at http://localhost%s/graphql\n", modelName, *addr)
+	if *playground != "" {
+		fmt.Printf("Playground at http://localhost%s%s\n", *addr, *playground)
+	}
+	return http.ListenAndServe(*addr, server.Mux())
+}
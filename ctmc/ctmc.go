@@ -0,0 +1,339 @@
+// Package ctmc builds continuous-time Markov chains from the reachability
+// graph of a bounded Petri net with exponential transition rates.
+// Where the ODE solver gives a mean-field approximation, the CTMC gives
+// exact probabilities: steady-state and transient distributions over
+// discrete markings, and expected time to absorption for nets with
+// terminal states (e.g., win probabilities in the game examples).
+package ctmc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/reachability"
+)
+
+// Builder configures CTMC construction from a Petri net.
+type Builder struct {
+	net       *petri.PetriNet
+	rates     map[string]float64
+	initial   reachability.Marking
+	maxStates int
+}
+
+// New creates a CTMC builder for the given net and exponential rates.
+// Transitions missing from rates default to 1.0.
+func New(net *petri.PetriNet, rates map[string]float64) *Builder {
+	return &Builder{
+		net:       net,
+		rates:     net.SetRates(rates),
+		maxStates: 10000,
+	}
+}
+
+// WithInitialMarking sets a custom initial marking.
+func (b *Builder) WithInitialMarking(marking reachability.Marking) *Builder {
+	b.initial = marking.Copy()
+	return b
+}
+
+// WithMaxStates sets the state space exploration limit.
+func (b *Builder) WithMaxStates(max int) *Builder {
+	b.maxStates = max
+	return b
+}
+
+// Chain is a continuous-time Markov chain over the reachable markings of a
+// Petri net. State i corresponds to States[i]; Q is the infinitesimal
+// generator, with Q[i][j] the total rate from state i to state j and
+// negative diagonal entries.
+type Chain struct {
+	Graph  *reachability.Graph
+	States []*reachability.State
+	Q      [][]float64
+
+	index map[string]int // marking hash -> state index
+}
+
+// Build explores the state space and assembles the generator matrix.
+// It fails if exploration is truncated, since a partial chain would give
+// wrong probabilities.
+func (b *Builder) Build() (*Chain, error) {
+	analyzer := reachability.NewAnalyzer(b.net).WithMaxStates(b.maxStates)
+	if b.initial != nil {
+		analyzer = analyzer.WithInitialMarking(b.initial)
+	}
+	result := analyzer.BuildGraph()
+	if result.Truncated {
+		return nil, fmt.Errorf("state space truncated at %d states: %s", result.StateCount, result.TruncateMsg)
+	}
+
+	states := result.Graph.StatesList()
+	index := make(map[string]int, len(states))
+	for i, s := range states {
+		index[s.Hash] = i
+	}
+
+	n := len(states)
+	q := make([][]float64, n)
+	for i := range q {
+		q[i] = make([]float64, n)
+	}
+	for _, edge := range result.Graph.Edges {
+		rate := b.rates[edge.Transition]
+		if rate <= 0 {
+			continue
+		}
+		i := index[edge.From.Hash]
+		j := index[edge.To.Hash]
+		q[i][j] += rate
+		q[i][i] -= rate
+	}
+
+	return &Chain{
+		Graph:  result.Graph,
+		States: states,
+		Q:      q,
+		index:  index,
+	}, nil
+}
+
+// StateIndex returns the index of the state with the given marking.
+func (c *Chain) StateIndex(marking reachability.Marking) (int, bool) {
+	i, ok := c.index[marking.Hash()]
+	return i, ok
+}
+
+// InitialDistribution returns the point distribution on the initial state.
+func (c *Chain) InitialDistribution() []float64 {
+	dist := make([]float64, len(c.States))
+	for i, s := range c.States {
+		if s.IsInitial {
+			dist[i] = 1.0
+			break
+		}
+	}
+	return dist
+}
+
+// AbsorbingStates returns states with no outgoing rate (terminal markings).
+func (c *Chain) AbsorbingStates() []*reachability.State {
+	var absorbing []*reachability.State
+	for i, s := range c.States {
+		if c.Q[i][i] == 0 {
+			absorbing = append(absorbing, s)
+		}
+	}
+	return absorbing
+}
+
+// uniformization returns the rate λ and the uniformized jump matrix
+// P = I + Q/λ, whose self-loops make the embedded chain aperiodic.
+func (c *Chain) uniformization() (float64, [][]float64) {
+	lambda := 0.0
+	for i := range c.Q {
+		if exit := -c.Q[i][i]; exit > lambda {
+			lambda = exit
+		}
+	}
+	if lambda == 0 {
+		lambda = 1.0
+	}
+	lambda *= 1.1
+
+	n := len(c.Q)
+	p := make([][]float64, n)
+	for i := range p {
+		p[i] = make([]float64, n)
+		for j := range p[i] {
+			p[i][j] = c.Q[i][j] / lambda
+		}
+		p[i][i] += 1.0
+	}
+	return lambda, p
+}
+
+// step computes dist * P.
+func step(dist []float64, p [][]float64) []float64 {
+	next := make([]float64, len(dist))
+	for i, di := range dist {
+		if di == 0 {
+			continue
+		}
+		for j, pij := range p[i] {
+			if pij != 0 {
+				next[j] += di * pij
+			}
+		}
+	}
+	return next
+}
+
+// SteadyState returns the limiting distribution reached from the initial
+// marking. For an irreducible chain this is the unique stationary
+// distribution; for a chain with absorbing states it gives the absorption
+// probabilities.
+func (c *Chain) SteadyState() []float64 {
+	const (
+		tol      = 1e-12
+		maxIters = 1000000
+	)
+	_, p := c.uniformization()
+	dist := c.InitialDistribution()
+	for iter := 0; iter < maxIters; iter++ {
+		next := step(dist, p)
+		delta := 0.0
+		for i := range next {
+			delta += math.Abs(next[i] - dist[i])
+		}
+		dist = next
+		if delta < tol {
+			break
+		}
+	}
+	return dist
+}
+
+// TransientAt returns the state distribution at time t, starting from the
+// initial marking, computed by uniformization. The Poisson jump-count
+// weights are accumulated in scaled form anchored at the distribution's
+// mode and renormalized at the end, so large lambda*t cannot underflow the
+// series to zero. It fails when the series would need more terms than the
+// iteration cap allows.
+func (c *Chain) TransientAt(t float64) ([]float64, error) {
+	if t <= 0 {
+		return c.InitialDistribution(), nil
+	}
+	lambda, p := c.uniformization()
+	lt := lambda * t
+
+	// The series needs roughly lt terms to reach the Poisson mode plus a
+	// tail of O(sqrt(lt)); refuse up front rather than grind to the cap.
+	const maxTerms = 1000000
+	if lt+40*math.Sqrt(lt)+10 > maxTerms {
+		return nil, fmt.Errorf("uniformization needs more than %d terms (lambda*t = %g)", maxTerms, lt)
+	}
+
+	// Scale each Poisson weight by the value at the mode: the log weight
+	// -lt + k*ln(lt) - ln(k!) peaks there, so the scaled weights stay
+	// representable no matter how large lt gets.
+	mode := math.Floor(lt)
+	logMode, _ := math.Lgamma(mode + 1)
+	logMode = -lt + mode*math.Log(lt) - logMode
+
+	dist := make([]float64, len(c.States))
+	term := c.InitialDistribution()
+	total := 0.0
+	logWeight := -lt
+	for k := 0; k <= maxTerms; k++ {
+		if k > 0 {
+			term = step(term, p)
+			logWeight += math.Log(lt / float64(k))
+		}
+		weight := math.Exp(logWeight - logMode)
+		total += weight
+		for i := range term {
+			dist[i] += weight * term[i]
+		}
+		// Weights decay monotonically past the mode; stop once the next
+		// terms cannot move the renormalized result.
+		if float64(k) > mode && weight < 1e-16*total {
+			break
+		}
+	}
+
+	// Renormalize so the truncated, scaled weights sum to 1, keeping the
+	// result an exact distribution instead of silently losing mass.
+	for i := range dist {
+		dist[i] /= total
+	}
+	return dist, nil
+}
+
+// ExpectedTimeToAbsorption returns, for every state, the expected time
+// until an absorbing state is reached. Absorbing states have time 0.
+// It fails if the chain has no absorbing states.
+func (c *Chain) ExpectedTimeToAbsorption() ([]float64, error) {
+	n := len(c.States)
+	transient := make([]int, 0, n)
+	for i := range c.States {
+		if c.Q[i][i] != 0 {
+			transient = append(transient, i)
+		}
+	}
+	if len(transient) == n {
+		return nil, fmt.Errorf("chain has no absorbing states")
+	}
+
+	// Solve Q_TT * u = -1 over the transient states.
+	m := len(transient)
+	a := make([][]float64, m)
+	for r, i := range transient {
+		a[r] = make([]float64, m+1)
+		for s, j := range transient {
+			a[r][s] = c.Q[i][j]
+		}
+		a[r][m] = -1.0
+	}
+	u, err := solveLinear(a)
+	if err != nil {
+		return nil, err
+	}
+
+	times := make([]float64, n)
+	for r, i := range transient {
+		times[i] = u[r]
+	}
+	return times, nil
+}
+
+// ExpectedTokens converts a state distribution into expected token counts
+// per place.
+func (c *Chain) ExpectedTokens(dist []float64) map[string]float64 {
+	expected := make(map[string]float64)
+	for i, s := range c.States {
+		if dist[i] == 0 {
+			continue
+		}
+		for place, tokens := range s.Marking {
+			expected[place] += dist[i] * float64(tokens)
+		}
+	}
+	return expected
+}
+
+// solveLinear solves an augmented system [A | b] by Gaussian elimination
+// with partial pivoting.
+func solveLinear(a [][]float64) ([]float64, error) {
+	const eps = 1e-12
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < eps {
+			return nil, fmt.Errorf("singular system at column %d", col)
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for r := col + 1; r < n; r++ {
+			factor := a[r][col] / a[col][col]
+			for cc := col; cc <= n; cc++ {
+				a[r][cc] -= factor * a[col][cc]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		sum := a[r][n]
+		for cc := r + 1; cc < n; cc++ {
+			sum -= a[r][cc] * x[cc]
+		}
+		x[r] = sum / a[r][r]
+	}
+	return x, nil
+}
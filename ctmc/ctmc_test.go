@@ -0,0 +1,176 @@
+package ctmc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// toggleNet is a two-state birth-death chain: a -> b at rate 2, b -> a at
+// rate 1, with stationary distribution (1/3, 2/3).
+func toggleNet() (*petri.PetriNet, map[string]float64) {
+	net := petri.Build().
+		Place("a", 1).Place("b", 0).
+		Transition("go").Transition("back").
+		Arc("a", "go", 1).Arc("go", "b", 1).
+		Arc("b", "back", 1).Arc("back", "a", 1).
+		Done()
+	return net, map[string]float64{"go": 2.0, "back": 1.0}
+}
+
+func TestBuildGenerator(t *testing.T) {
+	net, rates := toggleNet()
+	chain, err := New(net, rates).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	if len(chain.States) != 2 {
+		t.Fatalf("Expected 2 states, got %d", len(chain.States))
+	}
+	for i := range chain.Q {
+		rowSum := 0.0
+		for _, v := range chain.Q[i] {
+			rowSum += v
+		}
+		if math.Abs(rowSum) > 1e-12 {
+			t.Errorf("Generator row %d sums to %g, want 0", i, rowSum)
+		}
+	}
+}
+
+func TestSteadyState(t *testing.T) {
+	net, rates := toggleNet()
+	chain, err := New(net, rates).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	dist := chain.SteadyState()
+	tokens := chain.ExpectedTokens(dist)
+	if math.Abs(tokens["a"]-1.0/3.0) > 1e-6 {
+		t.Errorf("Expected P(a) = 1/3, got %f", tokens["a"])
+	}
+	if math.Abs(tokens["b"]-2.0/3.0) > 1e-6 {
+		t.Errorf("Expected P(b) = 2/3, got %f", tokens["b"])
+	}
+}
+
+func TestTransientAt(t *testing.T) {
+	net, rates := toggleNet()
+	chain, err := New(net, rates).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	// At t=0 all mass is on the initial state.
+	d0, err := chain.TransientAt(0)
+	if err != nil {
+		t.Fatalf("TransientAt(0): %v", err)
+	}
+	if tokens := chain.ExpectedTokens(d0); tokens["a"] != 1.0 {
+		t.Errorf("Expected P(a) = 1 at t=0, got %f", tokens["a"])
+	}
+
+	// P(a at t) = 1/3 + 2/3 * exp(-3t) for this chain.
+	dist, err := chain.TransientAt(0.5)
+	if err != nil {
+		t.Fatalf("TransientAt(0.5): %v", err)
+	}
+	tokens := chain.ExpectedTokens(dist)
+	want := 1.0/3.0 + 2.0/3.0*math.Exp(-1.5)
+	if math.Abs(tokens["a"]-want) > 1e-6 {
+		t.Errorf("Expected P(a) = %f at t=0.5, got %f", want, tokens["a"])
+	}
+
+	// Distribution always sums to 1.
+	d2, err := chain.TransientAt(2.0)
+	if err != nil {
+		t.Fatalf("TransientAt(2.0): %v", err)
+	}
+	sum := 0.0
+	for _, p := range d2 {
+		sum += p
+	}
+	if math.Abs(sum-1.0) > 1e-9 {
+		t.Errorf("Transient distribution sums to %f", sum)
+	}
+}
+
+func TestTransientAtLargeRateTime(t *testing.T) {
+	// lambda*t far beyond the range where exp(-lambda*t) is representable;
+	// the naive series underflows to an all-zero "distribution" here.
+	net, rates := toggleNet()
+	rates["go"] = 50.0
+	rates["back"] = 25.0
+	chain, err := New(net, rates).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	dist, err := chain.TransientAt(100)
+	if err != nil {
+		t.Fatalf("TransientAt(100): %v", err)
+	}
+	sum := 0.0
+	for _, p := range dist {
+		sum += p
+	}
+	if math.Abs(sum-1.0) > 1e-9 {
+		t.Fatalf("Transient distribution sums to %f", sum)
+	}
+
+	// Long after mixing the transient distribution is the stationary one:
+	// P(a) = 25/75, P(b) = 50/75.
+	tokens := chain.ExpectedTokens(dist)
+	if math.Abs(tokens["a"]-1.0/3.0) > 1e-6 || math.Abs(tokens["b"]-2.0/3.0) > 1e-6 {
+		t.Errorf("Expected stationary split (1/3, 2/3), got a=%f b=%f", tokens["a"], tokens["b"])
+	}
+}
+
+func TestExpectedTimeToAbsorption(t *testing.T) {
+	// Linear chain a -> b -> c; expected time 1/2 + 1/4 from a.
+	net := petri.Build().
+		Chain(1, "a", "t1", "b", "t2", "c").
+		Done()
+	rates := map[string]float64{"t1": 2.0, "t2": 4.0}
+
+	chain, err := New(net, rates).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	absorbing := chain.AbsorbingStates()
+	if len(absorbing) != 1 {
+		t.Fatalf("Expected 1 absorbing state, got %d", len(absorbing))
+	}
+
+	times, err := chain.ExpectedTimeToAbsorption()
+	if err != nil {
+		t.Fatalf("ExpectedTimeToAbsorption failed: %v", err)
+	}
+	initial := chain.InitialDistribution()
+	for i, p := range initial {
+		if p == 1.0 && math.Abs(times[i]-0.75) > 1e-9 {
+			t.Errorf("Expected absorption time 0.75, got %f", times[i])
+		}
+	}
+
+	// A cyclic chain has no absorbing states.
+	cyclic, rates2 := toggleNet()
+	c2, err := New(cyclic, rates2).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if _, err := c2.ExpectedTimeToAbsorption(); err == nil {
+		t.Error("Expected error for chain without absorbing states")
+	}
+}
+
+func TestBuildRejectsTruncation(t *testing.T) {
+	net, rates := toggleNet()
+	if _, err := New(net, rates).WithMaxStates(1).Build(); err == nil {
+		t.Error("Expected error when state space is truncated")
+	}
+}
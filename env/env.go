@@ -0,0 +1,178 @@
+// Package env exposes a Gym-style episode interface (Reset, Step,
+// LegalActions, Observation) over any Petri net with designated action
+// transitions and reward places. The chess, nim, connect4, and catacombs
+// examples each hand-roll this move loop; wrapping the net once lets RL
+// agents and the ODE strategies be benchmarked on equal footing. Firing
+// semantics (arc weights, inhibitors, guards, weight expressions) are the
+// discrete ones from the reachability package.
+package env
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/reachability"
+)
+
+// Reward computes the step reward from the observations before and after
+// the action fired.
+type Reward func(prev, next map[string]float64) float64
+
+// Environment is an episodic wrapper around a Petri net.
+type Environment struct {
+	net      *petri.PetriNet
+	graph    *reachability.Graph // discrete firing semantics
+	index    *petri.PlaceIndex
+	actions  []string
+	reward   Reward
+	maxSteps int
+
+	initial reachability.Marking
+	marking reachability.Marking
+	steps   int
+}
+
+// New creates an environment over the net's initial marking. By default
+// every transition is an action and rewards are zero.
+func New(net *petri.PetriNet) *Environment {
+	initial := make(reachability.Marking)
+	for name, place := range net.Places {
+		initial[name] = int(place.GetTokenCount())
+	}
+
+	actions := make([]string, 0, len(net.Transitions))
+	for t := range net.Transitions {
+		actions = append(actions, t)
+	}
+	sort.Strings(actions)
+
+	e := &Environment{
+		net:      net,
+		graph:    reachability.NewGraph(net, initial),
+		index:    net.PlaceIndex(),
+		actions:  actions,
+		reward:   func(prev, next map[string]float64) float64 { return 0 },
+		maxSteps: 1000,
+		initial:  initial,
+	}
+	e.marking = initial.Copy()
+	return e
+}
+
+// WithActions restricts the action space to the given transitions; other
+// transitions never fire.
+func (e *Environment) WithActions(transitions ...string) *Environment {
+	e.actions = append([]string(nil), transitions...)
+	sort.Strings(e.actions)
+	return e
+}
+
+// WithInitialMarking sets a custom starting marking for episodes.
+func (e *Environment) WithInitialMarking(marking reachability.Marking) *Environment {
+	e.initial = marking.Copy()
+	e.marking = marking.Copy()
+	return e
+}
+
+// WithRewardPlace rewards each step by the weighted token change in a
+// place (e.g. +10 per token flowing into "wins"). May be called several
+// times to combine places.
+func (e *Environment) WithRewardPlace(place string, weight float64) *Environment {
+	prev := e.reward
+	e.reward = func(before, after map[string]float64) float64 {
+		return prev(before, after) + weight*(after[place]-before[place])
+	}
+	return e
+}
+
+// WithReward replaces the reward function entirely.
+func (e *Environment) WithReward(r Reward) *Environment {
+	e.reward = r
+	return e
+}
+
+// WithMaxSteps caps episode length; Step reports Done when reached.
+func (e *Environment) WithMaxSteps(n int) *Environment {
+	e.maxSteps = n
+	return e
+}
+
+// Actions returns the full action space in stable order.
+func (e *Environment) Actions() []string {
+	return e.actions
+}
+
+// Reset restores the initial marking and returns the first observation.
+func (e *Environment) Reset() map[string]float64 {
+	e.marking = e.initial.Copy()
+	e.steps = 0
+	return e.Observation()
+}
+
+// Observation returns the current marking as a state map.
+func (e *Environment) Observation() map[string]float64 {
+	return e.marking.ToState()
+}
+
+// ObservationVector returns the current marking as a dense vector over the
+// net's stable place index, for agents that want fixed-size input.
+func (e *Environment) ObservationVector() []float64 {
+	m := e.index.MarkingFrom(e.Observation())
+	v := make([]float64, e.index.Len())
+	for i := range v {
+		v[i] = m.At(i)
+	}
+	return v
+}
+
+// LegalActions returns the actions enabled in the current marking.
+func (e *Environment) LegalActions() []string {
+	var legal []string
+	for _, a := range e.actions {
+		if e.graph.Fire(e.marking, a) != nil {
+			legal = append(legal, a)
+		}
+	}
+	return legal
+}
+
+// StepResult reports the outcome of one action.
+type StepResult struct {
+	Observation map[string]float64
+	Reward      float64
+	Done        bool // no legal actions remain or the step cap was hit
+	Steps       int  // steps taken this episode
+}
+
+// Step fires an action transition and returns the resulting observation,
+// reward, and episode status. Illegal actions are an error and leave the
+// marking unchanged.
+func (e *Environment) Step(action string) (*StepResult, error) {
+	found := false
+	for _, a := range e.actions {
+		if a == action {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("action %q not in action space", action)
+	}
+
+	before := e.Observation()
+	next := e.graph.Fire(e.marking, action)
+	if next == nil {
+		return nil, fmt.Errorf("action %q not enabled in current marking", action)
+	}
+	e.marking = next
+	e.steps++
+
+	after := e.Observation()
+	return &StepResult{
+		Observation: after,
+		Reward:      e.reward(before, after),
+		Done:        e.steps >= e.maxSteps || len(e.LegalActions()) == 0,
+		Steps:       e.steps,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// pileNet is a tiny take-away game: remove one or two tokens from a pile,
+// scoring a win token when the pile empties via take2.
+func pileNet() *petri.PetriNet {
+	return petri.Build().
+		Place("pile", 3).Place("wins", 0).
+		Transition("take1").Transition("take2").
+		Arc("pile", "take1", 1).
+		Arc("pile", "take2", 2).Arc("take2", "wins", 1).
+		Done()
+}
+
+func TestResetAndObservation(t *testing.T) {
+	e := New(pileNet())
+
+	obs := e.Reset()
+	if obs["pile"] != 3 || obs["wins"] != 0 {
+		t.Errorf("Unexpected initial observation %v", obs)
+	}
+
+	vec := e.ObservationVector()
+	if len(vec) != 2 {
+		t.Fatalf("Expected 2-element vector, got %d", len(vec))
+	}
+	// Index order is alphabetical: pile, wins.
+	if vec[0] != 3 || vec[1] != 0 {
+		t.Errorf("Unexpected observation vector %v", vec)
+	}
+}
+
+func TestLegalActionsShrink(t *testing.T) {
+	e := New(pileNet())
+	e.Reset()
+
+	legal := e.LegalActions()
+	if len(legal) != 2 {
+		t.Fatalf("Expected 2 legal actions, got %v", legal)
+	}
+
+	// After two singles only one token remains; take2 is disabled.
+	for i := 0; i < 2; i++ {
+		if _, err := e.Step("take1"); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+	}
+	legal = e.LegalActions()
+	if len(legal) != 1 || legal[0] != "take1" {
+		t.Errorf("Expected only take1 legal, got %v", legal)
+	}
+}
+
+func TestStepRewardAndDone(t *testing.T) {
+	e := New(pileNet()).WithRewardPlace("wins", 10)
+	e.Reset()
+
+	res, err := e.Step("take1")
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if res.Reward != 0 || res.Done {
+		t.Errorf("Expected neutral ongoing step, got %+v", res)
+	}
+
+	res, err = e.Step("take2")
+	if err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if res.Reward != 10 {
+		t.Errorf("Expected reward 10 for win, got %f", res.Reward)
+	}
+	if !res.Done {
+		t.Error("Expected episode to end with the pile empty")
+	}
+	if res.Observation["pile"] != 0 || res.Observation["wins"] != 1 {
+		t.Errorf("Unexpected final observation %v", res.Observation)
+	}
+}
+
+func TestStepErrors(t *testing.T) {
+	e := New(pileNet()).WithActions("take2")
+	e.Reset()
+
+	if _, err := e.Step("take1"); err == nil {
+		t.Error("Expected error for action outside the action space")
+	}
+
+	if _, err := e.Step("take2"); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	// One token left: take2 no longer enabled.
+	if _, err := e.Step("take2"); err == nil {
+		t.Error("Expected error for disabled action")
+	}
+}
+
+func TestMaxStepsEndsEpisode(t *testing.T) {
+	net := petri.Build().
+		Place("a", 1).Place("b", 0).
+		Transition("go").Transition("back").
+		Arc("a", "go", 1).Arc("go", "b", 1).
+		Arc("b", "back", 1).Arc("back", "a", 1).
+		Done()
+
+	e := New(net).WithMaxSteps(2)
+	e.Reset()
+	if res, _ := e.Step("go"); res.Done {
+		t.Error("Expected episode to continue after one step")
+	}
+	if res, _ := e.Step("back"); !res.Done {
+		t.Error("Expected episode to end at the step cap")
+	}
+
+	// Reset clears the step counter.
+	e.Reset()
+	if res, _ := e.Step("go"); res.Done {
+		t.Error("Expected fresh episode after Reset")
+	}
+}
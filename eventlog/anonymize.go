@@ -0,0 +1,131 @@
+package eventlog
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// AnonymizeConfig selects which anonymization strategies to apply. The zero
+// value applies none; DefaultAnonymizeConfig enables the common set.
+type AnonymizeConfig struct {
+	// HashCaseIDs replaces case IDs with hex-encoded HMAC-SHA256
+	// pseudonyms. The same input maps to the same pseudonym within one
+	// salt, preserving trace structure while severing the link to the
+	// original identifier.
+	HashCaseIDs bool
+
+	// HashResources pseudonymizes the Resource field the same way.
+	HashResources bool
+
+	// Salt keys the pseudonym HMAC. Logs hashed with different salts
+	// cannot be joined; an empty salt still hashes but is linkable by
+	// anyone who can guess inputs.
+	Salt string
+
+	// TimestampGranularity truncates timestamps, e.g. time.Hour turns
+	// 09:41:23 into 09:00:00. Zero keeps exact times.
+	TimestampGranularity time.Duration
+
+	// SuppressRareActivities replaces activities occurring in fewer than
+	// RareActivityThreshold cases with "other" — rare paths are the
+	// easiest to re-identify.
+	SuppressRareActivities bool
+	RareActivityThreshold  int
+
+	// DropAttributes removes all case and event attributes, which may
+	// carry free-form PII.
+	DropAttributes bool
+}
+
+// DefaultAnonymizeConfig hashes identities, truncates to the hour, and
+// suppresses activities seen in fewer than 5 cases.
+func DefaultAnonymizeConfig(salt string) AnonymizeConfig {
+	return AnonymizeConfig{
+		HashCaseIDs:            true,
+		HashResources:          true,
+		Salt:                   salt,
+		TimestampGranularity:   time.Hour,
+		SuppressRareActivities: true,
+		RareActivityThreshold:  5,
+		DropAttributes:         true,
+	}
+}
+
+// SuppressedActivity replaces rare activity names.
+const SuppressedActivity = "other"
+
+// Anonymize returns a new log with the configured strategies applied; the
+// original log is untouched. Trace ordering and inter-event structure are
+// preserved so the result remains usable for discovery and conformance.
+func Anonymize(log *EventLog, config AnonymizeConfig) *EventLog {
+	rare := make(map[string]bool)
+	if config.SuppressRareActivities {
+		caseCounts := make(map[string]map[string]bool)
+		for _, trace := range log.GetTraces() {
+			for _, event := range trace.Events {
+				if caseCounts[event.Activity] == nil {
+					caseCounts[event.Activity] = make(map[string]bool)
+				}
+				caseCounts[event.Activity][trace.CaseID] = true
+			}
+		}
+		for activity, cases := range caseCounts {
+			if len(cases) < config.RareActivityThreshold {
+				rare[activity] = true
+			}
+		}
+	}
+
+	anonymized := NewEventLog()
+	anonymized.Extensions = log.Extensions
+	anonymized.Classifiers = log.Classifiers
+	if !config.DropAttributes {
+		anonymized.Attributes = log.Attributes
+	}
+
+	for _, trace := range log.GetTraces() {
+		caseID := trace.CaseID
+		if config.HashCaseIDs {
+			caseID = pseudonym(caseID, config.Salt)
+		}
+
+		events := make([]Event, 0, len(trace.Events))
+		for _, event := range trace.Events {
+			e := event
+			e.CaseID = caseID
+			if config.HashResources && e.Resource != "" {
+				e.Resource = pseudonym(e.Resource, config.Salt)
+			}
+			if config.TimestampGranularity > 0 {
+				e.Timestamp = e.Timestamp.Truncate(config.TimestampGranularity)
+			}
+			if rare[e.Activity] {
+				e.Activity = SuppressedActivity
+			}
+			if config.DropAttributes {
+				e.Attributes = nil
+			}
+			events = append(events, e)
+		}
+
+		attrs := trace.Attributes
+		if config.DropAttributes {
+			attrs = nil
+		}
+		anonymized.Cases[caseID] = &Trace{
+			CaseID:     caseID,
+			Events:     events,
+			Attributes: attrs,
+		}
+	}
+	return anonymized
+}
+
+// pseudonym derives a stable, salted pseudonym for an identifier.
+func pseudonym(id, salt string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
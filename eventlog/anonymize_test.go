@@ -0,0 +1,116 @@
+package eventlog
+
+import (
+	"testing"
+	"time"
+)
+
+func createSensitiveLog() *EventLog {
+	log := NewEventLog()
+	baseTime := time.Date(2024, 1, 1, 9, 41, 23, 0, time.UTC)
+
+	for i := 0; i < 6; i++ {
+		caseID := "patient-" + string(rune('0'+i))
+		log.AddEvent(Event{
+			CaseID: caseID, Activity: "admit", Resource: "dr-smith",
+			Timestamp:  baseTime.Add(time.Duration(i) * time.Hour),
+			Attributes: map[string]interface{}{"diagnosis": "sensitive"},
+		})
+		log.AddEvent(Event{
+			CaseID: caseID, Activity: "discharge", Resource: "dr-smith",
+			Timestamp: baseTime.Add(time.Duration(i)*time.Hour + 30*time.Minute),
+		})
+	}
+	// One patient takes a rare path.
+	log.AddEvent(Event{
+		CaseID: "patient-0", Activity: "psych_referral", Resource: "dr-jones",
+		Timestamp: baseTime.Add(15 * time.Minute),
+	})
+	log.SortTraces()
+	return log
+}
+
+func TestAnonymizeHashesIdentities(t *testing.T) {
+	log := createSensitiveLog()
+	anon := Anonymize(log, DefaultAnonymizeConfig("salt-1"))
+
+	if anon.NumCases() != log.NumCases() || anon.NumEvents() != log.NumEvents() {
+		t.Fatalf("Structure changed: %d/%d cases, %d/%d events",
+			anon.NumCases(), log.NumCases(), anon.NumEvents(), log.NumEvents())
+	}
+	if _, ok := anon.Cases["patient-0"]; ok {
+		t.Error("Case IDs not hashed")
+	}
+	for _, trace := range anon.GetTraces() {
+		for _, event := range trace.Events {
+			if event.Resource == "dr-smith" || event.Resource == "dr-jones" {
+				t.Fatal("Resources not hashed")
+			}
+			if event.CaseID != trace.CaseID {
+				t.Error("Event case ID out of sync with trace")
+			}
+			if event.Attributes != nil {
+				t.Error("Attributes not dropped")
+			}
+		}
+	}
+
+	// Pseudonyms are stable within a salt but differ across salts.
+	again := Anonymize(log, DefaultAnonymizeConfig("salt-1"))
+	other := Anonymize(log, DefaultAnonymizeConfig("salt-2"))
+	for caseID := range anon.Cases {
+		if _, ok := again.Cases[caseID]; !ok {
+			t.Error("Pseudonyms not stable for same salt")
+		}
+		if _, ok := other.Cases[caseID]; ok {
+			t.Error("Different salts should not be joinable")
+		}
+	}
+}
+
+func TestAnonymizeGeneralizesTimestamps(t *testing.T) {
+	log := createSensitiveLog()
+	config := AnonymizeConfig{TimestampGranularity: time.Hour}
+	anon := Anonymize(log, config)
+
+	for _, trace := range anon.GetTraces() {
+		for _, event := range trace.Events {
+			if event.Timestamp.Minute() != 0 || event.Timestamp.Second() != 0 {
+				t.Fatalf("Timestamp not truncated: %v", event.Timestamp)
+			}
+		}
+	}
+	// Without hashing enabled, case IDs pass through.
+	if _, ok := anon.Cases["patient-0"]; !ok {
+		t.Error("Case IDs should be untouched without HashCaseIDs")
+	}
+}
+
+func TestAnonymizeSuppressesRareActivities(t *testing.T) {
+	log := createSensitiveLog()
+	config := AnonymizeConfig{
+		SuppressRareActivities: true,
+		RareActivityThreshold:  3,
+	}
+	anon := Anonymize(log, config)
+
+	activities := anon.GetActivities()
+	for _, a := range activities {
+		if a == "psych_referral" {
+			t.Fatal("Rare activity not suppressed")
+		}
+	}
+	found := false
+	for _, a := range activities {
+		if a == SuppressedActivity {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected %q placeholder, got %v", SuppressedActivity, activities)
+	}
+	// Common activities stay.
+	if len(activities) != 3 {
+		t.Errorf("Expected admit/discharge/other, got %v", activities)
+	}
+}
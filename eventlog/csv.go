@@ -12,14 +12,18 @@ import (
 
 // CSVConfig configures CSV parsing behavior.
 type CSVConfig struct {
-	CaseIDColumn     string   // Column name for case ID (required)
-	ActivityColumn   string   // Column name for activity (required)
-	TimestampColumn  string   // Column name for timestamp (required)
-	ResourceColumn   string   // Column name for resource (optional)
-	LifecycleColumn  string   // Column name for lifecycle (optional)
-	TimestampFormats []string // Date/time formats to try (optional)
-	Delimiter        rune     // CSV delimiter (default: comma)
-	SkipRows         int      // Number of header rows to skip
+	CaseIDColumn     string         // Column name for case ID (required)
+	ActivityColumn   string         // Column name for activity (required)
+	TimestampColumn  string         // Column name for timestamp (required)
+	ResourceColumn   string         // Column name for resource (optional)
+	LifecycleColumn  string         // Column name for lifecycle (optional)
+	TimestampFormats []string       // Date/time formats to try (optional)
+	Location         *time.Location // Timezone for timestamps without an offset (default: UTC)
+	Delimiter        rune           // CSV delimiter (default: comma)
+	SkipRows         int            // Number of header rows to skip
+	// Lenient skips rejected rows instead of failing on the first one;
+	// use ParseCSVWithReport to see what was rejected and why.
+	Lenient bool
 }
 
 // DefaultCSVConfig returns a configuration with common defaults.
@@ -45,46 +49,94 @@ func DefaultCSVConfig() CSVConfig {
 	}
 }
 
+// Rejection records one row that could not be parsed.
+type Rejection struct {
+	Line   int    // 1-based line number in the input
+	Reason string // why the row was rejected
+}
+
+// maxRejectionDetails caps the per-row details kept in a report;
+// reason counts are always complete.
+const maxRejectionDetails = 100
+
+// CSVReport summarizes a parse: how many rows were read, accepted, and
+// rejected, with rejection counts by reason and per-row details for the
+// first rejections.
+type CSVReport struct {
+	RowsRead     int
+	RowsAccepted int
+	RowsRejected int
+	Reasons      map[string]int // reason -> count
+	Rejections   []Rejection    // first maxRejectionDetails rejected rows
+}
+
+// reject records one rejected row.
+func (rep *CSVReport) reject(line int, reason string) {
+	rep.RowsRejected++
+	rep.Reasons[reason]++
+	if len(rep.Rejections) < maxRejectionDetails {
+		rep.Rejections = append(rep.Rejections, Rejection{Line: line, Reason: reason})
+	}
+}
+
 // ParseCSV parses an event log from a CSV file.
 func ParseCSV(filename string, config CSVConfig) (*EventLog, error) {
+	log, _, err := ParseCSVWithReport(filename, config)
+	return log, err
+}
+
+// ParseCSVReader parses an event log from a CSV reader.
+func ParseCSVReader(r io.Reader, config CSVConfig) (*EventLog, error) {
+	log, _, err := ParseCSVReaderWithReport(r, config)
+	return log, err
+}
+
+// ParseCSVWithReport parses a CSV file and reports rejected rows. With
+// config.Lenient set, bad rows are counted and skipped instead of
+// aborting the parse.
+func ParseCSVWithReport(filename string, config CSVConfig) (*EventLog, *CSVReport, error) {
 	f, err := os.Open(filename)
 	if err != nil {
-		return nil, fmt.Errorf("opening file: %w", err)
+		return nil, nil, fmt.Errorf("opening file: %w", err)
 	}
 	defer f.Close()
 
-	return ParseCSVReader(f, config)
+	return ParseCSVReaderWithReport(f, config)
 }
 
-// ParseCSVReader parses an event log from a CSV reader.
-func ParseCSVReader(r io.Reader, config CSVConfig) (*EventLog, error) {
+// ParseCSVReaderWithReport parses a CSV reader and reports rejected rows.
+func ParseCSVReaderWithReport(r io.Reader, config CSVConfig) (*EventLog, *CSVReport, error) {
 	// Validate required fields
 	if config.CaseIDColumn == "" {
-		return nil, fmt.Errorf("CaseIDColumn is required")
+		return nil, nil, fmt.Errorf("CaseIDColumn is required")
 	}
 	if config.ActivityColumn == "" {
-		return nil, fmt.Errorf("ActivityColumn is required")
+		return nil, nil, fmt.Errorf("ActivityColumn is required")
 	}
 	if config.TimestampColumn == "" {
-		return nil, fmt.Errorf("TimestampColumn is required")
+		return nil, nil, fmt.Errorf("TimestampColumn is required")
 	}
 
 	reader := csv.NewReader(r)
 	if config.Delimiter != 0 {
 		reader.Comma = config.Delimiter
 	}
+	if config.Lenient {
+		// Tolerate ragged rows; column counts are checked per row below.
+		reader.FieldsPerRecord = -1
+	}
 
 	// Skip initial rows if configured
 	for i := 0; i < config.SkipRows; i++ {
 		if _, err := reader.Read(); err != nil {
-			return nil, fmt.Errorf("skipping row %d: %w", i, err)
+			return nil, nil, fmt.Errorf("skipping row %d: %w", i, err)
 		}
 	}
 
 	// Read header row
 	header, err := reader.Read()
 	if err != nil {
-		return nil, fmt.Errorf("reading header: %w", err)
+		return nil, nil, fmt.Errorf("reading header: %w", err)
 	}
 
 	// Build column index map
@@ -96,17 +148,17 @@ func ParseCSVReader(r io.Reader, config CSVConfig) (*EventLog, error) {
 	// Find required columns
 	caseIdx, ok := colIndex[strings.ToLower(config.CaseIDColumn)]
 	if !ok {
-		return nil, fmt.Errorf("case ID column '%s' not found in header: %v", config.CaseIDColumn, header)
+		return nil, nil, fmt.Errorf("case ID column '%s' not found in header: %v", config.CaseIDColumn, header)
 	}
 
 	activityIdx, ok := colIndex[strings.ToLower(config.ActivityColumn)]
 	if !ok {
-		return nil, fmt.Errorf("activity column '%s' not found in header: %v", config.ActivityColumn, header)
+		return nil, nil, fmt.Errorf("activity column '%s' not found in header: %v", config.ActivityColumn, header)
 	}
 
 	timestampIdx, ok := colIndex[strings.ToLower(config.TimestampColumn)]
 	if !ok {
-		return nil, fmt.Errorf("timestamp column '%s' not found in header: %v", config.TimestampColumn, header)
+		return nil, nil, fmt.Errorf("timestamp column '%s' not found in header: %v", config.TimestampColumn, header)
 	}
 
 	// Find optional columns
@@ -126,6 +178,8 @@ func ParseCSVReader(r io.Reader, config CSVConfig) (*EventLog, error) {
 
 	// Parse events
 	log := NewEventLog()
+	report := &CSVReport{Reasons: make(map[string]int)}
+	ts := newTimestampParser(config.TimestampFormats, config.Location)
 	lineNum := config.SkipRows + 2 // +1 for header, +1 for 1-based line numbers
 
 	for {
@@ -134,94 +188,147 @@ func ParseCSVReader(r io.Reader, config CSVConfig) (*EventLog, error) {
 			break
 		}
 		if err != nil {
-			return nil, fmt.Errorf("reading line %d: %w", lineNum, err)
+			if config.Lenient {
+				report.RowsRead++
+				report.reject(lineNum, "malformed row")
+				lineNum++
+				continue
+			}
+			return nil, report, fmt.Errorf("reading line %d: %w", lineNum, err)
 		}
-
-		// Check minimum number of columns
-		if len(record) <= caseIdx || len(record) <= activityIdx || len(record) <= timestampIdx {
-			return nil, fmt.Errorf("line %d: insufficient columns", lineNum)
+		report.RowsRead++
+
+		event, reason := parseCSVRow(record, header, ts,
+			caseIdx, activityIdx, timestampIdx, resourceIdx, lifecycleIdx)
+		if reason != "" {
+			if config.Lenient {
+				report.reject(lineNum, reason)
+				lineNum++
+				continue
+			}
+			return nil, report, fmt.Errorf("line %d: %s", lineNum, reason)
 		}
 
-		// Parse required fields
-		caseID := strings.TrimSpace(record[caseIdx])
-		activity := strings.TrimSpace(record[activityIdx])
-		timestampStr := strings.TrimSpace(record[timestampIdx])
+		log.AddEvent(event)
+		report.RowsAccepted++
+		lineNum++
+	}
 
-		if caseID == "" {
-			return nil, fmt.Errorf("line %d: empty case ID", lineNum)
-		}
-		if activity == "" {
-			return nil, fmt.Errorf("line %d: empty activity", lineNum)
-		}
+	// Sort all traces by timestamp
+	log.SortTraces()
 
-		// Parse timestamp
-		timestamp, err := parseTimestamp(timestampStr, config.TimestampFormats)
-		if err != nil {
-			return nil, fmt.Errorf("line %d: invalid timestamp '%s': %w", lineNum, timestampStr, err)
-		}
+	return log, report, nil
+}
 
-		// Create event
-		event := Event{
-			CaseID:     caseID,
-			Activity:   activity,
-			Timestamp:  timestamp,
-			Attributes: make(map[string]interface{}),
-		}
+// parseCSVRow converts one record into an event, returning a rejection
+// reason when the row is unusable.
+func parseCSVRow(record, header []string, ts *timestampParser,
+	caseIdx, activityIdx, timestampIdx, resourceIdx, lifecycleIdx int) (Event, string) {
 
-		// Parse optional fields
-		if resourceIdx >= 0 && len(record) > resourceIdx {
-			event.Resource = strings.TrimSpace(record[resourceIdx])
-		}
+	// Check minimum number of columns
+	if len(record) <= caseIdx || len(record) <= activityIdx || len(record) <= timestampIdx {
+		return Event{}, "insufficient columns"
+	}
 
-		if lifecycleIdx >= 0 && len(record) > lifecycleIdx {
-			event.Lifecycle = strings.TrimSpace(record[lifecycleIdx])
-		}
+	// Parse required fields
+	caseID := strings.TrimSpace(record[caseIdx])
+	activity := strings.TrimSpace(record[activityIdx])
+	timestampStr := strings.TrimSpace(record[timestampIdx])
 
-		// Parse additional attributes (all columns not already used)
-		for i, value := range record {
-			// Skip columns we've already processed
-			if i == caseIdx || i == activityIdx || i == timestampIdx ||
-				i == resourceIdx || i == lifecycleIdx {
-				continue
-			}
+	if caseID == "" {
+		return Event{}, "empty case ID"
+	}
+	if activity == "" {
+		return Event{}, "empty activity"
+	}
 
-			colName := header[i]
-			if colName == "" {
-				continue
-			}
+	// Parse timestamp
+	timestamp, err := ts.parse(timestampStr)
+	if err != nil {
+		return Event{}, fmt.Sprintf("invalid timestamp '%s'", timestampStr)
+	}
 
-			// Try to parse as number, otherwise keep as string
-			trimmed := strings.TrimSpace(value)
-			if trimmed == "" {
-				continue
-			}
+	// Create event
+	event := Event{
+		CaseID:     caseID,
+		Activity:   activity,
+		Timestamp:  timestamp,
+		Attributes: make(map[string]interface{}),
+	}
 
-			if num, err := strconv.ParseFloat(trimmed, 64); err == nil {
-				event.Attributes[colName] = num
-			} else {
-				event.Attributes[colName] = trimmed
-			}
+	// Parse optional fields
+	if resourceIdx >= 0 && len(record) > resourceIdx {
+		event.Resource = strings.TrimSpace(record[resourceIdx])
+	}
+
+	if lifecycleIdx >= 0 && len(record) > lifecycleIdx {
+		event.Lifecycle = strings.TrimSpace(record[lifecycleIdx])
+	}
+
+	// Parse additional attributes (all columns not already used)
+	for i, value := range record {
+		// Skip columns we've already processed
+		if i == caseIdx || i == activityIdx || i == timestampIdx ||
+			i == resourceIdx || i == lifecycleIdx {
+			continue
+		}
+		if i >= len(header) {
+			break
 		}
 
-		log.AddEvent(event)
-		lineNum++
+		colName := header[i]
+		if colName == "" {
+			continue
+		}
+
+		// Try to parse as number, otherwise keep as string
+		trimmed := strings.TrimSpace(value)
+		if trimmed == "" {
+			continue
+		}
+
+		if num, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			event.Attributes[colName] = num
+		} else {
+			event.Attributes[colName] = trimmed
+		}
 	}
 
-	// Sort all traces by timestamp
-	log.SortTraces()
+	return event, ""
+}
 
-	return log, nil
+// timestampParser tries the configured layouts and remembers the first
+// one that matches, so homogeneous logs detect their layout once.
+type timestampParser struct {
+	formats  []string
+	location *time.Location
+	detected string
 }
 
-// parseTimestamp tries multiple date formats to parse a timestamp string.
-func parseTimestamp(s string, formats []string) (time.Time, error) {
-	// Try each format
-	for _, format := range formats {
-		if t, err := time.Parse(format, s); err == nil {
+func newTimestampParser(formats []string, location *time.Location) *timestampParser {
+	if location == nil {
+		location = time.UTC
+	}
+	return &timestampParser{formats: formats, location: location}
+}
+
+func (p *timestampParser) parse(s string) (time.Time, error) {
+	if p.detected != "" {
+		if t, err := time.ParseInLocation(p.detected, s, p.location); err == nil {
+			return t, nil
+		}
+	}
+	for _, format := range p.formats {
+		if t, err := time.ParseInLocation(format, s, p.location); err == nil {
+			p.detected = format
 			return t, nil
 		}
 	}
-
-	// If all formats fail, return error with the formats tried
 	return time.Time{}, fmt.Errorf("could not parse timestamp with any of the configured formats")
 }
+
+// parseTimestamp tries multiple date formats to parse a timestamp string.
+// Timestamps without an offset are interpreted as UTC.
+func parseTimestamp(s string, formats []string) (time.Time, error) {
+	return newTimestampParser(formats, nil).parse(s)
+}
@@ -1,6 +1,7 @@
 package eventlog
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -163,3 +164,99 @@ func TestGetActivityVariant(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCSVLenientReport(t *testing.T) {
+	data := `case_id,activity,timestamp
+c1,Start,2024-01-01T10:00:00Z
+,Start,2024-01-01T10:05:00Z
+c2,,2024-01-01T10:10:00Z
+c2,Start,not-a-date
+c2,End,2024-01-01T10:20:00Z
+short,row`
+
+	config := DefaultCSVConfig()
+	config.Lenient = true
+	log, report, err := ParseCSVReaderWithReport(strings.NewReader(data), config)
+	if err != nil {
+		t.Fatalf("ParseCSVReaderWithReport failed: %v", err)
+	}
+
+	if log.NumEvents() != 2 {
+		t.Errorf("Expected 2 accepted events, got %d", log.NumEvents())
+	}
+	if report.RowsRead != 6 || report.RowsAccepted != 2 || report.RowsRejected != 4 {
+		t.Errorf("Unexpected counts: %+v", report)
+	}
+	if report.Reasons["empty case ID"] != 1 ||
+		report.Reasons["empty activity"] != 1 ||
+		report.Reasons["invalid timestamp 'not-a-date'"] != 1 ||
+		report.Reasons["insufficient columns"] != 1 {
+		t.Errorf("Unexpected reasons: %v", report.Reasons)
+	}
+	if len(report.Rejections) != 4 {
+		t.Fatalf("Expected 4 rejection details, got %d", len(report.Rejections))
+	}
+	if report.Rejections[0].Line != 3 {
+		t.Errorf("Expected first rejection on line 3, got %d", report.Rejections[0].Line)
+	}
+
+	// Strict mode still fails fast on the same input.
+	config.Lenient = false
+	if _, err := ParseCSVReader(strings.NewReader(data), config); err == nil {
+		t.Error("Expected strict parse to fail")
+	}
+}
+
+func TestParseCSVSemicolonDelimiter(t *testing.T) {
+	data := "case_id;activity;timestamp\nc1;Start;2024-01-01T10:00:00Z\n"
+	config := DefaultCSVConfig()
+	config.Delimiter = ';'
+	log, err := ParseCSVReader(strings.NewReader(data), config)
+	if err != nil {
+		t.Fatalf("ParseCSVReader failed: %v", err)
+	}
+	if log.NumEvents() != 1 {
+		t.Errorf("Expected 1 event, got %d", log.NumEvents())
+	}
+}
+
+func TestParseCSVTimezone(t *testing.T) {
+	data := "case_id,activity,timestamp\nc1,Start,2024-06-01 12:00:00\n"
+
+	config := DefaultCSVConfig()
+	config.Location = time.FixedZone("CET-summer", 2*3600)
+	log, err := ParseCSVReader(strings.NewReader(data), config)
+	if err != nil {
+		t.Fatalf("ParseCSVReader failed: %v", err)
+	}
+	got := log.Cases["c1"].Events[0].Timestamp
+	want := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	if !got.UTC().Equal(want) {
+		t.Errorf("Expected %v, got %v", want, got.UTC())
+	}
+
+	// Default remains UTC.
+	config.Location = nil
+	log, err = ParseCSVReader(strings.NewReader(data), config)
+	if err != nil {
+		t.Fatalf("ParseCSVReader failed: %v", err)
+	}
+	want = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if !log.Cases["c1"].Events[0].Timestamp.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, log.Cases["c1"].Events[0].Timestamp)
+	}
+}
+
+func TestTimestampFormatDetection(t *testing.T) {
+	p := newTimestampParser(DefaultCSVConfig().TimestampFormats, nil)
+	if _, err := p.parse("01/02/2006 15:04:05"); err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if p.detected != "01/02/2006 15:04:05" {
+		t.Errorf("Expected detected format to stick, got %q", p.detected)
+	}
+	// A row in a different layout still parses.
+	if _, err := p.parse("2024-01-01T10:00:00Z"); err != nil {
+		t.Errorf("parse failed after detection: %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package eventlog
+
+import "time"
+
+// SetCaseAttribute sets a case-level attribute, creating the trace if the
+// case does not exist yet.
+func (log *EventLog) SetCaseAttribute(caseID, key string, value interface{}) {
+	trace, exists := log.Cases[caseID]
+	if !exists {
+		trace = &Trace{
+			CaseID:     caseID,
+			Events:     make([]Event, 0),
+			Attributes: make(map[string]interface{}),
+		}
+		log.Cases[caseID] = trace
+	}
+	if trace.Attributes == nil {
+		trace.Attributes = make(map[string]interface{})
+	}
+	trace.Attributes[key] = value
+}
+
+// Filter starts a filter chain over the log. Conditions accumulate with AND
+// semantics; Apply materializes a new log, leaving the original untouched.
+// Case conditions drop whole cases; event conditions drop individual events
+// (cases left with no events are dropped too).
+//
+//	p0 := log.Filter().ByAttribute("severity", "P0").Apply()
+func (log *EventLog) Filter() *Filter {
+	return &Filter{log: log}
+}
+
+// Filter is a chainable query over an event log.
+type Filter struct {
+	log        *EventLog
+	caseConds  []func(*Trace) bool
+	eventConds []func(Event) bool
+}
+
+// ByActivity keeps events whose activity is one of the given names.
+func (f *Filter) ByActivity(activities ...string) *Filter {
+	set := stringSet(activities)
+	f.eventConds = append(f.eventConds, func(e Event) bool {
+		return set[e.Activity]
+	})
+	return f
+}
+
+// ByResource keeps events performed by one of the given resources.
+func (f *Filter) ByResource(resources ...string) *Filter {
+	set := stringSet(resources)
+	f.eventConds = append(f.eventConds, func(e Event) bool {
+		return set[e.Resource]
+	})
+	return f
+}
+
+// ByTimeRange keeps events with start <= timestamp < end. A zero start or
+// end leaves that side unbounded.
+func (f *Filter) ByTimeRange(start, end time.Time) *Filter {
+	f.eventConds = append(f.eventConds, func(e Event) bool {
+		if !start.IsZero() && e.Timestamp.Before(start) {
+			return false
+		}
+		if !end.IsZero() && !e.Timestamp.Before(end) {
+			return false
+		}
+		return true
+	})
+	return f
+}
+
+// ByAttribute keeps cases whose case-level attribute equals value.
+func (f *Filter) ByAttribute(key string, value interface{}) *Filter {
+	f.caseConds = append(f.caseConds, func(t *Trace) bool {
+		return t.Attributes[key] == value
+	})
+	return f
+}
+
+// ByEventAttribute keeps events whose attribute equals value.
+func (f *Filter) ByEventAttribute(key string, value interface{}) *Filter {
+	f.eventConds = append(f.eventConds, func(e Event) bool {
+		return e.Attributes[key] == value
+	})
+	return f
+}
+
+// ByCaseID keeps the given cases.
+func (f *Filter) ByCaseID(caseIDs ...string) *Filter {
+	set := stringSet(caseIDs)
+	f.caseConds = append(f.caseConds, func(t *Trace) bool {
+		return set[t.CaseID]
+	})
+	return f
+}
+
+// WhereCase keeps cases matching an arbitrary predicate.
+func (f *Filter) WhereCase(pred func(*Trace) bool) *Filter {
+	f.caseConds = append(f.caseConds, pred)
+	return f
+}
+
+// WhereEvent keeps events matching an arbitrary predicate.
+func (f *Filter) WhereEvent(pred func(Event) bool) *Filter {
+	f.eventConds = append(f.eventConds, pred)
+	return f
+}
+
+// Apply materializes the filtered log. Case attributes are carried over;
+// cases with no surviving events are omitted.
+func (f *Filter) Apply() *EventLog {
+	filtered := NewEventLog()
+	filtered.Attributes = f.log.Attributes
+	filtered.Extensions = f.log.Extensions
+	filtered.Classifiers = f.log.Classifiers
+
+	for _, trace := range f.log.GetTraces() {
+		if !f.caseMatches(trace) {
+			continue
+		}
+		var events []Event
+		for _, event := range trace.Events {
+			if f.eventMatches(event) {
+				events = append(events, event)
+			}
+		}
+		if len(events) == 0 {
+			continue
+		}
+		filtered.Cases[trace.CaseID] = &Trace{
+			CaseID:     trace.CaseID,
+			Events:     events,
+			Attributes: trace.Attributes,
+		}
+	}
+	return filtered
+}
+
+func (f *Filter) caseMatches(t *Trace) bool {
+	for _, cond := range f.caseConds {
+		if !cond(t) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Filter) eventMatches(e Event) bool {
+	for _, cond := range f.eventConds {
+		if !cond(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,122 @@
+package eventlog
+
+import (
+	"testing"
+	"time"
+)
+
+func createFilterLog() *EventLog {
+	log := NewEventLog()
+	baseTime := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	addCase := func(caseID, severity, resource string, offset time.Duration) {
+		log.AddEvent(Event{
+			CaseID: caseID, Activity: "open", Resource: resource,
+			Timestamp:  baseTime.Add(offset),
+			Attributes: map[string]interface{}{"channel": "pager"},
+		})
+		log.AddEvent(Event{
+			CaseID: caseID, Activity: "resolve", Resource: resource,
+			Timestamp: baseTime.Add(offset + time.Hour),
+		})
+		log.SetCaseAttribute(caseID, "severity", severity)
+	}
+
+	addCase("inc-1", "P0", "alice", 0)
+	addCase("inc-2", "P1", "bob", 2*time.Hour)
+	addCase("inc-3", "P0", "bob", 48*time.Hour)
+	log.SortTraces()
+	return log
+}
+
+func TestFilterByAttribute(t *testing.T) {
+	log := createFilterLog()
+
+	p0 := log.Filter().ByAttribute("severity", "P0").Apply()
+	if p0.NumCases() != 2 {
+		t.Fatalf("Expected 2 P0 cases, got %d", p0.NumCases())
+	}
+	if _, ok := p0.Cases["inc-2"]; ok {
+		t.Error("P1 case should be filtered out")
+	}
+	// Case attributes survive filtering.
+	if p0.Cases["inc-1"].Attributes["severity"] != "P0" {
+		t.Error("Case attributes lost")
+	}
+	// The original log is untouched.
+	if log.NumCases() != 3 {
+		t.Errorf("Original log modified: %d cases", log.NumCases())
+	}
+}
+
+func TestFilterByActivityAndResource(t *testing.T) {
+	log := createFilterLog()
+
+	opens := log.Filter().ByActivity("open").Apply()
+	if opens.NumEvents() != 3 {
+		t.Errorf("Expected 3 open events, got %d", opens.NumEvents())
+	}
+
+	bob := log.Filter().ByResource("bob").Apply()
+	if bob.NumCases() != 2 || bob.NumEvents() != 4 {
+		t.Errorf("bob slice: %d cases, %d events", bob.NumCases(), bob.NumEvents())
+	}
+}
+
+func TestFilterByTimeRange(t *testing.T) {
+	log := createFilterLog()
+	baseTime := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	day1 := log.Filter().ByTimeRange(baseTime, baseTime.Add(24*time.Hour)).Apply()
+	if day1.NumCases() != 2 {
+		t.Errorf("Expected 2 cases on day one, got %d", day1.NumCases())
+	}
+	if _, ok := day1.Cases["inc-3"]; ok {
+		t.Error("inc-3 happened two days later")
+	}
+
+	// Open-ended range.
+	later := log.Filter().ByTimeRange(baseTime.Add(24*time.Hour), time.Time{}).Apply()
+	if later.NumCases() != 1 {
+		t.Errorf("Expected 1 late case, got %d", later.NumCases())
+	}
+}
+
+func TestFilterChaining(t *testing.T) {
+	log := createFilterLog()
+
+	// Conditions AND together across case and event levels.
+	result := log.Filter().
+		ByAttribute("severity", "P0").
+		ByResource("bob").
+		ByActivity("resolve").
+		Apply()
+	if result.NumCases() != 1 || result.NumEvents() != 1 {
+		t.Fatalf("Expected single event slice, got %d cases / %d events",
+			result.NumCases(), result.NumEvents())
+	}
+	if result.GetTraces()[0].CaseID != "inc-3" {
+		t.Errorf("Wrong case survived: %s", result.GetTraces()[0].CaseID)
+	}
+}
+
+func TestFilterByEventAttributeAndPredicates(t *testing.T) {
+	log := createFilterLog()
+
+	paged := log.Filter().ByEventAttribute("channel", "pager").Apply()
+	if paged.NumEvents() != 3 {
+		t.Errorf("Expected 3 paged events, got %d", paged.NumEvents())
+	}
+
+	long := log.Filter().WhereCase(func(tr *Trace) bool {
+		return tr.Duration() >= time.Hour
+	}).Apply()
+	if long.NumCases() != 3 {
+		t.Errorf("All cases last an hour: got %d", long.NumCases())
+	}
+
+	none := log.Filter().WhereEvent(func(e Event) bool { return false }).Apply()
+	if none.NumCases() != 0 {
+		t.Errorf("Empty cases should be dropped, got %d", none.NumCases())
+	}
+}
@@ -0,0 +1,104 @@
+package eventlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func generatorNet() *petri.PetriNet {
+	return petri.Build().
+		Chain(1, "open", "triage", "working", "resolve", "closed").
+		Done()
+}
+
+func TestGenerateBasicLog(t *testing.T) {
+	net := generatorNet()
+	opts := &GenerateOptions{
+		Seed:      42,
+		Start:     time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		Resources: []string{"alice", "bob"},
+	}
+	log, err := Generate(net, map[string]float64{"triage": 1.0, "resolve": 0.5}, 20, opts)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	if log.NumCases() != 20 {
+		t.Fatalf("Expected 20 cases, got %d", log.NumCases())
+	}
+	// Every case walks the chain: triage then resolve.
+	for _, trace := range log.GetTraces() {
+		if len(trace.Events) != 2 {
+			t.Fatalf("Case %s has %d events", trace.CaseID, len(trace.Events))
+		}
+		if trace.Events[0].Activity != "triage" || trace.Events[1].Activity != "resolve" {
+			t.Errorf("Case %s out of order: %s, %s",
+				trace.CaseID, trace.Events[0].Activity, trace.Events[1].Activity)
+		}
+		if !trace.Events[1].Timestamp.After(trace.Events[0].Timestamp) {
+			t.Errorf("Timestamps not increasing in %s", trace.CaseID)
+		}
+		for _, event := range trace.Events {
+			if event.Resource != "alice" && event.Resource != "bob" {
+				t.Errorf("Unassigned resource: %q", event.Resource)
+			}
+		}
+	}
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	net := generatorNet()
+	opts := &GenerateOptions{Seed: 7, Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	a, err := Generate(net, nil, 5, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Generate(net, nil, 5, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for caseID, traceA := range a.Cases {
+		traceB, ok := b.Cases[caseID]
+		if !ok || len(traceA.Events) != len(traceB.Events) {
+			t.Fatalf("Case %s differs between runs", caseID)
+		}
+		for i := range traceA.Events {
+			if !traceA.Events[i].Timestamp.Equal(traceB.Events[i].Timestamp) {
+				t.Fatalf("Timestamps differ in case %s", caseID)
+			}
+		}
+	}
+}
+
+func TestGenerateNoise(t *testing.T) {
+	net := generatorNet()
+	clean, err := Generate(net, nil, 50, &GenerateOptions{Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	noisy, err := Generate(net, nil, 50, &GenerateOptions{
+		Seed:               3,
+		MissingEventProb:   0.3,
+		DuplicateEventProb: 0.2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if noisy.NumEvents() == clean.NumEvents() {
+		t.Error("Noise options had no effect")
+	}
+
+	// Heavy missing-event noise loses events overall.
+	sparse, err := Generate(net, nil, 50, &GenerateOptions{Seed: 3, MissingEventProb: 0.9})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sparse.NumEvents() >= clean.NumEvents() {
+		t.Errorf("Expected fewer events with 90%% drops: %d vs %d",
+			sparse.NumEvents(), clean.NumEvents())
+	}
+}
@@ -0,0 +1,184 @@
+package eventlog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// parquetChunkSize is the number of events per Parquet record batch.
+const parquetChunkSize = 4096
+
+// parquetSchema is the fixed column layout: one row per event, with the
+// standard event fields. Pandas and Polars read it directly without the
+// column-mapping configs CSV ingestion needs.
+func parquetSchema() *arrow.Schema {
+	return arrow.NewSchema([]arrow.Field{
+		{Name: "case_id", Type: arrow.BinaryTypes.String},
+		{Name: "activity", Type: arrow.BinaryTypes.String},
+		{Name: "timestamp", Type: arrow.FixedWidthTypes.Timestamp_us},
+		{Name: "resource", Type: arrow.BinaryTypes.String},
+		{Name: "lifecycle", Type: arrow.BinaryTypes.String},
+	}, nil)
+}
+
+// WriteParquet writes the event log to a Parquet file, one row per
+// event, ordered by case ID and event order within each trace.
+func WriteParquet(log *EventLog, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	return WriteParquetWriter(log, f)
+}
+
+// WriteParquetWriter writes the event log as Parquet to a writer.
+func WriteParquetWriter(log *EventLog, w io.Writer) error {
+	schema := parquetSchema()
+	writer, err := pqarrow.NewFileWriter(schema, w,
+		parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+	if err != nil {
+		return fmt.Errorf("creating parquet writer: %w", err)
+	}
+
+	// Flatten traces in deterministic case order.
+	caseIDs := make([]string, 0, len(log.Cases))
+	for caseID := range log.Cases {
+		caseIDs = append(caseIDs, caseID)
+	}
+	sort.Strings(caseIDs)
+
+	events := make([]Event, 0)
+	for _, caseID := range caseIDs {
+		events = append(events, log.Cases[caseID].Events...)
+	}
+
+	alloc := memory.NewGoAllocator()
+	for start := 0; start < len(events); start += parquetChunkSize {
+		end := start + parquetChunkSize
+		if end > len(events) {
+			end = len(events)
+		}
+		record := buildEventRecord(schema, alloc, events[start:end])
+		err := writer.Write(record)
+		record.Release()
+		if err != nil {
+			writer.Close()
+			return fmt.Errorf("writing record batch: %w", err)
+		}
+	}
+	return writer.Close()
+}
+
+// buildEventRecord assembles one record batch from a slice of events.
+func buildEventRecord(schema *arrow.Schema, alloc memory.Allocator, events []Event) arrow.Record {
+	builder := array.NewRecordBuilder(alloc, schema)
+	defer builder.Release()
+
+	caseID := builder.Field(0).(*array.StringBuilder)
+	activity := builder.Field(1).(*array.StringBuilder)
+	timestamp := builder.Field(2).(*array.TimestampBuilder)
+	resource := builder.Field(3).(*array.StringBuilder)
+	lifecycle := builder.Field(4).(*array.StringBuilder)
+
+	for _, ev := range events {
+		caseID.Append(ev.CaseID)
+		activity.Append(ev.Activity)
+		timestamp.Append(arrow.Timestamp(ev.Timestamp.UnixMicro()))
+		resource.Append(ev.Resource)
+		lifecycle.Append(ev.Lifecycle)
+	}
+	return builder.NewRecord()
+}
+
+// ParseParquet parses an event log from a Parquet file written by
+// WriteParquet (or any file with the same column names).
+func ParseParquet(filename string) (*EventLog, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	return parseParquetReader(f)
+}
+
+// ParseParquetBytes parses an event log from Parquet data in memory.
+func ParseParquetBytes(data []byte) (*EventLog, error) {
+	return parseParquetReader(bytes.NewReader(data))
+}
+
+// parseParquetReader reads all row groups into an event log.
+func parseParquetReader(r parquet.ReaderAtSeeker) (*EventLog, error) {
+	pr, err := file.NewParquetReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading parquet: %w", err)
+	}
+	defer pr.Close()
+
+	reader, err := pqarrow.NewFileReader(pr, pqarrow.ArrowReadProperties{}, memory.NewGoAllocator())
+	if err != nil {
+		return nil, fmt.Errorf("creating arrow reader: %w", err)
+	}
+	table, err := reader.ReadTable(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("reading table: %w", err)
+	}
+	defer table.Release()
+
+	columns := make(map[string]int, int(table.NumCols()))
+	for i := 0; i < int(table.NumCols()); i++ {
+		columns[table.Schema().Field(i).Name] = i
+	}
+	for _, required := range []string{"case_id", "activity", "timestamp"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required column: %s", required)
+		}
+	}
+
+	stringAt := func(name string, chunk, row int) string {
+		idx, ok := columns[name]
+		if !ok {
+			return ""
+		}
+		col, ok := table.Column(idx).Data().Chunk(chunk).(*array.String)
+		if !ok {
+			return ""
+		}
+		return col.Value(row)
+	}
+
+	log := NewEventLog()
+	tsIdx := columns["timestamp"]
+	tsType, ok := table.Schema().Field(tsIdx).Type.(*arrow.TimestampType)
+	if !ok {
+		return nil, fmt.Errorf("timestamp column is not a timestamp type")
+	}
+
+	for chunk := 0; chunk < len(table.Column(tsIdx).Data().Chunks()); chunk++ {
+		ts := table.Column(tsIdx).Data().Chunk(chunk).(*array.Timestamp)
+		for row := 0; row < ts.Len(); row++ {
+			log.AddEvent(Event{
+				CaseID:     stringAt("case_id", chunk, row),
+				Activity:   stringAt("activity", chunk, row),
+				Timestamp:  ts.Value(row).ToTime(tsType.Unit).UTC(),
+				Resource:   stringAt("resource", chunk, row),
+				Lifecycle:  stringAt("lifecycle", chunk, row),
+				Attributes: make(map[string]interface{}),
+			})
+		}
+	}
+	return log, nil
+}
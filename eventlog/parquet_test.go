@@ -0,0 +1,86 @@
+package eventlog
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func sampleLog() *EventLog {
+	log := NewEventLog()
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	log.AddEvent(Event{CaseID: "case1", Activity: "Start", Timestamp: base, Resource: "John"})
+	log.AddEvent(Event{CaseID: "case1", Activity: "Process", Timestamp: base.Add(30 * time.Minute), Resource: "Jane", Lifecycle: "complete"})
+	log.AddEvent(Event{CaseID: "case1", Activity: "End", Timestamp: base.Add(time.Hour)})
+	log.AddEvent(Event{CaseID: "case2", Activity: "Start", Timestamp: base.Add(15 * time.Minute)})
+	log.AddEvent(Event{CaseID: "case2", Activity: "End", Timestamp: base.Add(45 * time.Minute)})
+	return log
+}
+
+func TestParquetRoundTrip(t *testing.T) {
+	log := sampleLog()
+	path := filepath.Join(t.TempDir(), "events.parquet")
+
+	if err := WriteParquet(log, path); err != nil {
+		t.Fatalf("WriteParquet failed: %v", err)
+	}
+	got, err := ParseParquet(path)
+	if err != nil {
+		t.Fatalf("ParseParquet failed: %v", err)
+	}
+
+	if got.NumCases() != 2 {
+		t.Errorf("Expected 2 cases, got %d", got.NumCases())
+	}
+	if got.NumEvents() != 5 {
+		t.Errorf("Expected 5 events, got %d", got.NumEvents())
+	}
+
+	trace := got.Cases["case1"]
+	if len(trace.Events) != 3 {
+		t.Fatalf("Expected 3 events in case1, got %d", len(trace.Events))
+	}
+	ev := trace.Events[1]
+	if ev.Activity != "Process" || ev.Resource != "Jane" || ev.Lifecycle != "complete" {
+		t.Errorf("Event fields not preserved: %+v", ev)
+	}
+	want := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	if !ev.Timestamp.Equal(want) {
+		t.Errorf("Expected timestamp %v, got %v", want, ev.Timestamp)
+	}
+}
+
+func TestParquetBytesRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteParquetWriter(sampleLog(), &buf); err != nil {
+		t.Fatalf("WriteParquetWriter failed: %v", err)
+	}
+	got, err := ParseParquetBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ParseParquetBytes failed: %v", err)
+	}
+	if got.NumEvents() != 5 {
+		t.Errorf("Expected 5 events, got %d", got.NumEvents())
+	}
+}
+
+func TestParquetEmptyLog(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteParquetWriter(NewEventLog(), &buf); err != nil {
+		t.Fatalf("WriteParquetWriter failed on empty log: %v", err)
+	}
+	got, err := ParseParquetBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ParseParquetBytes failed on empty log: %v", err)
+	}
+	if got.NumEvents() != 0 {
+		t.Errorf("Expected no events, got %d", got.NumEvents())
+	}
+}
+
+func TestParseParquetMissingColumns(t *testing.T) {
+	if _, err := ParseParquetBytes([]byte("not parquet")); err == nil {
+		t.Error("Expected error for invalid data")
+	}
+}
@@ -216,10 +216,36 @@ type Transition struct {
 	// Guard is an optional condition that must be true to fire.
 	Guard func(state any) bool
 
+	// MarkingGuard is an optional condition over the current marking; it
+	// complements Guard, which sees only the typed state.
+	MarkingGuard func(places map[string]int) bool
+
+	// InputsFor and OutputsFor optionally compute marking-dependent arc
+	// weights from the pre-firing marking. When nil, the static Inputs and
+	// Outputs maps are used.
+	InputsFor  func(places map[string]int) map[string]int
+	OutputsFor func(places map[string]int) map[string]int
+
 	// EventType is the event type to emit when fired.
 	EventType string
 }
 
+// effectiveInputs resolves the input weights for the given marking.
+func (t *Transition) effectiveInputs(places map[string]int) map[string]int {
+	if t.InputsFor != nil {
+		return t.InputsFor(places)
+	}
+	return t.Inputs
+}
+
+// effectiveOutputs resolves the output weights for the given marking.
+func (t *Transition) effectiveOutputs(places map[string]int) map[string]int {
+	if t.OutputsFor != nil {
+		return t.OutputsFor(places)
+	}
+	return t.Outputs
+}
+
 // NewStateMachine creates a new state machine aggregate.
 func NewStateMachine[S any](id string, initialState S, initialPlaces map[string]int) *StateMachine[S] {
 	places := make(map[string]int)
@@ -254,14 +280,17 @@ func (sm *StateMachine[S]) Apply(event *Event) error {
 		}
 	}
 
-	// Update places if we found a matching transition
+	// Update places if we found a matching transition. Marking-dependent
+	// weights are resolved against the pre-firing marking.
 	if transition != nil {
+		inputs := transition.effectiveInputs(sm.places)
+		outputs := transition.effectiveOutputs(sm.places)
 		// Remove input tokens
-		for place, count := range transition.Inputs {
+		for place, count := range inputs {
 			sm.places[place] -= count
 		}
 		// Add output tokens
-		for place, count := range transition.Outputs {
+		for place, count := range outputs {
 			sm.places[place] += count
 		}
 	}
@@ -277,31 +306,7 @@ func (sm *StateMachine[S]) CanFire(transitionID string) bool {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
-	t, ok := sm.transitions[transitionID]
-	if !ok {
-		return false
-	}
-
-	// Check input places have enough tokens
-	for place, required := range t.Inputs {
-		if sm.places[place] < required {
-			return false
-		}
-	}
-
-	// Check inhibitor arcs - blocked if any inhibitor place has tokens
-	for place := range t.Inhibitors {
-		if sm.places[place] > 0 {
-			return false
-		}
-	}
-
-	// Check guard
-	if t.Guard != nil && !t.Guard(sm.state) {
-		return false
-	}
-
-	return true
+	return sm.canFireLocked(transitionID)
 }
 
 // EnabledTransitions returns all transitions that can currently fire.
@@ -324,7 +329,7 @@ func (sm *StateMachine[S]) canFireLocked(transitionID string) bool {
 		return false
 	}
 
-	for place, required := range t.Inputs {
+	for place, required := range t.effectiveInputs(sm.places) {
 		if sm.places[place] < required {
 			return false
 		}
@@ -340,6 +345,9 @@ func (sm *StateMachine[S]) canFireLocked(transitionID string) bool {
 	if t.Guard != nil && !t.Guard(sm.state) {
 		return false
 	}
+	if t.MarkingGuard != nil && !t.MarkingGuard(sm.places) {
+		return false
+	}
 
 	return true
 }
@@ -357,7 +365,7 @@ func (sm *StateMachine[S]) Fire(transitionID string, data any) (*Event, error) {
 	}
 
 	// Check inputs
-	for place, required := range t.Inputs {
+	for place, required := range t.effectiveInputs(sm.places) {
 		if sm.places[place] < required {
 			return nil, fmt.Errorf("%w: insufficient tokens in %s", ErrInvalidTransition, place)
 		}
@@ -370,10 +378,13 @@ func (sm *StateMachine[S]) Fire(transitionID string, data any) (*Event, error) {
 		}
 	}
 
-	// Check guard
+	// Check guards
 	if t.Guard != nil && !t.Guard(sm.state) {
 		return nil, ErrCommandRejected
 	}
+	if t.MarkingGuard != nil && !t.MarkingGuard(sm.places) {
+		return nil, ErrCommandRejected
+	}
 
 	// Create event (places are updated when Apply is called)
 	eventType := t.EventType
@@ -18,8 +18,6 @@ type Position struct {
 }
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
-
 	// Command-line flags
 	problem := flag.String("problem", "queens", "Problem to solve: 'queens', 'knights', 'rooks'")
 	size := flag.Int("size", 8, "Board size (default: 8)")
@@ -28,8 +26,14 @@ func main() {
 	analyze := flag.Bool("analyze", false, "Analyze the problem model")
 	benchmark := flag.Bool("benchmark", false, "Run benchmark mode")
 	trials := flag.Int("trials", 10, "Number of trials for benchmark")
+	seed := flag.Int64("seed", 0, "Random seed for reproducible runs (0 = time-based)")
 	flag.Parse()
 
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rand.Seed(*seed)
+
 	switch *problem {
 	case "queens":
 		if *analyze {
@@ -946,3 +946,13 @@ func TestSimulatorStop(t *testing.T) {
 		t.Error("Simulator should stop within 2 seconds after Stop() called")
 	}
 }
+
+func TestInventoryUnitsConsistent(t *testing.T) {
+	net := NewInventoryNet()
+	if issues := net.ValidateUnits(); len(issues) != 0 {
+		t.Errorf("Inventory net should be dimensionally consistent, got %v", issues)
+	}
+	if unit := net.RateUnit(); unit != "per-minute" {
+		t.Errorf("Expected per-minute rates throughout, got %q", unit)
+	}
+}
@@ -174,6 +174,23 @@ func NewInventoryNet() *petri.PetriNet {
 	net.AddArc("syrup_supply", "refill_syrup", 100, false)
 	net.AddArc("refill_syrup", "syrup", 100, false)
 
+	// Unit annotations: the net mixes grams, milliliters, and counts, so
+	// declare each measure and let ValidateUnits catch mismatched arcs.
+	// Alert places stay unannotated — alerts are created, not conserved.
+	for place, unit := range map[string]string{
+		"coffee_beans": "g", "beans_used": "g", "beans_supply": "g",
+		"milk": "ml", "milk_used": "ml", "milk_supply": "ml",
+		"water": "ml", "water_used": "ml", "water_supply": "ml",
+		"cups": "cups", "cups_used": "cups", "cups_supply": "cups",
+		"sugar_packets": "packets", "sugar_used": "packets", "sugar_supply": "packets",
+		"syrup": "pumps", "syrup_used": "pumps", "syrup_supply": "pumps",
+	} {
+		net.SetPlaceUnit(place, unit)
+	}
+	for trans := range net.Transitions {
+		net.SetRateUnit(trans, "per-minute")
+	}
+
 	return net
 }
 
@@ -22,16 +22,20 @@ const (
 )
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
-
 	playerX := flag.String("player-x", "human", "Player X strategy: 'human', 'random', 'pattern', 'ode'")
 	playerO := flag.String("player-o", "ode", "Player O strategy: 'human', 'random', 'pattern', 'ode'")
 	games := flag.Int("games", 1, "Number of games to play")
 	benchmark := flag.Bool("benchmark", false, "Run benchmark mode")
 	analyze := flag.Bool("analyze", false, "Analyze game model with reachability")
 	verbose := flag.Bool("v", false, "Verbose output")
+	seed := flag.Int64("seed", 0, "Random seed for reproducible runs (0 = time-based)")
 	flag.Parse()
 
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rand.Seed(*seed)
+
 	if *analyze {
 		analyzeConnect4Model()
 		return
@@ -14,8 +14,6 @@ import (
 )
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
-
 	initialStones := flag.Int("stones", 15, "Initial number of stones")
 	playerX := flag.String("player-x", "human", "Player X strategy: 'human', 'random', 'ode', 'optimal'")
 	playerO := flag.String("player-o", "ode", "Player O strategy: 'human', 'random', 'ode', 'optimal'")
@@ -23,8 +21,14 @@ func main() {
 	benchmark := flag.Bool("benchmark", false, "Run benchmark mode")
 	analyze := flag.Bool("analyze", false, "Analyze game model with reachability")
 	verbose := flag.Bool("v", false, "Verbose output")
+	seed := flag.Int64("seed", 0, "Random seed for reproducible runs (0 = time-based)")
 	flag.Parse()
 
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rand.Seed(*seed)
+
 	if *analyze {
 		analyzeNimModel(*initialStones)
 		return
@@ -23,10 +23,14 @@ func main() {
 	initialChips := flag.Float64("chips", 1000, "Initial chip count")
 	smallBlind := flag.Float64("sb", 1, "Small blind")
 	bigBlind := flag.Float64("bb", 2, "Big blind")
+	seed := flag.Int64("seed", 0, "Random seed for reproducible runs (0 = time-based)")
 
 	flag.Parse()
 
-	rand.Seed(time.Now().UnixNano())
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rand.Seed(*seed)
 
 	if *analyze {
 		runAnalysis()
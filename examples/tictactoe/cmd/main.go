@@ -12,8 +12,6 @@ import (
 )
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
-
 	// Command-line flags
 	benchmark := flag.Bool("benchmark", false, "Run benchmark mode")
 	test := flag.Bool("test", false, "Run AI logic tests")
@@ -24,8 +22,14 @@ func main() {
 	delay := flag.Int("delay", 2, "Delay between moves in seconds")
 	modelPath := flag.String("model", "../../z2xFpT8B936shqtNayWbC8hwxCe4bRxdKrY13QaHa5h2jaFg2wh.jsonld", "Path to Petri net model")
 	verbose := flag.Bool("v", false, "Verbose output (show ODE evaluation details)")
+	seed := flag.Int64("seed", 0, "Random seed for reproducible runs (0 = time-based)")
 	flag.Parse()
 
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rand.Seed(*seed)
+
 	if *test {
 		TestAI()
 		return
@@ -0,0 +1,305 @@
+// Package factory generates valid random instances and firing traces from a
+// Petri net model. Application tests use it to produce realistic fixtures —
+// reachable markings and plausible event sequences — instead of hand-crafting
+// state maps that drift out of sync with the model.
+package factory
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/reachability"
+)
+
+// Firing records one step of a generated trace: the transition that fired
+// and the marking immediately after it.
+type Firing struct {
+	Transition string
+	Marking    reachability.Marking
+}
+
+// Trace is a generated firing sequence starting from the net's initial
+// marking. Final is the marking after the last firing (the initial marking
+// for an empty trace).
+type Trace struct {
+	Firings []Firing
+	Final   reachability.Marking
+}
+
+// Transitions returns just the transition labels of the trace, in order.
+func (t *Trace) Transitions() []string {
+	labels := make([]string, len(t.Firings))
+	for i, f := range t.Firings {
+		labels[i] = f.Transition
+	}
+	return labels
+}
+
+// Contains reports whether the trace fired the given transition at least once.
+func (t *Trace) Contains(transition string) bool {
+	for _, f := range t.Firings {
+		if f.Transition == transition {
+			return true
+		}
+	}
+	return false
+}
+
+// Factory generates random valid traces and markings from a net. Configure
+// it with the fluent With*/Must* methods, then call Trace, Traces, or
+// Marking. A Factory is not safe for concurrent use.
+type Factory struct {
+	net   *petri.PetriNet
+	rng   *rand.Rand
+	rates map[string]float64
+
+	maxSteps    int
+	maxAttempts int
+	mustFire    []string
+	avoid       map[string]bool
+}
+
+// New creates a factory for the given net. Without an explicit Seed each
+// factory draws from an unpredictable time-based seed.
+func New(net *petri.PetriNet) *Factory {
+	return &Factory{
+		net:         net,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		maxSteps:    100,
+		maxAttempts: 100,
+		avoid:       make(map[string]bool),
+	}
+}
+
+// Seed makes generation deterministic: the same seed and configuration
+// always produce the same traces.
+func (f *Factory) Seed(seed int64) *Factory {
+	f.rng = rand.New(rand.NewSource(seed))
+	return f
+}
+
+// Rand injects an explicit random stream, taking precedence over Seed.
+// Driving several generators from one seeded *rand.Rand keeps a whole
+// batch experiment replayable from that single seed.
+func (f *Factory) Rand(rng *rand.Rand) *Factory {
+	f.rng = rng
+	return f
+}
+
+// WithRates biases transition choice: among enabled transitions, each is
+// picked with probability proportional to its rate (unlisted transitions
+// default to 1.0). Use the rates learned from a real log to get traces with
+// realistic branching frequencies.
+func (f *Factory) WithRates(rates map[string]float64) *Factory {
+	f.rates = rates
+	return f
+}
+
+// MaxSteps caps trace length; generation also stops earlier when no
+// transition is enabled. Default 100.
+func (f *Factory) MaxSteps(n int) *Factory {
+	f.maxSteps = n
+	return f
+}
+
+// MustFire constrains generated traces to fire each listed transition at
+// least once ("must pass through approve"). Traces that miss a required
+// transition are discarded and regenerated.
+func (f *Factory) MustFire(transitions ...string) *Factory {
+	f.mustFire = append(f.mustFire, transitions...)
+	return f
+}
+
+// Avoid excludes transitions from generated traces entirely.
+func (f *Factory) Avoid(transitions ...string) *Factory {
+	for _, t := range transitions {
+		f.avoid[t] = true
+	}
+	return f
+}
+
+// Trace generates one random valid firing trace satisfying the configured
+// constraints. It retries internally when a constraint is missed and returns
+// an error if no satisfying trace is found after many attempts.
+func (f *Factory) Trace() (*Trace, error) {
+	if err := f.checkConfig(); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < f.maxAttempts; attempt++ {
+		trace := f.generate()
+		if f.satisfies(trace) {
+			return trace, nil
+		}
+	}
+	return nil, fmt.Errorf("no trace satisfying constraints %v after %d attempts (unreachable or too rare; raise MaxSteps or relax constraints)",
+		f.mustFire, f.maxAttempts)
+}
+
+// Traces generates n independent traces.
+func (f *Factory) Traces(n int) ([]*Trace, error) {
+	traces := make([]*Trace, 0, n)
+	for i := 0; i < n; i++ {
+		trace, err := f.Trace()
+		if err != nil {
+			return traces, err
+		}
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
+
+// Marking generates a random reachable marking by stopping a random trace
+// at a random point. Every returned marking is guaranteed reachable from
+// the initial marking under the configured constraints' Avoid set.
+func (f *Factory) Marking() (reachability.Marking, error) {
+	if err := f.checkConfig(); err != nil {
+		return nil, err
+	}
+
+	trace := f.generate()
+	if len(trace.Firings) == 0 {
+		return trace.Final, nil
+	}
+	cut := f.rng.Intn(len(trace.Firings) + 1)
+	if cut == 0 {
+		return reachability.NewMarking(f.net.SetState(nil)), nil
+	}
+	return trace.Firings[cut-1].Marking.Copy(), nil
+}
+
+// generate produces one trace without checking MustFire constraints.
+func (f *Factory) generate() *Trace {
+	marking := reachability.NewMarking(f.net.SetState(nil))
+	trace := &Trace{Final: marking}
+
+	for step := 0; step < f.maxSteps; step++ {
+		enabled := f.enabled(marking)
+		if len(enabled) == 0 {
+			break
+		}
+		choice := f.pick(enabled)
+		marking = f.fire(marking, choice)
+		trace.Firings = append(trace.Firings, Firing{Transition: choice, Marking: marking.Copy()})
+		trace.Final = marking
+	}
+	return trace
+}
+
+// enabled returns the transitions that can fire in the marking, excluding
+// avoided ones. Labels are sorted so seeded generation is deterministic
+// regardless of map iteration order. Inhibitor arcs block when the source
+// place holds at least the arc weight.
+func (f *Factory) enabled(marking reachability.Marking) []string {
+	labels := make([]string, 0, len(f.net.Transitions))
+	for label := range f.net.Transitions {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var result []string
+	for _, label := range labels {
+		if f.avoid[label] {
+			continue
+		}
+		if f.isEnabled(marking, label) {
+			result = append(result, label)
+		}
+	}
+	return result
+}
+
+func (f *Factory) isEnabled(marking reachability.Marking, transition string) bool {
+	for _, arc := range f.net.GetInputArcs(transition) {
+		weight := int(arc.GetWeightSum())
+		if weight < 1 {
+			weight = 1
+		}
+		if arc.InhibitTransition {
+			if marking.Get(arc.Source) >= weight {
+				return false
+			}
+		} else if marking.Get(arc.Source) < weight {
+			return false
+		}
+	}
+	return true
+}
+
+// fire applies a transition's arc weights to produce the successor marking.
+func (f *Factory) fire(marking reachability.Marking, transition string) reachability.Marking {
+	next := marking.Copy()
+	for _, arc := range f.net.GetInputArcs(transition) {
+		if arc.InhibitTransition {
+			continue
+		}
+		weight := int(arc.GetWeightSum())
+		if weight < 1 {
+			weight = 1
+		}
+		next.Sub(arc.Source, weight)
+	}
+	for _, arc := range f.net.GetOutputArcs(transition) {
+		weight := int(arc.GetWeightSum())
+		if weight < 1 {
+			weight = 1
+		}
+		next.Add(arc.Target, weight)
+	}
+	return next
+}
+
+// pick chooses among enabled transitions, weighted by rates when set.
+func (f *Factory) pick(enabled []string) string {
+	if f.rates == nil {
+		return enabled[f.rng.Intn(len(enabled))]
+	}
+
+	total := 0.0
+	weights := make([]float64, len(enabled))
+	for i, label := range enabled {
+		w, ok := f.rates[label]
+		if !ok || w <= 0 {
+			w = 1.0
+		}
+		weights[i] = w
+		total += w
+	}
+	r := f.rng.Float64() * total
+	for i, w := range weights {
+		r -= w
+		if r <= 0 {
+			return enabled[i]
+		}
+	}
+	return enabled[len(enabled)-1]
+}
+
+// satisfies checks the MustFire constraints against a trace.
+func (f *Factory) satisfies(trace *Trace) bool {
+	for _, required := range f.mustFire {
+		if !trace.Contains(required) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkConfig validates constraint names against the model so typos fail
+// loudly instead of silently never matching.
+func (f *Factory) checkConfig() error {
+	for _, label := range f.mustFire {
+		if _, ok := f.net.Transitions[label]; !ok {
+			return fmt.Errorf("MustFire: unknown transition %q", label)
+		}
+	}
+	for label := range f.avoid {
+		if _, ok := f.net.Transitions[label]; !ok {
+			return fmt.Errorf("Avoid: unknown transition %q", label)
+		}
+	}
+	return nil
+}
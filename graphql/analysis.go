@@ -0,0 +1,108 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/reachability"
+)
+
+// defaultAnalysisMaxStates bounds state space exploration for the
+// modelAnalysis query so a pathological model cannot pin the server.
+const defaultAnalysisMaxStates = 10000
+
+// ModelAnalysis is the machine-readable verification report returned by the
+// modelAnalysis query. It summarizes the reachability.Result for model
+// editors and CI checks that need structured feedback rather than CLI output.
+type ModelAnalysis struct {
+	Model           string            `json:"model"`
+	Bounded         bool              `json:"bounded"`
+	StateCount      int               `json:"stateCount"`
+	EdgeCount       int               `json:"edgeCount"`
+	HasDeadlock     bool              `json:"hasDeadlock"`
+	Deadlocks       []map[string]int  `json:"deadlocks"`
+	HasCycle        bool              `json:"hasCycle"`
+	Live            bool              `json:"live"`
+	DeadTransitions []string          `json:"deadTransitions"`
+	Invariants      []InvariantReport `json:"invariants"`
+	Truncated       bool              `json:"truncated"`
+}
+
+// InvariantReport is one P-invariant: a weighted sum of places that stays
+// constant across all reachable markings.
+type InvariantReport struct {
+	Places       []string       `json:"places"`
+	Coefficients map[string]int `json:"coefficients"`
+	Value        int            `json:"value"`
+}
+
+// AnalyzeModel runs reachability and invariant analysis on a model and
+// packages the results for API consumption.
+func AnalyzeModel(name string, model *petri.PetriNet) *ModelAnalysis {
+	result := reachability.NewAnalyzer(model).
+		WithMaxStates(defaultAnalysisMaxStates).
+		Analyze()
+
+	analysis := &ModelAnalysis{
+		Model:           name,
+		Bounded:         result.Bounded,
+		StateCount:      result.StateCount,
+		EdgeCount:       result.EdgeCount,
+		HasDeadlock:     result.HasDeadlock,
+		Deadlocks:       make([]map[string]int, 0, len(result.Deadlocks)),
+		HasCycle:        result.HasCycle,
+		Live:            result.Live,
+		DeadTransitions: result.DeadTrans,
+		Truncated:       result.Truncated,
+	}
+	if analysis.DeadTransitions == nil {
+		analysis.DeadTransitions = []string{}
+	}
+	for _, state := range result.Deadlocks {
+		analysis.Deadlocks = append(analysis.Deadlocks, state.Marking.Copy())
+	}
+
+	initial := reachability.NewMarking(model.SetState(nil))
+	invariants := reachability.NewInvariantAnalyzer(model).FindPInvariants(initial)
+	analysis.Invariants = make([]InvariantReport, 0, len(invariants))
+	for _, inv := range invariants {
+		analysis.Invariants = append(analysis.Invariants, InvariantReport{
+			Places:       inv.Places,
+			Coefficients: inv.Coefficients,
+			Value:        inv.Value,
+		})
+	}
+
+	return analysis
+}
+
+// modelAnalysis resolves the modelAnalysis query. Analysis is computed on
+// first request and cached until the model set changes.
+func (s *Server) modelAnalysis(ctx context.Context, args map[string]any) (any, error) {
+	name, _ := args["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("modelAnalysis requires a model name")
+	}
+
+	s.mu.RLock()
+	model, ok := s.models[name]
+	cached := s.analyses[name]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown model %q", name)
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	// Compute outside the lock; a concurrent duplicate just overwrites the
+	// cache with an identical report.
+	analysis := AnalyzeModel(name, model)
+
+	s.mu.Lock()
+	s.analyses[name] = analysis
+	s.mu.Unlock()
+
+	return analysis, nil
+}
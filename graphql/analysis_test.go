@@ -0,0 +1,125 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestAnalyzeModel(t *testing.T) {
+	analysis := AnalyzeModel("backlog", newBacklogModel())
+
+	if analysis.Model != "backlog" {
+		t.Errorf("Model name: %q", analysis.Model)
+	}
+	if !analysis.Bounded {
+		t.Error("Backlog model is bounded")
+	}
+	if analysis.Truncated {
+		t.Error("Small state space should not truncate")
+	}
+	// The inbox drains completely; the final marking has no enabled
+	// transitions.
+	if !analysis.HasDeadlock || len(analysis.Deadlocks) == 0 {
+		t.Errorf("Expected a terminal deadlock state: %+v", analysis)
+	}
+	// Tokens are conserved, so at least one P-invariant exists.
+	if len(analysis.Invariants) == 0 {
+		t.Fatal("Expected a conservation invariant")
+	}
+	if analysis.Invariants[0].Value != 10 {
+		t.Errorf("Invariant value: %d", analysis.Invariants[0].Value)
+	}
+}
+
+func TestAnalyzeModelDeadTransition(t *testing.T) {
+	model := petri.NewPetriNet()
+	model.AddPlace("a", 1, nil, 0, 0, nil)
+	model.AddPlace("b", 0, nil, 100, 0, nil)
+	model.AddPlace("never", 0, nil, 0, 100, nil)
+	model.AddTransition("go", "", 50, 0, nil)
+	model.AddTransition("stuck", "", 50, 100, nil)
+	model.AddArc("a", "go", 1, false)
+	model.AddArc("go", "b", 1, false)
+	model.AddArc("never", "stuck", 1, false)
+
+	analysis := AnalyzeModel("partial", model)
+	found := false
+	for _, name := range analysis.DeadTransitions {
+		if name == "stuck" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected stuck in dead transitions: %v", analysis.DeadTransitions)
+	}
+	if analysis.Live {
+		t.Error("Model with a dead transition is not live")
+	}
+}
+
+func TestModelAnalysisQuery(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	server := NewServer(WithModel("backlog", model, store))
+
+	resp := server.Execute(ctx, GraphQLRequest{
+		Query: `query { modelAnalysis(name: "backlog") { bounded } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Query errors: %v", resp.Errors)
+	}
+	analysis, ok := resp.Data["modelAnalysis"].(*ModelAnalysis)
+	if !ok {
+		t.Fatalf("Unexpected result type: %T", resp.Data["modelAnalysis"])
+	}
+	if !analysis.Bounded {
+		t.Error("Expected bounded analysis via GraphQL")
+	}
+
+	// Second query hits the cache.
+	again := server.Execute(ctx, GraphQLRequest{
+		Query: `query { modelAnalysis(name: "backlog") { bounded } }`,
+	})
+	if again.Data["modelAnalysis"].(*ModelAnalysis) != analysis {
+		t.Error("Expected the cached report on the second query")
+	}
+
+	// Registering a model invalidates the cache.
+	other := newBacklogModel()
+	server.AddModel("other", other, NewEventSourceStore(eventsource.NewMemoryStore(), other, "other"))
+	refreshed := server.Execute(ctx, GraphQLRequest{
+		Query: `query { modelAnalysis(name: "backlog") { bounded } }`,
+	})
+	if refreshed.Data["modelAnalysis"].(*ModelAnalysis) == analysis {
+		t.Error("Expected a fresh report after the model set changed")
+	}
+
+	// Unknown models are errors, not empty reports.
+	missing := server.Execute(ctx, GraphQLRequest{
+		Query: `query { modelAnalysis(name: "nope") { bounded } }`,
+	})
+	if len(missing.Errors) == 0 {
+		t.Error("Expected an error for an unknown model")
+	}
+}
+
+func TestModelAnalysisSchema(t *testing.T) {
+	model := newBacklogModel()
+	schema := GenerateSchema(model, "backlog")
+	if !strings.Contains(schema, "modelAnalysis(name: String!): ModelAnalysis!") {
+		t.Error("Schema missing modelAnalysis query")
+	}
+	if !strings.Contains(schema, "type ModelAnalysis {") || !strings.Contains(schema, "type Invariant {") {
+		t.Error("Schema missing analysis types")
+	}
+
+	unified := GenerateUnifiedSchema(map[string]*petri.PetriNet{"backlog": model})
+	if !strings.Contains(unified, "modelAnalysis(name: String!): ModelAnalysis!") {
+		t.Error("Unified schema missing modelAnalysis query")
+	}
+}
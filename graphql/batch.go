@@ -0,0 +1,191 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// BulkTransitionResult summarizes a bulk firing: how many instances were
+// attempted, how many succeeded, and the per-instance errors for the rest.
+// Partial success is expected — a disabled transition on one instance does
+// not abort the batch.
+type BulkTransitionResult struct {
+	Attempted int         `json:"attempted"`
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+	Instances []*Instance `json:"instances"`
+	Errors    []string    `json:"errors"`
+}
+
+// listInstances runs an instances query and assembles the page payload,
+// including the cursor fields. In cursor mode (After set) it probes for one
+// extra row to decide hasNextPage; in page mode it derives it from the
+// total.
+func (r *ModelResolver) listInstances(ctx context.Context, filter InstanceFilter) (any, error) {
+	perPage := filter.PerPage
+	if perPage < 1 {
+		perPage = 20
+	}
+	page := filter.Page
+	if page < 1 {
+		page = 1
+	}
+
+	var instances []*Instance
+	var total int
+	var err error
+	hasNext := false
+
+	if filter.After != "" {
+		probe := filter
+		probe.Page = 1
+		probe.PerPage = perPage + 1
+		instances, total, err = r.store.List(ctx, probe)
+		if err != nil {
+			return nil, err
+		}
+		if len(instances) > perPage {
+			hasNext = true
+			instances = instances[:perPage]
+		}
+	} else {
+		instances, total, err = r.store.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		hasNext = page*perPage < total
+	}
+
+	endCursor := ""
+	if len(instances) > 0 {
+		endCursor = instances[len(instances)-1].ID
+	}
+	return map[string]any{
+		"items":       instances,
+		"total":       total,
+		"page":        filter.Page,
+		"endCursor":   endCursor,
+		"hasNextPage": hasNext,
+	}, nil
+}
+
+// createMany creates count instances in one call and returns them all.
+func (r *ModelResolver) createMany(ctx context.Context, args map[string]any) (any, error) {
+	count := intArg(args, "count", 0)
+	if count < 1 {
+		return nil, fmt.Errorf("createMany requires count >= 1")
+	}
+
+	modelName := ""
+	if len(r.model.Token) > 0 {
+		modelName = r.model.Token[0]
+	}
+	instances := make([]*Instance, 0, count)
+	for i := 0; i < count; i++ {
+		id, err := r.store.Create(ctx, modelName)
+		if err != nil {
+			return nil, fmt.Errorf("created %d of %d: %w", i, count, err)
+		}
+		inst, err := r.store.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, inst)
+	}
+	return instances, nil
+}
+
+// fireMany fires one transition across a set of instances selected either
+// by explicit ids or by a place filter, continuing past individual
+// failures.
+func (r *ModelResolver) fireMany(ctx context.Context, transition string, args map[string]any) (any, error) {
+	input, _ := args["input"].(map[string]any)
+
+	ids := stringList(input["ids"])
+	if len(ids) == 0 {
+		filter := InstanceFilter{
+			Place:   stringArg(input, "place"),
+			PerPage: intArg(input, "limit", 100),
+		}
+		if len(r.model.Token) > 0 {
+			filter.ModelName = r.model.Token[0]
+		}
+		instances, _, err := r.store.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, inst := range instances {
+			ids = append(ids, inst.ID)
+		}
+	}
+
+	// Everything else in the input is a binding for each firing.
+	bindings := make(map[string]any)
+	for k, v := range input {
+		switch k {
+		case "ids", "place", "limit":
+		default:
+			bindings[k] = v
+		}
+	}
+
+	result := &BulkTransitionResult{Attempted: len(ids)}
+	for _, id := range ids {
+		inst, err := r.store.Fire(ctx, id, transition, bindings)
+		if err != nil {
+			result.Failed++
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		result.Succeeded++
+		result.Instances = append(result.Instances, inst)
+	}
+	return result, nil
+}
+
+// bulkTransition reports whether a mutation name is the Many-variant of a
+// model transition, e.g. approveMany for transition approve.
+func (r *ModelResolver) bulkTransition(name string) (string, bool) {
+	base := strings.TrimSuffix(name, "Many")
+	if base == name {
+		return "", false
+	}
+	if _, ok := r.model.Transitions[base]; !ok {
+		return "", false
+	}
+	return base, true
+}
+
+// intArg reads an integer argument that may arrive as int (direct callers)
+// or float64 (decoded JSON variables).
+func intArg(args map[string]any, key string, fallback int) int {
+	switch n := args[key].(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return fallback
+}
+
+func stringArg(args map[string]any, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
+
+func stringList(v any) []string {
+	switch list := v.(type) {
+	case []string:
+		return list
+	case []any:
+		out := make([]string, 0, len(list))
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+func TestCreateManyMutation(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	server := NewServer(WithModel("backlog", model, store))
+
+	resp := server.Execute(ctx, GraphQLRequest{
+		Query: `mutation { backlog_createMany(count: 3) { id } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Mutation errors: %v", resp.Errors)
+	}
+	instances, ok := resp.Data["backlog_createMany"].([]*Instance)
+	if !ok {
+		t.Fatalf("Unexpected result type: %T", resp.Data["backlog_createMany"])
+	}
+	if len(instances) != 3 {
+		t.Errorf("Expected 3 instances, got %d", len(instances))
+	}
+
+	_, total, err := store.List(ctx, InstanceFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("Store should hold 3 instances, has %d", total)
+	}
+}
+
+func TestFireManyByFilter(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	resolver := NewModelResolver(model, store)
+
+	for i := 0; i < 4; i++ {
+		if _, err := store.Create(ctx, "backlog"); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	// All 4 instances have tokens in inbox, so the filtered bulk firing
+	// hits every one of them.
+	out, err := resolver.Mutate(ctx, "enqueueMany", map[string]any{
+		"input": map[string]any{"place": "inbox"},
+	})
+	if err != nil {
+		t.Fatalf("enqueueMany: %v", err)
+	}
+	result, ok := out.(*BulkTransitionResult)
+	if !ok {
+		t.Fatalf("Unexpected result type: %T", out)
+	}
+	if result.Attempted != 4 || result.Succeeded != 4 || result.Failed != 0 {
+		t.Errorf("Bulk result: %+v", result)
+	}
+	for _, inst := range result.Instances {
+		if inst.Marking["backlog"] != 1 {
+			t.Errorf("Instance %s not fired: %v", inst.ID, inst.Marking)
+		}
+	}
+}
+
+func TestFireManyPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	resolver := NewModelResolver(model, store)
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	out, err := resolver.Mutate(ctx, "enqueueMany", map[string]any{
+		"input": map[string]any{"ids": []any{id, "missing"}},
+	})
+	if err != nil {
+		t.Fatalf("enqueueMany: %v", err)
+	}
+	result := out.(*BulkTransitionResult)
+	if result.Attempted != 2 || result.Succeeded != 1 || result.Failed != 1 {
+		t.Errorf("Bulk result: %+v", result)
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Expected one error, got %v", result.Errors)
+	}
+}
+
+func TestCursorPagination(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	resolver := NewModelResolver(model, store)
+
+	for i := 0; i < 5; i++ {
+		if _, err := store.Create(ctx, "backlog"); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	// Walk the full set two at a time and check each ID appears once.
+	seen := make(map[string]bool)
+	cursor := ""
+	pages := 0
+	for {
+		args := map[string]any{"perPage": 2}
+		if cursor != "" {
+			args["after"] = cursor
+		}
+		out, err := resolver.Query(ctx, "instances", args)
+		if err != nil {
+			t.Fatalf("instances: %v", err)
+		}
+		page := out.(map[string]any)
+		items := page["items"].([]*Instance)
+		for _, inst := range items {
+			if seen[inst.ID] {
+				t.Errorf("Instance %s returned twice", inst.ID)
+			}
+			seen[inst.ID] = true
+		}
+		pages++
+		if !page["hasNextPage"].(bool) {
+			break
+		}
+		cursor = page["endCursor"].(string)
+		if pages > 5 {
+			t.Fatal("Cursor walk did not terminate")
+		}
+	}
+	if len(seen) != 5 {
+		t.Errorf("Cursor walk visited %d of 5 instances", len(seen))
+	}
+	if pages != 3 {
+		t.Errorf("Expected 3 pages of 2, got %d", pages)
+	}
+}
+
+func TestUnknownCursor(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	if _, err := store.Create(ctx, "backlog"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, _, err := store.List(ctx, InstanceFilter{After: "bogus"}); err == nil {
+		t.Error("Expected error for unknown cursor")
+	}
+}
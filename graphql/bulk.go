@@ -0,0 +1,124 @@
+package graphql
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+// ExportRecord is one line of a JSONL instance export: the instance snapshot
+// (marking, state variables, version) plus its full event history when the
+// backing store provides one.
+type ExportRecord struct {
+	Instance *Instance            `json:"instance"`
+	History  []*eventsource.Event `json:"history,omitempty"`
+}
+
+// ExportInstances writes every instance of a model to w as JSONL, one
+// ExportRecord per line. Works with any Store; history is included only by
+// store-specific exporters like EventSourceStore.ExportJSONL.
+func ExportInstances(ctx context.Context, store Store, modelName string, w io.Writer) (int, error) {
+	enc := json.NewEncoder(w)
+	count := 0
+	page := 1
+	for {
+		instances, _, err := store.List(ctx, InstanceFilter{
+			ModelName: modelName,
+			Page:      page,
+			PerPage:   500,
+		})
+		if err != nil {
+			return count, fmt.Errorf("failed to list instances (page %d): %w", page, err)
+		}
+		if len(instances) == 0 {
+			break
+		}
+		for _, inst := range instances {
+			if err := enc.Encode(ExportRecord{Instance: inst}); err != nil {
+				return count, fmt.Errorf("failed to encode instance %s: %w", inst.ID, err)
+			}
+			count++
+		}
+		page++
+	}
+	return count, nil
+}
+
+// ExportJSONL writes all instances of this store's model to w as JSONL,
+// including the complete event history of each instance so the export can be
+// replayed into another environment with ImportJSONL.
+func (s *EventSourceStore) ExportJSONL(ctx context.Context, w io.Writer) (int, error) {
+	instances, _, err := s.List(ctx, InstanceFilter{ModelName: s.modelName, PerPage: 1 << 30})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list instances: %w", err)
+	}
+
+	enc := json.NewEncoder(w)
+	count := 0
+	for _, inst := range instances {
+		events, err := s.store.Read(ctx, inst.ID, 0)
+		if err != nil {
+			return count, fmt.Errorf("failed to read history for %s: %w", inst.ID, err)
+		}
+		if err := enc.Encode(ExportRecord{Instance: inst, History: events}); err != nil {
+			return count, fmt.Errorf("failed to encode instance %s: %w", inst.ID, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// ImportJSONL reads an ExportJSONL stream and recreates each instance by
+// replaying its event history into this store. Instance IDs are preserved;
+// importing an ID that already exists is an error (drills should target an
+// empty environment). Returns the number of instances imported.
+func (s *EventSourceStore) ImportJSONL(ctx context.Context, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+
+	count := 0
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record ExportRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return count, fmt.Errorf("line %d: failed to parse record: %w", line, err)
+		}
+		if record.Instance == nil || record.Instance.ID == "" {
+			return count, fmt.Errorf("line %d: record has no instance ID", line)
+		}
+		if len(record.History) == 0 {
+			return count, fmt.Errorf("line %d: instance %s has no history to replay", line, record.Instance.ID)
+		}
+
+		id := record.Instance.ID
+		if version, err := s.store.StreamVersion(ctx, id); err == nil && version >= 0 {
+			return count, fmt.Errorf("line %d: instance %s already exists", line, id)
+		}
+		if _, err := s.store.Append(ctx, id, -1, record.History); err != nil {
+			return count, fmt.Errorf("line %d: failed to replay history for %s: %w", line, id, err)
+		}
+
+		// Drop any stale cache entry so the next read replays the new stream.
+		s.mu.Lock()
+		delete(s.cache, id)
+		s.mu.Unlock()
+
+		// Load immediately so List (which is cache-backed) sees the instance.
+		if _, err := s.loadStateMachine(ctx, id); err != nil {
+			return count, fmt.Errorf("line %d: failed to rebuild instance %s: %w", line, id, err)
+		}
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return count, fmt.Errorf("failed to read import stream: %w", err)
+	}
+	return count, nil
+}
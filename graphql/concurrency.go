@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+// Reserved binding keys on transition mutations. They steer how a firing is
+// applied and are stripped from the event payload, so they never leak into
+// instance state variables.
+const (
+	// BindingExpectedVersion makes the firing a compare-and-swap: it fails
+	// with a version conflict unless the instance is at exactly this
+	// version. Clients read version from the instance, decide, and fire.
+	BindingExpectedVersion = "expectedVersion"
+
+	// BindingIdempotencyKey deduplicates retries: if an event carrying the
+	// same key is already in the stream, the firing is skipped and the
+	// current instance returned instead of double-firing.
+	BindingIdempotencyKey = "idempotencyKey"
+)
+
+// fireControls are the concurrency arguments extracted from a mutation's
+// bindings.
+type fireControls struct {
+	expectedVersion int
+	hasExpected     bool
+	idempotencyKey  string
+}
+
+// extractFireControls pulls the reserved keys out of bindings and returns
+// the remaining bindings as the event payload. The input map is not
+// modified.
+func extractFireControls(bindings map[string]any) (fireControls, map[string]any) {
+	var controls fireControls
+	if len(bindings) == 0 {
+		return controls, bindings
+	}
+
+	payload := make(map[string]any, len(bindings))
+	for k, v := range bindings {
+		switch k {
+		case BindingExpectedVersion:
+			// GraphQL variables decode numbers as float64; direct callers
+			// pass int.
+			switch n := v.(type) {
+			case int:
+				controls.expectedVersion = n
+				controls.hasExpected = true
+			case float64:
+				controls.expectedVersion = int(n)
+				controls.hasExpected = true
+			}
+		case BindingIdempotencyKey:
+			if key, ok := v.(string); ok {
+				controls.idempotencyKey = key
+			}
+		default:
+			payload[k] = v
+		}
+	}
+	return controls, payload
+}
+
+// findByIdempotencyKey reports whether an event carrying the key is already
+// in the instance's stream.
+func (s *EventSourceStore) findByIdempotencyKey(ctx context.Context, id, key string) (bool, error) {
+	events, err := s.store.Read(ctx, id, 0)
+	if err != nil {
+		return false, err
+	}
+	for _, event := range events {
+		if event.Metadata[BindingIdempotencyKey] == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// versionConflictError formats the error returned when expectedVersion does
+// not match; it wraps eventsource.ErrConcurrencyConflict so callers can
+// detect the case with errors.Is.
+func versionConflictError(expected, actual int) error {
+	return fmt.Errorf("%w: expected version %d, instance at version %d",
+		eventsource.ErrConcurrencyConflict, expected, actual)
+}
@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+func TestFireExpectedVersion(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	inst, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	// CAS at the right version succeeds.
+	updated, err := store.Fire(ctx, id, "enqueue", map[string]any{
+		BindingExpectedVersion: inst.Version,
+	})
+	if err != nil {
+		t.Fatalf("Fire with matching version: %v", err)
+	}
+	if updated.Marking["backlog"] != 1 {
+		t.Errorf("Marking after CAS fire: %v", updated.Marking)
+	}
+
+	// Re-using the stale version fails with a concurrency conflict, so two
+	// clients that both read version N cannot both win.
+	_, err = store.Fire(ctx, id, "enqueue", map[string]any{
+		BindingExpectedVersion: inst.Version,
+	})
+	if !errors.Is(err, eventsource.ErrConcurrencyConflict) {
+		t.Errorf("Fire with stale version: expected concurrency conflict, got %v", err)
+	}
+
+	// The failed CAS did not fire.
+	current, _ := store.Get(ctx, id)
+	if current.Marking["backlog"] != 1 {
+		t.Errorf("Stale CAS changed marking: %v", current.Marking)
+	}
+}
+
+func TestFireIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	first, err := store.Fire(ctx, id, "enqueue", map[string]any{
+		BindingIdempotencyKey: "req-42",
+	})
+	if err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+
+	// The retry is a no-op that returns current state.
+	retry, err := store.Fire(ctx, id, "enqueue", map[string]any{
+		BindingIdempotencyKey: "req-42",
+	})
+	if err != nil {
+		t.Fatalf("Retried fire: %v", err)
+	}
+	if retry.Marking["backlog"] != 1 || retry.Version != first.Version {
+		t.Errorf("Retry double-fired: %+v", retry)
+	}
+
+	// A different key fires normally.
+	second, err := store.Fire(ctx, id, "enqueue", map[string]any{
+		BindingIdempotencyKey: "req-43",
+	})
+	if err != nil {
+		t.Fatalf("Fire with new key: %v", err)
+	}
+	if second.Marking["backlog"] != 2 {
+		t.Errorf("New key should fire: %v", second.Marking)
+	}
+}
+
+func TestFireControlsStrippedFromState(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	created, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	inst, err := store.Fire(ctx, id, "enqueue", map[string]any{
+		BindingExpectedVersion: created.Version,
+		BindingIdempotencyKey:  "req-1",
+		"note":                 "kept",
+	})
+	if err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+	if _, ok := inst.State[BindingExpectedVersion]; ok {
+		t.Error("expectedVersion leaked into instance state")
+	}
+	if _, ok := inst.State[BindingIdempotencyKey]; ok {
+		t.Error("idempotencyKey leaked into instance state")
+	}
+	if inst.State["note"] != "kept" {
+		t.Errorf("Ordinary binding lost: %v", inst.State)
+	}
+}
+
+func TestSchemaIncludesConcurrencyArgs(t *testing.T) {
+	schema := GenerateSchema(newBacklogModel(), "backlog")
+	if !strings.Contains(schema, "expectedVersion: Int") {
+		t.Error("Schema missing expectedVersion input field")
+	}
+	if !strings.Contains(schema, "idempotencyKey: String") {
+		t.Error("Schema missing idempotencyKey input field")
+	}
+}
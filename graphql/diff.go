@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// ModelDiff is the structured comparison returned by the modelDiff query.
+// Two registered models act as the two versions; reviewing the changeset
+// before swapping a model is how server deployments upgrade safely.
+type ModelDiff struct {
+	From      string           `json:"from"`
+	To        string           `json:"to"`
+	Identical bool             `json:"identical"`
+	Summary   string           `json:"summary"`
+	Changes   *petri.Changeset `json:"changes"`
+}
+
+// modelDiff resolves the modelDiff query by diffing two registered models.
+func (s *Server) modelDiff(ctx context.Context, args map[string]any) (any, error) {
+	from, _ := args["from"].(string)
+	to, _ := args["to"].(string)
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("modelDiff requires from and to model names")
+	}
+
+	s.mu.RLock()
+	fromModel, fromOK := s.models[from]
+	toModel, toOK := s.models[to]
+	s.mu.RUnlock()
+	if !fromOK {
+		return nil, fmt.Errorf("unknown model %q", from)
+	}
+	if !toOK {
+		return nil, fmt.Errorf("unknown model %q", to)
+	}
+
+	changes := petri.Diff(fromModel, toModel)
+	return &ModelDiff{
+		From:      from,
+		To:        to,
+		Identical: changes.IsEmpty(),
+		Summary:   changes.Summary(),
+		Changes:   changes,
+	}, nil
+}
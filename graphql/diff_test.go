@@ -0,0 +1,78 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+func TestModelDiffQuery(t *testing.T) {
+	ctx := context.Background()
+
+	v1 := newBacklogModel()
+	v2 := newBacklogModel()
+	v2.AddPlace("archived", 0, nil, 0, 0, nil)
+	v2.AddTransition("archive", "default", 0, 0, nil)
+	v2.AddArc("backlog", "archive", 1, false)
+	v2.AddArc("archive", "archived", 1, false)
+
+	server := NewServer(
+		WithModel("backlog-v1", v1, NewEventSourceStore(eventsource.NewMemoryStore(), v1, "backlog-v1")),
+		WithModel("backlog-v2", v2, NewEventSourceStore(eventsource.NewMemoryStore(), v2, "backlog-v2")),
+	)
+
+	resp := server.Execute(ctx, GraphQLRequest{
+		Query: `query { modelDiff(from: "backlog-v1", to: "backlog-v2") { identical } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Query errors: %v", resp.Errors)
+	}
+	diff, ok := resp.Data["modelDiff"].(*ModelDiff)
+	if !ok {
+		t.Fatalf("Unexpected result type: %T", resp.Data["modelDiff"])
+	}
+	if diff.Identical {
+		t.Error("Expected versions to differ")
+	}
+	if len(diff.Changes.AddedPlaces) != 1 || diff.Changes.AddedPlaces[0] != "archived" {
+		t.Errorf("Expected added place 'archived', got %v", diff.Changes.AddedPlaces)
+	}
+	if !strings.Contains(diff.Summary, "+ transition archive") {
+		t.Errorf("Expected summary to mention added transition, got:\n%s", diff.Summary)
+	}
+
+	// A model compared to itself is identical.
+	same := server.Execute(ctx, GraphQLRequest{
+		Query: `query { modelDiff(from: "backlog-v1", to: "backlog-v1") { identical } }`,
+	})
+	if len(same.Errors) > 0 {
+		t.Fatalf("Query errors: %v", same.Errors)
+	}
+	if !same.Data["modelDiff"].(*ModelDiff).Identical {
+		t.Error("Expected a model to be identical to itself")
+	}
+
+	// Unknown models are errors.
+	missing := server.Execute(ctx, GraphQLRequest{
+		Query: `query { modelDiff(from: "backlog-v1", to: "nope") { identical } }`,
+	})
+	if len(missing.Errors) == 0 {
+		t.Error("Expected an error for an unknown model")
+	}
+}
+
+func TestModelDiffSchema(t *testing.T) {
+	model := newBacklogModel()
+	server := NewServer(WithModel("backlog", model,
+		NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")))
+
+	schema := server.Schema()
+	if !strings.Contains(schema, "modelDiff(from: String!, to: String!): ModelDiff!") {
+		t.Error("Expected modelDiff query in schema")
+	}
+	if !strings.Contains(schema, "type ModelDiff {") {
+		t.Error("Expected ModelDiff type in schema")
+	}
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+// enablementCache memoizes the enabled-transition computation per marking.
+// Every enablement input for store-built transitions — arc weights,
+// inhibitors, guard expressions, marking-dependent weights — is a function
+// of the marking alone, so instances sharing a marking share the result
+// and list queries stop recomputing it per instance.
+type enablementCache struct {
+	mu      sync.Mutex
+	entries map[string][]string
+	maxSize int
+	hits    int64
+	misses  int64
+}
+
+// enablementCacheSize bounds the cache; markings beyond it evict an
+// arbitrary entry (FIFO-ish, matching cache.StateCache).
+const enablementCacheSize = 4096
+
+func newEnablementCache() *enablementCache {
+	return &enablementCache{
+		entries: make(map[string][]string),
+		maxSize: enablementCacheSize,
+	}
+}
+
+// getOrCompute returns the cached enabled set for a marking, computing and
+// storing it on a miss. Results are sorted for deterministic output.
+func (c *enablementCache) getOrCompute(marking map[string]int, compute func() []string) []string {
+	key := markingKey(marking)
+
+	c.mu.Lock()
+	if enabled, ok := c.entries[key]; ok {
+		c.hits++
+		c.mu.Unlock()
+		return enabled
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	enabled := compute()
+	sort.Strings(enabled)
+
+	c.mu.Lock()
+	if c.maxSize > 0 && len(c.entries) >= c.maxSize {
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = enabled
+	c.mu.Unlock()
+	return enabled
+}
+
+// markingKey serializes a marking deterministically.
+func markingKey(marking map[string]int) string {
+	places := make([]string, 0, len(marking))
+	for place := range marking {
+		places = append(places, place)
+	}
+	sort.Strings(places)
+
+	var sb strings.Builder
+	for _, place := range places {
+		fmt.Fprintf(&sb, "%s=%d;", place, marking[place])
+	}
+	return sb.String()
+}
+
+// enablementBatchThreshold is the list size above which instance
+// conversion fans out to a worker pool.
+const enablementBatchThreshold = 32
+
+// batchInstances converts state machines to instances, in parallel for
+// large lists so marking extraction and enablement lookups overlap.
+func (s *EventSourceStore) batchInstances(sms []*eventsource.StateMachine[map[string]any]) []*Instance {
+	instances := make([]*Instance, len(sms))
+	if len(sms) < enablementBatchThreshold {
+		for i, sm := range sms {
+			instances[i] = s.toInstance(sm)
+		}
+		return instances
+	}
+
+	workers := runtime.NumCPU()
+	if workers > 8 {
+		workers = 8
+	}
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				instances[i] = s.toInstance(sms[i])
+			}
+		}()
+	}
+	for i := range sms {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return instances
+}
@@ -0,0 +1,107 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+func TestEnablementCacheSharesMarkings(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	// Several instances, all at the initial marking.
+	for i := 0; i < 5; i++ {
+		if _, err := store.Create(ctx, "backlog"); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	instances, total, err := store.List(ctx, InstanceFilter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 5 {
+		t.Fatalf("Expected 5 instances, got %d", total)
+	}
+	for _, inst := range instances {
+		if len(inst.EnabledTransitions) != 1 || inst.EnabledTransitions[0] != "enqueue" {
+			t.Errorf("Instance %s: enabled = %v", inst.ID, inst.EnabledTransitions)
+		}
+	}
+
+	// One unique marking means one computation; the rest hit the cache.
+	store.enablement.mu.Lock()
+	hits, misses := store.enablement.hits, store.enablement.misses
+	store.enablement.mu.Unlock()
+	if misses != 1 {
+		t.Errorf("Expected 1 miss for a shared marking, got %d", misses)
+	}
+	if hits < 4 {
+		t.Errorf("Expected at least 4 hits, got %d", hits)
+	}
+}
+
+func TestEnablementCacheTracksMarkingChanges(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Drain the inbox: enqueue stays enabled until the last token moves.
+	for i := 0; i < 10; i++ {
+		inst, err := store.Fire(ctx, id, "enqueue", nil)
+		if err != nil {
+			t.Fatalf("Fire %d: %v", i, err)
+		}
+		wantEnabled := i < 9
+		gotEnabled := len(inst.EnabledTransitions) == 1
+		if gotEnabled != wantEnabled {
+			t.Errorf("After firing %d: enabled = %v", i+1, inst.EnabledTransitions)
+		}
+	}
+}
+
+func TestBatchInstancesParallelPath(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	// Enough instances to cross the worker-pool threshold.
+	for i := 0; i < enablementBatchThreshold+8; i++ {
+		if _, err := store.Create(ctx, "backlog"); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	instances, total, err := store.List(ctx, InstanceFilter{PerPage: 100})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != enablementBatchThreshold+8 || len(instances) != total {
+		t.Fatalf("Expected %d instances, got %d (total %d)", enablementBatchThreshold+8, len(instances), total)
+	}
+	for _, inst := range instances {
+		if inst == nil || len(inst.EnabledTransitions) != 1 {
+			t.Errorf("Incomplete instance from parallel conversion: %+v", inst)
+		}
+	}
+}
+
+func TestMarkingKeyDeterministic(t *testing.T) {
+	a := markingKey(map[string]int{"inbox": 3, "backlog": 7})
+	b := markingKey(map[string]int{"backlog": 7, "inbox": 3})
+	if a != b {
+		t.Errorf("Key depends on map order: %q vs %q", a, b)
+	}
+	c := markingKey(map[string]int{"inbox": 4, "backlog": 7})
+	if a == c {
+		t.Error("Different markings must not collide")
+	}
+}
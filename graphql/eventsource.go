@@ -4,11 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
 	"github.com/pflow-xyz/go-pflow/eventsource"
 	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // EventSourceStore adapts eventsource.Store to graphql.Store.
@@ -21,20 +25,28 @@ type EventSourceStore struct {
 	// Cache of loaded state machines
 	mu    sync.RWMutex
 	cache map[string]*eventsource.StateMachine[map[string]any]
+
+	// Memoized enabled-transition sets, keyed by marking
+	enablement *enablementCache
 }
 
 // NewEventSourceStore creates a new store backed by an eventsource.Store.
 func NewEventSourceStore(store eventsource.Store, model *petri.PetriNet, modelName string) *EventSourceStore {
 	return &EventSourceStore{
-		store:     store,
-		model:     model,
-		modelName: modelName,
-		cache:     make(map[string]*eventsource.StateMachine[map[string]any]),
+		store:      store,
+		model:      model,
+		modelName:  modelName,
+		cache:      make(map[string]*eventsource.StateMachine[map[string]any]),
+		enablement: newEnablementCache(),
 	}
 }
 
 // Create creates a new Petri net instance.
-func (s *EventSourceStore) Create(ctx context.Context, modelName string) (string, error) {
+func (s *EventSourceStore) Create(ctx context.Context, modelName string) (_ string, err error) {
+	ctx, span := tracer.Start(ctx, "store.create",
+		trace.WithAttributes(attribute.String("model.name", modelName)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	id := uuid.New().String()
 
 	// Build initial marking from model
@@ -106,23 +118,66 @@ func (s *EventSourceStore) Get(ctx context.Context, id string) (*Instance, error
 }
 
 // Fire attempts to fire a transition on an instance.
-func (s *EventSourceStore) Fire(ctx context.Context, id string, transition string, bindings map[string]any) (*Instance, error) {
+func (s *EventSourceStore) Fire(ctx context.Context, id string, transition string, bindings map[string]any) (_ *Instance, err error) {
+	ctx, span := tracer.Start(ctx, "store.fire",
+		trace.WithAttributes(
+			attribute.String("instance.id", id),
+			attribute.String("transition", transition),
+		))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	sm, err := s.loadStateMachine(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	// Pull concurrency controls out of the bindings (see concurrency.go).
+	controls, payload := extractFireControls(bindings)
+
+	// A retried call with the same idempotency key returns the current
+	// state instead of firing twice.
+	if controls.idempotencyKey != "" {
+		seen, err := s.findByIdempotencyKey(ctx, id, controls.idempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if seen {
+			return s.toInstance(sm), nil
+		}
+	}
+
+	// Compare-and-swap on the instance version when the client asked for it.
+	if controls.hasExpected && sm.Version() != controls.expectedVersion {
+		return nil, versionConflictError(controls.expectedVersion, sm.Version())
+	}
+
 	// Check if transition can fire
 	if !sm.CanFire(transition) {
 		return nil, fmt.Errorf("transition %s is not enabled", transition)
 	}
 
 	// Create event with bindings as data
-	event, err := sm.Fire(transition, bindings)
+	event, err := sm.Fire(transition, payload)
 	if err != nil {
 		return nil, err
 	}
 
+	if controls.idempotencyKey != "" {
+		if event.Metadata == nil {
+			event.Metadata = make(map[string]string)
+		}
+		event.Metadata[BindingIdempotencyKey] = controls.idempotencyKey
+	}
+
+	// Record who fired the transition in the event metadata so the audit
+	// trail (see history.go) can attribute it.
+	if actor, ok := bindings["actor"].(string); ok && actor != "" {
+		if event.Metadata == nil {
+			event.Metadata = make(map[string]string)
+		}
+		event.Metadata["actor"] = actor
+	}
+
 	// Save event
 	if _, err := s.store.Append(ctx, id, sm.Version(), []*eventsource.Event{event}); err != nil {
 		return nil, err
@@ -138,15 +193,20 @@ func (s *EventSourceStore) Fire(ctx context.Context, id string, transition strin
 
 // List returns instances with optional filtering.
 func (s *EventSourceStore) List(ctx context.Context, filter InstanceFilter) ([]*Instance, int, error) {
+	_, span := tracer.Start(ctx, "store.list")
+	defer span.End()
+
 	// Use the cache since it has the correct Petri net state
 	// The eventsource.AdminStore interface doesn't track Petri net markings
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	var result []*Instance
+	sms := make([]*eventsource.StateMachine[map[string]any], 0, len(s.cache))
 	for _, sm := range s.cache {
-		inst := s.toInstance(sm)
+		sms = append(sms, sm)
+	}
+	s.mu.RUnlock()
 
+	var result []*Instance
+	for _, inst := range s.batchInstances(sms) {
 		// Apply place filter
 		if filter.Place != "" {
 			if inst.Marking[filter.Place] <= 0 {
@@ -162,8 +222,31 @@ func (s *EventSourceStore) List(ctx context.Context, filter InstanceFilter) ([]*
 		result = append(result, inst)
 	}
 
+	// Deterministic order so cursor pagination is stable across calls.
+	sort.Slice(result, func(i, j int) bool {
+		if filter.OrderBy == "version" && result[i].Version != result[j].Version {
+			return result[i].Version < result[j].Version
+		}
+		return result[i].ID < result[j].ID
+	})
+
 	total := len(result)
 
+	// Cursor: resume immediately after the given instance ID.
+	if filter.After != "" {
+		idx := -1
+		for i, inst := range result {
+			if inst.ID == filter.After {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, 0, fmt.Errorf("unknown cursor: %s", filter.After)
+		}
+		result = result[idx+1:]
+	}
+
 	// Apply pagination
 	page := filter.Page
 	if page < 1 {
@@ -299,9 +382,74 @@ func (s *EventSourceStore) buildTransition(label string) eventsource.Transition
 		t.Outputs[arc.Target] = weight
 	}
 
+	// Wire model-level guard expressions into the state machine. An
+	// erroring guard keeps the transition disabled rather than failing the
+	// request.
+	model := s.model
+	if mt, ok := model.Transitions[label]; ok && mt.Guard != "" {
+		t.MarkingGuard = func(places map[string]int) bool {
+			satisfied, err := model.GuardSatisfied(label, placesToState(places))
+			return err == nil && satisfied
+		}
+	}
+
+	// Marking-dependent arc weights re-evaluate per firing.
+	if hasArcExpressions(model, label) {
+		t.InputsFor = func(places map[string]int) map[string]int {
+			return evalArcWeights(model.GetInputArcs(label), places, func(arc *petri.Arc) string { return arc.Source })
+		}
+		t.OutputsFor = func(places map[string]int) map[string]int {
+			return evalArcWeights(model.GetOutputArcs(label), places, func(arc *petri.Arc) string { return arc.Target })
+		}
+	}
+
 	return t
 }
 
+// hasArcExpressions reports whether any arc touching the transition has a
+// weight expression.
+func hasArcExpressions(model *petri.PetriNet, label string) bool {
+	for _, arc := range model.Arcs {
+		if arc.Expression != "" && (arc.Source == label || arc.Target == label) {
+			return true
+		}
+	}
+	return false
+}
+
+// evalArcWeights computes effective integer weights for a set of arcs
+// against the given marking. Inhibitor arcs are excluded; evaluation errors
+// fall back to the static weight.
+func evalArcWeights(arcs []*petri.Arc, places map[string]int, placeOf func(*petri.Arc) string) map[string]int {
+	state := placesToState(places)
+	weights := make(map[string]int, len(arcs))
+	for _, arc := range arcs {
+		if arc.InhibitTransition {
+			continue
+		}
+		w, err := arc.EvalWeight(state)
+		if err != nil {
+			w = arc.GetWeightSum()
+		}
+		weight := int(w)
+		if weight < 0 {
+			weight = 0
+		}
+		weights[placeOf(arc)] = weight
+	}
+	return weights
+}
+
+// placesToState converts an integer marking to the float64 state maps the
+// petri expression helpers expect.
+func placesToState(places map[string]int) map[string]float64 {
+	state := make(map[string]float64, len(places))
+	for k, v := range places {
+		state[k] = float64(v)
+	}
+	return state
+}
+
 // toInstance converts a state machine to a GraphQL Instance.
 func (s *EventSourceStore) toInstance(sm *eventsource.StateMachine[map[string]any]) *Instance {
 	places := sm.Places()
@@ -318,12 +466,14 @@ func (s *EventSourceStore) toInstance(sm *eventsource.StateMachine[map[string]an
 	}
 
 	return &Instance{
-		ID:                 sm.ID(),
-		ModelName:          s.modelName,
-		Version:            sm.Version(),
-		Marking:            marking,
-		State:              stateMap,
-		EnabledTransitions: sm.EnabledTransitions(),
+		ID:        sm.ID(),
+		ModelName: s.modelName,
+		Version:   sm.Version(),
+		Marking:   marking,
+		State:     stateMap,
+		EnabledTransitions: s.enablement.getOrCompute(marking, func() []string {
+			return sm.EnabledTransitions()
+		}),
 	}
 }
 
@@ -243,3 +243,92 @@ func TestEventSourceStore_Persistence(t *testing.T) {
 		t.Errorf("approved = %d, want 1", instance.Marking["approved"])
 	}
 }
+
+func TestEventSourceStore_GuardedTransition(t *testing.T) {
+	ctx := context.Background()
+
+	// take moves tokens from pool to done, but only while done < 2.
+	model := petri.NewPetriNet()
+	model.AddPlace("pool", 5, 0, 0, 0, nil)
+	model.AddPlace("done", 0, 0, 100, 0, nil)
+	model.AddTransition("take", "", 50, 0, nil)
+	model.AddArc("pool", "take", 1, false)
+	model.AddArc("take", "done", 1, false)
+	if err := model.SetGuard("take", "done < 2"); err != nil {
+		t.Fatalf("SetGuard() error = %v", err)
+	}
+
+	memStore := eventsource.NewMemoryStore()
+	defer memStore.Close()
+
+	store := NewEventSourceStore(memStore, model, "guarded")
+
+	id, err := store.Create(ctx, "guarded")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// First two firings pass the guard
+	for i := 0; i < 2; i++ {
+		if _, err := store.Fire(ctx, id, "take", nil); err != nil {
+			t.Fatalf("Fire() #%d error = %v", i+1, err)
+		}
+	}
+
+	instance, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if instance.Marking["done"] != 2 {
+		t.Errorf("done tokens = %d, want 2", instance.Marking["done"])
+	}
+
+	// Guard now blocks further firings despite tokens remaining in pool
+	if _, err := store.Fire(ctx, id, "take", nil); err == nil {
+		t.Error("Expected guard to reject third firing")
+	}
+	for _, et := range instance.EnabledTransitions {
+		if et == "take" {
+			t.Error("Expected 'take' to be disabled by guard")
+		}
+	}
+}
+
+func TestEventSourceStore_ExpressionArc(t *testing.T) {
+	ctx := context.Background()
+
+	// drain consumes the whole pool in one firing.
+	model := petri.NewPetriNet()
+	model.AddPlace("pool", 4, 0, 0, 0, nil)
+	model.AddPlace("done", 0, 0, 100, 0, nil)
+	model.AddTransition("drain", "", 50, 0, nil)
+	model.AddArc("pool", "drain", 1, false)
+	model.AddArc("drain", "done", 1, false)
+	if err := model.SetArcExpression("pool", "drain", "pool"); err != nil {
+		t.Fatalf("SetArcExpression() error = %v", err)
+	}
+	if err := model.SetArcExpression("drain", "done", "pool"); err != nil {
+		t.Fatalf("SetArcExpression() error = %v", err)
+	}
+
+	memStore := eventsource.NewMemoryStore()
+	defer memStore.Close()
+
+	store := NewEventSourceStore(memStore, model, "draining")
+
+	id, err := store.Create(ctx, "draining")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	instance, err := store.Fire(ctx, id, "drain", nil)
+	if err != nil {
+		t.Fatalf("Fire() error = %v", err)
+	}
+	if instance.Marking["pool"] != 0 {
+		t.Errorf("pool tokens = %d, want 0", instance.Marking["pool"])
+	}
+	if instance.Marking["done"] != 4 {
+		t.Errorf("done tokens = %d, want 4", instance.Marking["done"])
+	}
+}
@@ -0,0 +1,128 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Apollo Federation subgraph support. With WithFederation enabled, the
+// generated schema carries @key directives on the Instance types and the
+// server answers the _service and _entities queries a federation gateway
+// uses to compose subgraphs, so a Petri net API joins an existing
+// supergraph instead of running as a standalone endpoint.
+
+// WithFederation enables Apollo Federation subgraph mode: Instance types
+// are annotated with @key(fields: "id"), the schema gains the federation
+// scaffolding (_Any, _Entity, _Service), and the server resolves _service
+// and _entities.
+func WithFederation() Option {
+	return func(s *Server) {
+		s.federation = true
+	}
+}
+
+// instanceTypePattern matches Instance type declarations (prefixed or not)
+// but not InstanceList and friends.
+var instanceTypePattern = regexp.MustCompile(`(?m)^type (\w*Instance) \{`)
+
+// federateSchema rewrites a generated schema as a federation subgraph SDL:
+// every Instance type is keyed by id, the Query type gains _service and
+// _entities, and the federation scaffolding types are appended.
+func federateSchema(schema string) string {
+	entityNames := instanceTypeNames(schema)
+
+	out := instanceTypePattern.ReplaceAllString(schema, `type $1 @key(fields: "id") {`)
+	out = strings.Replace(out, "type Query {\n",
+		"type Query {\n  _service: _Service!\n  _entities(representations: [_Any!]!): [_Entity]!\n", 1)
+
+	var sb strings.Builder
+	sb.WriteString(out)
+	sb.WriteString("\n# Apollo Federation scaffolding\n")
+	sb.WriteString("scalar _Any\n\n")
+	if len(entityNames) > 0 {
+		sb.WriteString("union _Entity = " + strings.Join(entityNames, " | ") + "\n\n")
+	}
+	sb.WriteString("type _Service {\n  sdl: String!\n}\n")
+	return sb.String()
+}
+
+// instanceTypeNames lists the Instance type names declared in a schema, in
+// declaration order.
+func instanceTypeNames(schema string) []string {
+	var names []string
+	for _, match := range instanceTypePattern.FindAllStringSubmatch(schema, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+// resolveEntities serves the _entities query: each representation carries
+// __typename and id, and resolves to the matching instance with __typename
+// echoed back (the gateway needs it to select the concrete type).
+// Representations that fail to resolve yield null, per the federation spec.
+func (s *Server) resolveEntities(ctx context.Context, args map[string]any) (any, error) {
+	raw, ok := args["representations"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("_entities requires a representations list")
+	}
+
+	s.mu.RLock()
+	resolvers := make(map[string]Resolver, len(s.resolvers))
+	for name, resolver := range s.resolvers {
+		resolvers[name] = resolver
+	}
+	s.mu.RUnlock()
+
+	// Map Instance type names back to their model resolvers.
+	byTypename := make(map[string]Resolver, len(resolvers)+1)
+	for name, resolver := range resolvers {
+		prefix := toPascalCase(strings.ReplaceAll(name, "-", ""))
+		byTypename[prefix+"Instance"] = resolver
+		// Single-model schemas use the unprefixed type name.
+		if len(resolvers) == 1 {
+			byTypename["Instance"] = resolver
+		}
+	}
+
+	entities := make([]any, 0, len(raw))
+	for _, rep := range raw {
+		fields, ok := rep.(map[string]any)
+		if !ok {
+			entities = append(entities, nil)
+			continue
+		}
+		typename, _ := fields["__typename"].(string)
+		id, _ := fields["id"].(string)
+		resolver, ok := byTypename[typename]
+		if !ok || id == "" {
+			entities = append(entities, nil)
+			continue
+		}
+
+		result, err := resolver.Query(ctx, "instance", map[string]any{"id": id})
+		if err != nil || result == nil {
+			entities = append(entities, nil)
+			continue
+		}
+		entities = append(entities, withTypename(result, typename))
+	}
+	return entities, nil
+}
+
+// withTypename converts a resolved value to a map with __typename set, as
+// the gateway's entity selection requires.
+func withTypename(value any, typename string) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return value
+	}
+	fields["__typename"] = typename
+	return fields
+}
@@ -0,0 +1,109 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestFederateSchema(t *testing.T) {
+	schema := GenerateUnifiedSchema(map[string]*petri.PetriNet{"backlog": newBacklogModel()})
+	federated := federateSchema(schema)
+
+	for _, want := range []string{
+		`type BacklogInstance @key(fields: "id") {`,
+		"  _service: _Service!",
+		"  _entities(representations: [_Any!]!): [_Entity]!",
+		"scalar _Any",
+		"union _Entity = BacklogInstance",
+		"type _Service {",
+	} {
+		if !strings.Contains(federated, want) {
+			t.Errorf("Federated schema missing %q", want)
+		}
+	}
+	// InstanceList must not be keyed.
+	if strings.Contains(federated, `BacklogInstanceList @key`) {
+		t.Error("InstanceList should not carry @key")
+	}
+}
+
+func TestFederationServiceQuery(t *testing.T) {
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	server := NewServer(WithModel("backlog", model, store), WithFederation())
+
+	resp := server.Execute(context.Background(), GraphQLRequest{
+		Query: `{ _service { sdl } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Errors: %v", resp.Errors)
+	}
+	svc, ok := resp.Data["_service"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected _service object, got %T", resp.Data["_service"])
+	}
+	sdl, _ := svc["sdl"].(string)
+	if !strings.Contains(sdl, `@key(fields: "id")`) {
+		t.Error("Service SDL should carry the @key directive")
+	}
+}
+
+func TestFederationEntitiesQuery(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	server := NewServer(WithModel("backlog", model, store), WithFederation())
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	resp := server.Execute(ctx, GraphQLRequest{
+		Query: `query ($representations: [_Any!]!) { _entities(representations: $representations) { id } }`,
+		Variables: map[string]any{
+			"representations": []any{
+				map[string]any{"__typename": "BacklogInstance", "id": id},
+				map[string]any{"__typename": "BacklogInstance", "id": "missing"},
+				map[string]any{"__typename": "Unknown", "id": id},
+			},
+		},
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Errors: %v", resp.Errors)
+	}
+	entities, ok := resp.Data["_entities"].([]any)
+	if !ok || len(entities) != 3 {
+		t.Fatalf("Expected 3 entities, got %v", resp.Data["_entities"])
+	}
+
+	first, ok := entities[0].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected entity object, got %T", entities[0])
+	}
+	if first["__typename"] != "BacklogInstance" || first["id"] != id {
+		t.Errorf("Unexpected entity: %v", first)
+	}
+	// Unresolvable representations yield null, per the federation spec.
+	if entities[1] != nil || entities[2] != nil {
+		t.Errorf("Expected nulls for unresolvable representations, got %v %v", entities[1], entities[2])
+	}
+}
+
+func TestFederationDisabledByDefault(t *testing.T) {
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	server := NewServer(WithModel("backlog", model, store))
+
+	if strings.Contains(server.Schema(), "_service") {
+		t.Error("Non-federated schema should not expose _service")
+	}
+	resp := server.Execute(context.Background(), GraphQLRequest{Query: `{ _service { sdl } }`})
+	if _, ok := resp.Data["_service"]; ok {
+		t.Error("Non-federated server should not resolve _service")
+	}
+}
@@ -0,0 +1,145 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestHistoryBuildsAuditTrail(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := store.Fire(ctx, id, "enqueue", map[string]any{"actor": "alice"}); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+	if _, err := store.Fire(ctx, id, "enqueue", nil); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+
+	entries, err := store.History(ctx, id)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries (created + 2 firings), got %d", len(entries))
+	}
+
+	created := entries[0]
+	if created.Transition != "created" || created.MarkingAfter["inbox"] != 10 {
+		t.Errorf("Created entry: %+v", created)
+	}
+
+	first := entries[1]
+	if first.Transition != "enqueue" || first.Actor != "alice" {
+		t.Errorf("First firing entry: %+v", first)
+	}
+	if first.MarkingBefore["backlog"] != 0 || first.MarkingAfter["backlog"] != 1 {
+		t.Errorf("First firing markings: before=%v after=%v",
+			first.MarkingBefore, first.MarkingAfter)
+	}
+
+	second := entries[2]
+	if second.Actor != "" {
+		t.Errorf("Anonymous firing should have no actor: %+v", second)
+	}
+	if second.MarkingBefore["backlog"] != 1 || second.MarkingAfter["backlog"] != 2 {
+		t.Errorf("Second firing markings: before=%v after=%v",
+			second.MarkingBefore, second.MarkingAfter)
+	}
+	if second.Timestamp.Before(first.Timestamp) {
+		t.Error("Entries out of chronological order")
+	}
+}
+
+func TestReplayToRebuildsPastVersion(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if _, err := store.Fire(ctx, id, "enqueue", nil); err != nil {
+			t.Fatalf("Fire: %v", err)
+		}
+	}
+
+	entries, err := store.History(ctx, id)
+	if err != nil {
+		t.Fatalf("History: %v", err)
+	}
+	// Replay to the version of the second firing.
+	inst, err := store.ReplayTo(ctx, id, entries[2].Version)
+	if err != nil {
+		t.Fatalf("ReplayTo: %v", err)
+	}
+	if inst.Marking["backlog"] != 2 || inst.Marking["inbox"] != 8 {
+		t.Errorf("Replayed marking: %v", inst.Marking)
+	}
+
+	// Live state is untouched by the replay.
+	live, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if live.Marking["backlog"] != 4 {
+		t.Errorf("Live marking disturbed by replay: %v", live.Marking)
+	}
+}
+
+func TestHistoryQuery(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	server := NewServer(WithModel("backlog", model, store))
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := store.Fire(ctx, id, "enqueue", map[string]any{"actor": "bob"}); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+
+	resp := server.Execute(ctx, GraphQLRequest{
+		Query: `query { backlogHistory(id: "` + id + `") { transition actor } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Query errors: %v", resp.Errors)
+	}
+	entries, ok := resp.Data["backlogHistory"].([]*AuditEntry)
+	if !ok {
+		t.Fatalf("Unexpected history result type: %T", resp.Data["backlogHistory"])
+	}
+	if len(entries) != 2 || entries[1].Actor != "bob" {
+		t.Errorf("History via GraphQL: %+v", entries)
+	}
+}
+
+func TestSchemaIncludesHistory(t *testing.T) {
+	model := newBacklogModel()
+	schema := GenerateSchema(model, "backlog")
+	if !strings.Contains(schema, "history(id: ID!): [AuditEntry!]!") {
+		t.Error("Schema missing history query")
+	}
+	if !strings.Contains(schema, "type AuditEntry {") {
+		t.Error("Schema missing AuditEntry type")
+	}
+
+	unified := GenerateUnifiedSchema(map[string]*petri.PetriNet{"backlog": model})
+	if !strings.Contains(unified, "backlogHistory(id: ID!): [AuditEntry!]!") {
+		t.Error("Unified schema missing history query")
+	}
+	if strings.Count(unified, "type AuditEntry {") != 1 {
+		t.Error("Unified schema should declare AuditEntry exactly once")
+	}
+}
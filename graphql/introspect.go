@@ -94,7 +94,8 @@ func BuildIntrospection(schema string) map[string]any {
 
 	for _, line := range lines {
 		trimmed := strings.TrimSpace(line)
-		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "scalar JSON" || trimmed == "scalar Time" {
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") ||
+			strings.HasPrefix(trimmed, "scalar ") || strings.HasPrefix(trimmed, "union ") {
 			continue
 		}
 
@@ -111,6 +112,10 @@ func BuildIntrospection(schema string) map[string]any {
 		}
 		if strings.HasPrefix(trimmed, "type ") && strings.HasSuffix(trimmed, "{") {
 			name := strings.TrimSuffix(strings.TrimPrefix(trimmed, "type "), " {")
+			// Drop directives like @key(fields: "id") from federated schemas.
+			if at := strings.Index(name, "@"); at >= 0 {
+				name = name[:at]
+			}
 			name = strings.TrimSpace(name)
 			currentType = map[string]any{
 				"kind":          "OBJECT",
@@ -0,0 +1,17 @@
+package graphql
+
+import (
+	"github.com/pflow-xyz/go-pflow/telemetry"
+)
+
+// tracer emits spans for query execution and store operations. Spans are
+// no-ops until the application installs an OpenTelemetry SDK provider.
+var tracer = telemetry.Tracer("graphql")
+
+// WithLogger plugs a structured logger into the server; failed requests
+// are logged through it. The default logger discards output.
+func WithLogger(logger telemetry.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
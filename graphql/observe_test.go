@@ -0,0 +1,78 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordingLogger captures structured log entries for assertions.
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []string
+}
+
+func (l *recordingLogger) record(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, msg)
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...any) { l.record(msg) }
+func (l *recordingLogger) Info(msg string, _ ...any)  { l.record(msg) }
+func (l *recordingLogger) Warn(msg string, _ ...any)  { l.record(msg) }
+func (l *recordingLogger) Error(msg string, _ ...any) { l.record(msg) }
+
+func TestServerLogsFailedRequests(t *testing.T) {
+	logger := &recordingLogger{}
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	server := NewServer(WithModel("backlog", model, store), WithLogger(logger))
+
+	resp := server.Execute(context.Background(), GraphQLRequest{
+		Query: `{ backlogInstance(id: "missing") { id } }`,
+	})
+	if len(resp.Errors) == 0 {
+		t.Fatal("Expected an error for an unknown instance")
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.entries) != 1 || logger.entries[0] != "request failed" {
+		t.Errorf("Expected one 'request failed' entry, got %v", logger.entries)
+	}
+}
+
+func TestStoreOperationsEmitSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(prev)
+
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := store.Fire(ctx, id, "enqueue", nil); err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+
+	names := make(map[string]bool)
+	for _, span := range recorder.Ended() {
+		names[span.Name()] = true
+	}
+	for _, want := range []string{"store.create", "store.fire"} {
+		if !names[want] {
+			t.Errorf("Missing span %q; got %v", want, names)
+		}
+	}
+}
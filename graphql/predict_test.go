@@ -0,0 +1,107 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestPredictedCompletion(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	resolver := NewModelResolver(model, store).
+		WithLearnedRates(map[string]float64{"enqueue": 1.0})
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	result, err := resolver.Query(ctx, "instance", map[string]any{"id": id})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	inst := result.(*Instance)
+	pred := inst.PredictedCompletion
+	if pred == nil {
+		t.Fatal("Expected predictedCompletion to be populated")
+	}
+	if !pred.Reached {
+		t.Error("Expected the final places to be reachable")
+	}
+	if pred.Seconds <= 0 || pred.Seconds > 3600 {
+		t.Errorf("Implausible completion estimate: %g seconds", pred.Seconds)
+	}
+	// The backlog place is the model's only sink.
+	if len(pred.FinalPlaces) != 1 || pred.FinalPlaces[0] != "backlog" {
+		t.Errorf("Expected default final places [backlog], got %v", pred.FinalPlaces)
+	}
+}
+
+func TestPredictedCompletionRequiresRates(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	resolver := NewModelResolver(model, store)
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	result, err := resolver.Query(ctx, "instance", map[string]any{"id": id})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if inst := result.(*Instance); inst.PredictedCompletion != nil {
+		t.Error("Expected null prediction without learned rates")
+	}
+}
+
+func TestPredictedCompletionExplicitFinalPlaces(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	resolver := NewModelResolver(model, store).
+		WithLearnedRates(map[string]float64{"enqueue": 1.0}, "inbox")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	result, err := resolver.Query(ctx, "instance", map[string]any{"id": id})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	inst := result.(*Instance)
+	if got := inst.PredictedCompletion.FinalPlaces; len(got) != 1 || got[0] != "inbox" {
+		t.Errorf("Expected explicit final places [inbox], got %v", got)
+	}
+	// The inbox starts full, so completion is immediate.
+	if !inst.PredictedCompletion.Reached || inst.PredictedCompletion.Seconds != 0 {
+		t.Errorf("Expected immediate completion, got %+v", inst.PredictedCompletion)
+	}
+}
+
+func TestSchemaDeclaresPredictedCompletion(t *testing.T) {
+	model := newBacklogModel()
+	schema := GenerateSchema(model, "backlog")
+	for _, want := range []string{
+		"predictedCompletion: PredictedCompletion",
+		"type PredictedCompletion {",
+	} {
+		if !strings.Contains(schema, want) {
+			t.Errorf("Schema missing %q", want)
+		}
+	}
+
+	unified := GenerateUnifiedSchema(map[string]*petri.PetriNet{"backlog": model})
+	if strings.Count(unified, "type PredictedCompletion {") != 1 {
+		t.Error("Unified schema should declare PredictedCompletion exactly once")
+	}
+}
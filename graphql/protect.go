@@ -0,0 +1,140 @@
+package graphql
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server hardening for public exposure. The generated API is convenient to
+// DOS by default — deeply nested introspection, unbounded instance lists —
+// so servers that face the internet can whitelist operations, bound query
+// shape, and rate limit clients.
+
+// WithPersistedQueries restricts the server to a whitelist of named
+// operations. Clients send {"id": "<name>", "variables": {...}} instead of
+// a raw query; raw queries — including introspection — are rejected.
+func WithPersistedQueries(queries map[string]string) Option {
+	return func(s *Server) {
+		s.persisted = queries
+	}
+}
+
+// WithQueryLimits rejects queries nesting selections deeper than maxDepth
+// or selecting more than maxComplexity fields in total. A zero disables
+// that limit.
+func WithQueryLimits(maxDepth, maxComplexity int) Option {
+	return func(s *Server) {
+		s.maxDepth = maxDepth
+		s.maxComplexity = maxComplexity
+	}
+}
+
+// WithRateLimit caps HTTP requests per client per minute, keyed by the
+// first X-Forwarded-For hop when present and the remote address otherwise.
+// Only ServeHTTP is limited; direct Execute calls from embedding code are
+// not.
+func WithRateLimit(perMinute int) Option {
+	return func(s *Server) {
+		s.limiter = &rateLimiter{
+			perMinute: perMinute,
+			counts:    make(map[string]int),
+			now:       time.Now,
+		}
+	}
+}
+
+// resolvePersisted swaps a persisted query ID for its whitelisted query
+// text. With a whitelist configured, raw queries are refused outright.
+func (s *Server) resolvePersisted(req *GraphQLRequest) *GraphQLError {
+	if s.persisted == nil {
+		return nil
+	}
+	if req.ID == "" {
+		return &GraphQLError{Message: "this server only accepts persisted queries"}
+	}
+	query, ok := s.persisted[req.ID]
+	if !ok {
+		return &GraphQLError{Message: "unknown persisted query: " + req.ID}
+	}
+	req.Query = query
+	return nil
+}
+
+// checkQueryLimits enforces depth and complexity bounds on a parsed query.
+func (s *Server) checkQueryLimits(parsed *ParsedOperation) *GraphQLError {
+	if s.maxDepth > 0 {
+		if depth := fieldDepth(parsed.Fields); depth > s.maxDepth {
+			return &GraphQLError{Message: fmt.Sprintf("query depth %d exceeds limit %d", depth, s.maxDepth)}
+		}
+	}
+	if s.maxComplexity > 0 {
+		if count := fieldCount(parsed.Fields); count > s.maxComplexity {
+			return &GraphQLError{Message: fmt.Sprintf("query complexity %d exceeds limit %d", count, s.maxComplexity)}
+		}
+	}
+	return nil
+}
+
+// fieldDepth returns the deepest selection nesting; top-level fields are
+// depth 1.
+func fieldDepth(fields []ParsedField) int {
+	depth := 0
+	for _, field := range fields {
+		d := 1 + fieldDepth(field.Selections)
+		if d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
+
+// fieldCount returns the total number of selected fields.
+func fieldCount(fields []ParsedField) int {
+	count := 0
+	for _, field := range fields {
+		count += 1 + fieldCount(field.Selections)
+	}
+	return count
+}
+
+// rateLimiter is a fixed-window per-client request counter.
+type rateLimiter struct {
+	mu        sync.Mutex
+	perMinute int
+	window    time.Time
+	counts    map[string]int
+	now       func() time.Time // injectable for tests
+}
+
+// allow counts a request from the client and reports whether it is within
+// this minute's budget.
+func (l *rateLimiter) allow(client string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	window := l.now().Truncate(time.Minute)
+	if window != l.window {
+		l.window = window
+		l.counts = make(map[string]int)
+	}
+	l.counts[client]++
+	return l.counts[client] <= l.perMinute
+}
+
+// clientAddr identifies the client for rate limiting.
+func clientAddr(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		if comma := strings.Index(fwd, ","); comma >= 0 {
+			fwd = fwd[:comma]
+		}
+		return strings.TrimSpace(fwd)
+	}
+	addr := r.RemoteAddr
+	if colon := strings.LastIndex(addr, ":"); colon >= 0 {
+		addr = addr[:colon]
+	}
+	return addr
+}
@@ -0,0 +1,132 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+func newProtectServer(opts ...Option) *Server {
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	all := append([]Option{WithModel("backlog", model, store)}, opts...)
+	return NewServer(all...)
+}
+
+func TestPersistedQueries(t *testing.T) {
+	server := newProtectServer(WithPersistedQueries(map[string]string{
+		"listInstances": `{ backlogInstances { total } }`,
+	}))
+
+	// Whitelisted ID resolves the stored query.
+	resp := server.Execute(context.Background(), GraphQLRequest{ID: "listInstances"})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Errors: %v", resp.Errors)
+	}
+	if _, ok := resp.Data["backlogInstances"]; !ok {
+		t.Errorf("Expected backlogInstances data, got %v", resp.Data)
+	}
+
+	// Raw queries are refused, including introspection.
+	for _, raw := range []string{
+		`{ backlogInstances { total } }`,
+		`{ __schema { types { name } } }`,
+	} {
+		resp = server.Execute(context.Background(), GraphQLRequest{Query: raw})
+		if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "persisted") {
+			t.Errorf("Expected raw query %q to be refused, got %v", raw, resp)
+		}
+	}
+
+	// Unknown IDs are refused.
+	resp = server.Execute(context.Background(), GraphQLRequest{ID: "nope"})
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "unknown persisted query") {
+		t.Errorf("Expected unknown ID error, got %v", resp)
+	}
+}
+
+func TestQueryDepthLimit(t *testing.T) {
+	server := newProtectServer(WithQueryLimits(2, 0))
+
+	resp := server.Execute(context.Background(), GraphQLRequest{
+		Query: `{ backlogInstances { total } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Depth-2 query should pass: %v", resp.Errors)
+	}
+
+	resp = server.Execute(context.Background(), GraphQLRequest{
+		Query: `{ backlogInstances { items { id } } }`,
+	})
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "depth") {
+		t.Errorf("Expected depth error, got %v", resp)
+	}
+}
+
+func TestQueryComplexityLimit(t *testing.T) {
+	server := newProtectServer(WithQueryLimits(0, 3))
+
+	resp := server.Execute(context.Background(), GraphQLRequest{
+		Query: `{ backlogInstances { total page } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("3-field query should pass: %v", resp.Errors)
+	}
+
+	resp = server.Execute(context.Background(), GraphQLRequest{
+		Query: `{ backlogInstances { total page hasNextPage } }`,
+	})
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "complexity") {
+		t.Errorf("Expected complexity error, got %v", resp)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	server := newProtectServer(WithRateLimit(2))
+	fixed := time.Date(2026, 8, 28, 12, 0, 30, 0, time.UTC)
+	server.limiter.now = func() time.Time { return fixed }
+
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	post := func(forwardedFor string) int {
+		body, _ := json.Marshal(GraphQLRequest{Query: `{ backlogInstances { total } }`})
+		req, _ := http.NewRequest(http.MethodPost, ts.URL, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("POST: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := post("10.0.0.1"); code != http.StatusOK {
+			t.Fatalf("Request %d: expected 200, got %d", i+1, code)
+		}
+	}
+	if code := post("10.0.0.1"); code != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 for the third request, got %d", code)
+	}
+	// Other clients have their own budget.
+	if code := post("10.0.0.2"); code != http.StatusOK {
+		t.Errorf("Expected 200 for a different client, got %d", code)
+	}
+
+	// The next minute resets the window.
+	fixed = fixed.Add(time.Minute)
+	if code := post("10.0.0.1"); code != http.StatusOK {
+		t.Errorf("Expected 200 after window reset, got %d", code)
+	}
+}
@@ -0,0 +1,188 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/parser"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// StoreFactory creates the instance store for a model loaded at runtime.
+// Back it with a shared eventsource store to keep instances durable across
+// reloads.
+type StoreFactory func(name string, model *petri.PetriNet) Store
+
+// ModelRegistry loads model definitions from a directory into a running
+// server. Files named <model>.json register under their base name; files in
+// a subdirectory register under "<tenant>_<model>", which namespaces every
+// tenant's types, mutations, and instances in the generated schema. LoadDir
+// is incremental: new files register, changed files re-register, and
+// removed files unregister — each pass regenerates the schema without a
+// restart.
+type ModelRegistry struct {
+	server   *Server
+	newStore StoreFactory
+
+	mu      sync.Mutex
+	loaded  map[string]loadedModel
+	onError func(path string, err error)
+}
+
+type loadedModel struct {
+	path    string
+	modTime time.Time
+}
+
+// NewModelRegistry creates a registry that feeds the given server.
+func NewModelRegistry(server *Server, newStore StoreFactory) *ModelRegistry {
+	return &ModelRegistry{
+		server:   server,
+		newStore: newStore,
+		loaded:   make(map[string]loadedModel),
+	}
+}
+
+// OnError installs a handler for per-file load failures; without one a bad
+// file is skipped silently so one broken tenant cannot block the rest.
+func (r *ModelRegistry) OnError(fn func(path string, err error)) *ModelRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onError = fn
+	return r
+}
+
+// LoadDir runs one load pass over dir and applies additions, updates, and
+// removals to the server. It returns the number of models now registered
+// from the directory.
+func (r *ModelRegistry) LoadDir(dir string) (int, error) {
+	found, err := scanModelDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, file := range found {
+		previous, ok := r.loaded[name]
+		if ok && previous.modTime.Equal(file.modTime) {
+			continue
+		}
+		if err := r.loadLocked(name, file); err != nil {
+			r.reportLocked(file.path, err)
+			continue
+		}
+		r.loaded[name] = file
+	}
+
+	// Unregister models whose files are gone.
+	for name := range r.loaded {
+		if _, ok := found[name]; !ok {
+			r.server.RemoveModel(name)
+			delete(r.loaded, name)
+		}
+	}
+
+	return len(r.loaded), nil
+}
+
+// Watch polls dir until the context is cancelled, applying a load pass at
+// every interval. Scan errors (e.g. the directory disappearing) go to the
+// error handler.
+func (r *ModelRegistry) Watch(ctx context.Context, dir string, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := r.LoadDir(dir); err != nil {
+					r.mu.Lock()
+					r.reportLocked(dir, err)
+					r.mu.Unlock()
+				}
+			}
+		}
+	}()
+}
+
+// loadLocked parses one model file and registers it on the server.
+func (r *ModelRegistry) loadLocked(name string, file loadedModel) error {
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return err
+	}
+	model, err := parser.FromJSON(data)
+	if err != nil {
+		return fmt.Errorf("parse model: %w", err)
+	}
+	r.server.AddModel(name, model, r.newStore(name, model))
+	return nil
+}
+
+func (r *ModelRegistry) reportLocked(path string, err error) {
+	if r.onError != nil {
+		r.onError(path, err)
+	}
+}
+
+// scanModelDir finds model files: *.json at the top level plus one level of
+// tenant subdirectories.
+func scanModelDir(dir string) (map[string]loadedModel, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]loadedModel)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			tenant := entry.Name()
+			subEntries, err := os.ReadDir(filepath.Join(dir, tenant))
+			if err != nil {
+				return nil, err
+			}
+			for _, sub := range subEntries {
+				if name, ok := modelFileName(sub.Name()); ok && !sub.IsDir() {
+					info, err := sub.Info()
+					if err != nil {
+						return nil, err
+					}
+					found[tenant+"_"+name] = loadedModel{
+						path:    filepath.Join(dir, tenant, sub.Name()),
+						modTime: info.ModTime(),
+					}
+				}
+			}
+			continue
+		}
+		if name, ok := modelFileName(entry.Name()); ok {
+			info, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+			found[name] = loadedModel{
+				path:    filepath.Join(dir, entry.Name()),
+				modTime: info.ModTime(),
+			}
+		}
+	}
+	return found, nil
+}
+
+// modelFileName extracts the model name from a file name, accepting only
+// .json definitions.
+func modelFileName(filename string) (string, bool) {
+	if !strings.HasSuffix(filename, ".json") {
+		return "", false
+	}
+	return strings.TrimSuffix(filename, ".json"), true
+}
@@ -0,0 +1,167 @@
+package graphql
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/parser"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func writeModelFile(t *testing.T, path string) {
+	t.Helper()
+	data, err := parser.ToJSON(newBacklogModel())
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func memoryStoreFactory(t *testing.T) StoreFactory {
+	return func(name string, model *petri.PetriNet) Store {
+		return NewEventSourceStore(eventsource.NewMemoryStore(), model, name)
+	}
+}
+
+func TestRegistryLoadsDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeModelFile(t, filepath.Join(dir, "orders.json"))
+	writeModelFile(t, filepath.Join(dir, "acme", "billing.json"))
+
+	server := NewServer()
+	registry := NewModelRegistry(server, memoryStoreFactory(t))
+
+	count, err := registry.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("Expected 2 models, got %d", count)
+	}
+	names := server.ModelNames()
+	if len(names) != 2 || names[0] != "acme_billing" || names[1] != "orders" {
+		t.Errorf("Models: %v", names)
+	}
+
+	// The regenerated schema serves the tenant-namespaced model.
+	if !strings.Contains(server.Schema(), "acme_billing_create") {
+		t.Error("Schema missing tenant mutation")
+	}
+	resp := server.Execute(ctx, GraphQLRequest{Query: `mutation { acme_billing_create { id } }`})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Mutation on loaded model: %v", resp.Errors)
+	}
+	if resp.Data["acme_billing_create"] == nil {
+		t.Error("Create on loaded model returned nothing")
+	}
+}
+
+func TestRegistryHotReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orders.json")
+	writeModelFile(t, path)
+
+	server := NewServer()
+	registry := NewModelRegistry(server, memoryStoreFactory(t))
+	if _, err := registry.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+
+	// An unchanged pass is a no-op.
+	if _, err := registry.LoadDir(dir); err != nil {
+		t.Fatalf("Second LoadDir: %v", err)
+	}
+
+	// A new file appears without restart.
+	writeModelFile(t, filepath.Join(dir, "shipping.json"))
+	if _, err := registry.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir after add: %v", err)
+	}
+	if len(server.ModelNames()) != 2 {
+		t.Errorf("Models after add: %v", server.ModelNames())
+	}
+	if !strings.Contains(server.Schema(), "shippingInstance") {
+		t.Error("Schema not regenerated after add")
+	}
+
+	// A removed file unregisters its model.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := registry.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir after remove: %v", err)
+	}
+	names := server.ModelNames()
+	if len(names) != 1 || names[0] != "shipping" {
+		t.Errorf("Models after remove: %v", names)
+	}
+	if strings.Contains(server.Schema(), "ordersInstance") {
+		t.Error("Schema still lists removed model")
+	}
+}
+
+func TestRegistryReloadsChangedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "orders.json")
+	writeModelFile(t, path)
+
+	server := NewServer()
+	factoryCalls := 0
+	registry := NewModelRegistry(server, func(name string, model *petri.PetriNet) Store {
+		factoryCalls++
+		return NewEventSourceStore(eventsource.NewMemoryStore(), model, name)
+	})
+	if _, err := registry.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+
+	// Touch the file into the future so the change is visible regardless
+	// of filesystem timestamp granularity.
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+	if _, err := registry.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir after change: %v", err)
+	}
+	if factoryCalls != 2 {
+		t.Errorf("Expected the changed model to be re-registered, factory calls: %d", factoryCalls)
+	}
+}
+
+func TestRegistrySkipsBrokenFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeModelFile(t, filepath.Join(dir, "good.json"))
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{nope"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	server := NewServer()
+	var failed []string
+	registry := NewModelRegistry(server, memoryStoreFactory(t)).
+		OnError(func(path string, err error) {
+			failed = append(failed, filepath.Base(path))
+		})
+
+	count, err := registry.LoadDir(dir)
+	if err != nil {
+		t.Fatalf("LoadDir: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected the good model to load, got %d", count)
+	}
+	if len(failed) != 1 || failed[0] != "bad.json" {
+		t.Errorf("Expected bad.json reported, got %v", failed)
+	}
+}
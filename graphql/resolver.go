@@ -24,6 +24,11 @@ type Resolver interface {
 type ModelResolver struct {
 	model *petri.PetriNet
 	store Store
+
+	// Learned rates and completion places for predictedCompletion
+	// (see WithLearnedRates); nil means the field resolves to null.
+	rates       map[string]float64
+	finalPlaces []string
 }
 
 // Store provides persistence for Petri net instances.
@@ -54,6 +59,10 @@ type Instance struct {
 	Marking            map[string]int `json:"marking"`
 	State              map[string]any `json:"state,omitempty"`
 	EnabledTransitions []string       `json:"enabledTransitions"`
+
+	// Simulation-based completion estimate; populated on single-instance
+	// reads for models registered with learned rates.
+	PredictedCompletion *PredictedCompletion `json:"predictedCompletion,omitempty"`
 }
 
 // InstanceFilter defines criteria for listing instances.
@@ -62,6 +71,8 @@ type InstanceFilter struct {
 	Place     string // Filter by place with tokens > 0
 	Page      int
 	PerPage   int
+	After     string // Cursor: return instances after this ID (replaces Page)
+	OrderBy   string // "id" (default) or "version"
 }
 
 // NewModelResolver creates a resolver for the given model.
@@ -77,11 +88,23 @@ func (r *ModelResolver) Query(ctx context.Context, name string, args map[string]
 	switch name {
 	case "instance":
 		id, _ := args["id"].(string)
-		return r.store.Get(ctx, id)
+		inst, err := r.store.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		r.attachPrediction(inst)
+		return inst, nil
+
+	case "history":
+		return r.resolveHistory(ctx, args)
+
+	case "simulate":
+		return r.resolveSimulate(ctx, args)
 
 	case "instances":
-		filter := InstanceFilter{
-			ModelName: r.model.Token[0], // Use first token as model name for now
+		filter := InstanceFilter{}
+		if len(r.model.Token) > 0 {
+			filter.ModelName = r.model.Token[0] // Use first token as model name for now
 		}
 		if place, ok := args["place"].(string); ok {
 			filter.Place = place
@@ -92,15 +115,13 @@ func (r *ModelResolver) Query(ctx context.Context, name string, args map[string]
 		if perPage, ok := args["perPage"].(int); ok {
 			filter.PerPage = perPage
 		}
-		instances, total, err := r.store.List(ctx, filter)
-		if err != nil {
-			return nil, err
+		if after, ok := args["after"].(string); ok {
+			filter.After = after
+		}
+		if orderBy, ok := args["orderBy"].(string); ok {
+			filter.OrderBy = orderBy
 		}
-		return map[string]any{
-			"items": instances,
-			"total": total,
-			"page":  filter.Page,
-		}, nil
+		return r.listInstances(ctx, filter)
 
 	default:
 		return nil, nil
@@ -121,7 +142,15 @@ func (r *ModelResolver) Mutate(ctx context.Context, name string, args map[string
 		}
 		return r.store.Get(ctx, id)
 
+	case "createMany":
+		return r.createMany(ctx, args)
+
 	default:
+		// Bulk variant: <transition>Many fires across a set of instances.
+		if base, ok := r.bulkTransition(name); ok {
+			return r.fireMany(ctx, base, args)
+		}
+
 		// Assume it's a transition name
 		input, _ := args["input"].(map[string]any)
 		id, _ := input["instanceId"].(string)
@@ -0,0 +1,335 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SchedulePolicy declares that a transition fires automatically: either a
+// fixed delay after it becomes enabled, or whenever a cron schedule matches
+// while it is enabled. Exactly one of Delay or Cron is set.
+type SchedulePolicy struct {
+	Model      string
+	Transition string
+	Delay      time.Duration
+	Cron       string
+}
+
+// Scheduler fires time-based transitions on the server's instances. It
+// polls rather than keeping per-instance timers: due times are recomputed
+// from each instance's event history on every pass, so a restarted server
+// picks overdue timers straight back up without any timer storage.
+type Scheduler struct {
+	mu       sync.Mutex
+	policies []SchedulePolicy
+	crons    map[int]cronSpec // index into policies
+	stores   map[string]Store
+	poll     time.Duration
+	onError  func(model, instanceID, transition string, err error)
+
+	// lastCron dedups cron firings to one per matching minute.
+	lastCron map[string]time.Time
+
+	// firstSeen backs delay timers for stores without history; it is not
+	// durable across restarts.
+	firstSeen map[string]time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewScheduler creates a scheduler with a 1-second poll interval.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		crons:     make(map[int]cronSpec),
+		stores:    make(map[string]Store),
+		poll:      time.Second,
+		lastCron:  make(map[string]time.Time),
+		firstSeen: make(map[string]time.Time),
+	}
+}
+
+// After declares that transition fires delay after it becomes enabled.
+// With an event-sourced store the delay is measured from the last marking
+// change, recomputed from history on every pass, so it survives restarts.
+func (s *Scheduler) After(model, transition string, delay time.Duration) *Scheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policies = append(s.policies, SchedulePolicy{Model: model, Transition: transition, Delay: delay})
+	return s
+}
+
+// Cron declares that transition fires whenever the five-field cron spec
+// (minute hour day-of-month month day-of-week) matches and the transition
+// is enabled, at most once per matching minute per instance.
+func (s *Scheduler) Cron(model, transition, spec string) *Scheduler {
+	parsed, err := parseCron(spec)
+	if err != nil {
+		panic(fmt.Sprintf("graphql: invalid cron spec %q: %v", spec, err))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policies = append(s.policies, SchedulePolicy{Model: model, Transition: transition, Cron: spec})
+	s.crons[len(s.policies)-1] = parsed
+	return s
+}
+
+// Poll overrides the poll interval; mainly useful in tests.
+func (s *Scheduler) Poll(interval time.Duration) *Scheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.poll = interval
+	return s
+}
+
+// OnError installs a handler for failed scheduled firings (e.g. a race
+// where the transition was disabled between the scan and the fire).
+func (s *Scheduler) OnError(fn func(model, instanceID, transition string, err error)) *Scheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onError = fn
+	return s
+}
+
+// Attach registers a model's store with the scheduler. WithScheduler does
+// this for every model on the server.
+func (s *Scheduler) Attach(model string, store Store) *Scheduler {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stores[model] = store
+	return s
+}
+
+// Start begins polling in the background until Stop or context cancel.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.mu.Lock()
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	poll := s.poll
+	s.mu.Unlock()
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(poll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.Tick(ctx)
+			}
+		}
+	}()
+}
+
+// Stop halts polling and waits for the current pass to finish.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+}
+
+// Tick runs one scheduling pass: scan every policy's instances and fire
+// whatever is due. Exported so servers with their own run loops can drive
+// the scheduler without Start.
+func (s *Scheduler) Tick(ctx context.Context) {
+	s.mu.Lock()
+	policies := append([]SchedulePolicy(nil), s.policies...)
+	s.mu.Unlock()
+
+	now := time.Now()
+	for i, policy := range policies {
+		store := s.storeFor(policy.Model)
+		if store == nil {
+			continue
+		}
+		instances, _, err := store.List(ctx, InstanceFilter{PerPage: 1 << 30})
+		if err != nil {
+			continue
+		}
+		for _, inst := range instances {
+			if !contains(inst.EnabledTransitions, policy.Transition) {
+				continue
+			}
+			if s.due(ctx, store, inst, i, policy, now) {
+				if _, err := store.Fire(ctx, inst.ID, policy.Transition, nil); err != nil {
+					s.reportError(policy.Model, inst.ID, policy.Transition, err)
+				}
+			}
+		}
+	}
+}
+
+// due decides whether a policy should fire on an instance now.
+func (s *Scheduler) due(ctx context.Context, store Store, inst *Instance, policyIdx int, policy SchedulePolicy, now time.Time) bool {
+	if policy.Cron != "" {
+		s.mu.Lock()
+		spec := s.crons[policyIdx]
+		s.mu.Unlock()
+		if !spec.matches(now) {
+			return false
+		}
+		key := fmt.Sprintf("%s|%s|%s", policy.Model, inst.ID, policy.Transition)
+		minute := now.Truncate(time.Minute)
+		s.mu.Lock()
+		already := s.lastCron[key].Equal(minute)
+		if !already {
+			s.lastCron[key] = minute
+		}
+		s.mu.Unlock()
+		return !already
+	}
+
+	return now.Sub(s.enabledSince(ctx, store, inst, policy)) >= policy.Delay
+}
+
+// enabledSince reports when the instance's marking last changed, which is
+// when the transition (enabled now) became enabled. History-capable stores
+// make this durable; others fall back to when the scheduler first saw the
+// instance.
+func (s *Scheduler) enabledSince(ctx context.Context, store Store, inst *Instance, policy SchedulePolicy) time.Time {
+	if source, ok := store.(HistorySource); ok {
+		if entries, err := source.History(ctx, inst.ID); err == nil && len(entries) > 0 {
+			return entries[len(entries)-1].Timestamp
+		}
+	}
+
+	key := fmt.Sprintf("%s|%s|%d", policy.Model, inst.ID, inst.Version)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen, ok := s.firstSeen[key]
+	if !ok {
+		seen = time.Now()
+		s.firstSeen[key] = seen
+	}
+	return seen
+}
+
+func (s *Scheduler) storeFor(model string) Store {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stores[model]
+}
+
+func (s *Scheduler) reportError(model, instanceID, transition string, err error) {
+	s.mu.Lock()
+	onError := s.onError
+	s.mu.Unlock()
+	if onError != nil {
+		onError(model, instanceID, transition, err)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// WithScheduler attaches every model store on the server to the scheduler.
+// Stores are attached after trigger wrapping, so scheduled firings dispatch
+// triggers like any other firing. The caller still owns Start/Stop.
+func WithScheduler(scheduler *Scheduler) Option {
+	return func(s *Server) {
+		s.scheduler = scheduler
+	}
+}
+
+// cronSpec is a parsed five-field cron expression. Each field is the set
+// of matching values.
+type cronSpec struct {
+	minute, hour, dom, month, dow map[int]bool
+}
+
+// matches reports whether the spec matches t at minute granularity. Like
+// classic cron, day-of-month and day-of-week are OR'd when both are
+// restricted.
+func (c cronSpec) matches(t time.Time) bool {
+	if !c.minute[t.Minute()] || !c.hour[t.Hour()] || !c.month[int(t.Month())] {
+		return false
+	}
+	domOk := c.dom[t.Day()]
+	dowOk := c.dow[int(t.Weekday())]
+	domAll := len(c.dom) == 31
+	dowAll := len(c.dow) == 7
+	if !domAll && !dowAll {
+		return domOk || dowOk
+	}
+	return domOk && dowOk
+}
+
+// parseCron parses a five-field cron spec supporting *, */step, values,
+// ranges, and comma lists.
+func parseCron(spec string) (cronSpec, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return cronSpec{}, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 6}}
+	sets := make([]map[int]bool, 5)
+	for i, field := range fields {
+		set, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return cronSpec{}, fmt.Errorf("field %d: %w", i+1, err)
+		}
+		sets[i] = set
+	}
+	return cronSpec{minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4]}, nil
+}
+
+// parseCronField expands one cron field into its set of matching values.
+func parseCronField(field string, min, max int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			n, err := strconv.Atoi(part[idx+1:])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("bad step in %q", part)
+			}
+			step = n
+			part = part[:idx]
+		}
+
+		lo, hi := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("bad range %q", part)
+			}
+		default:
+			n, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, fmt.Errorf("bad value %q", part)
+			}
+			lo, hi = n, n
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return nil, fmt.Errorf("value out of range in %q", part)
+		}
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
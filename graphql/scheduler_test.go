@@ -0,0 +1,177 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+// waitFor polls until the condition holds or the deadline passes.
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestSchedulerFiresAfterDelay(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	scheduler := NewScheduler().
+		After("backlog", "enqueue", 50*time.Millisecond).
+		Poll(10*time.Millisecond).
+		Attach("backlog", store)
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	scheduler.Start(ctx)
+	defer scheduler.Stop()
+
+	fired := waitFor(t, 2*time.Second, func() bool {
+		inst, err := store.Get(ctx, id)
+		return err == nil && inst.Marking["backlog"] >= 1
+	})
+	if !fired {
+		t.Fatal("Scheduled transition never fired")
+	}
+
+	// The timer must not fire before the delay: immediately after a firing
+	// the next one is at least the delay away.
+	inst, _ := store.Get(ctx, id)
+	count := inst.Marking["backlog"]
+	time.Sleep(20 * time.Millisecond)
+	inst, _ = store.Get(ctx, id)
+	if inst.Marking["backlog"] > count+1 {
+		t.Errorf("Transition fired faster than the delay: %v", inst.Marking)
+	}
+}
+
+func TestSchedulerRecoversOverdueTimers(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	// The instance was created before the scheduler existed; its delay is
+	// already overdue, as after a server restart.
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	scheduler := NewScheduler().
+		After("backlog", "enqueue", 20*time.Millisecond).
+		Attach("backlog", store)
+	scheduler.Tick(ctx)
+
+	inst, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if inst.Marking["backlog"] != 1 {
+		t.Errorf("Overdue timer should fire on the first pass: %v", inst.Marking)
+	}
+}
+
+func TestSchedulerCronFiresOncePerMinute(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	scheduler := NewScheduler().
+		Cron("backlog", "enqueue", "* * * * *").
+		Attach("backlog", store)
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Several passes within the same minute fire exactly once.
+	for i := 0; i < 3; i++ {
+		scheduler.Tick(ctx)
+	}
+	inst, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if inst.Marking["backlog"] != 1 {
+		t.Errorf("Cron should fire once per matching minute, marking: %v", inst.Marking)
+	}
+}
+
+func TestSchedulerViaServerOption(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	var events []FiringEvent
+	registry := NewTriggerRegistry().
+		On("backlog", "enqueue", func(ctx context.Context, event FiringEvent) error {
+			events = append(events, event)
+			return nil
+		})
+	scheduler := NewScheduler().After("backlog", "enqueue", 0)
+
+	NewServer(
+		WithModel("backlog", model, store),
+		WithTriggers(registry),
+		WithScheduler(scheduler),
+	)
+
+	if _, err := store.Create(ctx, "backlog"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	scheduler.Tick(ctx)
+
+	// Scheduled firings go through the trigger-wrapped store.
+	if len(events) != 1 {
+		t.Errorf("Expected scheduled firing to dispatch triggers, got %d events", len(events))
+	}
+}
+
+func TestParseCron(t *testing.T) {
+	cases := []struct {
+		spec string
+		time time.Time
+		want bool
+	}{
+		{"* * * * *", time.Date(2026, 8, 28, 10, 30, 0, 0, time.UTC), true},
+		{"30 10 * * *", time.Date(2026, 8, 28, 10, 30, 0, 0, time.UTC), true},
+		{"0 * * * *", time.Date(2026, 8, 28, 10, 30, 0, 0, time.UTC), false},
+		{"*/15 * * * *", time.Date(2026, 8, 28, 10, 30, 0, 0, time.UTC), true},
+		{"*/15 * * * *", time.Date(2026, 8, 28, 10, 20, 0, 0, time.UTC), false},
+		{"0 9-17 * * *", time.Date(2026, 8, 28, 13, 0, 0, 0, time.UTC), true},
+		{"0 9-17 * * *", time.Date(2026, 8, 28, 20, 0, 0, 0, time.UTC), false},
+		// 2026-08-28 is a Friday (weekday 5).
+		{"* * * * 5", time.Date(2026, 8, 28, 10, 0, 0, 0, time.UTC), true},
+		{"* * * * 0,6", time.Date(2026, 8, 28, 10, 0, 0, 0, time.UTC), false},
+	}
+	for _, tc := range cases {
+		spec, err := parseCron(tc.spec)
+		if err != nil {
+			t.Fatalf("parseCron(%q): %v", tc.spec, err)
+		}
+		if got := spec.matches(tc.time); got != tc.want {
+			t.Errorf("%q at %v: got %v, want %v", tc.spec, tc.time, got, tc.want)
+		}
+	}
+
+	for _, bad := range []string{"", "* * * *", "60 * * * *", "a * * * *", "*/0 * * * *"} {
+		if _, err := parseCron(bad); err == nil {
+			t.Errorf("parseCron(%q): expected error", bad)
+		}
+	}
+}
@@ -30,8 +30,20 @@ func GenerateSchema(model *petri.PetriNet, name string) string {
 	sb.WriteString("  # Get instance by ID\n")
 	sb.WriteString("  instance(id: ID!): Instance\n")
 	sb.WriteString("\n")
-	sb.WriteString("  # List instances with optional filtering\n")
-	sb.WriteString("  instances(place: String, page: Int, perPage: Int): InstanceList!\n")
+	sb.WriteString("  # List instances with optional filtering; use after for cursor pagination\n")
+	sb.WriteString("  instances(place: String, page: Int, perPage: Int, after: String, orderBy: String): InstanceList!\n")
+	sb.WriteString("\n")
+	sb.WriteString("  # Audit trail: every fired transition with marking before/after\n")
+	sb.WriteString("  history(id: ID!): [AuditEntry!]!\n")
+	sb.WriteString("\n")
+	sb.WriteString("  # Server-side ODE simulation with rate/marking overrides\n")
+	sb.WriteString("  simulate(tStart: Float, tEnd: Float!, samples: Int, rates: JSON, initial: JSON): SimulationResult!\n")
+	sb.WriteString("\n")
+	sb.WriteString("  # Structural verification: boundedness, deadlocks, liveness, invariants\n")
+	sb.WriteString("  modelAnalysis(name: String!): ModelAnalysis!\n")
+	sb.WriteString("\n")
+	sb.WriteString("  # Structural changes between two registered model versions\n")
+	sb.WriteString("  modelDiff(from: String!, to: String!): ModelDiff!\n")
 	sb.WriteString("}\n\n")
 
 	// Mutation type
@@ -39,14 +51,21 @@ func GenerateSchema(model *petri.PetriNet, name string) string {
 	sb.WriteString("  # Create a new instance\n")
 	sb.WriteString("  create: Instance!\n")
 	sb.WriteString("\n")
+	sb.WriteString("  # Create count instances in one call\n")
+	sb.WriteString("  createMany(count: Int!): [Instance!]!\n")
+	sb.WriteString("\n")
 
-	// Add mutation for each transition
+	// Add mutations for each transition
 	transitions := sortedTransitions(model)
 	for _, t := range transitions {
 		sb.WriteString(fmt.Sprintf("  # Fire transition: %s\n", t.Label))
 		inputName := toPascalCase(t.Label) + "Input"
 		sb.WriteString(fmt.Sprintf("  %s(input: %s!): TransitionResult!\n", t.Label, inputName))
 		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("  # Fire %s across a set of instances\n", t.Label))
+		manyInput := toPascalCase(t.Label) + "ManyInput"
+		sb.WriteString(fmt.Sprintf("  %sMany(input: %s!): BulkTransitionResult!\n", t.Label, manyInput))
+		sb.WriteString("\n")
 	}
 	sb.WriteString("}\n\n")
 
@@ -59,6 +78,8 @@ func GenerateSchema(model *petri.PetriNet, name string) string {
 	sb.WriteString("  marking: Marking!\n")
 	sb.WriteString("  state: JSON\n")
 	sb.WriteString("  enabledTransitions: [String!]!\n")
+	sb.WriteString("  # Simulation-based completion estimate (models with learned rates)\n")
+	sb.WriteString("  predictedCompletion: PredictedCompletion\n")
 	sb.WriteString("}\n\n")
 
 	// Marking type (place -> token count)
@@ -85,16 +106,56 @@ func GenerateSchema(model *petri.PetriNet, name string) string {
 	sb.WriteString("  items: [Instance!]!\n")
 	sb.WriteString("  total: Int!\n")
 	sb.WriteString("  page: Int!\n")
+	sb.WriteString("  endCursor: String\n")
+	sb.WriteString("  hasNextPage: Boolean!\n")
 	sb.WriteString("}\n\n")
 
+	// BulkTransitionResult type
+	sb.WriteString(bulkResultSchema)
+	sb.WriteString("\n\n")
+
+	// SimulationResult type
+	sb.WriteString(simulationResultSchema)
+	sb.WriteString("\n\n")
+
+	// PredictedCompletion type
+	sb.WriteString(predictedCompletionSchema)
+	sb.WriteString("\n\n")
+
+	// AuditEntry type
+	sb.WriteString(auditEntrySchema)
+	sb.WriteString("\n\n")
+
+	// ModelAnalysis type
+	sb.WriteString(modelAnalysisSchema)
+	sb.WriteString("\n\n")
+
+	// ModelDiff type
+	sb.WriteString(modelDiffSchema)
+	sb.WriteString("\n\n")
+
 	// Input types for each transition
 	sb.WriteString("# Input types for mutations\n\n")
 	for _, t := range transitions {
 		inputName := toPascalCase(t.Label) + "Input"
 		sb.WriteString(fmt.Sprintf("input %s {\n", inputName))
 		sb.WriteString("  instanceId: ID!\n")
+		sb.WriteString("  # Fail unless the instance is at exactly this version\n")
+		sb.WriteString("  expectedVersion: Int\n")
+		sb.WriteString("  # Skip the firing if a retry with this key already succeeded\n")
+		sb.WriteString("  idempotencyKey: String\n")
 		// TODO: Add binding fields based on transition definition
 		sb.WriteString("}\n\n")
+
+		manyInput := toPascalCase(t.Label) + "ManyInput"
+		sb.WriteString(fmt.Sprintf("input %s {\n", manyInput))
+		sb.WriteString("  # Explicit targets; omit to select by place filter\n")
+		sb.WriteString("  ids: [ID!]\n")
+		sb.WriteString("  # Target instances with tokens in this place\n")
+		sb.WriteString("  place: String\n")
+		sb.WriteString("  # Cap on filtered targets (default 100)\n")
+		sb.WriteString("  limit: Int\n")
+		sb.WriteString("}\n\n")
 	}
 
 	return sb.String()
@@ -128,19 +189,24 @@ func GenerateUnifiedSchema(models map[string]*petri.PetriNet) string {
 		// Query fields
 		allQueries = append(allQueries,
 			fmt.Sprintf("  %sInstance(id: ID!): %sInstance", lowerPrefix, prefix),
-			fmt.Sprintf("  %sInstances(place: String, page: Int, perPage: Int): %sInstanceList!", lowerPrefix, prefix),
+			fmt.Sprintf("  %sInstances(place: String, page: Int, perPage: Int, after: String, orderBy: String): %sInstanceList!", lowerPrefix, prefix),
+			fmt.Sprintf("  %sHistory(id: ID!): [AuditEntry!]!", lowerPrefix),
+			fmt.Sprintf("  %sSimulate(tStart: Float, tEnd: Float!, samples: Int, rates: JSON, initial: JSON): SimulationResult!", lowerPrefix),
 		)
 
 		// Mutation fields
 		allMutations = append(allMutations,
 			fmt.Sprintf("  %s_create: %sInstance!", lowerPrefix, prefix),
+			fmt.Sprintf("  %s_createMany(count: Int!): [%sInstance!]!", lowerPrefix, prefix),
 		)
 
 		transitions := sortedTransitions(model)
 		for _, t := range transitions {
 			inputName := prefix + toPascalCase(t.Label) + "Input"
+			manyInput := prefix + toPascalCase(t.Label) + "ManyInput"
 			allMutations = append(allMutations,
 				fmt.Sprintf("  %s_%s(input: %s!): %sTransitionResult!", lowerPrefix, t.Label, inputName, prefix),
+				fmt.Sprintf("  %s_%sMany(input: %s!): %sBulkTransitionResult!", lowerPrefix, t.Label, manyInput, prefix),
 			)
 		}
 
@@ -155,6 +221,7 @@ func GenerateUnifiedSchema(models map[string]*petri.PetriNet) string {
 		typeBuf.WriteString(fmt.Sprintf("  marking: %sMarking!\n", prefix))
 		typeBuf.WriteString("  state: JSON\n")
 		typeBuf.WriteString("  enabledTransitions: [String!]!\n")
+		typeBuf.WriteString("  predictedCompletion: PredictedCompletion\n")
 		typeBuf.WriteString("}")
 		allTypes = append(allTypes, typeBuf.String())
 		typeBuf.Reset()
@@ -184,6 +251,19 @@ func GenerateUnifiedSchema(models map[string]*petri.PetriNet) string {
 		typeBuf.WriteString(fmt.Sprintf("  items: [%sInstance!]!\n", prefix))
 		typeBuf.WriteString("  total: Int!\n")
 		typeBuf.WriteString("  page: Int!\n")
+		typeBuf.WriteString("  endCursor: String\n")
+		typeBuf.WriteString("  hasNextPage: Boolean!\n")
+		typeBuf.WriteString("}")
+		allTypes = append(allTypes, typeBuf.String())
+		typeBuf.Reset()
+
+		// BulkTransitionResult type
+		typeBuf.WriteString(fmt.Sprintf("type %sBulkTransitionResult {\n", prefix))
+		typeBuf.WriteString("  attempted: Int!\n")
+		typeBuf.WriteString("  succeeded: Int!\n")
+		typeBuf.WriteString("  failed: Int!\n")
+		typeBuf.WriteString(fmt.Sprintf("  instances: [%sInstance!]!\n", prefix))
+		typeBuf.WriteString("  errors: [String!]!\n")
 		typeBuf.WriteString("}")
 		allTypes = append(allTypes, typeBuf.String())
 		typeBuf.Reset()
@@ -193,12 +273,33 @@ func GenerateUnifiedSchema(models map[string]*petri.PetriNet) string {
 			inputName := prefix + toPascalCase(t.Label) + "Input"
 			typeBuf.WriteString(fmt.Sprintf("input %s {\n", inputName))
 			typeBuf.WriteString("  instanceId: ID!\n")
+			typeBuf.WriteString("  expectedVersion: Int\n")
+			typeBuf.WriteString("  idempotencyKey: String\n")
+			typeBuf.WriteString("}")
+			allTypes = append(allTypes, typeBuf.String())
+			typeBuf.Reset()
+
+			manyInput := prefix + toPascalCase(t.Label) + "ManyInput"
+			typeBuf.WriteString(fmt.Sprintf("input %s {\n", manyInput))
+			typeBuf.WriteString("  ids: [ID!]\n")
+			typeBuf.WriteString("  place: String\n")
+			typeBuf.WriteString("  limit: Int\n")
 			typeBuf.WriteString("}")
 			allTypes = append(allTypes, typeBuf.String())
 			typeBuf.Reset()
 		}
 	}
 
+	// Server-level queries that dispatch on the model name argument
+	allQueries = append(allQueries,
+		"  modelAnalysis(name: String!): ModelAnalysis!",
+		"  modelDiff(from: String!, to: String!): ModelDiff!",
+	)
+
+	// Shared audit trail, simulation, and analysis types (payloads are JSON
+	// so one declaration serves all models)
+	allTypes = append(allTypes, auditEntrySchema, simulationResultSchema, predictedCompletionSchema, modelAnalysisSchema, modelDiffSchema)
+
 	// Write Query type
 	sb.WriteString("type Query {\n")
 	for _, q := range allQueries {
@@ -221,6 +322,84 @@ func GenerateUnifiedSchema(models map[string]*petri.PetriNet) string {
 	return sb.String()
 }
 
+// bulkResultSchema is the SDL for bulk firing results; one type serves all
+// transitions and models.
+const bulkResultSchema = `# Outcome of a bulk firing; partial success is expected
+type BulkTransitionResult {
+  attempted: Int!
+  succeeded: Int!
+  failed: Int!
+  instances: [Instance!]!
+  errors: [String!]!
+}`
+
+// simulationResultSchema is the SDL for sampled simulation trajectories.
+const simulationResultSchema = `# Sampled trajectory from a server-side simulation
+type SimulationResult {
+  t: [Float!]!
+  states: JSON!
+  finalState: JSON!
+}`
+
+// predictedCompletionSchema is the SDL for simulation-based completion
+// estimates on instances of models with learned rates.
+const predictedCompletionSchema = `# Expected time to reach the model's final places
+type PredictedCompletion {
+  seconds: Float!
+  reached: Boolean!
+  confidence: Float!
+  finalPlaces: [String!]!
+}`
+
+// modelAnalysisSchema is the SDL for verification results. Computed lazily
+// on first query and cached until the model set changes.
+const modelAnalysisSchema = `# Structural verification report from reachability analysis
+type ModelAnalysis {
+  model: String!
+  bounded: Boolean!
+  stateCount: Int!
+  edgeCount: Int!
+  hasDeadlock: Boolean!
+  deadlocks: JSON!
+  hasCycle: Boolean!
+  live: Boolean!
+  deadTransitions: [String!]!
+  invariants: [Invariant!]!
+  # True if exploration hit the state limit; results are partial
+  truncated: Boolean!
+}
+
+# A weighted sum of places that stays constant across reachable markings
+type Invariant {
+  places: [String!]!
+  coefficients: JSON!
+  value: Int!
+}`
+
+// modelDiffSchema is the SDL for version comparisons. The changeset payload
+// is JSON since its shape varies with the kind of change.
+const modelDiffSchema = `# Structural changes between two registered model versions
+type ModelDiff {
+  from: String!
+  to: String!
+  identical: Boolean!
+  summary: String!
+  changes: JSON!
+}`
+
+// auditEntrySchema is the SDL for audit trail records returned by the
+// history query. Markings are JSON so the same type serves every model.
+const auditEntrySchema = `# One audit trail record: a fired transition with the marking before/after
+type AuditEntry {
+  instanceId: ID!
+  transition: String!
+  timestamp: Time!
+  actor: String
+  version: Int!
+  markingBefore: JSON!
+  markingAfter: JSON!
+}`
+
 // sortedTransitions returns transitions sorted by label.
 func sortedTransitions(model *petri.PetriNet) []*petri.Transition {
 	transitions := make([]*petri.Transition, 0, len(model.Transitions))
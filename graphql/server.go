@@ -5,19 +5,39 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Server is a GraphQL HTTP server for Petri net models.
 type Server struct {
+	mu               sync.RWMutex
 	models           map[string]*petri.PetriNet
 	resolvers        map[string]Resolver
 	externalServices []ExternalService
 	schema           string
 	introspection    map[string]any
 	playgroundPath   string
+	triggers         *TriggerRegistry
+	scheduler        *Scheduler
+	analyses         map[string]*ModelAnalysis
+	federation       bool
+
+	// Public-exposure hardening (see protect.go)
+	persisted     map[string]string
+	maxDepth      int
+	maxComplexity int
+	limiter       *rateLimiter
+
+	// Structured logger (see observe.go)
+	logger telemetry.Logger
 }
 
 // ExternalService represents a service with an externally-provided schema.
@@ -62,21 +82,93 @@ func NewServer(opts ...Option) *Server {
 	s := &Server{
 		models:    make(map[string]*petri.PetriNet),
 		resolvers: make(map[string]Resolver),
+		analyses:  make(map[string]*ModelAnalysis),
+		logger:    telemetry.Nop(),
 	}
 
 	for _, opt := range opts {
 		opt(s)
 	}
 
-	// Generate unified schema
+	// Wrap model stores so firings dispatch to the trigger registry.
+	if s.triggers != nil {
+		for name, resolver := range s.resolvers {
+			if mr, ok := resolver.(*ModelResolver); ok {
+				mr.store = NewTriggeredStore(mr.store, name, s.triggers)
+			}
+		}
+	}
+
+	// Attach model stores to the scheduler after trigger wrapping, so
+	// scheduled firings dispatch triggers like client-initiated ones.
+	if s.scheduler != nil {
+		for name, resolver := range s.resolvers {
+			if mr, ok := resolver.(*ModelResolver); ok {
+				s.scheduler.Attach(name, mr.store)
+			}
+		}
+	}
+
+	s.regenerateLocked()
+
+	return s
+}
+
+// AddModel registers a model at runtime and regenerates the schema, so new
+// tenants or model versions go live without a restart. Trigger and
+// scheduler wiring is applied the same way as for construction-time models.
+func (s *Server) AddModel(name string, model *petri.PetriNet, store Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.triggers != nil {
+		store = NewTriggeredStore(store, name, s.triggers)
+	}
+	s.models[name] = model
+	s.resolvers[name] = NewModelResolver(model, store)
+	if s.scheduler != nil {
+		s.scheduler.Attach(name, store)
+	}
+	s.regenerateLocked()
+}
+
+// RemoveModel unregisters a model at runtime and regenerates the schema.
+// Instances live in the store, so a later AddModel with the same store
+// brings them back.
+func (s *Server) RemoveModel(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.models, name)
+	delete(s.resolvers, name)
+	s.regenerateLocked()
+}
+
+// ModelNames returns the currently registered model names.
+func (s *Server) ModelNames() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	names := make([]string, 0, len(s.models))
+	for name := range s.models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// regenerateLocked rebuilds the schema and introspection; the caller holds
+// the write lock (or is still constructing the server).
+func (s *Server) regenerateLocked() {
 	if len(s.externalServices) > 0 {
 		s.schema = s.generateUnifiedSchemaWithExternal()
 	} else {
 		s.schema = GenerateUnifiedSchema(s.models)
 	}
+	if s.federation {
+		s.schema = federateSchema(s.schema)
+	}
 	s.introspection = BuildIntrospection(s.schema)
-
-	return s
+	// Cached analyses describe a model set that no longer exists.
+	s.analyses = make(map[string]*ModelAnalysis)
 }
 
 // generateUnifiedSchemaWithExternal combines Petri net models and external services.
@@ -93,6 +185,8 @@ func (s *Server) generateUnifiedSchemaWithExternal() string {
 
 // Schema returns the combined GraphQL schema.
 func (s *Server) Schema() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.schema
 }
 
@@ -119,6 +213,11 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if s.limiter != nil && !s.limiter.allow(clientAddr(r)) {
+		http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
 	var req GraphQLRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -136,6 +235,10 @@ type GraphQLRequest struct {
 	Query         string         `json:"query"`
 	OperationName string         `json:"operationName"`
 	Variables     map[string]any `json:"variables"`
+
+	// ID selects a whitelisted operation on servers configured with
+	// WithPersistedQueries; Query is ignored there.
+	ID string `json:"id,omitempty"`
 }
 
 // GraphQLResponse represents a GraphQL response.
@@ -151,10 +254,34 @@ type GraphQLError struct {
 
 // Execute runs a GraphQL query and returns the result.
 func (s *Server) Execute(ctx context.Context, req GraphQLRequest) GraphQLResponse {
+	ctx, span := tracer.Start(ctx, "graphql.execute",
+		trace.WithAttributes(attribute.String("graphql.operation", req.OperationName)))
+	defer span.End()
+
+	resp := s.execute(ctx, req)
+	if len(resp.Errors) > 0 {
+		span.SetStatus(codes.Error, resp.Errors[0].Message)
+		s.logger.Warn("request failed", "operation", req.OperationName,
+			"errors", len(resp.Errors), "error", resp.Errors[0].Message)
+	}
+	return resp
+}
+
+// execute runs the request after the Execute wrapper has opened its span.
+func (s *Server) execute(ctx context.Context, req GraphQLRequest) GraphQLResponse {
+	// Persisted-query whitelisting replaces (or rejects) the raw query
+	// before anything else looks at it.
+	if gqlErr := s.resolvePersisted(&req); gqlErr != nil {
+		return GraphQLResponse{Errors: []GraphQLError{*gqlErr}}
+	}
+
 	// Handle introspection
 	if IsIntrospectionQuery(req.Query) {
+		s.mu.RLock()
+		introspection := s.introspection
+		s.mu.RUnlock()
 		return GraphQLResponse{
-			Data: s.introspection["data"].(map[string]any),
+			Data: introspection["data"].(map[string]any),
 		}
 	}
 
@@ -169,6 +296,12 @@ func (s *Server) Execute(ctx context.Context, req GraphQLRequest) GraphQLRespons
 		return result
 	}
 
+	// Enforce depth/complexity bounds before touching any resolver
+	if gqlErr := s.checkQueryLimits(parsed); gqlErr != nil {
+		result.Errors = append(result.Errors, *gqlErr)
+		return result
+	}
+
 	isMutation := parsed.Type == "mutation"
 
 	// Execute each field in the query
@@ -190,15 +323,42 @@ func (s *Server) Execute(ctx context.Context, req GraphQLRequest) GraphQLRespons
 
 // executeField executes a single field against the appropriate resolver.
 func (s *Server) executeField(ctx context.Context, field ParsedField, isMutation bool) (any, error) {
+	// Snapshot under the read lock so hot reloads don't race execution.
+	s.mu.RLock()
+	externalServices := s.externalServices
+	resolvers := make(map[string]Resolver, len(s.resolvers))
+	for name, resolver := range s.resolvers {
+		resolvers[name] = resolver
+	}
+	s.mu.RUnlock()
+
+	// Federation gateway queries
+	if s.federation && !isMutation {
+		if field.Name == "_service" {
+			return map[string]any{"sdl": s.Schema()}, nil
+		}
+		if field.Name == "_entities" {
+			return s.resolveEntities(ctx, field.Arguments)
+		}
+	}
+
+	// Server-level queries that take the model name as an argument
+	if !isMutation && field.Name == "modelAnalysis" {
+		return s.modelAnalysis(ctx, field.Arguments)
+	}
+	if !isMutation && field.Name == "modelDiff" {
+		return s.modelDiff(ctx, field.Arguments)
+	}
+
 	// First, check external service resolvers
-	for _, svc := range s.externalServices {
+	for _, svc := range externalServices {
 		if resolver, ok := svc.Resolvers[field.Name]; ok {
 			return resolver(ctx, field.Arguments)
 		}
 	}
 
 	// Then check Petri net model resolvers
-	for modelName, resolver := range s.resolvers {
+	for modelName, resolver := range resolvers {
 		prefix := strings.ToLower(toPascalCase(strings.ReplaceAll(modelName, "-", "")))
 
 		var opName string
@@ -221,6 +381,12 @@ func (s *Server) executeField(ctx context.Context, field ParsedField, isMutation
 			} else if field.Name == prefix+"Instances" {
 				opName = "instances"
 				args = field.Arguments
+			} else if field.Name == prefix+"History" {
+				opName = "history"
+				args = field.Arguments
+			} else if field.Name == prefix+"Simulate" {
+				opName = "simulate"
+				args = field.Arguments
 			}
 		}
 
@@ -239,7 +405,7 @@ func (s *Server) executeField(ctx context.Context, field ParsedField, isMutation
 func (s *Server) SchemaHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
-		fmt.Fprint(w, s.schema)
+		fmt.Fprint(w, s.Schema())
 	}
 }
 
@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// SimulationRequest configures a server-side ODE simulation: a time
+// horizon, optional transition rate overrides (default 1.0), optional
+// initial marking overrides (default: the model's initial marking), and how
+// many trajectory points to return.
+type SimulationRequest struct {
+	TStart  float64            `json:"tStart"`
+	TEnd    float64            `json:"tEnd"`
+	Samples int                `json:"samples,omitempty"`
+	Rates   map[string]float64 `json:"rates,omitempty"`
+	Initial map[string]float64 `json:"initial,omitempty"`
+}
+
+// SimulationResult is a sampled trajectory: parallel arrays of time points
+// and states, plus the final state for callers that only want the
+// equilibrium.
+type SimulationResult struct {
+	T          []float64            `json:"t"`
+	States     []map[string]float64 `json:"states"`
+	FinalState map[string]float64   `json:"finalState"`
+}
+
+// defaultSimulationSamples bounds the response size; solver output for a
+// long horizon can run to thousands of steps.
+const defaultSimulationSamples = 101
+
+// SimulateModel runs a mass-action ODE simulation of a model and samples
+// the trajectory. It backs the GraphQL simulate query, the REST simulate
+// endpoint, and is exported for embedders.
+func SimulateModel(model *petri.PetriNet, req SimulationRequest) (*SimulationResult, error) {
+	if req.TEnd <= req.TStart {
+		return nil, fmt.Errorf("empty time span [%g, %g]", req.TStart, req.TEnd)
+	}
+
+	initial := model.SetState(nil)
+	for place, value := range req.Initial {
+		if _, ok := model.Places[place]; !ok {
+			return nil, fmt.Errorf("unknown place %q", place)
+		}
+		initial[place] = value
+	}
+	rates := make(map[string]float64, len(model.Transitions))
+	for transition := range model.Transitions {
+		rates[transition] = 1.0
+	}
+	for transition, rate := range req.Rates {
+		if _, ok := model.Transitions[transition]; !ok {
+			return nil, fmt.Errorf("unknown transition %q", transition)
+		}
+		rates[transition] = rate
+	}
+
+	prob := solver.NewProblem(model, initial, [2]float64{req.TStart, req.TEnd}, rates)
+	sol := solver.Solve(prob, solver.Tsit5(), solver.DefaultOptions())
+	if len(sol.T) == 0 {
+		return nil, fmt.Errorf("solver produced no trajectory")
+	}
+
+	samples := req.Samples
+	if samples < 2 {
+		samples = defaultSimulationSamples
+	}
+	if samples > len(sol.T) {
+		samples = len(sol.T)
+	}
+
+	result := &SimulationResult{
+		T:      make([]float64, 0, samples),
+		States: make([]map[string]float64, 0, samples),
+	}
+	// Evenly spaced indices, always including the first and last step.
+	for i := 0; i < samples; i++ {
+		idx := i * (len(sol.T) - 1) / (samples - 1)
+		result.T = append(result.T, sol.T[idx])
+		result.States = append(result.States, sol.U[idx])
+	}
+	result.FinalState = sol.GetFinalState()
+	return result, nil
+}
+
+// resolveSimulate serves the simulate(tStart, tEnd, samples, rates,
+// initial) query.
+func (r *ModelResolver) resolveSimulate(ctx context.Context, args map[string]any) (any, error) {
+	req := SimulationRequest{
+		TStart:  floatArg(args, "tStart"),
+		TEnd:    floatArg(args, "tEnd"),
+		Samples: intArg(args, "samples", 0),
+		Rates:   floatMapArg(args, "rates"),
+		Initial: floatMapArg(args, "initial"),
+	}
+	return SimulateModel(r.model, req)
+}
+
+func floatArg(args map[string]any, key string) float64 {
+	switch n := args[key].(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	}
+	return 0
+}
+
+// floatMapArg converts a JSON object argument to a float map, tolerating
+// int-typed values from inline literals.
+func floatMapArg(args map[string]any, key string) map[string]float64 {
+	raw, ok := args[key].(map[string]any)
+	if !ok {
+		return nil
+	}
+	out := make(map[string]float64, len(raw))
+	for k, v := range raw {
+		switch n := v.(type) {
+		case float64:
+			out[k] = n
+		case int:
+			out[k] = float64(n)
+		}
+	}
+	return out
+}
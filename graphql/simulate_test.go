@@ -0,0 +1,98 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+func TestSimulateModel(t *testing.T) {
+	model := newBacklogModel()
+
+	result, err := SimulateModel(model, SimulationRequest{TEnd: 20})
+	if err != nil {
+		t.Fatalf("SimulateModel: %v", err)
+	}
+	if len(result.T) != len(result.States) {
+		t.Fatalf("Trajectory arrays disagree: %d times, %d states", len(result.T), len(result.States))
+	}
+	if len(result.T) < 2 || len(result.T) > defaultSimulationSamples {
+		t.Errorf("Sample count: %d", len(result.T))
+	}
+	if result.T[0] != 0 || result.T[len(result.T)-1] < 19 {
+		t.Errorf("Trajectory span: [%g, %g]", result.T[0], result.T[len(result.T)-1])
+	}
+
+	// The inbox drains into the backlog over the horizon.
+	if result.FinalState["backlog"] < 9 {
+		t.Errorf("Final state: %v", result.FinalState)
+	}
+	first := result.States[0]
+	if first["inbox"] != 10 {
+		t.Errorf("Initial state: %v", first)
+	}
+}
+
+func TestSimulateModelOverrides(t *testing.T) {
+	model := newBacklogModel()
+
+	// A much smaller inbox and a crawling rate leave most of it unmoved.
+	result, err := SimulateModel(model, SimulationRequest{
+		TEnd:    1,
+		Samples: 11,
+		Initial: map[string]float64{"inbox": 2},
+		Rates:   map[string]float64{"enqueue": 0.01},
+	})
+	if err != nil {
+		t.Fatalf("SimulateModel: %v", err)
+	}
+	if len(result.T) != 11 {
+		t.Errorf("Requested 11 samples, got %d", len(result.T))
+	}
+	if result.FinalState["backlog"] > 0.5 {
+		t.Errorf("Slow rate should barely move tokens: %v", result.FinalState)
+	}
+	if result.States[0]["inbox"] != 2 {
+		t.Errorf("Initial override ignored: %v", result.States[0])
+	}
+}
+
+func TestSimulateModelValidation(t *testing.T) {
+	model := newBacklogModel()
+
+	cases := []struct {
+		name string
+		req  SimulationRequest
+	}{
+		{"empty span", SimulationRequest{TStart: 5, TEnd: 5}},
+		{"unknown place", SimulationRequest{TEnd: 10, Initial: map[string]float64{"nope": 1}}},
+		{"unknown transition", SimulationRequest{TEnd: 10, Rates: map[string]float64{"nope": 1}}},
+	}
+	for _, tc := range cases {
+		if _, err := SimulateModel(model, tc.req); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+func TestSimulateQuery(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+	server := NewServer(WithModel("backlog", model, store))
+
+	resp := server.Execute(ctx, GraphQLRequest{
+		Query: `query { backlogSimulate(tEnd: 20) { finalState } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Query errors: %v", resp.Errors)
+	}
+	result, ok := resp.Data["backlogSimulate"].(*SimulationResult)
+	if !ok {
+		t.Fatalf("Unexpected result type: %T", resp.Data["backlogSimulate"])
+	}
+	if result.FinalState["backlog"] < 9 {
+		t.Errorf("Final state via GraphQL: %v", result.FinalState)
+	}
+}
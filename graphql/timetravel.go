@@ -0,0 +1,175 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// HistoryPoint pairs an event with the instance marking immediately after
+// that event was applied. It is the unit of time-travel queries: a sequence
+// of HistoryPoints is the full marking trajectory of an instance.
+type HistoryPoint struct {
+	Event   *eventsource.Event
+	Marking map[string]int
+}
+
+// MarkingAt reconstructs the marking of an instance as it was at the given
+// timestamp by replaying only events that occurred at or before it. Events
+// after the timestamp are ignored, so the result is exactly what a live
+// observer would have seen at that moment.
+func (s *EventSourceStore) MarkingAt(ctx context.Context, id string, at time.Time) (map[string]int, error) {
+	events, err := s.readHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sm := s.replayStateMachine(id)
+	for _, event := range events {
+		if event.Timestamp.After(at) {
+			break
+		}
+		if err := sm.Apply(event); err != nil {
+			continue
+		}
+	}
+	return sm.Places(), nil
+}
+
+// MarkingTimeline replays the full history of an instance and returns the
+// marking after every event, in order. Use it to inspect how an instance
+// evolved when debugging a past incident.
+func (s *EventSourceStore) MarkingTimeline(ctx context.Context, id string) ([]HistoryPoint, error) {
+	events, err := s.readHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sm := s.replayStateMachine(id)
+	timeline := make([]HistoryPoint, 0, len(events))
+	for _, event := range events {
+		if err := sm.Apply(event); err != nil {
+			continue
+		}
+		timeline = append(timeline, HistoryPoint{Event: event, Marking: sm.Places()})
+	}
+	return timeline, nil
+}
+
+// FirstTimeWhere bisects an instance's history for the first event after
+// which the predicate holds, returning that event and the marking it
+// produced. The predicate must be monotone over the history (once true it
+// stays true, e.g. "place X has ever exceeded 3 tokens" tracked by an
+// accumulating place); for non-monotone conditions walk MarkingTimeline
+// instead. Returns nil if the predicate never becomes true.
+func (s *EventSourceStore) FirstTimeWhere(ctx context.Context, id string, pred func(marking map[string]int) bool) (*HistoryPoint, error) {
+	events, err := s.readHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// markingAfter replays the first n events and reports the marking.
+	markingAfter := func(n int) map[string]int {
+		sm := s.replayStateMachine(id)
+		for _, event := range events[:n] {
+			if err := sm.Apply(event); err != nil {
+				continue
+			}
+		}
+		return sm.Places()
+	}
+
+	if !pred(markingAfter(len(events))) {
+		return nil, nil
+	}
+
+	// Binary search for the smallest prefix where the predicate holds.
+	lo, hi := 1, len(events)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if pred(markingAfter(mid)) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return &HistoryPoint{Event: events[lo-1], Marking: markingAfter(lo)}, nil
+}
+
+// FirstExceeding reports when a place first held more than threshold tokens:
+// the classic post-incident question "when did the backlog first exceed 3?".
+// Returns nil if the place never exceeded the threshold.
+func (s *EventSourceStore) FirstExceeding(ctx context.Context, id string, place string, threshold int) (*HistoryPoint, error) {
+	if _, ok := s.model.Places[place]; !ok {
+		return nil, fmt.Errorf("unknown place: %s", place)
+	}
+	return s.FirstTimeWhere(ctx, id, func(marking map[string]int) bool {
+		return marking[place] > threshold
+	})
+}
+
+// NetAt returns a copy of the model with the instance's historical marking
+// at the given timestamp as its initial marking. The copy can be handed
+// straight to the visualization package to render the past state.
+func (s *EventSourceStore) NetAt(ctx context.Context, id string, at time.Time) (*petri.PetriNet, error) {
+	marking, err := s.MarkingAt(ctx, id, at)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := petri.NewPetriNet()
+	snapshot.Token = append([]string(nil), s.model.Token...)
+	for name, p := range s.model.Places {
+		snapshot.AddPlace(name, []float64{float64(marking[name])},
+			append([]float64(nil), p.Capacity...), p.X, p.Y, p.LabelText)
+	}
+	for name, t := range s.model.Transitions {
+		snapshot.AddTransition(name, t.Role, t.X, t.Y, t.LabelText)
+	}
+	for _, a := range s.model.Arcs {
+		snapshot.AddArc(a.Source, a.Target, append([]float64(nil), a.Weight...), a.InhibitTransition)
+	}
+	return snapshot, nil
+}
+
+// readHistory loads the full, ordered event history of an instance.
+func (s *EventSourceStore) readHistory(ctx context.Context, id string) ([]*eventsource.Event, error) {
+	events, err := s.store.Read(ctx, id, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("instance not found: %s", id)
+	}
+	return events, nil
+}
+
+// replayStateMachine builds a fresh, uncached state machine for replay so
+// time-travel queries never disturb the live cached instance state.
+func (s *EventSourceStore) replayStateMachine(id string) *eventsource.StateMachine[map[string]any] {
+	initialPlaces := make(map[string]int)
+	for label, place := range s.model.Places {
+		initialPlaces[label] = int(place.GetTokenCount())
+	}
+
+	sm := eventsource.NewStateMachine[map[string]any](id, make(map[string]any), initialPlaces)
+	for label := range s.model.Transitions {
+		sm.AddTransition(s.buildTransition(label))
+		sm.RegisterHandler(label, func(state *map[string]any, event *eventsource.Event) error {
+			var data map[string]any
+			if err := event.Decode(&data); err == nil {
+				for k, v := range data {
+					(*state)[k] = v
+				}
+			}
+			return nil
+		})
+	}
+	sm.RegisterHandler("created", func(state *map[string]any, event *eventsource.Event) error {
+		return nil
+	})
+	return sm
+}
@@ -0,0 +1,143 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func newBacklogModel() *petri.PetriNet {
+	model := petri.NewPetriNet()
+	model.AddPlace("inbox", 10, 0, 0, 0, nil)
+	model.AddPlace("backlog", 0, 0, 100, 0, nil)
+	model.AddTransition("enqueue", "", 50, 0, nil)
+	model.AddArc("inbox", "enqueue", 1, false)
+	model.AddArc("enqueue", "backlog", 1, false)
+	return model
+}
+
+func TestMarkingAtReconstructsPastState(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := store.Fire(ctx, id, "enqueue", nil); err != nil {
+			t.Fatalf("Fire: %v", err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	checkpoint := time.Now()
+	time.Sleep(2 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		if _, err := store.Fire(ctx, id, "enqueue", nil); err != nil {
+			t.Fatalf("Fire: %v", err)
+		}
+	}
+
+	marking, err := store.MarkingAt(ctx, id, checkpoint)
+	if err != nil {
+		t.Fatalf("MarkingAt: %v", err)
+	}
+	if marking["backlog"] != 2 || marking["inbox"] != 8 {
+		t.Errorf("Expected backlog=2 inbox=8 at checkpoint, got %v", marking)
+	}
+
+	// Current state is unaffected by the replay.
+	inst, err := store.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if inst.Marking["backlog"] != 5 {
+		t.Errorf("Live marking disturbed by time travel: %v", inst.Marking)
+	}
+}
+
+func TestFirstExceedingBisectsHistory(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for i := 0; i < 6; i++ {
+		if _, err := store.Fire(ctx, id, "enqueue", nil); err != nil {
+			t.Fatalf("Fire: %v", err)
+		}
+	}
+
+	point, err := store.FirstExceeding(ctx, id, "backlog", 3)
+	if err != nil {
+		t.Fatalf("FirstExceeding: %v", err)
+	}
+	if point == nil {
+		t.Fatal("Expected a history point, got nil")
+	}
+	// The fourth enqueue pushes the backlog past 3; with the creation event
+	// at version 0, that is version 4.
+	if point.Event.Version != 4 {
+		t.Errorf("Expected version 4, got %d", point.Event.Version)
+	}
+	if point.Marking["backlog"] != 4 {
+		t.Errorf("Expected backlog=4 at crossing, got %v", point.Marking)
+	}
+
+	// Never reached: returns nil without error.
+	never, err := store.FirstExceeding(ctx, id, "backlog", 100)
+	if err != nil {
+		t.Fatalf("FirstExceeding: %v", err)
+	}
+	if never != nil {
+		t.Errorf("Expected nil for unreached threshold, got %+v", never)
+	}
+
+	if _, err := store.FirstExceeding(ctx, id, "nope", 1); err == nil {
+		t.Error("Expected error for unknown place")
+	}
+}
+
+func TestMarkingTimelineAndNetAt(t *testing.T) {
+	ctx := context.Background()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), newBacklogModel(), "backlog")
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := store.Fire(ctx, id, "enqueue", nil); err != nil {
+			t.Fatalf("Fire: %v", err)
+		}
+	}
+
+	timeline, err := store.MarkingTimeline(ctx, id)
+	if err != nil {
+		t.Fatalf("MarkingTimeline: %v", err)
+	}
+	if len(timeline) != 4 { // created + 3 fires
+		t.Fatalf("Expected 4 history points, got %d", len(timeline))
+	}
+	for i, point := range timeline {
+		if point.Marking["backlog"] != i {
+			t.Errorf("Point %d: expected backlog=%d, got %v", i, i, point.Marking)
+		}
+	}
+
+	snapshot, err := store.NetAt(ctx, id, timeline[2].Event.Timestamp)
+	if err != nil {
+		t.Fatalf("NetAt: %v", err)
+	}
+	if got := snapshot.Places["backlog"].GetTokenCount(); got != 2 {
+		t.Errorf("Expected snapshot backlog=2, got %v", got)
+	}
+	if len(snapshot.Arcs) != len(store.model.Arcs) {
+		t.Errorf("Snapshot lost arcs: %d vs %d", len(snapshot.Arcs), len(store.model.Arcs))
+	}
+}
@@ -0,0 +1,201 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FiringEvent describes a transition that just fired; it is the payload
+// handed to every trigger action.
+type FiringEvent struct {
+	Model      string         `json:"model"`
+	Transition string         `json:"transition"`
+	InstanceID string         `json:"instanceId"`
+	Version    int            `json:"version"`
+	Marking    map[string]int `json:"marking"`
+	Timestamp  time.Time      `json:"timestamp"`
+}
+
+// TriggerAction reacts to a firing. Actions run synchronously after the
+// firing has been persisted; an action error never rolls the firing back,
+// it is reported through the registry's error handler instead.
+type TriggerAction func(ctx context.Context, event FiringEvent) error
+
+// TriggerRegistry maps (model, transition) pairs to actions. Register "*"
+// as the transition to match every transition of a model. The registry
+// turns the server from a passive state store into an orchestrator: firings
+// fan out to webhooks, message buses, or in-process functions.
+type TriggerRegistry struct {
+	mu      sync.RWMutex
+	actions map[string][]TriggerAction
+	onError func(event FiringEvent, err error)
+}
+
+// NewTriggerRegistry creates an empty registry.
+func NewTriggerRegistry() *TriggerRegistry {
+	return &TriggerRegistry{
+		actions: make(map[string][]TriggerAction),
+	}
+}
+
+// On registers an action for firings of the given transition on the given
+// model. Use "*" as the transition to match all transitions.
+func (r *TriggerRegistry) On(model, transition string, action TriggerAction) *TriggerRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := model + "|" + transition
+	r.actions[key] = append(r.actions[key], action)
+	return r
+}
+
+// OnError installs a handler for action failures. Without one, failures
+// are silently dropped.
+func (r *TriggerRegistry) OnError(fn func(event FiringEvent, err error)) *TriggerRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onError = fn
+	return r
+}
+
+// dispatch invokes every action registered for the event's transition plus
+// the model's wildcard actions.
+func (r *TriggerRegistry) dispatch(ctx context.Context, event FiringEvent) {
+	r.mu.RLock()
+	actions := append([]TriggerAction(nil), r.actions[event.Model+"|"+event.Transition]...)
+	actions = append(actions, r.actions[event.Model+"|*"]...)
+	onError := r.onError
+	r.mu.RUnlock()
+
+	for _, action := range actions {
+		if err := action(ctx, event); err != nil && onError != nil {
+			onError(event, err)
+		}
+	}
+}
+
+// Publisher abstracts a message bus so NATS, Kafka, or anything else can
+// receive firing events without this package importing a client library.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, payload []byte) error
+}
+
+// WebhookAction POSTs the firing event as JSON to url. When secret is
+// non-empty the body is signed with HMAC-SHA256 and the hex digest sent in
+// the X-Pflow-Signature header so receivers can verify origin. A nil client
+// uses http.DefaultClient.
+func WebhookAction(url, secret string, client *http.Client) TriggerAction {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context, event FiringEvent) error {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if secret != "" {
+			req.Header.Set("X-Pflow-Signature", SignPayload(body, secret))
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook %s returned status %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// SignPayload returns the hex HMAC-SHA256 digest of body under secret;
+// webhook receivers recompute it to verify the X-Pflow-Signature header.
+func SignPayload(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// PublishAction sends the firing event as JSON to a message bus subject.
+// Use "{model}" and "{transition}" in the subject and they are replaced per
+// event, e.g. "pflow.{model}.{transition}".
+func PublishAction(publisher Publisher, subject string) TriggerAction {
+	return func(ctx context.Context, event FiringEvent) error {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		return publisher.Publish(ctx, resolveSubject(subject, event), payload)
+	}
+}
+
+// resolveSubject substitutes the event's model and transition into a
+// subject template.
+func resolveSubject(subject string, event FiringEvent) string {
+	r := strings.NewReplacer("{model}", event.Model, "{transition}", event.Transition)
+	return r.Replace(subject)
+}
+
+// triggeredStore decorates a Store so successful firings dispatch to a
+// TriggerRegistry.
+type triggeredStore struct {
+	Store
+	model    string
+	registry *TriggerRegistry
+}
+
+// NewTriggeredStore wraps a store so every successful Fire dispatches a
+// FiringEvent to the registry. WithTriggers applies this automatically to
+// all of a server's stores.
+func NewTriggeredStore(store Store, model string, registry *TriggerRegistry) Store {
+	return &triggeredStore{Store: store, model: model, registry: registry}
+}
+
+// Fire implements Store.
+func (s *triggeredStore) Fire(ctx context.Context, id string, transition string, bindings map[string]any) (*Instance, error) {
+	instance, err := s.Store.Fire(ctx, id, transition, bindings)
+	if err != nil {
+		return nil, err
+	}
+	s.registry.dispatch(ctx, FiringEvent{
+		Model:      s.model,
+		Transition: transition,
+		InstanceID: instance.ID,
+		Version:    instance.Version,
+		Marking:    instance.Marking,
+		Timestamp:  time.Now().UTC(),
+	})
+	return instance, nil
+}
+
+// History passes through to the wrapped store so that wrapping does not
+// hide the audit trail from the history query or the scheduler.
+func (s *triggeredStore) History(ctx context.Context, id string) ([]*AuditEntry, error) {
+	source, ok := s.Store.(HistorySource)
+	if !ok {
+		return nil, fmt.Errorf("store does not support history")
+	}
+	return source.History(ctx, id)
+}
+
+// WithTriggers installs a trigger registry on the server: every model
+// store is wrapped so successful firings dispatch to it, regardless of
+// option order.
+func WithTriggers(registry *TriggerRegistry) Option {
+	return func(s *Server) {
+		s.triggers = registry
+	}
+}
@@ -0,0 +1,160 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+)
+
+func TestTriggerDispatchOnFire(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	var events []FiringEvent
+	registry := NewTriggerRegistry().
+		On("backlog", "enqueue", func(ctx context.Context, event FiringEvent) error {
+			events = append(events, event)
+			return nil
+		})
+
+	server := NewServer(WithModel("backlog", model, store), WithTriggers(registry))
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	resp := server.Execute(ctx, GraphQLRequest{
+		Query: `mutation { backlog_enqueue(input: {instanceId: "` + id + `"}) { id } }`,
+	})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Mutation errors: %v", resp.Errors)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 trigger dispatch, got %d", len(events))
+	}
+	event := events[0]
+	if event.Model != "backlog" || event.Transition != "enqueue" || event.InstanceID != id {
+		t.Errorf("Event: %+v", event)
+	}
+	if event.Marking["backlog"] != 1 {
+		t.Errorf("Event marking: %v", event.Marking)
+	}
+}
+
+func TestTriggerWildcardAndErrors(t *testing.T) {
+	ctx := context.Background()
+	model := newBacklogModel()
+	store := NewEventSourceStore(eventsource.NewMemoryStore(), model, "backlog")
+
+	wildcardHits := 0
+	var failures []error
+	registry := NewTriggerRegistry().
+		On("backlog", "*", func(ctx context.Context, event FiringEvent) error {
+			wildcardHits++
+			return nil
+		}).
+		On("backlog", "enqueue", func(ctx context.Context, event FiringEvent) error {
+			return errors.New("downstream unavailable")
+		}).
+		OnError(func(event FiringEvent, err error) {
+			failures = append(failures, err)
+		})
+
+	triggered := NewTriggeredStore(store, "backlog", registry)
+
+	id, err := store.Create(ctx, "backlog")
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	inst, err := triggered.Fire(ctx, id, "enqueue", nil)
+	if err != nil {
+		t.Fatalf("Fire: %v", err)
+	}
+	if inst.Marking["backlog"] != 1 {
+		t.Errorf("Action failure must not roll back the firing: %v", inst.Marking)
+	}
+	if wildcardHits != 1 {
+		t.Errorf("Wildcard action hits: %d", wildcardHits)
+	}
+	if len(failures) != 1 {
+		t.Errorf("Expected 1 reported failure, got %v", failures)
+	}
+}
+
+func TestWebhookAction(t *testing.T) {
+	ctx := context.Background()
+
+	var gotSignature string
+	var gotBody []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Pflow-Signature")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	action := WebhookAction(ts.URL, "s3cret", ts.Client())
+	event := FiringEvent{Model: "backlog", Transition: "enqueue", InstanceID: "i-1"}
+	if err := action(ctx, event); err != nil {
+		t.Fatalf("WebhookAction: %v", err)
+	}
+
+	if gotSignature != SignPayload(gotBody, "s3cret") {
+		t.Error("Signature does not verify against the delivered body")
+	}
+	var delivered FiringEvent
+	if err := json.Unmarshal(gotBody, &delivered); err != nil {
+		t.Fatalf("Invalid webhook body: %v", err)
+	}
+	if delivered.InstanceID != "i-1" {
+		t.Errorf("Delivered event: %+v", delivered)
+	}
+
+	// Non-2xx responses surface as errors.
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	if err := WebhookAction(failing.URL, "", failing.Client())(ctx, event); err == nil {
+		t.Error("Expected error for 502 response")
+	}
+}
+
+type recordingPublisher struct {
+	subjects []string
+	payloads [][]byte
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, subject string, payload []byte) error {
+	p.subjects = append(p.subjects, subject)
+	p.payloads = append(p.payloads, payload)
+	return nil
+}
+
+func TestPublishAction(t *testing.T) {
+	publisher := &recordingPublisher{}
+	action := PublishAction(publisher, "pflow.{model}.{transition}")
+
+	event := FiringEvent{Model: "backlog", Transition: "enqueue", InstanceID: "i-1"}
+	if err := action(context.Background(), event); err != nil {
+		t.Fatalf("PublishAction: %v", err)
+	}
+	if len(publisher.subjects) != 1 || publisher.subjects[0] != "pflow.backlog.enqueue" {
+		t.Errorf("Subjects: %v", publisher.subjects)
+	}
+	var delivered FiringEvent
+	if err := json.Unmarshal(publisher.payloads[0], &delivered); err != nil {
+		t.Fatalf("Invalid payload: %v", err)
+	}
+	if delivered.InstanceID != "i-1" {
+		t.Errorf("Payload: %+v", delivered)
+	}
+}
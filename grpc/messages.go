@@ -0,0 +1,341 @@
+// Package grpc provides a gRPC service for Petri net model execution and
+// simulation. The message types mirror modelservice.proto and encode the
+// standard protobuf wire format by hand, so the package needs no protoc
+// step while remaining compatible with stubs generated from the proto file.
+package grpc
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// wireMessage is implemented by every message in this package; the codec
+// dispatches on it.
+type wireMessage interface {
+	marshal() []byte
+	unmarshal(data []byte) error
+}
+
+// CreateInstanceRequest asks for a new instance of a registered model.
+type CreateInstanceRequest struct {
+	Model string
+}
+
+// GetInstanceRequest identifies an instance.
+type GetInstanceRequest struct {
+	ID string
+}
+
+// FireTransitionRequest fires a transition on an instance.
+type FireTransitionRequest struct {
+	ID         string
+	Transition string
+}
+
+// Instance is the current state of a model instance.
+type Instance struct {
+	ID                 string
+	Model              string
+	Version            int64
+	Marking            map[string]int64
+	EnabledTransitions []string
+}
+
+// SimulateRequest configures an ODE simulation of a registered model.
+type SimulateRequest struct {
+	Model   string
+	TStart  float64
+	TEnd    float64
+	Rates   map[string]float64
+	Initial map[string]float64
+}
+
+// SimulatePoint is one time point of a streamed trajectory.
+type SimulatePoint struct {
+	T     float64
+	State map[string]float64
+}
+
+func (m *CreateInstanceRequest) marshal() []byte {
+	return appendString(nil, 1, m.Model)
+}
+
+func (m *CreateInstanceRequest) unmarshal(data []byte) error {
+	return walkFields(data, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if num == 1 && typ == protowire.BytesType {
+			m.Model = string(v)
+		}
+		return nil
+	})
+}
+
+func (m *GetInstanceRequest) marshal() []byte {
+	return appendString(nil, 1, m.ID)
+}
+
+func (m *GetInstanceRequest) unmarshal(data []byte) error {
+	return walkFields(data, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if num == 1 && typ == protowire.BytesType {
+			m.ID = string(v)
+		}
+		return nil
+	})
+}
+
+func (m *FireTransitionRequest) marshal() []byte {
+	b := appendString(nil, 1, m.ID)
+	return appendString(b, 2, m.Transition)
+}
+
+func (m *FireTransitionRequest) unmarshal(data []byte) error {
+	return walkFields(data, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case 1:
+			m.ID = string(v)
+		case 2:
+			m.Transition = string(v)
+		}
+		return nil
+	})
+}
+
+func (m *Instance) marshal() []byte {
+	b := appendString(nil, 1, m.ID)
+	b = appendString(b, 2, m.Model)
+	if m.Version != 0 {
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(m.Version))
+	}
+	for _, key := range sortedKeys(m.Marking) {
+		entry := appendString(nil, 1, key)
+		entry = protowire.AppendTag(entry, 2, protowire.VarintType)
+		entry = protowire.AppendVarint(entry, uint64(m.Marking[key]))
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	for _, t := range m.EnabledTransitions {
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendBytes(b, []byte(t))
+	}
+	return b
+}
+
+func (m *Instance) unmarshal(data []byte) error {
+	return walkFields(data, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch num {
+		case 1:
+			m.ID = string(v)
+		case 2:
+			m.Model = string(v)
+		case 3:
+			if typ == protowire.VarintType {
+				value, _ := protowire.ConsumeVarint(v)
+				m.Version = int64(value)
+			}
+		case 4:
+			key, value, err := consumeInt64Entry(v)
+			if err != nil {
+				return err
+			}
+			if m.Marking == nil {
+				m.Marking = make(map[string]int64)
+			}
+			m.Marking[key] = value
+		case 5:
+			m.EnabledTransitions = append(m.EnabledTransitions, string(v))
+		}
+		return nil
+	})
+}
+
+func (m *SimulateRequest) marshal() []byte {
+	b := appendString(nil, 1, m.Model)
+	b = appendDouble(b, 2, m.TStart)
+	b = appendDouble(b, 3, m.TEnd)
+	b = appendDoubleMap(b, 4, m.Rates)
+	return appendDoubleMap(b, 5, m.Initial)
+}
+
+func (m *SimulateRequest) unmarshal(data []byte) error {
+	return walkFields(data, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch num {
+		case 1:
+			m.Model = string(v)
+		case 2:
+			m.TStart = consumeDouble(v, typ)
+		case 3:
+			m.TEnd = consumeDouble(v, typ)
+		case 4:
+			key, value, err := consumeDoubleEntry(v)
+			if err != nil {
+				return err
+			}
+			if m.Rates == nil {
+				m.Rates = make(map[string]float64)
+			}
+			m.Rates[key] = value
+		case 5:
+			key, value, err := consumeDoubleEntry(v)
+			if err != nil {
+				return err
+			}
+			if m.Initial == nil {
+				m.Initial = make(map[string]float64)
+			}
+			m.Initial[key] = value
+		}
+		return nil
+	})
+}
+
+func (m *SimulatePoint) marshal() []byte {
+	b := appendDouble(nil, 1, m.T)
+	return appendDoubleMap(b, 2, m.State)
+}
+
+func (m *SimulatePoint) unmarshal(data []byte) error {
+	return walkFields(data, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch num {
+		case 1:
+			m.T = consumeDouble(v, typ)
+		case 2:
+			key, value, err := consumeDoubleEntry(v)
+			if err != nil {
+				return err
+			}
+			if m.State == nil {
+				m.State = make(map[string]float64)
+			}
+			m.State[key] = value
+		}
+		return nil
+	})
+}
+
+// walkFields iterates a message's fields. Bytes fields pass the payload;
+// varint fields pass the raw varint bytes; fixed64 fields pass 8 bytes.
+func walkFields(data []byte, fn func(num protowire.Number, typ protowire.Type, v []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		var payload []byte
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			payload = v
+			data = data[n:]
+		case protowire.VarintType:
+			_, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			payload = data[:n]
+			data = data[n:]
+		case protowire.Fixed64Type:
+			if len(data) < 8 {
+				return fmt.Errorf("truncated fixed64 field %d", num)
+			}
+			payload = data[:8]
+			data = data[8:]
+		case protowire.Fixed32Type:
+			if len(data) < 4 {
+				return fmt.Errorf("truncated fixed32 field %d", num)
+			}
+			payload = data[:4]
+			data = data[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %v for field %d", typ, num)
+		}
+		if err := fn(num, typ, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, []byte(s))
+}
+
+func appendDouble(b []byte, num protowire.Number, v float64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, math.Float64bits(v))
+}
+
+func appendDoubleMap(b []byte, num protowire.Number, m map[string]float64) []byte {
+	for _, key := range sortedKeys(m) {
+		entry := appendString(nil, 1, key)
+		entry = protowire.AppendTag(entry, 2, protowire.Fixed64Type)
+		entry = protowire.AppendFixed64(entry, math.Float64bits(m[key]))
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		b = protowire.AppendBytes(b, entry)
+	}
+	return b
+}
+
+func consumeDouble(v []byte, typ protowire.Type) float64 {
+	if typ != protowire.Fixed64Type || len(v) < 8 {
+		return 0
+	}
+	bits, _ := protowire.ConsumeFixed64(v)
+	return math.Float64frombits(bits)
+}
+
+func consumeInt64Entry(entry []byte) (string, int64, error) {
+	var key string
+	var value int64
+	err := walkFields(entry, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch num {
+		case 1:
+			key = string(v)
+		case 2:
+			raw, _ := protowire.ConsumeVarint(v)
+			value = int64(raw)
+		}
+		return nil
+	})
+	return key, value, err
+}
+
+func consumeDoubleEntry(entry []byte) (string, float64, error) {
+	var key string
+	var value float64
+	err := walkFields(entry, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch num {
+		case 1:
+			key = string(v)
+		case 2:
+			value = consumeDouble(v, typ)
+		}
+		return nil
+	})
+	return key, value, err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
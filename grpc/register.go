@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"context"
+
+	gogrpc "google.golang.org/grpc"
+)
+
+// This file plays the role protoc-generated code usually would: the service
+// descriptor, per-method handlers, stream wrappers, and a typed client for
+// the pflow.ModelService contract in modelservice.proto.
+
+const serviceName = "pflow.ModelService"
+
+// RegisterModelServiceServer registers the service implementation on a
+// gRPC server. The server must use Codec (NewGRPCServer handles this).
+func RegisterModelServiceServer(s gogrpc.ServiceRegistrar, srv ModelServiceServer) {
+	s.RegisterService(&modelServiceDesc, srv)
+}
+
+var modelServiceDesc = gogrpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*ModelServiceServer)(nil),
+	Methods: []gogrpc.MethodDesc{
+		{MethodName: "CreateInstance", Handler: createInstanceHandler},
+		{MethodName: "GetInstance", Handler: getInstanceHandler},
+		{MethodName: "FireTransition", Handler: fireTransitionHandler},
+	},
+	Streams: []gogrpc.StreamDesc{
+		{StreamName: "WatchInstance", Handler: watchInstanceHandler, ServerStreams: true},
+		{StreamName: "Simulate", Handler: simulateHandler, ServerStreams: true},
+	},
+	Metadata: "modelservice.proto",
+}
+
+func createInstanceHandler(srv any, ctx context.Context, dec func(any) error, interceptor gogrpc.UnaryServerInterceptor) (any, error) {
+	in := new(CreateInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelServiceServer).CreateInstance(ctx, in)
+	}
+	info := &gogrpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/CreateInstance"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(ModelServiceServer).CreateInstance(ctx, req.(*CreateInstanceRequest))
+	})
+}
+
+func getInstanceHandler(srv any, ctx context.Context, dec func(any) error, interceptor gogrpc.UnaryServerInterceptor) (any, error) {
+	in := new(GetInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelServiceServer).GetInstance(ctx, in)
+	}
+	info := &gogrpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/GetInstance"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(ModelServiceServer).GetInstance(ctx, req.(*GetInstanceRequest))
+	})
+}
+
+func fireTransitionHandler(srv any, ctx context.Context, dec func(any) error, interceptor gogrpc.UnaryServerInterceptor) (any, error) {
+	in := new(FireTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelServiceServer).FireTransition(ctx, in)
+	}
+	info := &gogrpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/FireTransition"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req any) (any, error) {
+		return srv.(ModelServiceServer).FireTransition(ctx, req.(*FireTransitionRequest))
+	})
+}
+
+func watchInstanceHandler(srv any, stream gogrpc.ServerStream) error {
+	in := new(GetInstanceRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(ModelServiceServer).WatchInstance(in, &instanceStream{stream})
+}
+
+func simulateHandler(srv any, stream gogrpc.ServerStream) error {
+	in := new(SimulateRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(ModelServiceServer).Simulate(in, &simulatePointStream{stream})
+}
+
+type instanceStream struct{ gogrpc.ServerStream }
+
+func (s *instanceStream) Send(m *Instance) error { return s.SendMsg(m) }
+
+type simulatePointStream struct{ gogrpc.ServerStream }
+
+func (s *simulatePointStream) Send(m *SimulatePoint) error { return s.SendMsg(m) }
+
+// ModelServiceClient is the client API for pflow.ModelService.
+type ModelServiceClient struct {
+	cc gogrpc.ClientConnInterface
+}
+
+// NewModelServiceClient creates a typed client. Dial with
+// gogrpc.WithDefaultCallOptions(gogrpc.ForceCodec(Codec{})) so requests use
+// the package codec.
+func NewModelServiceClient(cc gogrpc.ClientConnInterface) *ModelServiceClient {
+	return &ModelServiceClient{cc: cc}
+}
+
+func (c *ModelServiceClient) CreateInstance(ctx context.Context, req *CreateInstanceRequest, opts ...gogrpc.CallOption) (*Instance, error) {
+	out := new(Instance)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/CreateInstance", req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ModelServiceClient) GetInstance(ctx context.Context, req *GetInstanceRequest, opts ...gogrpc.CallOption) (*Instance, error) {
+	out := new(Instance)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/GetInstance", req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ModelServiceClient) FireTransition(ctx context.Context, req *FireTransitionRequest, opts ...gogrpc.CallOption) (*Instance, error) {
+	out := new(Instance)
+	if err := c.cc.Invoke(ctx, "/"+serviceName+"/FireTransition", req, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WatchInstanceClient receives streamed instance updates.
+type WatchInstanceClient struct{ gogrpc.ClientStream }
+
+// Recv returns the next instance update.
+func (c *WatchInstanceClient) Recv() (*Instance, error) {
+	m := new(Instance)
+	if err := c.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ModelServiceClient) WatchInstance(ctx context.Context, req *GetInstanceRequest, opts ...gogrpc.CallOption) (*WatchInstanceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &modelServiceDesc.Streams[0], "/"+serviceName+"/WatchInstance", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &WatchInstanceClient{stream}, nil
+}
+
+// SimulateClient receives streamed trajectory points.
+type SimulateClient struct{ gogrpc.ClientStream }
+
+// Recv returns the next trajectory point.
+func (c *SimulateClient) Recv() (*SimulatePoint, error) {
+	m := new(SimulatePoint)
+	if err := c.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ModelServiceClient) Simulate(ctx context.Context, req *SimulateRequest, opts ...gogrpc.CallOption) (*SimulateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &modelServiceDesc.Streams[1], "/"+serviceName+"/Simulate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &SimulateClient{stream}, nil
+}
@@ -0,0 +1,268 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pflow-xyz/go-pflow/graphql"
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// ModelServiceServer is the server API for the pflow.ModelService contract.
+type ModelServiceServer interface {
+	CreateInstance(ctx context.Context, req *CreateInstanceRequest) (*Instance, error)
+	GetInstance(ctx context.Context, req *GetInstanceRequest) (*Instance, error)
+	FireTransition(ctx context.Context, req *FireTransitionRequest) (*Instance, error)
+	WatchInstance(req *GetInstanceRequest, stream InstanceStream) error
+	Simulate(req *SimulateRequest, stream SimulatePointStream) error
+}
+
+// InstanceStream sends instance updates to a watching client.
+type InstanceStream interface {
+	Send(*Instance) error
+	Context() context.Context
+}
+
+// SimulatePointStream sends trajectory points to a simulating client.
+type SimulatePointStream interface {
+	Send(*SimulatePoint) error
+	Context() context.Context
+}
+
+// Service implements ModelServiceServer over the same graphql.Store
+// abstraction the GraphQL and REST servers use.
+type Service struct {
+	models map[string]*petri.PetriNet
+	stores map[string]graphql.Store
+
+	mu       sync.Mutex
+	watchers map[string]map[chan *Instance]struct{}
+}
+
+// Option configures a Service.
+type Option func(*Service)
+
+// WithModel registers a Petri net model and its instance store.
+func WithModel(name string, model *petri.PetriNet, store graphql.Store) Option {
+	return func(s *Service) {
+		s.models[name] = model
+		s.stores[name] = store
+	}
+}
+
+// NewService creates a model execution service.
+func NewService(opts ...Option) *Service {
+	s := &Service{
+		models:   make(map[string]*petri.PetriNet),
+		stores:   make(map[string]graphql.Store),
+		watchers: make(map[string]map[chan *Instance]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewGRPCServer creates a gRPC server with the wire codec configured and
+// the model service registered.
+func NewGRPCServer(svc ModelServiceServer, opts ...gogrpc.ServerOption) *gogrpc.Server {
+	opts = append([]gogrpc.ServerOption{gogrpc.ForceServerCodec(Codec{})}, opts...)
+	server := gogrpc.NewServer(opts...)
+	RegisterModelServiceServer(server, svc)
+	return server
+}
+
+// CreateInstance implements ModelServiceServer.
+func (s *Service) CreateInstance(ctx context.Context, req *CreateInstanceRequest) (*Instance, error) {
+	store, ok := s.stores[req.Model]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown model %q", req.Model)
+	}
+	id, err := store.Create(ctx, req.Model)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	instance, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toWireInstance(instance), nil
+}
+
+// GetInstance implements ModelServiceServer.
+func (s *Service) GetInstance(ctx context.Context, req *GetInstanceRequest) (*Instance, error) {
+	instance, _, err := s.findInstance(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toWireInstance(instance), nil
+}
+
+// FireTransition implements ModelServiceServer.
+func (s *Service) FireTransition(ctx context.Context, req *FireTransitionRequest) (*Instance, error) {
+	_, store, err := s.findInstance(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := store.Fire(ctx, req.ID, req.Transition, nil)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	wire := toWireInstance(updated)
+	s.notify(req.ID, wire)
+	return wire, nil
+}
+
+// WatchInstance implements ModelServiceServer: the current state first,
+// then every marking update produced by FireTransition.
+func (s *Service) WatchInstance(req *GetInstanceRequest, stream InstanceStream) error {
+	instance, _, err := s.findInstance(stream.Context(), req.ID)
+	if err != nil {
+		return err
+	}
+	updates := make(chan *Instance, 16)
+	s.subscribe(req.ID, updates)
+	defer s.unsubscribe(req.ID, updates)
+
+	if err := stream.Send(toWireInstance(instance)); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case update := <-updates:
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Simulate implements ModelServiceServer: mass-action ODE simulation of a
+// registered model, streamed point by point.
+func (s *Service) Simulate(req *SimulateRequest, stream SimulatePointStream) error {
+	model, ok := s.models[req.Model]
+	if !ok {
+		return status.Errorf(codes.NotFound, "unknown model %q", req.Model)
+	}
+	if req.TEnd <= req.TStart {
+		return status.Errorf(codes.InvalidArgument, "empty time span [%g, %g]", req.TStart, req.TEnd)
+	}
+
+	initial := model.SetState(nil)
+	for place, value := range req.Initial {
+		if _, ok := model.Places[place]; !ok {
+			return status.Errorf(codes.InvalidArgument, "unknown place %q", place)
+		}
+		initial[place] = value
+	}
+	rates := make(map[string]float64, len(model.Transitions))
+	for transition := range model.Transitions {
+		rates[transition] = 1.0
+	}
+	for transition, rate := range req.Rates {
+		if _, ok := model.Transitions[transition]; !ok {
+			return status.Errorf(codes.InvalidArgument, "unknown transition %q", transition)
+		}
+		rates[transition] = rate
+	}
+
+	prob := solver.NewProblem(model, initial, [2]float64{req.TStart, req.TEnd}, rates)
+	sol := solver.Solve(prob, solver.Tsit5(), solver.DefaultOptions())
+
+	for i := range sol.T {
+		point := &SimulatePoint{T: sol.T[i], State: sol.U[i]}
+		if err := stream.Send(point); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findInstance locates an instance by ID across all registered stores.
+func (s *Service) findInstance(ctx context.Context, id string) (*graphql.Instance, graphql.Store, error) {
+	for _, name := range sortedKeys(s.stores) {
+		if instance, err := s.stores[name].Get(ctx, id); err == nil && instance != nil {
+			return instance, s.stores[name], nil
+		}
+	}
+	return nil, nil, status.Errorf(codes.NotFound, "unknown instance %q", id)
+}
+
+func (s *Service) subscribe(id string, ch chan *Instance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.watchers[id] == nil {
+		s.watchers[id] = make(map[chan *Instance]struct{})
+	}
+	s.watchers[id][ch] = struct{}{}
+}
+
+func (s *Service) unsubscribe(id string, ch chan *Instance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.watchers[id], ch)
+	if len(s.watchers[id]) == 0 {
+		delete(s.watchers, id)
+	}
+}
+
+// notify fans out an update to watchers; slow consumers drop updates
+// rather than block the firing path.
+func (s *Service) notify(id string, instance *Instance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.watchers[id] {
+		select {
+		case ch <- instance:
+		default:
+		}
+	}
+}
+
+func toWireInstance(instance *graphql.Instance) *Instance {
+	marking := make(map[string]int64, len(instance.Marking))
+	for place, tokens := range instance.Marking {
+		marking[place] = int64(tokens)
+	}
+	return &Instance{
+		ID:                 instance.ID,
+		Model:              instance.ModelName,
+		Version:            int64(instance.Version),
+		Marking:            marking,
+		EnabledTransitions: instance.EnabledTransitions,
+	}
+}
+
+// Codec encodes the package's wire messages; register it on both ends
+// (NewGRPCServer does so for servers).
+type Codec struct{}
+
+// Marshal implements encoding.Codec.
+func (Codec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(wireMessage)
+	if !ok {
+		return nil, fmt.Errorf("unsupported message type %T", v)
+	}
+	return msg.marshal(), nil
+}
+
+// Unmarshal implements encoding.Codec.
+func (Codec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(wireMessage)
+	if !ok {
+		return fmt.Errorf("unsupported message type %T", v)
+	}
+	return msg.unmarshal(data)
+}
+
+// Name implements encoding.Codec; "proto" because the wire format is
+// standard protobuf.
+func (Codec) Name() string { return "proto" }
@@ -0,0 +1,230 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/graphql"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func approvalNet() *petri.PetriNet {
+	model := petri.NewPetriNet()
+	model.AddPlace("pending", 1, nil, 0, 0, nil)
+	model.AddPlace("approved", 0, nil, 100, 0, nil)
+	model.AddTransition("approve", "", 50, 0, nil)
+	model.AddArc("pending", "approve", 1, false)
+	model.AddArc("approve", "approved", 1, false)
+	return model
+}
+
+func testClient(t *testing.T) *ModelServiceClient {
+	t.Helper()
+	model := approvalNet()
+	eventStore := eventsource.NewMemoryStore()
+	t.Cleanup(func() { eventStore.Close() })
+	store := graphql.NewEventSourceStore(eventStore, model, "approval")
+
+	svc := NewService(WithModel("approval", model, store))
+	server := NewGRPCServer(svc)
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := gogrpc.NewClient("passthrough:///bufnet",
+		gogrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()),
+		gogrpc.WithDefaultCallOptions(gogrpc.ForceCodec(Codec{})),
+	)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewModelServiceClient(conn)
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	created, err := client.CreateInstance(ctx, &CreateInstanceRequest{Model: "approval"})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	if created.ID == "" || created.Marking["pending"] != 1 {
+		t.Fatalf("Created instance: %+v", created)
+	}
+
+	fired, err := client.FireTransition(ctx, &FireTransitionRequest{ID: created.ID, Transition: "approve"})
+	if err != nil {
+		t.Fatalf("FireTransition: %v", err)
+	}
+	if fired.Marking["approved"] != 1 {
+		t.Errorf("Marking after fire: %v", fired.Marking)
+	}
+
+	got, err := client.GetInstance(ctx, &GetInstanceRequest{ID: created.ID})
+	if err != nil {
+		t.Fatalf("GetInstance: %v", err)
+	}
+	if got.Marking["approved"] != 1 || got.Marking["pending"] != 0 {
+		t.Errorf("Fetched marking: %v", got.Marking)
+	}
+
+	// Firing again must fail: pending is empty.
+	_, err = client.FireTransition(ctx, &FireTransitionRequest{ID: created.ID, Transition: "approve"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Refire: expected FailedPrecondition, got %v", err)
+	}
+}
+
+func TestUnknownModelAndInstance(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	_, err := client.CreateInstance(ctx, &CreateInstanceRequest{Model: "nope"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Unknown model: expected NotFound, got %v", err)
+	}
+	_, err = client.GetInstance(ctx, &GetInstanceRequest{ID: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Unknown instance: expected NotFound, got %v", err)
+	}
+}
+
+func TestWatchInstance(t *testing.T) {
+	client := testClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	created, err := client.CreateInstance(ctx, &CreateInstanceRequest{Model: "approval"})
+	if err != nil {
+		t.Fatalf("CreateInstance: %v", err)
+	}
+	watch, err := client.WatchInstance(ctx, &GetInstanceRequest{ID: created.ID})
+	if err != nil {
+		t.Fatalf("WatchInstance: %v", err)
+	}
+
+	first, err := watch.Recv()
+	if err != nil {
+		t.Fatalf("Recv initial state: %v", err)
+	}
+	if first.Marking["pending"] != 1 {
+		t.Errorf("Initial watched marking: %v", first.Marking)
+	}
+
+	if _, err := client.FireTransition(ctx, &FireTransitionRequest{ID: created.ID, Transition: "approve"}); err != nil {
+		t.Fatalf("FireTransition: %v", err)
+	}
+	update, err := watch.Recv()
+	if err != nil {
+		t.Fatalf("Recv update: %v", err)
+	}
+	if update.Marking["approved"] != 1 {
+		t.Errorf("Watched marking after fire: %v", update.Marking)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	stream, err := client.Simulate(ctx, &SimulateRequest{
+		Model:  "approval",
+		TStart: 0,
+		TEnd:   10,
+		Rates:  map[string]float64{"approve": 1.0},
+	})
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+
+	var points []*SimulatePoint
+	for {
+		point, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		points = append(points, point)
+	}
+	if len(points) < 2 {
+		t.Fatalf("Expected multiple trajectory points, got %d", len(points))
+	}
+	final := points[len(points)-1]
+	if final.State["approved"] < 0.9 {
+		t.Errorf("Expected approval to complete, final state: %v", final.State)
+	}
+}
+
+func TestSimulateValidation(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name string
+		req  *SimulateRequest
+	}{
+		{"empty span", &SimulateRequest{Model: "approval", TStart: 5, TEnd: 5}},
+		{"unknown place", &SimulateRequest{Model: "approval", TEnd: 10, Initial: map[string]float64{"nope": 1}}},
+		{"unknown transition", &SimulateRequest{Model: "approval", TEnd: 10, Rates: map[string]float64{"nope": 1}}},
+	}
+	for _, tc := range cases {
+		stream, err := client.Simulate(ctx, tc.req)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: expected InvalidArgument, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestWireRoundTrip(t *testing.T) {
+	messages := []wireMessage{
+		&CreateInstanceRequest{Model: "approval"},
+		&GetInstanceRequest{ID: "i-1"},
+		&FireTransitionRequest{ID: "i-1", Transition: "approve"},
+		&Instance{
+			ID:                 "i-1",
+			Model:              "approval",
+			Version:            3,
+			Marking:            map[string]int64{"pending": 0, "approved": 1},
+			EnabledTransitions: []string{"approve"},
+		},
+		&SimulateRequest{
+			Model:   "approval",
+			TStart:  0,
+			TEnd:    10,
+			Rates:   map[string]float64{"approve": 2.5},
+			Initial: map[string]float64{"pending": 4},
+		},
+		&SimulatePoint{T: 1.5, State: map[string]float64{"pending": 0.25, "approved": 0.75}},
+	}
+	for _, msg := range messages {
+		data := msg.marshal()
+		decoded := reflect.New(reflect.TypeOf(msg).Elem()).Interface().(wireMessage)
+		if err := decoded.unmarshal(data); err != nil {
+			t.Fatalf("%T: unmarshal: %v", msg, err)
+		}
+		if !reflect.DeepEqual(msg, decoded) {
+			t.Errorf("%T round trip:\n got %+v\nwant %+v", msg, decoded, msg)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+// Package hybrid simulates Petri nets that mix discrete events with
+// continuous dynamics. Nominated transitions fire as whole events —
+// either at scheduled times or stochastically from exponential clocks —
+// while the remaining transitions evolve as ODE fluxes between events.
+// Models like the coffeeshop previously approximated this by hand,
+// slicing a continuous simulation and patching state between slices; the
+// Simulator does the interleaving correctly and keeps one stitched
+// trajectory.
+package hybrid
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+	"github.com/pflow-xyz/go-pflow/stateutil"
+)
+
+// Event records one discrete firing attempt during a run.
+type Event struct {
+	Time       float64
+	Transition string
+	Fired      bool // false when the transition was disabled at the event time
+}
+
+// Result holds the stitched trajectory and the discrete events of a run.
+type Result struct {
+	Solution *solver.Solution
+	Events   []Event
+}
+
+// FiredCount returns how many events actually fired.
+func (r *Result) FiredCount() int {
+	n := 0
+	for _, e := range r.Events {
+		if e.Fired {
+			n++
+		}
+	}
+	return n
+}
+
+// Simulator runs hybrid simulations of a Petri net.
+type Simulator struct {
+	net        *petri.PetriNet
+	rates      map[string]float64
+	state      map[string]float64
+	schedules  map[string][]float64
+	stochastic map[string]bool
+	rateSched  map[string]*solver.RateSchedule
+	method     *solver.Solver
+	opts       *solver.Options
+	seed       int64
+}
+
+// New creates a hybrid simulator. All transitions are continuous until
+// marked discrete with Schedule or Stochastic; rates drive both the ODE
+// fluxes and the stochastic clocks.
+func New(net *petri.PetriNet, rates map[string]float64) *Simulator {
+	return &Simulator{
+		net:        net,
+		rates:      rates,
+		state:      net.SetState(nil),
+		schedules:  make(map[string][]float64),
+		stochastic: make(map[string]bool),
+		method:     solver.Tsit5(),
+		opts:       solver.DefaultOptions(),
+	}
+}
+
+// WithInitialState overrides the net's initial state.
+func (s *Simulator) WithInitialState(state map[string]float64) *Simulator {
+	s.state = stateutil.Copy(state)
+	return s
+}
+
+// WithOptions sets the solver options used for the continuous segments.
+func (s *Simulator) WithOptions(opts *solver.Options) *Simulator {
+	s.opts = opts
+	return s
+}
+
+// WithSeed fixes the random seed for stochastic clocks, making runs
+// reproducible. Zero (the default) seeds from the clock.
+func (s *Simulator) WithSeed(seed int64) *Simulator {
+	s.seed = seed
+	return s
+}
+
+// WithRateSchedules attaches hour-of-week rate schedules (see
+// solver.RateSchedule) to transitions. Scheduled rates replace the constant
+// rate in both the continuous fluxes and the stochastic clocks, so peak-hour
+// and off-hour regimes learned from a log carry into the simulation.
+func (s *Simulator) WithRateSchedules(schedules map[string]*solver.RateSchedule) *Simulator {
+	s.rateSched = schedules
+	return s
+}
+
+// Schedule makes a transition discrete and fires it at the given times.
+// Times outside the simulated span are ignored; calling Schedule again
+// adds more firings.
+func (s *Simulator) Schedule(transition string, times ...float64) *Simulator {
+	s.schedules[transition] = append(s.schedules[transition], times...)
+	return s
+}
+
+// Stochastic makes transitions discrete with exponential firing clocks.
+// Each clock's propensity is the transition's rate times the product of
+// its input place markings (mass-action), re-evaluated after every event;
+// continuous drift within a segment is not integrated into the hazard.
+// Transitions carrying a fitted duration distribution (see
+// petri.PetriNet.SetDuration) sample their delay from it instead.
+func (s *Simulator) Stochastic(transitions ...string) *Simulator {
+	for _, t := range transitions {
+		s.stochastic[t] = true
+	}
+	return s
+}
+
+// Run simulates over the time span and returns the stitched trajectory
+// with the discrete events that occurred.
+func (s *Simulator) Run(tspan [2]float64) (*Result, error) {
+	for trans := range s.schedules {
+		if _, ok := s.net.Transitions[trans]; !ok {
+			return nil, fmt.Errorf("scheduled transition not found: %s", trans)
+		}
+	}
+	for trans := range s.stochastic {
+		if _, ok := s.net.Transitions[trans]; !ok {
+			return nil, fmt.Errorf("stochastic transition not found: %s", trans)
+		}
+	}
+	for trans := range s.rateSched {
+		if _, ok := s.net.Transitions[trans]; !ok {
+			return nil, fmt.Errorf("scheduled-rate transition not found: %s", trans)
+		}
+	}
+
+	contRates, contSched := s.continuousRates()
+
+	seed := s.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	// Scheduled events inside the span, in time order.
+	var schedule []Event
+	for trans, times := range s.schedules {
+		for _, t := range times {
+			if t >= tspan[0] && t <= tspan[1] {
+				schedule = append(schedule, Event{Time: t, Transition: trans})
+			}
+		}
+	}
+	sortEvents(schedule)
+
+	result := &Result{}
+	state := stateutil.Copy(s.state)
+	t := tspan[0]
+	next := 0
+
+	for t < tspan[1] {
+		// Next event: the earlier of the next scheduled firing and the
+		// winning stochastic clock, clipped to the end of the span.
+		te := tspan[1]
+		trans := ""
+		if next < len(schedule) && schedule[next].Time < te {
+			te = schedule[next].Time
+			trans = schedule[next].Transition
+		}
+		if st, stTime := s.sampleStochastic(rng, state, t); st != "" && stTime < te {
+			te = stTime
+			trans = st
+		}
+		// Rate schedules are piecewise-constant by hour; cap the segment
+		// at the next rate change so stochastic clocks re-arm under the
+		// new rate and the ODE never integrates across a discontinuity.
+		for _, sched := range s.rateSched {
+			if b := sched.NextChange(t); b < te {
+				te = b
+				trans = ""
+			}
+		}
+
+		if te > t {
+			prob := solver.NewProblem(s.net, state, [2]float64{t, te}, contRates)
+			if len(contSched) > 0 {
+				prob.WithRateSchedules(contSched)
+			}
+			seg := solver.Solve(prob, s.method, s.opts)
+			if result.Solution == nil {
+				result.Solution = seg
+			} else {
+				result.Solution.Append(seg)
+			}
+			state = stateutil.Copy(result.Solution.GetFinalState())
+		}
+
+		if trans != "" {
+			fired := s.fire(trans, state)
+			result.Events = append(result.Events, Event{Time: te, Transition: trans, Fired: fired})
+			if next < len(schedule) && schedule[next].Time == te && schedule[next].Transition == trans {
+				next++
+			}
+		}
+		t = te
+	}
+
+	if result.Solution == nil {
+		// Degenerate span: no continuous segment ever ran.
+		result.Solution = &solver.Solution{
+			T:           []float64{tspan[0]},
+			U:           []map[string]float64{stateutil.Copy(state)},
+			StateLabels: stateLabels(state),
+		}
+	}
+	return result, nil
+}
+
+// continuousRates returns the rates and rate schedules the ODE segments
+// integrate with. Discrete transitions contribute no continuous flux; rate
+// schedules on them are consulted by the stochastic clocks instead.
+func (s *Simulator) continuousRates() (map[string]float64, map[string]*solver.RateSchedule) {
+	contRates := make(map[string]float64, len(s.rates))
+	for k, v := range s.rates {
+		contRates[k] = v
+	}
+	for trans := range s.schedules {
+		contRates[trans] = 0
+	}
+	for trans := range s.stochastic {
+		contRates[trans] = 0
+	}
+
+	contSched := make(map[string]*solver.RateSchedule)
+	for trans, sched := range s.rateSched {
+		if _, discrete := s.schedules[trans]; discrete {
+			continue
+		}
+		if s.stochastic[trans] {
+			continue
+		}
+		contSched[trans] = sched
+	}
+	return contRates, contSched
+}
+
+// Cost accumulates the net's cost annotations over a run: holding cost
+// integrated along the stitched trajectory, continuous firing cost from the
+// ODE fluxes, and one per-firing cost for each discrete event that fired.
+// See petri.PetriNet.SetFiringCost and SetHoldingCost.
+func (s *Simulator) Cost(result *Result) *solver.CostReport {
+	sol := result.Solution
+	contRates, contSched := s.continuousRates()
+	span := [2]float64{sol.T[0], sol.T[len(sol.T)-1]}
+	prob := solver.NewProblem(s.net, sol.U[0], span, contRates)
+	if len(contSched) > 0 {
+		prob.WithRateSchedules(contSched)
+	}
+	report := solver.AccumulateCost(prob, sol)
+
+	for _, e := range result.Events {
+		if !e.Fired {
+			continue
+		}
+		if tr, ok := s.net.Transitions[e.Transition]; ok && tr.FiringCost != 0 {
+			report.Firing[e.Transition] += tr.FiringCost
+			report.Total += tr.FiringCost
+		}
+	}
+	return report
+}
+
+// sampleStochastic draws the earliest stochastic firing after t, using
+// each clock's mass-action propensity at the current state.
+func (s *Simulator) sampleStochastic(rng *rand.Rand, state map[string]float64, t float64) (string, float64) {
+	best := ""
+	bestTime := math.Inf(1)
+	for trans := range s.stochastic {
+		propensity := s.rates[trans]
+		if sched, ok := s.rateSched[trans]; ok {
+			propensity = sched.At(t)
+		}
+		for _, arc := range s.net.Arcs {
+			if arc.Target == trans && !arc.InhibitTransition {
+				if _, ok := s.net.Places[arc.Source]; ok {
+					propensity *= state[arc.Source]
+				}
+			}
+		}
+		if propensity <= 0 {
+			continue
+		}
+		var when float64
+		if tr := s.net.Transitions[trans]; tr.Duration != nil {
+			// A fitted duration distribution overrides the exponential clock.
+			when = t + tr.Duration.Sample(rng)
+		} else {
+			when = t + rng.ExpFloat64()/propensity
+		}
+		if when < bestTime {
+			best = trans
+			bestTime = when
+		}
+	}
+	return best, bestTime
+}
+
+// fire applies one discrete firing to the state, honoring inhibitor
+// arcs, guards, and capacity policies. Reports whether it fired.
+func (s *Simulator) fire(trans string, state map[string]float64) bool {
+	// Enablement against the continuous state.
+	for _, arc := range s.net.Arcs {
+		if arc.Target != trans {
+			continue
+		}
+		if _, ok := s.net.Places[arc.Source]; !ok {
+			continue
+		}
+		if arc.InhibitTransition {
+			if state[arc.Source] > 0 {
+				return false
+			}
+		} else if state[arc.Source] < arc.GetWeightSum() {
+			return false
+		}
+	}
+	if tr, ok := s.net.Transitions[trans]; ok && tr.Guard != "" {
+		if ok, err := s.net.GuardSatisfied(trans, state); err != nil || !ok {
+			return false
+		}
+	}
+
+	// Blocking capacity check before any tokens move.
+	for _, arc := range s.net.Arcs {
+		if arc.Source != trans {
+			continue
+		}
+		if p, ok := s.net.Places[arc.Target]; ok && p.Overflow == petri.OverflowBlock {
+			if c := p.GetCapacity(); c > 0 && state[arc.Target]+arc.GetWeightSum() > c {
+				return false
+			}
+		}
+	}
+
+	for _, arc := range s.net.Arcs {
+		if arc.Target == trans && !arc.InhibitTransition {
+			if _, ok := s.net.Places[arc.Source]; ok {
+				state[arc.Source] -= arc.GetWeightSum()
+			}
+		}
+		if arc.Source == trans {
+			if p, ok := s.net.Places[arc.Target]; ok {
+				state[arc.Target] += arc.GetWeightSum()
+				if c := p.GetCapacity(); c > 0 && p.Overflow == petri.OverflowDiscard && state[arc.Target] > c {
+					state[arc.Target] = c
+				}
+			}
+		}
+	}
+	return true
+}
+
+// sortEvents orders scheduled events by time.
+func sortEvents(events []Event) {
+	sort.Slice(events, func(i, j int) bool { return events[i].Time < events[j].Time })
+}
+
+// stateLabels returns the place labels of a state map in sorted order.
+func stateLabels(state map[string]float64) []string {
+	labels := make([]string, 0, len(state))
+	for k := range state {
+		labels = append(labels, k)
+	}
+	sort.Strings(labels)
+	return labels
+}
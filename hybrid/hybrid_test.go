@@ -0,0 +1,237 @@
+package hybrid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+func TestScheduledRefill(t *testing.T) {
+	// Continuous conversion A -> B with a discrete refill of A at t=5.
+	net := petri.Build().
+		Place("A", 10).Place("B", 0).
+		Transition("convert").Transition("refill").
+		Arc("A", "convert", 1).Arc("convert", "B", 1).
+		Arc("refill", "A", 5).
+		Done()
+	rates := map[string]float64{"convert": 0.1}
+
+	result, err := New(net, rates).
+		Schedule("refill", 5).
+		Run([2]float64{0, 10})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(result.Events) != 1 || !result.Events[0].Fired {
+		t.Fatalf("Expected one fired refill event, got %v", result.Events)
+	}
+	if result.Events[0].Time != 5 {
+		t.Errorf("Expected refill at t=5, got %f", result.Events[0].Time)
+	}
+
+	// The refill injects 5 tokens, so the total jumps from 10 to 15.
+	final := result.Solution.GetFinalState()
+	if total := final["A"] + final["B"]; math.Abs(total-15) > 0.01 {
+		t.Errorf("Expected total 15 after refill, got %f", total)
+	}
+
+	// The trajectory shows the jump: A just after t=5 exceeds A just before.
+	before := result.Solution.GetVariableAt("A", 4.99)
+	after := result.Solution.GetVariableAt("A", 5.01)
+	if after-before < 4 {
+		t.Errorf("Expected A to jump ~5 at the event, got %f -> %f", before, after)
+	}
+}
+
+func TestDisabledEventIsRecorded(t *testing.T) {
+	// consume needs 5 tokens but the place drains continuously first.
+	net := petri.Build().
+		Place("A", 1).
+		Transition("drain").Transition("consume").
+		Arc("A", "drain", 1).
+		Arc("A", "consume", 5).
+		Done()
+	rates := map[string]float64{"drain": 1.0}
+
+	result, err := New(net, rates).
+		Schedule("consume", 2).
+		Run([2]float64{0, 4})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(result.Events) != 1 || result.Events[0].Fired {
+		t.Fatalf("Expected one skipped event, got %v", result.Events)
+	}
+	if result.FiredCount() != 0 {
+		t.Errorf("Expected no fired events, got %d", result.FiredCount())
+	}
+}
+
+func TestStochasticArrivals(t *testing.T) {
+	// A source place keeps the arrival clock's propensity constant.
+	net := petri.Build().
+		Place("source", 1).Place("queue", 0).
+		Transition("arrive").
+		Arc("source", "arrive", 1).
+		Arc("arrive", "source", 1).
+		Arc("arrive", "queue", 1).
+		Done()
+	rates := map[string]float64{"arrive": 2.0}
+
+	result, err := New(net, rates).
+		Stochastic("arrive").
+		WithSeed(42).
+		Run([2]float64{0, 50})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Rate 2 over 50 time units: ~100 arrivals. Allow generous slack.
+	fired := result.FiredCount()
+	if fired < 60 || fired > 140 {
+		t.Errorf("Expected ~100 arrivals, got %d", fired)
+	}
+	final := result.Solution.GetFinalState()
+	if int(final["queue"]) != fired {
+		t.Errorf("Queue %f does not match %d fired events", final["queue"], fired)
+	}
+
+	// Same seed, same run.
+	again, err := New(net, rates).Stochastic("arrive").WithSeed(42).Run([2]float64{0, 50})
+	if err != nil {
+		t.Fatalf("Second run failed: %v", err)
+	}
+	if again.FiredCount() != fired {
+		t.Errorf("Expected reproducible run with fixed seed: %d vs %d", again.FiredCount(), fired)
+	}
+}
+
+func TestUnknownTransition(t *testing.T) {
+	net := petri.Build().Place("A", 1).Transition("t").Arc("A", "t", 1).Done()
+	if _, err := New(net, nil).Schedule("missing", 1).Run([2]float64{0, 1}); err == nil {
+		t.Error("Expected error for unknown scheduled transition")
+	}
+	if _, err := New(net, nil).Stochastic("missing").Run([2]float64{0, 1}); err == nil {
+		t.Error("Expected error for unknown stochastic transition")
+	}
+}
+
+func TestStochasticRateSchedule(t *testing.T) {
+	// Arrivals are closed for the first two hours of the schedule, then
+	// open; every firing should land in the open slots.
+	net := petri.Build().
+		Place("source", 1).Place("queue", 0).
+		Transition("arrive").
+		Arc("source", "arrive", 1).
+		Arc("arrive", "source", 1).
+		Arc("arrive", "queue", 1).
+		Done()
+
+	hourly := make([]float64, solver.HoursPerWeek)
+	for i := 2; i < solver.HoursPerWeek; i++ {
+		hourly[i] = 0.01
+	}
+	sched := map[string]*solver.RateSchedule{"arrive": solver.NewRateSchedule(hourly)}
+
+	result, err := New(net, map[string]float64{"arrive": 1.0}).
+		Stochastic("arrive").
+		WithRateSchedules(sched).
+		WithSeed(7).
+		Run([2]float64{0, 4 * 3600})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.FiredCount() == 0 {
+		t.Fatal("Expected arrivals during open hours")
+	}
+	for _, e := range result.Events {
+		if e.Time < 7200 {
+			t.Errorf("Arrival at t=%f inside closed hours", e.Time)
+		}
+	}
+
+	if _, err := New(net, nil).
+		WithRateSchedules(map[string]*solver.RateSchedule{"missing": solver.ConstantRateSchedule(1)}).
+		Run([2]float64{0, 1}); err == nil {
+		t.Error("Expected error for unknown scheduled-rate transition")
+	}
+}
+
+func TestContinuousRateSchedule(t *testing.T) {
+	// A continuous conversion whose rate is zero in the first hour: the ODE
+	// segments should see the schedule, not the constant rate.
+	net := petri.Build().
+		Place("A", 100).Place("B", 0).
+		Transition("convert").
+		Arc("A", "convert", 1).Arc("convert", "B", 1).
+		Done()
+
+	hourly := make([]float64, solver.HoursPerWeek)
+	for i := 1; i < solver.HoursPerWeek; i++ {
+		hourly[i] = 0.01
+	}
+	sched := map[string]*solver.RateSchedule{"convert": solver.NewRateSchedule(hourly)}
+
+	result, err := New(net, map[string]float64{"convert": 1.0}).
+		WithRateSchedules(sched).
+		Run([2]float64{0, 1800})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if b := result.Solution.GetFinalState()["B"]; b > 1e-6 {
+		t.Errorf("Expected no flow during closed hour, got B=%f", b)
+	}
+
+	result, err = New(net, map[string]float64{"convert": 1.0}).
+		WithRateSchedules(sched).
+		Run([2]float64{0, 2 * 3600})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if b := result.Solution.GetFinalState()["B"]; b < 1 {
+		t.Errorf("Expected flow after opening, got B=%f", b)
+	}
+}
+
+func TestRunCost(t *testing.T) {
+	// Continuous conversion with annotated costs plus a discrete refill
+	// charged per firing.
+	net := petri.Build().
+		Place("A", 10).Place("B", 0).
+		Transition("convert").Transition("refill").
+		Arc("A", "convert", 1).Arc("convert", "B", 1).
+		Arc("refill", "A", 5).
+		FiringCost("convert", 1).
+		FiringCost("refill", 20).
+		HoldingCost("B", 0.1).
+		Done()
+	rates := map[string]float64{"convert": 0.1}
+
+	sim := New(net, rates).Schedule("refill", 5)
+	result, err := sim.Run([2]float64{0, 10})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	report := sim.Cost(result)
+	if report.Firing["refill"] != 20 {
+		t.Errorf("Refill cost = %f, want 20 (one firing)", report.Firing["refill"])
+	}
+	// Continuous firing cost tracks the tokens converted into B.
+	converted := result.Solution.GetFinalState()["B"]
+	if math.Abs(report.Firing["convert"]-converted)/converted > 0.05 {
+		t.Errorf("Convert cost = %f, want ~%f", report.Firing["convert"], converted)
+	}
+	if report.Holding["B"] <= 0 {
+		t.Errorf("Expected positive holding cost for B, got %f", report.Holding["B"])
+	}
+	want := report.Firing["convert"] + report.Firing["refill"] + report.Holding["B"]
+	if math.Abs(report.Total-want) > 1e-9 {
+		t.Errorf("Total %f does not match components %f", report.Total, want)
+	}
+}
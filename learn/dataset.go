@@ -112,49 +112,18 @@ func RelativeMSELoss(sol *solver.Solution, data *Dataset) float64 {
 	return totalError / float64(numPoints)
 }
 
-// InterpolateSolution interpolates a solution at given time points.
-// Uses linear interpolation between solution time points.
+// InterpolateSolution interpolates a solution at given time points. It
+// uses the solver's dense output (cubic Hermite through the accepted
+// steps) when available, falling back to linear interpolation for
+// solutions without derivative data.
 func InterpolateSolution(sol *solver.Solution, times []float64, place string) []float64 {
 	result := make([]float64, len(times))
-
-	// Get solution times and values for the place
-	solTimes := sol.T
-	solValues := sol.GetVariable(place)
-
 	for i, t := range times {
-		result[i] = interpolateAt(solTimes, solValues, t)
+		result[i] = sol.GetVariableAt(place, t)
 	}
-
 	return result
 }
 
-// interpolateAt performs linear interpolation at a single time point.
-func interpolateAt(times []float64, values []float64, t float64) float64 {
-	// Handle edge cases
-	if t <= times[0] {
-		return values[0]
-	}
-	if t >= times[len(times)-1] {
-		return values[len(values)-1]
-	}
-
-	// Find the bracketing indices
-	for i := 0; i < len(times)-1; i++ {
-		if times[i] <= t && t <= times[i+1] {
-			// Linear interpolation
-			dt := times[i+1] - times[i]
-			if dt == 0 {
-				return values[i]
-			}
-			alpha := (t - times[i]) / dt
-			return values[i]*(1-alpha) + values[i+1]*alpha
-		}
-	}
-
-	// Should not reach here, but return last value as fallback
-	return values[len(values)-1]
-}
-
 // GenerateUniformTimes generates uniformly spaced time points.
 func GenerateUniformTimes(t0, tf float64, n int) []float64 {
 	times := make([]float64, n)
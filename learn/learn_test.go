@@ -1,6 +1,7 @@
 package learn
 
 import (
+	"context"
 	"math"
 	"testing"
 
@@ -462,3 +463,58 @@ func TestFitSimpleRecovery(t *testing.T) {
 		t.Errorf("Final loss too high: %.4f", result.FinalLoss)
 	}
 }
+
+func TestMinimizeContextReturnsBestSoFar(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	objective := func(x []float64) float64 {
+		calls++
+		if calls > 20 {
+			cancel()
+		}
+		return (x[0] - 3) * (x[0] - 3)
+	}
+
+	result, err := MinimizeContext(ctx, objective, []float64{0}, nil)
+	if err == nil {
+		t.Error("Expected context error after cancellation")
+	}
+	if result == nil {
+		t.Fatal("Expected best-so-far result despite cancellation")
+	}
+	if result.Converged {
+		t.Error("Expected unconverged result under cancellation")
+	}
+	// The partial search should still have improved on the start point.
+	if result.FinalLoss > result.InitialLoss {
+		t.Errorf("Expected loss not to regress: %f > %f", result.FinalLoss, result.InitialLoss)
+	}
+}
+
+func TestMinimizeProgressCallback(t *testing.T) {
+	var reports []Progress
+	opts := DefaultFitOptions()
+	opts.Progress = func(p Progress) { reports = append(reports, p) }
+
+	objective := func(x []float64) float64 {
+		return (x[0] - 3) * (x[0] - 3)
+	}
+	result, err := Minimize(objective, []float64{0}, opts)
+	if err != nil {
+		t.Fatalf("Minimize failed: %v", err)
+	}
+
+	if len(reports) == 0 {
+		t.Fatal("Expected progress callbacks during optimization")
+	}
+	last := reports[len(reports)-1]
+	if last.MaxIters != opts.MaxIters {
+		t.Errorf("Expected MaxIters %d in report, got %d", opts.MaxIters, last.MaxIters)
+	}
+	if last.Loss > reports[0].Loss {
+		t.Errorf("Expected loss not to regress: %f > %f", last.Loss, reports[0].Loss)
+	}
+	if last.Loss != result.FinalLoss {
+		t.Errorf("Last reported loss %f != final loss %f", last.Loss, result.FinalLoss)
+	}
+}
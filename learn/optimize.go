@@ -1,19 +1,31 @@
 package learn
 
 import (
+	"context"
 	"fmt"
 	"math"
 
 	"github.com/pflow-xyz/go-pflow/solver"
 )
 
+// Progress is a snapshot of the optimizer state handed to the
+// FitOptions.Progress callback once per iteration. Percent is measured
+// against MaxIters, so a fit that converges early stops short of 100.
+type Progress struct {
+	Iteration int     // Iterations completed so far
+	MaxIters  int     // Iteration budget
+	Percent   float64 // 0-100, fraction of the iteration budget used
+	Loss      float64 // Best loss found so far
+}
+
 // FitOptions configures the parameter fitting process.
 type FitOptions struct {
-	MaxIters      int     // Maximum number of iterations
-	Tolerance     float64 // Convergence tolerance for loss
-	Method        string  // Optimization method: "nelder-mead", "coordinate-descent"
-	StepSize      float64 // Initial step size (for coordinate descent)
-	Verbose       bool    // Print progress during optimization
+	MaxIters      int            // Maximum number of iterations
+	Tolerance     float64        // Convergence tolerance for loss
+	Method        string         // Optimization method: "nelder-mead", "coordinate-descent"
+	StepSize      float64        // Initial step size (for coordinate descent)
+	Verbose       bool           // Print progress during optimization
+	Progress      func(Progress) // Optional per-iteration progress callback (nil = none)
 	SolverMethod  *solver.Solver
 	SolverOptions *solver.Options
 }
@@ -42,6 +54,13 @@ type FitResult struct {
 
 // Fit optimizes the parameters of a LearnableProblem to minimize the loss on a dataset.
 func Fit(prob *LearnableProblem, data *Dataset, lossFunc LossFunc, opts *FitOptions) (*FitResult, error) {
+	return FitContext(context.Background(), prob, data, lossFunc, opts)
+}
+
+// FitContext is Fit with cooperative cancellation: when the context is
+// canceled or its deadline passes, the search stops and the best-so-far
+// parameters are returned alongside the context's error.
+func FitContext(ctx context.Context, prob *LearnableProblem, data *Dataset, lossFunc LossFunc, opts *FitOptions) (*FitResult, error) {
 	if opts == nil {
 		opts = DefaultFitOptions()
 	}
@@ -69,6 +88,41 @@ func Fit(prob *LearnableProblem, data *Dataset, lossFunc LossFunc, opts *FitOpti
 	}
 
 	// Run optimization
+	result, err := MinimizeContext(ctx, objective, initialParams, opts)
+	if result == nil {
+		return nil, err
+	}
+	result.InitialLoss = initialLoss
+
+	// Set final parameters
+	prob.SetAllParams(result.Params, indices)
+
+	if opts.Verbose {
+		fmt.Printf("Final loss: %.6f\n", result.FinalLoss)
+		fmt.Printf("Final params: %v\n", result.Params)
+		fmt.Printf("Iterations: %d, Converged: %v\n", result.Iterations, result.Converged)
+	}
+
+	return result, err
+}
+
+// Minimize runs the configured optimization method on an arbitrary
+// objective function. It backs Fit and is exported for callers that
+// optimize something other than a dataset loss (e.g. the optimize
+// package searching simulation outputs).
+func Minimize(f func([]float64) float64, x0 []float64, opts *FitOptions) (*FitResult, error) {
+	return MinimizeContext(context.Background(), f, x0, opts)
+}
+
+// MinimizeContext is Minimize with cooperative cancellation. On
+// cancellation it returns the best point found so far together with the
+// context's error; the result is never marked converged in that case.
+func MinimizeContext(ctx context.Context, f func([]float64) float64, x0 []float64, opts *FitOptions) (*FitResult, error) {
+	if opts == nil {
+		opts = DefaultFitOptions()
+	}
+	initialLoss := f(x0)
+
 	var finalParams []float64
 	var finalLoss float64
 	var iters int
@@ -76,33 +130,36 @@ func Fit(prob *LearnableProblem, data *Dataset, lossFunc LossFunc, opts *FitOpti
 
 	switch opts.Method {
 	case "nelder-mead":
-		finalParams, finalLoss, iters, converged = nelderMead(objective, initialParams, opts)
+		finalParams, finalLoss, iters, converged = nelderMead(ctx, f, x0, opts)
 	case "coordinate-descent":
-		finalParams, finalLoss, iters, converged = coordinateDescent(objective, initialParams, opts)
+		finalParams, finalLoss, iters, converged = coordinateDescent(ctx, f, x0, opts)
 	default:
 		return nil, fmt.Errorf("unknown optimization method: %s", opts.Method)
 	}
 
-	// Set final parameters
-	prob.SetAllParams(finalParams, indices)
-
-	if opts.Verbose {
-		fmt.Printf("Final loss: %.6f\n", finalLoss)
-		fmt.Printf("Final params: %v\n", finalParams)
-		fmt.Printf("Iterations: %d, Converged: %v\n", iters, converged)
-	}
-
 	return &FitResult{
 		Params:      finalParams,
 		InitialLoss: initialLoss,
 		FinalLoss:   finalLoss,
 		Iterations:  iters,
 		Converged:   converged,
-	}, nil
+	}, ctx.Err()
+}
+
+// reportProgress invokes the configured progress callback, if any.
+func (opts *FitOptions) reportProgress(iter int, loss float64) {
+	if opts.Progress == nil {
+		return
+	}
+	pct := 0.0
+	if opts.MaxIters > 0 {
+		pct = float64(iter) / float64(opts.MaxIters) * 100
+	}
+	opts.Progress(Progress{Iteration: iter, MaxIters: opts.MaxIters, Percent: pct, Loss: loss})
 }
 
 // coordinateDescent implements simple coordinate descent optimization.
-func coordinateDescent(f func([]float64) float64, x0 []float64, opts *FitOptions) ([]float64, float64, int, bool) {
+func coordinateDescent(ctx context.Context, f func([]float64) float64, x0 []float64, opts *FitOptions) ([]float64, float64, int, bool) {
 	x := make([]float64, len(x0))
 	copy(x, x0)
 
@@ -110,6 +167,9 @@ func coordinateDescent(f func([]float64) float64, x0 []float64, opts *FitOptions
 	stepSize := opts.StepSize
 
 	for iter := 0; iter < opts.MaxIters; iter++ {
+		if ctx.Err() != nil {
+			return x, bestLoss, iter, false
+		}
 		improved := false
 
 		// Try updating each coordinate
@@ -138,6 +198,8 @@ func coordinateDescent(f func([]float64) float64, x0 []float64, opts *FitOptions
 			}
 		}
 
+		opts.reportProgress(iter+1, bestLoss)
+
 		if opts.Verbose && iter%100 == 0 {
 			fmt.Printf("Iter %d: loss = %.6f\n", iter, bestLoss)
 		}
@@ -159,7 +221,7 @@ func coordinateDescent(f func([]float64) float64, x0 []float64, opts *FitOptions
 }
 
 // nelderMead implements the Nelder-Mead simplex algorithm.
-func nelderMead(f func([]float64) float64, x0 []float64, opts *FitOptions) ([]float64, float64, int, bool) {
+func nelderMead(ctx context.Context, f func([]float64) float64, x0 []float64, opts *FitOptions) ([]float64, float64, int, bool) {
 	n := len(x0)
 
 	// Algorithm parameters
@@ -189,6 +251,12 @@ func nelderMead(f func([]float64) float64, x0 []float64, opts *FitOptions) ([]fl
 		// Sort simplex by function values
 		sortSimplex(simplex, values)
 
+		if ctx.Err() != nil {
+			return simplex[0], values[0], iter, false
+		}
+
+		opts.reportProgress(iter, values[0])
+
 		if opts.Verbose && iter%100 == 0 {
 			fmt.Printf("Iter %d: best = %.6f, worst = %.6f\n", iter, values[0], values[n])
 		}
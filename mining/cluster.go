@@ -0,0 +1,285 @@
+package mining
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// Trace clustering.
+//
+// Heterogeneous logs — a hospital log mixing emergency and elective
+// flows, an incident log mixing P0 escalations with routine tickets —
+// contain behavioral variants that no single discovered model represents
+// well. Clustering segments the traces first, so Discover can build one
+// model per cluster.
+
+// ClusterResult holds cluster assignments and per-cluster sub-logs.
+type ClusterResult struct {
+	Assignments map[string]int       // case ID -> cluster index
+	Clusters    []*eventlog.EventLog // sub-log per cluster, ready for Discover
+	Sizes       []int                // traces per cluster
+}
+
+// ClusterTraces segments a log into k behavioral clusters using
+// activity-profile features (per-trace activity counts, k-means).
+func ClusterTraces(log *eventlog.EventLog, k int) (*ClusterResult, error) {
+	return ClusterTracesWith(log, k, "activity-profile")
+}
+
+// ClusterTracesWith clusters with an explicit method:
+//
+//	activity-profile - k-means on per-trace activity count vectors;
+//	                   fast, ignores ordering
+//	edit-distance    - k-medoids on activity sequence edit distance;
+//	                   order-sensitive, quadratic in trace count
+func ClusterTracesWith(log *eventlog.EventLog, k int, method string) (*ClusterResult, error) {
+	caseIDs := make([]string, 0, len(log.Cases))
+	for caseID := range log.Cases {
+		caseIDs = append(caseIDs, caseID)
+	}
+	sort.Strings(caseIDs)
+
+	if k < 1 {
+		return nil, fmt.Errorf("k must be at least 1, got %d", k)
+	}
+	if k > len(caseIDs) {
+		return nil, fmt.Errorf("k=%d exceeds the %d traces in the log", k, len(caseIDs))
+	}
+
+	var labels []int
+	switch method {
+	case "activity-profile":
+		labels = kmeansProfiles(profileVectors(log, caseIDs), k)
+	case "edit-distance":
+		labels = kmedoids(editDistances(log, caseIDs), k)
+	default:
+		return nil, fmt.Errorf("unknown clustering method: %s (use activity-profile or edit-distance)", method)
+	}
+
+	result := &ClusterResult{
+		Assignments: make(map[string]int, len(caseIDs)),
+		Clusters:    make([]*eventlog.EventLog, k),
+		Sizes:       make([]int, k),
+	}
+	for i := range result.Clusters {
+		result.Clusters[i] = eventlog.NewEventLog()
+	}
+	for i, caseID := range caseIDs {
+		cluster := labels[i]
+		result.Assignments[caseID] = cluster
+		result.Clusters[cluster].Cases[caseID] = log.Cases[caseID]
+		result.Sizes[cluster]++
+	}
+	return result, nil
+}
+
+// profileVectors builds one activity-count vector per trace, over the
+// log's full activity alphabet.
+func profileVectors(log *eventlog.EventLog, caseIDs []string) [][]float64 {
+	activities := log.GetActivities()
+	sort.Strings(activities)
+	index := make(map[string]int, len(activities))
+	for i, a := range activities {
+		index[a] = i
+	}
+
+	vectors := make([][]float64, len(caseIDs))
+	for i, caseID := range caseIDs {
+		vec := make([]float64, len(activities))
+		for _, ev := range log.Cases[caseID].Events {
+			vec[index[ev.Activity]]++
+		}
+		vectors[i] = vec
+	}
+	return vectors
+}
+
+// kmeansProfiles runs deterministic k-means: farthest-first seeding from
+// the first trace, then standard assign/update until stable.
+func kmeansProfiles(vectors [][]float64, k int) []int {
+	centers := make([][]float64, 0, k)
+	centers = append(centers, append([]float64(nil), vectors[0]...))
+	for len(centers) < k {
+		best, bestDist := 0, -1.0
+		for i, vec := range vectors {
+			d := math.Inf(1)
+			for _, c := range centers {
+				if dd := sqDist(vec, c); dd < d {
+					d = dd
+				}
+			}
+			if d > bestDist {
+				best, bestDist = i, d
+			}
+		}
+		centers = append(centers, append([]float64(nil), vectors[best]...))
+	}
+
+	labels := make([]int, len(vectors))
+	for iter := 0; iter < 100; iter++ {
+		changed := false
+		for i, vec := range vectors {
+			best, bestDist := 0, math.Inf(1)
+			for c, center := range centers {
+				if d := sqDist(vec, center); d < bestDist {
+					best, bestDist = c, d
+				}
+			}
+			if labels[i] != best {
+				labels[i] = best
+				changed = true
+			}
+		}
+		if !changed && iter > 0 {
+			break
+		}
+		for c := range centers {
+			count := 0
+			sum := make([]float64, len(centers[c]))
+			for i, vec := range vectors {
+				if labels[i] == c {
+					count++
+					for j, v := range vec {
+						sum[j] += v
+					}
+				}
+			}
+			if count == 0 {
+				continue // keep the old center for empty clusters
+			}
+			for j := range sum {
+				sum[j] /= float64(count)
+			}
+			centers[c] = sum
+		}
+	}
+	return labels
+}
+
+// sqDist is the squared Euclidean distance between two vectors.
+func sqDist(a, b []float64) float64 {
+	d := 0.0
+	for i := range a {
+		diff := a[i] - b[i]
+		d += diff * diff
+	}
+	return d
+}
+
+// editDistances builds the pairwise edit-distance matrix over the
+// traces' activity sequences.
+func editDistances(log *eventlog.EventLog, caseIDs []string) [][]float64 {
+	variants := make([][]string, len(caseIDs))
+	for i, caseID := range caseIDs {
+		variants[i] = log.Cases[caseID].GetActivityVariant()
+	}
+
+	dist := make([][]float64, len(caseIDs))
+	for i := range dist {
+		dist[i] = make([]float64, len(caseIDs))
+	}
+	for i := 0; i < len(caseIDs); i++ {
+		for j := i + 1; j < len(caseIDs); j++ {
+			d := float64(editDistance(variants[i], variants[j]))
+			dist[i][j] = d
+			dist[j][i] = d
+		}
+	}
+	return dist
+}
+
+// editDistance is the Levenshtein distance between activity sequences.
+func editDistance(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// kmedoids runs deterministic k-medoids on a distance matrix:
+// farthest-first seeding, then alternate assignment and medoid updates.
+func kmedoids(dist [][]float64, k int) []int {
+	n := len(dist)
+	medoids := make([]int, 0, k)
+	medoids = append(medoids, 0)
+	for len(medoids) < k {
+		best, bestDist := 0, -1.0
+		for i := 0; i < n; i++ {
+			d := math.Inf(1)
+			for _, m := range medoids {
+				if dist[i][m] < d {
+					d = dist[i][m]
+				}
+			}
+			if d > bestDist {
+				best, bestDist = i, d
+			}
+		}
+		medoids = append(medoids, best)
+	}
+
+	labels := make([]int, n)
+	for iter := 0; iter < 100; iter++ {
+		changed := false
+		for i := 0; i < n; i++ {
+			best, bestDist := 0, math.Inf(1)
+			for c, m := range medoids {
+				if dist[i][m] < bestDist {
+					best, bestDist = c, dist[i][m]
+				}
+			}
+			if labels[i] != best {
+				labels[i] = best
+				changed = true
+			}
+		}
+		if !changed && iter > 0 {
+			break
+		}
+		for c := range medoids {
+			bestMedoid, bestCost := medoids[c], math.Inf(1)
+			for i := 0; i < n; i++ {
+				if labels[i] != c {
+					continue
+				}
+				cost := 0.0
+				for j := 0; j < n; j++ {
+					if labels[j] == c {
+						cost += dist[i][j]
+					}
+				}
+				if cost < bestCost {
+					bestMedoid, bestCost = i, cost
+				}
+			}
+			medoids[c] = bestMedoid
+		}
+	}
+	return labels
+}
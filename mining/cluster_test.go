@@ -0,0 +1,96 @@
+package mining
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// mixedLog builds a log with two clearly distinct variants.
+func mixedLog() *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	add := func(caseID string, activities ...string) {
+		for i, a := range activities {
+			log.AddEvent(eventlog.Event{
+				CaseID:    caseID,
+				Activity:  a,
+				Timestamp: base.Add(time.Duration(i) * time.Minute),
+			})
+		}
+	}
+	for i := 0; i < 5; i++ {
+		add(fmt.Sprintf("fast%d", i), "triage", "treat", "discharge")
+	}
+	for i := 0; i < 5; i++ {
+		add(fmt.Sprintf("slow%d", i), "triage", "admit", "surgery", "recover", "discharge")
+	}
+	return log
+}
+
+func TestClusterTracesSeparatesVariants(t *testing.T) {
+	for _, method := range []string{"activity-profile", "edit-distance"} {
+		result, err := ClusterTracesWith(mixedLog(), 2, method)
+		if err != nil {
+			t.Fatalf("%s: ClusterTracesWith failed: %v", method, err)
+		}
+
+		if len(result.Clusters) != 2 {
+			t.Fatalf("%s: expected 2 clusters, got %d", method, len(result.Clusters))
+		}
+		if result.Sizes[0]+result.Sizes[1] != 10 {
+			t.Errorf("%s: sizes should cover all traces: %v", method, result.Sizes)
+		}
+
+		// All fast cases in one cluster, all slow cases in the other.
+		fast := result.Assignments["fast0"]
+		slow := result.Assignments["slow0"]
+		if fast == slow {
+			t.Errorf("%s: expected variants in different clusters", method)
+		}
+		for i := 0; i < 5; i++ {
+			if result.Assignments[fmt.Sprintf("fast%d", i)] != fast {
+				t.Errorf("%s: fast%d not in the fast cluster", method, i)
+			}
+			if result.Assignments[fmt.Sprintf("slow%d", i)] != slow {
+				t.Errorf("%s: slow%d not in the slow cluster", method, i)
+			}
+		}
+
+		// Sub-logs feed straight into discovery.
+		for c, sub := range result.Clusters {
+			res, err := Discover(sub, "heuristic")
+			if err != nil {
+				t.Fatalf("%s: Discover on cluster %d failed: %v", method, c, err)
+			}
+			if res.Net == nil {
+				t.Errorf("%s: cluster %d produced no net", method, c)
+			}
+		}
+	}
+}
+
+func TestClusterTracesValidation(t *testing.T) {
+	log := mixedLog()
+	if _, err := ClusterTraces(log, 0); err == nil {
+		t.Error("Expected error for k=0")
+	}
+	if _, err := ClusterTraces(log, 11); err == nil {
+		t.Error("Expected error for k > trace count")
+	}
+	if _, err := ClusterTracesWith(log, 2, "bogus"); err == nil {
+		t.Error("Expected error for unknown method")
+	}
+}
+
+func TestClusterTracesSingleCluster(t *testing.T) {
+	result, err := ClusterTraces(mixedLog(), 1)
+	if err != nil {
+		t.Fatalf("ClusterTraces failed: %v", err)
+	}
+	if result.Sizes[0] != 10 {
+		t.Errorf("Expected all traces in one cluster, got %v", result.Sizes)
+	}
+}
@@ -0,0 +1,321 @@
+package mining
+
+import (
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Decision mining.
+//
+// Discovery recovers where a process branches, but not why. At an XOR
+// split — a place with several outgoing transitions — the log usually
+// carries the answer in case and event attributes: claims above a
+// threshold go to review, gold customers skip the queue. Decision mining
+// learns a single-split rule (a decision stump) per choice place, keeps
+// the empirical branch distributions on each side of the split, and
+// falls back to plain branch frequencies when no attribute beats them.
+
+// DecisionRule captures the learned routing behavior at one choice place.
+type DecisionRule struct {
+	Place    string
+	Branches []string // outgoing transitions at the split
+
+	// The learned split; Attribute is empty when no attribute
+	// outperformed the baseline frequencies.
+	Attribute string
+	Numeric   bool
+	Threshold float64 // condition: attr >= Threshold (numeric)
+	Value     string  // condition: attr == Value (categorical)
+
+	Accuracy    float64            // stump accuracy over the observations
+	Baseline    float64            // accuracy of always predicting the majority branch
+	Frequencies map[string]float64 // overall branch probabilities
+	ThenFreq    map[string]float64 // branch probabilities when the condition holds
+	ElseFreq    map[string]float64 // branch probabilities when it does not
+}
+
+// DecisionModel holds the learned rules for a net's choice places.
+type DecisionModel struct {
+	Rules map[string]*DecisionRule // by choice place
+}
+
+// decisionObs is one observed routing decision with its attributes.
+type decisionObs struct {
+	branch string
+	attrs  map[string]interface{}
+}
+
+// MineDecisions learns decision rules for every choice place in the net
+// from the traces' case and event attributes.
+func MineDecisions(log *eventlog.EventLog, net *petri.PetriNet) *DecisionModel {
+	model := &DecisionModel{Rules: make(map[string]*DecisionRule)}
+
+	for place, branches := range choicePlaces(net) {
+		branchSet := make(map[string]bool, len(branches))
+		for _, b := range branches {
+			branchSet[b] = true
+		}
+
+		// Collect one observation per routing decision in the log.
+		var observations []decisionObs
+		for _, trace := range log.GetTraces() {
+			for _, ev := range trace.Events {
+				if !branchSet[ev.Activity] {
+					continue
+				}
+				attrs := make(map[string]interface{})
+				for k, v := range trace.Attributes {
+					attrs[k] = v
+				}
+				for k, v := range ev.Attributes {
+					attrs[k] = v
+				}
+				observations = append(observations, decisionObs{branch: ev.Activity, attrs: attrs})
+			}
+		}
+		if len(observations) == 0 {
+			continue
+		}
+
+		rule := learnStump(place, branches, observations)
+		model.Rules[place] = rule
+	}
+	return model
+}
+
+// choicePlaces returns each place with two or more outgoing transitions,
+// mapped to those transitions in sorted order.
+func choicePlaces(net *petri.PetriNet) map[string][]string {
+	out := make(map[string][]string)
+	for _, arc := range net.Arcs {
+		if arc.InhibitTransition {
+			continue
+		}
+		if _, isPlace := net.Places[arc.Source]; !isPlace {
+			continue
+		}
+		if _, isTrans := net.Transitions[arc.Target]; !isTrans {
+			continue
+		}
+		out[arc.Source] = append(out[arc.Source], arc.Target)
+	}
+	choices := make(map[string][]string)
+	for place, branches := range out {
+		if len(branches) >= 2 {
+			sort.Strings(branches)
+			choices[place] = branches
+		}
+	}
+	return choices
+}
+
+// learnStump finds the single attribute split that best predicts the
+// branch, keeping frequency fallbacks when nothing beats the baseline.
+func learnStump(place string, branches []string, observations []decisionObs) *DecisionRule {
+	rule := &DecisionRule{
+		Place:       place,
+		Branches:    branches,
+		Frequencies: branchFrequencies(observations),
+	}
+
+	// Baseline: always predict the majority branch.
+	best := 0.0
+	for _, f := range rule.Frequencies {
+		if f > best {
+			best = f
+		}
+	}
+	rule.Baseline = best
+	rule.Accuracy = best
+
+	for _, attr := range observedAttributes(observations) {
+		if numeric, ok := numericValues(observations, attr); ok {
+			for _, threshold := range candidateThresholds(numeric) {
+				cond := func(o decisionObs) bool {
+					v, ok := o.attrs[attr].(float64)
+					return ok && v >= threshold
+				}
+				if acc := splitAccuracy(observations, cond); acc > rule.Accuracy {
+					rule.Attribute = attr
+					rule.Numeric = true
+					rule.Threshold = threshold
+					rule.Value = ""
+					rule.Accuracy = acc
+					rule.ThenFreq, rule.ElseFreq = splitFrequencies(observations, cond)
+				}
+			}
+			continue
+		}
+		for _, value := range stringValues(observations, attr) {
+			cond := func(o decisionObs) bool {
+				v, ok := o.attrs[attr].(string)
+				return ok && v == value
+			}
+			if acc := splitAccuracy(observations, cond); acc > rule.Accuracy {
+				rule.Attribute = attr
+				rule.Numeric = false
+				rule.Threshold = 0
+				rule.Value = value
+				rule.Accuracy = acc
+				rule.ThenFreq, rule.ElseFreq = splitFrequencies(observations, cond)
+			}
+		}
+	}
+	return rule
+}
+
+// Predict returns the branch probabilities at a choice place given the
+// case attributes. Unknown places return nil; missing attributes fall
+// back to the overall frequencies.
+func (dm *DecisionModel) Predict(place string, attrs map[string]interface{}) map[string]float64 {
+	rule, ok := dm.Rules[place]
+	if !ok {
+		return nil
+	}
+	if rule.Attribute == "" {
+		return rule.Frequencies
+	}
+	if rule.Numeric {
+		if v, ok := attrs[rule.Attribute].(float64); ok {
+			if v >= rule.Threshold {
+				return rule.ThenFreq
+			}
+			return rule.ElseFreq
+		}
+	} else {
+		if v, ok := attrs[rule.Attribute].(string); ok {
+			if v == rule.Value {
+				return rule.ThenFreq
+			}
+			return rule.ElseFreq
+		}
+	}
+	return rule.Frequencies
+}
+
+// AdjustRates scales the branch transitions' rates by the predicted
+// probabilities so simulations route according to the learned rules. A
+// uniform prediction leaves the rates unchanged; rates for transitions
+// outside any choice are copied as-is.
+func (dm *DecisionModel) AdjustRates(rates map[string]float64, attrs map[string]interface{}) map[string]float64 {
+	adjusted := make(map[string]float64, len(rates))
+	for k, v := range rates {
+		adjusted[k] = v
+	}
+	for place, rule := range dm.Rules {
+		probs := dm.Predict(place, attrs)
+		n := float64(len(rule.Branches))
+		for _, branch := range rule.Branches {
+			adjusted[branch] *= probs[branch] * n
+		}
+	}
+	return adjusted
+}
+
+// branchFrequencies computes the empirical branch distribution.
+func branchFrequencies(observations []decisionObs) map[string]float64 {
+	freq := make(map[string]float64)
+	for _, o := range observations {
+		freq[o.branch]++
+	}
+	for b := range freq {
+		freq[b] /= float64(len(observations))
+	}
+	return freq
+}
+
+// splitAccuracy scores a condition by predicting the majority branch on
+// each side of the split.
+func splitAccuracy(observations []decisionObs, cond func(decisionObs) bool) float64 {
+	thenCounts := make(map[string]int)
+	elseCounts := make(map[string]int)
+	for _, o := range observations {
+		if cond(o) {
+			thenCounts[o.branch]++
+		} else {
+			elseCounts[o.branch]++
+		}
+	}
+	correct := maxCount(thenCounts) + maxCount(elseCounts)
+	return float64(correct) / float64(len(observations))
+}
+
+// splitFrequencies computes the branch distributions on each side of a
+// condition.
+func splitFrequencies(observations []decisionObs, cond func(decisionObs) bool) (map[string]float64, map[string]float64) {
+	var thenObs, elseObs []decisionObs
+	for _, o := range observations {
+		if cond(o) {
+			thenObs = append(thenObs, o)
+		} else {
+			elseObs = append(elseObs, o)
+		}
+	}
+	return branchFrequencies(thenObs), branchFrequencies(elseObs)
+}
+
+func maxCount(counts map[string]int) int {
+	best := 0
+	for _, c := range counts {
+		if c > best {
+			best = c
+		}
+	}
+	return best
+}
+
+// observedAttributes returns every attribute key seen, sorted for
+// deterministic rule selection.
+func observedAttributes(observations []decisionObs) []string {
+	set := make(map[string]bool)
+	for _, o := range observations {
+		for k := range o.attrs {
+			set[k] = true
+		}
+	}
+	return sortedKeys(set)
+}
+
+// numericValues collects an attribute's values when they are all numeric.
+func numericValues(observations []decisionObs, attr string) ([]float64, bool) {
+	var values []float64
+	for _, o := range observations {
+		v, ok := o.attrs[attr]
+		if !ok {
+			continue
+		}
+		f, ok := v.(float64)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, f)
+	}
+	return values, len(values) > 0
+}
+
+// candidateThresholds returns midpoints between consecutive distinct
+// values.
+func candidateThresholds(values []float64) []float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	var thresholds []float64
+	for i := 0; i+1 < len(sorted); i++ {
+		if sorted[i] != sorted[i+1] {
+			thresholds = append(thresholds, (sorted[i]+sorted[i+1])/2)
+		}
+	}
+	return thresholds
+}
+
+// stringValues returns an attribute's distinct string values, sorted.
+func stringValues(observations []decisionObs, attr string) []string {
+	set := make(map[string]bool)
+	for _, o := range observations {
+		if v, ok := o.attrs[attr].(string); ok {
+			set[v] = true
+		}
+	}
+	return sortedKeys(set)
+}
@@ -0,0 +1,145 @@
+package mining
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// claimNet models receive -> (approve | review) -> done.
+func claimNet() *petri.PetriNet {
+	return petri.Build().
+		Place("start", 1).Place("decided", 0).Place("end", 0).
+		Transition("receive").Transition("approve").Transition("review").
+		Arc("start", "receive", 1).Arc("receive", "decided", 1).
+		Arc("decided", "approve", 1).Arc("decided", "review", 1).
+		Arc("approve", "end", 1).Arc("review", "end", 1).
+		Done()
+}
+
+// claimLog routes claims >= 1000 to review, smaller ones to approve.
+func claimLog() *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	add := func(caseID string, amount float64, branch string) {
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "receive", Timestamp: base,
+			Attributes: map[string]interface{}{"amount": amount}})
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: branch, Timestamp: base.Add(time.Minute),
+			Attributes: map[string]interface{}{"amount": amount}})
+	}
+	for i := 0; i < 10; i++ {
+		add(fmt.Sprintf("small%d", i), float64(100+i*50), "approve")
+	}
+	for i := 0; i < 10; i++ {
+		add(fmt.Sprintf("large%d", i), float64(1000+i*500), "review")
+	}
+	return log
+}
+
+func TestMineDecisionsNumericSplit(t *testing.T) {
+	model := MineDecisions(claimLog(), claimNet())
+
+	rule, ok := model.Rules["decided"]
+	if !ok {
+		t.Fatal("Expected a rule for the choice place")
+	}
+	if rule.Attribute != "amount" || !rule.Numeric {
+		t.Fatalf("Expected a numeric split on amount, got %+v", rule)
+	}
+	if rule.Threshold <= 550 || rule.Threshold > 1000 {
+		t.Errorf("Expected threshold between the classes, got %f", rule.Threshold)
+	}
+	if rule.Accuracy != 1.0 {
+		t.Errorf("Expected a perfect split, got accuracy %f", rule.Accuracy)
+	}
+	if rule.Baseline != 0.5 {
+		t.Errorf("Expected 0.5 baseline on a balanced log, got %f", rule.Baseline)
+	}
+
+	// Predictions follow the learned condition.
+	high := model.Predict("decided", map[string]interface{}{"amount": 5000.0})
+	if high["review"] != 1.0 {
+		t.Errorf("Expected large claims routed to review, got %v", high)
+	}
+	low := model.Predict("decided", map[string]interface{}{"amount": 200.0})
+	if low["approve"] != 1.0 {
+		t.Errorf("Expected small claims routed to approve, got %v", low)
+	}
+
+	// Missing attribute falls back to overall frequencies.
+	fallback := model.Predict("decided", nil)
+	if fallback["approve"] != 0.5 || fallback["review"] != 0.5 {
+		t.Errorf("Expected frequency fallback, got %v", fallback)
+	}
+	if model.Predict("nowhere", nil) != nil {
+		t.Error("Expected nil for an unknown place")
+	}
+}
+
+func TestAdjustRatesRoutesFlow(t *testing.T) {
+	model := MineDecisions(claimLog(), claimNet())
+	rates := map[string]float64{"receive": 1.0, "approve": 0.5, "review": 0.5}
+
+	adjusted := model.AdjustRates(rates, map[string]interface{}{"amount": 5000.0})
+	if adjusted["review"] != 1.0 || adjusted["approve"] != 0 {
+		t.Errorf("Expected all flow through review, got %v", adjusted)
+	}
+	if adjusted["receive"] != 1.0 {
+		t.Errorf("Non-branch rates should be untouched, got %f", adjusted["receive"])
+	}
+
+	// Uniform prediction leaves rates unchanged.
+	uniform := model.AdjustRates(rates, nil)
+	if uniform["approve"] != 0.5 || uniform["review"] != 0.5 {
+		t.Errorf("Expected unchanged rates under uniform routing, got %v", uniform)
+	}
+}
+
+func TestMineDecisionsCategoricalSplit(t *testing.T) {
+	log := eventlog.NewEventLog()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	add := func(caseID, tier, branch string) {
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: branch, Timestamp: base,
+			Attributes: map[string]interface{}{"tier": tier}})
+	}
+	for i := 0; i < 8; i++ {
+		add(fmt.Sprintf("g%d", i), "gold", "approve")
+	}
+	for i := 0; i < 8; i++ {
+		add(fmt.Sprintf("s%d", i), "standard", "review")
+	}
+
+	model := MineDecisions(log, claimNet())
+	rule := model.Rules["decided"]
+	if rule == nil || rule.Attribute != "tier" || rule.Numeric {
+		t.Fatalf("Expected a categorical split on tier, got %+v", rule)
+	}
+	gold := model.Predict("decided", map[string]interface{}{"tier": "gold"})
+	if gold["approve"] != 1.0 {
+		t.Errorf("Expected gold routed to approve, got %v", gold)
+	}
+}
+
+func TestMineDecisionsNoAttributes(t *testing.T) {
+	log := eventlog.NewEventLog()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		log.AddEvent(eventlog.Event{CaseID: fmt.Sprintf("a%d", i), Activity: "approve", Timestamp: base})
+	}
+	log.AddEvent(eventlog.Event{CaseID: "r0", Activity: "review", Timestamp: base})
+
+	model := MineDecisions(log, claimNet())
+	rule := model.Rules["decided"]
+	if rule == nil {
+		t.Fatal("Expected a frequency rule")
+	}
+	if rule.Attribute != "" {
+		t.Errorf("Expected no attribute split, got %q", rule.Attribute)
+	}
+	if rule.Frequencies["approve"] != 0.75 {
+		t.Errorf("Expected 0.75 approve frequency, got %v", rule.Frequencies)
+	}
+}
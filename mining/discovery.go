@@ -1,6 +1,7 @@
 package mining
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pflow-xyz/go-pflow/eventlog"
@@ -167,6 +168,24 @@ type DiscoveryResult struct {
 //   - "alpha": Alpha Miner algorithm (discovers concurrency, sensitive to noise)
 //   - "heuristic": Heuristic Miner (robust to noise, handles loops)
 func Discover(log *eventlog.EventLog, method string) (*DiscoveryResult, error) {
+	return DiscoverContext(context.Background(), log, method)
+}
+
+// DiscoverContext is Discover with cancellation checkpoints: an already
+// canceled or expired context fails fast, and cancellation during mining
+// discards the result instead of returning a half-built model.
+func DiscoverContext(ctx context.Context, log *eventlog.EventLog, method string) (*DiscoveryResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result, err := discover(log, method)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	return result, err
+}
+
+func discover(log *eventlog.EventLog, method string) (*DiscoveryResult, error) {
 	switch method {
 	case "sequential":
 		net := DiscoverSequentialNet(log)
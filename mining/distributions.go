@@ -0,0 +1,199 @@
+package mining
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Duration distribution fitting.
+//
+// LearnRatesFromLog reduces each activity's timing to 1/mean, which
+// erases the shape of the duration distribution. FitDurations fits
+// exponential, lognormal, gamma, and Weibull candidates by maximum
+// likelihood to each activity's observed durations, picks the family
+// with the lowest AIC, and ApplyDurations stores the winners on the
+// net's transitions for timed simulators to sample.
+
+// DistributionFit is the selected distribution for one activity.
+type DistributionFit struct {
+	Activity string
+	Dist     *petri.Distribution
+	Samples  int
+	AIC      map[string]float64 // AIC per candidate family
+}
+
+// minDurationSamples is the fewest positive durations worth fitting.
+const minDurationSamples = 3
+
+// FitDurations fits duration distributions for every net transition
+// with enough observed durations in the log.
+func FitDurations(log *eventlog.EventLog, net *petri.PetriNet) map[string]*DistributionFit {
+	timing := ExtractTiming(log)
+	fits := make(map[string]*DistributionFit)
+
+	for activity := range net.Transitions {
+		durations := positive(timing.ActivityDurations[activity])
+		if len(durations) < minDurationSamples {
+			continue
+		}
+		fits[activity] = fitBest(activity, durations)
+	}
+	return fits
+}
+
+// ApplyDurations stores the fitted distributions on the net.
+func ApplyDurations(net *petri.PetriNet, fits map[string]*DistributionFit) {
+	for activity, fit := range fits {
+		net.SetDuration(activity, fit.Dist)
+	}
+}
+
+// fitBest fits every candidate family and keeps the lowest AIC.
+func fitBest(activity string, durations []float64) *DistributionFit {
+	fit := &DistributionFit{
+		Activity: activity,
+		Samples:  len(durations),
+		AIC:      make(map[string]float64),
+	}
+
+	type candidate struct {
+		dist *petri.Distribution
+		aic  float64
+	}
+	var candidates []candidate
+	consider := func(dist *petri.Distribution, logL float64, params int, ok bool) {
+		if !ok {
+			return
+		}
+		aic := 2*float64(params) - 2*logL
+		fit.AIC[dist.Family] = aic
+		candidates = append(candidates, candidate{dist: dist, aic: aic})
+	}
+
+	consider(fitExponential(durations))
+	consider(fitLogNormal(durations))
+	consider(fitGamma(durations))
+	consider(fitWeibull(durations))
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].aic < candidates[j].aic })
+	fit.Dist = candidates[0].dist
+	return fit
+}
+
+// positive filters out non-positive durations, which instantaneous
+// transitions and clock skew produce.
+func positive(durations []float64) []float64 {
+	out := make([]float64, 0, len(durations))
+	for _, d := range durations {
+		if d > 0 {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// fitExponential fits by MLE: rate = 1/mean.
+func fitExponential(x []float64) (*petri.Distribution, float64, int, bool) {
+	n := float64(len(x))
+	sum := 0.0
+	for _, v := range x {
+		sum += v
+	}
+	rate := n / sum
+	logL := n*math.Log(rate) - rate*sum
+	return petri.Exponential(rate), logL, 1, true
+}
+
+// fitLogNormal fits by MLE on the log durations.
+func fitLogNormal(x []float64) (*petri.Distribution, float64, int, bool) {
+	n := float64(len(x))
+	sumLog, sumLogSq := 0.0, 0.0
+	for _, v := range x {
+		l := math.Log(v)
+		sumLog += l
+		sumLogSq += l * l
+	}
+	mu := sumLog / n
+	variance := sumLogSq/n - mu*mu
+	if variance <= 0 {
+		return nil, 0, 0, false // degenerate: identical durations
+	}
+	sigma := math.Sqrt(variance)
+	// At the MLE the quadratic term sums to n/2.
+	logL := -sumLog - n*math.Log(sigma*math.Sqrt(2*math.Pi)) - n/2
+	return petri.LogNormal(mu, sigma), logL, 2, true
+}
+
+// fitGamma fits shape and scale with the Minka closed-form
+// approximation to the shape MLE.
+func fitGamma(x []float64) (*petri.Distribution, float64, int, bool) {
+	n := float64(len(x))
+	sum, sumLog := 0.0, 0.0
+	for _, v := range x {
+		sum += v
+		sumLog += math.Log(v)
+	}
+	mean := sum / n
+	s := math.Log(mean) - sumLog/n
+	if s <= 0 {
+		return nil, 0, 0, false // degenerate: no spread
+	}
+	shape := (3 - s + math.Sqrt((s-3)*(s-3)+24*s)) / (12 * s)
+	scale := mean / shape
+
+	lg, _ := math.Lgamma(shape)
+	logL := (shape-1)*sumLog - sum/scale - n*shape*math.Log(scale) - n*lg
+	return petri.Gamma(shape, scale), logL, 2, true
+}
+
+// fitWeibull fits the shape by Newton iteration on the profile
+// likelihood, then the scale in closed form.
+func fitWeibull(x []float64) (*petri.Distribution, float64, int, bool) {
+	n := float64(len(x))
+	sumLog := 0.0
+	for _, v := range x {
+		sumLog += math.Log(v)
+	}
+	meanLog := sumLog / n
+
+	shape := 1.0
+	for iter := 0; iter < 100; iter++ {
+		sumPow, sumPowLog, sumPowLogSq := 0.0, 0.0, 0.0
+		for _, v := range x {
+			p := math.Pow(v, shape)
+			l := math.Log(v)
+			sumPow += p
+			sumPowLog += p * l
+			sumPowLogSq += p * l * l
+		}
+		f := sumPowLog/sumPow - 1/shape - meanLog
+		df := (sumPowLogSq*sumPow-sumPowLog*sumPowLog)/(sumPow*sumPow) + 1/(shape*shape)
+		if df == 0 {
+			break
+		}
+		next := shape - f/df
+		if next <= 0 {
+			next = shape / 2
+		}
+		if math.Abs(next-shape) < 1e-10 {
+			shape = next
+			break
+		}
+		shape = next
+	}
+	if math.IsNaN(shape) || math.IsInf(shape, 0) || shape <= 0 {
+		return nil, 0, 0, false
+	}
+
+	sumPow := 0.0
+	for _, v := range x {
+		sumPow += math.Pow(v, shape)
+	}
+	scale := math.Pow(sumPow/n, 1/shape)
+
+	logL := n*math.Log(shape) - n*shape*math.Log(scale) + (shape-1)*sumLog - sumPow/math.Pow(scale, shape)
+	return petri.Weibull(shape, scale), logL, 2, true
+}
@@ -0,0 +1,122 @@
+package mining
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// durationLog builds a log where "work" takes the given durations.
+func durationLog(durations []float64) *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, d := range durations {
+		caseID := fmt.Sprintf("c%d", i)
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "work", Timestamp: base})
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "done",
+			Timestamp: base.Add(time.Duration(d * float64(time.Second)))})
+	}
+	return log
+}
+
+func workNet() *petri.PetriNet {
+	return petri.Build().
+		Chain(1, "start", "work", "mid", "done", "end").
+		Done()
+}
+
+func TestFitDurationsPicksLognormal(t *testing.T) {
+	// Heavily skewed lognormal durations.
+	rng := rand.New(rand.NewSource(7))
+	durations := make([]float64, 500)
+	for i := range durations {
+		durations[i] = math.Exp(4 + 1.5*rng.NormFloat64())
+	}
+
+	fits := FitDurations(durationLog(durations), workNet())
+	fit, ok := fits["work"]
+	if !ok {
+		t.Fatal("Expected a fit for work")
+	}
+	if fit.Dist.Family != "lognormal" {
+		t.Errorf("Expected lognormal to win by AIC, got %s (AIC %v)", fit.Dist.Family, fit.AIC)
+	}
+	if fit.AIC["lognormal"] >= fit.AIC["exponential"] {
+		t.Errorf("Lognormal AIC should beat exponential: %v", fit.AIC)
+	}
+	mu := fit.Dist.Params["mu"]
+	if math.Abs(mu-4) > 0.2 {
+		t.Errorf("Expected mu near 4, got %f", mu)
+	}
+
+	// The fitted mean should be near the sample mean.
+	sample := 0.0
+	for _, d := range durations {
+		sample += d
+	}
+	sample /= float64(len(durations))
+	if math.Abs(fit.Dist.Mean()-sample)/sample > 0.25 {
+		t.Errorf("Fitted mean %f far from sample mean %f", fit.Dist.Mean(), sample)
+	}
+}
+
+func TestFitDurationsExponentialData(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+	durations := make([]float64, 500)
+	for i := range durations {
+		durations[i] = rng.ExpFloat64() * 60
+	}
+
+	fits := FitDurations(durationLog(durations), workNet())
+	fit := fits["work"]
+	if fit == nil {
+		t.Fatal("Expected a fit for work")
+	}
+	// Exponential is a special case of gamma and Weibull, so any of the
+	// three may win narrowly — but the fitted mean must be right.
+	if math.Abs(fit.Dist.Mean()-60)/60 > 0.15 {
+		t.Errorf("Expected mean near 60, got %f (%s)", fit.Dist.Mean(), fit.Dist)
+	}
+	if len(fit.AIC) < 3 {
+		t.Errorf("Expected AIC for all viable families, got %v", fit.AIC)
+	}
+}
+
+func TestFitDurationsSkipsSparseActivities(t *testing.T) {
+	fits := FitDurations(durationLog([]float64{5, 10}), workNet())
+	if _, ok := fits["work"]; ok {
+		t.Error("Expected no fit with only two samples")
+	}
+}
+
+func TestApplyDurationsSamplesInSimulation(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	durations := make([]float64, 200)
+	for i := range durations {
+		durations[i] = math.Exp(1 + 0.5*rng.NormFloat64())
+	}
+
+	net := workNet()
+	fits := FitDurations(durationLog(durations), net)
+	ApplyDurations(net, fits)
+
+	if net.Transitions["work"].Duration == nil {
+		t.Fatal("Expected the distribution stored on the transition")
+	}
+
+	// Samples track the fitted mean.
+	dist := net.Transitions["work"].Duration
+	sum := 0.0
+	const n = 2000
+	for i := 0; i < n; i++ {
+		sum += dist.Sample(rng)
+	}
+	if math.Abs(sum/n-dist.Mean())/dist.Mean() > 0.15 {
+		t.Errorf("Sample mean %f far from distribution mean %f", sum/n, dist.Mean())
+	}
+}
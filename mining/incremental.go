@@ -0,0 +1,164 @@
+package mining
+
+import (
+	"fmt"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Incremental discovery.
+//
+// Batch discovery assumes the log is complete and the process stable.
+// Streams are neither: activities appear, dependencies shift, and a
+// model mined last month quietly degrades. The incremental miner accepts
+// traces one at a time, tracks the structural footprint (activities and
+// direct-follows dependencies), and rediscovers the net only when the
+// accumulated structural change exceeds a threshold — emitting a
+// versioned snapshot each time. Pairs with monitoring's drift alerting:
+// a drift alert is a good moment to check for a new model version.
+
+// ModelSnapshot is one versioned model emitted by the incremental miner.
+type ModelSnapshot struct {
+	Version    int
+	Net        *petri.PetriNet
+	TraceCount int      // traces observed when the snapshot was taken
+	Changes    []string // structural changes that triggered it
+}
+
+// ChangeReport describes the structural drift that triggered a snapshot.
+type ChangeReport struct {
+	Version         int
+	NewActivities   []string
+	NewDependencies []string // "a -> b" direct-follows pairs
+	Score           float64  // new structure relative to the known footprint
+	TraceCount      int
+}
+
+// IncrementalMiner maintains a discovered model over a stream of traces.
+type IncrementalMiner struct {
+	method    string
+	threshold float64
+
+	log       *eventlog.EventLog
+	current   *petri.PetriNet
+	version   int
+	snapshots []ModelSnapshot
+
+	knownActivities map[string]bool
+	knownDeps       map[string]bool
+	pendingActs     map[string]bool
+	pendingDeps     map[string]bool
+}
+
+// NewIncrementalMiner creates a miner using the given discovery method
+// (see Discover) with a 10% structural change threshold.
+func NewIncrementalMiner(method string) *IncrementalMiner {
+	return &IncrementalMiner{
+		method:          method,
+		threshold:       0.1,
+		log:             eventlog.NewEventLog(),
+		knownActivities: make(map[string]bool),
+		knownDeps:       make(map[string]bool),
+		pendingActs:     make(map[string]bool),
+		pendingDeps:     make(map[string]bool),
+	}
+}
+
+// WithThreshold sets the fraction of new structural elements (relative
+// to the known footprint) that triggers rediscovery.
+func (m *IncrementalMiner) WithThreshold(threshold float64) *IncrementalMiner {
+	m.threshold = threshold
+	return m
+}
+
+// Observe feeds one trace to the miner. It returns a non-nil report
+// when the accumulated drift triggered a new model snapshot.
+func (m *IncrementalMiner) Observe(trace *eventlog.Trace) (*ChangeReport, error) {
+	if trace == nil || len(trace.Events) == 0 {
+		return nil, fmt.Errorf("trace is empty")
+	}
+	m.log.Cases[trace.CaseID] = trace
+
+	// Accumulate structural novelty.
+	variant := trace.GetActivityVariant()
+	for _, act := range variant {
+		if !m.knownActivities[act] {
+			m.pendingActs[act] = true
+		}
+	}
+	for i := 0; i+1 < len(variant); i++ {
+		dep := variant[i] + " -> " + variant[i+1]
+		if !m.knownDeps[dep] {
+			m.pendingDeps[dep] = true
+		}
+	}
+
+	score := float64(len(m.pendingActs)+len(m.pendingDeps)) /
+		float64(max(1, len(m.knownActivities)+len(m.knownDeps)))
+	if m.version > 0 && score <= m.threshold {
+		return nil, nil
+	}
+	return m.rediscover(score)
+}
+
+// rediscover rebuilds the model from the accumulated log and records a
+// versioned snapshot of the structural changes.
+func (m *IncrementalMiner) rediscover(score float64) (*ChangeReport, error) {
+	result, err := Discover(m.log, m.method)
+	if err != nil {
+		return nil, fmt.Errorf("rediscovering model: %w", err)
+	}
+
+	report := &ChangeReport{
+		Version:         m.version + 1,
+		NewActivities:   sortedKeys(m.pendingActs),
+		NewDependencies: sortedKeys(m.pendingDeps),
+		Score:           score,
+		TraceCount:      len(m.log.Cases),
+	}
+
+	changes := make([]string, 0, len(report.NewActivities)+len(report.NewDependencies))
+	for _, act := range report.NewActivities {
+		changes = append(changes, "new activity: "+act)
+	}
+	for _, dep := range report.NewDependencies {
+		changes = append(changes, "new dependency: "+dep)
+	}
+
+	m.version++
+	m.current = result.Net
+	m.snapshots = append(m.snapshots, ModelSnapshot{
+		Version:    m.version,
+		Net:        result.Net,
+		TraceCount: len(m.log.Cases),
+		Changes:    changes,
+	})
+
+	// The pending structure is now part of the known footprint.
+	for act := range m.pendingActs {
+		m.knownActivities[act] = true
+	}
+	for dep := range m.pendingDeps {
+		m.knownDeps[dep] = true
+	}
+	m.pendingActs = make(map[string]bool)
+	m.pendingDeps = make(map[string]bool)
+
+	return report, nil
+}
+
+// Current returns the latest discovered net, nil before the first trace.
+func (m *IncrementalMiner) Current() *petri.PetriNet {
+	return m.current
+}
+
+// Version returns the current model version, zero before the first trace.
+func (m *IncrementalMiner) Version() int {
+	return m.version
+}
+
+// Snapshots returns every versioned model emitted so far.
+func (m *IncrementalMiner) Snapshots() []ModelSnapshot {
+	return m.snapshots
+}
@@ -0,0 +1,120 @@
+package mining
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// makeTrace builds a trace with one event per activity, a minute apart.
+func makeTrace(caseID string, activities ...string) *eventlog.Trace {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	trace := &eventlog.Trace{CaseID: caseID, Attributes: make(map[string]interface{})}
+	for i, a := range activities {
+		trace.Events = append(trace.Events, eventlog.Event{
+			CaseID:    caseID,
+			Activity:  a,
+			Timestamp: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	return trace
+}
+
+func TestIncrementalMinerStableStream(t *testing.T) {
+	m := NewIncrementalMiner("heuristic")
+
+	report, err := m.Observe(makeTrace("c0", "receive", "validate", "ship"))
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if report == nil || report.Version != 1 {
+		t.Fatalf("Expected baseline snapshot at version 1, got %+v", report)
+	}
+	if len(report.NewActivities) != 3 {
+		t.Errorf("Expected 3 new activities in the baseline, got %v", report.NewActivities)
+	}
+
+	// Repeats of the same variant add no structure: no new snapshots.
+	for i := 1; i <= 5; i++ {
+		report, err = m.Observe(makeTrace(fmt.Sprintf("c%d", i), "receive", "validate", "ship"))
+		if err != nil {
+			t.Fatalf("Observe failed: %v", err)
+		}
+		if report != nil {
+			t.Errorf("Unexpected snapshot on stable trace %d: %+v", i, report)
+		}
+	}
+	if m.Version() != 1 || len(m.Snapshots()) != 1 {
+		t.Errorf("Expected one snapshot, got version %d with %d snapshots", m.Version(), len(m.Snapshots()))
+	}
+	if m.Current() == nil {
+		t.Error("Expected a current model")
+	}
+}
+
+func TestIncrementalMinerDetectsDrift(t *testing.T) {
+	m := NewIncrementalMiner("heuristic")
+	if _, err := m.Observe(makeTrace("c0", "receive", "validate", "ship")); err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+
+	// A new escalation path exceeds the 10% structural threshold.
+	report, err := m.Observe(makeTrace("c1", "receive", "escalate", "review", "ship"))
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if report == nil {
+		t.Fatal("Expected a drift snapshot")
+	}
+	if report.Version != 2 {
+		t.Errorf("Expected version 2, got %d", report.Version)
+	}
+	if len(report.NewActivities) != 2 {
+		t.Errorf("Expected escalate and review as new, got %v", report.NewActivities)
+	}
+	found := false
+	for _, dep := range report.NewDependencies {
+		if dep == "receive -> escalate" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the new dependency recorded, got %v", report.NewDependencies)
+	}
+
+	snapshots := m.Snapshots()
+	if len(snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots, got %d", len(snapshots))
+	}
+	if snapshots[1].TraceCount != 2 {
+		t.Errorf("Expected snapshot at 2 traces, got %d", snapshots[1].TraceCount)
+	}
+	if len(snapshots[1].Changes) == 0 {
+		t.Error("Expected the snapshot to describe its changes")
+	}
+}
+
+func TestIncrementalMinerThreshold(t *testing.T) {
+	// With a huge threshold, drift never triggers after the baseline.
+	m := NewIncrementalMiner("heuristic").WithThreshold(100)
+	m.Observe(makeTrace("c0", "a", "b"))
+	report, err := m.Observe(makeTrace("c1", "x", "y", "z"))
+	if err != nil {
+		t.Fatalf("Observe failed: %v", err)
+	}
+	if report != nil {
+		t.Errorf("Expected no snapshot under a high threshold, got %+v", report)
+	}
+}
+
+func TestIncrementalMinerEmptyTrace(t *testing.T) {
+	m := NewIncrementalMiner("heuristic")
+	if _, err := m.Observe(&eventlog.Trace{CaseID: "c"}); err == nil {
+		t.Error("Expected error for empty trace")
+	}
+	if _, err := m.Observe(nil); err == nil {
+		t.Error("Expected error for nil trace")
+	}
+}
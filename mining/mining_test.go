@@ -1,6 +1,7 @@
 package mining
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -392,6 +393,24 @@ func TestDiscoverUnknownMethod(t *testing.T) {
 	}
 }
 
+func TestDiscoverContextCanceled(t *testing.T) {
+	log := createSequentialLog()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := DiscoverContext(ctx, log, "heuristic"); err == nil {
+		t.Error("Expected error for canceled context")
+	}
+
+	result, err := DiscoverContext(context.Background(), log, "heuristic")
+	if err != nil {
+		t.Fatalf("Discovery failed: %v", err)
+	}
+	if result.Net == nil {
+		t.Error("Expected discovered net with open context")
+	}
+}
+
 func TestEmptyLog(t *testing.T) {
 	log := eventlog.NewEventLog()
 
@@ -0,0 +1,113 @@
+package mining
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// EndOfCase is the pseudo-activity predicted when similar traces finish
+// after the given prefix.
+const EndOfCase = "__end__"
+
+// ActivityProbability is one entry in a next-activity distribution.
+type ActivityProbability struct {
+	Activity    string
+	Probability float64
+}
+
+// NextActivityForecast is a confidence-scored next-activity distribution.
+type NextActivityForecast struct {
+	// Predictions, sorted by probability descending.
+	Predictions []ActivityProbability
+	// Support is how many historical observations back the distribution.
+	Support int
+	// Order is the history length actually matched (maxOrder down to 0);
+	// lower orders mean the exact prefix was rare and the model backed
+	// off to shorter context.
+	Order int
+}
+
+// Top returns the most likely next activity, or "" for an empty forecast.
+func (f *NextActivityForecast) Top() (string, float64) {
+	if len(f.Predictions) == 0 {
+		return "", 0
+	}
+	return f.Predictions[0].Activity, f.Predictions[0].Probability
+}
+
+// ActivityPredictor predicts the next activity of a partial trace from
+// variant statistics: an order-k Markov model with backoff, mined from a
+// historical log.
+type ActivityPredictor struct {
+	maxOrder int
+	// counts[context][next] = observations, where context is the joined
+	// tail of the prefix ("" for order 0).
+	counts map[string]map[string]int
+}
+
+// NewActivityPredictor mines variant statistics from the log. Contexts up
+// to three activities long are tracked; prediction backs off to shorter
+// contexts when a prefix was never observed.
+func NewActivityPredictor(log *eventlog.EventLog) *ActivityPredictor {
+	p := &ActivityPredictor{
+		maxOrder: 3,
+		counts:   make(map[string]map[string]int),
+	}
+
+	for _, trace := range log.GetTraces() {
+		activities := make([]string, len(trace.Events))
+		for i, event := range trace.Events {
+			activities[i] = event.Activity
+		}
+		for i := 0; i <= len(activities); i++ {
+			next := EndOfCase
+			if i < len(activities) {
+				next = activities[i]
+			}
+			for order := 0; order <= p.maxOrder && order <= i; order++ {
+				context := strings.Join(activities[i-order:i], "\x00")
+				if p.counts[context] == nil {
+					p.counts[context] = make(map[string]int)
+				}
+				p.counts[context][next]++
+			}
+		}
+	}
+	return p
+}
+
+// PredictNext returns the probability distribution over next activities for
+// a partial trace, e.g. ["open", "triage"] → {"escalate": 0.9, ...}. The
+// longest observed suffix of the prefix decides; the forecast reports which
+// order matched and on how many observations it rests.
+func (p *ActivityPredictor) PredictNext(prefix []string) *NextActivityForecast {
+	for order := min(p.maxOrder, len(prefix)); order >= 0; order-- {
+		context := strings.Join(prefix[len(prefix)-order:], "\x00")
+		counts, ok := p.counts[context]
+		if !ok || len(counts) == 0 {
+			continue
+		}
+
+		total := 0
+		for _, c := range counts {
+			total += c
+		}
+		forecast := &NextActivityForecast{Support: total, Order: order}
+		for activity, c := range counts {
+			forecast.Predictions = append(forecast.Predictions, ActivityProbability{
+				Activity:    activity,
+				Probability: float64(c) / float64(total),
+			})
+		}
+		sort.Slice(forecast.Predictions, func(i, j int) bool {
+			if forecast.Predictions[i].Probability != forecast.Predictions[j].Probability {
+				return forecast.Predictions[i].Probability > forecast.Predictions[j].Probability
+			}
+			return forecast.Predictions[i].Activity < forecast.Predictions[j].Activity
+		})
+		return forecast
+	}
+	return &NextActivityForecast{}
+}
@@ -0,0 +1,87 @@
+package mining
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// Helper: ticket log where triage escalates 9 times out of 10.
+func createVariantLog() *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	baseTime := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	addTrace := func(caseID string, activities ...string) {
+		for i, activity := range activities {
+			log.AddEvent(eventlog.Event{
+				CaseID:    caseID,
+				Activity:  activity,
+				Timestamp: baseTime.Add(time.Duration(i) * time.Minute),
+			})
+		}
+	}
+	for i := 0; i < 9; i++ {
+		addTrace(string(rune('a'+i)), "open", "triage", "escalate", "close")
+	}
+	addTrace("j", "open", "triage", "close")
+	log.SortTraces()
+	return log
+}
+
+func TestPredictNextDistribution(t *testing.T) {
+	p := NewActivityPredictor(createVariantLog())
+
+	forecast := p.PredictNext([]string{"open", "triage"})
+	activity, prob := forecast.Top()
+	if activity != "escalate" {
+		t.Fatalf("Expected escalate on top, got %s", activity)
+	}
+	if prob != 0.9 {
+		t.Errorf("Expected probability 0.9, got %f", prob)
+	}
+	if forecast.Support != 10 {
+		t.Errorf("Expected support 10, got %d", forecast.Support)
+	}
+	if forecast.Order != 2 {
+		t.Errorf("Expected full 2-activity context match, got order %d", forecast.Order)
+	}
+
+	// Probabilities sum to 1 across escalate and close.
+	sum := 0.0
+	for _, pred := range forecast.Predictions {
+		sum += pred.Probability
+	}
+	if sum < 0.999 || sum > 1.001 {
+		t.Errorf("Distribution should sum to 1, got %f", sum)
+	}
+}
+
+func TestPredictNextBackoff(t *testing.T) {
+	p := NewActivityPredictor(createVariantLog())
+
+	// Unseen prefix: the model backs off to the last activity only.
+	forecast := p.PredictNext([]string{"reopen", "triage"})
+	if forecast.Order != 1 {
+		t.Errorf("Expected backoff to order 1, got %d", forecast.Order)
+	}
+	if activity, _ := forecast.Top(); activity != "escalate" {
+		t.Errorf("Backed-off prediction should still rank escalate, got %s", activity)
+	}
+
+	// Completely unknown activity backs off to the unconditional
+	// distribution.
+	forecast = p.PredictNext([]string{"weird"})
+	if forecast.Order != 0 || forecast.Support == 0 {
+		t.Errorf("Expected order-0 fallback with support, got %+v", forecast)
+	}
+}
+
+func TestPredictNextEndOfCase(t *testing.T) {
+	p := NewActivityPredictor(createVariantLog())
+
+	forecast := p.PredictNext([]string{"escalate", "close"})
+	if activity, prob := forecast.Top(); activity != EndOfCase || prob != 1.0 {
+		t.Errorf("Expected certain end-of-case, got %s %.2f", activity, prob)
+	}
+}
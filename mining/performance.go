@@ -0,0 +1,240 @@
+package mining
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// DurationStats summarizes a distribution of durations, in seconds.
+type DurationStats struct {
+	Count  int
+	Mean   float64
+	Median float64
+	P95    float64
+	Max    float64
+}
+
+// newDurationStats computes summary statistics from raw samples.
+func newDurationStats(samples []float64) DurationStats {
+	if len(samples) == 0 {
+		return DurationStats{}
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, s := range sorted {
+		sum += s
+	}
+	return DurationStats{
+		Count:  len(sorted),
+		Mean:   sum / float64(len(sorted)),
+		Median: percentile(sorted, 0.50),
+		P95:    percentile(sorted, 0.95),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// percentile reads the p-th percentile from pre-sorted samples.
+func percentile(sorted []float64, p float64) float64 {
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+// TransitionPerformance annotates one transition with timing distributions
+// taken from the log.
+type TransitionPerformance struct {
+	Transition string
+	Activity   string
+
+	// Waiting is time spent enabled but not yet started (requires
+	// start/complete lifecycle events; otherwise zero).
+	Waiting DurationStats
+	// Service is the activity's own processing time (start to complete
+	// when lifecycle events exist, otherwise zero).
+	Service DurationStats
+	// Sojourn is the total time from the previous activity's completion,
+	// waiting plus service. Without lifecycle events this is the
+	// inter-event gap.
+	Sojourn DurationStats
+}
+
+// PlacePerformance annotates one place with the time tokens spend in it,
+// aggregated from the waiting (or sojourn) times of downstream transitions.
+type PlacePerformance struct {
+	Place   string
+	Waiting DurationStats
+}
+
+// Bottleneck is one ranked entry in a performance report.
+type Bottleneck struct {
+	Node string // transition or place ID
+	Kind string // "transition" or "place"
+
+	// Impact is total waiting attributed to the node across the log, in
+	// seconds — mean waiting weighted by frequency.
+	Impact float64
+	Stats  DurationStats
+}
+
+// PerformanceReport holds waiting, service and sojourn time annotations for
+// a net, mined from an event log.
+type PerformanceReport struct {
+	Transitions map[string]*TransitionPerformance
+	Places      map[string]*PlacePerformance
+	Bottlenecks []Bottleneck // sorted by Impact, descending
+}
+
+// AnalyzePerformance replays the log's timestamps against the net and
+// annotates each transition and place with waiting, service and sojourn time
+// distributions. When the log carries start/complete lifecycle events,
+// waiting and service are separated; otherwise the inter-event gap is
+// reported as sojourn (and attributed to input places as waiting).
+// Bottlenecks are ranked by total waiting impact.
+func AnalyzePerformance(log *eventlog.EventLog, net *petri.PetriNet) *PerformanceReport {
+	activityToTransition := buildActivityMapping(net)
+
+	waiting := make(map[string][]float64)
+	service := make(map[string][]float64)
+	sojourn := make(map[string][]float64)
+
+	for _, trace := range log.GetTraces() {
+		// Pair start/complete lifecycle events per activity occurrence.
+		starts := make(map[string][]int)
+
+		lastDone := -1 // index of the previous completed (or plain) event
+		for i, event := range trace.Events {
+			switch strings.ToLower(event.Lifecycle) {
+			case "start":
+				starts[event.Activity] = append(starts[event.Activity], i)
+
+			case "complete":
+				if open := starts[event.Activity]; len(open) > 0 {
+					startIdx := open[len(open)-1]
+					starts[event.Activity] = open[:len(open)-1]
+
+					start := trace.Events[startIdx]
+					service[event.Activity] = append(service[event.Activity],
+						event.Timestamp.Sub(start.Timestamp).Seconds())
+					if lastDone >= 0 {
+						waiting[event.Activity] = append(waiting[event.Activity],
+							start.Timestamp.Sub(trace.Events[lastDone].Timestamp).Seconds())
+						sojourn[event.Activity] = append(sojourn[event.Activity],
+							event.Timestamp.Sub(trace.Events[lastDone].Timestamp).Seconds())
+					}
+				}
+				lastDone = i
+
+			default:
+				// No lifecycle information: the inter-event gap is the
+				// activity's sojourn time, and the best available
+				// estimate of waiting in its input places.
+				if lastDone >= 0 {
+					gap := event.Timestamp.Sub(trace.Events[lastDone].Timestamp).Seconds()
+					sojourn[event.Activity] = append(sojourn[event.Activity], gap)
+					waiting[event.Activity] = append(waiting[event.Activity], gap)
+				}
+				lastDone = i
+			}
+		}
+	}
+
+	report := &PerformanceReport{
+		Transitions: make(map[string]*TransitionPerformance),
+		Places:      make(map[string]*PlacePerformance),
+	}
+
+	// Annotate transitions and roll waiting up into their input places.
+	placeWaiting := make(map[string][]float64)
+	for activity, transID := range activityToTransition {
+		if len(waiting[activity]) == 0 && len(service[activity]) == 0 && len(sojourn[activity]) == 0 {
+			continue
+		}
+		report.Transitions[transID] = &TransitionPerformance{
+			Transition: transID,
+			Activity:   activity,
+			Waiting:    newDurationStats(waiting[activity]),
+			Service:    newDurationStats(service[activity]),
+			Sojourn:    newDurationStats(sojourn[activity]),
+		}
+		for _, arc := range net.Arcs {
+			if arc.Target == transID && !arc.InhibitTransition {
+				if _, isPlace := net.Places[arc.Source]; isPlace {
+					placeWaiting[arc.Source] = append(placeWaiting[arc.Source], waiting[activity]...)
+				}
+			}
+		}
+	}
+	for place, samples := range placeWaiting {
+		report.Places[place] = &PlacePerformance{
+			Place:   place,
+			Waiting: newDurationStats(samples),
+		}
+	}
+
+	// Rank bottlenecks by total waiting impact.
+	for _, tp := range report.Transitions {
+		report.Bottlenecks = append(report.Bottlenecks, Bottleneck{
+			Node:   tp.Transition,
+			Kind:   "transition",
+			Impact: tp.Waiting.Mean * float64(tp.Waiting.Count),
+			Stats:  tp.Waiting,
+		})
+	}
+	for _, pp := range report.Places {
+		report.Bottlenecks = append(report.Bottlenecks, Bottleneck{
+			Node:   pp.Place,
+			Kind:   "place",
+			Impact: pp.Waiting.Mean * float64(pp.Waiting.Count),
+			Stats:  pp.Waiting,
+		})
+	}
+	sort.Slice(report.Bottlenecks, func(i, j int) bool {
+		if report.Bottlenecks[i].Impact != report.Bottlenecks[j].Impact {
+			return report.Bottlenecks[i].Impact > report.Bottlenecks[j].Impact
+		}
+		return report.Bottlenecks[i].Node < report.Bottlenecks[j].Node
+	})
+
+	return report
+}
+
+// TopBottlenecks returns the k highest-impact bottlenecks.
+func (r *PerformanceReport) TopBottlenecks(k int) []Bottleneck {
+	if k > len(r.Bottlenecks) {
+		k = len(r.Bottlenecks)
+	}
+	return r.Bottlenecks[:k]
+}
+
+// Heatmap maps each annotated node to a 0-1 intensity (waiting impact
+// relative to the worst bottleneck), suitable for
+// visualization.RenderHeatmapSVG.
+func (r *PerformanceReport) Heatmap() map[string]float64 {
+	heat := make(map[string]float64)
+	if len(r.Bottlenecks) == 0 || r.Bottlenecks[0].Impact == 0 {
+		return heat
+	}
+	max := r.Bottlenecks[0].Impact
+	for _, b := range r.Bottlenecks {
+		heat[b.Node] = b.Impact / max
+	}
+	return heat
+}
+
+// String renders a human-readable summary of the top bottlenecks.
+func (r *PerformanceReport) String() string {
+	var b strings.Builder
+	b.WriteString("Performance Report\n")
+	fmt.Fprintf(&b, "  Annotated: %d transitions, %d places\n",
+		len(r.Transitions), len(r.Places))
+	for i, bn := range r.TopBottlenecks(5) {
+		fmt.Fprintf(&b, "  #%d %s %s: mean wait %.1fs over %d observations\n",
+			i+1, bn.Kind, bn.Node, bn.Stats.Mean, bn.Stats.Count)
+	}
+	return b.String()
+}
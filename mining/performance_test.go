@@ -0,0 +1,116 @@
+package mining
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/visualization"
+)
+
+// Helper: sequential log where B consistently waits longer than C.
+func createPerformanceLog() *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	baseTime := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 4; i++ {
+		caseID := string(rune('0' + i))
+		start := baseTime.Add(time.Duration(i) * time.Hour)
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "A", Timestamp: start})
+		// B arrives 30 minutes after A: the bottleneck.
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "B", Timestamp: start.Add(30 * time.Minute)})
+		// C follows B quickly.
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "C", Timestamp: start.Add(32 * time.Minute)})
+	}
+	log.SortTraces()
+	return log
+}
+
+func performanceNet() *petri.PetriNet {
+	return petri.Build().
+		Chain(1, "p0", "A", "p1", "B", "p2", "C", "p3").
+		Done()
+}
+
+func TestAnalyzePerformance(t *testing.T) {
+	report := AnalyzePerformance(createPerformanceLog(), performanceNet())
+
+	b := report.Transitions["B"]
+	if b == nil {
+		t.Fatal("Transition B not annotated")
+	}
+	if b.Sojourn.Count != 4 || b.Sojourn.Mean != 1800 {
+		t.Errorf("B sojourn: count=%d mean=%.0f", b.Sojourn.Count, b.Sojourn.Mean)
+	}
+	c := report.Transitions["C"]
+	if c == nil || c.Sojourn.Mean != 120 {
+		t.Errorf("C sojourn: %+v", c)
+	}
+
+	// B's waiting rolls up into its input place.
+	p1 := report.Places["p1"]
+	if p1 == nil || p1.Waiting.Mean != 1800 {
+		t.Errorf("p1 waiting: %+v", p1)
+	}
+
+	// B (and its input place) dominate the bottleneck ranking.
+	top := report.TopBottlenecks(2)
+	if len(top) != 2 {
+		t.Fatalf("Expected 2 bottlenecks, got %d", len(top))
+	}
+	for _, bn := range top {
+		if bn.Node != "B" && bn.Node != "p1" {
+			t.Errorf("Unexpected top bottleneck: %+v", bn)
+		}
+	}
+}
+
+func TestAnalyzePerformanceLifecycle(t *testing.T) {
+	log := eventlog.NewEventLog()
+	baseTime := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	// A completes at t+0; B waits 10m, then takes 5m of service.
+	log.AddEvent(eventlog.Event{CaseID: "1", Activity: "A", Lifecycle: "complete", Timestamp: baseTime})
+	log.AddEvent(eventlog.Event{CaseID: "1", Activity: "B", Lifecycle: "start", Timestamp: baseTime.Add(10 * time.Minute)})
+	log.AddEvent(eventlog.Event{CaseID: "1", Activity: "B", Lifecycle: "complete", Timestamp: baseTime.Add(15 * time.Minute)})
+	log.SortTraces()
+
+	net := petri.Build().Chain(1, "p0", "A", "p1", "B", "p2").Done()
+	report := AnalyzePerformance(log, net)
+
+	b := report.Transitions["B"]
+	if b == nil {
+		t.Fatal("Transition B not annotated")
+	}
+	if b.Waiting.Mean != 600 {
+		t.Errorf("B waiting: %.0f", b.Waiting.Mean)
+	}
+	if b.Service.Mean != 300 {
+		t.Errorf("B service: %.0f", b.Service.Mean)
+	}
+	if b.Sojourn.Mean != 900 {
+		t.Errorf("B sojourn: %.0f", b.Sojourn.Mean)
+	}
+}
+
+func TestPerformanceHeatmap(t *testing.T) {
+	net := performanceNet()
+	report := AnalyzePerformance(createPerformanceLog(), net)
+
+	heat := report.Heatmap()
+	if heat["B"] != 1.0 {
+		t.Errorf("Worst bottleneck should have intensity 1, got %f", heat["B"])
+	}
+	if heat["C"] >= heat["B"] {
+		t.Errorf("C should be cooler than B: %f vs %f", heat["C"], heat["B"])
+	}
+
+	svg, err := visualization.RenderHeatmapSVG(net, heat)
+	if err != nil {
+		t.Fatalf("RenderHeatmapSVG: %v", err)
+	}
+	if !strings.Contains(svg, "heatmap") {
+		t.Error("SVG missing heatmap overlay")
+	}
+}
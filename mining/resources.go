@@ -0,0 +1,208 @@
+package mining
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// ResourceProfile summarizes one resource's workload and focus.
+type ResourceProfile struct {
+	Name       string         `json:"name"`
+	Events     int            `json:"events"`     // total events performed
+	Cases      int            `json:"cases"`      // distinct cases touched
+	Activities map[string]int `json:"activities"` // events per activity
+
+	// Specialization measures how concentrated the resource's work is:
+	// 1 means all events are one activity, 0 means work is spread evenly
+	// across every activity in the log (1 - normalized entropy).
+	Specialization float64 `json:"specialization"`
+}
+
+// SocialEdge is one weighted connection in a social network.
+type SocialEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Weight int    `json:"weight"`
+}
+
+// SocialNetwork is a resource-level graph mined from an event log.
+// Handover networks are directed (who passes work to whom); working-together
+// networks are undirected (who appears in the same cases).
+type SocialNetwork struct {
+	Directed bool         `json:"directed"`
+	Nodes    []string     `json:"nodes"`
+	Edges    []SocialEdge `json:"edges"`
+}
+
+// ResourceAnalysis contains organizational mining results.
+type ResourceAnalysis struct {
+	Profiles        []ResourceProfile `json:"profiles"`
+	Handover        *SocialNetwork    `json:"handover"`
+	WorkingTogether *SocialNetwork    `json:"workingTogether"`
+}
+
+// AnalyzeResources mines organizational structure from the Resource field:
+// per-resource workload and specialization, a handover-of-work network
+// (directed, counting consecutive events passed between resources within a
+// case) and a working-together network (undirected, counting cases two
+// resources both touched). Events with an empty Resource are ignored.
+func AnalyzeResources(log *eventlog.EventLog) *ResourceAnalysis {
+	events := make(map[string]int)
+	cases := make(map[string]map[string]bool)
+	activities := make(map[string]map[string]int)
+	handover := make(map[string]map[string]int)
+	together := make(map[string]map[string]int)
+	totalActivities := make(map[string]bool)
+
+	for _, trace := range log.GetTraces() {
+		var prev string
+		caseResources := make(map[string]bool)
+		for _, event := range trace.Events {
+			totalActivities[event.Activity] = true
+			r := event.Resource
+			if r == "" {
+				prev = ""
+				continue
+			}
+
+			events[r]++
+			if cases[r] == nil {
+				cases[r] = make(map[string]bool)
+			}
+			cases[r][trace.CaseID] = true
+			if activities[r] == nil {
+				activities[r] = make(map[string]int)
+			}
+			activities[r][event.Activity]++
+			caseResources[r] = true
+
+			if prev != "" && prev != r {
+				if handover[prev] == nil {
+					handover[prev] = make(map[string]int)
+				}
+				handover[prev][r]++
+			}
+			prev = r
+		}
+
+		// Every unordered pair of resources in this case worked together.
+		names := sortedKeys(caseResources)
+		for i := 0; i < len(names); i++ {
+			for j := i + 1; j < len(names); j++ {
+				if together[names[i]] == nil {
+					together[names[i]] = make(map[string]int)
+				}
+				together[names[i]][names[j]]++
+			}
+		}
+	}
+
+	analysis := &ResourceAnalysis{
+		Handover:        buildNetwork(handover, true),
+		WorkingTogether: buildNetwork(together, false),
+	}
+
+	for _, name := range sortedKeys(events) {
+		analysis.Profiles = append(analysis.Profiles, ResourceProfile{
+			Name:           name,
+			Events:         events[name],
+			Cases:          len(cases[name]),
+			Activities:     activities[name],
+			Specialization: specialization(activities[name], events[name], len(totalActivities)),
+		})
+	}
+
+	return analysis
+}
+
+// specialization computes 1 - normalized entropy of the resource's activity
+// distribution, relative to the number of activities in the whole log.
+func specialization(counts map[string]int, total, logActivities int) float64 {
+	if total == 0 || logActivities <= 1 {
+		return 1.0
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return 1.0 - entropy/math.Log2(float64(logActivities))
+}
+
+// buildNetwork flattens an adjacency map into a SocialNetwork with sorted,
+// deterministic node and edge order.
+func buildNetwork(adjacency map[string]map[string]int, directed bool) *SocialNetwork {
+	network := &SocialNetwork{Directed: directed}
+
+	nodes := make(map[string]bool)
+	for from, targets := range adjacency {
+		nodes[from] = true
+		for to := range targets {
+			nodes[to] = true
+		}
+	}
+	network.Nodes = sortedKeys(nodes)
+
+	for _, from := range sortedKeys(adjacency) {
+		for _, to := range sortedKeys(adjacency[from]) {
+			network.Edges = append(network.Edges, SocialEdge{
+				From:   from,
+				To:     to,
+				Weight: adjacency[from][to],
+			})
+		}
+	}
+	return network
+}
+
+// sortedKeys returns map keys in sorted order, for deterministic output.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ToJSON serializes the network for the visualization package or external
+// tools.
+func (n *SocialNetwork) ToJSON() ([]byte, error) {
+	return json.MarshalIndent(n, "", "  ")
+}
+
+// ToDOT renders the network in Graphviz DOT format. Edge weights become
+// labels and proportional pen widths.
+func (n *SocialNetwork) ToDOT() string {
+	var b strings.Builder
+
+	graph, edge := "digraph", "->"
+	if !n.Directed {
+		graph, edge = "graph", "--"
+	}
+	fmt.Fprintf(&b, "%s social_network {\n", graph)
+	b.WriteString("  node [shape=ellipse];\n")
+
+	maxWeight := 1
+	for _, e := range n.Edges {
+		if e.Weight > maxWeight {
+			maxWeight = e.Weight
+		}
+	}
+
+	for _, node := range n.Nodes {
+		fmt.Fprintf(&b, "  %q;\n", node)
+	}
+	for _, e := range n.Edges {
+		width := 1.0 + 3.0*float64(e.Weight)/float64(maxWeight)
+		fmt.Fprintf(&b, "  %q %s %q [label=%q, penwidth=%.1f];\n",
+			e.From, edge, e.To, fmt.Sprintf("%d", e.Weight), width)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package mining
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// Helper: two-resource log where alice hands over to bob.
+func createResourceLog() *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	baseTime := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		caseID := string(rune('0' + i))
+		log.AddEvent(eventlog.Event{
+			CaseID: caseID, Activity: "triage", Resource: "alice",
+			Timestamp: baseTime.Add(time.Duration(i) * time.Hour),
+		})
+		log.AddEvent(eventlog.Event{
+			CaseID: caseID, Activity: "treat", Resource: "bob",
+			Timestamp: baseTime.Add(time.Duration(i)*time.Hour + 10*time.Minute),
+		})
+		log.AddEvent(eventlog.Event{
+			CaseID: caseID, Activity: "discharge", Resource: "bob",
+			Timestamp: baseTime.Add(time.Duration(i)*time.Hour + 20*time.Minute),
+		})
+	}
+	log.SortTraces()
+	return log
+}
+
+func TestAnalyzeResourcesProfiles(t *testing.T) {
+	analysis := AnalyzeResources(createResourceLog())
+
+	if len(analysis.Profiles) != 2 {
+		t.Fatalf("Expected 2 profiles, got %d", len(analysis.Profiles))
+	}
+
+	alice := analysis.Profiles[0]
+	bob := analysis.Profiles[1]
+	if alice.Name != "alice" || bob.Name != "bob" {
+		t.Fatalf("Profiles not sorted by name: %s, %s", alice.Name, bob.Name)
+	}
+	if alice.Events != 5 || alice.Cases != 5 {
+		t.Errorf("alice workload: events=%d cases=%d", alice.Events, alice.Cases)
+	}
+	if bob.Events != 10 || bob.Activities["treat"] != 5 {
+		t.Errorf("bob workload: events=%d activities=%v", bob.Events, bob.Activities)
+	}
+
+	// alice only triages — fully specialized. bob splits work across two
+	// of the three activities.
+	if alice.Specialization != 1.0 {
+		t.Errorf("alice specialization: %f", alice.Specialization)
+	}
+	if bob.Specialization <= 0 || bob.Specialization >= 1 {
+		t.Errorf("bob specialization should be in (0,1): %f", bob.Specialization)
+	}
+}
+
+func TestAnalyzeResourcesNetworks(t *testing.T) {
+	analysis := AnalyzeResources(createResourceLog())
+
+	handover := analysis.Handover
+	if !handover.Directed {
+		t.Error("Handover network should be directed")
+	}
+	if len(handover.Edges) != 1 {
+		t.Fatalf("Expected 1 handover edge, got %d", len(handover.Edges))
+	}
+	edge := handover.Edges[0]
+	if edge.From != "alice" || edge.To != "bob" || edge.Weight != 5 {
+		t.Errorf("Unexpected handover edge: %+v", edge)
+	}
+
+	together := analysis.WorkingTogether
+	if together.Directed {
+		t.Error("Working-together network should be undirected")
+	}
+	if len(together.Edges) != 1 || together.Edges[0].Weight != 5 {
+		t.Errorf("Unexpected working-together edges: %+v", together.Edges)
+	}
+}
+
+func TestSocialNetworkExport(t *testing.T) {
+	analysis := AnalyzeResources(createResourceLog())
+
+	data, err := analysis.Handover.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON: %v", err)
+	}
+	if !strings.Contains(string(data), `"from": "alice"`) {
+		t.Errorf("JSON missing edge: %s", data)
+	}
+
+	dot := analysis.Handover.ToDOT()
+	if !strings.HasPrefix(dot, "digraph") {
+		t.Errorf("Directed network should render as digraph: %s", dot)
+	}
+	if !strings.Contains(dot, `"alice" -> "bob"`) {
+		t.Errorf("DOT missing handover edge: %s", dot)
+	}
+
+	undirected := analysis.WorkingTogether.ToDOT()
+	if !strings.HasPrefix(undirected, "graph") || !strings.Contains(undirected, `"alice" -- "bob"`) {
+		t.Errorf("Undirected DOT wrong: %s", undirected)
+	}
+}
@@ -0,0 +1,96 @@
+package mining
+
+import (
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// LearnRateSchedulesFromLog learns hour-of-week service rate schedules from
+// an event log. Where LearnRatesFromLog collapses each activity to one rate,
+// this buckets activity durations by the hour of the week in which the
+// activity started and estimates a separate rate (1/mean duration, per
+// second) per slot. Slots with no observations fall back to the activity's
+// overall rate, so the schedule is always fully populated. Activities map to
+// transitions by name, like LearnRatesFromLog.
+func LearnRateSchedulesFromLog(log *eventlog.EventLog, net *petri.PetriNet) map[string]*solver.RateSchedule {
+	// Per activity, per slot: accumulated duration and count.
+	sums := make(map[string][]float64)
+	counts := make(map[string][]int)
+	for _, trace := range log.GetTraces() {
+		for i := 0; i < len(trace.Events)-1; i++ {
+			activity := trace.Events[i].Activity
+			duration := trace.Events[i+1].Timestamp.Sub(trace.Events[i].Timestamp).Seconds()
+			if duration < 0 {
+				continue
+			}
+			if sums[activity] == nil {
+				sums[activity] = make([]float64, solver.HoursPerWeek)
+				counts[activity] = make([]int, solver.HoursPerWeek)
+			}
+			slot := solver.HourOfWeek(trace.Events[i].Timestamp)
+			sums[activity][slot] += duration
+			counts[activity][slot]++
+		}
+	}
+
+	stats := ExtractTiming(log)
+	schedules := make(map[string]*solver.RateSchedule)
+	for transName := range net.Transitions {
+		fallback := stats.EstimateRate(transName)
+		hourly := make([]float64, solver.HoursPerWeek)
+		for slot := range hourly {
+			if counts[transName] != nil && counts[transName][slot] > 0 {
+				mean := sums[transName][slot] / float64(counts[transName][slot])
+				if mean > 0 {
+					hourly[slot] = 1.0 / mean
+					continue
+				}
+			}
+			hourly[slot] = fallback
+		}
+		schedules[transName] = solver.NewRateSchedule(hourly)
+	}
+	return schedules
+}
+
+// LearnArrivalScheduleFromLog learns an hour-of-week case arrival rate
+// schedule: case starts per slot divided by how often that slot occurs in
+// the log's span, converted to arrivals per second. Returns nil for a log
+// with no cases.
+func LearnArrivalScheduleFromLog(log *eventlog.EventLog) *solver.RateSchedule {
+	var first, last time.Time
+	starts := make([]int, solver.HoursPerWeek)
+	for _, trace := range log.GetTraces() {
+		if len(trace.Events) == 0 {
+			continue
+		}
+		at := trace.StartTime()
+		starts[solver.HourOfWeek(at)]++
+		if first.IsZero() || at.Before(first) {
+			first = at
+		}
+		if at.After(last) {
+			last = at
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+
+	// Count how many times each slot occurs between the first and last case.
+	occurrences := make([]int, solver.HoursPerWeek)
+	for h := first.Truncate(time.Hour); !h.After(last); h = h.Add(time.Hour) {
+		occurrences[solver.HourOfWeek(h)]++
+	}
+
+	hourly := make([]float64, solver.HoursPerWeek)
+	for slot := range hourly {
+		if occurrences[slot] > 0 {
+			hourly[slot] = float64(starts[slot]) / float64(occurrences[slot]) / 3600.0
+		}
+	}
+	return solver.NewRateSchedule(hourly)
+}
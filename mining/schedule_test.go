@@ -0,0 +1,101 @@
+package mining
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// scheduleLog builds a log where "work" takes 10s in the 9:00 hour and 100s
+// in the 18:00 hour, across two weeks of weekdays.
+func scheduleLog() *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	// Monday 2026-03-02.
+	base := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	n := 0
+	for day := 0; day < 12; day++ {
+		at := base.AddDate(0, 0, day)
+		if wd := at.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			continue
+		}
+		for _, h := range []struct {
+			hour     int
+			duration float64
+		}{{9, 10}, {18, 100}} {
+			n++
+			caseID := fmt.Sprintf("c%d", n)
+			start := at.Add(time.Duration(h.hour) * time.Hour)
+			log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "work", Timestamp: start})
+			log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "done",
+				Timestamp: start.Add(time.Duration(h.duration * float64(time.Second)))})
+		}
+	}
+	return log
+}
+
+func TestLearnRateSchedulesFromLog(t *testing.T) {
+	log := scheduleLog()
+	schedules := LearnRateSchedulesFromLog(log, workNet())
+
+	sched, ok := schedules["work"]
+	if !ok {
+		t.Fatal("Expected a schedule for work")
+	}
+
+	morning := solver.HourOfWeek(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	evening := solver.HourOfWeek(time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC))
+	if got := sched.Hourly[morning]; math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("Morning rate = %f, want 0.1", got)
+	}
+	if got := sched.Hourly[evening]; math.Abs(got-0.01) > 1e-9 {
+		t.Errorf("Evening rate = %f, want 0.01", got)
+	}
+
+	// Unobserved slots fall back to the overall rate (mean duration 55s).
+	idle := solver.HourOfWeek(time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC))
+	overall := ExtractTiming(log).EstimateRate("work")
+	if got := sched.Hourly[idle]; math.Abs(got-overall) > 1e-9 {
+		t.Errorf("Idle slot = %f, want overall fallback %f", got, overall)
+	}
+
+	// Every transition in the net gets a fully populated schedule.
+	if _, ok := schedules["done"]; !ok {
+		t.Error("Expected a schedule for every transition")
+	}
+}
+
+func TestLearnArrivalScheduleFromLog(t *testing.T) {
+	log := eventlog.NewEventLog()
+	// Three cases at Monday 09:xx, two weeks running: slot rate 3/hour.
+	for week := 0; week < 2; week++ {
+		for i := 0; i < 3; i++ {
+			caseID := fmt.Sprintf("w%d-c%d", week, i)
+			at := time.Date(2026, 3, 2, 9, 10*i, 0, 0, time.UTC).AddDate(0, 0, 7*week)
+			log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "start", Timestamp: at})
+		}
+	}
+
+	sched := LearnArrivalScheduleFromLog(log)
+	if sched == nil {
+		t.Fatal("Expected a schedule")
+	}
+	slot := solver.HourOfWeek(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC))
+	want := 3.0 / 3600.0 // 6 arrivals over 2 slot occurrences
+	if math.Abs(sched.Hourly[slot]-want) > 1e-12 {
+		t.Errorf("Arrival rate = %f, want %f", sched.Hourly[slot], want)
+	}
+
+	// Slots never observed arriving hold zero.
+	quiet := solver.HourOfWeek(time.Date(2026, 3, 4, 2, 0, 0, 0, time.UTC))
+	if sched.Hourly[quiet] != 0 {
+		t.Errorf("Quiet slot = %f, want 0", sched.Hourly[quiet])
+	}
+
+	if LearnArrivalScheduleFromLog(eventlog.NewEventLog()) != nil {
+		t.Error("Expected nil schedule for empty log")
+	}
+}
@@ -0,0 +1,202 @@
+package mining
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// TimeSeries is a regularly sampled KPI series extracted from an event log.
+type TimeSeries struct {
+	Start    time.Time
+	Interval time.Duration
+	Values   []float64
+}
+
+// Time returns the bucket start time of sample i.
+func (s *TimeSeries) Time(i int) time.Time {
+	return s.Start.Add(time.Duration(i) * s.Interval)
+}
+
+// ExtractThroughput buckets case arrivals into a throughput series: cases
+// started per interval, from the first to the last case in the log.
+func ExtractThroughput(log *eventlog.EventLog, interval time.Duration) *TimeSeries {
+	return extractSeries(log, interval, func(trace *eventlog.Trace) (time.Time, float64, bool) {
+		if len(trace.Events) == 0 {
+			return time.Time{}, 0, false
+		}
+		return trace.StartTime(), 1, true
+	}, false)
+}
+
+// ExtractCycleTimes buckets case completions into a mean cycle-time series,
+// in seconds per interval. Intervals without completions hold zero.
+func ExtractCycleTimes(log *eventlog.EventLog, interval time.Duration) *TimeSeries {
+	return extractSeries(log, interval, func(trace *eventlog.Trace) (time.Time, float64, bool) {
+		if len(trace.Events) == 0 {
+			return time.Time{}, 0, false
+		}
+		return trace.EndTime(), trace.Duration().Seconds(), true
+	}, true)
+}
+
+// extractSeries buckets one value per trace; mean=true averages within a
+// bucket instead of summing.
+func extractSeries(log *eventlog.EventLog, interval time.Duration,
+	sample func(*eventlog.Trace) (time.Time, float64, bool), mean bool) *TimeSeries {
+
+	var first, last time.Time
+	for _, trace := range log.GetTraces() {
+		at, _, ok := sample(trace)
+		if !ok {
+			continue
+		}
+		if first.IsZero() || at.Before(first) {
+			first = at
+		}
+		if at.After(last) {
+			last = at
+		}
+	}
+	if first.IsZero() {
+		return &TimeSeries{Interval: interval}
+	}
+
+	start := first.Truncate(interval)
+	buckets := int(last.Sub(start)/interval) + 1
+	values := make([]float64, buckets)
+	counts := make([]int, buckets)
+	for _, trace := range log.GetTraces() {
+		at, v, ok := sample(trace)
+		if !ok {
+			continue
+		}
+		i := int(at.Sub(start) / interval)
+		values[i] += v
+		counts[i]++
+	}
+	if mean {
+		for i := range values {
+			if counts[i] > 0 {
+				values[i] /= float64(counts[i])
+			}
+		}
+	}
+	return &TimeSeries{Start: start, Interval: interval, Values: values}
+}
+
+// Decomposition splits a series into trend, seasonal and residual
+// components, aligned index-for-index with the input.
+type Decomposition struct {
+	Period   int
+	Trend    []float64
+	Seasonal []float64
+	Residual []float64
+
+	// SeasonalStrength is 1 - Var(residual)/Var(seasonal+residual): near 1
+	// means the seasonal pattern explains most non-trend variation, near 0
+	// means apparent seasonality is noise.
+	SeasonalStrength float64
+}
+
+// Decompose performs an additive STL-style decomposition with the given
+// period (e.g. 7 for daily samples with weekly seasonality, 168 for hourly).
+// The series must cover at least two full periods.
+func Decompose(series *TimeSeries, period int) (*Decomposition, error) {
+	n := len(series.Values)
+	if period < 2 {
+		return nil, fmt.Errorf("period must be at least 2, got %d", period)
+	}
+	if n < 2*period {
+		return nil, fmt.Errorf("series too short: %d samples, need %d (two periods)", n, 2*period)
+	}
+
+	// Trend: centered moving average over one period.
+	trend := make([]float64, n)
+	half := period / 2
+	for i := 0; i < n; i++ {
+		lo, hi := i-half, i+half
+		if lo < 0 {
+			lo = 0
+		}
+		if hi >= n {
+			hi = n - 1
+		}
+		sum := 0.0
+		for j := lo; j <= hi; j++ {
+			sum += series.Values[j]
+		}
+		trend[i] = sum / float64(hi-lo+1)
+	}
+
+	// Seasonal: mean detrended value per phase, centered to zero.
+	phaseSum := make([]float64, period)
+	phaseCount := make([]int, period)
+	for i := 0; i < n; i++ {
+		phaseSum[i%period] += series.Values[i] - trend[i]
+		phaseCount[i%period]++
+	}
+	phase := make([]float64, period)
+	meanPhase := 0.0
+	for p := 0; p < period; p++ {
+		phase[p] = phaseSum[p] / float64(phaseCount[p])
+		meanPhase += phase[p]
+	}
+	meanPhase /= float64(period)
+	for p := range phase {
+		phase[p] -= meanPhase
+	}
+
+	seasonal := make([]float64, n)
+	residual := make([]float64, n)
+	for i := 0; i < n; i++ {
+		seasonal[i] = phase[i%period]
+		residual[i] = series.Values[i] - trend[i] - seasonal[i]
+	}
+
+	d := &Decomposition{
+		Period:   period,
+		Trend:    trend,
+		Seasonal: seasonal,
+		Residual: residual,
+	}
+	detrended := make([]float64, n)
+	for i := range detrended {
+		detrended[i] = seasonal[i] + residual[i]
+	}
+	if v := variance(detrended); v > 0 {
+		d.SeasonalStrength = 1 - variance(residual)/v
+		if d.SeasonalStrength < 0 {
+			d.SeasonalStrength = 0
+		}
+	}
+	return d, nil
+}
+
+// DecomposeWeekly decomposes with a one-week period derived from the
+// series' sampling interval.
+func DecomposeWeekly(series *TimeSeries) (*Decomposition, error) {
+	if series.Interval <= 0 {
+		return nil, fmt.Errorf("series has no sampling interval")
+	}
+	period := int(7 * 24 * time.Hour / series.Interval)
+	return Decompose(series, period)
+}
+
+// variance computes population variance.
+func variance(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return sum / float64(len(values))
+}
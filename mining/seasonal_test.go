@@ -0,0 +1,115 @@
+package mining
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// Helper: four weeks of daily cases, busier on weekdays, with case counts
+// drifting upward.
+func createSeasonalLog() *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	// A Monday.
+	baseTime := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	caseNum := 0
+	for day := 0; day < 28; day++ {
+		dayStart := baseTime.Add(time.Duration(day) * 24 * time.Hour)
+		cases := 2 + day/10 // slow upward trend
+		if dayStart.Weekday() == time.Saturday || dayStart.Weekday() == time.Sunday {
+			cases = 1
+		} else {
+			cases += 4 // weekday bump
+		}
+		for i := 0; i < cases; i++ {
+			caseNum++
+			caseID := fmt.Sprintf("c%d", caseNum)
+			log.AddEvent(eventlog.Event{
+				CaseID: caseID, Activity: "open",
+				Timestamp: dayStart.Add(time.Duration(i) * time.Minute),
+			})
+			log.AddEvent(eventlog.Event{
+				CaseID: caseID, Activity: "close",
+				Timestamp: dayStart.Add(time.Hour),
+			})
+		}
+	}
+	log.SortTraces()
+	return log
+}
+
+func TestExtractThroughput(t *testing.T) {
+	series := ExtractThroughput(createSeasonalLog(), 24*time.Hour)
+	if len(series.Values) != 28 {
+		t.Fatalf("Expected 28 daily buckets, got %d", len(series.Values))
+	}
+	// First Monday: 6 cases; first Saturday: 1.
+	if series.Values[0] != 6 {
+		t.Errorf("Monday throughput: %f", series.Values[0])
+	}
+	if series.Values[5] != 1 {
+		t.Errorf("Saturday throughput: %f", series.Values[5])
+	}
+}
+
+func TestExtractCycleTimes(t *testing.T) {
+	series := ExtractCycleTimes(createSeasonalLog(), 24*time.Hour)
+	if len(series.Values) == 0 {
+		t.Fatal("Empty cycle time series")
+	}
+	// Every case completes within the hour it opened; first bucket mean
+	// cycle time is under an hour.
+	if series.Values[0] <= 0 || series.Values[0] > 3600 {
+		t.Errorf("Cycle time out of range: %f", series.Values[0])
+	}
+}
+
+func TestDecomposeWeekly(t *testing.T) {
+	series := ExtractThroughput(createSeasonalLog(), 24*time.Hour)
+	d, err := Decompose(series, 7)
+	if err != nil {
+		t.Fatalf("Decompose: %v", err)
+	}
+
+	if len(d.Trend) != 28 || len(d.Seasonal) != 28 || len(d.Residual) != 28 {
+		t.Fatal("Components not aligned with input")
+	}
+	// Weekend phases carry negative seasonal components, weekdays
+	// positive.
+	if d.Seasonal[5] >= 0 || d.Seasonal[6] >= 0 {
+		t.Errorf("Weekend seasonality should be negative: %f, %f",
+			d.Seasonal[5], d.Seasonal[6])
+	}
+	if d.Seasonal[1] <= 0 {
+		t.Errorf("Weekday seasonality should be positive: %f", d.Seasonal[1])
+	}
+	// The weekday/weekend pattern dominates the variation.
+	if d.SeasonalStrength < 0.5 {
+		t.Errorf("Expected strong seasonality, got %f", d.SeasonalStrength)
+	}
+	// Components reassemble the observations.
+	for i := range series.Values {
+		sum := d.Trend[i] + d.Seasonal[i] + d.Residual[i]
+		if math.Abs(sum-series.Values[i]) > 1e-9 {
+			t.Fatalf("Decomposition does not reassemble at %d: %f vs %f",
+				i, sum, series.Values[i])
+		}
+	}
+}
+
+func TestDecomposeErrors(t *testing.T) {
+	short := &TimeSeries{Interval: 24 * time.Hour, Values: []float64{1, 2, 3}}
+	if _, err := Decompose(short, 7); err == nil {
+		t.Error("Expected error for series shorter than two periods")
+	}
+	if _, err := Decompose(short, 1); err == nil {
+		t.Error("Expected error for degenerate period")
+	}
+	if _, err := DecomposeWeekly(&TimeSeries{}); err == nil {
+		t.Error("Expected error for missing interval")
+	}
+}
@@ -0,0 +1,116 @@
+package monitoring
+
+import (
+	"math"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// BacktestResult reports how well the prediction engine would have
+// performed on a historical log: error statistics for remaining-time
+// predictions and a confusion matrix for SLA-violation calls.
+type BacktestResult struct {
+	Cases       int // completed traces replayed
+	Predictions int // predictions evaluated (one per non-final event)
+
+	// Remaining-time accuracy across all predictions.
+	MAE  time.Duration // mean absolute error
+	MAPE float64       // mean absolute percentage error (0-1 scale)
+
+	// SLA-violation classification per case: a case is predicted violating
+	// when any prefix predicted completion beyond the SLA threshold, and
+	// actually violating when its real duration exceeded it.
+	TruePositives  int
+	FalsePositives int
+	FalseNegatives int
+	TrueNegatives  int
+	Precision      float64
+	Recall         float64
+}
+
+// Backtest replays a historical event log through the prediction engine.
+// At every event prefix of every completed trace it predicts the remaining
+// time exactly as the live monitor would — using only events up to that
+// point and their historical timestamps — then scores the prediction
+// against what actually happened. Use it to answer "is predictive
+// monitoring actually working" before trusting it in production.
+func Backtest(log *eventlog.EventLog, net *petri.PetriNet, rates map[string]float64, config MonitorConfig) *BacktestResult {
+	predictor := NewPredictor(net, rates)
+	result := &BacktestResult{}
+
+	var absErrSum float64 // seconds
+	var pctErrSum float64
+	var pctErrCount int
+
+	for _, trace := range log.GetTraces() {
+		if len(trace.Events) < 2 {
+			continue
+		}
+		result.Cases++
+
+		startTime := trace.Events[0].Timestamp
+		endTime := trace.Events[len(trace.Events)-1].Timestamp
+		actualDuration := endTime.Sub(startTime)
+
+		predictedViolation := false
+		c := &Case{ID: trace.CaseID, StartTime: startTime}
+		for i := 0; i < len(trace.Events)-1; i++ {
+			event := trace.Events[i]
+			c.History = append(c.History, Event{
+				CaseID:    trace.CaseID,
+				Activity:  event.Activity,
+				Timestamp: event.Timestamp,
+				Resource:  event.Resource,
+			})
+
+			// Predict from the prefix using historical time, not the wall
+			// clock.
+			state := EstimateCurrentState(c, net)
+			elapsed := event.Timestamp.Sub(startTime)
+			pred := predictor.PredictFromState(state, elapsed.Seconds())
+			predictedRemaining := time.Duration((pred.PredictedEndTime - pred.CurrentTime) * float64(time.Second))
+
+			actualRemaining := endTime.Sub(event.Timestamp)
+			result.Predictions++
+			absErrSum += math.Abs((predictedRemaining - actualRemaining).Seconds())
+			if actualRemaining > 0 {
+				pctErrSum += math.Abs((predictedRemaining-actualRemaining).Seconds()) / actualRemaining.Seconds()
+				pctErrCount++
+			}
+
+			if config.SLAThreshold > 0 && elapsed+predictedRemaining > config.SLAThreshold {
+				predictedViolation = true
+			}
+		}
+
+		if config.SLAThreshold > 0 {
+			actualViolation := actualDuration > config.SLAThreshold
+			switch {
+			case predictedViolation && actualViolation:
+				result.TruePositives++
+			case predictedViolation && !actualViolation:
+				result.FalsePositives++
+			case !predictedViolation && actualViolation:
+				result.FalseNegatives++
+			default:
+				result.TrueNegatives++
+			}
+		}
+	}
+
+	if result.Predictions > 0 {
+		result.MAE = time.Duration(absErrSum / float64(result.Predictions) * float64(time.Second))
+	}
+	if pctErrCount > 0 {
+		result.MAPE = pctErrSum / float64(pctErrCount)
+	}
+	if result.TruePositives+result.FalsePositives > 0 {
+		result.Precision = float64(result.TruePositives) / float64(result.TruePositives+result.FalsePositives)
+	}
+	if result.TruePositives+result.FalseNegatives > 0 {
+		result.Recall = float64(result.TruePositives) / float64(result.TruePositives+result.FalseNegatives)
+	}
+	return result
+}
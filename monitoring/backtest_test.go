@@ -0,0 +1,88 @@
+package monitoring
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// backtestLog builds completed begin -> finish traces; slow cases take
+// well over the SLA, fast ones finish quickly.
+func backtestLog(fast, slow int) *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	base := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	addCase := func(id string, start time.Time, step time.Duration) {
+		for j, activity := range []string{"begin", "finish", "done"} {
+			log.AddEvent(eventlog.Event{
+				CaseID:    id,
+				Activity:  activity,
+				Timestamp: start.Add(time.Duration(j) * step),
+			})
+		}
+	}
+	for i := 0; i < fast; i++ {
+		addCase(fmt.Sprintf("fast-%d", i), base.Add(time.Duration(i)*time.Hour), time.Minute)
+	}
+	for i := 0; i < slow; i++ {
+		addCase(fmt.Sprintf("slow-%d", i), base.Add(time.Duration(fast+i)*time.Hour), 3*time.Hour)
+	}
+	return log
+}
+
+func TestBacktestScoresPredictions(t *testing.T) {
+	net := chainNet() // start -> begin -> working -> finish -> end
+	rates := map[string]float64{"begin": 1.0 / 60, "finish": 1.0 / 60}
+
+	config := DefaultMonitorConfig()
+	config.SLAThreshold = time.Hour
+
+	result := Backtest(backtestLog(5, 5), net, rates, config)
+
+	if result.Cases != 10 {
+		t.Fatalf("Expected 10 cases, got %d", result.Cases)
+	}
+	// Two evaluated prefixes per 3-event trace.
+	if result.Predictions != 20 {
+		t.Errorf("Expected 20 predictions, got %d", result.Predictions)
+	}
+	if result.MAE <= 0 {
+		t.Errorf("Expected positive MAE, got %v", result.MAE)
+	}
+	if result.MAPE <= 0 {
+		t.Errorf("Expected positive MAPE, got %f", result.MAPE)
+	}
+
+	total := result.TruePositives + result.FalsePositives + result.FalseNegatives + result.TrueNegatives
+	if total != result.Cases {
+		t.Errorf("Confusion matrix covers %d of %d cases", total, result.Cases)
+	}
+	// Slow cases blow through the 1h SLA with hours between events; once
+	// elapsed alone exceeds the threshold every predictor must flag them.
+	if result.TruePositives+result.FalseNegatives != 5 {
+		t.Errorf("Expected 5 actually-violating cases, got %d",
+			result.TruePositives+result.FalseNegatives)
+	}
+	if result.Recall < 1.0 {
+		t.Errorf("Slow cases should all be flagged: recall=%f (%+v)", result.Recall, result)
+	}
+	if result.Precision < 0 || result.Precision > 1 {
+		t.Errorf("Precision out of range: %f", result.Precision)
+	}
+}
+
+func TestBacktestWithoutSLA(t *testing.T) {
+	net := chainNet()
+	config := DefaultMonitorConfig()
+	config.SLAThreshold = 0
+
+	result := Backtest(backtestLog(3, 0), net, map[string]float64{"begin": 0.1, "finish": 0.1}, config)
+	if result.Cases != 3 || result.Predictions != 6 {
+		t.Errorf("Unexpected counts: %+v", result)
+	}
+	if result.TruePositives+result.FalsePositives+result.FalseNegatives+result.TrueNegatives != 0 {
+		t.Error("SLA classification should be skipped without a threshold")
+	}
+}
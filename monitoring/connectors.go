@@ -0,0 +1,339 @@
+package monitoring
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// FieldMapping tells a connector which fields of an incoming record carry
+// the case ID, activity, timestamp, and resource, and which activity values
+// mark the start and end of a case. All connectors share this mapping so the
+// monitor can be pointed at real systems without bespoke glue code.
+type FieldMapping struct {
+	CaseID    string // field holding the case identifier (required)
+	Activity  string // field holding the activity name (required)
+	Timestamp string // field holding the event time ("" = receive time)
+	Resource  string // field holding the resource ("" = none)
+
+	// TimestampLayout parses the timestamp field; defaults to RFC 3339.
+	TimestampLayout string
+
+	// StartActivities lists activity values that open a new case. When
+	// empty, a case is started implicitly on its first event.
+	StartActivities []string
+
+	// EndActivities lists activity values that complete the case.
+	EndActivities []string
+}
+
+// Dispatch maps one record onto the monitor: StartCase for new cases (or
+// start activities), RecordEvent for every event, and CompleteCase when the
+// activity is an end activity.
+func (fm FieldMapping) Dispatch(m *Monitor, record map[string]string) error {
+	caseID := record[fm.CaseID]
+	activity := record[fm.Activity]
+	if caseID == "" {
+		return fmt.Errorf("record missing case field %q", fm.CaseID)
+	}
+	if activity == "" {
+		return fmt.Errorf("record missing activity field %q", fm.Activity)
+	}
+
+	timestamp := time.Now()
+	if fm.Timestamp != "" {
+		layout := fm.TimestampLayout
+		if layout == "" {
+			layout = time.RFC3339
+		}
+		if raw := record[fm.Timestamp]; raw != "" {
+			parsed, err := time.Parse(layout, raw)
+			if err != nil {
+				return fmt.Errorf("record has invalid timestamp %q: %w", raw, err)
+			}
+			timestamp = parsed
+		}
+	}
+
+	resource := ""
+	if fm.Resource != "" {
+		resource = record[fm.Resource]
+	}
+
+	// Start the case if this is a start activity or the case is unknown.
+	if _, exists := m.GetCase(caseID); !exists {
+		if len(fm.StartActivities) == 0 || containsString(fm.StartActivities, activity) {
+			if err := m.StartCase(caseID, timestamp); err != nil {
+				return err
+			}
+		} else {
+			return fmt.Errorf("event for unknown case %s (activity %q is not a start activity)", caseID, activity)
+		}
+	}
+
+	if err := m.RecordEvent(caseID, activity, timestamp, resource); err != nil {
+		return err
+	}
+
+	if containsString(fm.EndActivities, activity) {
+		return m.CompleteCase(caseID, timestamp)
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// --- HTTP webhook connector ---
+
+// WebhookConnector receives events as HTTP POSTs with a JSON object body
+// (or a JSON array of objects) and dispatches them to the monitor. Mount it
+// on any mux: http.Handle("/events", connector).
+type WebhookConnector struct {
+	monitor *Monitor
+	mapping FieldMapping
+}
+
+// NewWebhookConnector creates an HTTP handler that feeds the monitor.
+func NewWebhookConnector(m *Monitor, mapping FieldMapping) *WebhookConnector {
+	return &WebhookConnector{monitor: m, mapping: mapping}
+}
+
+// ServeHTTP implements http.Handler.
+func (c *WebhookConnector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	records, err := decodeRecords(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, record := range records {
+		if err := c.mapping.Dispatch(c.monitor, record); err != nil {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// decodeRecords accepts a JSON object or array of objects and flattens
+// values to strings.
+func decodeRecords(body []byte) ([]map[string]string, error) {
+	var single map[string]any
+	if err := json.Unmarshal(body, &single); err == nil {
+		return []map[string]string{stringifyRecord(single)}, nil
+	}
+	var many []map[string]any
+	if err := json.Unmarshal(body, &many); err == nil {
+		records := make([]map[string]string, len(many))
+		for i, rec := range many {
+			records[i] = stringifyRecord(rec)
+		}
+		return records, nil
+	}
+	return nil, fmt.Errorf("body is not a JSON object or array of objects")
+}
+
+func stringifyRecord(rec map[string]any) map[string]string {
+	out := make(map[string]string, len(rec))
+	for k, v := range rec {
+		out[k] = fmt.Sprintf("%v", v)
+	}
+	return out
+}
+
+// --- File tail connector ---
+
+// FileTailConnector tails a CSV (with header) or JSONL file and dispatches
+// appended rows to the monitor. It polls for new lines, so it works on plain
+// files without inotify support.
+type FileTailConnector struct {
+	monitor  *Monitor
+	mapping  FieldMapping
+	path     string
+	interval time.Duration
+
+	stopCh chan struct{}
+}
+
+// NewFileTailConnector creates a connector tailing the given file. Format is
+// inferred from the extension: ".csv" parses rows against the header line;
+// anything else is treated as JSONL.
+func NewFileTailConnector(m *Monitor, mapping FieldMapping, path string, interval time.Duration) *FileTailConnector {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	return &FileTailConnector{
+		monitor:  m,
+		mapping:  mapping,
+		path:     path,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Run tails the file until the context is cancelled or Stop is called.
+// Dispatch errors on individual rows are reported to errFn (if non-nil) and
+// do not stop the tailer.
+func (c *FileTailConnector) Run(ctx context.Context, errFn func(error)) error {
+	file, err := os.Open(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", c.path, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	isCSV := strings.HasSuffix(strings.ToLower(c.path), ".csv")
+	var header []string
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			if line == "" {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-c.stopCh:
+					return nil
+				case <-time.After(c.interval):
+					continue
+				}
+			}
+			// Partial line without newline yet; wait for the rest.
+			if _, err := file.Seek(-int64(len(line)), io.SeekCurrent); err == nil {
+				reader.Reset(file)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", c.path, err)
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			continue
+		}
+
+		var record map[string]string
+		if isCSV {
+			fields, err := csv.NewReader(strings.NewReader(line)).Read()
+			if err != nil {
+				if errFn != nil {
+					errFn(fmt.Errorf("bad CSV row: %w", err))
+				}
+				continue
+			}
+			if header == nil {
+				header = fields
+				continue
+			}
+			record = make(map[string]string, len(header))
+			for i, name := range header {
+				if i < len(fields) {
+					record[name] = fields[i]
+				}
+			}
+		} else {
+			var raw map[string]any
+			if err := json.Unmarshal([]byte(line), &raw); err != nil {
+				if errFn != nil {
+					errFn(fmt.Errorf("bad JSONL row: %w", err))
+				}
+				continue
+			}
+			record = stringifyRecord(raw)
+		}
+
+		if err := c.mapping.Dispatch(c.monitor, record); err != nil && errFn != nil {
+			errFn(err)
+		}
+	}
+}
+
+// Stop terminates a running tailer.
+func (c *FileTailConnector) Stop() {
+	close(c.stopCh)
+}
+
+// --- Kafka connector ---
+
+// KafkaConnectorConfig configures a Kafka consumer connector.
+type KafkaConnectorConfig struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+}
+
+// KafkaConnector consumes JSON messages from a Kafka topic and dispatches
+// them to the monitor. Each message value must be a JSON object whose fields
+// match the configured FieldMapping.
+type KafkaConnector struct {
+	monitor *Monitor
+	mapping FieldMapping
+	reader  *kafka.Reader
+}
+
+// NewKafkaConnector creates a connector consuming from the given topic.
+func NewKafkaConnector(m *Monitor, mapping FieldMapping, config KafkaConnectorConfig) *KafkaConnector {
+	return &KafkaConnector{
+		monitor: m,
+		mapping: mapping,
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: config.Brokers,
+			Topic:   config.Topic,
+			GroupID: config.GroupID,
+		}),
+	}
+}
+
+// Run consumes messages until the context is cancelled. Dispatch errors on
+// individual messages are reported to errFn (if non-nil) and do not stop
+// consumption.
+func (c *KafkaConnector) Run(ctx context.Context, errFn func(error)) error {
+	defer c.reader.Close()
+	for {
+		msg, err := c.reader.ReadMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to read from kafka: %w", err)
+		}
+		records, err := decodeRecords(msg.Value)
+		if err != nil {
+			if errFn != nil {
+				errFn(err)
+			}
+			continue
+		}
+		for _, record := range records {
+			if err := c.mapping.Dispatch(c.monitor, record); err != nil && errFn != nil {
+				errFn(err)
+			}
+		}
+	}
+}
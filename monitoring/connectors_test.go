@@ -0,0 +1,212 @@
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newConnectorMonitor() *Monitor {
+	config := DefaultMonitorConfig()
+	config.EnablePredictions = false
+	config.EnableAlerts = false
+	return NewMonitor(newTestNet(), nil, config)
+}
+
+func connectorMapping() FieldMapping {
+	return FieldMapping{
+		CaseID:        "case",
+		Activity:      "activity",
+		Timestamp:     "ts",
+		Resource:      "agent",
+		EndActivities: []string{"resolve"},
+	}
+}
+
+func TestDispatchLifecycle(t *testing.T) {
+	m := newConnectorMonitor()
+	mapping := connectorMapping()
+
+	err := mapping.Dispatch(m, map[string]string{
+		"case": "c1", "activity": "triage", "ts": "2026-08-28T10:00:00Z", "agent": "alice",
+	})
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+
+	c, ok := m.GetCase("c1")
+	if !ok {
+		t.Fatal("Case not started by first event")
+	}
+	if len(c.History) != 1 || c.History[0].Resource != "alice" {
+		t.Errorf("Event not recorded: %+v", c.History)
+	}
+	want := time.Date(2026, 8, 28, 10, 0, 0, 0, time.UTC)
+	if !c.History[0].Timestamp.Equal(want) {
+		t.Errorf("Timestamp not parsed: got %v", c.History[0].Timestamp)
+	}
+
+	err = mapping.Dispatch(m, map[string]string{
+		"case": "c1", "activity": "resolve", "ts": "2026-08-28T10:05:00Z",
+	})
+	if err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if _, ok := m.GetCase("c1"); ok {
+		t.Error("End activity should complete the case")
+	}
+	if stats := m.GetStatistics(); stats.CompletedCases != 1 {
+		t.Errorf("Completed cases: %d", stats.CompletedCases)
+	}
+}
+
+func TestDispatchRejectsBadRecords(t *testing.T) {
+	m := newConnectorMonitor()
+	mapping := connectorMapping()
+
+	if err := mapping.Dispatch(m, map[string]string{"activity": "triage"}); err == nil {
+		t.Error("Expected error for missing case field")
+	}
+	if err := mapping.Dispatch(m, map[string]string{"case": "c1"}); err == nil {
+		t.Error("Expected error for missing activity field")
+	}
+	err := mapping.Dispatch(m, map[string]string{
+		"case": "c1", "activity": "triage", "ts": "yesterday",
+	})
+	if err == nil {
+		t.Error("Expected error for unparseable timestamp")
+	}
+}
+
+func TestDispatchStartActivities(t *testing.T) {
+	m := newConnectorMonitor()
+	mapping := connectorMapping()
+	mapping.StartActivities = []string{"triage"}
+
+	err := mapping.Dispatch(m, map[string]string{"case": "c1", "activity": "resolve"})
+	if err == nil {
+		t.Error("Non-start activity must not open a case")
+	}
+	if err := mapping.Dispatch(m, map[string]string{"case": "c1", "activity": "triage"}); err != nil {
+		t.Fatalf("Start activity rejected: %v", err)
+	}
+	if _, ok := m.GetCase("c1"); !ok {
+		t.Error("Case not started by start activity")
+	}
+}
+
+func TestWebhookConnector(t *testing.T) {
+	m := newConnectorMonitor()
+	srv := httptest.NewServer(NewWebhookConnector(m, connectorMapping()))
+	defer srv.Close()
+
+	post := func(body string) int {
+		resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if code := post(`{"case": "w1", "activity": "triage", "agent": "bob"}`); code != http.StatusAccepted {
+		t.Errorf("Single object: status %d", code)
+	}
+	if _, ok := m.GetCase("w1"); !ok {
+		t.Error("Webhook event did not start the case")
+	}
+
+	// A batch whose last record is an end activity completes the case.
+	code := post(`[{"case": "w2", "activity": "triage"}, {"case": "w2", "activity": "resolve"}]`)
+	if code != http.StatusAccepted {
+		t.Errorf("Array body: status %d", code)
+	}
+	if stats := m.GetStatistics(); stats.CompletedCases != 1 {
+		t.Errorf("Completed cases: %d", stats.CompletedCases)
+	}
+
+	if code := post(`not json`); code != http.StatusBadRequest {
+		t.Errorf("Bad body: status %d", code)
+	}
+	if code := post(`{"activity": "triage"}`); code != http.StatusUnprocessableEntity {
+		t.Errorf("Record missing case field: status %d", code)
+	}
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET: status %d", resp.StatusCode)
+	}
+}
+
+func TestFileTailConnectorCSV(t *testing.T) {
+	m := newConnectorMonitor()
+	path := filepath.Join(t.TempDir(), "events.csv")
+	header := "case,activity,ts,agent\n"
+	first := "f1,triage,2026-08-28T10:00:00Z,alice\n"
+	if err := os.WriteFile(path, []byte(header+first), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	connector := NewFileTailConnector(m, connectorMapping(), path, 10*time.Millisecond)
+	done := make(chan error, 1)
+	go func() { done <- connector.Run(context.Background(), nil) }()
+
+	waitFor(t, func() bool { _, ok := m.GetCase("f1"); return ok })
+	if c, ok := m.GetCase("f1"); ok && c.History[0].Resource != "alice" {
+		t.Errorf("Row not mapped through header: %+v", c.History[0])
+	}
+
+	// Appended rows are picked up by the poller.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("f1,resolve,2026-08-28T10:09:00Z,alice\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	waitFor(t, func() bool { return m.GetStatistics().CompletedCases == 1 })
+
+	connector.Stop()
+	if err := <-done; err != nil {
+		t.Errorf("Run returned %v after Stop", err)
+	}
+}
+
+func TestFileTailConnectorJSONL(t *testing.T) {
+	m := newConnectorMonitor()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	lines := `{"case": "j1", "activity": "triage"}
+garbage line
+{"case": "j1", "activity": "resolve"}
+`
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var tailErrs []error
+	connector := NewFileTailConnector(m, connectorMapping(), path, 10*time.Millisecond)
+	done := make(chan error, 1)
+	go func() {
+		done <- connector.Run(context.Background(), func(err error) { tailErrs = append(tailErrs, err) })
+	}()
+
+	waitFor(t, func() bool { return m.GetStatistics().CompletedCases == 1 })
+	connector.Stop()
+	if err := <-done; err != nil {
+		t.Errorf("Run returned %v after Stop", err)
+	}
+	if len(tailErrs) != 1 {
+		t.Errorf("Expected one bad-row error, got %v", tailErrs)
+	}
+}
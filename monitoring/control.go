@@ -0,0 +1,203 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ParameterChange is one entry in a controller's audit trail.
+type ParameterChange struct {
+	Timestamp time.Time `json:"timestamp"`
+	Name      string    `json:"name"`
+	Old       float64   `json:"old"`
+	New       float64   `json:"new"`
+	Actor     string    `json:"actor,omitempty"`
+}
+
+// ParameterInfo describes one tunable parameter.
+type ParameterInfo struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Value       float64 `json:"value"`
+}
+
+// parameter binds a name to live getter/setter callbacks.
+type parameter struct {
+	description string
+	get         func() float64
+	set         func(float64)
+}
+
+// Controller exposes named runtime parameters for live tuning, with an
+// audit trail of every change. Long-running simulators register their
+// rates and speeds, then serve Handler (or call Set from their own input
+// loop) instead of hard-wiring keyboard controls.
+type Controller struct {
+	mu     sync.Mutex
+	params map[string]parameter
+	audit  []ParameterChange
+}
+
+// NewController creates an empty controller.
+func NewController() *Controller {
+	return &Controller{params: make(map[string]parameter)}
+}
+
+// Register adds a parameter with explicit accessors; set runs under the
+// controller lock, so keep it quick.
+func (c *Controller) Register(name, description string, get func() float64, set func(float64)) *Controller {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.params[name] = parameter{description: description, get: get, set: set}
+	return c
+}
+
+// RegisterVar registers a plain variable as a parameter.
+func (c *Controller) RegisterVar(name, description string, v *float64) *Controller {
+	return c.Register(name, description,
+		func() float64 { return *v },
+		func(value float64) { *v = value })
+}
+
+// Set updates a parameter and records the change in the audit trail.
+func (c *Controller) Set(name string, value float64, actor string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	param, ok := c.params[name]
+	if !ok {
+		return fmt.Errorf("unknown parameter: %s", name)
+	}
+	old := param.get()
+	param.set(value)
+	c.audit = append(c.audit, ParameterChange{
+		Timestamp: time.Now(),
+		Name:      name,
+		Old:       old,
+		New:       value,
+		Actor:     actor,
+	})
+	return nil
+}
+
+// Get reads a parameter's current value.
+func (c *Controller) Get(name string) (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	param, ok := c.params[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown parameter: %s", name)
+	}
+	return param.get(), nil
+}
+
+// List returns all parameters with current values, sorted by name.
+func (c *Controller) List() []ParameterInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	infos := make([]ParameterInfo, 0, len(c.params))
+	for name, param := range c.params {
+		infos = append(infos, ParameterInfo{Name: name, Description: param.description, Value: param.get()})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// AuditTrail returns a copy of all recorded changes, oldest first.
+func (c *Controller) AuditTrail() []ParameterChange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ParameterChange(nil), c.audit...)
+}
+
+// Handler serves the control API:
+//
+//	GET /params        — list parameters and current values
+//	GET /params/{name} — read one parameter
+//	PUT /params/{name} — set one parameter: {"value": 1.5, "actor": "ops"}
+//	GET /audit         — full audit trail
+func (c *Controller) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/params", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(c.List())
+	})
+	mux.HandleFunc("/params/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/params/")
+		switch r.Method {
+		case http.MethodGet:
+			value, err := c.Get(name)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(ParameterInfo{Name: name, Value: value})
+		case http.MethodPut:
+			var body struct {
+				Value float64 `json:"value"`
+				Actor string  `json:"actor"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, fmt.Sprintf("bad request: %v", err), http.StatusBadRequest)
+				return
+			}
+			if err := c.Set(name, body.Value, body.Actor); err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/audit", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(c.AuditTrail())
+	})
+	return mux
+}
+
+// ControlAPI builds a controller pre-wired to the monitor's tunable state:
+// every transition rate (rate:<label>) plus the SLA and stuck thresholds
+// in seconds. Rate changes take effect on the next prediction.
+func (m *Monitor) ControlAPI() *Controller {
+	c := NewController()
+
+	m.mu.RLock()
+	labels := make([]string, 0, len(m.rates))
+	for label := range m.rates {
+		labels = append(labels, label)
+	}
+	m.mu.RUnlock()
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		label := label
+		c.Register("rate:"+label, "firing rate of "+label,
+			func() float64 {
+				m.mu.RLock()
+				defer m.mu.RUnlock()
+				return m.rates[label]
+			},
+			func(value float64) {
+				m.mu.Lock()
+				defer m.mu.Unlock()
+				m.rates[label] = value
+			})
+	}
+
+	c.Register("sla_threshold_seconds", "SLA deadline in seconds",
+		func() float64 { return m.config.SLAThreshold.Seconds() },
+		func(value float64) { m.config.SLAThreshold = time.Duration(value * float64(time.Second)) })
+	c.Register("stuck_threshold_seconds", "inactivity threshold in seconds",
+		func() float64 { return m.config.StuckThreshold.Seconds() },
+		func(value float64) { m.config.StuckThreshold = time.Duration(value * float64(time.Second)) })
+	return c
+}
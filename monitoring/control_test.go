@@ -0,0 +1,110 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestControllerSetGetAudit(t *testing.T) {
+	speed := 1.0
+	c := NewController().RegisterVar("speed", "simulation speed", &speed)
+
+	if err := c.Set("speed", 4.0, "ops"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if speed != 4.0 {
+		t.Errorf("Variable not updated: %f", speed)
+	}
+	if err := c.Set("nope", 1, ""); err == nil {
+		t.Error("Expected error for unknown parameter")
+	}
+
+	audit := c.AuditTrail()
+	if len(audit) != 1 {
+		t.Fatalf("Expected 1 audit entry, got %d", len(audit))
+	}
+	if audit[0].Old != 1.0 || audit[0].New != 4.0 || audit[0].Actor != "ops" {
+		t.Errorf("Bad audit entry: %+v", audit[0])
+	}
+}
+
+func TestControlHTTPEndpoints(t *testing.T) {
+	rate := 0.5
+	c := NewController().RegisterVar("rate:work", "work rate", &rate)
+	server := httptest.NewServer(c.Handler())
+	defer server.Close()
+
+	// List.
+	resp, err := http.Get(server.URL + "/params")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var infos []ParameterInfo
+	json.NewDecoder(resp.Body).Decode(&infos)
+	resp.Body.Close()
+	if len(infos) != 1 || infos[0].Name != "rate:work" || infos[0].Value != 0.5 {
+		t.Errorf("Unexpected list: %+v", infos)
+	}
+
+	// Update.
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/params/rate:work",
+		strings.NewReader(`{"value": 2.0, "actor": "dashboard"}`))
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("PUT returned %s", resp.Status)
+	}
+	if rate != 2.0 {
+		t.Errorf("Rate not updated: %f", rate)
+	}
+
+	// Unknown parameter 404s.
+	req, _ = http.NewRequest(http.MethodPut, server.URL+"/params/ghost", strings.NewReader(`{"value":1}`))
+	resp, _ = http.DefaultClient.Do(req)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for unknown parameter, got %s", resp.Status)
+	}
+
+	// Audit.
+	resp, err = http.Get(server.URL + "/audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var audit []ParameterChange
+	json.NewDecoder(resp.Body).Decode(&audit)
+	resp.Body.Close()
+	if len(audit) != 1 || audit[0].Actor != "dashboard" {
+		t.Errorf("Unexpected audit: %+v", audit)
+	}
+}
+
+func TestMonitorControlAPI(t *testing.T) {
+	rates := map[string]float64{"begin": 0.1, "finish": 0.2}
+	m := NewMonitor(chainNet(), rates, DefaultMonitorConfig())
+
+	c := m.ControlAPI()
+	if err := c.Set("rate:begin", 0.9, "test"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if rates["begin"] != 0.9 {
+		t.Errorf("Monitor rate not updated: %v", rates)
+	}
+	// The predictor shares the rates map, so tuning reaches simulations.
+	if m.predictor.rates["begin"] != 0.9 {
+		t.Errorf("Predictor rate not updated: %v", m.predictor.rates)
+	}
+
+	if err := c.Set("sla_threshold_seconds", 60, "test"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if m.config.SLAThreshold.Seconds() != 60 {
+		t.Errorf("SLA threshold not updated: %v", m.config.SLAThreshold)
+	}
+}
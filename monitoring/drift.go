@@ -0,0 +1,263 @@
+package monitoring
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/mining"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// DriftConfig tunes concept drift detection.
+type DriftConfig struct {
+	// WindowSize is the number of recent events compared against the
+	// baseline (default 200).
+	WindowSize int
+
+	// Threshold is the KL divergence above which drift is reported
+	// (default 0.25). Higher values tolerate more deviation.
+	Threshold float64
+
+	// CheckInterval is the number of events between checks once the
+	// window is full (default WindowSize/4), so a full recompute does not
+	// run on every event.
+	CheckInterval int
+
+	// Rediscover runs model discovery on the drifted window and attaches
+	// the new model to the report.
+	Rediscover bool
+
+	// DiscoveryMethod for rediscovery (default "heuristic").
+	DiscoveryMethod string
+
+	// OnDrift is called with the report whenever drift is detected.
+	OnDrift func(DriftReport)
+}
+
+// DefaultDriftConfig returns sensible drift detection defaults.
+func DefaultDriftConfig() DriftConfig {
+	return DriftConfig{
+		WindowSize: 200,
+		Threshold:  0.25,
+	}
+}
+
+// ActivityDrift quantifies how far one activity's observed frequency moved
+// from the baseline.
+type ActivityDrift struct {
+	Activity string
+	Expected float64 // baseline relative frequency
+	Observed float64 // recent-window relative frequency
+}
+
+// DriftReport describes one detected concept drift.
+type DriftReport struct {
+	DetectedAt time.Time
+	Divergence float64 // KL divergence of window from baseline
+
+	// DivergingActivities lists activities by descending frequency shift.
+	DivergingActivities []ActivityDrift
+
+	// Window is the recent events as an event log, ready for rediscovery
+	// or inspection.
+	Window *eventlog.EventLog
+
+	// NewNet is the rediscovered model (only when Rediscover is set).
+	NewNet *petri.PetriNet
+}
+
+// DriftDetector watches the live event stream for divergence between
+// observed activity frequencies and a baseline learned from the training
+// log. Attach it with Monitor.WithDriftDetection; every RecordEvent then
+// feeds it automatically.
+type DriftDetector struct {
+	monitor  *Monitor
+	config   DriftConfig
+	baseline map[string]float64 // activity -> relative frequency
+
+	window      []eventlog.Event
+	sinceCheck  int
+	lastReport  *DriftReport
+	reportCount int
+}
+
+// WithDriftDetection enables drift detection against the activity
+// distribution of the baseline log (typically the log the model was
+// discovered from). Detected drift raises an AlertTypeConceptDrift alert
+// naming the diverging activities.
+func (m *Monitor) WithDriftDetection(baseline *eventlog.EventLog, config DriftConfig) *Monitor {
+	if config.WindowSize <= 0 {
+		config.WindowSize = 200
+	}
+	if config.Threshold <= 0 {
+		config.Threshold = 0.25
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = config.WindowSize / 4
+		if config.CheckInterval < 1 {
+			config.CheckInterval = 1
+		}
+	}
+	if config.DiscoveryMethod == "" {
+		config.DiscoveryMethod = "heuristic"
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.drift = &DriftDetector{
+		monitor:  m,
+		config:   config,
+		baseline: activityFrequencies(baseline),
+	}
+	return m
+}
+
+// LastDriftReport returns the most recent drift report, or nil when no
+// drift has been detected.
+func (m *Monitor) LastDriftReport() *DriftReport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.drift == nil {
+		return nil
+	}
+	return m.drift.lastReport
+}
+
+// observe feeds one live event into the sliding window; callers hold m.mu.
+func (d *DriftDetector) observe(event Event) {
+	d.window = append(d.window, eventlog.Event{
+		CaseID:    event.CaseID,
+		Activity:  event.Activity,
+		Timestamp: event.Timestamp,
+		Resource:  event.Resource,
+	})
+	if len(d.window) > d.config.WindowSize {
+		d.window = d.window[len(d.window)-d.config.WindowSize:]
+	}
+	if len(d.window) < d.config.WindowSize {
+		return
+	}
+
+	d.sinceCheck++
+	if d.sinceCheck < d.config.CheckInterval {
+		return
+	}
+	d.sinceCheck = 0
+	d.check()
+}
+
+// check compares the window distribution against the baseline and reports
+// drift when divergence crosses the threshold.
+func (d *DriftDetector) check() {
+	observed := make(map[string]float64)
+	for _, event := range d.window {
+		observed[event.Activity]++
+	}
+	total := float64(len(d.window))
+	for activity := range observed {
+		observed[activity] /= total
+	}
+
+	divergence, shifts := klDivergence(observed, d.baseline)
+	if divergence < d.config.Threshold {
+		return
+	}
+
+	report := DriftReport{
+		DetectedAt:          time.Now(),
+		Divergence:          divergence,
+		DivergingActivities: shifts,
+		Window:              windowLog(d.window),
+	}
+	if d.config.Rediscover {
+		if result, err := mining.Discover(report.Window, d.config.DiscoveryMethod); err == nil {
+			report.NewNet = result.Net
+		}
+	}
+	d.lastReport = &report
+	d.reportCount++
+
+	// Restart the window so one sustained shift yields one alert, not a
+	// storm on every subsequent check.
+	d.window = nil
+
+	var names []string
+	for i, shift := range shifts {
+		if i == 3 {
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (%.0f%%→%.0f%%)",
+			shift.Activity, shift.Expected*100, shift.Observed*100))
+	}
+	d.monitor.triggerAlert(Alert{
+		Timestamp: report.DetectedAt,
+		Type:      AlertTypeConceptDrift,
+		Severity:  SeverityWarning,
+		Message: fmt.Sprintf("Live behavior diverged from model (KL=%.2f); diverging: %s",
+			divergence, strings.Join(names, ", ")),
+	})
+	if d.config.OnDrift != nil {
+		go d.config.OnDrift(report)
+	}
+}
+
+// activityFrequencies computes the relative activity frequency of a log.
+func activityFrequencies(log *eventlog.EventLog) map[string]float64 {
+	counts := make(map[string]float64)
+	total := 0.0
+	for _, trace := range log.Cases {
+		for _, event := range trace.Events {
+			counts[event.Activity]++
+			total++
+		}
+	}
+	if total > 0 {
+		for activity := range counts {
+			counts[activity] /= total
+		}
+	}
+	return counts
+}
+
+// klDivergence computes the smoothed KL divergence of observed from
+// baseline, plus the per-activity shifts sorted by magnitude.
+func klDivergence(observed, baseline map[string]float64) (float64, []ActivityDrift) {
+	const epsilon = 1e-4 // smoothing for unseen activities
+
+	activities := make(map[string]bool)
+	for a := range observed {
+		activities[a] = true
+	}
+	for a := range baseline {
+		activities[a] = true
+	}
+
+	divergence := 0.0
+	shifts := make([]ActivityDrift, 0, len(activities))
+	for activity := range activities {
+		p := observed[activity]
+		q := baseline[activity]
+		if p > 0 {
+			divergence += p * math.Log(p/math.Max(q, epsilon))
+		}
+		shifts = append(shifts, ActivityDrift{Activity: activity, Expected: q, Observed: p})
+	}
+	sort.Slice(shifts, func(i, j int) bool {
+		return math.Abs(shifts[i].Observed-shifts[i].Expected) > math.Abs(shifts[j].Observed-shifts[j].Expected)
+	})
+	return divergence, shifts
+}
+
+// windowLog converts the sliding window into an event log.
+func windowLog(events []eventlog.Event) *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	for _, event := range events {
+		log.AddEvent(event)
+	}
+	log.SortTraces()
+	return log
+}
@@ -0,0 +1,106 @@
+package monitoring
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// baselineLog produces a log where cases follow a -> b -> c.
+func baselineLog(cases int) *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < cases; i++ {
+		for j, activity := range []string{"a", "b", "c"} {
+			log.AddEvent(eventlog.Event{
+				CaseID:    fmt.Sprintf("c%d", i),
+				Activity:  activity,
+				Timestamp: base.Add(time.Duration(i*30+j) * time.Minute),
+			})
+		}
+	}
+	return log
+}
+
+func TestDriftDetectionFiresOnShiftedBehavior(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.EnablePredictions = false
+
+	var mu sync.Mutex
+	var alerts []Alert
+	m := NewMonitor(newTestNet(), nil, config)
+	m.AddAlertHandler(func(a Alert) {
+		mu.Lock()
+		alerts = append(alerts, a)
+		mu.Unlock()
+	})
+
+	driftConfig := DefaultDriftConfig()
+	driftConfig.WindowSize = 30
+	driftConfig.Rediscover = true
+	m.WithDriftDetection(baselineLog(50), driftConfig)
+
+	// Live behavior: cases now loop through a rework activity instead.
+	now := time.Now()
+	for i := 0; i < 15; i++ {
+		caseID := fmt.Sprintf("live-%d", i)
+		m.StartCase(caseID, now)
+		for j, activity := range []string{"a", "rework", "rework", "rework"} {
+			m.RecordEvent(caseID, activity, now.Add(time.Duration(j)*time.Minute), "")
+		}
+	}
+
+	report := m.LastDriftReport()
+	if report == nil {
+		t.Fatal("Expected drift to be detected")
+	}
+	if report.Divergence <= 0 {
+		t.Errorf("Non-positive divergence: %f", report.Divergence)
+	}
+	if len(report.DivergingActivities) == 0 || report.DivergingActivities[0].Activity != "rework" {
+		t.Errorf("Expected rework as top diverging activity: %+v", report.DivergingActivities)
+	}
+	if report.Window == nil || len(report.Window.Cases) == 0 {
+		t.Error("Report missing window log")
+	}
+	if report.NewNet == nil {
+		t.Error("Rediscovery did not attach a new model")
+	}
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, a := range alerts {
+			if a.Type == AlertTypeConceptDrift {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func TestNoDriftOnConformingBehavior(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.EnablePredictions = false
+
+	m := NewMonitor(newTestNet(), nil, config)
+	driftConfig := DefaultDriftConfig()
+	driftConfig.WindowSize = 30
+	m.WithDriftDetection(baselineLog(50), driftConfig)
+
+	now := time.Now()
+	for i := 0; i < 20; i++ {
+		caseID := fmt.Sprintf("live-%d", i)
+		m.StartCase(caseID, now)
+		for j, activity := range []string{"a", "b", "c"} {
+			m.RecordEvent(caseID, activity, now.Add(time.Duration(j)*time.Minute), "")
+		}
+	}
+
+	if report := m.LastDriftReport(); report != nil {
+		t.Errorf("Unexpected drift report: KL=%f", report.Divergence)
+	}
+}
@@ -0,0 +1,150 @@
+package monitoring
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Risk explanations.
+//
+// A risk score alone tells a responder that a case is in trouble, not
+// what to do about it. The explanation payload breaks the score down:
+// which past activities ran longer than their learned means, and which
+// remaining steps dominate the predicted completion time — measured by
+// re-simulating with each step made instant and seeing how much the
+// prediction improves.
+
+// ActivityDelay compares an observed activity duration with the mean
+// implied by its learned rate.
+type ActivityDelay struct {
+	Activity string
+	Observed time.Duration
+	Expected time.Duration // 1/rate, the learned mean service time
+	Excess   time.Duration // Observed - Expected
+}
+
+// PathContribution quantifies how much one remaining transition drives
+// the predicted completion time.
+type PathContribution struct {
+	Transition string
+	Expected   time.Duration // learned mean service time for the step
+	// MarginalEffect is how much sooner the case would complete if this
+	// step were instant; Share is that as a fraction of the remaining
+	// time prediction.
+	MarginalEffect time.Duration
+	Share          float64
+}
+
+// RiskExplanation explains a prediction's risk score.
+type RiskExplanation struct {
+	Delays        []ActivityDelay    // past activities slower than learned, worst first
+	RemainingPath []PathContribution // remaining steps by marginal effect, largest first
+}
+
+// String renders a short, actionable summary.
+func (e *RiskExplanation) String() string {
+	s := ""
+	if len(e.Delays) > 0 {
+		d := e.Delays[0]
+		s += fmt.Sprintf("slowest so far: %s (%s over the %s mean)",
+			d.Activity, d.Excess.Round(time.Second), d.Expected.Round(time.Second))
+	}
+	if len(e.RemainingPath) > 0 {
+		if s != "" {
+			s += "; "
+		}
+		p := e.RemainingPath[0]
+		s += fmt.Sprintf("remaining time dominated by %s (%.0f%%)", p.Transition, p.Share*100)
+	}
+	if s == "" {
+		s = "no dominant factor"
+	}
+	return s
+}
+
+// ExplainRisk computes the explanation for a case on demand.
+func (m *Monitor) ExplainRisk(caseID string) (*RiskExplanation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, exists := m.cases[caseID]
+	if !exists {
+		return nil, fmt.Errorf("case %s not found", caseID)
+	}
+	return m.explainRisk(c, m.modelFor(c)), nil
+}
+
+// explainRisk builds the explanation payload from the case history and
+// marginal re-simulations against its model.
+func (m *Monitor) explainRisk(c *Case, model *caseModel) *RiskExplanation {
+	ex := &RiskExplanation{}
+
+	// Past activities slower than their learned means. An event's
+	// timestamp marks the activity's completion, so its duration runs
+	// from the previous event (or the case start).
+	prev := c.StartTime
+	for _, ev := range c.History {
+		observed := ev.Timestamp.Sub(prev)
+		prev = ev.Timestamp
+		rate, ok := model.rates[ev.Activity]
+		if !ok || rate <= 0 {
+			continue
+		}
+		expected := time.Duration(float64(time.Second) / rate)
+		if observed > expected {
+			ex.Delays = append(ex.Delays, ActivityDelay{
+				Activity: ev.Activity,
+				Observed: observed,
+				Expected: expected,
+				Excess:   observed - expected,
+			})
+		}
+	}
+	sort.Slice(ex.Delays, func(i, j int) bool {
+		return ex.Delays[i].Excess > ex.Delays[j].Excess
+	})
+
+	// Marginal effect of each remaining step: make it instant and
+	// re-simulate. Steps whose speedup barely moves the prediction are
+	// not the bottleneck.
+	state := EstimateCurrentState(c, model.net)
+	currentTime := time.Since(c.StartTime).Seconds()
+	base := model.predictor.PredictFromState(state, currentTime)
+	baseRemaining := base.PredictedEndTime - base.CurrentTime
+	if baseRemaining <= 0 {
+		return ex
+	}
+
+	names := make([]string, 0, len(model.net.Transitions))
+	for name := range model.net.Transitions {
+		if model.rates[name] > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		boosted := make(map[string]float64, len(model.rates))
+		for k, v := range model.rates {
+			boosted[k] = v
+		}
+		boosted[name] *= 1000 // effectively instant
+		alt := NewPredictor(model.net, boosted).PredictFromState(state, currentTime)
+		marginal := baseRemaining - (alt.PredictedEndTime - alt.CurrentTime)
+		if marginal < 1 { // under a second: not a bottleneck
+			continue
+		}
+		ex.RemainingPath = append(ex.RemainingPath, PathContribution{
+			Transition:     name,
+			Expected:       time.Duration(float64(time.Second) / model.rates[name]),
+			MarginalEffect: time.Duration(marginal * float64(time.Second)),
+			Share:          marginal / baseRemaining,
+		})
+	}
+	sort.Slice(ex.RemainingPath, func(i, j int) bool {
+		return ex.RemainingPath[i].MarginalEffect > ex.RemainingPath[j].MarginalEffect
+	})
+
+	return ex
+}
@@ -0,0 +1,90 @@
+package monitoring
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExplainRiskDelaysAndPath(t *testing.T) {
+	m := typedTestMonitor() // begin and finish both learned at ~1/min
+
+	start := time.Now().Add(-15 * time.Minute)
+	m.StartCase("c1", start)
+	// begin took 10 minutes against a 1 minute learned mean.
+	if err := m.RecordEvent("c1", "begin", start.Add(10*time.Minute), ""); err != nil {
+		t.Fatalf("RecordEvent failed: %v", err)
+	}
+
+	ex, err := m.ExplainRisk("c1")
+	if err != nil {
+		t.Fatalf("ExplainRisk failed: %v", err)
+	}
+
+	if len(ex.Delays) != 1 {
+		t.Fatalf("Expected one delayed activity, got %v", ex.Delays)
+	}
+	d := ex.Delays[0]
+	if d.Activity != "begin" {
+		t.Errorf("Expected begin to be the delayed activity, got %s", d.Activity)
+	}
+	if d.Excess < 8*time.Minute || d.Excess > 10*time.Minute {
+		t.Errorf("Expected ~9m excess, got %v", d.Excess)
+	}
+
+	// begin already fired, so finish dominates the remaining time.
+	if len(ex.RemainingPath) == 0 {
+		t.Fatal("Expected remaining path contributions")
+	}
+	top := ex.RemainingPath[0]
+	if top.Transition != "finish" {
+		t.Errorf("Expected finish to dominate, got %s", top.Transition)
+	}
+	if top.Share < 0.5 {
+		t.Errorf("Expected finish to carry most of the remaining time, got %f", top.Share)
+	}
+
+	s := ex.String()
+	if !strings.Contains(s, "begin") || !strings.Contains(s, "finish") {
+		t.Errorf("Summary should name the delay and the bottleneck: %q", s)
+	}
+}
+
+func TestExplanationAttachedToPrediction(t *testing.T) {
+	m := typedTestMonitor()
+	m.config.EnableExplanations = true
+
+	m.StartCase("c1", time.Now())
+	pred, err := m.PredictCompletion("c1")
+	if err != nil {
+		t.Fatalf("PredictCompletion failed: %v", err)
+	}
+	if pred.Explanation == nil {
+		t.Fatal("Expected an explanation on the prediction")
+	}
+	// Nothing has happened yet: no delays, but a remaining path exists.
+	if len(pred.Explanation.Delays) != 0 {
+		t.Errorf("Expected no delays on a fresh case, got %v", pred.Explanation.Delays)
+	}
+	if len(pred.Explanation.RemainingPath) == 0 {
+		t.Error("Expected remaining path contributions on a fresh case")
+	}
+
+	// Off by default.
+	off := typedTestMonitor()
+	off.StartCase("c1", time.Now())
+	pred, err = off.PredictCompletion("c1")
+	if err != nil {
+		t.Fatalf("PredictCompletion failed: %v", err)
+	}
+	if pred.Explanation != nil {
+		t.Error("Expected no explanation when disabled")
+	}
+}
+
+func TestExplainRiskUnknownCase(t *testing.T) {
+	m := typedTestMonitor()
+	if _, err := m.ExplainRisk("missing"); err == nil {
+		t.Error("Expected error for unknown case")
+	}
+}
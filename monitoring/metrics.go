@@ -0,0 +1,131 @@
+package monitoring
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MetricsHandler exposes monitor statistics in the Prometheus text
+// exposition format so dashboards can be built in Grafana instead of
+// terminal ASCII. Mount it on any mux:
+//
+//	http.Handle("/metrics", monitor.MetricsHandler())
+//
+// The exposition is written by hand rather than via the Prometheus client
+// library; the format is stable and this keeps the module dependency-free
+// for users who don't scrape.
+func (m *Monitor) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprint(w, m.renderMetrics())
+	})
+}
+
+// renderMetrics builds the full exposition. Label values are sorted so
+// output is deterministic and diff-friendly.
+func (m *Monitor) renderMetrics() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var b strings.Builder
+
+	writeMetric(&b, "pflow_monitor_cases_total", "counter",
+		"Total cases started since monitor creation.",
+		fmt.Sprintf("pflow_monitor_cases_total %d\n", m.stats.TotalCases))
+	writeMetric(&b, "pflow_monitor_cases_completed_total", "counter",
+		"Total cases completed since monitor creation.",
+		fmt.Sprintf("pflow_monitor_cases_completed_total %d\n", m.stats.CompletedCases))
+	writeMetric(&b, "pflow_monitor_cases_active", "gauge",
+		"Cases currently being tracked.",
+		fmt.Sprintf("pflow_monitor_cases_active %d\n", len(m.cases)))
+
+	writeMetric(&b, "pflow_monitor_alerts_total", "counter",
+		"Total alerts triggered.",
+		fmt.Sprintf("pflow_monitor_alerts_total %d\n", m.stats.TotalAlerts))
+
+	var byType strings.Builder
+	for _, alertType := range sortedAlertTypes(m.stats.AlertsByType) {
+		fmt.Fprintf(&byType, "pflow_monitor_alerts_by_type_total{type=%q} %d\n",
+			string(alertType), m.stats.AlertsByType[alertType])
+	}
+	if byType.Len() > 0 {
+		writeMetric(&b, "pflow_monitor_alerts_by_type_total", "counter",
+			"Alerts triggered, by alert type.", byType.String())
+	}
+
+	var bySeverity strings.Builder
+	for _, severity := range sortedSeverities(m.stats.AlertsBySeverity) {
+		fmt.Fprintf(&bySeverity, "pflow_monitor_alerts_by_severity_total{severity=%q} %d\n",
+			string(severity), m.stats.AlertsBySeverity[severity])
+	}
+	if bySeverity.Len() > 0 {
+		writeMetric(&b, "pflow_monitor_alerts_by_severity_total", "counter",
+			"Alerts triggered, by severity.", bySeverity.String())
+	}
+
+	// Predicted SLA violations: active cases whose latest prediction puts
+	// them at high risk of missing the SLA threshold.
+	atRisk := 0
+	for _, c := range m.cases {
+		if c.Predictions != nil && c.Predictions.RiskScore >= 0.8 {
+			atRisk++
+		}
+	}
+	writeMetric(&b, "pflow_monitor_predicted_sla_violations", "gauge",
+		"Active cases whose predicted completion puts them at high SLA risk.",
+		fmt.Sprintf("pflow_monitor_predicted_sla_violations %d\n", atRisk))
+
+	var throughput strings.Builder
+	for _, activity := range sortedStringKeys(m.activityCounts) {
+		fmt.Fprintf(&throughput, "pflow_monitor_activity_events_total{activity=%q} %d\n",
+			activity, m.activityCounts[activity])
+	}
+	if throughput.Len() > 0 {
+		writeMetric(&b, "pflow_monitor_activity_events_total", "counter",
+			"Events recorded, by activity. The rate() of this series is per-transition throughput.",
+			throughput.String())
+	}
+
+	if m.stats.PredictionAccuracy > 0 {
+		writeMetric(&b, "pflow_monitor_prediction_accuracy", "gauge",
+			"Fraction of completion predictions within threshold.",
+			fmt.Sprintf("pflow_monitor_prediction_accuracy %g\n", m.stats.PredictionAccuracy))
+	}
+
+	return b.String()
+}
+
+// writeMetric emits the HELP/TYPE preamble followed by the sample lines.
+func writeMetric(b *strings.Builder, name, metricType, help, samples string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+	b.WriteString(samples)
+}
+
+func sortedAlertTypes(m map[AlertType]int) []AlertType {
+	keys := make([]AlertType, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func sortedSeverities(m map[AlertSeverity]int) []AlertSeverity {
+	keys := make([]AlertSeverity, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func sortedStringKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,47 @@
+package monitoring
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.EnablePredictions = false
+	config.EnableAlerts = false
+
+	m := NewMonitor(newTestNet(), nil, config)
+	start := time.Now().Add(-time.Hour)
+	m.StartCase("c1", start)
+	m.RecordEvent("c1", "triage", start.Add(time.Minute), "alice")
+	m.RecordEvent("c1", "triage", start.Add(2*time.Minute), "bob")
+	m.StartCase("c2", start)
+	m.CompleteCase("c2", start.Add(30*time.Minute))
+	m.triggerAlert(Alert{Type: AlertTypeStuck, Severity: SeverityWarning})
+
+	rec := httptest.NewRecorder()
+	m.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain") {
+		t.Errorf("Unexpected content type: %s", got)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"pflow_monitor_cases_total 2",
+		"pflow_monitor_cases_completed_total 1",
+		"pflow_monitor_cases_active 1",
+		"pflow_monitor_alerts_total 1",
+		`pflow_monitor_alerts_by_type_total{type="stuck"} 1`,
+		`pflow_monitor_alerts_by_severity_total{severity="warning"} 1`,
+		`pflow_monitor_activity_events_total{activity="triage"} 2`,
+		"pflow_monitor_predicted_sla_violations 0",
+		"# TYPE pflow_monitor_cases_active gauge",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Metrics output missing %q:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package monitoring
+
+import (
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Multi-model monitoring.
+//
+// One monitor often tracks cases that follow different processes: a P0
+// incident escalates through a different net than a routine P3 ticket.
+// Registering a model per case type routes each case's state estimation
+// and predictions to its own net and rates while alerting, handlers, and
+// statistics stay shared across the monitor.
+
+// caseModel bundles the net, rates, and predictor backing one case type.
+type caseModel struct {
+	net       *petri.PetriNet
+	rates     map[string]float64
+	predictor *Predictor
+}
+
+// WithModel registers a model for a case type. Cases started with
+// StartTypedCase using this type are estimated and predicted against it;
+// untyped cases (and unregistered types) keep the monitor's base model.
+func (m *Monitor) WithModel(caseType string, net *petri.PetriNet, rates map[string]float64) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.models == nil {
+		m.models = make(map[string]*caseModel)
+	}
+	m.models[caseType] = &caseModel{
+		net:       net,
+		rates:     rates,
+		predictor: NewPredictor(net, rates),
+	}
+	return m
+}
+
+// StartTypedCase begins monitoring a case routed to the model registered
+// for its type, at medium priority. An empty type or an unregistered one
+// falls back to the monitor's base model.
+func (m *Monitor) StartTypedCase(caseID, caseType string, startTime time.Time) error {
+	return m.StartCaseWithPriority(caseID, caseType, PriorityMedium, startTime)
+}
+
+// GetCasesByType returns the active cases of one type.
+func (m *Monitor) GetCasesByType(caseType string) []*Case {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var cases []*Case
+	for _, c := range m.cases {
+		if c.Type == caseType {
+			cases = append(cases, c)
+		}
+	}
+	return cases
+}
+
+// modelForType resolves a case type to its registered model, falling
+// back to the monitor's base model. Models are registered at setup time
+// (before cases start), so reads here are unsynchronized.
+func (m *Monitor) modelForType(caseType string) *caseModel {
+	if caseType != "" {
+		if cm, ok := m.models[caseType]; ok {
+			return cm
+		}
+	}
+	return &caseModel{net: m.net, rates: m.rates, predictor: m.predictor}
+}
+
+// modelFor resolves the model backing a case.
+func (m *Monitor) modelFor(c *Case) *caseModel {
+	return m.modelForType(c.Type)
+}
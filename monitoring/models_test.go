@@ -0,0 +1,107 @@
+package monitoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func typedTestMonitor() *Monitor {
+	base := petri.Build().
+		Chain(1, "start", "begin", "working", "finish", "end").
+		Done()
+	config := DefaultMonitorConfig()
+	config.EnableAlerts = false
+	return NewMonitor(base, map[string]float64{"begin": 1.0 / 60, "finish": 1.0 / 60}, config)
+}
+
+func TestStartTypedCaseRoutesToModel(t *testing.T) {
+	m := typedTestMonitor()
+
+	p0 := petri.Build().
+		Chain(1, "start", "triage", "fixing", "resolve", "end").
+		Done()
+	m.WithModel("P0", p0, map[string]float64{"triage": 1.0, "resolve": 1.0})
+
+	if err := m.StartTypedCase("inc-1", "P0", time.Now()); err != nil {
+		t.Fatalf("StartTypedCase failed: %v", err)
+	}
+	if err := m.StartCase("inc-2", time.Now()); err != nil {
+		t.Fatalf("StartCase failed: %v", err)
+	}
+
+	c, _ := m.GetCase("inc-1")
+	if c.Type != "P0" {
+		t.Errorf("Expected case type P0, got %q", c.Type)
+	}
+	if c.State["start"] != 1 {
+		t.Errorf("Expected initial state from the P0 net, got %v", c.State)
+	}
+
+	// The P0 model resolves ~60x faster, so its prediction should too.
+	fast, err := m.PredictCompletion("inc-1")
+	if err != nil {
+		t.Fatalf("PredictCompletion failed: %v", err)
+	}
+	slow, err := m.PredictCompletion("inc-2")
+	if err != nil {
+		t.Fatalf("PredictCompletion failed: %v", err)
+	}
+	if fast.RemainingTime >= slow.RemainingTime {
+		t.Errorf("Expected P0 model to predict faster completion: %v >= %v",
+			fast.RemainingTime, slow.RemainingTime)
+	}
+}
+
+func TestUnregisteredTypeFallsBackToBaseModel(t *testing.T) {
+	m := typedTestMonitor()
+
+	if err := m.StartTypedCase("inc-1", "P3", time.Now()); err != nil {
+		t.Fatalf("StartTypedCase failed: %v", err)
+	}
+	c, _ := m.GetCase("inc-1")
+	if c.State["start"] != 1 {
+		t.Errorf("Expected base model state, got %v", c.State)
+	}
+	if _, err := m.PredictCompletion("inc-1"); err != nil {
+		t.Fatalf("PredictCompletion failed: %v", err)
+	}
+}
+
+func TestStatisticsByType(t *testing.T) {
+	m := typedTestMonitor()
+	m.WithModel("P0", petri.Build().Chain(1, "start", "t", "end").Done(), map[string]float64{"t": 1})
+
+	now := time.Now()
+	m.StartTypedCase("a", "P0", now)
+	m.StartTypedCase("b", "P0", now)
+	m.StartTypedCase("c", "P3", now)
+	m.StartCase("d", now)
+
+	stats := m.GetStatistics()
+	if stats.TotalCases != 4 {
+		t.Errorf("Expected 4 total cases, got %d", stats.TotalCases)
+	}
+	if stats.CasesByType["P0"] != 2 || stats.CasesByType["P3"] != 1 {
+		t.Errorf("Unexpected CasesByType: %v", stats.CasesByType)
+	}
+	if stats.ActiveCasesByType["P0"] != 2 {
+		t.Errorf("Expected 2 active P0 cases, got %d", stats.ActiveCasesByType["P0"])
+	}
+
+	if err := m.CompleteCase("a", now.Add(time.Minute)); err != nil {
+		t.Fatalf("CompleteCase failed: %v", err)
+	}
+	stats = m.GetStatistics()
+	if stats.ActiveCasesByType["P0"] != 1 {
+		t.Errorf("Expected 1 active P0 case after completion, got %d", stats.ActiveCasesByType["P0"])
+	}
+	if stats.CasesByType["P0"] != 2 {
+		t.Errorf("CasesByType should keep completed cases: %v", stats.CasesByType)
+	}
+
+	if cases := m.GetCasesByType("P0"); len(cases) != 1 {
+		t.Errorf("Expected 1 active P0 case, got %d", len(cases))
+	}
+}
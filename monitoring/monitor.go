@@ -1,39 +1,28 @@
 package monitoring
 
 import (
+	"context"
 	"fmt"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// StartCase begins monitoring a new case.
+// StartCase begins monitoring a new case against the monitor's base model.
 func (m *Monitor) StartCase(caseID string, startTime time.Time) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if _, exists := m.cases[caseID]; exists {
-		return fmt.Errorf("case %s already exists", caseID)
-	}
-
-	// Initialize case with starting state (all tokens in start place)
-	initialState := m.net.SetState(nil)
-
-	c := &Case{
-		ID:            caseID,
-		StartTime:     startTime,
-		LastEventTime: startTime,
-		State:         initialState,
-		History:       make([]Event, 0),
-		Attributes:    make(map[string]interface{}),
-	}
-
-	m.cases[caseID] = c
-	m.stats.TotalCases++
-
-	return nil
+	return m.StartTypedCase(caseID, "", startTime)
 }
 
 // RecordEvent records a new event for a case and updates predictions.
 func (m *Monitor) RecordEvent(caseID string, activity string, timestamp time.Time, resource string) error {
+	_, span := tracer.Start(context.Background(), "monitoring.record_event",
+		trace.WithAttributes(
+			attribute.String("case.id", caseID),
+			attribute.String("activity", activity),
+		))
+	defer span.End()
+
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -55,6 +44,11 @@ func (m *Monitor) RecordEvent(caseID string, activity string, timestamp time.Tim
 	c.History = append(c.History, event)
 	c.CurrentActivity = activity
 	c.LastEventTime = timestamp
+	m.activityCounts[activity]++
+	m.observeResource(c, event)
+	if m.drift != nil {
+		m.drift.observe(event)
+	}
 
 	// Update state estimate
 	// In a real implementation, this would use the Petri net structure
@@ -80,6 +74,8 @@ func (m *Monitor) RecordEvent(caseID string, activity string, timestamp time.Tim
 		m.updatePredictions(c)
 	}
 
+	m.persistCase(c)
+
 	return nil
 }
 
@@ -112,12 +108,17 @@ func (m *Monitor) CompleteCase(caseID string, completionTime time.Time) error {
 
 	// Remove from active cases
 	delete(m.cases, caseID)
+	m.unpersistCase(caseID)
 
 	return nil
 }
 
 // updatePredictions updates predictions for a case using simulation.
 func (m *Monitor) updatePredictions(c *Case) {
+	_, span := tracer.Start(context.Background(), "monitoring.predict",
+		trace.WithAttributes(attribute.String("case.id", c.ID)))
+	defer span.End()
+
 	// Use the Petri net model and learned rates to predict future
 	// This is where the magic happens - we simulate forward from current state
 
@@ -126,20 +127,58 @@ func (m *Monitor) updatePredictions(c *Case) {
 		NextActivities: make([]NextActivity, 0),
 	}
 
-	// Use ODE-based prediction from current state
-	remaining, confidence := PredictRemainingTime(c, m.predictor)
+	// Use ODE-based prediction from the case's model and current state
+	model := m.modelFor(c)
+	remaining, confidence := PredictRemainingTime(c, model.predictor)
+	// Account for contention on the case's current resource.
+	remaining += m.queueDelayFor(c)
 	prediction.RemainingTime = remaining
-	prediction.ExpectedCompletion = time.Now().Add(remaining)
 	prediction.Confidence = confidence
 
+	// The SLA policy decides how elapsed time is measured and where the
+	// completion lands: a business calendar defers the deadline past
+	// closed hours, and paused segments do not count against the SLA.
+	now := time.Now()
+	policy := m.policyFor(c)
+	profile := m.profileFor(c.Priority)
+	var elapsed time.Duration
+	if policy != nil {
+		elapsed = policy.elapsedFor(c, now)
+	} else {
+		elapsed = now.Sub(c.StartTime)
+	}
+	if policy != nil && policy.Calendar != nil {
+		prediction.ExpectedCompletion = policy.Calendar.Deadline(now, remaining)
+	} else {
+		prediction.ExpectedCompletion = now.Add(remaining)
+	}
+
 	// Predict next activities
-	nextActivities := PredictNextActivity(c, m.predictor)
+	nextActivities := PredictNextActivity(c, model.predictor)
 	prediction.NextActivities = nextActivities
 
-	// Compute risk score
-	if m.config.SLAThreshold > 0 {
-		totalExpected := time.Since(c.StartTime) + remaining
-		if totalExpected > m.config.SLAThreshold {
+	// Quantile predictions via Monte Carlo rollouts, when enabled.
+	if m.config.QuantileSamples > 0 {
+		state := EstimateCurrentState(c, model.net)
+		slaBudget := m.config.SLAThreshold
+		if policy != nil {
+			slaBudget = time.Duration(float64(policy.Breach) * profile.SLAScale)
+		}
+		prediction.Quantiles = model.predictor.PredictQuantiles(
+			state, elapsed, slaBudget, m.config.QuantileSamples, nil)
+	}
+
+	// Attach the risk breakdown when enabled, so alerts carry it too.
+	if m.config.EnableExplanations {
+		prediction.Explanation = m.explainRisk(c, model)
+	}
+
+	// Compute risk score against the priority-scaled thresholds
+	if policy != nil && policy.Breach > 0 {
+		breach := time.Duration(float64(policy.Breach) * profile.SLAScale)
+		warning := time.Duration(float64(policy.Warning) * profile.SLAScale)
+		totalExpected := elapsed + remaining
+		if totalExpected > breach {
 			prediction.RiskScore = 0.9 // High risk
 
 			// Trigger SLA violation alert
@@ -150,17 +189,17 @@ func (m *Monitor) updatePredictions(c *Case) {
 					Type:      AlertTypeSLAViolation,
 					Severity:  SeverityCritical,
 					Message: fmt.Sprintf("Predicted completion (%s) exceeds SLA threshold (%s)",
-						totalExpected.Round(time.Minute), m.config.SLAThreshold),
+						totalExpected.Round(time.Minute), breach),
 					Prediction: prediction,
-					Threshold:  m.config.SLAThreshold,
+					Threshold:  breach,
 				})
 			}
 		} else {
-			ratio := float64(totalExpected) / float64(m.config.SLAThreshold)
+			ratio := float64(totalExpected) / float64(breach)
 			prediction.RiskScore = ratio // 0-1 scale
 
-			// Warning if getting close (>80%)
-			if ratio > 0.8 && m.config.EnableAlerts {
+			// Warning when past the policy's warning threshold
+			if warning > 0 && totalExpected > warning && m.config.EnableAlerts {
 				m.triggerAlert(Alert{
 					Timestamp:  time.Now(),
 					CaseID:     c.ID,
@@ -168,13 +207,16 @@ func (m *Monitor) updatePredictions(c *Case) {
 					Severity:   SeverityWarning,
 					Message:    fmt.Sprintf("Case at risk: %.0f%% of SLA threshold used", ratio*100),
 					Prediction: prediction,
-					Threshold:  m.config.SLAThreshold,
+					Threshold:  warning,
 				})
 			}
 		}
 	}
 
 	c.Predictions = prediction
+
+	m.logger.Debug("prediction updated", "case", c.ID,
+		"remaining", prediction.RemainingTime, "risk", prediction.RiskScore)
 }
 
 // PredictCompletion returns the latest prediction for a case.
@@ -188,8 +230,8 @@ func (m *Monitor) PredictCompletion(caseID string) (*Prediction, error) {
 		return nil, fmt.Errorf("case %s not found", caseID)
 	}
 
-	// Update if stale or missing
-	if c.Predictions == nil || time.Since(c.Predictions.ComputedAt) > m.config.PredictionInterval {
+	// Update if stale or missing; higher priorities go stale sooner
+	if c.Predictions == nil || time.Since(c.Predictions.ComputedAt) > m.profileFor(c.Priority).PredictionInterval {
 		m.updatePredictions(c)
 	}
 
@@ -223,13 +265,17 @@ func (m *Monitor) Stop() {
 	}
 }
 
-// periodicUpdate updates predictions for all active cases.
+// periodicUpdate refreshes predictions for cases whose predictions have
+// gone stale under their priority's interval.
 func (m *Monitor) periodicUpdate() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if !m.config.EnablePredictions {
+		return
+	}
 	for _, c := range m.cases {
-		if m.config.EnablePredictions {
+		if c.Predictions == nil || time.Since(c.Predictions.ComputedAt) >= m.profileFor(c.Priority).PredictionInterval {
 			m.updatePredictions(c)
 		}
 	}
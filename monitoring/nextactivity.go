@@ -0,0 +1,40 @@
+package monitoring
+
+import (
+	"fmt"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/mining"
+)
+
+// WithVariantPredictions mines variant statistics from a historical log so
+// active cases get a confidence-scored next-activity distribution — the
+// "90% of similar tickets go to Escalate next" hint.
+func (m *Monitor) WithVariantPredictions(log *eventlog.EventLog) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.variants = mining.NewActivityPredictor(log)
+	return m
+}
+
+// NextActivityDistribution returns the distribution over next activities for
+// an active case, predicted from the case's history against the variant
+// model. Requires WithVariantPredictions.
+func (m *Monitor) NextActivityDistribution(caseID string) (*mining.NextActivityForecast, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.variants == nil {
+		return nil, fmt.Errorf("no variant model; call WithVariantPredictions first")
+	}
+	c, exists := m.cases[caseID]
+	if !exists {
+		return nil, fmt.Errorf("case %s not found", caseID)
+	}
+
+	prefix := make([]string, len(c.History))
+	for i, event := range c.History {
+		prefix[i] = event.Activity
+	}
+	return m.variants.PredictNext(prefix), nil
+}
@@ -0,0 +1,45 @@
+package monitoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+func TestNextActivityDistribution(t *testing.T) {
+	history := eventlog.NewEventLog()
+	baseTime := time.Now().Add(-24 * time.Hour)
+	for i := 0; i < 4; i++ {
+		caseID := string(rune('a' + i))
+		history.AddEvent(eventlog.Event{CaseID: caseID, Activity: "begin", Timestamp: baseTime})
+		history.AddEvent(eventlog.Event{CaseID: caseID, Activity: "finish", Timestamp: baseTime.Add(time.Hour)})
+	}
+	history.SortTraces()
+
+	config := DefaultMonitorConfig()
+	config.EnablePredictions = false
+	config.EnableAlerts = false
+	m := NewMonitor(chainNet(), nil, config).WithVariantPredictions(history)
+
+	m.StartCase("live-1", time.Now())
+	m.RecordEvent("live-1", "begin", time.Now(), "")
+
+	forecast, err := m.NextActivityDistribution("live-1")
+	if err != nil {
+		t.Fatalf("NextActivityDistribution: %v", err)
+	}
+	if activity, prob := forecast.Top(); activity != "finish" || prob != 1.0 {
+		t.Errorf("Expected certain finish, got %s %.2f", activity, prob)
+	}
+
+	if _, err := m.NextActivityDistribution("ghost"); err == nil {
+		t.Error("Expected error for unknown case")
+	}
+
+	bare := NewMonitor(chainNet(), nil, config)
+	bare.StartCase("x", time.Now())
+	if _, err := bare.NextActivityDistribution("x"); err == nil {
+		t.Error("Expected error without variant model")
+	}
+}
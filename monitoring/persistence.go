@@ -0,0 +1,107 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pflow-xyz/go-pflow/storage"
+)
+
+// casesBucket is the storage bucket holding one snapshot per active case.
+const casesBucket = "monitor_cases"
+
+// WithPersistence attaches a storage backend to the monitor. Every case
+// mutation (start, event, completion) updates the case's snapshot, so a
+// restarted process can call RestoreCases to resume SLA monitoring where it
+// left off. Any storage.Store driver works (memory, SQLite, Bolt).
+func (m *Monitor) WithPersistence(store storage.Store) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.persist = store
+	return m
+}
+
+// caseSnapshot is the serialized form of a Case. Predictions are persisted
+// too so alerts don't re-fire for already-known risks after recovery.
+type caseSnapshot struct {
+	Case *Case `json:"case"`
+}
+
+// persistCase writes a case snapshot; callers must hold m.mu.
+func (m *Monitor) persistCase(c *Case) {
+	if m.persist == nil {
+		return
+	}
+	data, err := json.Marshal(caseSnapshot{Case: c})
+	if err != nil {
+		return
+	}
+	m.persist.Put(casesBucket, c.ID, data)
+}
+
+// unpersistCase removes a completed case's snapshot; callers must hold m.mu.
+func (m *Monitor) unpersistCase(caseID string) {
+	if m.persist == nil {
+		return
+	}
+	m.persist.Delete(casesBucket, caseID)
+}
+
+// SnapshotCases writes a snapshot of every active case to the attached
+// store. Useful for periodic checkpointing when per-event persistence is
+// too costly.
+func (m *Monitor) SnapshotCases() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.persist == nil {
+		return fmt.Errorf("no persistence store attached (call WithPersistence)")
+	}
+	for _, c := range m.cases {
+		data, err := json.Marshal(caseSnapshot{Case: c})
+		if err != nil {
+			return fmt.Errorf("failed to snapshot case %s: %w", c.ID, err)
+		}
+		if err := m.persist.Put(casesBucket, c.ID, data); err != nil {
+			return fmt.Errorf("failed to persist case %s: %w", c.ID, err)
+		}
+	}
+	return nil
+}
+
+// RestoreCases reloads all persisted case snapshots into the monitor,
+// replacing any in-memory cases with the same IDs. Call it once after
+// construction to survive a restart; predictions resume on the next
+// recorded event. Returns the number of cases restored.
+func (m *Monitor) RestoreCases() (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.persist == nil {
+		return 0, fmt.Errorf("no persistence store attached (call WithPersistence)")
+	}
+
+	restored := 0
+	var iterErr error
+	err := m.persist.Iterate(casesBucket, func(key string, value []byte) bool {
+		var snap caseSnapshot
+		if err := json.Unmarshal(value, &snap); err != nil {
+			iterErr = fmt.Errorf("failed to decode snapshot %s: %w", key, err)
+			return false
+		}
+		if snap.Case == nil || snap.Case.ID == "" {
+			iterErr = fmt.Errorf("snapshot %s has no case", key)
+			return false
+		}
+		m.cases[snap.Case.ID] = snap.Case
+		restored++
+		return true
+	})
+	if iterErr != nil {
+		return restored, iterErr
+	}
+	if err != nil {
+		return restored, err
+	}
+	return restored, nil
+}
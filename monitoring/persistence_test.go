@@ -0,0 +1,73 @@
+package monitoring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/storage"
+)
+
+func newTestNet() *petri.PetriNet {
+	return petri.Build().
+		Place("triage", 1).Place("resolved", 0).
+		Transition("resolve").
+		Arc("triage", "resolve", 1).
+		Arc("resolve", "resolved", 1).
+		Done()
+}
+
+func TestMonitorRestartRecovery(t *testing.T) {
+	net := newTestNet()
+	rates := net.SetRates(nil)
+	config := DefaultMonitorConfig()
+	config.EnablePredictions = false
+	config.EnableAlerts = false
+
+	store := storage.NewMemoryStore()
+	start := time.Now().Add(-time.Hour)
+
+	// First process lifetime: two active cases, one completed.
+	m1 := NewMonitor(net, rates, config).WithPersistence(store)
+	m1.StartCase("c1", start)
+	m1.RecordEvent("c1", "triage", start.Add(time.Minute), "alice")
+	m1.StartCase("c2", start)
+	m1.StartCase("c3", start)
+	m1.CompleteCase("c3", start.Add(30*time.Minute))
+
+	// Simulated restart: fresh monitor, same store.
+	m2 := NewMonitor(net, rates, config).WithPersistence(store)
+	restored, err := m2.RestoreCases()
+	if err != nil {
+		t.Fatalf("RestoreCases: %v", err)
+	}
+	if restored != 2 {
+		t.Fatalf("Expected 2 restored cases, got %d", restored)
+	}
+
+	c1, exists := m2.GetCase("c1")
+	if !exists {
+		t.Fatal("Case c1 not restored")
+	}
+	if c1.CurrentActivity != "triage" || len(c1.History) != 1 {
+		t.Errorf("Case c1 state lost: activity=%q history=%d", c1.CurrentActivity, len(c1.History))
+	}
+	if !c1.StartTime.Equal(start) {
+		t.Errorf("Case c1 start time changed: %v", c1.StartTime)
+	}
+	if _, exists := m2.GetCase("c3"); exists {
+		t.Error("Completed case c3 should not be restored")
+	}
+
+	// Recovered monitor keeps working normally.
+	if err := m2.RecordEvent("c1", "resolve", time.Now(), "bob"); err != nil {
+		t.Errorf("RecordEvent after recovery: %v", err)
+	}
+}
+
+func TestRestoreWithoutStore(t *testing.T) {
+	m := NewMonitor(newTestNet(), nil, DefaultMonitorConfig())
+	if _, err := m.RestoreCases(); err == nil {
+		t.Error("Expected error when no store attached")
+	}
+}
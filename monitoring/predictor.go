@@ -28,6 +28,13 @@ func NewPredictor(net *petri.PetriNet, rates map[string]float64) *Predictor {
 // PredictFromState runs simulation from current state to predict completion time.
 // This is the core predictive capability - uses ODE simulation with learned rates.
 func (p *Predictor) PredictFromState(currentState map[string]float64, currentTime float64) *SimulationPrediction {
+	return p.PredictToPlaces(currentState, currentTime, []string{"end"})
+}
+
+// PredictToPlaces runs simulation from the current state and predicts when
+// the designated final places accumulate a token. PredictFromState is the
+// common case where the single final place is named "end".
+func (p *Predictor) PredictToPlaces(currentState map[string]float64, currentTime float64, finalPlaces []string) *SimulationPrediction {
 	// Simulate forward to see when case completes
 	// We'll simulate up to a reasonable maximum time horizon
 	maxHorizon := 86400.0 // 24 hours in seconds
@@ -47,25 +54,28 @@ func (p *Predictor) PredictFromState(currentState map[string]float64, currentTim
 	}
 	sol := solver.Solve(prob, p.solverMethod, opts)
 
-	// Find when end place gets a token (completion)
-	endTimes := sol.GetVariable("end")
+	// Find when the final places get a token (completion)
 	predictedEndTime := currentTime + maxHorizon // Default: assume max time
-
-	if endTimes != nil {
-		// Find first time when end place reaches significant token count
-		threshold := 0.5 // Consider case complete when end place has >0.5 tokens
-		for i, endTokens := range endTimes {
-			if endTokens >= threshold {
-				predictedEndTime = sol.T[i]
-				break
-			}
+	reached := false
+	threshold := 0.5 // Consider case complete when final places hold >0.5 tokens
+	for i, state := range sol.U {
+		total := 0.0
+		for _, place := range finalPlaces {
+			total += state[place]
+		}
+		if total >= threshold {
+			predictedEndTime = sol.T[i]
+			reached = true
+			break
 		}
 	}
 
 	// Compute confidence based on how much token mass reaches the end
 	finalState := sol.GetFinalState()
-	endTokens := finalState["end"]
-	confidence := endTokens // More tokens in end = higher confidence
+	confidence := 0.0
+	for _, place := range finalPlaces {
+		confidence += finalState[place]
+	}
 
 	// Identify currently enabled transitions
 	enabledTransitions := p.getEnabledTransitions(currentState)
@@ -74,6 +84,7 @@ func (p *Predictor) PredictFromState(currentState map[string]float64, currentTim
 		CurrentTime:        currentTime,
 		PredictedEndTime:   predictedEndTime,
 		Confidence:         confidence,
+		Reached:            reached,
 		StateTrajectory:    make(map[string][]float64),
 		EnabledTransitions: enabledTransitions,
 	}
@@ -86,6 +97,7 @@ type SimulationPrediction struct {
 	CurrentTime        float64
 	PredictedEndTime   float64
 	Confidence         float64
+	Reached            bool                 // final places crossed the threshold within the horizon
 	StateTrajectory    map[string][]float64 // Full state trajectory (optional)
 	EnabledTransitions []string             // Currently enabled transitions
 }
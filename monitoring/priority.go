@@ -0,0 +1,166 @@
+package monitoring
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Case priority.
+//
+// Not every active case deserves the same attention: a critical incident
+// should be re-predicted more often and alerted on a tighter budget than
+// a routine request. Priority is a first-class case attribute that can
+// change while the case runs, and the attention queue orders active
+// cases by how urgently they need a human.
+
+// Priority levels for monitored cases, matching the workflow package.
+type Priority int
+
+const (
+	PriorityCritical Priority = 0 // P0 - Immediate attention
+	PriorityHigh     Priority = 1 // P1 - High priority
+	PriorityMedium   Priority = 2 // P2 - Normal priority
+	PriorityLow      Priority = 3 // P3 - Low priority
+)
+
+// String returns the conventional P-label for a priority.
+func (p Priority) String() string {
+	switch p {
+	case PriorityCritical:
+		return "P0"
+	case PriorityHigh:
+		return "P1"
+	case PriorityMedium:
+		return "P2"
+	case PriorityLow:
+		return "P3"
+	}
+	return fmt.Sprintf("P%d", int(p))
+}
+
+// PriorityProfile tunes monitoring intensity for one priority level.
+type PriorityProfile struct {
+	PredictionInterval time.Duration // staleness bound for predictions (0 = base interval)
+	SLAScale           float64       // multiplier on SLA thresholds (0 = 1.0)
+}
+
+// WithPriorityProfile overrides the monitoring profile for a priority.
+func (m *Monitor) WithPriorityProfile(p Priority, profile PriorityProfile) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.priorityProfiles == nil {
+		m.priorityProfiles = make(map[Priority]PriorityProfile)
+	}
+	m.priorityProfiles[p] = profile
+	return m
+}
+
+// profileFor resolves the effective profile for a priority. Without an
+// override, critical and high priority cases are refreshed more often
+// and judged against a tightened SLA; low priority the reverse.
+func (m *Monitor) profileFor(p Priority) PriorityProfile {
+	prof, ok := m.priorityProfiles[p]
+	if !ok {
+		base := m.config.PredictionInterval
+		switch p {
+		case PriorityCritical:
+			prof = PriorityProfile{PredictionInterval: base / 4, SLAScale: 0.5}
+		case PriorityHigh:
+			prof = PriorityProfile{PredictionInterval: base / 2, SLAScale: 0.75}
+		case PriorityLow:
+			prof = PriorityProfile{PredictionInterval: base * 2, SLAScale: 1.25}
+		default:
+			prof = PriorityProfile{PredictionInterval: base, SLAScale: 1.0}
+		}
+	}
+	if prof.PredictionInterval == 0 {
+		prof.PredictionInterval = m.config.PredictionInterval
+	}
+	if prof.SLAScale == 0 {
+		prof.SLAScale = 1.0
+	}
+	return prof
+}
+
+// StartCaseWithPriority begins monitoring a case with an explicit
+// priority, routed to the model registered for its type.
+func (m *Monitor) StartCaseWithPriority(caseID, caseType string, priority Priority, startTime time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.cases[caseID]; exists {
+		return fmt.Errorf("case %s already exists", caseID)
+	}
+
+	model := m.modelForType(caseType)
+	c := &Case{
+		ID:            caseID,
+		Type:          caseType,
+		Priority:      priority,
+		StartTime:     startTime,
+		LastEventTime: startTime,
+		State:         model.net.SetState(nil),
+		History:       make([]Event, 0),
+		Attributes:    make(map[string]interface{}),
+	}
+
+	m.cases[caseID] = c
+	m.stats.TotalCases++
+	if caseType != "" {
+		m.stats.CasesByType[caseType]++
+	}
+	m.persistCase(c)
+
+	return nil
+}
+
+// Reprioritize changes a running case's priority and immediately
+// refreshes its predictions so the new thresholds take effect.
+func (m *Monitor) Reprioritize(caseID string, priority Priority) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, exists := m.cases[caseID]
+	if !exists {
+		return fmt.Errorf("case %s not found", caseID)
+	}
+	c.Priority = priority
+	if m.config.EnablePredictions {
+		m.updatePredictions(c)
+	}
+	m.persistCase(c)
+	return nil
+}
+
+// AttentionQueue returns the active cases ordered by how urgently they
+// need attention: highest risk score first, priority breaking ties, and
+// older cases ahead of newer ones.
+func (m *Monitor) AttentionQueue() []*Case {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	queue := make([]*Case, 0, len(m.cases))
+	for _, c := range m.cases {
+		queue = append(queue, c)
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		ri, rj := caseRisk(queue[i]), caseRisk(queue[j])
+		if ri != rj {
+			return ri > rj
+		}
+		if queue[i].Priority != queue[j].Priority {
+			return queue[i].Priority < queue[j].Priority
+		}
+		return queue[i].StartTime.Before(queue[j].StartTime)
+	})
+	return queue
+}
+
+// caseRisk returns a case's latest risk score, zero when unpredicted.
+func caseRisk(c *Case) float64 {
+	if c.Predictions == nil {
+		return 0
+	}
+	return c.Predictions.RiskScore
+}
@@ -0,0 +1,106 @@
+package monitoring
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReprioritize(t *testing.T) {
+	m := typedTestMonitor()
+
+	if err := m.StartCaseWithPriority("c1", "", PriorityLow, time.Now()); err != nil {
+		t.Fatalf("StartCaseWithPriority failed: %v", err)
+	}
+	c, _ := m.GetCase("c1")
+	if c.Priority != PriorityLow {
+		t.Errorf("Expected PriorityLow, got %v", c.Priority)
+	}
+
+	if err := m.Reprioritize("c1", PriorityCritical); err != nil {
+		t.Fatalf("Reprioritize failed: %v", err)
+	}
+	if c.Priority != PriorityCritical {
+		t.Errorf("Expected PriorityCritical after reprioritize, got %v", c.Priority)
+	}
+	if c.Predictions == nil {
+		t.Error("Expected predictions refreshed on reprioritize")
+	}
+
+	if err := m.Reprioritize("missing", PriorityHigh); err == nil {
+		t.Error("Expected error for unknown case")
+	}
+}
+
+func TestPriorityProfileScalesThresholds(t *testing.T) {
+	m := typedTestMonitor()
+
+	// Critical cases go stale 4x faster and get half the SLA budget.
+	crit := m.profileFor(PriorityCritical)
+	med := m.profileFor(PriorityMedium)
+	if crit.PredictionInterval >= med.PredictionInterval {
+		t.Errorf("Expected tighter interval for critical: %v vs %v",
+			crit.PredictionInterval, med.PredictionInterval)
+	}
+	if crit.SLAScale >= med.SLAScale {
+		t.Errorf("Expected tighter SLA scale for critical: %f vs %f", crit.SLAScale, med.SLAScale)
+	}
+
+	// Overrides win, with zero fields falling back to defaults.
+	m.WithPriorityProfile(PriorityLow, PriorityProfile{SLAScale: 3.0})
+	low := m.profileFor(PriorityLow)
+	if low.SLAScale != 3.0 {
+		t.Errorf("Expected overridden SLA scale, got %f", low.SLAScale)
+	}
+	if low.PredictionInterval != m.config.PredictionInterval {
+		t.Errorf("Expected base interval fallback, got %v", low.PredictionInterval)
+	}
+
+	// The same case is judged riskier at higher priority.
+	now := time.Now()
+	m.StartCaseWithPriority("c1", "", PriorityLow, now)
+	lowPred, err := m.PredictCompletion("c1")
+	if err != nil {
+		t.Fatalf("PredictCompletion failed: %v", err)
+	}
+	m.Reprioritize("c1", PriorityCritical)
+	c, _ := m.GetCase("c1")
+	if c.Predictions.RiskScore <= lowPred.RiskScore {
+		t.Errorf("Expected higher risk at critical priority: %f <= %f",
+			c.Predictions.RiskScore, lowPred.RiskScore)
+	}
+}
+
+func TestAttentionQueue(t *testing.T) {
+	m := typedTestMonitor()
+	now := time.Now()
+	m.StartCaseWithPriority("low-risk", "", PriorityLow, now)
+	m.StartCaseWithPriority("high-risk", "", PriorityMedium, now)
+	m.StartCaseWithPriority("critical-tie", "", PriorityCritical, now.Add(time.Minute))
+	m.StartCaseWithPriority("old-tie", "", PriorityCritical, now)
+
+	set := func(id string, risk float64) {
+		c, _ := m.GetCase(id)
+		c.Predictions = &Prediction{RiskScore: risk}
+	}
+	set("low-risk", 0.1)
+	set("high-risk", 0.9)
+	set("critical-tie", 0.5)
+	set("old-tie", 0.5)
+
+	queue := m.AttentionQueue()
+	if len(queue) != 4 {
+		t.Fatalf("Expected 4 cases, got %d", len(queue))
+	}
+	want := []string{"high-risk", "old-tie", "critical-tie", "low-risk"}
+	for i, id := range want {
+		if queue[i].ID != id {
+			t.Errorf("Position %d: expected %s, got %s", i, id, queue[i].ID)
+		}
+	}
+}
+
+func TestPriorityString(t *testing.T) {
+	if PriorityCritical.String() != "P0" || PriorityLow.String() != "P3" {
+		t.Errorf("Unexpected priority labels: %s %s", PriorityCritical, PriorityLow)
+	}
+}
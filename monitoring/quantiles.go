@@ -0,0 +1,222 @@
+package monitoring
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// QuantilePrediction summarizes Monte Carlo completion rollouts. Unlike the
+// single ExpectedCompletion point estimate, it exposes the distribution so
+// alert thresholds can be set on quantiles ("alert when P90 breaches SLA")
+// and a calibrated breach probability.
+type QuantilePrediction struct {
+	Samples int // rollouts run
+
+	// Remaining-time quantiles from the current state.
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+
+	// Completion times corresponding to the quantiles, anchored at the
+	// prediction time.
+	Completion50 time.Time
+	Completion90 time.Time
+	Completion99 time.Time
+
+	// BreachProbability is the fraction of rollouts whose total case time
+	// (elapsed + remaining) exceeds the SLA threshold.
+	BreachProbability float64
+
+	// Censored is the fraction of rollouts that did not complete within
+	// the simulation horizon; their remaining time is counted at the
+	// horizon, so high values mean the quantiles are lower bounds.
+	Censored float64
+}
+
+// PredictQuantiles runs Monte Carlo rollouts (Gillespie-style stochastic
+// simulation with the learned rates) from the given state and returns
+// completion time quantiles and SLA breach probability. elapsed is the time
+// the case has already been running; sla of zero disables breach
+// accounting. A nil rng gets an unpredictable seed — pass a seeded one for
+// reproducible predictions.
+func (p *Predictor) PredictQuantiles(state map[string]float64, elapsed, sla time.Duration, samples int, rng *rand.Rand) *QuantilePrediction {
+	if samples <= 0 {
+		samples = 200
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	const horizon = 86400.0 // seconds; matches the ODE predictor's cap
+
+	remaining := make([]float64, samples)
+	censored := 0
+	breaches := 0
+	for i := 0; i < samples; i++ {
+		seconds, completed := p.rollout(state, horizon, rng)
+		remaining[i] = seconds
+		if !completed {
+			censored++
+		}
+		if sla > 0 && elapsed+secondsToDuration(seconds) > sla {
+			breaches++
+		}
+	}
+	sort.Float64s(remaining)
+
+	now := time.Now()
+	pred := &QuantilePrediction{
+		Samples:           samples,
+		P50:               secondsToDuration(quantile(remaining, 0.50)),
+		P90:               secondsToDuration(quantile(remaining, 0.90)),
+		P99:               secondsToDuration(quantile(remaining, 0.99)),
+		Censored:          float64(censored) / float64(samples),
+		BreachProbability: float64(breaches) / float64(samples),
+	}
+	pred.Completion50 = now.Add(pred.P50)
+	pred.Completion90 = now.Add(pred.P90)
+	pred.Completion99 = now.Add(pred.P99)
+	return pred
+}
+
+// PredictQuantilesForCase runs quantile prediction for an active case using
+// its estimated state, elapsed time, and the monitor's SLA threshold.
+func (m *Monitor) PredictQuantilesForCase(caseID string, samples int) (*QuantilePrediction, error) {
+	m.mu.RLock()
+	c, exists := m.cases[caseID]
+	m.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("case %s not found", caseID)
+	}
+
+	model := m.modelFor(c)
+	state := EstimateCurrentState(c, model.net)
+	elapsed := time.Since(c.StartTime)
+	slaBudget := m.config.SLAThreshold
+	if policy := m.policyFor(c); policy != nil {
+		elapsed = policy.elapsedFor(c, time.Now())
+		slaBudget = policy.Breach
+	}
+	return model.predictor.PredictQuantiles(state, elapsed, slaBudget, samples, nil), nil
+}
+
+// rollout runs one stochastic trajectory until the end place is marked, the
+// net is absorbed (nothing can fire), or the horizon elapses. Returns the
+// simulated seconds and whether the case completed.
+func (p *Predictor) rollout(initial map[string]float64, horizon float64, rng *rand.Rand) (float64, bool) {
+	// Work on an integer token copy; stochastic firings are discrete.
+	state := make(map[string]float64, len(initial))
+	for place, tokens := range initial {
+		state[place] = math.Round(tokens)
+	}
+
+	t := 0.0
+	for t < horizon {
+		if state["end"] >= 1 {
+			return t, true
+		}
+
+		// Race the enabled transitions: each draws a waiting time from
+		// its fitted duration distribution when one is stored on the
+		// net, otherwise from an exponential clock at its effective
+		// mass-action rate. The earliest fires.
+		chosen := ""
+		wait := math.Inf(1)
+		for label, trans := range p.net.Transitions {
+			flux := p.effectiveRate(label, state)
+			if flux <= 0 {
+				continue
+			}
+			var w float64
+			if trans.Duration != nil {
+				w = trans.Duration.Sample(rng)
+			} else {
+				w = rng.ExpFloat64() / flux
+			}
+			if w < wait {
+				chosen = label
+				wait = w
+			}
+		}
+		if chosen == "" {
+			// Absorbed: complete if tokens reached the end place, else the
+			// case is stuck and never finishes.
+			return t, state["end"] >= 1
+		}
+
+		t += wait
+		if t >= horizon {
+			break
+		}
+		p.fireDiscrete(chosen, state)
+	}
+	return horizon, state["end"] >= 1
+}
+
+// effectiveRate computes a transition's stochastic firing rate in the given
+// state: learned rate times the product of input place tokens, zero when an
+// input is empty or an inhibitor threshold is met.
+func (p *Predictor) effectiveRate(label string, state map[string]float64) float64 {
+	rate := p.rates[label]
+	if rate == 0 {
+		rate = 1.0
+	}
+	flux := rate
+	for _, arc := range p.net.GetInputArcs(label) {
+		weight := arc.GetWeightSum()
+		if weight < 1 {
+			weight = 1
+		}
+		tokens := state[arc.Source]
+		if arc.InhibitTransition {
+			if tokens >= weight {
+				return 0
+			}
+			continue
+		}
+		if tokens < weight {
+			return 0
+		}
+		flux *= tokens
+	}
+	return flux
+}
+
+// fireDiscrete applies one discrete firing to the state.
+func (p *Predictor) fireDiscrete(label string, state map[string]float64) {
+	for _, arc := range p.net.GetInputArcs(label) {
+		if arc.InhibitTransition {
+			continue
+		}
+		weight := arc.GetWeightSum()
+		if weight < 1 {
+			weight = 1
+		}
+		state[arc.Source] -= weight
+		if state[arc.Source] < 0 {
+			state[arc.Source] = 0
+		}
+	}
+	for _, arc := range p.net.GetOutputArcs(label) {
+		weight := arc.GetWeightSum()
+		if weight < 1 {
+			weight = 1
+		}
+		state[arc.Target] += weight
+	}
+}
+
+// quantile reads the q-th quantile from a sorted sample.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(q * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
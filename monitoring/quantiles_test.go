@@ -0,0 +1,90 @@
+package monitoring
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// chainNet is a start -> work -> end flow with a known service rate.
+func chainNet() *petri.PetriNet {
+	return petri.Build().
+		Chain(1, "start", "begin", "working", "finish", "end").
+		Done()
+}
+
+func TestPredictQuantilesOrderingAndBreach(t *testing.T) {
+	net := chainNet()
+	rates := map[string]float64{"begin": 1.0 / 60, "finish": 1.0 / 60} // ~1/min each
+	p := NewPredictor(net, rates)
+
+	state := net.SetState(nil)
+	rng := rand.New(rand.NewSource(1))
+	pred := p.PredictQuantiles(state, 0, 10*time.Minute, 500, rng)
+
+	if pred.Samples != 500 {
+		t.Errorf("Expected 500 samples, got %d", pred.Samples)
+	}
+	if pred.P50 <= 0 || pred.P50 > pred.P90 || pred.P90 > pred.P99 {
+		t.Errorf("Quantiles not ordered: P50=%v P90=%v P99=%v", pred.P50, pred.P90, pred.P99)
+	}
+	// Two sequential exp(1/min) stages: median around 100s, well inside a
+	// 10 minute SLA most of the time.
+	if pred.BreachProbability < 0 || pred.BreachProbability > 0.5 {
+		t.Errorf("Implausible breach probability %f", pred.BreachProbability)
+	}
+	if pred.Censored != 0 {
+		t.Errorf("Short chain should always complete, censored=%f", pred.Censored)
+	}
+
+	// Tight SLA: breach probability must rise.
+	tight := p.PredictQuantiles(state, 0, 30*time.Second, 500, rand.New(rand.NewSource(1)))
+	if tight.BreachProbability <= pred.BreachProbability {
+		t.Errorf("Tighter SLA should breach more often: %f <= %f",
+			tight.BreachProbability, pred.BreachProbability)
+	}
+
+	// Elapsed time counts toward the SLA.
+	late := p.PredictQuantiles(state, 9*time.Minute+50*time.Second, 10*time.Minute, 500, rand.New(rand.NewSource(1)))
+	if late.BreachProbability < 0.9 {
+		t.Errorf("Nearly exhausted SLA should almost surely breach: %f", late.BreachProbability)
+	}
+}
+
+func TestSeededQuantilesAreReproducible(t *testing.T) {
+	p := NewPredictor(chainNet(), map[string]float64{"begin": 0.1, "finish": 0.1})
+	state := p.net.SetState(nil)
+
+	a := p.PredictQuantiles(state, 0, time.Hour, 200, rand.New(rand.NewSource(7)))
+	b := p.PredictQuantiles(state, 0, time.Hour, 200, rand.New(rand.NewSource(7)))
+	if a.P50 != b.P50 || a.P99 != b.P99 || a.BreachProbability != b.BreachProbability {
+		t.Errorf("Same seed produced different predictions: %+v vs %+v", a, b)
+	}
+}
+
+func TestMonitorQuantilePredictions(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.EnableAlerts = false
+	config.QuantileSamples = 100
+
+	m := NewMonitor(chainNet(), map[string]float64{"begin": 0.1, "finish": 0.1}, config)
+	m.StartCase("c1", time.Now().Add(-time.Minute))
+	m.RecordEvent("c1", "begin", time.Now(), "")
+
+	pred, err := m.PredictCompletion("c1")
+	if err != nil {
+		t.Fatalf("PredictCompletion: %v", err)
+	}
+	if pred.Quantiles == nil {
+		t.Fatal("Expected quantiles on prediction")
+	}
+	if pred.Quantiles.P50 > pred.Quantiles.P99 {
+		t.Errorf("Quantiles not ordered: %+v", pred.Quantiles)
+	}
+
+	if _, err := m.PredictQuantilesForCase("missing", 10); err == nil {
+		t.Error("Expected error for unknown case")
+	}
+}
@@ -0,0 +1,178 @@
+package monitoring
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ResourcePool declares how many cases a resource can serve concurrently —
+// one on-call engineer, three baristas, a five-slot review board.
+type ResourcePool struct {
+	Name     string
+	Capacity int
+}
+
+// ResourceStats reports the live load on one resource pool.
+type ResourceStats struct {
+	Name     string
+	Capacity int
+	Busy     int // active cases whose latest event used this resource
+	Waiting  int // cases beyond capacity, queued
+
+	Utilization float64 // Busy / Capacity
+
+	// MeanServiceTime is the observed mean time between events handled by
+	// this resource.
+	MeanServiceTime time.Duration
+
+	// EstimatedQueueDelay is the extra wait a newly arriving case should
+	// expect before this resource frees up.
+	EstimatedQueueDelay time.Duration
+
+	// Bottleneck marks pools running at or beyond capacity.
+	Bottleneck bool
+}
+
+// resourceUsage accumulates observed service times per resource.
+type resourceUsage struct {
+	serviceSum   time.Duration
+	serviceCount int
+	bottleneck   bool // last reported state, for alert edge-triggering
+}
+
+// WithResources declares resource pools so predictions and statistics
+// account for contention. Events whose Resource names a declared pool
+// occupy one slot from that event until the case's next event or
+// completion; pools running past capacity are flagged as bottlenecks and
+// raise an AlertTypeResourceIssue alert.
+func (m *Monitor) WithResources(pools ...ResourcePool) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.resourcePools == nil {
+		m.resourcePools = make(map[string]int)
+		m.resourceUsage = make(map[string]*resourceUsage)
+	}
+	for _, pool := range pools {
+		capacity := pool.Capacity
+		if capacity < 1 {
+			capacity = 1
+		}
+		m.resourcePools[pool.Name] = capacity
+		if m.resourceUsage[pool.Name] == nil {
+			m.resourceUsage[pool.Name] = &resourceUsage{}
+		}
+	}
+	return m
+}
+
+// ResourceStatistics returns current load per declared pool, sorted by
+// name.
+func (m *Monitor) ResourceStatistics() []ResourceStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.resourceStatsLocked()
+}
+
+// resourceStatsLocked computes pool stats; callers hold m.mu.
+func (m *Monitor) resourceStatsLocked() []ResourceStats {
+	busy := make(map[string]int)
+	for _, c := range m.cases {
+		if len(c.History) == 0 {
+			continue
+		}
+		resource := c.History[len(c.History)-1].Resource
+		if _, declared := m.resourcePools[resource]; declared {
+			busy[resource]++
+		}
+	}
+
+	names := make([]string, 0, len(m.resourcePools))
+	for name := range m.resourcePools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stats := make([]ResourceStats, 0, len(names))
+	for _, name := range names {
+		capacity := m.resourcePools[name]
+		usage := m.resourceUsage[name]
+
+		s := ResourceStats{
+			Name:        name,
+			Capacity:    capacity,
+			Busy:        busy[name],
+			Utilization: float64(busy[name]) / float64(capacity),
+		}
+		if s.Busy > capacity {
+			s.Waiting = s.Busy - capacity
+		}
+		if usage.serviceCount > 0 {
+			s.MeanServiceTime = usage.serviceSum / time.Duration(usage.serviceCount)
+			// Each waiting case must wait for a slot; slots free at
+			// capacity/meanService per unit time.
+			if s.Waiting > 0 {
+				s.EstimatedQueueDelay = time.Duration(s.Waiting) * s.MeanServiceTime / time.Duration(capacity)
+			}
+		}
+		s.Bottleneck = s.Busy >= capacity
+		stats = append(stats, s)
+	}
+	return stats
+}
+
+// observeResource records service time and bottleneck transitions for one
+// event; callers hold m.mu.
+func (m *Monitor) observeResource(c *Case, event Event) {
+	if m.resourcePools == nil {
+		return
+	}
+
+	// Attribute the gap since the previous event to the resource that was
+	// serving the case during that gap.
+	if len(c.History) >= 2 {
+		previous := c.History[len(c.History)-2]
+		if usage, declared := m.resourceUsage[previous.Resource]; declared {
+			if gap := event.Timestamp.Sub(previous.Timestamp); gap > 0 {
+				usage.serviceSum += gap
+				usage.serviceCount++
+			}
+		}
+	}
+
+	// Edge-trigger bottleneck alerts so a saturated pool alerts once, not
+	// on every event.
+	if _, declared := m.resourcePools[event.Resource]; !declared {
+		return
+	}
+	for _, s := range m.resourceStatsLocked() {
+		usage := m.resourceUsage[s.Name]
+		if s.Bottleneck && !usage.bottleneck && m.config.EnableAlerts {
+			m.triggerAlert(Alert{
+				Timestamp: event.Timestamp,
+				CaseID:    event.CaseID,
+				Type:      AlertTypeResourceIssue,
+				Severity:  SeverityWarning,
+				Message: fmt.Sprintf("Resource %s saturated: %d/%d busy, %d waiting (est. delay %s)",
+					s.Name, s.Busy, s.Capacity, s.Waiting, s.EstimatedQueueDelay.Round(time.Second)),
+			})
+		}
+		usage.bottleneck = s.Bottleneck
+	}
+}
+
+// queueDelayFor estimates the queueing delay the case faces on its current
+// resource; callers hold m.mu. Zero when the resource is undeclared or
+// idle.
+func (m *Monitor) queueDelayFor(c *Case) time.Duration {
+	if m.resourcePools == nil || len(c.History) == 0 {
+		return 0
+	}
+	resource := c.History[len(c.History)-1].Resource
+	for _, s := range m.resourceStatsLocked() {
+		if s.Name == resource {
+			return s.EstimatedQueueDelay
+		}
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package monitoring
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResourceStatisticsAndBottleneck(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.EnablePredictions = false
+
+	var mu sync.Mutex
+	var alerts []Alert
+	m := NewMonitor(newTestNet(), nil, config).
+		WithResources(ResourcePool{Name: "alice", Capacity: 2})
+	m.AddAlertHandler(func(a Alert) {
+		mu.Lock()
+		alerts = append(alerts, a)
+		mu.Unlock()
+	})
+
+	start := time.Now().Add(-time.Hour)
+	// Three concurrent cases all served by alice (capacity 2).
+	for i := 0; i < 3; i++ {
+		id := fmt.Sprintf("c%d", i)
+		m.StartCase(id, start)
+		m.RecordEvent(id, "triage", start.Add(time.Duration(i)*10*time.Minute), "alice")
+		m.RecordEvent(id, "triage", start.Add(time.Duration(i)*10*time.Minute+5*time.Minute), "alice")
+	}
+
+	stats := m.ResourceStatistics()
+	if len(stats) != 1 {
+		t.Fatalf("Expected 1 pool, got %d", len(stats))
+	}
+	s := stats[0]
+	if s.Busy != 3 || s.Waiting != 1 || !s.Bottleneck {
+		t.Errorf("Unexpected stats: %+v", s)
+	}
+	if s.Utilization != 1.5 {
+		t.Errorf("Expected utilization 1.5, got %f", s.Utilization)
+	}
+	// Gaps of 5 minutes were attributed to alice.
+	if s.MeanServiceTime != 5*time.Minute {
+		t.Errorf("Expected 5m mean service, got %v", s.MeanServiceTime)
+	}
+	if s.EstimatedQueueDelay <= 0 {
+		t.Errorf("Expected positive queue delay, got %v", s.EstimatedQueueDelay)
+	}
+
+	// Saturation alert fired exactly once despite multiple events past
+	// capacity.
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(alerts) >= 1
+	})
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	resourceAlerts := 0
+	for _, a := range alerts {
+		if a.Type == AlertTypeResourceIssue {
+			resourceAlerts++
+		}
+	}
+	mu.Unlock()
+	if resourceAlerts != 1 {
+		t.Errorf("Expected exactly 1 resource alert, got %d", resourceAlerts)
+	}
+
+	// Completing cases frees the pool.
+	m.CompleteCase("c0", time.Now())
+	m.CompleteCase("c1", time.Now())
+	stats = m.ResourceStatistics()
+	if stats[0].Busy != 1 || stats[0].Bottleneck {
+		t.Errorf("Pool not freed: %+v", stats[0])
+	}
+}
+
+func TestQueueDelayExtendsPredictions(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.EnableAlerts = false
+
+	m := NewMonitor(chainNet(), map[string]float64{"begin": 0.1, "finish": 0.1}, config).
+		WithResources(ResourcePool{Name: "oncall", Capacity: 1})
+
+	start := time.Now().Add(-30 * time.Minute)
+	for i := 0; i < 3; i++ {
+		id := fmt.Sprintf("c%d", i)
+		m.StartCase(id, start)
+		m.RecordEvent(id, "begin", start.Add(10*time.Minute), "oncall")
+		m.RecordEvent(id, "begin", start.Add(20*time.Minute), "oncall")
+	}
+
+	// The contended case's prediction includes queueing delay on top of
+	// the model's estimate.
+	contended, err := m.PredictCompletion("c2")
+	if err != nil {
+		t.Fatalf("PredictCompletion: %v", err)
+	}
+
+	solo := NewMonitor(chainNet(), map[string]float64{"begin": 0.1, "finish": 0.1}, config)
+	solo.StartCase("s1", start)
+	solo.RecordEvent("s1", "begin", start.Add(10*time.Minute), "oncall")
+	solo.RecordEvent("s1", "begin", start.Add(20*time.Minute), "oncall")
+	baseline, err := solo.PredictCompletion("s1")
+	if err != nil {
+		t.Fatalf("PredictCompletion: %v", err)
+	}
+
+	if contended.RemainingTime <= baseline.RemainingTime {
+		t.Errorf("Contended prediction (%v) should exceed uncontended (%v)",
+			contended.RemainingTime, baseline.RemainingTime)
+	}
+}
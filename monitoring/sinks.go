@@ -0,0 +1,282 @@
+package monitoring
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RouteConfig holds the delivery options shared by every alert sink:
+// severity filtering, batching, and deduplication.
+type RouteConfig struct {
+	// MinSeverity drops alerts below this severity ("" = deliver all).
+	MinSeverity AlertSeverity
+
+	// BatchWindow collects alerts for this long before delivering them
+	// together. Zero delivers each alert immediately.
+	BatchWindow time.Duration
+
+	// DedupWindow suppresses repeat alerts with the same case ID and type
+	// within the window. Zero disables deduplication.
+	DedupWindow time.Duration
+}
+
+// SlackSinkConfig routes alerts to a Slack incoming webhook.
+type SlackSinkConfig struct {
+	RouteConfig
+	WebhookURL string
+}
+
+// PagerDutySinkConfig routes alerts to the PagerDuty Events API v2.
+type PagerDutySinkConfig struct {
+	RouteConfig
+	RoutingKey string
+	// APIURL overrides the Events API endpoint (for testing); defaults to
+	// the public PagerDuty enqueue endpoint.
+	APIURL string
+}
+
+// WebhookSinkConfig routes alerts to a generic HTTP endpoint as a JSON
+// array. When Secret is set, each request carries an X-Pflow-Signature
+// header with the hex HMAC-SHA256 of the body so receivers can verify
+// authenticity.
+type WebhookSinkConfig struct {
+	RouteConfig
+	URL    string
+	Secret string
+}
+
+// AlertSinks configures built-in alert delivery on MonitorConfig. Any
+// combination may be set; each sink filters, batches, and deduplicates
+// independently.
+type AlertSinks struct {
+	Slack     *SlackSinkConfig
+	PagerDuty *PagerDutySinkConfig
+	Webhook   *WebhookSinkConfig
+
+	// OnError is called when delivery fails (nil = errors are dropped).
+	OnError func(error)
+}
+
+// severityRank orders severities for MinSeverity filtering.
+func severityRank(s AlertSeverity) int {
+	switch s {
+	case SeverityWarning:
+		return 1
+	case SeverityCritical:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// alertRouter applies RouteConfig policy in front of a delivery function.
+// Its Handle method is an AlertHandler.
+type alertRouter struct {
+	config  RouteConfig
+	deliver func(alerts []Alert) error
+	onError func(error)
+
+	mu       sync.Mutex
+	pending  []Alert
+	lastSeen map[string]time.Time // dedup key -> last delivery time
+	timer    *time.Timer
+}
+
+func newAlertRouter(config RouteConfig, deliver func([]Alert) error, onError func(error)) *alertRouter {
+	return &alertRouter{
+		config:   config,
+		deliver:  deliver,
+		onError:  onError,
+		lastSeen: make(map[string]time.Time),
+	}
+}
+
+// Handle filters, deduplicates, and batches one alert. Safe for concurrent
+// use; Monitor invokes handlers from goroutines.
+func (r *alertRouter) Handle(alert Alert) {
+	if severityRank(alert.Severity) < severityRank(r.config.MinSeverity) {
+		return
+	}
+
+	r.mu.Lock()
+	if r.config.DedupWindow > 0 {
+		key := alert.CaseID + "|" + string(alert.Type)
+		if last, seen := r.lastSeen[key]; seen && time.Since(last) < r.config.DedupWindow {
+			r.mu.Unlock()
+			return
+		}
+		r.lastSeen[key] = time.Now()
+	}
+
+	if r.config.BatchWindow <= 0 {
+		r.mu.Unlock()
+		r.send([]Alert{alert})
+		return
+	}
+
+	r.pending = append(r.pending, alert)
+	if r.timer == nil {
+		r.timer = time.AfterFunc(r.config.BatchWindow, r.Flush)
+	}
+	r.mu.Unlock()
+}
+
+// Flush delivers any batched alerts immediately.
+func (r *alertRouter) Flush() {
+	r.mu.Lock()
+	batch := r.pending
+	r.pending = nil
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.mu.Unlock()
+
+	if len(batch) > 0 {
+		r.send(batch)
+	}
+}
+
+func (r *alertRouter) send(alerts []Alert) {
+	if err := r.deliver(alerts); err != nil && r.onError != nil {
+		r.onError(err)
+	}
+}
+
+// WithAlertSinks registers the configured sinks as alert handlers.
+// NewMonitor calls this automatically when MonitorConfig.Sinks is set; it
+// is exported so sinks can also be attached after construction. Call
+// FlushSinks on shutdown so a final batch is not lost.
+func (m *Monitor) WithAlertSinks(sinks AlertSinks) *Monitor {
+	var routers []*alertRouter
+	if sinks.Slack != nil {
+		routers = append(routers,
+			newAlertRouter(sinks.Slack.RouteConfig, slackDeliver(sinks.Slack), sinks.OnError))
+	}
+	if sinks.PagerDuty != nil {
+		routers = append(routers,
+			newAlertRouter(sinks.PagerDuty.RouteConfig, pagerDutyDeliver(sinks.PagerDuty), sinks.OnError))
+	}
+	if sinks.Webhook != nil {
+		routers = append(routers,
+			newAlertRouter(sinks.Webhook.RouteConfig, webhookDeliver(sinks.Webhook), sinks.OnError))
+	}
+
+	for _, router := range routers {
+		m.AddAlertHandler(router.Handle)
+	}
+	m.sinkRouters = append(m.sinkRouters, routers...)
+	return m
+}
+
+// FlushSinks delivers any batched alerts held by the configured sinks.
+func (m *Monitor) FlushSinks() {
+	for _, router := range m.sinkRouters {
+		router.Flush()
+	}
+}
+
+// slackDeliver posts a batch as one Slack message, one line per alert.
+func slackDeliver(config *SlackSinkConfig) func([]Alert) error {
+	return func(alerts []Alert) error {
+		var lines []string
+		for _, alert := range alerts {
+			lines = append(lines, alert.String())
+		}
+		payload, err := json.Marshal(map[string]string{"text": strings.Join(lines, "\n")})
+		if err != nil {
+			return err
+		}
+		return postJSON(config.WebhookURL, payload, nil)
+	}
+}
+
+// pagerDutyDeliver enqueues one Events API v2 trigger per alert so each
+// gets its own incident with a stable dedup key.
+func pagerDutyDeliver(config *PagerDutySinkConfig) func([]Alert) error {
+	url := config.APIURL
+	if url == "" {
+		url = "https://events.pagerduty.com/v2/enqueue"
+	}
+	return func(alerts []Alert) error {
+		for _, alert := range alerts {
+			severity := "info"
+			switch alert.Severity {
+			case SeverityWarning:
+				severity = "warning"
+			case SeverityCritical:
+				severity = "critical"
+			}
+			payload, err := json.Marshal(map[string]any{
+				"routing_key":  config.RoutingKey,
+				"event_action": "trigger",
+				"dedup_key":    fmt.Sprintf("pflow-%s-%s", alert.CaseID, alert.Type),
+				"payload": map[string]any{
+					"summary":   alert.String(),
+					"source":    "go-pflow/monitoring",
+					"severity":  severity,
+					"timestamp": alert.Timestamp.Format(time.RFC3339),
+				},
+			})
+			if err != nil {
+				return err
+			}
+			if err := postJSON(url, payload, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// webhookDeliver posts the batch as a JSON array, signed when a secret is
+// configured.
+func webhookDeliver(config *WebhookSinkConfig) func([]Alert) error {
+	return func(alerts []Alert) error {
+		payload, err := json.Marshal(alerts)
+		if err != nil {
+			return err
+		}
+		var headers map[string]string
+		if config.Secret != "" {
+			headers = map[string]string{"X-Pflow-Signature": SignPayload(payload, config.Secret)}
+		}
+		return postJSON(config.URL, payload, headers)
+	}
+}
+
+// SignPayload computes the hex HMAC-SHA256 signature webhook receivers
+// should compare against the X-Pflow-Signature header.
+func SignPayload(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func postJSON(url string, payload []byte, headers map[string]string) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert sink %s returned %s", url, resp.Status)
+	}
+	return nil
+}
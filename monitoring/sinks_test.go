@@ -0,0 +1,174 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sinkRecorder captures webhook deliveries for assertions.
+type sinkRecorder struct {
+	mu        sync.Mutex
+	bodies    [][]byte
+	signature string
+}
+
+func (r *sinkRecorder) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.bodies = append(r.bodies, body)
+		r.signature = req.Header.Get("X-Pflow-Signature")
+		r.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (r *sinkRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies)
+}
+
+func TestWebhookSinkSignsAndFilters(t *testing.T) {
+	rec := &sinkRecorder{}
+	server := httptest.NewServer(rec.handler())
+	defer server.Close()
+
+	m := NewMonitor(newTestNet(), nil, DefaultMonitorConfig()).
+		WithAlertSinks(AlertSinks{
+			Webhook: &WebhookSinkConfig{
+				RouteConfig: RouteConfig{MinSeverity: SeverityWarning},
+				URL:         server.URL,
+				Secret:      "s3cret",
+			},
+		})
+
+	// Info is filtered; warning is delivered.
+	m.triggerAlert(Alert{CaseID: "c1", Type: AlertTypeDelayed, Severity: SeverityInfo})
+	m.triggerAlert(Alert{CaseID: "c1", Type: AlertTypeStuck, Severity: SeverityWarning})
+
+	waitFor(t, func() bool { return rec.count() == 1 })
+
+	rec.mu.Lock()
+	body, sig := rec.bodies[0], rec.signature
+	rec.mu.Unlock()
+
+	var alerts []Alert
+	if err := json.Unmarshal(body, &alerts); err != nil {
+		t.Fatalf("Bad webhook body: %v", err)
+	}
+	if len(alerts) != 1 || alerts[0].Type != AlertTypeStuck {
+		t.Errorf("Unexpected delivery: %+v", alerts)
+	}
+	if sig != SignPayload(body, "s3cret") {
+		t.Errorf("Bad signature %q", sig)
+	}
+}
+
+func TestSinkDeduplication(t *testing.T) {
+	rec := &sinkRecorder{}
+	server := httptest.NewServer(rec.handler())
+	defer server.Close()
+
+	m := NewMonitor(newTestNet(), nil, DefaultMonitorConfig()).
+		WithAlertSinks(AlertSinks{
+			Webhook: &WebhookSinkConfig{
+				RouteConfig: RouteConfig{DedupWindow: time.Minute},
+				URL:         server.URL,
+			},
+		})
+
+	for i := 0; i < 5; i++ {
+		m.triggerAlert(Alert{CaseID: "c1", Type: AlertTypeStuck, Severity: SeverityWarning})
+	}
+	m.triggerAlert(Alert{CaseID: "c2", Type: AlertTypeStuck, Severity: SeverityWarning})
+
+	waitFor(t, func() bool { return rec.count() == 2 })
+	time.Sleep(20 * time.Millisecond)
+	if got := rec.count(); got != 2 {
+		t.Errorf("Expected 2 deliveries after dedup, got %d", got)
+	}
+}
+
+func TestSinkBatching(t *testing.T) {
+	rec := &sinkRecorder{}
+	server := httptest.NewServer(rec.handler())
+	defer server.Close()
+
+	m := NewMonitor(newTestNet(), nil, DefaultMonitorConfig()).
+		WithAlertSinks(AlertSinks{
+			Webhook: &WebhookSinkConfig{
+				RouteConfig: RouteConfig{BatchWindow: time.Hour},
+				URL:         server.URL,
+			},
+		})
+
+	m.triggerAlert(Alert{CaseID: "c1", Type: AlertTypeStuck, Severity: SeverityWarning})
+	m.triggerAlert(Alert{CaseID: "c2", Type: AlertTypeDelayed, Severity: SeverityCritical})
+	time.Sleep(20 * time.Millisecond)
+	if rec.count() != 0 {
+		t.Fatal("Batch delivered before window elapsed or flush")
+	}
+
+	m.FlushSinks()
+	waitFor(t, func() bool { return rec.count() == 1 })
+
+	var alerts []Alert
+	rec.mu.Lock()
+	json.Unmarshal(rec.bodies[0], &alerts)
+	rec.mu.Unlock()
+	if len(alerts) != 2 {
+		t.Errorf("Expected batch of 2, got %d", len(alerts))
+	}
+}
+
+func TestSlackAndPagerDutyPayloads(t *testing.T) {
+	rec := &sinkRecorder{}
+	server := httptest.NewServer(rec.handler())
+	defer server.Close()
+
+	config := DefaultMonitorConfig()
+	config.Sinks = &AlertSinks{
+		Slack:     &SlackSinkConfig{WebhookURL: server.URL},
+		PagerDuty: &PagerDutySinkConfig{RoutingKey: "rk", APIURL: server.URL},
+	}
+	m := NewMonitor(newTestNet(), nil, config)
+	m.triggerAlert(Alert{CaseID: "c1", Type: AlertTypeSLAViolation, Severity: SeverityCritical, Message: "late"})
+
+	waitFor(t, func() bool { return rec.count() == 2 })
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	var sawSlack, sawPagerDuty bool
+	for _, body := range rec.bodies {
+		s := string(body)
+		if strings.Contains(s, `"text"`) {
+			sawSlack = true
+		}
+		if strings.Contains(s, `"routing_key":"rk"`) && strings.Contains(s, `"severity":"critical"`) {
+			sawPagerDuty = true
+		}
+	}
+	if !sawSlack || !sawPagerDuty {
+		t.Errorf("Missing sink payloads (slack=%v pagerduty=%v)", sawSlack, sawPagerDuty)
+	}
+}
+
+// waitFor polls a condition; alert handlers run on goroutines.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("Condition not met in time")
+}
@@ -0,0 +1,203 @@
+package monitoring
+
+import (
+	"time"
+)
+
+// SLA policies.
+//
+// MonitorConfig.SLAThreshold treats every case the same and counts wall
+// clock time. Real SLAs rarely do either: a P0 incident has a tighter
+// target than a P3, "4 business hours" excludes nights and weekends, and
+// the clock pauses while waiting on the customer. An SLAPolicy captures
+// those rules per case type; when none is registered the monitor falls
+// back to the flat threshold.
+
+// BusinessCalendar defines when the SLA clock runs: a daily working
+// window on a set of workdays, minus holidays. Times outside the window
+// do not count toward elapsed SLA time.
+type BusinessCalendar struct {
+	OpenHour  int                   // working window start, 0-23
+	CloseHour int                   // working window end, 1-24
+	Workdays  map[time.Weekday]bool // days the window applies
+	Holidays  map[string]bool       // dates in "2006-01-02" form, always closed
+}
+
+// NewBusinessCalendar creates a Monday-Friday calendar with the given
+// daily working window (hours in local time, e.g. 9 and 17 for 9am-5pm).
+func NewBusinessCalendar(openHour, closeHour int) *BusinessCalendar {
+	return &BusinessCalendar{
+		OpenHour:  openHour,
+		CloseHour: closeHour,
+		Workdays: map[time.Weekday]bool{
+			time.Monday:    true,
+			time.Tuesday:   true,
+			time.Wednesday: true,
+			time.Thursday:  true,
+			time.Friday:    true,
+		},
+		Holidays: make(map[string]bool),
+	}
+}
+
+// WithWorkdays replaces the set of workdays.
+func (cal *BusinessCalendar) WithWorkdays(days ...time.Weekday) *BusinessCalendar {
+	cal.Workdays = make(map[time.Weekday]bool, len(days))
+	for _, d := range days {
+		cal.Workdays[d] = true
+	}
+	return cal
+}
+
+// WithHolidays marks dates ("2006-01-02") as closed.
+func (cal *BusinessCalendar) WithHolidays(dates ...string) *BusinessCalendar {
+	for _, d := range dates {
+		cal.Holidays[d] = true
+	}
+	return cal
+}
+
+// workday reports whether the calendar is open at all on t's date.
+func (cal *BusinessCalendar) workday(t time.Time) bool {
+	return cal.Workdays[t.Weekday()] && !cal.Holidays[t.Format("2006-01-02")]
+}
+
+// Elapsed returns the business time between two instants: the overlap of
+// [from, to] with the working windows of the days in between.
+func (cal *BusinessCalendar) Elapsed(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	var total time.Duration
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for !day.After(to) {
+		if cal.workday(day) {
+			opens := day.Add(time.Duration(cal.OpenHour) * time.Hour)
+			closes := day.Add(time.Duration(cal.CloseHour) * time.Hour)
+			s, e := from, to
+			if opens.After(s) {
+				s = opens
+			}
+			if closes.Before(e) {
+				e = closes
+			}
+			if e.After(s) {
+				total += e.Sub(s)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return total
+}
+
+// Deadline returns the instant by which the given amount of business
+// time has elapsed, starting from start. A calendar that never opens
+// falls back to wall-clock addition.
+func (cal *BusinessCalendar) Deadline(start time.Time, d time.Duration) time.Time {
+	remaining := d
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for i := 0; i < 3700; i++ { // ~10 years of days; guards empty calendars
+		if cal.workday(day) {
+			opens := day.Add(time.Duration(cal.OpenHour) * time.Hour)
+			closes := day.Add(time.Duration(cal.CloseHour) * time.Hour)
+			s := opens
+			if start.After(s) {
+				s = start
+			}
+			if s.Before(closes) {
+				avail := closes.Sub(s)
+				if avail >= remaining {
+					return s.Add(remaining)
+				}
+				remaining -= avail
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return start.Add(d)
+}
+
+// SLAPolicy defines the SLA rules for one case type.
+type SLAPolicy struct {
+	Warning  time.Duration     // elapsed + predicted time beyond this raises a delayed warning
+	Breach   time.Duration     // beyond this raises an SLA violation
+	Calendar *BusinessCalendar // nil counts wall-clock time
+	// PauseActivities stops the SLA clock while the case's most recent
+	// activity is one of these (e.g. waiting on the customer).
+	PauseActivities []string
+}
+
+// paused reports whether the SLA clock stops during an activity.
+func (p *SLAPolicy) paused(activity string) bool {
+	for _, a := range p.PauseActivities {
+		if a == activity {
+			return true
+		}
+	}
+	return false
+}
+
+// elapsedFor returns the SLA time a case has consumed by now: the
+// calendar time of every history segment whose activity does not pause
+// the clock.
+func (p *SLAPolicy) elapsedFor(c *Case, now time.Time) time.Duration {
+	measure := func(from, to time.Time) time.Duration {
+		if p.Calendar != nil {
+			return p.Calendar.Elapsed(from, to)
+		}
+		if !to.After(from) {
+			return 0
+		}
+		return to.Sub(from)
+	}
+
+	var total time.Duration
+	prev := c.StartTime
+	activity := "" // clock runs until the first event
+	for _, ev := range c.History {
+		if !p.paused(activity) {
+			total += measure(prev, ev.Timestamp)
+		}
+		prev = ev.Timestamp
+		activity = ev.Activity
+	}
+	if !p.paused(activity) {
+		total += measure(prev, now)
+	}
+	return total
+}
+
+// WithSLAPolicy registers an SLA policy for a case type. The empty type
+// sets the default policy for untyped cases and unregistered types;
+// without one the monitor derives a flat policy from SLAThreshold.
+func (m *Monitor) WithSLAPolicy(caseType string, policy SLAPolicy) *Monitor {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.slaPolicies == nil {
+		m.slaPolicies = make(map[string]*SLAPolicy)
+	}
+	p := policy
+	m.slaPolicies[caseType] = &p
+	return m
+}
+
+// policyFor resolves the SLA policy for a case: the one registered for
+// its type, else the default policy, else a flat policy derived from
+// MonitorConfig.SLAThreshold (warning at 80%). Nil means no SLA applies.
+func (m *Monitor) policyFor(c *Case) *SLAPolicy {
+	if c.Type != "" {
+		if p, ok := m.slaPolicies[c.Type]; ok {
+			return p
+		}
+	}
+	if p, ok := m.slaPolicies[""]; ok {
+		return p
+	}
+	if m.config.SLAThreshold > 0 {
+		return &SLAPolicy{
+			Warning: m.config.SLAThreshold * 4 / 5,
+			Breach:  m.config.SLAThreshold,
+		}
+	}
+	return nil
+}
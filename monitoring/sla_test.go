@@ -0,0 +1,161 @@
+package monitoring
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBusinessCalendarElapsed(t *testing.T) {
+	cal := NewBusinessCalendar(9, 17)
+
+	// Monday 2026-03-02 10:00 to 14:00 — fully inside the window.
+	mon10 := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	if got := cal.Elapsed(mon10, mon10.Add(4*time.Hour)); got != 4*time.Hour {
+		t.Errorf("Expected 4h inside the window, got %v", got)
+	}
+
+	// Monday 16:00 to Tuesday 10:00 — one hour Monday, one hour Tuesday.
+	mon16 := time.Date(2026, 3, 2, 16, 0, 0, 0, time.UTC)
+	tue10 := time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC)
+	if got := cal.Elapsed(mon16, tue10); got != 2*time.Hour {
+		t.Errorf("Expected 2h across the overnight gap, got %v", got)
+	}
+
+	// Friday 16:00 to Monday 10:00 — the weekend does not count.
+	fri16 := time.Date(2026, 3, 6, 16, 0, 0, 0, time.UTC)
+	nextMon10 := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	if got := cal.Elapsed(fri16, nextMon10); got != 2*time.Hour {
+		t.Errorf("Expected 2h across the weekend, got %v", got)
+	}
+
+	// Holidays are closed.
+	cal.WithHolidays("2026-03-03")
+	if got := cal.Elapsed(mon16, tue10); got != time.Hour {
+		t.Errorf("Expected 1h with Tuesday a holiday, got %v", got)
+	}
+}
+
+func TestBusinessCalendarDeadline(t *testing.T) {
+	cal := NewBusinessCalendar(9, 17)
+
+	// 4 business hours from Friday 15:00 lands Monday 11:00.
+	fri15 := time.Date(2026, 3, 6, 15, 0, 0, 0, time.UTC)
+	want := time.Date(2026, 3, 9, 11, 0, 0, 0, time.UTC)
+	if got := cal.Deadline(fri15, 4*time.Hour); !got.Equal(want) {
+		t.Errorf("Expected deadline %v, got %v", want, got)
+	}
+
+	// Starting outside the window, the clock begins at the next opening.
+	sat := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
+	want = time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	if got := cal.Deadline(sat, time.Hour); !got.Equal(want) {
+		t.Errorf("Expected deadline %v, got %v", want, got)
+	}
+}
+
+func TestSLAPolicyPauseActivities(t *testing.T) {
+	policy := &SLAPolicy{
+		Breach:          4 * time.Hour,
+		PauseActivities: []string{"waiting_on_customer"},
+	}
+
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	c := &Case{
+		ID:        "c1",
+		StartTime: start,
+		History: []Event{
+			{Activity: "waiting_on_customer", Timestamp: start.Add(time.Hour)},
+			{Activity: "investigate", Timestamp: start.Add(3 * time.Hour)},
+		},
+	}
+
+	// 1h running, 2h paused, 1h running again.
+	if got := policy.elapsedFor(c, start.Add(4*time.Hour)); got != 2*time.Hour {
+		t.Errorf("Expected 2h of SLA time with the pause excluded, got %v", got)
+	}
+
+	// The clock stays stopped while the case is still waiting.
+	c.History = c.History[:1]
+	if got := policy.elapsedFor(c, start.Add(10*time.Hour)); got != time.Hour {
+		t.Errorf("Expected the clock frozen at 1h, got %v", got)
+	}
+}
+
+func TestSLAPolicyRouting(t *testing.T) {
+	m := typedTestMonitor()
+	m.WithSLAPolicy("P0", SLAPolicy{Warning: 15 * time.Minute, Breach: time.Hour}).
+		WithSLAPolicy("", SLAPolicy{Warning: 2 * time.Hour, Breach: 8 * time.Hour})
+
+	now := time.Now()
+	m.StartTypedCase("p0", "P0", now)
+	m.StartTypedCase("p3", "P3", now)
+
+	c0, _ := m.GetCase("p0")
+	if p := m.policyFor(c0); p.Breach != time.Hour {
+		t.Errorf("Expected the P0 policy, got breach %v", p.Breach)
+	}
+	c3, _ := m.GetCase("p3")
+	if p := m.policyFor(c3); p.Breach != 8*time.Hour {
+		t.Errorf("Expected the default policy, got breach %v", p.Breach)
+	}
+
+	// Without any registered policy the flat threshold still applies.
+	flat := typedTestMonitor()
+	flat.StartCase("c", now)
+	fc, _ := flat.GetCase("c")
+	p := flat.policyFor(fc)
+	if p == nil || p.Breach != flat.config.SLAThreshold {
+		t.Errorf("Expected a policy derived from SLAThreshold, got %+v", p)
+	}
+}
+
+func TestSLABreachAlertUsesPolicy(t *testing.T) {
+	m := typedTestMonitor()
+	m.config.EnableAlerts = true
+	// A breach threshold below the ~2min predicted completion time.
+	m.WithSLAPolicy("P0", SLAPolicy{Warning: 30 * time.Second, Breach: time.Minute})
+
+	alerts := make(chan Alert, 10)
+	m.AddAlertHandler(func(a Alert) { alerts <- a })
+
+	m.StartTypedCase("p0", "P0", time.Now())
+	pred, err := m.PredictCompletion("p0")
+	if err != nil {
+		t.Fatalf("PredictCompletion failed: %v", err)
+	}
+	if pred.RiskScore < 0.9 {
+		t.Errorf("Expected high risk against the tight policy, got %f", pred.RiskScore)
+	}
+
+	select {
+	case a := <-alerts:
+		if a.Type != AlertTypeSLAViolation {
+			t.Errorf("Expected SLA violation alert, got %s", a.Type)
+		}
+		if a.Threshold != time.Minute {
+			t.Errorf("Expected the policy breach threshold, got %v", a.Threshold)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected an SLA violation alert")
+	}
+}
+
+func TestPredictionRespectsCalendar(t *testing.T) {
+	m := typedTestMonitor()
+	cal := NewBusinessCalendar(0, 24).WithWorkdays() // never open
+	m.WithSLAPolicy("", SLAPolicy{Breach: 4 * time.Hour, Calendar: cal})
+
+	m.StartCase("c", time.Now().Add(-8*time.Hour))
+	c, _ := m.GetCase("c")
+	p := m.policyFor(c)
+
+	// The calendar never opens, so the 8 wall-clock hours count for
+	// nothing against the 4 business hour target.
+	if got := p.elapsedFor(c, time.Now()); got != 0 {
+		t.Errorf("Expected zero elapsed business time, got %v", got)
+	}
+	wall := &SLAPolicy{Breach: 4 * time.Hour}
+	if got := wall.elapsedFor(c, time.Now()); got < 7*time.Hour {
+		t.Errorf("Expected ~8h of wall-clock time, got %v", got)
+	}
+}
@@ -0,0 +1,134 @@
+package monitoring
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/statemachine"
+)
+
+// StateSLA sets a maximum dwell time for a chart state. When the observed
+// machine stays in the state longer than MaxDuration, the monitor raises an
+// alert at the given severity.
+type StateSLA struct {
+	Region      string        // region to watch (e.g. "state")
+	State       string        // state name within the region
+	MaxDuration time.Duration // maximum time allowed in this state
+	Severity    AlertSeverity // severity of the raised alert (default warning)
+}
+
+// MachineObserver couples a statemachine.Machine to the Monitor: state
+// changes appear as events on the case timeline, and per-state SLAs
+// generate alerts through the monitor's normal alert handlers.
+type MachineObserver struct {
+	monitor *Monitor
+	machine *statemachine.Machine
+	caseID  string
+	slas    []StateSLA
+
+	lastState map[string]string    // region -> last observed state path
+	enteredAt map[string]time.Time // region -> when the current state was entered
+	alerted   map[string]bool      // region:state -> SLA alert already sent
+}
+
+// ObserveMachine registers a state machine with the monitor under the given
+// case ID. The returned observer records state changes on each Poll (or
+// SendEvent) call. Pass one or more StateSLA values to get max-time-in-state
+// alerting.
+func (m *Monitor) ObserveMachine(caseID string, machine *statemachine.Machine, startTime time.Time, slas ...StateSLA) (*MachineObserver, error) {
+	if err := m.StartCase(caseID, startTime); err != nil {
+		return nil, err
+	}
+
+	o := &MachineObserver{
+		monitor:   m,
+		machine:   machine,
+		caseID:    caseID,
+		slas:      slas,
+		lastState: make(map[string]string),
+		enteredAt: make(map[string]time.Time),
+		alerted:   make(map[string]bool),
+	}
+
+	// Record the initial configuration so the timeline starts complete.
+	for regionName := range machine.GetChart().Regions {
+		full := machine.FullState(regionName)
+		if full == "" {
+			continue
+		}
+		o.lastState[regionName] = full
+		o.enteredAt[regionName] = startTime
+		m.RecordEvent(caseID, regionName+":"+full, startTime, "")
+	}
+	return o, nil
+}
+
+// SendEvent forwards an event to the machine and then records any resulting
+// state changes with the monitor, stamped at now.
+func (o *MachineObserver) SendEvent(event string, now time.Time) bool {
+	fired := o.machine.SendEvent(event)
+	o.Poll(now)
+	return fired
+}
+
+// Poll compares the machine's current configuration with the last observed
+// one, records a timeline event for every changed region, and checks
+// state-dwell SLAs. Call it after driving the machine directly, or on a
+// timer for SLA checking alone.
+func (o *MachineObserver) Poll(now time.Time) {
+	for regionName := range o.machine.GetChart().Regions {
+		full := o.machine.FullState(regionName)
+		if full == "" {
+			continue
+		}
+		if full != o.lastState[regionName] {
+			o.lastState[regionName] = full
+			o.enteredAt[regionName] = now
+			// A state change resets any pending SLA alert for the region.
+			for key := range o.alerted {
+				if len(key) > len(regionName) && key[:len(regionName)] == regionName {
+					delete(o.alerted, key)
+				}
+			}
+			o.monitor.RecordEvent(o.caseID, regionName+":"+full, now, "")
+		}
+	}
+	o.checkSLAs(now)
+}
+
+// checkSLAs raises an alert for each state whose dwell time exceeds its SLA.
+func (o *MachineObserver) checkSLAs(now time.Time) {
+	for _, sla := range o.slas {
+		current := o.machine.State(sla.Region)
+		if current != sla.State {
+			continue
+		}
+		key := sla.Region + ":" + sla.State
+		if o.alerted[key] {
+			continue
+		}
+		dwell := now.Sub(o.enteredAt[sla.Region])
+		if dwell <= sla.MaxDuration {
+			continue
+		}
+		severity := sla.Severity
+		if severity == "" {
+			severity = SeverityWarning
+		}
+		o.alerted[key] = true
+		o.monitor.triggerAlert(Alert{
+			Timestamp: now,
+			CaseID:    o.caseID,
+			Type:      AlertTypeStuck,
+			Severity:  severity,
+			Message: fmt.Sprintf("State %s:%s active for %v (SLA %v)",
+				sla.Region, sla.State, dwell.Round(time.Second), sla.MaxDuration),
+			Threshold: sla.MaxDuration,
+		})
+	}
+}
+
+// Complete marks the observed case as finished.
+func (o *MachineObserver) Complete(now time.Time) error {
+	return o.monitor.CompleteCase(o.caseID, now)
+}
@@ -0,0 +1,127 @@
+package monitoring
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/statemachine"
+)
+
+func newLightMachine() *statemachine.Machine {
+	chart := statemachine.NewChart("light").
+		Region("state").
+		State("red").Initial().
+		State("green").
+		State("yellow").
+		EndRegion().
+		When("timer").In("state:red").GoTo("state:green").
+		When("timer").In("state:green").GoTo("state:yellow").
+		When("timer").In("state:yellow").GoTo("state:red").
+		Build()
+	return statemachine.NewMachine(chart)
+}
+
+func TestObserveMachineTimeline(t *testing.T) {
+	m := newConnectorMonitor()
+	machine := newLightMachine()
+	start := time.Now()
+
+	o, err := m.ObserveMachine("sm1", machine, start)
+	if err != nil {
+		t.Fatalf("ObserveMachine failed: %v", err)
+	}
+
+	c, ok := m.GetCase("sm1")
+	if !ok {
+		t.Fatal("Observed case not started")
+	}
+	if len(c.History) != 1 || c.History[0].Activity != "state:red" {
+		t.Errorf("Initial configuration not recorded: %+v", c.History)
+	}
+
+	if fired := o.SendEvent("timer", start.Add(time.Minute)); !fired {
+		t.Fatal("timer event should fire")
+	}
+	c, _ = m.GetCase("sm1")
+	if len(c.History) != 2 || c.History[1].Activity != "state:green" {
+		t.Errorf("State change not recorded: %+v", c.History)
+	}
+
+	// Polling without a state change adds nothing to the timeline.
+	o.Poll(start.Add(2 * time.Minute))
+	c, _ = m.GetCase("sm1")
+	if len(c.History) != 2 {
+		t.Errorf("Poll without change grew the timeline: %+v", c.History)
+	}
+
+	if err := o.Complete(start.Add(3 * time.Minute)); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if _, ok := m.GetCase("sm1"); ok {
+		t.Error("Complete should finish the case")
+	}
+	if stats := m.GetStatistics(); stats.CompletedCases != 1 {
+		t.Errorf("Completed cases: %d", stats.CompletedCases)
+	}
+}
+
+func TestStateSLAAlertsOnceAndClearsOnExit(t *testing.T) {
+	m := newConnectorMonitor()
+	var mu sync.Mutex
+	var alerts []Alert
+	m.AddAlertHandler(func(a Alert) {
+		mu.Lock()
+		defer mu.Unlock()
+		alerts = append(alerts, a)
+	})
+
+	machine := newLightMachine()
+	start := time.Now()
+	sla := StateSLA{Region: "state", State: "red", MaxDuration: time.Minute}
+	o, err := m.ObserveMachine("sm2", machine, start, sla)
+	if err != nil {
+		t.Fatalf("ObserveMachine failed: %v", err)
+	}
+
+	// Within the SLA: no alert.
+	o.Poll(start.Add(30 * time.Second))
+	if n := m.GetStatistics().TotalAlerts; n != 0 {
+		t.Errorf("Alert before the deadline: %d", n)
+	}
+
+	// Past the deadline: exactly one alert, repeated polls do not re-fire.
+	o.Poll(start.Add(2 * time.Minute))
+	o.Poll(start.Add(3 * time.Minute))
+	if n := m.GetStatistics().TotalAlerts; n != 1 {
+		t.Errorf("Breach should alert exactly once, got %d", n)
+	}
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(alerts) == 1
+	})
+	mu.Lock()
+	if alerts[0].Type != AlertTypeStuck || alerts[0].Severity != SeverityWarning || alerts[0].CaseID != "sm2" {
+		t.Errorf("Unexpected alert: %+v", alerts[0])
+	}
+	mu.Unlock()
+
+	// Leaving the state clears the breach; cycle back into red.
+	o.SendEvent("timer", start.Add(4*time.Minute)) // -> green
+	o.SendEvent("timer", start.Add(5*time.Minute)) // -> yellow
+	reentry := start.Add(6 * time.Minute)
+	o.SendEvent("timer", reentry) // -> red again
+
+	// The dwell clock restarts at re-entry.
+	o.Poll(reentry.Add(30 * time.Second))
+	if n := m.GetStatistics().TotalAlerts; n != 1 {
+		t.Errorf("Alert fired before the new deadline: %d", n)
+	}
+
+	// A second breach after re-entry alerts again.
+	o.Poll(reentry.Add(2 * time.Minute))
+	if n := m.GetStatistics().TotalAlerts; n != 2 {
+		t.Errorf("Re-entered state should alert again, got %d", n)
+	}
+}
@@ -0,0 +1,16 @@
+package monitoring
+
+import (
+	"github.com/pflow-xyz/go-pflow/telemetry"
+)
+
+// tracer emits spans around event recording and prediction runs. Spans
+// are no-ops until the application installs an OpenTelemetry SDK provider.
+var tracer = telemetry.Tracer("monitoring")
+
+// WithLogger plugs a structured logger into the monitor. Alerts and
+// prediction updates are logged through it; the default discards them.
+func (m *Monitor) WithLogger(logger telemetry.Logger) *Monitor {
+	m.logger = logger
+	return m
+}
@@ -0,0 +1,55 @@
+package monitoring
+
+import (
+	"sync"
+	"testing"
+)
+
+// recordingLogger captures structured log entries for assertions.
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+type logEntry struct {
+	level   string
+	msg     string
+	keyvals []any
+}
+
+func (l *recordingLogger) record(level, msg string, keyvals []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level, msg, keyvals})
+}
+
+func (l *recordingLogger) Debug(msg string, kv ...any) { l.record("debug", msg, kv) }
+func (l *recordingLogger) Info(msg string, kv ...any)  { l.record("info", msg, kv) }
+func (l *recordingLogger) Warn(msg string, kv ...any)  { l.record("warn", msg, kv) }
+func (l *recordingLogger) Error(msg string, kv ...any) { l.record("error", msg, kv) }
+
+func (l *recordingLogger) levels() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]string, len(l.entries))
+	for i, e := range l.entries {
+		out[i] = e.level
+	}
+	return out
+}
+
+func TestAlertsRouteToLogger(t *testing.T) {
+	config := DefaultMonitorConfig()
+	config.EnablePredictions = false
+
+	logger := &recordingLogger{}
+	m := NewMonitor(newTestNet(), nil, config).WithLogger(logger)
+
+	m.triggerAlert(Alert{CaseID: "c1", Type: AlertTypeStuck, Severity: SeverityWarning})
+	m.triggerAlert(Alert{CaseID: "c1", Type: AlertTypeSLAViolation, Severity: SeverityCritical})
+
+	levels := logger.levels()
+	if len(levels) != 2 || levels[0] != "warn" || levels[1] != "error" {
+		t.Errorf("Expected [warn error] alert entries, got %v", levels)
+	}
+}
@@ -7,12 +7,17 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pflow-xyz/go-pflow/mining"
 	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/storage"
+	"github.com/pflow-xyz/go-pflow/telemetry"
 )
 
 // Case represents an active process instance being monitored.
 type Case struct {
 	ID              string                 // Unique case identifier
+	Type            string                 // Case type for model routing (see WithModel)
+	Priority        Priority               // Attention level, adjustable via Reprioritize
 	StartTime       time.Time              // When the case started
 	CurrentActivity string                 // Current activity (last observed)
 	LastEventTime   time.Time              // Time of last event
@@ -39,6 +44,15 @@ type Prediction struct {
 	Confidence         float64        // Confidence score (0-1)
 	NextActivities     []NextActivity // Likely next activities
 	RiskScore          float64        // Risk of SLA violation (0-1)
+
+	// Quantiles holds Monte Carlo completion quantiles when
+	// MonitorConfig.QuantileSamples is set (see quantiles.go).
+	Quantiles *QuantilePrediction
+
+	// Explanation breaks the risk score down into past delays and
+	// remaining bottlenecks when MonitorConfig.EnableExplanations is
+	// set (see explain.go).
+	Explanation *RiskExplanation
 }
 
 // NextActivity represents a predicted next activity.
@@ -68,6 +82,7 @@ const (
 	AlertTypeStuck          AlertType = "stuck"
 	AlertTypeUnexpectedPath AlertType = "unexpected_path"
 	AlertTypeResourceIssue  AlertType = "resource_issue"
+	AlertTypeConceptDrift   AlertType = "concept_drift"
 )
 
 // AlertSeverity indicates alert importance.
@@ -90,6 +105,9 @@ type MonitorConfig struct {
 	ConfidenceLevel    float64       // Minimum confidence for predictions
 	EnablePredictions  bool          // Enable/disable predictions
 	EnableAlerts       bool          // Enable/disable alerting
+	EnableExplanations bool          // Attach risk explanations to predictions
+	Sinks              *AlertSinks   // Built-in alert delivery (see sinks.go)
+	QuantileSamples    int           // Monte Carlo rollouts per prediction (0 = off)
 }
 
 // DefaultMonitorConfig returns sensible defaults.
@@ -109,6 +127,8 @@ type Statistics struct {
 	TotalCases            int
 	ActiveCases           int
 	CompletedCases        int
+	CasesByType           map[string]int // total cases started per type
+	ActiveCasesByType     map[string]int // currently active cases per type
 	TotalAlerts           int
 	AlertsBySeverity      map[AlertSeverity]int
 	AlertsByType          map[AlertType]int
@@ -123,11 +143,25 @@ type Monitor struct {
 	config    MonitorConfig
 	predictor *Predictor // ODE-based predictor
 
+	models           map[string]*caseModel        // per-case-type models (see WithModel)
+	slaPolicies      map[string]*SLAPolicy        // per-case-type SLA rules (see WithSLAPolicy)
+	priorityProfiles map[Priority]PriorityProfile // per-priority tuning (see WithPriorityProfile)
+
 	cases map[string]*Case // Active cases
 	mu    sync.RWMutex     // Protects cases map
 
-	handlers []AlertHandler
-	stats    Statistics
+	handlers       []AlertHandler
+	stats          Statistics
+	persist        storage.Store  // optional snapshot store (see WithPersistence)
+	activityCounts map[string]int // events recorded per activity, for metrics
+	sinkRouters    []*alertRouter // built-in alert sinks (see WithAlertSinks)
+	drift          *DriftDetector // concept drift detection (see WithDriftDetection)
+	variants       *mining.ActivityPredictor // variant-based next-activity model (see WithVariantPredictions)
+
+	resourcePools map[string]int            // declared capacity per resource
+	resourceUsage map[string]*resourceUsage // observed service times and alert state
+
+	logger telemetry.Logger // structured logger (see WithLogger)
 
 	running bool
 	stopCh  chan struct{}
@@ -136,7 +170,7 @@ type Monitor struct {
 // NewMonitor creates a new process monitor with learned model parameters.
 func NewMonitor(net *petri.PetriNet, rates map[string]float64, config MonitorConfig) *Monitor {
 	predictor := NewPredictor(net, rates)
-	return &Monitor{
+	m := &Monitor{
 		net:       net,
 		rates:     rates,
 		config:    config,
@@ -144,11 +178,18 @@ func NewMonitor(net *petri.PetriNet, rates map[string]float64, config MonitorCon
 		cases:     make(map[string]*Case),
 		handlers:  make([]AlertHandler, 0),
 		stats: Statistics{
+			CasesByType:      make(map[string]int),
 			AlertsBySeverity: make(map[AlertSeverity]int),
 			AlertsByType:     make(map[AlertType]int),
 		},
-		stopCh: make(chan struct{}),
+		activityCounts: make(map[string]int),
+		logger:         telemetry.Nop(),
+		stopCh:         make(chan struct{}),
 	}
+	if config.Sinks != nil {
+		m.WithAlertSinks(*config.Sinks)
+	}
+	return m
 }
 
 // AddAlertHandler registers a function to be called on alerts.
@@ -183,6 +224,12 @@ func (m *Monitor) GetStatistics() Statistics {
 
 	stats := m.stats
 	stats.ActiveCases = len(m.cases)
+	stats.ActiveCasesByType = make(map[string]int)
+	for _, c := range m.cases {
+		if c.Type != "" {
+			stats.ActiveCasesByType[c.Type]++
+		}
+	}
 	return stats
 }
 
@@ -193,6 +240,13 @@ func (m *Monitor) triggerAlert(alert Alert) {
 	m.stats.AlertsBySeverity[alert.Severity]++
 	m.stats.AlertsByType[alert.Type]++
 
+	// Route to the structured logger alongside the registered handlers
+	if alert.Severity == SeverityCritical {
+		m.logger.Error("alert", "case", alert.CaseID, "type", alert.Type, "message", alert.Message)
+	} else {
+		m.logger.Warn("alert", "case", alert.CaseID, "type", alert.Type, "message", alert.Message)
+	}
+
 	// Call handlers
 	for _, handler := range m.handlers {
 		go handler(alert) // Non-blocking
@@ -0,0 +1,139 @@
+// Separable CMA-ES: covariance matrix adaptation evolution strategy with a
+// diagonal covariance, which avoids eigendecomposition and scales fine for
+// the low-dimensional capacity-planning problems this package targets.
+// Follows Hansen's sep-CMA-ES parameterization.
+
+package optimize
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// cmaes minimizes f starting from x0 within the box [lo, hi]. It returns
+// the best point found, its value, generations used, and whether the run
+// converged before exhausting the budget.
+func cmaes(f func([]float64) float64, x0, lo, hi []float64, maxIters int, tol float64, seed int64) ([]float64, float64, int, bool) {
+	n := len(x0)
+	rng := rand.New(rand.NewSource(seed))
+
+	// Population and recombination weights
+	lambda := 4 + int(3*math.Log(float64(n)))
+	mu := lambda / 2
+	weights := make([]float64, mu)
+	wSum := 0.0
+	for i := range weights {
+		weights[i] = math.Log(float64(mu)+0.5) - math.Log(float64(i+1))
+		wSum += weights[i]
+	}
+	wSqSum := 0.0
+	for i := range weights {
+		weights[i] /= wSum
+		wSqSum += weights[i] * weights[i]
+	}
+	muEff := 1.0 / wSqSum
+
+	// Adaptation constants
+	cc := (4.0 + muEff/float64(n)) / (float64(n) + 4.0 + 2.0*muEff/float64(n))
+	cs := (muEff + 2.0) / (float64(n) + muEff + 5.0)
+	c1 := 2.0 / (math.Pow(float64(n)+1.3, 2) + muEff)
+	cmu := math.Min(1.0-c1, 2.0*(muEff-2.0+1.0/muEff)/(math.Pow(float64(n)+2.0, 2)+muEff))
+	damps := 1.0 + 2.0*math.Max(0, math.Sqrt((muEff-1.0)/float64(n+1))-1.0) + cs
+	chiN := math.Sqrt(float64(n)) * (1.0 - 1.0/(4.0*float64(n)) + 1.0/(21.0*float64(n*n)))
+
+	// Initial step size from the box extent
+	sigma := 0.0
+	for i := range lo {
+		sigma += hi[i] - lo[i]
+	}
+	sigma = 0.3 * sigma / float64(n)
+	if sigma <= 0 {
+		sigma = 0.3
+	}
+
+	mean := append([]float64(nil), x0...)
+	diag := make([]float64, n) // diagonal covariance
+	for i := range diag {
+		diag[i] = 1.0
+	}
+	ps := make([]float64, n)
+	pc := make([]float64, n)
+
+	best := append([]float64(nil), x0...)
+	bestVal := f(best)
+
+	type candidate struct {
+		x, z  []float64
+		value float64
+	}
+
+	for gen := 0; gen < maxIters; gen++ {
+		pop := make([]candidate, lambda)
+		for k := range pop {
+			z := make([]float64, n)
+			x := make([]float64, n)
+			for i := range z {
+				z[i] = rng.NormFloat64()
+				x[i] = mean[i] + sigma*math.Sqrt(diag[i])*z[i]
+			}
+			pop[k] = candidate{x: x, z: z, value: f(x)}
+		}
+		sort.Slice(pop, func(i, j int) bool { return pop[i].value < pop[j].value })
+
+		if pop[0].value < bestVal {
+			bestVal = pop[0].value
+			copy(best, pop[0].x)
+		}
+
+		// Recombine mean and the weighted average step
+		oldMean := append([]float64(nil), mean...)
+		zMean := make([]float64, n)
+		for i := 0; i < n; i++ {
+			mean[i] = 0
+			for k := 0; k < mu; k++ {
+				mean[i] += weights[k] * pop[k].x[i]
+				zMean[i] += weights[k] * pop[k].z[i]
+			}
+		}
+
+		// Evolution paths
+		psNorm := 0.0
+		for i := 0; i < n; i++ {
+			ps[i] = (1.0-cs)*ps[i] + math.Sqrt(cs*(2.0-cs)*muEff)*zMean[i]
+			psNorm += ps[i] * ps[i]
+		}
+		psNorm = math.Sqrt(psNorm)
+
+		hsig := 0.0
+		if psNorm/math.Sqrt(1.0-math.Pow(1.0-cs, 2.0*float64(gen+1)))/chiN < 1.4+2.0/float64(n+1) {
+			hsig = 1.0
+		}
+		for i := 0; i < n; i++ {
+			pc[i] = (1.0-cc)*pc[i] + hsig*math.Sqrt(cc*(2.0-cc)*muEff)*(mean[i]-oldMean[i])/sigma
+		}
+
+		// Diagonal covariance update
+		for i := 0; i < n; i++ {
+			rankMu := 0.0
+			for k := 0; k < mu; k++ {
+				rankMu += weights[k] * pop[k].z[i] * pop[k].z[i]
+			}
+			diag[i] = (1.0-c1-cmu)*diag[i] +
+				c1*(pc[i]*pc[i]+(1.0-hsig)*cc*(2.0-cc)*diag[i]) +
+				cmu*rankMu*diag[i]
+			if diag[i] < 1e-20 {
+				diag[i] = 1e-20
+			}
+		}
+
+		// Step-size control
+		sigma *= math.Exp((cs / damps) * (psNorm/chiN - 1.0))
+
+		// Converged when the population value spread or step size collapses
+		if pop[lambda-1].value-pop[0].value < tol || sigma < 1e-12 {
+			return best, bestVal, gen + 1, true
+		}
+	}
+	return best, bestVal, maxIters, false
+}
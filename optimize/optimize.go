@@ -0,0 +1,274 @@
+// Package optimize searches over controllable Petri net parameters —
+// transition rates and initial markings, e.g. number of baristas or
+// restock rates — to maximize or minimize an objective computed from
+// simulation output. It reuses the Nelder-Mead and coordinate-descent
+// minimizers from the learn package and adds a separable CMA-ES variant
+// for rougher objective landscapes. Parameters are box-bounded, and
+// arbitrary feasibility constraints can reject combinations outright.
+package optimize
+
+import (
+	"fmt"
+
+	"github.com/pflow-xyz/go-pflow/learn"
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// Objective scores a simulation result; higher is better under Maximize,
+// lower under Minimize.
+type Objective func(sol *solver.Solution) float64
+
+// Constraint reports whether a parameter combination is feasible. The
+// map holds the decoded values of every controllable parameter by name.
+type Constraint func(params map[string]float64) bool
+
+// param is one controllable dimension: a rate or an initial token count,
+// with box bounds.
+type param struct {
+	name   string
+	isRate bool
+	min    float64
+	max    float64
+}
+
+// Problem configures an optimization over a net's parameters.
+type Problem struct {
+	net         *petri.PetriNet
+	state       map[string]float64
+	rates       map[string]float64
+	tspan       [2]float64
+	solverOpts  *solver.Options
+	params      []param
+	objective   Objective
+	maximize    bool
+	method      string
+	maxIters    int
+	tolerance   float64
+	seed        int64
+	constraints []Constraint
+}
+
+// New creates an optimization problem with baseline state and rates.
+func New(net *petri.PetriNet, state, rates map[string]float64) *Problem {
+	return &Problem{
+		net:        net,
+		state:      net.SetState(state),
+		rates:      net.SetRates(rates),
+		tspan:      [2]float64{0, 10},
+		solverOpts: solver.DefaultOptions(),
+		method:     "nelder-mead",
+		maxIters:   500,
+		tolerance:  1e-6,
+		seed:       1,
+	}
+}
+
+// WithTimeSpan sets the simulation time span.
+func (p *Problem) WithTimeSpan(t0, tf float64) *Problem {
+	p.tspan = [2]float64{t0, tf}
+	return p
+}
+
+// WithOptions sets the solver options.
+func (p *Problem) WithOptions(opts *solver.Options) *Problem {
+	p.solverOpts = opts
+	return p
+}
+
+// WithMethod selects the search method: "nelder-mead" (default),
+// "coordinate-descent", or "cma-es".
+func (p *Problem) WithMethod(method string) *Problem {
+	p.method = method
+	return p
+}
+
+// WithMaxIters sets the iteration budget.
+func (p *Problem) WithMaxIters(n int) *Problem {
+	p.maxIters = n
+	return p
+}
+
+// WithSeed sets the random seed for CMA-ES.
+func (p *Problem) WithSeed(seed int64) *Problem {
+	p.seed = seed
+	return p
+}
+
+// Rate makes a transition rate controllable within [min, max].
+func (p *Problem) Rate(transition string, min, max float64) *Problem {
+	p.params = append(p.params, param{name: transition, isRate: true, min: min, max: max})
+	return p
+}
+
+// Initial makes a place's initial token count controllable within [min, max].
+func (p *Problem) Initial(place string, min, max float64) *Problem {
+	p.params = append(p.params, param{name: place, min: min, max: max})
+	return p
+}
+
+// Maximize sets the objective to maximize.
+func (p *Problem) Maximize(obj Objective) *Problem {
+	p.objective = obj
+	p.maximize = true
+	return p
+}
+
+// Minimize sets the objective to minimize.
+func (p *Problem) Minimize(obj Objective) *Problem {
+	p.objective = obj
+	p.maximize = false
+	return p
+}
+
+// Subject adds a feasibility constraint; infeasible combinations are
+// penalized out of the search.
+func (p *Problem) Subject(c Constraint) *Problem {
+	p.constraints = append(p.constraints, c)
+	return p
+}
+
+// Result holds the best parameter combination found.
+type Result struct {
+	Params     map[string]float64 // best value per controllable parameter
+	Score      float64            // objective at the best parameters
+	Iterations int
+	Converged  bool
+}
+
+// infeasiblePenalty keeps rejected combinations finite so simplex
+// arithmetic stays well-behaved.
+const infeasiblePenalty = 1e12
+
+// Run searches for the best parameter combination.
+func (p *Problem) Run() (*Result, error) {
+	if p.objective == nil {
+		return nil, fmt.Errorf("no objective configured; use Maximize or Minimize")
+	}
+	if len(p.params) == 0 {
+		return nil, fmt.Errorf("no controllable parameters; use Rate or Initial")
+	}
+	for _, pr := range p.params {
+		if pr.isRate {
+			if _, ok := p.net.Transitions[pr.name]; !ok {
+				return nil, fmt.Errorf("controllable transition %q not in net", pr.name)
+			}
+		} else if _, ok := p.net.Places[pr.name]; !ok {
+			return nil, fmt.Errorf("controllable place %q not in net", pr.name)
+		}
+		if pr.min > pr.max {
+			return nil, fmt.Errorf("parameter %q has min %g > max %g", pr.name, pr.min, pr.max)
+		}
+	}
+
+	// Start from the baseline values, clamped into bounds.
+	x0 := make([]float64, len(p.params))
+	for i, pr := range p.params {
+		v := p.state[pr.name]
+		if pr.isRate {
+			v = p.rates[pr.name]
+		}
+		x0[i] = clamp(v, pr.min, pr.max)
+	}
+
+	f := p.penalizedObjective()
+
+	var best []float64
+	var bestVal float64
+	var iters int
+	var converged bool
+	switch p.method {
+	case "nelder-mead", "coordinate-descent":
+		fit, err := learn.Minimize(f, x0, &learn.FitOptions{
+			MaxIters:  p.maxIters,
+			Tolerance: p.tolerance,
+			Method:    p.method,
+			StepSize:  0.01,
+		})
+		if err != nil {
+			return nil, err
+		}
+		best, bestVal = fit.Params, fit.FinalLoss
+		iters, converged = fit.Iterations, fit.Converged
+	case "cma-es":
+		lo := make([]float64, len(p.params))
+		hi := make([]float64, len(p.params))
+		for i, pr := range p.params {
+			lo[i], hi[i] = pr.min, pr.max
+		}
+		best, bestVal, iters, converged = cmaes(f, x0, lo, hi, p.maxIters, p.tolerance, p.seed)
+	default:
+		return nil, fmt.Errorf("unknown optimization method: %s", p.method)
+	}
+
+	result := &Result{
+		Params:     p.decode(best),
+		Score:      bestVal,
+		Iterations: iters,
+		Converged:  converged,
+	}
+	if p.maximize {
+		result.Score = -result.Score
+	}
+	return result, nil
+}
+
+// penalizedObjective wraps simulation scoring as a minimization target:
+// values are clamped into bounds with a quadratic penalty for straying,
+// infeasible combinations get a flat penalty, and maximization flips sign.
+func (p *Problem) penalizedObjective() func([]float64) float64 {
+	return func(x []float64) float64 {
+		penalty := 0.0
+		for i, pr := range p.params {
+			if x[i] < pr.min {
+				penalty += (pr.min - x[i]) * (pr.min - x[i])
+			} else if x[i] > pr.max {
+				penalty += (x[i] - pr.max) * (x[i] - pr.max)
+			}
+		}
+
+		values := p.decode(x)
+		for _, c := range p.constraints {
+			if !c(values) {
+				return infeasiblePenalty + penalty
+			}
+		}
+
+		state := solver.CopyState(p.state)
+		rates := solver.CopyState(p.rates)
+		for _, pr := range p.params {
+			if pr.isRate {
+				rates[pr.name] = values[pr.name]
+			} else {
+				state[pr.name] = values[pr.name]
+			}
+		}
+
+		prob := solver.NewProblem(p.net, state, p.tspan, rates)
+		sol := solver.Solve(prob, solver.Tsit5(), p.solverOpts)
+		score := p.objective(sol)
+		if p.maximize {
+			score = -score
+		}
+		return score + penalty*infeasiblePenalty
+	}
+}
+
+// decode maps a search vector to named, bound-clamped parameter values.
+func (p *Problem) decode(x []float64) map[string]float64 {
+	values := make(map[string]float64, len(p.params))
+	for i, pr := range p.params {
+		values[pr.name] = clamp(x[i], pr.min, pr.max)
+	}
+	return values
+}
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
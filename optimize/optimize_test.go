@@ -0,0 +1,118 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// serviceNet is a simple service line: waiting customers are served into
+// done at a controllable rate.
+func serviceNet() *petri.PetriNet {
+	return petri.Build().
+		Chain(20, "waiting", "serve", "done").
+		Done()
+}
+
+func TestMaximizeRate(t *testing.T) {
+	net := serviceNet()
+	result, err := New(net, nil, nil).
+		WithTimeSpan(0, 5).
+		Rate("serve", 0.1, 2.0).
+		Maximize(func(sol *solver.Solution) float64 {
+			return sol.GetFinalState()["done"]
+		}).
+		Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Throughput is monotone in the service rate, so the optimum sits at
+	// the upper bound.
+	if math.Abs(result.Params["serve"]-2.0) > 0.05 {
+		t.Errorf("Expected serve near 2.0, got %f", result.Params["serve"])
+	}
+	if result.Score <= 0 {
+		t.Errorf("Expected positive throughput, got %f", result.Score)
+	}
+}
+
+func TestMinimizeWithTarget(t *testing.T) {
+	net := serviceNet()
+	// Find the service rate whose remaining queue at t=5 is closest to 5.
+	result, err := New(net, nil, nil).
+		WithTimeSpan(0, 5).
+		Rate("serve", 0.01, 3.0).
+		Minimize(func(sol *solver.Solution) float64 {
+			diff := sol.GetFinalState()["waiting"] - 5.0
+			return diff * diff
+		}).
+		Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Score > 0.1 {
+		t.Errorf("Expected near-zero residual, got %f", result.Score)
+	}
+}
+
+func TestConstraintRejectsRegion(t *testing.T) {
+	net := serviceNet()
+	result, err := New(net, nil, nil).
+		WithTimeSpan(0, 5).
+		Rate("serve", 0.1, 2.0).
+		Subject(func(params map[string]float64) bool {
+			return params["serve"] <= 1.0 // staffing cap
+		}).
+		Maximize(func(sol *solver.Solution) float64 {
+			return sol.GetFinalState()["done"]
+		}).
+		Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Params["serve"] > 1.05 {
+		t.Errorf("Expected constrained optimum at or below 1.0, got %f", result.Params["serve"])
+	}
+}
+
+func TestCMAES(t *testing.T) {
+	net := serviceNet()
+	result, err := New(net, nil, nil).
+		WithTimeSpan(0, 5).
+		WithMethod("cma-es").
+		WithMaxIters(100).
+		Rate("serve", 0.1, 2.0).
+		Maximize(func(sol *solver.Solution) float64 {
+			return sol.GetFinalState()["done"]
+		}).
+		Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if math.Abs(result.Params["serve"]-2.0) > 0.1 {
+		t.Errorf("Expected serve near 2.0, got %f", result.Params["serve"])
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	net := serviceNet()
+	if _, err := New(net, nil, nil).Rate("serve", 0, 1).Run(); err == nil {
+		t.Error("Expected error without objective")
+	}
+	obj := func(sol *solver.Solution) float64 { return 0 }
+	if _, err := New(net, nil, nil).Maximize(obj).Run(); err == nil {
+		t.Error("Expected error without parameters")
+	}
+	if _, err := New(net, nil, nil).Rate("bogus", 0, 1).Maximize(obj).Run(); err == nil {
+		t.Error("Expected error for unknown transition")
+	}
+	if _, err := New(net, nil, nil).Rate("serve", 2, 1).Maximize(obj).Run(); err == nil {
+		t.Error("Expected error for inverted bounds")
+	}
+	if _, err := New(net, nil, nil).Rate("serve", 0, 1).Maximize(obj).WithMethod("annealing").Run(); err == nil {
+		t.Error("Expected error for unknown method")
+	}
+}
@@ -94,6 +94,7 @@ func FromJSON(data []byte) (*petri.PetriNet, error) {
 				x := 0.0
 				y := 0.0
 				role := "default"
+				guardExpr := ""
 				var labelText *string
 				if tmap, ok := td.(map[string]interface{}); ok {
 					if r, ok := tmap["role"]; ok {
@@ -116,8 +117,13 @@ func FromJSON(data []byte) (*petri.PetriNet, error) {
 							labelText = &s
 						}
 					}
+					if g, ok := tmap["guard"]; ok {
+						if s, ok := g.(string); ok {
+							guardExpr = s
+						}
+					}
 				}
-				net.AddTransition(label, role, x, y, labelText)
+				net.AddTransition(label, role, x, y, labelText).Guard = guardExpr
 			}
 		}
 	}
@@ -153,7 +159,12 @@ func FromJSON(data []byte) (*petri.PetriNet, error) {
 					if weight == nil {
 						weight = []float64{1}
 					}
-					net.AddArc(source, target, weight, inhibit)
+					a := net.AddArc(source, target, weight, inhibit)
+					if v, ok := amap["expression"]; ok {
+						if s, ok := v.(string); ok {
+							a.Expression = s
+						}
+					}
 				}
 			}
 		}
@@ -206,6 +217,9 @@ func ToJSON(net *petri.PetriNet) ([]byte, error) {
 		if t.LabelText != nil {
 			tdata["label"] = *t.LabelText
 		}
+		if t.Guard != "" {
+			tdata["guard"] = t.Guard
+		}
 		transitions[label] = tdata
 	}
 	result["transitions"] = transitions
@@ -222,6 +236,9 @@ func ToJSON(net *petri.PetriNet) ([]byte, error) {
 		if a.InhibitTransition {
 			adata["inhibitTransition"] = true
 		}
+		if a.Expression != "" {
+			adata["expression"] = a.Expression
+		}
 		arcs = append(arcs, adata)
 	}
 	result["arcs"] = arcs
@@ -78,6 +78,101 @@ func (b *Builder) InhibitorArc(source, target string, weight float64) *Builder {
 	return b
 }
 
+// Guard attaches a guard expression to an existing transition. Builder
+// chains have no error channel, so invalid expressions panic; use
+// PetriNet.SetGuard for checked assignment.
+func (b *Builder) Guard(transition, expr string) *Builder {
+	if err := b.net.SetGuard(transition, expr); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Overflow sets the overflow policy of an existing place. Unknown places
+// panic, like Guard; use PetriNet.SetOverflowPolicy for checked
+// assignment.
+func (b *Builder) Overflow(place string, policy OverflowPolicy) *Builder {
+	if err := b.net.SetOverflowPolicy(place, policy); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Unit annotates an existing place with a unit of measure. Unknown
+// places panic, like Guard; use PetriNet.SetPlaceUnit for checked
+// assignment.
+func (b *Builder) Unit(place, unit string) *Builder {
+	if err := b.net.SetPlaceUnit(place, unit); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// RateUnit annotates an existing transition with the unit of its rate.
+// Unknown transitions panic; use PetriNet.SetRateUnit for checked
+// assignment.
+func (b *Builder) RateUnit(transition, unit string) *Builder {
+	if err := b.net.SetRateUnit(transition, unit); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Duration attaches a duration distribution to a transition for timed
+// simulation. Panics if the transition does not exist; use
+// PetriNet.SetDuration for a checked version.
+func (b *Builder) Duration(transition string, dist *Distribution) *Builder {
+	if err := b.net.SetDuration(transition, dist); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// FiringCost sets the cost charged per firing of a transition. Panics if
+// the transition does not exist; use PetriNet.SetFiringCost for a checked
+// version.
+func (b *Builder) FiringCost(transition string, cost float64) *Builder {
+	if err := b.net.SetFiringCost(transition, cost); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// HoldingCost sets the cost of holding one token in a place for one time
+// unit. Panics if the place does not exist; use PetriNet.SetHoldingCost
+// for a checked version.
+func (b *Builder) HoldingCost(place string, cost float64) *Builder {
+	if err := b.net.SetHoldingCost(place, cost); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// ExprArc adds an arc whose weight is an expression evaluated against the
+// current marking. Invalid expressions panic, like Guard.
+func (b *Builder) ExprArc(source, target, expr string) *Builder {
+	b.net.AddArc(source, target, 1.0, false)
+	if err := b.net.SetArcExpression(source, target, expr); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Export marks places or transitions as part of the net's interface.
+// Exported nodes are merged by label when nets are composed with
+// MergeExported; see Compose.
+func (b *Builder) Export(labels ...string) *Builder {
+	for _, label := range labels {
+		if p, ok := b.net.Places[label]; ok {
+			p.Exported = true
+		}
+		if t, ok := b.net.Transitions[label]; ok {
+			t.Exported = true
+		}
+	}
+	return b
+}
+
 // Flow adds bidirectional arcs for a simple flow pattern: place -> transition -> place.
 // This is a convenience for the common pattern of consuming from one place
 // and producing to another.
@@ -0,0 +1,65 @@
+package petri
+
+import "fmt"
+
+// Place capacities.
+//
+// A place with a capacity holds at most that many tokens. Examples like
+// the coffeeshop enforced maxima by hand (constants checked in
+// application code); setting an overflow policy on the place lets the
+// discrete simulator, the ODE solver, and reachability analysis respect
+// the capacity uniformly. Enforcement is opt-in per place because
+// existing models (the JSONLD exports, colored-token examples) carry
+// capacity values as annotations and must keep their semantics.
+
+// OverflowPolicy controls what happens when firing a transition would
+// push a place past its capacity.
+type OverflowPolicy int
+
+const (
+	// OverflowIgnore leaves the capacity advisory: nothing enforces it.
+	// The default, matching models that predate enforcement.
+	OverflowIgnore OverflowPolicy = iota
+
+	// OverflowBlock disables any transition whose firing would exceed
+	// the place's capacity, like an implicit inhibitor.
+	OverflowBlock
+
+	// OverflowDiscard lets the transition fire and drops the excess,
+	// leaving the place exactly at capacity.
+	OverflowDiscard
+)
+
+// GetCapacity returns the sum of the place's capacity across colors.
+// Zero means unlimited.
+func (p *Place) GetCapacity() float64 {
+	sum := 0.0
+	for _, v := range p.Capacity {
+		sum += v
+	}
+	return sum
+}
+
+// SetOverflowPolicy sets how a place handles firings that would exceed
+// its capacity. The policy only matters for places with a capacity.
+func (n *PetriNet) SetOverflowPolicy(place string, policy OverflowPolicy) error {
+	p, ok := n.Places[place]
+	if !ok {
+		return fmt.Errorf("place not found: %s", place)
+	}
+	p.Overflow = policy
+	return nil
+}
+
+// EnforcesCapacities reports whether any place has both a capacity and a
+// non-advisory overflow policy. The ODE solver uses this to route
+// capacity-enforcing nets through the marking-dependent derivative
+// function, like HasDynamics does for guards.
+func (n *PetriNet) EnforcesCapacities() bool {
+	for _, p := range n.Places {
+		if p.Overflow != OverflowIgnore && p.GetCapacity() > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,333 @@
+// Composition of Petri nets. Large systems are modeled as separate component
+// nets (an inventory net, a workflow net per case type, equipment state
+// machines) and merged into one analyzable model. Components are namespaced
+// on merge; coupling happens through shared places (resource fusion),
+// synchronized transitions (event fusion), or the net's declared interface
+// of exported nodes.
+
+package petri
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Composition assembles component nets into a single Petri net.
+//
+// Example:
+//
+//	net, err := petri.Compose().
+//	    Add("inv", inventory).
+//	    Add("order", workflow).
+//	    SharePlace("beans", "inv.beans", "order.beans").
+//	    Synchronize("brew", "inv.consume", "order.brew").
+//	    Done()
+type Composition struct {
+	components    []component
+	merges        []mergeSpec
+	mergeExported bool
+}
+
+type component struct {
+	namespace string
+	net       *PetriNet
+}
+
+type mergeSpec struct {
+	name    string
+	members []string // namespace-qualified labels
+	places  bool     // true = place fusion, false = transition fusion
+}
+
+// Compose starts a new composition.
+func Compose() *Composition {
+	return &Composition{}
+}
+
+// Add includes a component net under the given namespace. Every place and
+// transition label is prefixed with "namespace." in the composed net; an
+// empty namespace keeps labels unqualified.
+func (c *Composition) Add(namespace string, net *PetriNet) *Composition {
+	c.components = append(c.components, component{namespace: namespace, net: net})
+	return c
+}
+
+// SharePlace fuses the named places from different components into a single
+// place. Initial tokens are summed across members (a shared pool), and the
+// elementwise maximum capacity is kept.
+func (c *Composition) SharePlace(name string, members ...string) *Composition {
+	c.merges = append(c.merges, mergeSpec{name: name, members: members, places: true})
+	return c
+}
+
+// Synchronize fuses the named transitions from different components into a
+// single transition that fires atomically with the union of their arcs.
+// Guards are conjoined.
+func (c *Composition) Synchronize(name string, members ...string) *Composition {
+	c.merges = append(c.merges, mergeSpec{name: name, members: members, places: false})
+	return c
+}
+
+// MergeExported fuses exported nodes that share a label across components,
+// keeping the unqualified label as the composed name. This is the interface
+// style of composition: each component declares its boundary via Exported
+// and matching boundaries are wired together automatically.
+func (c *Composition) MergeExported() *Composition {
+	c.mergeExported = true
+	return c
+}
+
+// Done builds the composed net. Guard and arc expressions are copied as-is,
+// so expressions in namespaced components should only reference shared or
+// exported names that survive the rename.
+func (c *Composition) Done() (*PetriNet, error) {
+	// Qualified label -> final label, seeded with the identity rename.
+	rename := make(map[string]string)
+	isPlace := make(map[string]bool)
+	seen := make(map[string]bool)
+
+	for _, comp := range c.components {
+		for label := range comp.net.Places {
+			q := qualify(comp.namespace, label)
+			if seen[q] {
+				return nil, fmt.Errorf("duplicate label %q; use distinct namespaces", q)
+			}
+			seen[q] = true
+			rename[q] = q
+			isPlace[q] = true
+		}
+		for label := range comp.net.Transitions {
+			q := qualify(comp.namespace, label)
+			if seen[q] {
+				return nil, fmt.Errorf("duplicate label %q; use distinct namespaces", q)
+			}
+			seen[q] = true
+			rename[q] = q
+		}
+	}
+
+	merges := c.merges
+	if c.mergeExported {
+		specs, err := c.exportedMerges()
+		if err != nil {
+			return nil, err
+		}
+		merges = append(merges, specs...)
+	}
+
+	for _, spec := range merges {
+		for _, member := range spec.members {
+			if _, ok := rename[member]; !ok {
+				return nil, fmt.Errorf("merge %q: unknown node %q", spec.name, member)
+			}
+			if isPlace[member] != spec.places {
+				kind := "transition"
+				if spec.places {
+					kind = "place"
+				}
+				return nil, fmt.Errorf("merge %q: %q is not a %s", spec.name, member, kind)
+			}
+			rename[member] = spec.name
+		}
+	}
+
+	net := NewPetriNet()
+	for _, comp := range c.components {
+		c.mergeTokens(net, comp.net)
+		for label, p := range comp.net.Places {
+			addComposedPlace(net, rename[qualify(comp.namespace, label)], p)
+		}
+		for label, t := range comp.net.Transitions {
+			addComposedTransition(net, rename[qualify(comp.namespace, label)], t)
+		}
+	}
+	for _, comp := range c.components {
+		for _, arc := range comp.net.Arcs {
+			addComposedArc(net,
+				rename[qualify(comp.namespace, arc.Source)],
+				rename[qualify(comp.namespace, arc.Target)],
+				arc)
+		}
+	}
+
+	return net, nil
+}
+
+// exportedMerges groups exported nodes by unqualified label.
+func (c *Composition) exportedMerges() ([]mergeSpec, error) {
+	groups := make(map[string]*mergeSpec)
+	var order []string
+	for _, comp := range c.components {
+		for label, p := range comp.net.Places {
+			if !p.Exported {
+				continue
+			}
+			spec, ok := groups[label]
+			if !ok {
+				spec = &mergeSpec{name: label, places: true}
+				groups[label] = spec
+				order = append(order, label)
+			}
+			if !spec.places {
+				return nil, fmt.Errorf("exported %q is a place in %q but a transition elsewhere", label, comp.namespace)
+			}
+			spec.members = append(spec.members, qualify(comp.namespace, label))
+		}
+		for label, t := range comp.net.Transitions {
+			if !t.Exported {
+				continue
+			}
+			spec, ok := groups[label]
+			if !ok {
+				spec = &mergeSpec{name: label, places: false}
+				groups[label] = spec
+				order = append(order, label)
+			}
+			if spec.places {
+				return nil, fmt.Errorf("exported %q is a transition in %q but a place elsewhere", label, comp.namespace)
+			}
+			spec.members = append(spec.members, qualify(comp.namespace, label))
+		}
+	}
+
+	sort.Strings(order)
+	specs := make([]mergeSpec, 0, len(order))
+	for _, label := range order {
+		specs = append(specs, *groups[label])
+	}
+	return specs, nil
+}
+
+// mergeTokens unions token color names, preserving component order.
+func (c *Composition) mergeTokens(dst, src *PetriNet) {
+	for _, color := range src.Token {
+		found := false
+		for _, existing := range dst.Token {
+			if existing == color {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst.Token = append(dst.Token, color)
+		}
+	}
+}
+
+// addComposedPlace adds or fuses a place under its final label. Fused
+// places sum initial tokens and keep the elementwise maximum capacity.
+func addComposedPlace(net *PetriNet, label string, p *Place) {
+	existing, ok := net.Places[label]
+	if !ok {
+		added := net.AddPlace(label, append([]float64(nil), p.Initial...),
+			append([]float64(nil), p.Capacity...), p.X, p.Y, p.LabelText)
+		added.Exported = p.Exported
+		return
+	}
+	existing.Initial = addSlices(existing.Initial, p.Initial)
+	existing.Capacity = maxSlices(existing.Capacity, p.Capacity)
+	existing.Exported = existing.Exported || p.Exported
+}
+
+// addComposedTransition adds or fuses a transition under its final label.
+// The first component's role and position win; guards are conjoined.
+func addComposedTransition(net *PetriNet, label string, t *Transition) {
+	existing, ok := net.Transitions[label]
+	if !ok {
+		added := net.AddTransition(label, t.Role, t.X, t.Y, t.LabelText)
+		added.Guard = t.Guard
+		added.Exported = t.Exported
+		return
+	}
+	existing.Guard = conjoinGuards(existing.Guard, t.Guard)
+	existing.Exported = existing.Exported || t.Exported
+}
+
+// addComposedArc adds an arc with renamed endpoints, skipping exact
+// duplicates so fused nodes contribute a shared arc only once.
+func addComposedArc(net *PetriNet, source, target string, arc *Arc) {
+	for _, existing := range net.Arcs {
+		if existing.Source == source && existing.Target == target &&
+			existing.InhibitTransition == arc.InhibitTransition &&
+			existing.Expression == arc.Expression &&
+			equalSlices(existing.Weight, arc.Weight) {
+			return
+		}
+	}
+	added := net.AddArc(source, target, append([]float64(nil), arc.Weight...), arc.InhibitTransition)
+	added.Expression = arc.Expression
+}
+
+// qualify prefixes a label with its component namespace.
+func qualify(namespace, label string) string {
+	if namespace == "" {
+		return label
+	}
+	return namespace + "." + label
+}
+
+// conjoinGuards combines two guard expressions with a logical AND.
+func conjoinGuards(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" || a == b {
+		return a
+	}
+	return "(" + a + ") && (" + b + ")"
+}
+
+func addSlices(a, b []float64) []float64 {
+	out := make([]float64, maxLen(a, b))
+	for i := range out {
+		if i < len(a) {
+			out[i] += a[i]
+		}
+		if i < len(b) {
+			out[i] += b[i]
+		}
+	}
+	return out
+}
+
+func maxSlices(a, b []float64) []float64 {
+	out := make([]float64, maxLen(a, b))
+	for i := range out {
+		if i < len(a) && a[i] > out[i] {
+			out[i] = a[i]
+		}
+		if i < len(b) && b[i] > out[i] {
+			out[i] = b[i]
+		}
+	}
+	return out
+}
+
+func equalSlices(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func maxLen(a, b []float64) int {
+	if len(a) > len(b) {
+		return len(a)
+	}
+	return len(b)
+}
+
+// Namespace returns the component prefix of a composed label, or "" if the
+// label is unqualified.
+func Namespace(label string) string {
+	if i := strings.Index(label, "."); i >= 0 {
+		return label[:i]
+	}
+	return ""
+}
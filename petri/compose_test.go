@@ -0,0 +1,186 @@
+package petri
+
+import (
+	"strings"
+	"testing"
+)
+
+func inventoryNet() *PetriNet {
+	return Build().
+		Place("beans", 10).
+		Transition("restock").
+		Arc("restock", "beans", 5).
+		Done()
+}
+
+func orderNet() *PetriNet {
+	return Build().
+		Place("beans", 0).Place("queued", 3).Place("served", 0).
+		Transition("brew").
+		Arc("queued", "brew", 1).Arc("beans", "brew", 2).Arc("brew", "served", 1).
+		Done()
+}
+
+func TestComposeNamespacing(t *testing.T) {
+	net, err := Compose().
+		Add("inv", inventoryNet()).
+		Add("order", orderNet()).
+		Done()
+	if err != nil {
+		t.Fatalf("Compose failed: %v", err)
+	}
+
+	for _, label := range []string{"inv.beans", "order.beans", "order.queued", "order.served"} {
+		if _, ok := net.Places[label]; !ok {
+			t.Errorf("Expected place %q in composed net", label)
+		}
+	}
+	if _, ok := net.Transitions["inv.restock"]; !ok {
+		t.Error("Expected transition 'inv.restock'")
+	}
+	if _, ok := net.Transitions["order.brew"]; !ok {
+		t.Error("Expected transition 'order.brew'")
+	}
+	if len(net.Arcs) != 4 {
+		t.Errorf("Expected 4 arcs, got %d", len(net.Arcs))
+	}
+}
+
+func TestComposeSharePlace(t *testing.T) {
+	net, err := Compose().
+		Add("inv", inventoryNet()).
+		Add("order", orderNet()).
+		SharePlace("beans", "inv.beans", "order.beans").
+		Done()
+	if err != nil {
+		t.Fatalf("Compose failed: %v", err)
+	}
+
+	beans, ok := net.Places["beans"]
+	if !ok {
+		t.Fatal("Expected fused place 'beans'")
+	}
+	if beans.GetTokenCount() != 10 {
+		t.Errorf("Expected fused initial 10, got %f", beans.GetTokenCount())
+	}
+	if _, ok := net.Places["inv.beans"]; ok {
+		t.Error("Expected 'inv.beans' to be replaced by fused place")
+	}
+
+	// Both the restock output and the brew input now touch the shared place
+	sources := make(map[string]bool)
+	targets := make(map[string]bool)
+	for _, arc := range net.Arcs {
+		sources[arc.Source] = true
+		targets[arc.Target] = true
+	}
+	if !targets["beans"] || !sources["beans"] {
+		t.Error("Expected arcs in and out of fused place 'beans'")
+	}
+}
+
+func TestComposeSynchronize(t *testing.T) {
+	left := Build().
+		Place("a", 1).
+		Transition("step").
+		Arc("a", "step", 1).
+		Guard("step", "a > 0").
+		Done()
+	right := Build().
+		Place("b", 1).Place("c", 0).
+		Transition("go").
+		Arc("b", "go", 1).Arc("go", "c", 1).
+		Guard("go", "b > 0").
+		Done()
+
+	net, err := Compose().
+		Add("l", left).
+		Add("r", right).
+		Synchronize("step", "l.step", "r.go").
+		Done()
+	if err != nil {
+		t.Fatalf("Compose failed: %v", err)
+	}
+
+	step, ok := net.Transitions["step"]
+	if !ok {
+		t.Fatal("Expected fused transition 'step'")
+	}
+	if len(net.Transitions) != 1 {
+		t.Errorf("Expected 1 transition, got %d", len(net.Transitions))
+	}
+	// Fused transition consumes from both components atomically
+	if len(net.GetInputArcs("step")) != 2 {
+		t.Errorf("Expected 2 input arcs on fused transition, got %d", len(net.GetInputArcs("step")))
+	}
+	if !strings.Contains(step.Guard, "&&") {
+		t.Errorf("Expected conjoined guard, got %q", step.Guard)
+	}
+}
+
+func TestComposeMergeExported(t *testing.T) {
+	producer := Build().
+		Place("buffer", 0).
+		Transition("produce").
+		Arc("produce", "buffer", 1).
+		Export("buffer").
+		Done()
+	consumer := Build().
+		Place("buffer", 0).Place("used", 0).
+		Transition("consume").
+		Arc("buffer", "consume", 1).Arc("consume", "used", 1).
+		Export("buffer").
+		Done()
+
+	net, err := Compose().
+		Add("p", producer).
+		Add("c", consumer).
+		MergeExported().
+		Done()
+	if err != nil {
+		t.Fatalf("Compose failed: %v", err)
+	}
+
+	if _, ok := net.Places["buffer"]; !ok {
+		t.Fatal("Expected exported place merged under 'buffer'")
+	}
+	if _, ok := net.Places["p.buffer"]; ok {
+		t.Error("Expected 'p.buffer' to be merged away")
+	}
+	// Unexported nodes stay namespaced
+	if _, ok := net.Places["c.used"]; !ok {
+		t.Error("Expected unexported 'c.used' to stay namespaced")
+	}
+}
+
+func TestComposeErrors(t *testing.T) {
+	if _, err := Compose().
+		Add("a", inventoryNet()).
+		Add("a", inventoryNet()).
+		Done(); err == nil {
+		t.Error("Expected error for duplicate namespace")
+	}
+
+	if _, err := Compose().
+		Add("inv", inventoryNet()).
+		SharePlace("x", "inv.missing").
+		Done(); err == nil {
+		t.Error("Expected error for unknown merge member")
+	}
+
+	if _, err := Compose().
+		Add("inv", inventoryNet()).
+		SharePlace("x", "inv.restock").
+		Done(); err == nil {
+		t.Error("Expected error when sharing a transition as a place")
+	}
+}
+
+func TestNamespace(t *testing.T) {
+	if Namespace("inv.beans") != "inv" {
+		t.Errorf("Expected namespace 'inv', got %q", Namespace("inv.beans"))
+	}
+	if Namespace("beans") != "" {
+		t.Errorf("Expected empty namespace, got %q", Namespace("beans"))
+	}
+}
@@ -0,0 +1,25 @@
+package petri
+
+import "fmt"
+
+// SetFiringCost assigns a per-firing cost to a transition. Returns an
+// error if the transition does not exist.
+func (n *PetriNet) SetFiringCost(transition string, cost float64) error {
+	t, ok := n.Transitions[transition]
+	if !ok {
+		return fmt.Errorf("transition not found: %s", transition)
+	}
+	t.FiringCost = cost
+	return nil
+}
+
+// SetHoldingCost assigns a per-token, per-time-unit holding cost to a
+// place. Returns an error if the place does not exist.
+func (n *PetriNet) SetHoldingCost(place string, cost float64) error {
+	p, ok := n.Places[place]
+	if !ok {
+		return fmt.Errorf("place not found: %s", place)
+	}
+	p.HoldingCost = cost
+	return nil
+}
@@ -0,0 +1,24 @@
+package petri
+
+import "testing"
+
+func TestSetCosts(t *testing.T) {
+	net := Build().
+		Place("p", 1).Transition("t").Arc("p", "t", 1).
+		FiringCost("t", 3).
+		HoldingCost("p", 0.25).
+		Done()
+	if net.Transitions["t"].FiringCost != 3 {
+		t.Errorf("FiringCost = %f, want 3", net.Transitions["t"].FiringCost)
+	}
+	if net.Places["p"].HoldingCost != 0.25 {
+		t.Errorf("HoldingCost = %f, want 0.25", net.Places["p"].HoldingCost)
+	}
+
+	if err := net.SetFiringCost("missing", 1); err == nil {
+		t.Error("Expected error for unknown transition")
+	}
+	if err := net.SetHoldingCost("missing", 1); err == nil {
+		t.Error("Expected error for unknown place")
+	}
+}
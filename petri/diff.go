@@ -0,0 +1,569 @@
+// Model diffing and merging. Diff produces a structured changeset between
+// two versions of a net so stored models can be reviewed before an upgrade;
+// Merge performs a three-way merge for models edited on parallel branches.
+// Layout-only edits (positions, display labels) are ignored: a change is
+// reported only when it affects behavior.
+
+package petri
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldChange records a semantic change to a named node.
+type FieldChange struct {
+	Label string `json:"label"`
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// ArcRef identifies an arc by its endpoints.
+type ArcRef struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// RateChange records a transition rate that differs between versions.
+type RateChange struct {
+	Transition string  `json:"transition"`
+	Old        float64 `json:"old"`
+	New        float64 `json:"new"`
+}
+
+// Changeset is the structured difference between two net versions.
+type Changeset struct {
+	AddedPlaces         []string      `json:"addedPlaces,omitempty"`
+	RemovedPlaces       []string      `json:"removedPlaces,omitempty"`
+	ModifiedPlaces      []FieldChange `json:"modifiedPlaces,omitempty"`
+	AddedTransitions    []string      `json:"addedTransitions,omitempty"`
+	RemovedTransitions  []string      `json:"removedTransitions,omitempty"`
+	ModifiedTransitions []FieldChange `json:"modifiedTransitions,omitempty"`
+	AddedArcs           []ArcRef      `json:"addedArcs,omitempty"`
+	RemovedArcs         []ArcRef      `json:"removedArcs,omitempty"`
+	ModifiedArcs        []FieldChange `json:"modifiedArcs,omitempty"`
+	RateChanges         []RateChange  `json:"rateChanges,omitempty"`
+}
+
+// IsEmpty reports whether the two versions are behaviorally identical.
+func (c *Changeset) IsEmpty() bool {
+	return len(c.AddedPlaces) == 0 && len(c.RemovedPlaces) == 0 &&
+		len(c.ModifiedPlaces) == 0 &&
+		len(c.AddedTransitions) == 0 && len(c.RemovedTransitions) == 0 &&
+		len(c.ModifiedTransitions) == 0 &&
+		len(c.AddedArcs) == 0 && len(c.RemovedArcs) == 0 &&
+		len(c.ModifiedArcs) == 0 && len(c.RateChanges) == 0
+}
+
+// Summary renders the changeset as a short human-readable report.
+func (c *Changeset) Summary() string {
+	if c.IsEmpty() {
+		return "no changes"
+	}
+	var lines []string
+	for _, p := range c.AddedPlaces {
+		lines = append(lines, "+ place "+p)
+	}
+	for _, p := range c.RemovedPlaces {
+		lines = append(lines, "- place "+p)
+	}
+	for _, ch := range c.ModifiedPlaces {
+		lines = append(lines, fmt.Sprintf("~ place %s %s: %s -> %s", ch.Label, ch.Field, ch.Old, ch.New))
+	}
+	for _, t := range c.AddedTransitions {
+		lines = append(lines, "+ transition "+t)
+	}
+	for _, t := range c.RemovedTransitions {
+		lines = append(lines, "- transition "+t)
+	}
+	for _, ch := range c.ModifiedTransitions {
+		lines = append(lines, fmt.Sprintf("~ transition %s %s: %s -> %s", ch.Label, ch.Field, ch.Old, ch.New))
+	}
+	for _, a := range c.AddedArcs {
+		lines = append(lines, fmt.Sprintf("+ arc %s -> %s", a.Source, a.Target))
+	}
+	for _, a := range c.RemovedArcs {
+		lines = append(lines, fmt.Sprintf("- arc %s -> %s", a.Source, a.Target))
+	}
+	for _, ch := range c.ModifiedArcs {
+		lines = append(lines, fmt.Sprintf("~ arc %s %s: %s -> %s", ch.Label, ch.Field, ch.Old, ch.New))
+	}
+	for _, r := range c.RateChanges {
+		lines = append(lines, fmt.Sprintf("~ rate %s: %g -> %g", r.Transition, r.Old, r.New))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Diff compares two net versions and returns the changes from a to b.
+func Diff(a, b *PetriNet) *Changeset {
+	c := &Changeset{}
+
+	for _, label := range sortedPlaceLabels(a) {
+		pb, ok := b.Places[label]
+		if !ok {
+			c.RemovedPlaces = append(c.RemovedPlaces, label)
+			continue
+		}
+		pa := a.Places[label]
+		if !equalSlices(normalizeSlice(pa.Initial), normalizeSlice(pb.Initial)) {
+			c.ModifiedPlaces = append(c.ModifiedPlaces, FieldChange{
+				Label: label, Field: "initial",
+				Old: formatSlice(pa.Initial), New: formatSlice(pb.Initial),
+			})
+		}
+		if !equalSlices(normalizeSlice(pa.Capacity), normalizeSlice(pb.Capacity)) {
+			c.ModifiedPlaces = append(c.ModifiedPlaces, FieldChange{
+				Label: label, Field: "capacity",
+				Old: formatSlice(pa.Capacity), New: formatSlice(pb.Capacity),
+			})
+		}
+	}
+	for _, label := range sortedPlaceLabels(b) {
+		if _, ok := a.Places[label]; !ok {
+			c.AddedPlaces = append(c.AddedPlaces, label)
+		}
+	}
+
+	for _, label := range sortedTransitionLabels(a) {
+		tb, ok := b.Transitions[label]
+		if !ok {
+			c.RemovedTransitions = append(c.RemovedTransitions, label)
+			continue
+		}
+		ta := a.Transitions[label]
+		if ta.Role != tb.Role {
+			c.ModifiedTransitions = append(c.ModifiedTransitions, FieldChange{
+				Label: label, Field: "role", Old: ta.Role, New: tb.Role,
+			})
+		}
+		if ta.Guard != tb.Guard {
+			c.ModifiedTransitions = append(c.ModifiedTransitions, FieldChange{
+				Label: label, Field: "guard", Old: ta.Guard, New: tb.Guard,
+			})
+		}
+	}
+	for _, label := range sortedTransitionLabels(b) {
+		if _, ok := a.Transitions[label]; !ok {
+			c.AddedTransitions = append(c.AddedTransitions, label)
+		}
+	}
+
+	arcsA := arcsByRef(a)
+	arcsB := arcsByRef(b)
+	for _, ref := range sortedArcRefs(arcsA) {
+		arcB, ok := arcsB[ref]
+		if !ok {
+			c.RemovedArcs = append(c.RemovedArcs, ref)
+			continue
+		}
+		arcA := arcsA[ref]
+		label := ref.Source + " -> " + ref.Target
+		if !equalSlices(normalizeWeight(arcA.Weight), normalizeWeight(arcB.Weight)) {
+			c.ModifiedArcs = append(c.ModifiedArcs, FieldChange{
+				Label: label, Field: "weight",
+				Old: formatSlice(arcA.Weight), New: formatSlice(arcB.Weight),
+			})
+		}
+		if arcA.InhibitTransition != arcB.InhibitTransition {
+			c.ModifiedArcs = append(c.ModifiedArcs, FieldChange{
+				Label: label, Field: "inhibit",
+				Old: fmt.Sprintf("%t", arcA.InhibitTransition),
+				New: fmt.Sprintf("%t", arcB.InhibitTransition),
+			})
+		}
+		if arcA.Expression != arcB.Expression {
+			c.ModifiedArcs = append(c.ModifiedArcs, FieldChange{
+				Label: label, Field: "expression",
+				Old: arcA.Expression, New: arcB.Expression,
+			})
+		}
+	}
+	for _, ref := range sortedArcRefs(arcsB) {
+		if _, ok := arcsA[ref]; !ok {
+			c.AddedArcs = append(c.AddedArcs, ref)
+		}
+	}
+
+	return c
+}
+
+// DiffRates compares two rate maps and records transitions whose rates
+// differ. Missing entries are treated as the default rate of 1.0, matching
+// SetRates.
+func DiffRates(old, new map[string]float64) []RateChange {
+	seen := make(map[string]bool)
+	var labels []string
+	for label := range old {
+		labels = append(labels, label)
+		seen[label] = true
+	}
+	for label := range new {
+		if !seen[label] {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+
+	var changes []RateChange
+	for _, label := range labels {
+		o, ok := old[label]
+		if !ok {
+			o = 1.0
+		}
+		n, ok := new[label]
+		if !ok {
+			n = 1.0
+		}
+		if o != n {
+			changes = append(changes, RateChange{Transition: label, Old: o, New: n})
+		}
+	}
+	return changes
+}
+
+// Conflict records a node changed incompatibly on both sides of a merge.
+type Conflict struct {
+	Kind   string `json:"kind"` // "place", "transition", or "arc"
+	Label  string `json:"label"`
+	Ours   string `json:"ours"`
+	Theirs string `json:"theirs"`
+}
+
+// MergeResult is the output of a three-way merge. When Conflicts is
+// non-empty the merged net resolves each conflict in favor of ours.
+type MergeResult struct {
+	Net       *PetriNet  `json:"-"`
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+}
+
+// Merge performs a three-way merge of two versions edited from a common
+// base. Changes made on only one side are applied; nodes changed on both
+// sides are kept when the edits agree and reported as conflicts (resolved
+// in favor of ours) when they do not.
+func Merge(base, ours, theirs *PetriNet) *MergeResult {
+	result := &MergeResult{Net: NewPetriNet()}
+	result.Net.Token = mergeTokenColors(base, ours, theirs)
+
+	for _, label := range unionLabels(placeLabels(base), placeLabels(ours), placeLabels(theirs)) {
+		pb, inBase := base.Places[label]
+		po, inOurs := ours.Places[label]
+		pt, inTheirs := theirs.Places[label]
+
+		chosen, conflict := mergeNode(inBase, inOurs, inTheirs,
+			func() bool { return placesEqual(po, pb) },
+			func() bool { return placesEqual(pt, pb) },
+			func() bool { return placesEqual(po, pt) })
+		switch chosen {
+		case keepOurs:
+			copyPlace(result.Net, label, po)
+		case keepTheirs:
+			copyPlace(result.Net, label, pt)
+		}
+		if conflict {
+			result.Conflicts = append(result.Conflicts, Conflict{
+				Kind: "place", Label: label,
+				Ours: describePlace(po), Theirs: describePlace(pt),
+			})
+		}
+	}
+
+	for _, label := range unionLabels(transitionLabels(base), transitionLabels(ours), transitionLabels(theirs)) {
+		tb, inBase := base.Transitions[label]
+		to, inOurs := ours.Transitions[label]
+		tt, inTheirs := theirs.Transitions[label]
+
+		chosen, conflict := mergeNode(inBase, inOurs, inTheirs,
+			func() bool { return transitionsEqual(to, tb) },
+			func() bool { return transitionsEqual(tt, tb) },
+			func() bool { return transitionsEqual(to, tt) })
+		switch chosen {
+		case keepOurs:
+			copyTransition(result.Net, label, to)
+		case keepTheirs:
+			copyTransition(result.Net, label, tt)
+		}
+		if conflict {
+			result.Conflicts = append(result.Conflicts, Conflict{
+				Kind: "transition", Label: label,
+				Ours: describeTransition(to), Theirs: describeTransition(tt),
+			})
+		}
+	}
+
+	arcsBase := arcsByRef(base)
+	arcsOurs := arcsByRef(ours)
+	arcsTheirs := arcsByRef(theirs)
+	for _, ref := range unionArcRefs(arcsBase, arcsOurs, arcsTheirs) {
+		ab, inBase := arcsBase[ref]
+		ao, inOurs := arcsOurs[ref]
+		at, inTheirs := arcsTheirs[ref]
+
+		chosen, conflict := mergeNode(inBase, inOurs, inTheirs,
+			func() bool { return arcsEqual(ao, ab) },
+			func() bool { return arcsEqual(at, ab) },
+			func() bool { return arcsEqual(ao, at) })
+		switch chosen {
+		case keepOurs:
+			copyArc(result.Net, ao)
+		case keepTheirs:
+			copyArc(result.Net, at)
+		}
+		if conflict {
+			result.Conflicts = append(result.Conflicts, Conflict{
+				Kind: "arc", Label: ref.Source + " -> " + ref.Target,
+				Ours: describeArc(ao), Theirs: describeArc(at),
+			})
+		}
+	}
+
+	// Drop arcs whose endpoints did not survive the merge.
+	kept := result.Net.Arcs[:0]
+	for _, arc := range result.Net.Arcs {
+		_, srcPlace := result.Net.Places[arc.Source]
+		_, srcTrans := result.Net.Transitions[arc.Source]
+		_, tgtPlace := result.Net.Places[arc.Target]
+		_, tgtTrans := result.Net.Transitions[arc.Target]
+		if (srcPlace || srcTrans) && (tgtPlace || tgtTrans) {
+			kept = append(kept, arc)
+		}
+	}
+	result.Net.Arcs = kept
+
+	return result
+}
+
+type mergeChoice int
+
+const (
+	keepNone mergeChoice = iota
+	keepOurs
+	keepTheirs
+)
+
+// mergeNode applies standard three-way resolution for one node: the side
+// that changed relative to base wins; when both sides changed, agreement
+// keeps the shared version and disagreement keeps ours with a conflict.
+func mergeNode(inBase, inOurs, inTheirs bool, oursEqBase, theirsEqBase, oursEqTheirs func() bool) (mergeChoice, bool) {
+	switch {
+	case !inOurs && !inTheirs:
+		return keepNone, false
+	case inOurs && !inTheirs:
+		if !inBase {
+			return keepOurs, false // added by us
+		}
+		if oursEqBase() {
+			return keepNone, false // deleted by them, untouched by us
+		}
+		return keepOurs, true // modified by us, deleted by them
+	case !inOurs && inTheirs:
+		if !inBase {
+			return keepTheirs, false // added by them
+		}
+		if theirsEqBase() {
+			return keepNone, false // deleted by us, untouched by them
+		}
+		return keepNone, true // deleted by us, modified by them
+	default:
+		if oursEqTheirs() {
+			return keepOurs, false
+		}
+		if inBase && oursEqBase() {
+			return keepTheirs, false
+		}
+		if inBase && theirsEqBase() {
+			return keepOurs, false
+		}
+		return keepOurs, true // both changed, differently
+	}
+}
+
+func placesEqual(a, b *Place) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalSlices(normalizeSlice(a.Initial), normalizeSlice(b.Initial)) &&
+		equalSlices(normalizeSlice(a.Capacity), normalizeSlice(b.Capacity))
+}
+
+func transitionsEqual(a, b *Transition) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Role == b.Role && a.Guard == b.Guard
+}
+
+func arcsEqual(a, b *Arc) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalSlices(normalizeWeight(a.Weight), normalizeWeight(b.Weight)) &&
+		a.InhibitTransition == b.InhibitTransition &&
+		a.Expression == b.Expression
+}
+
+func copyPlace(net *PetriNet, label string, p *Place) {
+	added := net.AddPlace(label, append([]float64(nil), p.Initial...),
+		append([]float64(nil), p.Capacity...), p.X, p.Y, p.LabelText)
+	added.Exported = p.Exported
+}
+
+func copyTransition(net *PetriNet, label string, t *Transition) {
+	added := net.AddTransition(label, t.Role, t.X, t.Y, t.LabelText)
+	added.Guard = t.Guard
+	added.Exported = t.Exported
+}
+
+func copyArc(net *PetriNet, a *Arc) {
+	added := net.AddArc(a.Source, a.Target, append([]float64(nil), a.Weight...), a.InhibitTransition)
+	added.Expression = a.Expression
+}
+
+func describePlace(p *Place) string {
+	if p == nil {
+		return "removed"
+	}
+	return fmt.Sprintf("initial=%s capacity=%s", formatSlice(p.Initial), formatSlice(p.Capacity))
+}
+
+func describeTransition(t *Transition) string {
+	if t == nil {
+		return "removed"
+	}
+	return fmt.Sprintf("role=%s guard=%q", t.Role, t.Guard)
+}
+
+func describeArc(a *Arc) string {
+	if a == nil {
+		return "removed"
+	}
+	return fmt.Sprintf("weight=%s inhibit=%t expression=%q", formatSlice(a.Weight), a.InhibitTransition, a.Expression)
+}
+
+func mergeTokenColors(nets ...*PetriNet) []string {
+	var colors []string
+	seen := make(map[string]bool)
+	for _, net := range nets {
+		for _, color := range net.Token {
+			if !seen[color] {
+				seen[color] = true
+				colors = append(colors, color)
+			}
+		}
+	}
+	return colors
+}
+
+func arcsByRef(net *PetriNet) map[ArcRef]*Arc {
+	arcs := make(map[ArcRef]*Arc, len(net.Arcs))
+	for _, arc := range net.Arcs {
+		arcs[ArcRef{Source: arc.Source, Target: arc.Target}] = arc
+	}
+	return arcs
+}
+
+func sortedArcRefs(arcs map[ArcRef]*Arc) []ArcRef {
+	refs := make([]ArcRef, 0, len(arcs))
+	for ref := range arcs {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Source != refs[j].Source {
+			return refs[i].Source < refs[j].Source
+		}
+		return refs[i].Target < refs[j].Target
+	})
+	return refs
+}
+
+func unionArcRefs(maps ...map[ArcRef]*Arc) []ArcRef {
+	seen := make(map[ArcRef]bool)
+	var refs []ArcRef
+	for _, m := range maps {
+		for ref := range m {
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Source != refs[j].Source {
+			return refs[i].Source < refs[j].Source
+		}
+		return refs[i].Target < refs[j].Target
+	})
+	return refs
+}
+
+func sortedPlaceLabels(net *PetriNet) []string {
+	labels := placeLabels(net)
+	sort.Strings(labels)
+	return labels
+}
+
+func sortedTransitionLabels(net *PetriNet) []string {
+	labels := transitionLabels(net)
+	sort.Strings(labels)
+	return labels
+}
+
+func placeLabels(net *PetriNet) []string {
+	labels := make([]string, 0, len(net.Places))
+	for label := range net.Places {
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+func transitionLabels(net *PetriNet) []string {
+	labels := make([]string, 0, len(net.Transitions))
+	for label := range net.Transitions {
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+func unionLabels(sets ...[]string) []string {
+	seen := make(map[string]bool)
+	var labels []string
+	for _, set := range sets {
+		for _, label := range set {
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// normalizeSlice trims trailing zeros so padded and unpadded color vectors
+// compare equal.
+func normalizeSlice(s []float64) []float64 {
+	end := len(s)
+	for end > 0 && s[end-1] == 0 {
+		end--
+	}
+	return s[:end]
+}
+
+// normalizeWeight is normalizeSlice for arc weights, where empty means the
+// default weight of 1 (see GetWeightSum).
+func normalizeWeight(s []float64) []float64 {
+	s = normalizeSlice(s)
+	if len(s) == 0 {
+		return []float64{1}
+	}
+	return s
+}
+
+func formatSlice(s []float64) string {
+	parts := make([]string, len(s))
+	for i, v := range s {
+		parts[i] = fmt.Sprintf("%g", v)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
@@ -0,0 +1,192 @@
+package petri
+
+import (
+	"strings"
+	"testing"
+)
+
+func diffBaseNet() *PetriNet {
+	return Build().
+		Place("pending", 1).Place("approved", 0).
+		Transition("approve").
+		Arc("pending", "approve", 1).Arc("approve", "approved", 1).
+		Done()
+}
+
+func TestDiffIdentical(t *testing.T) {
+	c := Diff(diffBaseNet(), diffBaseNet())
+	if !c.IsEmpty() {
+		t.Errorf("Expected empty changeset, got:\n%s", c.Summary())
+	}
+	if c.Summary() != "no changes" {
+		t.Errorf("Expected 'no changes' summary, got %q", c.Summary())
+	}
+}
+
+func TestDiffAddedRemoved(t *testing.T) {
+	b := diffBaseNet()
+	b.AddPlace("rejected", 0, nil, 0, 0, nil)
+	b.AddTransition("reject", "default", 0, 0, nil)
+	b.AddArc("pending", "reject", 1, false)
+	b.AddArc("reject", "rejected", 1, false)
+	delete(b.Places, "approved")
+
+	c := Diff(diffBaseNet(), b)
+	if len(c.AddedPlaces) != 1 || c.AddedPlaces[0] != "rejected" {
+		t.Errorf("Expected added place 'rejected', got %v", c.AddedPlaces)
+	}
+	if len(c.RemovedPlaces) != 1 || c.RemovedPlaces[0] != "approved" {
+		t.Errorf("Expected removed place 'approved', got %v", c.RemovedPlaces)
+	}
+	if len(c.AddedTransitions) != 1 || c.AddedTransitions[0] != "reject" {
+		t.Errorf("Expected added transition 'reject', got %v", c.AddedTransitions)
+	}
+	if len(c.AddedArcs) != 2 {
+		t.Errorf("Expected 2 added arcs, got %v", c.AddedArcs)
+	}
+}
+
+func TestDiffModified(t *testing.T) {
+	b := diffBaseNet()
+	b.Places["pending"].Initial = []float64{5}
+	b.Transitions["approve"].Guard = "pending > 0"
+	b.Arcs[0].Weight = []float64{2}
+
+	c := Diff(diffBaseNet(), b)
+	if len(c.ModifiedPlaces) != 1 || c.ModifiedPlaces[0].Field != "initial" {
+		t.Errorf("Expected modified initial on 'pending', got %v", c.ModifiedPlaces)
+	}
+	if len(c.ModifiedTransitions) != 1 || c.ModifiedTransitions[0].Field != "guard" {
+		t.Errorf("Expected modified guard on 'approve', got %v", c.ModifiedTransitions)
+	}
+	if len(c.ModifiedArcs) != 1 || c.ModifiedArcs[0].Field != "weight" {
+		t.Errorf("Expected modified arc weight, got %v", c.ModifiedArcs)
+	}
+	if !strings.Contains(c.Summary(), "~ place pending initial") {
+		t.Errorf("Expected summary to mention initial change, got:\n%s", c.Summary())
+	}
+}
+
+func TestDiffIgnoresLayout(t *testing.T) {
+	b := diffBaseNet()
+	b.Places["pending"].X = 500
+	b.Places["pending"].Y = 500
+	label := "Pending Work"
+	b.Places["pending"].LabelText = &label
+
+	if c := Diff(diffBaseNet(), b); !c.IsEmpty() {
+		t.Errorf("Expected layout-only changes to be ignored, got:\n%s", c.Summary())
+	}
+}
+
+func TestDiffRates(t *testing.T) {
+	changes := DiffRates(
+		map[string]float64{"approve": 1.0, "reject": 0.5},
+		map[string]float64{"approve": 2.0, "reject": 0.5},
+	)
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 rate change, got %v", changes)
+	}
+	if changes[0].Transition != "approve" || changes[0].Old != 1.0 || changes[0].New != 2.0 {
+		t.Errorf("Unexpected rate change %v", changes[0])
+	}
+
+	// Missing entries default to 1.0
+	changes = DiffRates(map[string]float64{"approve": 1.0}, map[string]float64{})
+	if len(changes) != 0 {
+		t.Errorf("Expected no change when rate equals the default, got %v", changes)
+	}
+}
+
+func TestMergeNonConflicting(t *testing.T) {
+	base := diffBaseNet()
+
+	ours := diffBaseNet()
+	ours.Places["pending"].Initial = []float64{5}
+
+	theirs := diffBaseNet()
+	theirs.AddPlace("rejected", 0, nil, 0, 0, nil)
+	theirs.AddTransition("reject", "default", 0, 0, nil)
+	theirs.AddArc("pending", "reject", 1, false)
+	theirs.AddArc("reject", "rejected", 1, false)
+
+	result := Merge(base, ours, theirs)
+	if len(result.Conflicts) != 0 {
+		t.Fatalf("Expected no conflicts, got %v", result.Conflicts)
+	}
+	if result.Net.Places["pending"].GetTokenCount() != 5 {
+		t.Errorf("Expected our initial change to survive, got %f", result.Net.Places["pending"].GetTokenCount())
+	}
+	if _, ok := result.Net.Transitions["reject"]; !ok {
+		t.Error("Expected their added transition to survive")
+	}
+	if len(result.Net.Arcs) != 4 {
+		t.Errorf("Expected 4 arcs after merge, got %d", len(result.Net.Arcs))
+	}
+}
+
+func TestMergeConflict(t *testing.T) {
+	base := diffBaseNet()
+
+	ours := diffBaseNet()
+	ours.Places["pending"].Initial = []float64{5}
+
+	theirs := diffBaseNet()
+	theirs.Places["pending"].Initial = []float64{9}
+
+	result := Merge(base, ours, theirs)
+	if len(result.Conflicts) != 1 {
+		t.Fatalf("Expected 1 conflict, got %v", result.Conflicts)
+	}
+	if result.Conflicts[0].Kind != "place" || result.Conflicts[0].Label != "pending" {
+		t.Errorf("Unexpected conflict %v", result.Conflicts[0])
+	}
+	// Conflicts resolve in favor of ours
+	if result.Net.Places["pending"].GetTokenCount() != 5 {
+		t.Errorf("Expected our value to win, got %f", result.Net.Places["pending"].GetTokenCount())
+	}
+}
+
+func TestMergeDeleteVsModify(t *testing.T) {
+	base := diffBaseNet()
+
+	// We delete the approve path; they add a guard to it
+	ours := diffBaseNet()
+	delete(ours.Transitions, "approve")
+	ours.Arcs = nil
+
+	theirs := diffBaseNet()
+	theirs.Transitions["approve"].Guard = "pending > 0"
+
+	result := Merge(base, ours, theirs)
+	if len(result.Conflicts) != 1 {
+		t.Fatalf("Expected 1 conflict, got %v", result.Conflicts)
+	}
+	if _, ok := result.Net.Transitions["approve"]; ok {
+		t.Error("Expected our deletion to win")
+	}
+	// Arcs referencing the deleted transition are dropped
+	for _, arc := range result.Net.Arcs {
+		if arc.Source == "approve" || arc.Target == "approve" {
+			t.Errorf("Expected dangling arc %s -> %s to be dropped", arc.Source, arc.Target)
+		}
+	}
+}
+
+func TestMergeDeleteUntouched(t *testing.T) {
+	base := diffBaseNet()
+
+	ours := diffBaseNet()
+	delete(ours.Places, "approved")
+	ours.Arcs = ours.Arcs[:1]
+
+	theirs := diffBaseNet()
+
+	result := Merge(base, ours, theirs)
+	if len(result.Conflicts) != 0 {
+		t.Fatalf("Expected no conflicts, got %v", result.Conflicts)
+	}
+	if _, ok := result.Net.Places["approved"]; ok {
+		t.Error("Expected clean deletion to apply")
+	}
+}
@@ -0,0 +1,53 @@
+package petri
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDistributionSampleMeans(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dists := []*Distribution{
+		Exponential(0.5),
+		LogNormal(2, 0.75),
+		Gamma(3, 10),
+		Weibull(1.5, 20),
+	}
+	const n = 20000
+	for _, d := range dists {
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			v := d.Sample(rng)
+			if v < 0 {
+				t.Fatalf("%s: negative sample %f", d, v)
+			}
+			sum += v
+		}
+		mean := sum / n
+		if math.Abs(mean-d.Mean())/d.Mean() > 0.05 {
+			t.Errorf("%s: sample mean %f far from %f", d, mean, d.Mean())
+		}
+	}
+}
+
+func TestSetDuration(t *testing.T) {
+	net := Build().Place("p", 1).Transition("t").Arc("p", "t", 1).Done()
+	if err := net.SetDuration("t", Exponential(1)); err != nil {
+		t.Fatalf("SetDuration failed: %v", err)
+	}
+	if net.Transitions["t"].Duration == nil {
+		t.Error("Expected duration stored")
+	}
+	if err := net.SetDuration("missing", Exponential(1)); err == nil {
+		t.Error("Expected error for unknown transition")
+	}
+
+	// Builder form panics on unknown transitions, like Guard.
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for unknown transition in builder")
+		}
+	}()
+	Build().Place("p", 1).Transition("t").Duration("missing", Exponential(1))
+}
@@ -0,0 +1,187 @@
+// Guard expressions and marking-dependent arc weights. Both use the small
+// expression language from tokenmodel/guard: place names are variables bound
+// to their current token counts, with the usual arithmetic, comparison, and
+// boolean operators plus aggregate helpers (sum, count, min, max, tokens).
+//
+// Guards gate enablement: a transition with a Guard fires only when the
+// expression is true for the current marking. Arc Expressions replace the
+// static weight with a value computed from the marking at evaluation time.
+// Both are respected by the ODE solver, the reachability analyzer, and
+// server-side execution.
+
+package petri
+
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/pflow-xyz/go-pflow/tokenmodel/guard"
+)
+
+// compiledExpr caches a compiled expression alongside its source. SetGuard
+// and SetArcExpression install it at mutation time; evaluation only reads
+// the cache, so concurrent evaluators never write shared state.
+type compiledExpr struct {
+	once sync.Once
+	src  string
+	ast  *guard.Compiled
+	err  error
+}
+
+func (c *compiledExpr) compile() (*guard.Compiled, error) {
+	c.once.Do(func() {
+		c.ast, c.err = guard.Compile(c.src)
+	})
+	return c.ast, c.err
+}
+
+// SetGuard attaches a guard expression to a transition, validating and
+// compiling it immediately. An empty expression clears the guard.
+func (n *PetriNet) SetGuard(transition, expr string) error {
+	t, ok := n.Transitions[transition]
+	if !ok {
+		return fmt.Errorf("unknown transition %q", transition)
+	}
+	if expr == "" {
+		t.Guard = ""
+		t.compiledGuard = nil
+		return nil
+	}
+	compiled := &compiledExpr{src: expr}
+	if _, err := compiled.compile(); err != nil {
+		return fmt.Errorf("guard for %s: %w", transition, err)
+	}
+	t.Guard = expr
+	t.compiledGuard = compiled
+	return nil
+}
+
+// SetArcExpression attaches a marking-dependent weight expression to the
+// arc from source to target, validating and compiling it immediately.
+func (n *PetriNet) SetArcExpression(source, target, expr string) error {
+	for _, arc := range n.Arcs {
+		if arc.Source == source && arc.Target == target {
+			if expr == "" {
+				arc.Expression = ""
+				arc.compiledWeight = nil
+				return nil
+			}
+			compiled := &compiledExpr{src: expr}
+			if _, err := compiled.compile(); err != nil {
+				return fmt.Errorf("expression for %s->%s: %w", source, target, err)
+			}
+			arc.Expression = expr
+			arc.compiledWeight = compiled
+			return nil
+		}
+	}
+	return fmt.Errorf("no arc from %q to %q", source, target)
+}
+
+// GuardSatisfied evaluates a transition's guard against the given marking.
+// Transitions without a guard are always satisfied.
+func (n *PetriNet) GuardSatisfied(transition string, state map[string]float64) (bool, error) {
+	t, ok := n.Transitions[transition]
+	if !ok {
+		return false, fmt.Errorf("unknown transition %q", transition)
+	}
+	if t.Guard == "" {
+		return true, nil
+	}
+
+	// SetGuard installs the compiled form; a guard assigned directly to
+	// the struct field is compiled locally instead, because storing it here
+	// would be an unsynchronized write racing concurrent evaluators.
+	cached := t.compiledGuard
+	if cached == nil || cached.src != t.Guard {
+		cached = &compiledExpr{src: t.Guard}
+	}
+	compiled, err := cached.compile()
+	if err != nil {
+		return false, err
+	}
+
+	bindings, funcs := markingContext(state)
+	return guard.EvalCompiled(compiled, bindings, funcs)
+}
+
+// EvalWeight returns the arc's effective weight under the given marking:
+// the Expression when set, the static weight sum otherwise. Expression
+// results are clamped at zero so an arc can be disabled but never reversed.
+func (a *Arc) EvalWeight(state map[string]float64) (float64, error) {
+	if a.Expression == "" {
+		return a.GetWeightSum(), nil
+	}
+
+	// Same caching discipline as GuardSatisfied: only SetArcExpression
+	// writes the shared field; a directly-assigned Expression compiles
+	// into a local that is never stored.
+	cached := a.compiledWeight
+	if cached == nil || cached.src != a.Expression {
+		cached = &compiledExpr{src: a.Expression}
+	}
+	compiled, err := cached.compile()
+	if err != nil {
+		return 0, err
+	}
+
+	bindings, funcs := markingContext(state)
+	ctx := &guard.Context{Bindings: bindings, Funcs: funcs}
+	result, err := guard.Eval(compiled.AST(), ctx)
+	if err != nil {
+		return 0, fmt.Errorf("expression for %s->%s: %w", a.Source, a.Target, err)
+	}
+
+	w, ok := toWeight(result)
+	if !ok {
+		return 0, fmt.Errorf("expression for %s->%s: result %T is not numeric", a.Source, a.Target, result)
+	}
+	if w < 0 {
+		return 0, nil
+	}
+	return w, nil
+}
+
+// HasDynamics reports whether any transition has a guard or any arc a
+// weight expression; static nets can skip per-state evaluation entirely.
+func (n *PetriNet) HasDynamics() bool {
+	for _, t := range n.Transitions {
+		if t.Guard != "" {
+			return true
+		}
+	}
+	for _, arc := range n.Arcs {
+		if arc.Expression != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// markingContext builds expression bindings from a marking: each place name
+// is bound to its (rounded) token count, with aggregate functions attached.
+func markingContext(state map[string]float64) (map[string]interface{}, map[string]guard.GuardFunc) {
+	bindings := make(map[string]interface{}, len(state))
+	marking := make(guard.Marking, len(state))
+	for place, tokens := range state {
+		count := int64(math.Round(tokens))
+		bindings[place] = count
+		marking[place] = int(count)
+	}
+	return bindings, guard.MakeAggregates(marking)
+}
+
+// toWeight converts an expression result to a float64 weight.
+func toWeight(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,245 @@
+package petri
+
+import (
+	"sync"
+	"testing"
+)
+
+func guardedNet() *PetriNet {
+	return Build().
+		Place("pool", 5).Place("done", 0).
+		Transition("take").
+		Arc("pool", "take", 1).Arc("take", "done", 1).
+		Done()
+}
+
+func TestSetGuard(t *testing.T) {
+	net := guardedNet()
+
+	if err := net.SetGuard("take", "pool > 2"); err != nil {
+		t.Fatalf("SetGuard failed: %v", err)
+	}
+	if net.Transitions["take"].Guard != "pool > 2" {
+		t.Errorf("Expected guard to be stored, got %q", net.Transitions["take"].Guard)
+	}
+
+	if err := net.SetGuard("take", "pool >"); err == nil {
+		t.Error("Expected error for invalid guard expression")
+	}
+	if err := net.SetGuard("missing", "pool > 0"); err == nil {
+		t.Error("Expected error for unknown transition")
+	}
+
+	// Empty expression clears the guard
+	if err := net.SetGuard("take", ""); err != nil {
+		t.Fatalf("Clearing guard failed: %v", err)
+	}
+	if net.Transitions["take"].Guard != "" {
+		t.Error("Expected guard to be cleared")
+	}
+}
+
+func TestGuardSatisfied(t *testing.T) {
+	net := guardedNet()
+	if err := net.SetGuard("take", "pool > 2"); err != nil {
+		t.Fatalf("SetGuard failed: %v", err)
+	}
+
+	ok, err := net.GuardSatisfied("take", map[string]float64{"pool": 5, "done": 0})
+	if err != nil {
+		t.Fatalf("GuardSatisfied failed: %v", err)
+	}
+	if !ok {
+		t.Error("Expected guard satisfied with pool=5")
+	}
+
+	ok, err = net.GuardSatisfied("take", map[string]float64{"pool": 2, "done": 3})
+	if err != nil {
+		t.Fatalf("GuardSatisfied failed: %v", err)
+	}
+	if ok {
+		t.Error("Expected guard not satisfied with pool=2")
+	}
+
+	// Transitions without a guard always pass
+	if err := net.SetGuard("take", ""); err != nil {
+		t.Fatalf("Clearing guard failed: %v", err)
+	}
+	ok, err = net.GuardSatisfied("take", map[string]float64{"pool": 0})
+	if err != nil || !ok {
+		t.Errorf("Expected empty guard to pass, got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestGuardAggregates(t *testing.T) {
+	net := guardedNet()
+	if err := net.SetGuard("take", `sum("") < 10 && pool >= 1`); err != nil {
+		t.Fatalf("SetGuard failed: %v", err)
+	}
+
+	ok, err := net.GuardSatisfied("take", map[string]float64{"pool": 5, "done": 0})
+	if err != nil {
+		t.Fatalf("GuardSatisfied failed: %v", err)
+	}
+	if !ok {
+		t.Error("Expected aggregate guard satisfied with total 5")
+	}
+
+	ok, err = net.GuardSatisfied("take", map[string]float64{"pool": 5, "done": 6})
+	if err != nil {
+		t.Fatalf("GuardSatisfied failed: %v", err)
+	}
+	if ok {
+		t.Error("Expected aggregate guard not satisfied with total 11")
+	}
+}
+
+func TestSetArcExpression(t *testing.T) {
+	net := guardedNet()
+
+	if err := net.SetArcExpression("pool", "take", "pool / 2"); err != nil {
+		t.Fatalf("SetArcExpression failed: %v", err)
+	}
+	if err := net.SetArcExpression("pool", "take", "pool /"); err == nil {
+		t.Error("Expected error for invalid expression")
+	}
+	if err := net.SetArcExpression("done", "take", "1"); err == nil {
+		t.Error("Expected error for missing arc")
+	}
+}
+
+func TestArcEvalWeight(t *testing.T) {
+	net := guardedNet()
+	if err := net.SetArcExpression("pool", "take", "pool / 2"); err != nil {
+		t.Fatalf("SetArcExpression failed: %v", err)
+	}
+
+	var arc *Arc
+	for _, a := range net.Arcs {
+		if a.Source == "pool" && a.Target == "take" {
+			arc = a
+		}
+	}
+
+	w, err := arc.EvalWeight(map[string]float64{"pool": 6, "done": 0})
+	if err != nil {
+		t.Fatalf("EvalWeight failed: %v", err)
+	}
+	if w != 3 {
+		t.Errorf("Expected weight 3, got %f", w)
+	}
+
+	// Negative results are clamped to zero
+	arc.Expression = "pool - 10"
+	w, err = arc.EvalWeight(map[string]float64{"pool": 6, "done": 0})
+	if err != nil {
+		t.Fatalf("EvalWeight failed: %v", err)
+	}
+	if w != 0 {
+		t.Errorf("Expected negative result clamped to 0, got %f", w)
+	}
+
+	// Arcs without expressions use the static weight
+	arc.Expression = ""
+	w, err = arc.EvalWeight(nil)
+	if err != nil {
+		t.Fatalf("EvalWeight failed: %v", err)
+	}
+	if w != 1 {
+		t.Errorf("Expected static weight 1, got %f", w)
+	}
+}
+
+func TestHasDynamics(t *testing.T) {
+	net := guardedNet()
+	if net.HasDynamics() {
+		t.Error("Expected no dynamics on a static net")
+	}
+
+	if err := net.SetGuard("take", "pool > 0"); err != nil {
+		t.Fatalf("SetGuard failed: %v", err)
+	}
+	if !net.HasDynamics() {
+		t.Error("Expected dynamics after setting a guard")
+	}
+
+	net2 := guardedNet()
+	if err := net2.SetArcExpression("pool", "take", "pool"); err != nil {
+		t.Fatalf("SetArcExpression failed: %v", err)
+	}
+	if !net2.HasDynamics() {
+		t.Error("Expected dynamics after setting an arc expression")
+	}
+}
+
+func TestBuilderGuard(t *testing.T) {
+	net := Build().
+		Place("pool", 5).Place("done", 0).
+		Transition("take").
+		Arc("pool", "take", 1).
+		ExprArc("take", "done", "pool").
+		Guard("take", "pool > 1").
+		Done()
+
+	if net.Transitions["take"].Guard != "pool > 1" {
+		t.Errorf("Expected builder to set guard, got %q", net.Transitions["take"].Guard)
+	}
+	found := false
+	for _, a := range net.Arcs {
+		if a.Source == "take" && a.Target == "done" && a.Expression == "pool" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected builder to set arc expression")
+	}
+}
+
+func TestBuilderGuardPanicsOnInvalid(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected panic for invalid guard in builder")
+		}
+	}()
+	Build().
+		Place("p", 1).
+		Transition("t").
+		Guard("t", "p >").
+		Done()
+}
+
+func TestConcurrentGuardEvaluation(t *testing.T) {
+	// Evaluation must not write shared state: parallel workers evaluate
+	// guards and arc expressions on one net (run under -race).
+	net := guardedNet()
+	if err := net.SetGuard("take", "pool > 2"); err != nil {
+		t.Fatalf("SetGuard failed: %v", err)
+	}
+	if err := net.SetArcExpression("pool", "take", "pool - 4"); err != nil {
+		t.Fatalf("SetArcExpression failed: %v", err)
+	}
+	// Assign one guard directly to exercise the uncached path too.
+	net.Transitions["take"].Guard = "pool >= 1"
+
+	state := map[string]float64{"pool": 5, "done": 0}
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if _, err := net.GuardSatisfied("take", state); err != nil {
+					t.Errorf("GuardSatisfied: %v", err)
+					return
+				}
+				for _, arc := range net.Arcs {
+					if _, err := arc.EvalWeight(state); err != nil {
+						t.Errorf("EvalWeight: %v", err)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
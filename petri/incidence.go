@@ -0,0 +1,204 @@
+// Incidence matrices expose the net's stoichiometry as plain matrices so
+// structural analyses (invariants, state equation checks, rank conditions)
+// can be written against linear algebra instead of re-deriving weights from
+// Arcs each time. The matrix is a snapshot: compute it once and reuse it;
+// recompute after structural edits.
+
+package petri
+
+import (
+	"math"
+	"sort"
+)
+
+// IncidenceMatrix holds the pre, post, and combined incidence matrices of a
+// net. Rows are places and columns are transitions, both in alphabetical
+// order so the same net always produces the same layout. Inhibitor arcs do
+// not contribute; arcs with a weight Expression contribute their static
+// Weight.
+type IncidenceMatrix struct {
+	Places      []string    // Row labels in alphabetical order
+	Transitions []string    // Column labels in alphabetical order
+	Pre         [][]float64 // Pre[p][t]: weight of the arc place->transition
+	Post        [][]float64 // Post[p][t]: weight of the arc transition->place
+	C           [][]float64 // C = Post - Pre
+
+	placeIdx map[string]int
+	transIdx map[string]int
+}
+
+// IncidenceMatrix computes the net's pre, post, and combined incidence
+// matrices.
+func (n *PetriNet) IncidenceMatrix() *IncidenceMatrix {
+	places := make([]string, 0, len(n.Places))
+	for p := range n.Places {
+		places = append(places, p)
+	}
+	sort.Strings(places)
+
+	transitions := make([]string, 0, len(n.Transitions))
+	for t := range n.Transitions {
+		transitions = append(transitions, t)
+	}
+	sort.Strings(transitions)
+
+	placeIdx := make(map[string]int, len(places))
+	for i, p := range places {
+		placeIdx[p] = i
+	}
+	transIdx := make(map[string]int, len(transitions))
+	for i, t := range transitions {
+		transIdx[t] = i
+	}
+
+	pre := make([][]float64, len(places))
+	post := make([][]float64, len(places))
+	c := make([][]float64, len(places))
+	for i := range pre {
+		pre[i] = make([]float64, len(transitions))
+		post[i] = make([]float64, len(transitions))
+		c[i] = make([]float64, len(transitions))
+	}
+
+	for _, arc := range n.Arcs {
+		if arc.InhibitTransition {
+			continue
+		}
+		weight := arc.GetWeightSum()
+
+		if pi, ok := placeIdx[arc.Source]; ok {
+			if ti, ok := transIdx[arc.Target]; ok {
+				pre[pi][ti] += weight
+			}
+		} else if ti, ok := transIdx[arc.Source]; ok {
+			if pi, ok := placeIdx[arc.Target]; ok {
+				post[pi][ti] += weight
+			}
+		}
+	}
+
+	for i := range c {
+		for j := range c[i] {
+			c[i][j] = post[i][j] - pre[i][j]
+		}
+	}
+
+	return &IncidenceMatrix{
+		Places:      places,
+		Transitions: transitions,
+		Pre:         pre,
+		Post:        post,
+		C:           c,
+		placeIdx:    placeIdx,
+		transIdx:    transIdx,
+	}
+}
+
+// Get returns the combined incidence value C[place][transition], or 0 for
+// unknown labels.
+func (im *IncidenceMatrix) Get(place, transition string) float64 {
+	pi, ok := im.placeIdx[place]
+	if !ok {
+		return 0
+	}
+	ti, ok := im.transIdx[transition]
+	if !ok {
+		return 0
+	}
+	return im.C[pi][ti]
+}
+
+// PreWeight returns Pre[place][transition], or 0 for unknown labels.
+func (im *IncidenceMatrix) PreWeight(place, transition string) float64 {
+	pi, ok := im.placeIdx[place]
+	if !ok {
+		return 0
+	}
+	ti, ok := im.transIdx[transition]
+	if !ok {
+		return 0
+	}
+	return im.Pre[pi][ti]
+}
+
+// PostWeight returns Post[place][transition], or 0 for unknown labels.
+func (im *IncidenceMatrix) PostWeight(place, transition string) float64 {
+	pi, ok := im.placeIdx[place]
+	if !ok {
+		return 0
+	}
+	ti, ok := im.transIdx[transition]
+	if !ok {
+		return 0
+	}
+	return im.Post[pi][ti]
+}
+
+// Rank returns the rank of the combined incidence matrix. The number of
+// independent P-invariants is len(Places) - Rank(), and of T-invariants
+// len(Transitions) - Rank().
+func (im *IncidenceMatrix) Rank() int {
+	return MatrixRank(im.C)
+}
+
+// Apply evaluates the state equation M' = M + C*firings, where firings maps
+// transition labels to firing counts. Places absent from state start at 0;
+// unknown transition labels are ignored.
+func (im *IncidenceMatrix) Apply(state map[string]float64, firings map[string]float64) map[string]float64 {
+	next := make(map[string]float64, len(im.Places))
+	for _, p := range im.Places {
+		next[p] = state[p]
+	}
+	for label, count := range firings {
+		ti, ok := im.transIdx[label]
+		if !ok {
+			continue
+		}
+		for pi, p := range im.Places {
+			next[p] += im.C[pi][ti] * count
+		}
+	}
+	return next
+}
+
+// MatrixRank computes the rank of a matrix using Gaussian elimination with
+// partial pivoting. Entries smaller than 1e-9 in absolute value are treated
+// as zero.
+func MatrixRank(matrix [][]float64) int {
+	const eps = 1e-9
+	if len(matrix) == 0 {
+		return 0
+	}
+
+	rows := len(matrix)
+	cols := len(matrix[0])
+	m := make([][]float64, rows)
+	for i := range matrix {
+		m[i] = append([]float64(nil), matrix[i]...)
+	}
+
+	rank := 0
+	for col := 0; col < cols && rank < rows; col++ {
+		// Find the largest pivot in this column
+		pivot := rank
+		for r := rank + 1; r < rows; r++ {
+			if math.Abs(m[r][col]) > math.Abs(m[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(m[pivot][col]) < eps {
+			continue
+		}
+		m[rank], m[pivot] = m[pivot], m[rank]
+
+		// Eliminate below the pivot
+		for r := rank + 1; r < rows; r++ {
+			factor := m[r][col] / m[rank][col]
+			for cc := col; cc < cols; cc++ {
+				m[r][cc] -= factor * m[rank][cc]
+			}
+		}
+		rank++
+	}
+	return rank
+}
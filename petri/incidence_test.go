@@ -0,0 +1,93 @@
+package petri
+
+import (
+	"testing"
+)
+
+func incidenceNet() *PetriNet {
+	// S + I -> 2I, I -> R
+	return Build().
+		Place("S", 10).Place("I", 1).Place("R", 0).
+		Transition("infect").Transition("recover").
+		Arc("S", "infect", 1).Arc("I", "infect", 1).Arc("infect", "I", 2).
+		Arc("I", "recover", 1).Arc("recover", "R", 1).
+		Done()
+}
+
+func TestIncidenceMatrixEntries(t *testing.T) {
+	im := incidenceNet().IncidenceMatrix()
+
+	if len(im.Places) != 3 || len(im.Transitions) != 2 {
+		t.Fatalf("Unexpected dimensions: %d places, %d transitions", len(im.Places), len(im.Transitions))
+	}
+	if im.Places[0] != "I" || im.Transitions[0] != "infect" {
+		t.Errorf("Expected alphabetical labels, got %v / %v", im.Places, im.Transitions)
+	}
+
+	if im.PreWeight("S", "infect") != 1 || im.PostWeight("I", "infect") != 2 {
+		t.Error("Unexpected pre/post weights for infect")
+	}
+	if im.Get("S", "infect") != -1 {
+		t.Errorf("Expected C[S][infect] = -1, got %f", im.Get("S", "infect"))
+	}
+	if im.Get("I", "infect") != 1 {
+		t.Errorf("Expected C[I][infect] = 1, got %f", im.Get("I", "infect"))
+	}
+	if im.Get("R", "recover") != 1 || im.Get("I", "recover") != -1 {
+		t.Error("Unexpected incidence for recover")
+	}
+	if im.Get("missing", "infect") != 0 {
+		t.Error("Expected 0 for unknown place")
+	}
+}
+
+func TestIncidenceIgnoresInhibitorArcs(t *testing.T) {
+	net := Build().
+		Place("buffer", 0).Place("out", 0).
+		Transition("work").
+		InhibitorArc("buffer", "work", 5).
+		Arc("work", "out", 1).
+		Done()
+
+	im := net.IncidenceMatrix()
+	if im.Get("buffer", "work") != 0 {
+		t.Errorf("Expected inhibitor arc to contribute 0, got %f", im.Get("buffer", "work"))
+	}
+	if im.Get("out", "work") != 1 {
+		t.Errorf("Expected C[out][work] = 1, got %f", im.Get("out", "work"))
+	}
+}
+
+func TestIncidenceRank(t *testing.T) {
+	im := incidenceNet().IncidenceMatrix()
+
+	// SIR conserves total population: one P-invariant, so rank = places - 1.
+	if got := im.Rank(); got != 2 {
+		t.Errorf("Expected rank 2, got %d", got)
+	}
+
+	if MatrixRank(nil) != 0 {
+		t.Error("Expected rank 0 for an empty matrix")
+	}
+	if MatrixRank([][]float64{{1, 2}, {2, 4}}) != 1 {
+		t.Error("Expected rank 1 for linearly dependent rows")
+	}
+}
+
+func TestIncidenceApplyStateEquation(t *testing.T) {
+	net := incidenceNet()
+	im := net.IncidenceMatrix()
+
+	state := net.SetState(nil)
+	next := im.Apply(state, map[string]float64{"infect": 2, "recover": 1})
+
+	if next["S"] != 8 || next["I"] != 2 || next["R"] != 1 {
+		t.Errorf("Unexpected state after firing: %v", next)
+	}
+
+	// Unknown transitions are ignored
+	same := im.Apply(state, map[string]float64{"bogus": 3})
+	if same["S"] != 10 || same["I"] != 1 || same["R"] != 0 {
+		t.Errorf("Expected unchanged state, got %v", same)
+	}
+}
@@ -0,0 +1,320 @@
+// First-class markings with dense-vector backing. Markings are otherwise
+// passed around as map[string]float64 with ad hoc copying; a PlaceIndex
+// fixes a stable order over place labels so a marking can live in a flat
+// slice, making copy, compare, and hash cheap. Integer and float variants
+// cover discrete analysis and continuous simulation respectively.
+
+package petri
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PlaceIndex assigns each place label a stable position, shared by all
+// markings derived from it. Labels are ordered alphabetically so the same
+// net always produces the same index.
+type PlaceIndex struct {
+	labels []string
+	pos    map[string]int
+}
+
+// PlaceIndex builds the stable place index for this net.
+func (n *PetriNet) PlaceIndex() *PlaceIndex {
+	labels := make([]string, 0, len(n.Places))
+	for label := range n.Places {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	pos := make(map[string]int, len(labels))
+	for i, label := range labels {
+		pos[label] = i
+	}
+	return &PlaceIndex{labels: labels, pos: pos}
+}
+
+// Len returns the number of indexed places.
+func (ix *PlaceIndex) Len() int {
+	return len(ix.labels)
+}
+
+// Labels returns place labels in index order. The slice is shared; do not
+// modify it.
+func (ix *PlaceIndex) Labels() []string {
+	return ix.labels
+}
+
+// Of returns the position of a place label.
+func (ix *PlaceIndex) Of(label string) (int, bool) {
+	i, ok := ix.pos[label]
+	return i, ok
+}
+
+// Marking is a dense float64 token vector over a PlaceIndex.
+type Marking struct {
+	ix *PlaceIndex
+	v  []float64
+}
+
+// Marking creates a zero marking over this index.
+func (ix *PlaceIndex) Marking() Marking {
+	return Marking{ix: ix, v: make([]float64, len(ix.labels))}
+}
+
+// MarkingFrom creates a marking from a state map. Unknown labels are
+// ignored.
+func (ix *PlaceIndex) MarkingFrom(state map[string]float64) Marking {
+	m := ix.Marking()
+	for label, tokens := range state {
+		if i, ok := ix.pos[label]; ok {
+			m.v[i] = tokens
+		}
+	}
+	return m
+}
+
+// InitialMarking returns the net's initial state as a dense marking.
+func (n *PetriNet) InitialMarking() Marking {
+	ix := n.PlaceIndex()
+	return ix.MarkingFrom(n.SetState(nil))
+}
+
+// Index returns the place index this marking is keyed by.
+func (m Marking) Index() *PlaceIndex {
+	return m.ix
+}
+
+// Get returns the token count for a place (0 for unknown labels).
+func (m Marking) Get(label string) float64 {
+	if i, ok := m.ix.pos[label]; ok {
+		return m.v[i]
+	}
+	return 0
+}
+
+// Set sets the token count for a place; unknown labels are ignored.
+func (m Marking) Set(label string, tokens float64) {
+	if i, ok := m.ix.pos[label]; ok {
+		m.v[i] = tokens
+	}
+}
+
+// At returns the token count at an index position.
+func (m Marking) At(i int) float64 {
+	return m.v[i]
+}
+
+// Copy returns an independent copy sharing the same index.
+func (m Marking) Copy() Marking {
+	return Marking{ix: m.ix, v: append([]float64(nil), m.v...)}
+}
+
+// Add adds other into m elementwise. Both markings must share an index.
+func (m Marking) Add(other Marking) {
+	for i := range m.v {
+		m.v[i] += other.v[i]
+	}
+}
+
+// Sub subtracts other from m elementwise.
+func (m Marking) Sub(other Marking) {
+	for i := range m.v {
+		m.v[i] -= other.v[i]
+	}
+}
+
+// Covers reports whether m >= other for every place.
+func (m Marking) Covers(other Marking) bool {
+	for i := range m.v {
+		if m.v[i] < other.v[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether both markings hold the same tokens.
+func (m Marking) Equal(other Marking) bool {
+	if len(m.v) != len(other.v) {
+		return false
+	}
+	for i := range m.v {
+		if m.v[i] != other.v[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns a deterministic key for the marking, suitable for visited
+// sets and memoization.
+func (m Marking) Hash() string {
+	var sb strings.Builder
+	for i, v := range m.v {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return sb.String()
+}
+
+// Total returns the sum of all tokens.
+func (m Marking) Total() float64 {
+	sum := 0.0
+	for _, v := range m.v {
+		sum += v
+	}
+	return sum
+}
+
+// ToMap converts the marking back to a state map.
+func (m Marking) ToMap() map[string]float64 {
+	state := make(map[string]float64, len(m.v))
+	for i, label := range m.ix.labels {
+		state[label] = m.v[i]
+	}
+	return state
+}
+
+// Round converts to the integer variant, rounding each count.
+func (m Marking) Round() IntMarking {
+	im := IntMarking{ix: m.ix, v: make([]int, len(m.v))}
+	for i, v := range m.v {
+		im.v[i] = int(math.Round(v))
+	}
+	return im
+}
+
+// IntMarking is a dense integer token vector over a PlaceIndex, for
+// discrete analysis.
+type IntMarking struct {
+	ix *PlaceIndex
+	v  []int
+}
+
+// IntMarking creates a zero integer marking over this index.
+func (ix *PlaceIndex) IntMarking() IntMarking {
+	return IntMarking{ix: ix, v: make([]int, len(ix.labels))}
+}
+
+// IntMarkingFrom creates an integer marking from a counts map. Unknown
+// labels are ignored.
+func (ix *PlaceIndex) IntMarkingFrom(counts map[string]int) IntMarking {
+	m := ix.IntMarking()
+	for label, tokens := range counts {
+		if i, ok := ix.pos[label]; ok {
+			m.v[i] = tokens
+		}
+	}
+	return m
+}
+
+// Index returns the place index this marking is keyed by.
+func (m IntMarking) Index() *PlaceIndex {
+	return m.ix
+}
+
+// Get returns the token count for a place (0 for unknown labels).
+func (m IntMarking) Get(label string) int {
+	if i, ok := m.ix.pos[label]; ok {
+		return m.v[i]
+	}
+	return 0
+}
+
+// Set sets the token count for a place; unknown labels are ignored.
+func (m IntMarking) Set(label string, tokens int) {
+	if i, ok := m.ix.pos[label]; ok {
+		m.v[i] = tokens
+	}
+}
+
+// At returns the token count at an index position.
+func (m IntMarking) At(i int) int {
+	return m.v[i]
+}
+
+// Copy returns an independent copy sharing the same index.
+func (m IntMarking) Copy() IntMarking {
+	return IntMarking{ix: m.ix, v: append([]int(nil), m.v...)}
+}
+
+// Add adds other into m elementwise.
+func (m IntMarking) Add(other IntMarking) {
+	for i := range m.v {
+		m.v[i] += other.v[i]
+	}
+}
+
+// Sub subtracts other from m elementwise.
+func (m IntMarking) Sub(other IntMarking) {
+	for i := range m.v {
+		m.v[i] -= other.v[i]
+	}
+}
+
+// Covers reports whether m >= other for every place.
+func (m IntMarking) Covers(other IntMarking) bool {
+	for i := range m.v {
+		if m.v[i] < other.v[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports whether both markings hold the same tokens.
+func (m IntMarking) Equal(other IntMarking) bool {
+	if len(m.v) != len(other.v) {
+		return false
+	}
+	for i := range m.v {
+		if m.v[i] != other.v[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Hash returns a deterministic key for the marking.
+func (m IntMarking) Hash() string {
+	var sb strings.Builder
+	for i, v := range m.v {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.Itoa(v))
+	}
+	return sb.String()
+}
+
+// Total returns the sum of all tokens.
+func (m IntMarking) Total() int {
+	sum := 0
+	for _, v := range m.v {
+		sum += v
+	}
+	return sum
+}
+
+// ToMap converts the marking back to a counts map.
+func (m IntMarking) ToMap() map[string]int {
+	counts := make(map[string]int, len(m.v))
+	for i, label := range m.ix.labels {
+		counts[label] = m.v[i]
+	}
+	return counts
+}
+
+// Float converts to the float64 variant.
+func (m IntMarking) Float() Marking {
+	fm := Marking{ix: m.ix, v: make([]float64, len(m.v))}
+	for i, v := range m.v {
+		fm.v[i] = float64(v)
+	}
+	return fm
+}
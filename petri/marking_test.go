@@ -0,0 +1,129 @@
+package petri
+
+import (
+	"testing"
+)
+
+func markingNet() *PetriNet {
+	return Build().
+		Place("a", 3).Place("b", 1).Place("c", 0).
+		Transition("t").
+		Arc("a", "t", 1).Arc("t", "c", 1).
+		Done()
+}
+
+func TestPlaceIndexStableOrder(t *testing.T) {
+	net := markingNet()
+	ix := net.PlaceIndex()
+
+	if ix.Len() != 3 {
+		t.Fatalf("Expected 3 places, got %d", ix.Len())
+	}
+	labels := ix.Labels()
+	if labels[0] != "a" || labels[1] != "b" || labels[2] != "c" {
+		t.Errorf("Expected alphabetical order, got %v", labels)
+	}
+
+	// Rebuilding the index gives the same positions
+	again := net.PlaceIndex()
+	for _, label := range labels {
+		i1, _ := ix.Of(label)
+		i2, _ := again.Of(label)
+		if i1 != i2 {
+			t.Errorf("Index for %q not stable: %d vs %d", label, i1, i2)
+		}
+	}
+
+	if _, ok := ix.Of("missing"); ok {
+		t.Error("Expected lookup miss for unknown label")
+	}
+}
+
+func TestInitialMarking(t *testing.T) {
+	m := markingNet().InitialMarking()
+
+	if m.Get("a") != 3 || m.Get("b") != 1 || m.Get("c") != 0 {
+		t.Errorf("Unexpected initial marking %v", m.ToMap())
+	}
+	if m.Total() != 4 {
+		t.Errorf("Expected total 4, got %f", m.Total())
+	}
+}
+
+func TestMarkingArithmetic(t *testing.T) {
+	ix := markingNet().PlaceIndex()
+	m := ix.MarkingFrom(map[string]float64{"a": 3, "b": 1})
+	delta := ix.MarkingFrom(map[string]float64{"a": 1, "c": 2})
+
+	m.Add(delta)
+	if m.Get("a") != 4 || m.Get("c") != 2 {
+		t.Errorf("Unexpected marking after Add: %v", m.ToMap())
+	}
+
+	m.Sub(delta)
+	if m.Get("a") != 3 || m.Get("c") != 0 {
+		t.Errorf("Unexpected marking after Sub: %v", m.ToMap())
+	}
+
+	if !m.Covers(ix.MarkingFrom(map[string]float64{"a": 2})) {
+		t.Error("Expected m to cover a smaller marking")
+	}
+	if m.Covers(ix.MarkingFrom(map[string]float64{"c": 1})) {
+		t.Error("Expected m not to cover a marking with more c tokens")
+	}
+}
+
+func TestMarkingEqualHashCopy(t *testing.T) {
+	ix := markingNet().PlaceIndex()
+	m := ix.MarkingFrom(map[string]float64{"a": 3, "b": 1})
+
+	cp := m.Copy()
+	if !m.Equal(cp) {
+		t.Error("Expected copy to be equal")
+	}
+	if m.Hash() != cp.Hash() {
+		t.Error("Expected equal markings to share a hash")
+	}
+
+	cp.Set("a", 99)
+	if m.Equal(cp) {
+		t.Error("Expected modified copy to differ")
+	}
+	if m.Hash() == cp.Hash() {
+		t.Error("Expected differing markings to hash differently")
+	}
+	if m.Get("a") != 3 {
+		t.Error("Expected copy to be independent of the original")
+	}
+}
+
+func TestIntMarkingRoundTrip(t *testing.T) {
+	ix := markingNet().PlaceIndex()
+	m := ix.MarkingFrom(map[string]float64{"a": 2.6, "b": 0.4})
+
+	im := m.Round()
+	if im.Get("a") != 3 || im.Get("b") != 0 {
+		t.Errorf("Unexpected rounded marking %v", im.ToMap())
+	}
+	if im.Total() != 3 {
+		t.Errorf("Expected total 3, got %d", im.Total())
+	}
+
+	back := im.Float()
+	if back.Get("a") != 3.0 {
+		t.Errorf("Expected float conversion 3.0, got %f", back.Get("a"))
+	}
+
+	other := ix.IntMarkingFrom(map[string]int{"a": 3})
+	if !im.Equal(other) {
+		t.Errorf("Expected %v to equal %v", im.ToMap(), other.ToMap())
+	}
+	if im.Hash() != other.Hash() {
+		t.Error("Expected equal int markings to share a hash")
+	}
+
+	other.Add(ix.IntMarkingFrom(map[string]int{"c": 1}))
+	if !other.Covers(im) || im.Covers(other) {
+		t.Error("Expected strict covering after adding tokens")
+	}
+}
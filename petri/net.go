@@ -17,6 +17,16 @@ type Place struct {
 	X         float64   // X coordinate for visualization
 	Y         float64   // Y coordinate for visualization
 	LabelText *string   // Optional display label
+	Exported  bool      // Part of the net's interface for composition
+	Unit      string    // Optional unit of measure for tokens (e.g. "ml", "g")
+
+	// HoldingCost is the cost of keeping one token in this place for one
+	// time unit; zero means holding is free.
+	HoldingCost float64
+
+	// Overflow is applied when a firing would exceed Capacity; it is
+	// meaningless for places without one. See OverflowPolicy.
+	Overflow OverflowPolicy
 }
 
 // NewPlace creates a new Place with the given parameters.
@@ -53,10 +63,20 @@ func (p *Place) GetTokenCount() float64 {
 // and produces tokens in output places according to arc weights.
 type Transition struct {
 	Label     string
-	Role      string  // Role/type of transition (e.g., "default", "inhibitor")
-	X         float64 // X coordinate for visualization
-	Y         float64 // Y coordinate for visualization
-	LabelText *string // Optional display label
+	Role      string        // Role/type of transition (e.g., "default", "inhibitor")
+	X         float64       // X coordinate for visualization
+	Y         float64       // Y coordinate for visualization
+	LabelText *string       // Optional display label
+	Guard     string        // Optional boolean expression over the marking; empty = always enabled
+	Exported  bool          // Part of the net's interface for composition
+	RateUnit  string        // Optional unit of the firing rate (e.g. "per-minute")
+	Duration  *Distribution // Optional fitted duration distribution for timed simulation
+
+	// FiringCost is the cost charged each time this transition fires;
+	// zero means firing is free.
+	FiringCost float64
+
+	compiledGuard *compiledExpr // compiled by SetGuard (see guards.go)
 }
 
 // NewTransition creates a new Transition with the given parameters.
@@ -77,6 +97,9 @@ type Arc struct {
 	Target            string
 	Weight            []float64 // Weight per color (tokens consumed/produced)
 	InhibitTransition bool      // If true, this is an inhibitor arc
+	Expression        string    // Optional marking-dependent weight expression; empty = static Weight
+
+	compiledWeight *compiledExpr // compiled by SetArcExpression (see guards.go)
 }
 
 // NewArc creates a new Arc with the given parameters.
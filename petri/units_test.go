@@ -0,0 +1,100 @@
+package petri
+
+import "testing"
+
+func TestValidateUnitsBalanced(t *testing.T) {
+	// Espresso-style recipe: every unit consumed is accounted for.
+	net := Build().
+		Place("beans", 100).Place("water", 500).
+		Place("beans_used", 0).Place("water_used", 0).
+		Transition("brew").
+		Arc("beans", "brew", 18).Arc("water", "brew", 30).
+		Arc("brew", "beans_used", 18).Arc("brew", "water_used", 30).
+		Unit("beans", "g").Unit("beans_used", "g").
+		Unit("water", "ml").Unit("water_used", "ml").
+		Done()
+
+	if issues := net.ValidateUnits(); len(issues) != 0 {
+		t.Errorf("Expected no issues for balanced net, got %v", issues)
+	}
+}
+
+func TestValidateUnitsUnbalanced(t *testing.T) {
+	// Bug under test: 30 ml of water ends up in a grams place.
+	net := Build().
+		Place("water", 500).Place("beans_used", 0).
+		Transition("brew").
+		Arc("water", "brew", 30).
+		Arc("brew", "beans_used", 30).
+		Unit("water", "ml").Unit("beans_used", "g").
+		Done()
+
+	issues := net.ValidateUnits()
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues (ml vanishes, g appears), got %v", issues)
+	}
+	for _, issue := range issues {
+		if issue.Kind != "unbalanced" {
+			t.Errorf("Expected unbalanced issue, got %q", issue.Kind)
+		}
+		if issue.Transition != "brew" {
+			t.Errorf("Expected issue on brew, got %q", issue.Transition)
+		}
+	}
+}
+
+func TestValidateUnitsUnannotatedOptsOut(t *testing.T) {
+	// A sink transition destroys tokens; leaving the place unannotated
+	// keeps it out of the balance check.
+	net := Build().
+		Place("A", 10).
+		Transition("decay").
+		Arc("A", "decay", 1).
+		Done()
+
+	if issues := net.ValidateUnits(); len(issues) != 0 {
+		t.Errorf("Expected no issues without annotations, got %v", issues)
+	}
+
+	net.SetPlaceUnit("A", "ml")
+	if issues := net.ValidateUnits(); len(issues) != 1 {
+		t.Errorf("Expected 1 issue once the sink's input is annotated, got %v", issues)
+	}
+}
+
+func TestValidateUnitsRateMismatch(t *testing.T) {
+	net := Build().
+		Place("A", 10).Place("B", 0).
+		Transition("t1").Transition("t2").
+		Arc("A", "t1", 1).Arc("t1", "B", 1).
+		Arc("A", "t2", 1).Arc("t2", "B", 1).
+		RateUnit("t1", "per-minute").
+		RateUnit("t2", "per-hour").
+		Done()
+
+	issues := net.ValidateUnits()
+	if len(issues) != 1 || issues[0].Kind != "rate-mismatch" {
+		t.Fatalf("Expected one rate-mismatch issue, got %v", issues)
+	}
+	if net.RateUnit() != "" {
+		t.Errorf("Expected no common rate unit, got %q", net.RateUnit())
+	}
+
+	net.SetRateUnit("t2", "per-minute")
+	if issues := net.ValidateUnits(); len(issues) != 0 {
+		t.Errorf("Expected agreement after fixing t2, got %v", issues)
+	}
+	if net.RateUnit() != "per-minute" {
+		t.Errorf("Expected common rate unit per-minute, got %q", net.RateUnit())
+	}
+}
+
+func TestSetUnitErrors(t *testing.T) {
+	net := NewPetriNet()
+	if err := net.SetPlaceUnit("missing", "ml"); err == nil {
+		t.Error("Expected error for unknown place")
+	}
+	if err := net.SetRateUnit("missing", "per-minute"); err == nil {
+		t.Error("Expected error for unknown transition")
+	}
+}
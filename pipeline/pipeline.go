@@ -0,0 +1,168 @@
+// Package pipeline runs the standard go-pflow flow — parse log, discover
+// model, learn rates, simulate, monitor, visualize — from one config struct
+// in one call. Every demo wires these packages together with the same glue;
+// this package is that glue, written once.
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/mining"
+	"github.com/pflow-xyz/go-pflow/monitoring"
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+	"github.com/pflow-xyz/go-pflow/visualization"
+)
+
+// Config describes one end-to-end run. Only a log source is required;
+// every other field has a sensible default.
+type Config struct {
+	// Log source: either an already-parsed log or a path to a .csv or
+	// .jsonl file. Log takes precedence when both are set.
+	Log     *eventlog.EventLog
+	LogPath string
+
+	// CSVConfig and JSONLConfig override the default field mappings when
+	// parsing LogPath.
+	CSVConfig   *eventlog.CSVConfig
+	JSONLConfig *eventlog.JSONLConfig
+
+	// DiscoveryMethod selects the mining algorithm: "common-path",
+	// "sequential", "alpha", or "heuristic" (default).
+	DiscoveryMethod string
+
+	// Simulate enables the ODE simulation stage.
+	Simulate bool
+	// TSpan is the simulation time span (default [0, 100]).
+	TSpan [2]float64
+	// SolverOptions override solver.DefaultOptions().
+	SolverOptions *solver.Options
+
+	// Conformance enables replay of the log against the discovered model.
+	Conformance bool
+
+	// Monitor enables construction of a ready-to-use process monitor with
+	// the discovered model and learned rates.
+	Monitor bool
+	// MonitorConfig overrides monitoring.DefaultMonitorConfig().
+	MonitorConfig *monitoring.MonitorConfig
+
+	// SVGPath, when set, writes a rendering of the discovered model.
+	SVGPath string
+}
+
+// Result collects the artifacts of every stage that ran.
+type Result struct {
+	Log         *eventlog.EventLog
+	Discovery   *mining.DiscoveryResult
+	Net         *petri.PetriNet
+	Rates       map[string]float64
+	Solution    *solver.Solution
+	FinalState  map[string]float64
+	Conformance *mining.ConformanceResult
+	Monitor     *monitoring.Monitor
+}
+
+// Run executes the configured pipeline and returns the combined result.
+// Stages run in order — parse, discover, learn rates, simulate,
+// conformance, monitor, visualize — and the first failure aborts the run.
+func Run(config Config) (*Result, error) {
+	result := &Result{}
+
+	// Parse.
+	log, err := loadLog(config)
+	if err != nil {
+		return nil, err
+	}
+	result.Log = log
+
+	// Discover.
+	method := config.DiscoveryMethod
+	if method == "" {
+		method = "heuristic"
+	}
+	discovery, err := mining.Discover(log, method)
+	if err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+	result.Discovery = discovery
+	result.Net = discovery.Net
+
+	// Learn rates.
+	result.Rates = mining.LearnRatesFromLog(log, result.Net)
+
+	// Simulate.
+	if config.Simulate {
+		tspan := config.TSpan
+		if tspan == [2]float64{} {
+			tspan = [2]float64{0, 100}
+		}
+		opts := config.SolverOptions
+		if opts == nil {
+			opts = solver.DefaultOptions()
+		}
+		prob := solver.NewProblem(result.Net, result.Net.SetState(nil), tspan, result.Rates)
+		result.Solution = solver.Solve(prob, solver.Tsit5(), opts)
+		result.FinalState = result.Solution.GetFinalState()
+	}
+
+	// Conformance.
+	if config.Conformance {
+		result.Conformance = mining.CheckConformance(log, result.Net)
+	}
+
+	// Monitor.
+	if config.Monitor {
+		monitorConfig := monitoring.DefaultMonitorConfig()
+		if config.MonitorConfig != nil {
+			monitorConfig = *config.MonitorConfig
+		}
+		result.Monitor = monitoring.NewMonitor(result.Net, result.Rates, monitorConfig)
+	}
+
+	// Visualize.
+	if config.SVGPath != "" {
+		if err := visualization.SaveSVG(result.Net, config.SVGPath); err != nil {
+			return nil, fmt.Errorf("visualization failed: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// loadLog resolves the configured log source.
+func loadLog(config Config) (*eventlog.EventLog, error) {
+	if config.Log != nil {
+		return config.Log, nil
+	}
+	if config.LogPath == "" {
+		return nil, fmt.Errorf("no log source: set Log or LogPath")
+	}
+
+	switch {
+	case strings.HasSuffix(strings.ToLower(config.LogPath), ".csv"):
+		csvConfig := eventlog.DefaultCSVConfig()
+		if config.CSVConfig != nil {
+			csvConfig = *config.CSVConfig
+		}
+		log, err := eventlog.ParseCSV(config.LogPath, csvConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", config.LogPath, err)
+		}
+		return log, nil
+	case strings.HasSuffix(strings.ToLower(config.LogPath), ".jsonl"):
+		jsonlConfig := eventlog.DefaultJSONLConfig()
+		if config.JSONLConfig != nil {
+			jsonlConfig = *config.JSONLConfig
+		}
+		log, err := eventlog.ParseJSONL(config.LogPath, jsonlConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", config.LogPath, err)
+		}
+		return log, nil
+	default:
+		return nil, fmt.Errorf("unsupported log format: %s (want .csv or .jsonl)", config.LogPath)
+	}
+}
@@ -0,0 +1,108 @@
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+)
+
+// syntheticLog builds a simple receive -> validate -> ship log.
+func syntheticLog(cases int) *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < cases; i++ {
+		caseID := fmt.Sprintf("case-%d", i)
+		start := base.Add(time.Duration(i) * time.Hour)
+		for j, activity := range []string{"receive", "validate", "ship"} {
+			log.AddEvent(eventlog.Event{
+				CaseID:    caseID,
+				Activity:  activity,
+				Timestamp: start.Add(time.Duration(j) * 10 * time.Minute),
+				Resource:  "worker",
+			})
+		}
+	}
+	return log
+}
+
+func TestRunFullPipeline(t *testing.T) {
+	svgPath := filepath.Join(t.TempDir(), "model.svg")
+
+	result, err := Run(Config{
+		Log:         syntheticLog(10),
+		Simulate:    true,
+		Conformance: true,
+		Monitor:     true,
+		SVGPath:     svgPath,
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result.Net == nil || len(result.Net.Transitions) == 0 {
+		t.Fatal("No model discovered")
+	}
+	if len(result.Rates) == 0 {
+		t.Error("No rates learned")
+	}
+	if result.Solution == nil || result.FinalState == nil {
+		t.Error("Simulation stage did not run")
+	}
+	if result.Conformance == nil || result.Conformance.Fitness <= 0 {
+		t.Errorf("Conformance stage missing or unfit: %+v", result.Conformance)
+	}
+	if result.Monitor == nil {
+		t.Error("Monitor stage did not run")
+	}
+	if data, err := os.ReadFile(svgPath); err != nil || !strings.Contains(string(data), "<svg") {
+		t.Errorf("SVG not written: %v", err)
+	}
+}
+
+func TestRunFromCSVPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.csv")
+	var b strings.Builder
+	b.WriteString("case_id,activity,timestamp\n")
+	base := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		for j, activity := range []string{"start", "finish"} {
+			fmt.Fprintf(&b, "c%d,%s,%s\n", i, activity,
+				base.Add(time.Duration(i*60+j*10)*time.Minute).Format(time.RFC3339))
+		}
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Run(Config{LogPath: path})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(result.Log.Cases) != 5 {
+		t.Errorf("Expected 5 cases, got %d", len(result.Log.Cases))
+	}
+	if result.Net == nil {
+		t.Error("No model discovered")
+	}
+	// Optional stages stay off by default.
+	if result.Solution != nil || result.Monitor != nil || result.Conformance != nil {
+		t.Error("Optional stages ran without being enabled")
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if _, err := Run(Config{}); err == nil {
+		t.Error("Expected error for missing log source")
+	}
+	if _, err := Run(Config{LogPath: "events.xml"}); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if _, err := Run(Config{Log: syntheticLog(3), DiscoveryMethod: "nope"}); err == nil {
+		t.Error("Expected error for unknown discovery method")
+	}
+}
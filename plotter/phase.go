@@ -0,0 +1,125 @@
+package plotter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// PlotPhasePlane plots one state variable against another over a solved
+// trajectory, e.g. PlotPhasePlane(sol, "S", "I") for the classic SIR orbit.
+// The start of the trajectory is marked with a dot.
+func PlotPhasePlane(sol *solver.Solution, xVar, yVar string, width, height float64) (string, *PlotData) {
+	x := sol.GetVariable(xVar)
+	y := sol.GetVariable(yVar)
+
+	plotter := NewSVGPlotter(width, height).
+		SetTitle(fmt.Sprintf("Phase plane: %s vs %s", yVar, xVar)).
+		SetXLabel(xVar).
+		SetYLabel(yVar)
+	plotter.AddSeries(x, y, "trajectory", "")
+	if len(x) > 0 {
+		plotter.AddScatter(x[:1], y[:1], "start", "#333333")
+	}
+
+	svg := plotter.Render()
+	return svg, plotter.LastPlot
+}
+
+// PlotVectorField renders the ODE right-hand side as a field of arrows in
+// the (xVar, yVar) plane, with the remaining state variables pinned at the
+// problem's initial values. Pass a solution to overlay its trajectory, or
+// nil for the field alone. Axis ranges span [0, total initial tokens],
+// which covers every reachable state of a closed net.
+func PlotVectorField(prob *solver.Problem, xVar, yVar string, width, height float64, sol *solver.Solution) (string, *PlotData) {
+	total := 0.0
+	for _, v := range prob.U0 {
+		total += v
+	}
+	if total <= 0 {
+		total = 1
+	}
+
+	plotter := NewSVGPlotter(width, height).
+		SetTitle(fmt.Sprintf("Vector field: %s vs %s", yVar, xVar)).
+		SetXLabel(xVar).
+		SetYLabel(yVar)
+	// Pin the axis ranges with an invisible corner series so arrows and
+	// trajectory share one coordinate system.
+	plotter.AddStyledSeries(Series{X: []float64{0, total}, Y: []float64{0, total}, Color: "none"})
+	if sol != nil {
+		plotter.AddSeries(sol.GetVariable(xVar), sol.GetVariable(yVar), "trajectory", "")
+	}
+
+	svg := plotter.Render()
+	meta := plotter.LastPlot
+
+	// Sample the derivative on a grid and inject arrows behind nothing —
+	// just before the closing tag, like the heat-map overlay does.
+	const gridN = 15
+	sx := func(x float64) float64 {
+		return meta.Margin["left"] + ((x-meta.Xmin)/(meta.Xmax-meta.Xmin))*meta.PlotWidth
+	}
+	sy := func(y float64) float64 {
+		return meta.Margin["top"] + meta.PlotHeight - ((y-meta.Ymin)/(meta.Ymax-meta.Ymin))*meta.PlotHeight
+	}
+
+	type arrow struct {
+		x, y, dx, dy, mag float64
+	}
+	arrows := make([]arrow, 0, gridN*gridN)
+	maxMag := 0.0
+	for i := 0; i < gridN; i++ {
+		for j := 0; j < gridN; j++ {
+			x := total * (float64(i) + 0.5) / gridN
+			y := total * (float64(j) + 0.5) / gridN
+
+			state := make(map[string]float64, len(prob.U0))
+			for k, v := range prob.U0 {
+				state[k] = v
+			}
+			state[xVar] = x
+			state[yVar] = y
+			du := prob.F(0, state)
+
+			mag := math.Hypot(du[xVar], du[yVar])
+			if mag > maxMag {
+				maxMag = mag
+			}
+			arrows = append(arrows, arrow{x, y, du[xVar], du[yVar], mag})
+		}
+	}
+
+	var overlay strings.Builder
+	overlay.WriteString(`<g class="vector-field">`)
+	cell := math.Min(meta.PlotWidth, meta.PlotHeight) / gridN
+	for _, a := range arrows {
+		if a.mag == 0 || maxMag == 0 {
+			continue
+		}
+		// Square-root scaling keeps slow regions visible next to fast ones.
+		length := cell * 0.85 * math.Sqrt(a.mag/maxMag)
+		ux, uy := a.dx/a.mag, a.dy/a.mag
+		x0, y0 := sx(a.x), sy(a.y)
+		// Screen Y grows downward, state Y upward.
+		x1, y1 := x0+ux*length, y0-uy*length
+		overlay.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#8899aa" stroke-width="1"/>`,
+			x0, y0, x1, y1))
+		// Arrowhead: two short barbs.
+		bx, by := -ux*3, uy*3
+		px, py := -uy*2, -ux*2
+		overlay.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#8899aa" stroke-width="1"/>`,
+			x1, y1, x1+bx+px, y1+by+py))
+		overlay.WriteString(fmt.Sprintf(`<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#8899aa" stroke-width="1"/>`,
+			x1, y1, x1+bx-px, y1+by-py))
+	}
+	overlay.WriteString(`</g>`)
+
+	idx := strings.LastIndex(svg, "</svg>")
+	if idx >= 0 {
+		svg = svg[:idx] + overlay.String() + svg[idx:]
+	}
+	return svg, meta
+}
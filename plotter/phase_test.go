@@ -0,0 +1,69 @@
+package plotter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+func sirSetup() (*solver.Problem, *solver.Solution) {
+	net, rates := petri.Build().SIR(999, 1, 0).WithRates(1.0)
+	prob := solver.NewProblem(net, net.SetState(nil), [2]float64{0, 10}, rates)
+	sol := solver.Solve(prob, solver.Tsit5(), solver.DefaultOptions())
+	return prob, sol
+}
+
+func TestPlotPhasePlane(t *testing.T) {
+	_, sol := sirSetup()
+
+	svg, meta := PlotPhasePlane(sol, "S", "I", 800, 600)
+
+	if !strings.HasPrefix(svg, "<svg") {
+		t.Fatal("Phase plane should produce SVG")
+	}
+	if !strings.Contains(svg, "Phase plane: I vs S") {
+		t.Error("Title missing")
+	}
+	if meta == nil {
+		t.Fatal("Metadata missing")
+	}
+	// S runs from ~999 down; the X range should reflect that, not time.
+	if meta.Xmax < 900 {
+		t.Errorf("X axis should span S values, Xmax=%f", meta.Xmax)
+	}
+}
+
+func TestPlotVectorField(t *testing.T) {
+	prob, sol := sirSetup()
+
+	svg, meta := PlotVectorField(prob, "S", "I", 800, 600, sol)
+
+	if !strings.Contains(svg, `class="vector-field"`) {
+		t.Fatal("Vector field overlay missing")
+	}
+	if strings.Count(svg, "<line") < 100 {
+		t.Error("Expected a grid of arrows")
+	}
+	if !strings.Contains(svg, "trajectory") {
+		t.Error("Trajectory overlay missing")
+	}
+	// Axes span the closed net's total population.
+	if meta.Xmax < 1000 {
+		t.Errorf("Field range should cover the population, Xmax=%f", meta.Xmax)
+	}
+}
+
+func TestPlotVectorFieldWithoutSolution(t *testing.T) {
+	prob, _ := sirSetup()
+
+	svg, _ := PlotVectorField(prob, "S", "I", 800, 600, nil)
+
+	if !strings.Contains(svg, `class="vector-field"`) {
+		t.Fatal("Vector field overlay missing")
+	}
+	if strings.Contains(svg, "trajectory") {
+		t.Error("No trajectory expected without a solution")
+	}
+}
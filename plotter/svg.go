@@ -17,8 +17,25 @@ type Series struct {
 	Y     []float64
 	Label string
 	Color string
+	// Scatter draws markers at each point instead of a connected line,
+	// for observed data as opposed to fitted curves.
+	Scatter bool
+	// Dashed draws the line with a dash pattern. Ignored for scatter.
+	Dashed bool
+	// RightAxis scales this series against the secondary Y axis.
+	RightAxis bool
 }
 
+// Legend placement options for SVGPlotter.LegendPos.
+const (
+	LegendTopRight      = "top-right" // inside the plot area (default)
+	LegendTopLeft       = "top-left"
+	LegendBottomRight   = "bottom-right"
+	LegendBottomLeft    = "bottom-left"
+	LegendOutsideRight  = "outside-right" // in the right margin
+	LegendOutsideBottom = "outside-bottom"
+)
+
 // PlotData contains metadata about the last rendered plot.
 // This can be used for interactive features like crosshairs and tooltips.
 type PlotData struct {
@@ -30,6 +47,9 @@ type PlotData struct {
 	Xmax       float64
 	Ymin       float64
 	Ymax       float64
+	Y2min      float64
+	Y2max      float64
+	LogY       bool
 	Series     []Series
 }
 
@@ -43,6 +63,9 @@ type SVGPlotter struct {
 	Title      string
 	XLabel     string
 	YLabel     string
+	Y2Label    string // secondary axis label; the axis appears when set or when a series uses it
+	LogY       bool   // log-scale primary Y axis (positive values only)
+	LegendPos  string // one of the Legend* constants; default LegendTopRight
 	Series     []Series
 	LastPlot   *PlotData
 }
@@ -84,35 +107,119 @@ func (p *SVGPlotter) SetYLabel(s string) *SVGPlotter {
 	return p
 }
 
-// AddSeries adds a data series to the plot.
+// SetY2Label sets the secondary (right) Y-axis label and enables that axis.
+func (p *SVGPlotter) SetY2Label(s string) *SVGPlotter {
+	p.Y2Label = s
+	return p
+}
+
+// SetLogY switches the primary Y axis to a base-10 log scale. Points with
+// non-positive values are skipped.
+func (p *SVGPlotter) SetLogY(enabled bool) *SVGPlotter {
+	p.LogY = enabled
+	return p
+}
+
+// SetLegendPos places the legend; see the Legend* constants. Outside
+// placements draw in the margins — widen Margin["right"] or
+// Margin["bottom"] if labels are long.
+func (p *SVGPlotter) SetLegendPos(pos string) *SVGPlotter {
+	p.LegendPos = pos
+	return p
+}
+
+// AddSeries adds a line series to the plot.
 // If color is empty, a default color from a palette will be used.
 func (p *SVGPlotter) AddSeries(x, y []float64, label, color string) *SVGPlotter {
-	if color == "" {
+	return p.addSeries(Series{X: x, Y: y, Label: label, Color: color})
+}
+
+// AddScatter adds a marker-only series, for observed data points as
+// distinct from fitted lines.
+func (p *SVGPlotter) AddScatter(x, y []float64, label, color string) *SVGPlotter {
+	return p.addSeries(Series{X: x, Y: y, Label: label, Color: color, Scatter: true})
+}
+
+// AddDashed adds a dashed line series.
+func (p *SVGPlotter) AddDashed(x, y []float64, label, color string) *SVGPlotter {
+	return p.addSeries(Series{X: x, Y: y, Label: label, Color: color, Dashed: true})
+}
+
+// AddSeriesRight adds a line series scaled against the secondary Y axis.
+func (p *SVGPlotter) AddSeriesRight(x, y []float64, label, color string) *SVGPlotter {
+	return p.addSeries(Series{X: x, Y: y, Label: label, Color: color, RightAxis: true})
+}
+
+// AddStyledSeries adds a fully specified series for combinations the
+// shorthand methods don't cover (e.g. dashed on the right axis).
+func (p *SVGPlotter) AddStyledSeries(s Series) *SVGPlotter {
+	return p.addSeries(s)
+}
+
+func (p *SVGPlotter) addSeries(s Series) *SVGPlotter {
+	if s.Color == "" {
 		colors := []string{"#e41a1c", "#377eb8", "#4daf4a", "#984ea3", "#ff7f00", "#ffff33", "#a65628", "#f781bf"}
-		color = colors[len(p.Series)%len(colors)]
+		s.Color = colors[len(p.Series)%len(colors)]
 	}
-	p.Series = append(p.Series, Series{X: x, Y: y, Label: label, Color: color})
+	p.Series = append(p.Series, s)
 	return p
 }
 
+// tY transforms a primary-axis value into plot space (identity or log10).
+// ok is false for points a log axis cannot show.
+func (p *SVGPlotter) tY(y float64) (float64, bool) {
+	if !p.LogY {
+		return y, true
+	}
+	if y <= 0 {
+		return 0, false
+	}
+	return math.Log10(y), true
+}
+
+// hasRightAxis reports whether a secondary axis should be drawn.
+func (p *SVGPlotter) hasRightAxis() bool {
+	if p.Y2Label != "" {
+		return true
+	}
+	for _, s := range p.Series {
+		if s.RightAxis {
+			return true
+		}
+	}
+	return false
+}
+
 // Render generates the SVG string and stores metadata in LastPlot.
 func (p *SVGPlotter) Render() string {
-	// Compute data ranges
-	xmin := math.Inf(1)
-	xmax := math.Inf(-1)
-	ymin := math.Inf(1)
-	ymax := math.Inf(-1)
+	// Compute data ranges; the right axis scales independently.
+	xmin, xmax := math.Inf(1), math.Inf(-1)
+	ymin, ymax := math.Inf(1), math.Inf(-1)
+	y2min, y2max := math.Inf(1), math.Inf(-1)
 
 	for _, s := range p.Series {
 		for i := range s.X {
 			x := s.X[i]
-			y := s.Y[i]
 			if x < xmin {
 				xmin = x
 			}
 			if x > xmax {
 				xmax = x
 			}
+			if s.RightAxis {
+				y := s.Y[i]
+				if y < y2min {
+					y2min = y
+				}
+				if y > y2max {
+					y2max = y
+				}
+				continue
+			}
+			y, ok := p.tY(s.Y[i])
+			if !ok {
+				continue
+			}
 			if y < ymin {
 				ymin = y
 			}
@@ -131,6 +238,10 @@ func (p *SVGPlotter) Render() string {
 		ymin = 0
 		ymax = 1
 	}
+	if math.IsInf(y2min, 1) || math.IsInf(y2max, -1) {
+		y2min = 0
+		y2max = 1
+	}
 
 	xrange := xmax - xmin
 	if xrange == 0 {
@@ -140,12 +251,18 @@ func (p *SVGPlotter) Render() string {
 	if yrange == 0 {
 		yrange = 1
 	}
+	y2range := y2max - y2min
+	if y2range == 0 {
+		y2range = 1
+	}
 
 	// Add padding
 	xmin -= xrange * 0.05
 	xmax += xrange * 0.05
 	ymin -= yrange * 0.1
 	ymax += yrange * 0.1
+	y2min -= y2range * 0.1
+	y2max += y2range * 0.1
 
 	// Scaling functions
 	sx := func(x float64) float64 {
@@ -154,6 +271,9 @@ func (p *SVGPlotter) Render() string {
 	sy := func(y float64) float64 {
 		return p.Margin["top"] + p.PlotHeight - ((y-ymin)/(ymax-ymin))*p.PlotHeight
 	}
+	sy2 := func(y float64) float64 {
+		return p.Margin["top"] + p.PlotHeight - ((y-y2min)/(y2max-y2min))*p.PlotHeight
+	}
 
 	plotID := "plot_" + strconv.FormatInt(int64(math.Round(1000000*math.Abs(xmin+xmax+ymin+ymax))), 10)
 
@@ -176,12 +296,22 @@ func (p *SVGPlotter) Render() string {
 		p.Margin["left"], p.Margin["top"], p.Margin["left"], p.Margin["top"]+p.PlotHeight))
 	sb.WriteString(fmt.Sprintf(`<line x1="%f" y1="%f" x2="%f" y2="%f" stroke="#333" stroke-width="2"/>`,
 		p.Margin["left"], p.Margin["top"]+p.PlotHeight, p.Margin["left"]+p.PlotWidth, p.Margin["top"]+p.PlotHeight))
+	rightAxis := p.hasRightAxis()
+	if rightAxis {
+		sb.WriteString(fmt.Sprintf(`<line x1="%f" y1="%f" x2="%f" y2="%f" stroke="#333" stroke-width="2"/>`,
+			p.Margin["left"]+p.PlotWidth, p.Margin["top"], p.Margin["left"]+p.PlotWidth, p.Margin["top"]+p.PlotHeight))
+	}
 
 	// Axis labels
 	sb.WriteString(fmt.Sprintf(`<text x="%f" y="%f" text-anchor="middle" font-family="Arial, sans-serif" font-size="12">%s</text>`,
 		p.Margin["left"]+p.PlotWidth/2, p.Height-10, petri.Escape(p.XLabel)))
 	sb.WriteString(fmt.Sprintf(`<text x="15" y="%f" text-anchor="middle" font-family="Arial, sans-serif" font-size="12" transform="rotate(-90, 15, %f)">%s</text>`,
 		p.Margin["top"]+p.PlotHeight/2, p.Margin["top"]+p.PlotHeight/2, petri.Escape(p.YLabel)))
+	if p.Y2Label != "" {
+		x := p.Width - 15
+		sb.WriteString(fmt.Sprintf(`<text x="%f" y="%f" text-anchor="middle" font-family="Arial, sans-serif" font-size="12" transform="rotate(90, %f, %f)">%s</text>`,
+			x, p.Margin["top"]+p.PlotHeight/2, x, p.Margin["top"]+p.PlotHeight/2, petri.Escape(p.Y2Label)))
+	}
 
 	// Grid and ticks
 	numXTicks := 5
@@ -201,55 +331,76 @@ func (p *SVGPlotter) Render() string {
 		py := sy(y)
 		sb.WriteString(fmt.Sprintf(`<line x1="%f" y1="%f" x2="%f" y2="%f" stroke="#333" stroke-width="1"/>`,
 			p.Margin["left"]-5, py, p.Margin["left"], py))
-		sb.WriteString(fmt.Sprintf(`<text x="%f" y="%f" text-anchor="end" font-family="Arial, sans-serif" font-size="10">%.1f</text>`,
-			p.Margin["left"]-10, py+4, y))
+		sb.WriteString(fmt.Sprintf(`<text x="%f" y="%f" text-anchor="end" font-family="Arial, sans-serif" font-size="10">%s</text>`,
+			p.Margin["left"]-10, py+4, p.formatYTick(y)))
 		sb.WriteString(fmt.Sprintf(`<line x1="%f" y1="%f" x2="%f" y2="%f" stroke="#ddd" stroke-width="0.5"/>`,
 			p.Margin["left"], py, p.Margin["left"]+p.PlotWidth, py))
 	}
+	if rightAxis {
+		for i := 0; i <= numYTicks; i++ {
+			y := y2min + (y2max-y2min)*float64(i)/float64(numYTicks)
+			py := sy2(y)
+			axisX := p.Margin["left"] + p.PlotWidth
+			sb.WriteString(fmt.Sprintf(`<line x1="%f" y1="%f" x2="%f" y2="%f" stroke="#333" stroke-width="1"/>`,
+				axisX, py, axisX+5, py))
+			sb.WriteString(fmt.Sprintf(`<text x="%f" y="%f" text-anchor="start" font-family="Arial, sans-serif" font-size="10">%.1f</text>`,
+				axisX+10, py+4, y))
+		}
+	}
 
 	// Plot series
 	for _, s := range p.Series {
 		if len(s.X) == 0 {
 			continue
 		}
+		scaleY := func(y float64) (float64, bool) {
+			if s.RightAxis {
+				return sy2(y), true
+			}
+			ty, ok := p.tY(y)
+			if !ok {
+				return 0, false
+			}
+			return sy(ty), true
+		}
+		if s.Scatter {
+			for i := range s.X {
+				py, ok := scaleY(s.Y[i])
+				if !ok {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf(`<circle cx="%f" cy="%f" r="3.5" fill="%s" stroke="white" stroke-width="1"/>`,
+					sx(s.X[i]), py, s.Color))
+			}
+			continue
+		}
 		path := strings.Builder{}
+		penDown := false
 		for i := range s.X {
+			py, ok := scaleY(s.Y[i])
+			if !ok {
+				// Log axis gap: lift the pen across unplottable points.
+				penDown = false
+				continue
+			}
 			px := sx(s.X[i])
-			py := sy(s.Y[i])
-			if i == 0 {
+			if !penDown {
 				path.WriteString(fmt.Sprintf("M%f,%f", px, py))
+				penDown = true
 			} else {
 				path.WriteString(fmt.Sprintf(" L%f,%f", px, py))
 			}
 		}
-		sb.WriteString(fmt.Sprintf(`<path d="%s" stroke="%s" stroke-width="2" fill="none"/>`,
-			path.String(), s.Color))
-	}
-
-	// Legend
-	hasLabel := false
-	for _, s := range p.Series {
-		if s.Label != "" {
-			hasLabel = true
-			break
-		}
-	}
-	if hasLabel {
-		legendY := p.Margin["top"] + 10
-		for _, s := range p.Series {
-			if s.Label == "" {
-				continue
-			}
-			x1 := p.Width - p.Margin["right"] - 50
-			x2 := p.Width - p.Margin["right"] - 30
-			sb.WriteString(fmt.Sprintf(`<line x1="%f" y1="%f" x2="%f" y2="%f" stroke="%s" stroke-width="2"/>`,
-				x1, legendY, x2, legendY, s.Color))
-			sb.WriteString(fmt.Sprintf(`<text x="%f" y="%f" font-family="Arial, sans-serif" font-size="10">%s</text>`,
-				x2+5, legendY+4, petri.Escape(s.Label)))
-			legendY += 20
+		dash := ""
+		if s.Dashed {
+			dash = ` stroke-dasharray="6,4"`
 		}
+		sb.WriteString(fmt.Sprintf(`<path d="%s" stroke="%s" stroke-width="2" fill="none"%s/>`,
+			path.String(), s.Color, dash))
 	}
 
+	p.renderLegend(&sb, rightAxis)
+
 	// Crosshair group for interactivity (hidden by default)
 	sb.WriteString(fmt.Sprintf(`<g id="%s_crosshair" style="display:none;">`, plotID))
 	sb.WriteString(fmt.Sprintf(`<line id="%s_line" x1="0" y1="%f" x2="0" y2="%f" stroke="#666" stroke-width="1" stroke-dasharray="4,4"/>`,
@@ -274,12 +425,103 @@ func (p *SVGPlotter) Render() string {
 		Xmax:       xmax,
 		Ymin:       ymin,
 		Ymax:       ymax,
+		Y2min:      y2min,
+		Y2max:      y2max,
+		LogY:       p.LogY,
 		Series:     p.Series,
 	}
 
 	return sb.String()
 }
 
+// formatYTick formats a primary-axis tick label. On a log axis the tick is
+// in log10 space, so show the actual value.
+func (p *SVGPlotter) formatYTick(y float64) string {
+	if p.LogY {
+		return fmt.Sprintf("%.3g", math.Pow(10, y))
+	}
+	return fmt.Sprintf("%.1f", y)
+}
+
+// renderLegend draws labeled series keys at the configured position.
+func (p *SVGPlotter) renderLegend(sb *strings.Builder, rightAxis bool) {
+	var labeled []Series
+	for _, s := range p.Series {
+		if s.Label != "" {
+			labeled = append(labeled, s)
+		}
+	}
+	if len(labeled) == 0 {
+		return
+	}
+
+	pos := p.LegendPos
+	if pos == "" {
+		pos = LegendTopRight
+	}
+
+	// Horizontal layout under the plot; everything else stacks vertically.
+	if pos == LegendOutsideBottom {
+		x := p.Margin["left"]
+		y := p.Margin["top"] + p.PlotHeight + 35
+		for _, s := range labeled {
+			p.legendKey(sb, &x, y, s, true)
+		}
+		return
+	}
+
+	rowH := 20.0
+	blockH := float64(len(labeled)) * rowH
+	var x, y float64
+	switch pos {
+	case LegendTopLeft:
+		x = p.Margin["left"] + 10
+		y = p.Margin["top"] + 10
+	case LegendBottomLeft:
+		x = p.Margin["left"] + 10
+		y = p.Margin["top"] + p.PlotHeight - blockH
+	case LegendBottomRight:
+		x = p.Margin["left"] + p.PlotWidth - 50
+		y = p.Margin["top"] + p.PlotHeight - blockH
+	case LegendOutsideRight:
+		x = p.Margin["left"] + p.PlotWidth + 10
+		if rightAxis {
+			x += 40 // clear the secondary axis tick labels
+		}
+		y = p.Margin["top"] + 10
+	default: // LegendTopRight: matches the historical placement
+		x = p.Width - p.Margin["right"] - 50
+		y = p.Margin["top"] + 10
+	}
+	for _, s := range labeled {
+		keyX := x
+		p.legendKey(sb, &keyX, y, s, false)
+		y += rowH
+	}
+}
+
+// legendKey draws one legend entry at (x, y); when flowing horizontally it
+// advances x past the drawn entry.
+func (p *SVGPlotter) legendKey(sb *strings.Builder, x *float64, y float64, s Series, flow bool) {
+	x1, x2 := *x, *x+20
+	if s.Scatter {
+		sb.WriteString(fmt.Sprintf(`<circle cx="%f" cy="%f" r="3.5" fill="%s" stroke="white" stroke-width="1"/>`,
+			(x1+x2)/2, y, s.Color))
+	} else {
+		dash := ""
+		if s.Dashed {
+			dash = ` stroke-dasharray="6,4"`
+		}
+		sb.WriteString(fmt.Sprintf(`<line x1="%f" y1="%f" x2="%f" y2="%f" stroke="%s" stroke-width="2"%s/>`,
+			x1, y, x2, y, s.Color, dash))
+	}
+	sb.WriteString(fmt.Sprintf(`<text x="%f" y="%f" font-family="Arial, sans-serif" font-size="10">%s</text>`,
+		x2+5, y+4, petri.Escape(s.Label)))
+	if flow {
+		*x = x2 + 5 + float64(len(s.Label))*6 + 15
+	}
+}
+
 // PlotSolution is a convenience function to plot an ODE solution.
 // If variables is nil, all state variables will be plotted.
 func PlotSolution(sol *solver.Solution, variables []string, width, height float64, title, xlabel, ylabel string) (string, *PlotData) {
@@ -306,3 +548,55 @@ func PlotSolution(sol *solver.Solution, variables []string, width, height float6
 	svg := plotter.Render()
 	return svg, plotter.LastPlot
 }
+
+// PlotSolutionUnits plots an ODE solution with axis labels derived from
+// the net's unit annotations. When every plotted place shares one unit it
+// appears on the Y axis; with mixed units each series label carries its
+// own unit instead. A model-wide rate unit of the form "per-<x>" labels
+// the time axis. If variables is nil, all state variables are plotted.
+func PlotSolutionUnits(net *petri.PetriNet, sol *solver.Solution, variables []string, width, height float64, title string) (string, *PlotData) {
+	varsToPlot := variables
+	if varsToPlot == nil {
+		varsToPlot = sol.StateLabels
+	}
+
+	// Collect the distinct units of the plotted places.
+	units := make(map[string]bool)
+	unitOf := make(map[string]string, len(varsToPlot))
+	for _, vn := range varsToPlot {
+		if p, ok := net.Places[vn]; ok && p.Unit != "" {
+			units[p.Unit] = true
+			unitOf[vn] = p.Unit
+		}
+	}
+
+	ylabel := "Value"
+	mixed := len(units) > 1
+	if len(units) == 1 {
+		for u := range units {
+			ylabel = fmt.Sprintf("Value (%s)", u)
+		}
+	}
+
+	xlabel := "Time"
+	if rate := net.RateUnit(); strings.HasPrefix(rate, "per-") {
+		xlabel = fmt.Sprintf("Time (%s)", strings.TrimPrefix(rate, "per-"))
+	}
+
+	plotter := NewSVGPlotter(width, height)
+	if title != "" {
+		plotter.SetTitle(title)
+	}
+	plotter.SetXLabel(xlabel).SetYLabel(ylabel)
+
+	for _, vn := range varsToPlot {
+		label := vn
+		if mixed && unitOf[vn] != "" {
+			label = fmt.Sprintf("%s (%s)", vn, unitOf[vn])
+		}
+		plotter.AddSeries(sol.T, sol.GetVariable(vn), label, "")
+	}
+
+	svg := plotter.Render()
+	return svg, plotter.LastPlot
+}
@@ -326,3 +326,142 @@ func TestIntegrationWithPetriNet(t *testing.T) {
 		}
 	}
 }
+
+func TestRenderScatter(t *testing.T) {
+	plotter := NewSVGPlotter(800, 600)
+	plotter.AddScatter([]float64{0, 1, 2}, []float64{1, 3, 2}, "observed", "#e41a1c")
+
+	svg := plotter.Render()
+
+	if !strings.Contains(svg, "<circle") {
+		t.Error("Scatter series should render circle markers")
+	}
+	if strings.Contains(svg, `<path d="M`) {
+		t.Error("Scatter series should not render a connecting path")
+	}
+}
+
+func TestRenderDashed(t *testing.T) {
+	plotter := NewSVGPlotter(800, 600)
+	plotter.AddDashed([]float64{0, 1, 2}, []float64{1, 2, 3}, "fit", "#377eb8")
+
+	svg := plotter.Render()
+
+	if !strings.Contains(svg, `stroke-dasharray="6,4"`) {
+		t.Error("Dashed series should set a dash pattern")
+	}
+}
+
+func TestRenderLogY(t *testing.T) {
+	plotter := NewSVGPlotter(800, 600)
+	plotter.SetLogY(true)
+	plotter.AddSeries([]float64{0, 1, 2, 3}, []float64{1, 10, 100, 1000}, "cases", "")
+
+	svg := plotter.Render()
+
+	if plotter.LastPlot == nil || !plotter.LastPlot.LogY {
+		t.Fatal("LastPlot should record log scale")
+	}
+	// The axis range is stored in log10 space: roughly [0, 3] plus padding.
+	if plotter.LastPlot.Ymax > 4 || plotter.LastPlot.Ymin < -1 {
+		t.Errorf("Log-space range looks wrong: [%f, %f]",
+			plotter.LastPlot.Ymin, plotter.LastPlot.Ymax)
+	}
+	// Tick labels show actual values, not log-space exponents, so the top
+	// of the padded range lands near 2000.
+	if !strings.Contains(svg, "e+03") {
+		t.Error("Log axis should label ticks with actual values")
+	}
+}
+
+func TestRenderLogYSkipsNonPositive(t *testing.T) {
+	plotter := NewSVGPlotter(800, 600)
+	plotter.SetLogY(true)
+	plotter.AddSeries([]float64{0, 1, 2}, []float64{0, 10, 100}, "cases", "")
+
+	svg := plotter.Render()
+
+	if !strings.HasPrefix(svg, "<svg") {
+		t.Fatal("Plot with non-positive values should still render")
+	}
+	if plotter.LastPlot.Ymin < 0.8 {
+		t.Errorf("Zero value should be excluded from the log range, Ymin=%f",
+			plotter.LastPlot.Ymin)
+	}
+}
+
+func TestRenderRightAxis(t *testing.T) {
+	plotter := NewSVGPlotter(800, 600)
+	plotter.SetY2Label("Rate")
+	plotter.AddSeries([]float64{0, 1, 2}, []float64{100, 200, 300}, "count", "")
+	plotter.AddSeriesRight([]float64{0, 1, 2}, []float64{0.1, 0.5, 0.2}, "rate", "")
+
+	svg := plotter.Render()
+
+	if !strings.Contains(svg, "Rate") {
+		t.Error("Secondary axis label missing")
+	}
+	if plotter.LastPlot.Y2max < 0.5 || plotter.LastPlot.Y2max > 1 {
+		t.Errorf("Secondary axis range should follow its own series, Y2max=%f",
+			plotter.LastPlot.Y2max)
+	}
+	// The primary range must not absorb the right-axis series.
+	if plotter.LastPlot.Ymin > 100 || plotter.LastPlot.Ymax < 300 {
+		t.Errorf("Primary range wrong: [%f, %f]",
+			plotter.LastPlot.Ymin, plotter.LastPlot.Ymax)
+	}
+}
+
+func TestLegendPlacements(t *testing.T) {
+	for _, pos := range []string{
+		LegendTopRight, LegendTopLeft, LegendBottomRight,
+		LegendBottomLeft, LegendOutsideRight, LegendOutsideBottom,
+	} {
+		plotter := NewSVGPlotter(800, 600)
+		plotter.SetLegendPos(pos)
+		plotter.AddSeries([]float64{0, 1}, []float64{0, 1}, "series", "")
+
+		svg := plotter.Render()
+		if !strings.Contains(svg, "series") {
+			t.Errorf("Legend missing at position %s", pos)
+		}
+	}
+}
+
+func TestPlotSolutionUnits(t *testing.T) {
+	net := petri.Build().
+		Place("water", 500).Place("water_used", 0).
+		Transition("pour").
+		Arc("water", "pour", 1).Arc("pour", "water_used", 1).
+		Unit("water", "ml").Unit("water_used", "ml").
+		RateUnit("pour", "per-minute").
+		Done()
+
+	sol := &solver.Solution{
+		T: []float64{0, 1, 2},
+		U: []map[string]float64{
+			{"water": 500, "water_used": 0},
+			{"water": 400, "water_used": 100},
+			{"water": 300, "water_used": 200},
+		},
+		StateLabels: []string{"water", "water_used"},
+	}
+
+	svg, _ := PlotSolutionUnits(net, sol, nil, 640, 480, "Water")
+	if !strings.Contains(svg, "Value (ml)") {
+		t.Error("Expected shared unit on the Y axis")
+	}
+	if !strings.Contains(svg, "Time (minute)") {
+		t.Error("Expected rate unit on the time axis")
+	}
+
+	// Mixed units move onto the series labels instead.
+	net.SetPlaceUnit("water_used", "g")
+	svg, _ = PlotSolutionUnits(net, sol, nil, 640, 480, "Water")
+	if strings.Contains(svg, "Value (") {
+		t.Error("Expected generic Y label for mixed units")
+	}
+	if !strings.Contains(svg, "water (ml)") || !strings.Contains(svg, "water_used (g)") {
+		t.Error("Expected per-series unit suffixes for mixed units")
+	}
+}
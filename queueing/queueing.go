@@ -0,0 +1,181 @@
+// Package queueing interprets a Petri net plus rates as an open queueing
+// network and reports utilization, expected queue lengths, and throughput
+// per transition using a Jackson-network style approximation. Transitions
+// act as exponential single-server stations with service rate rates[t];
+// external work enters through arrival streams on places. This gives quick
+// capacity-planning numbers for SLA scenarios (coffeeshop, incident
+// handling) without running and eyeballing an ODE simulation.
+package queueing
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Analyzer configures a queueing analysis of a net.
+type Analyzer struct {
+	net      *petri.PetriNet
+	rates    map[string]float64 // service rate per transition
+	arrivals map[string]float64 // external arrival rate per place
+}
+
+// New creates a queueing analyzer for the given net and service rates.
+// Transitions missing from rates default to 1.0.
+func New(net *petri.PetriNet, rates map[string]float64) *Analyzer {
+	return &Analyzer{
+		net:      net,
+		rates:    net.SetRates(rates),
+		arrivals: make(map[string]float64),
+	}
+}
+
+// WithArrival adds an external Poisson arrival stream of the given rate
+// (tokens per time unit) into a place.
+func (a *Analyzer) WithArrival(place string, rate float64) *Analyzer {
+	a.arrivals[place] = rate
+	return a
+}
+
+// Station holds steady-state M/M/1 metrics for one transition.
+type Station struct {
+	Transition  string
+	ArrivalRate float64 // λ: work arriving at this station
+	ServiceRate float64 // μ: configured service rate
+	Utilization float64 // ρ = λ/μ
+	QueueLength float64 // L: expected jobs in system (queue + service)
+	WaitTime    float64 // W: expected time in system, L/λ
+	Stable      bool    // λ < μ
+}
+
+// Report is the result of a queueing analysis.
+type Report struct {
+	Stations   map[string]*Station
+	Throughput map[string]float64 // per-transition completion rate
+	Bottleneck string             // transition with the highest utilization
+	Stable     bool               // all stations stable
+}
+
+// Analyze solves the traffic equations and computes per-station metrics.
+// It fails if no arrival stream was configured, since a network without
+// external work has nothing to plan capacity for.
+func (a *Analyzer) Analyze() (*Report, error) {
+	if len(a.arrivals) == 0 {
+		return nil, fmt.Errorf("no external arrivals configured; use WithArrival")
+	}
+	for place := range a.arrivals {
+		if _, ok := a.net.Places[place]; !ok {
+			return nil, fmt.Errorf("arrival place %q not in net", place)
+		}
+	}
+
+	im := a.net.IncidenceMatrix()
+
+	// Consumers of each place, for routing splits. When several transitions
+	// compete for a place, flow is split proportionally to service rates, as
+	// in the race semantics of stochastic nets.
+	consumers := make(map[string][]string)
+	for _, t := range im.Transitions {
+		for _, p := range im.Places {
+			if im.PreWeight(p, t) > 0 {
+				consumers[p] = append(consumers[p], t)
+			}
+		}
+	}
+
+	// Fixed-point iteration on the traffic equations: flow into a place is
+	// external arrivals plus upstream production; a transition's arrival
+	// rate is its routed share, limited by its scarcest input (join
+	// synchronization).
+	lambda := make(map[string]float64, len(im.Transitions))
+	for iter := 0; iter < 1000; iter++ {
+		inflow := make(map[string]float64, len(im.Places))
+		for _, p := range im.Places {
+			inflow[p] = a.arrivals[p]
+			for _, t := range im.Transitions {
+				inflow[p] += lambda[t] * im.PostWeight(p, t)
+			}
+		}
+
+		delta := 0.0
+		for _, t := range im.Transitions {
+			next := math.Inf(1)
+			hasInput := false
+			for _, p := range im.Places {
+				w := im.PreWeight(p, t)
+				if w == 0 {
+					continue
+				}
+				hasInput = true
+				share := a.routingShare(consumers[p], t)
+				if supply := share * inflow[p] / w; supply < next {
+					next = supply
+				}
+			}
+			if !hasInput {
+				next = 0 // source transitions carry no external work here
+			}
+			delta += math.Abs(next - lambda[t])
+			lambda[t] = next
+		}
+		if delta < 1e-12 {
+			break
+		}
+	}
+
+	report := &Report{
+		Stations:   make(map[string]*Station, len(im.Transitions)),
+		Throughput: make(map[string]float64, len(im.Transitions)),
+		Stable:     true,
+	}
+	maxUtil := 0.0
+	for _, t := range im.Transitions {
+		mu := a.rates[t]
+		s := &Station{
+			Transition:  t,
+			ArrivalRate: lambda[t],
+			ServiceRate: mu,
+		}
+		if mu > 0 {
+			s.Utilization = s.ArrivalRate / mu
+		}
+		s.Stable = s.Utilization < 1
+		if s.Stable && s.Utilization > 0 {
+			s.QueueLength = s.Utilization / (1 - s.Utilization)
+			s.WaitTime = s.QueueLength / s.ArrivalRate
+		} else if !s.Stable {
+			s.QueueLength = math.Inf(1)
+			s.WaitTime = math.Inf(1)
+			report.Stable = false
+		}
+
+		report.Stations[t] = s
+		if s.Stable {
+			report.Throughput[t] = s.ArrivalRate
+		} else {
+			report.Throughput[t] = mu // saturated station completes at capacity
+		}
+		if s.Utilization > maxUtil {
+			maxUtil = s.Utilization
+			report.Bottleneck = t
+		}
+	}
+	return report, nil
+}
+
+// routingShare returns the fraction of a place's flow routed to transition
+// t when several consumers compete, split proportionally to service rates.
+func (a *Analyzer) routingShare(consumers []string, t string) float64 {
+	if len(consumers) <= 1 {
+		return 1.0
+	}
+	total := 0.0
+	for _, c := range consumers {
+		total += a.rates[c]
+	}
+	if total == 0 {
+		return 1.0 / float64(len(consumers))
+	}
+	return a.rates[t] / total
+}
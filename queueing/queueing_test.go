@@ -0,0 +1,107 @@
+package queueing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// tandemNet is a two-station line: orders flow through prep then serve.
+func tandemNet() *petri.PetriNet {
+	return petri.Build().
+		Chain(0, "orders", "prep", "ready", "serve", "done").
+		Done()
+}
+
+func TestTandemStations(t *testing.T) {
+	net := tandemNet()
+	report, err := New(net, map[string]float64{"prep": 2.0, "serve": 4.0}).
+		WithArrival("orders", 1.0).
+		Analyze()
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	prep := report.Stations["prep"]
+	if math.Abs(prep.Utilization-0.5) > 1e-9 {
+		t.Errorf("Expected prep utilization 0.5, got %f", prep.Utilization)
+	}
+	if math.Abs(prep.QueueLength-1.0) > 1e-9 {
+		t.Errorf("Expected prep queue length 1.0, got %f", prep.QueueLength)
+	}
+
+	serve := report.Stations["serve"]
+	if math.Abs(serve.Utilization-0.25) > 1e-9 {
+		t.Errorf("Expected serve utilization 0.25, got %f", serve.Utilization)
+	}
+	if math.Abs(serve.ArrivalRate-1.0) > 1e-9 {
+		t.Errorf("Expected serve throughput 1.0, got %f", serve.ArrivalRate)
+	}
+
+	if report.Bottleneck != "prep" {
+		t.Errorf("Expected bottleneck prep, got %q", report.Bottleneck)
+	}
+	if !report.Stable {
+		t.Error("Expected stable network")
+	}
+}
+
+func TestOverloadedStation(t *testing.T) {
+	net := tandemNet()
+	report, err := New(net, map[string]float64{"prep": 0.5, "serve": 4.0}).
+		WithArrival("orders", 1.0).
+		Analyze()
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	prep := report.Stations["prep"]
+	if prep.Stable {
+		t.Error("Expected prep to be unstable at utilization 2.0")
+	}
+	if !math.IsInf(prep.QueueLength, 1) {
+		t.Errorf("Expected infinite queue length, got %f", prep.QueueLength)
+	}
+	if report.Stable {
+		t.Error("Expected unstable network")
+	}
+	// A saturated station completes work at its capacity.
+	if math.Abs(report.Throughput["prep"]-0.5) > 1e-9 {
+		t.Errorf("Expected prep throughput 0.5, got %f", report.Throughput["prep"])
+	}
+}
+
+func TestRoutingSplit(t *testing.T) {
+	// Two competing consumers split flow proportionally to service rates.
+	net := petri.Build().
+		Place("in", 0).Place("a", 0).Place("b", 0).
+		Transition("fast").Transition("slow").
+		Arc("in", "fast", 1).Arc("fast", "a", 1).
+		Arc("in", "slow", 1).Arc("slow", "b", 1).
+		Done()
+
+	report, err := New(net, map[string]float64{"fast": 3.0, "slow": 1.0}).
+		WithArrival("in", 2.0).
+		Analyze()
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	if math.Abs(report.Stations["fast"].ArrivalRate-1.5) > 1e-9 {
+		t.Errorf("Expected fast arrival rate 1.5, got %f", report.Stations["fast"].ArrivalRate)
+	}
+	if math.Abs(report.Stations["slow"].ArrivalRate-0.5) > 1e-9 {
+		t.Errorf("Expected slow arrival rate 0.5, got %f", report.Stations["slow"].ArrivalRate)
+	}
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	net := tandemNet()
+	if _, err := New(net, nil).Analyze(); err == nil {
+		t.Error("Expected error without arrival streams")
+	}
+	if _, err := New(net, nil).WithArrival("missing", 1.0).Analyze(); err == nil {
+		t.Error("Expected error for unknown arrival place")
+	}
+}
@@ -1,15 +1,34 @@
 package reachability
 
 import (
+	"context"
+
 	"github.com/pflow-xyz/go-pflow/petri"
 )
 
 // Analyzer performs reachability analysis on Petri nets.
 type Analyzer struct {
-	net       *petri.PetriNet
-	initial   Marking
-	maxStates int
-	maxTokens int
+	net           *petri.PetriNet
+	initial       Marking
+	maxStates     int
+	maxTokens     int
+	symmetries    *Symmetries     // non-nil when symmetry reduction is enabled
+	workers       int             // >1 enables parallel frontier exploration
+	deterministic bool            // reproducible ordering in parallel mode
+	ctx           context.Context // cooperative cancellation; nil = never canceled
+	progress      func(Progress)  // optional exploration progress callback
+}
+
+// Progress is a snapshot of state space exploration handed to the callback
+// installed with WithProgress. The full state space size is unknowable up
+// front, so Percent measures consumption of the state budget (maxStates)
+// rather than true completion; it jumps to 100 when exploration finishes.
+type Progress struct {
+	StatesExplored int     // States added to the graph so far
+	StatesLimit    int     // Configured state budget
+	QueueLen       int     // Markings waiting to be expanded
+	Percent        float64 // 0-100, fraction of the state budget used
+	Done           bool    // True for the final report after exploration stops
 }
 
 // NewAnalyzer creates a new reachability analyzer.
@@ -46,6 +65,52 @@ func (a *Analyzer) WithMaxTokens(max int) *Analyzer {
 	return a
 }
 
+// WithContext makes exploration stop cooperatively when the context is
+// canceled or its deadline passes. The partial graph explored so far is
+// returned with Truncated set.
+func (a *Analyzer) WithContext(ctx context.Context) *Analyzer {
+	a.ctx = ctx
+	return a
+}
+
+// WithProgress installs a callback that receives exploration snapshots:
+// every progressStride states in the sequential explorer, once per level in
+// the parallel one, and a final Done report when exploration stops. The
+// callback runs on the exploring goroutine, so it should be cheap.
+func (a *Analyzer) WithProgress(fn func(Progress)) *Analyzer {
+	a.progress = fn
+	return a
+}
+
+// progressStride is how many new states the sequential explorer adds
+// between progress reports.
+const progressStride = 100
+
+// report delivers a progress snapshot to the configured callback, if any.
+func (a *Analyzer) report(explored, queueLen int, done bool) {
+	if a.progress == nil {
+		return
+	}
+	pct := 0.0
+	if done {
+		pct = 100
+	} else if a.maxStates > 0 {
+		pct = float64(explored) / float64(a.maxStates) * 100
+	}
+	a.progress(Progress{
+		StatesExplored: explored,
+		StatesLimit:    a.maxStates,
+		QueueLen:       queueLen,
+		Percent:        pct,
+		Done:           done,
+	})
+}
+
+// canceled reports whether the analyzer's context has ended.
+func (a *Analyzer) canceled() bool {
+	return a.ctx != nil && a.ctx.Err() != nil
+}
+
 // Result contains the results of reachability analysis.
 type Result struct {
 	Graph       *Graph
@@ -90,8 +155,12 @@ type ExplorationStats struct {
 	ExplorationRatio float64 // Fraction of estimated total states explored
 }
 
-// BuildGraph constructs the reachability graph using BFS.
+// BuildGraph constructs the reachability graph using BFS. With WithWorkers
+// set above one, exploration proceeds level-parallel across goroutines.
 func (a *Analyzer) BuildGraph() *Result {
+	if a.workers > 1 {
+		return a.buildGraphParallel()
+	}
 	graph := NewGraph(a.net, a.initial)
 	result := &Result{
 		Graph:     graph,
@@ -104,13 +173,20 @@ func (a *Analyzer) BuildGraph() *Result {
 	}
 
 	// BFS exploration
-	queue := []Marking{a.initial}
-	graph.AddState(a.initial)
+	initial := a.canonical(a.initial)
+	queue := []Marking{initial}
+	graph.AddState(initial)
 	maxQueueSize := 1
 	totalEnabled := 0
 	statesWithEnabled := 0
+	lastReported := 0
 
 	for len(queue) > 0 && graph.StateCount() < a.maxStates {
+		if a.canceled() {
+			result.Truncated = true
+			result.TruncateMsg = "canceled: " + a.ctx.Err().Error()
+			break
+		}
 		current := queue[0]
 		queue = queue[1:]
 
@@ -131,6 +207,7 @@ func (a *Analyzer) BuildGraph() *Result {
 			if newMarking == nil {
 				continue
 			}
+			newMarking = a.canonical(newMarking)
 
 			// Check for unboundedness
 			if newMarking.Max() > a.maxTokens {
@@ -157,8 +234,25 @@ func (a *Analyzer) BuildGraph() *Result {
 		if result.Truncated {
 			break
 		}
+
+		if graph.StateCount()-lastReported >= progressStride {
+			lastReported = graph.StateCount()
+			a.report(lastReported, len(queue), false)
+		}
 	}
 
+	a.finalizeResult(result, maxQueueSize, totalEnabled, statesWithEnabled)
+
+	return result
+}
+
+// finalizeResult fills in truncation status, statistics, and deadlock
+// detection once exploration has stopped. Shared by the sequential and
+// parallel explorers.
+func (a *Analyzer) finalizeResult(result *Result, maxQueueSize, totalEnabled, statesWithEnabled int) {
+	graph := result.Graph
+	a.report(graph.StateCount(), 0, true)
+
 	// Check if truncated due to state limit
 	if graph.StateCount() >= a.maxStates && !result.Truncated {
 		result.Truncated = true
@@ -210,8 +304,15 @@ func (a *Analyzer) BuildGraph() *Result {
 			result.Deadlocks = append(result.Deadlocks, state)
 		}
 	}
+}
 
-	return result
+// canonical maps a marking to its symmetry-orbit representative when
+// symmetry reduction is enabled, and returns it unchanged otherwise.
+func (a *Analyzer) canonical(m Marking) Marking {
+	if a.symmetries == nil {
+		return m
+	}
+	return a.symmetries.CanonicalMarking(m)
 }
 
 // Analyze performs full reachability analysis including cycle and liveness detection.
@@ -129,11 +129,27 @@ func (g *Graph) findEnabled(marking Marking) []string {
 
 // isEnabled checks if a transition can fire.
 func (g *Graph) isEnabled(marking Marking, transName string) bool {
+	// State map for expression evaluation, built only when needed.
+	var state map[string]float64
+	lazyState := func() map[string]float64 {
+		if state == nil {
+			state = marking.ToState()
+		}
+		return state
+	}
+
 	// Check all input arcs
 	for _, arc := range g.Net.Arcs {
 		if arc.Target == transName {
 			tokens := marking.Get(arc.Source)
 			required := int(arc.GetWeightSum())
+			if arc.Expression != "" {
+				w, err := arc.EvalWeight(lazyState())
+				if err != nil {
+					return false
+				}
+				required = int(w)
+			}
 
 			// Normal arc: need enough tokens
 			if !arc.InhibitTransition && tokens < required {
@@ -145,9 +161,61 @@ func (g *Graph) isEnabled(marking Marking, transName string) bool {
 			}
 		}
 	}
+
+	// Capacity check: a firing may not push a blocking place past its
+	// capacity. Computed on the net effect so self-loops that consume
+	// and produce from the same place are judged fairly.
+	for place, produced := range g.firingDelta(marking, transName, lazyState) {
+		p, ok := g.Net.Places[place]
+		if !ok || p.Overflow != petri.OverflowBlock {
+			continue
+		}
+		capacity := int(p.GetCapacity())
+		if capacity > 0 && marking.Get(place)+produced > capacity {
+			return false
+		}
+	}
+
+	// Check the guard last; an erroring guard disables the transition.
+	if t, ok := g.Net.Transitions[transName]; ok && t.Guard != "" {
+		satisfied, err := g.Net.GuardSatisfied(transName, lazyState())
+		if err != nil || !satisfied {
+			return false
+		}
+	}
 	return true
 }
 
+// firingDelta computes the net token change per output place if the
+// transition fired, counting consumption from the same place. Only places
+// with capacities need it, so it returns nil when none are declared.
+func (g *Graph) firingDelta(marking Marking, transName string, lazyState func() map[string]float64) map[string]int {
+	if !g.Net.EnforcesCapacities() {
+		return nil
+	}
+	delta := make(map[string]int)
+	for _, arc := range g.Net.Arcs {
+		if arc.InhibitTransition {
+			continue
+		}
+		weight := int(arc.GetWeightSum())
+		if arc.Expression != "" {
+			w, err := arc.EvalWeight(lazyState())
+			if err != nil {
+				continue
+			}
+			weight = int(w)
+		}
+		if arc.Source == transName {
+			delta[arc.Target] += weight
+		}
+		if arc.Target == transName {
+			delta[arc.Source] -= weight
+		}
+	}
+	return delta
+}
+
 // Fire fires a transition and returns the new marking.
 // Returns nil if the transition is not enabled.
 func (g *Graph) Fire(marking Marking, transName string) Marking {
@@ -157,17 +225,44 @@ func (g *Graph) Fire(marking Marking, transName string) Marking {
 
 	newMarking := marking.Copy()
 
+	// Expression weights are evaluated against the pre-firing marking.
+	var state map[string]float64
+	arcWeight := func(arc *petri.Arc) int {
+		if arc.Expression == "" {
+			return int(arc.GetWeightSum())
+		}
+		if state == nil {
+			state = marking.ToState()
+		}
+		w, err := arc.EvalWeight(state)
+		if err != nil {
+			return 0
+		}
+		return int(w)
+	}
+
 	// Remove tokens from input places
 	for _, arc := range g.Net.Arcs {
 		if arc.Target == transName && !arc.InhibitTransition {
-			newMarking.Sub(arc.Source, int(arc.GetWeightSum()))
+			newMarking.Sub(arc.Source, arcWeight(arc))
 		}
 	}
 
 	// Add tokens to output places
 	for _, arc := range g.Net.Arcs {
 		if arc.Source == transName {
-			newMarking.Add(arc.Target, int(arc.GetWeightSum()))
+			newMarking.Add(arc.Target, arcWeight(arc))
+		}
+	}
+
+	// Discard-policy places shed any excess and sit at capacity.
+	for place, tokens := range newMarking {
+		p, ok := g.Net.Places[place]
+		if !ok || p.Overflow != petri.OverflowDiscard {
+			continue
+		}
+		if capacity := int(p.GetCapacity()); capacity > 0 && tokens > capacity {
+			newMarking[place] = capacity
 		}
 	}
 
@@ -0,0 +1,70 @@
+package reachability
+
+import (
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// TestGuardLimitsStateSpace checks that a guarded transition stops firing
+// once its guard becomes false, producing a smaller state space.
+func TestGuardLimitsStateSpace(t *testing.T) {
+	net := petri.Build().
+		Place("pool", 3).Place("done", 0).
+		Transition("take").
+		Arc("pool", "take", 1).Arc("take", "done", 1).
+		Done()
+	if err := net.SetGuard("take", "done < 2"); err != nil {
+		t.Fatalf("SetGuard failed: %v", err)
+	}
+
+	result := NewAnalyzer(net).Analyze()
+
+	// Without the guard the pool drains fully (4 states); the guard stops
+	// firing once done reaches 2.
+	if result.StateCount != 3 {
+		t.Errorf("Expected 3 states with guard, got %d", result.StateCount)
+	}
+
+	for _, deadlock := range result.Deadlocks {
+		if deadlock.Marking["done"] != 2 || deadlock.Marking["pool"] != 1 {
+			t.Errorf("Expected terminal marking pool=1 done=2, got %v", deadlock.Marking)
+		}
+	}
+}
+
+// TestExpressionArcWeight checks that a marking-dependent arc weight is
+// evaluated against the pre-firing marking when exploring.
+func TestExpressionArcWeight(t *testing.T) {
+	net := petri.Build().
+		Place("pool", 4).Place("done", 0).
+		Transition("drain").
+		Arc("pool", "drain", 1).Arc("drain", "done", 1).
+		Done()
+	// Consume the whole pool in one firing; produce the same amount.
+	if err := net.SetArcExpression("pool", "drain", "pool"); err != nil {
+		t.Fatalf("SetArcExpression failed: %v", err)
+	}
+	if err := net.SetArcExpression("drain", "done", "pool"); err != nil {
+		t.Fatalf("SetArcExpression failed: %v", err)
+	}
+
+	graph := NewGraph(net, NewMarking(net.SetState(nil)))
+	initial := graph.Initial
+
+	next := graph.Fire(initial, "drain")
+	if next == nil {
+		t.Fatal("Expected drain to be enabled")
+	}
+	if next.Get("pool") != 0 || next.Get("done") != 4 {
+		t.Errorf("Expected pool=0 done=4 after firing, got %v", next)
+	}
+
+	// With the pool empty the expression evaluates to 0 tokens required,
+	// but the guard-free transition would fire as a no-op; the full
+	// analysis must still terminate.
+	result := NewAnalyzer(net).WithMaxStates(100).Analyze()
+	if result.Truncated {
+		t.Error("Expected analysis to terminate without truncation")
+	}
+}
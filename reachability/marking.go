@@ -10,6 +10,8 @@ import (
 	"math"
 	"sort"
 	"strings"
+
+	"github.com/pflow-xyz/go-pflow/petri"
 )
 
 // Marking represents a state of the Petri net (token distribution).
@@ -183,6 +185,17 @@ func (m Marking) IsZero() bool {
 	return true
 }
 
+// Dense converts the marking to a dense petri.IntMarking over the given
+// place index, for callers that want cheap copy/compare/hash.
+func (m Marking) Dense(ix *petri.PlaceIndex) petri.IntMarking {
+	return ix.IntMarkingFrom(m)
+}
+
+// FromDense converts a dense petri.IntMarking back to a map marking.
+func FromDense(m petri.IntMarking) Marking {
+	return Marking(m.ToMap())
+}
+
 // NonZeroPlaces returns places with non-zero tokens.
 func (m Marking) NonZeroPlaces() []string {
 	var places []string
@@ -0,0 +1,177 @@
+package reachability
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// MinimizeResult holds a bisimulation-minimized model of the net's behavior.
+type MinimizeResult struct {
+	// Net is a state-machine Petri net (one token, one place per state
+	// class) that is bisimilar to the original net's reachability
+	// behavior: it allows exactly the same labeled firing sequences.
+	Net *petri.PetriNet
+
+	OriginalStates  int
+	MinimizedStates int
+
+	// ClassOf maps each original state hash to its equivalence class.
+	ClassOf map[string]int
+
+	Truncated bool // state space exceeded limits; result covers explored part
+}
+
+// Minimize computes the strong bisimulation quotient of the net's
+// reachability graph and synthesizes a smaller, observably-equivalent
+// state-machine net from it. Transition labels are preserved, so the
+// minimized net produces the same traces — useful for simplifying mined
+// models before presenting them.
+func (a *Analyzer) Minimize() *MinimizeResult {
+	result := a.BuildGraph()
+	graph := result.Graph
+	states := graph.States
+
+	// Equivalence is judged on observable labels: a transition's LabelText
+	// when set, its ID otherwise. Distinct transitions sharing a label are
+	// indistinguishable to an observer and may merge.
+	obsLabel := func(transID string) string {
+		if t, ok := graph.Net.Transitions[transID]; ok && t.LabelText != nil && *t.LabelText != "" {
+			return *t.LabelText
+		}
+		return transID
+	}
+
+	// Partition refinement: start with one block (split terminals out so
+	// deadlock behavior is preserved), then split blocks until every
+	// state in a block reaches the same set of blocks per label.
+	class := make(map[string]int, len(states))
+	for hash, s := range states {
+		if s.IsTerminal {
+			class[hash] = 1
+		} else {
+			class[hash] = 0
+		}
+	}
+
+	for {
+		// Signature: for each state, the sorted set of (label, target
+		// class) pairs.
+		signatures := make(map[string]string, len(states))
+		for hash, s := range states {
+			moves := make([]string, 0, len(s.Successors))
+			seen := make(map[string]bool)
+			for _, edge := range s.Successors {
+				move := fmt.Sprintf("%s>%d", obsLabel(edge.Transition), class[edge.To.Hash])
+				if !seen[move] {
+					seen[move] = true
+					moves = append(moves, move)
+				}
+			}
+			sort.Strings(moves)
+			signatures[hash] = fmt.Sprintf("%d|%v", class[hash], moves)
+		}
+
+		// Reassign classes by signature, deterministically.
+		sigToClass := make(map[string]int)
+		hashes := make([]string, 0, len(states))
+		for hash := range states {
+			hashes = append(hashes, hash)
+		}
+		sort.Slice(hashes, func(i, j int) bool {
+			return states[hashes[i]].ID < states[hashes[j]].ID
+		})
+		next := make(map[string]int, len(states))
+		for _, hash := range hashes {
+			sig := signatures[hash]
+			if _, ok := sigToClass[sig]; !ok {
+				sigToClass[sig] = len(sigToClass)
+			}
+			next[hash] = sigToClass[sig]
+		}
+
+		stable := true
+		for hash := range states {
+			if next[hash] != class[hash] {
+				stable = false
+				break
+			}
+		}
+		class = next
+		if stable {
+			break
+		}
+	}
+
+	classCount := 0
+	for _, c := range class {
+		if c >= classCount {
+			classCount = c + 1
+		}
+	}
+
+	return &MinimizeResult{
+		Net:             synthesizeQuotient(graph, class, classCount, obsLabel),
+		OriginalStates:  len(states),
+		MinimizedStates: classCount,
+		ClassOf:         class,
+		Truncated:       result.Truncated,
+	}
+}
+
+// synthesizeQuotient builds a state-machine net from the quotient graph:
+// one place per class, one labeled transition per distinct
+// (class, label, class) move.
+func synthesizeQuotient(graph *Graph, class map[string]int, classCount int, obsLabel func(string) string) *petri.PetriNet {
+	net := petri.NewPetriNet()
+
+	for c := 0; c < classCount; c++ {
+		tokens := 0.0
+		if graph.Root != nil && class[graph.Root.Hash] == c {
+			tokens = 1.0
+		}
+		net.AddPlace(fmt.Sprintf("s%d", c), tokens, nil, 100, 100+float64(c)*80, nil)
+	}
+
+	type move struct {
+		from, to int
+		label    string
+	}
+	moves := make(map[move]bool)
+	for _, edge := range graph.Edges {
+		moves[move{class[edge.From.Hash], class[edge.To.Hash], obsLabel(edge.Transition)}] = true
+	}
+
+	ordered := make([]move, 0, len(moves))
+	for mv := range moves {
+		ordered = append(ordered, mv)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].from != ordered[j].from {
+			return ordered[i].from < ordered[j].from
+		}
+		if ordered[i].label != ordered[j].label {
+			return ordered[i].label < ordered[j].label
+		}
+		return ordered[i].to < ordered[j].to
+	})
+
+	// The same observable label can occur on several quotient edges;
+	// transition IDs get a suffix then, but keep the label as LabelText so
+	// the observable alphabet is unchanged.
+	labelUses := make(map[string]int)
+	for _, mv := range ordered {
+		id := mv.label
+		labelUses[mv.label]++
+		if n := labelUses[mv.label]; n > 1 {
+			id = fmt.Sprintf("%s_%d", mv.label, n)
+		}
+		label := mv.label
+		net.AddTransition(id, "default", 150, 100+float64(mv.from)*80, &label)
+		net.AddArc(fmt.Sprintf("s%d", mv.from), id, 1.0, false)
+		net.AddArc(id, fmt.Sprintf("s%d", mv.to), 1.0, false)
+	}
+
+	return net
+}
@@ -0,0 +1,273 @@
+package reachability
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// WithWorkers enables parallel frontier exploration with n goroutines.
+// Values below two keep the sequential explorer. Workers expand the BFS
+// frontier level by level, claiming states through a sharded visited set;
+// the graph itself is mutated only between levels, so no locking is needed
+// on the hot path.
+func (a *Analyzer) WithWorkers(n int) *Analyzer {
+	a.workers = n
+	return a
+}
+
+// WithAutoWorkers sets the worker count to the number of available CPUs.
+func (a *Analyzer) WithAutoWorkers() *Analyzer {
+	return a.WithWorkers(runtime.NumCPU())
+}
+
+// WithDeterministic makes parallel exploration reproducible: expansions are
+// merged in marking-hash order and enabled transition lists are sorted, so
+// state IDs and edge order match across runs at a small sorting cost.
+func (a *Analyzer) WithDeterministic(d bool) *Analyzer {
+	a.deterministic = d
+	return a
+}
+
+// visitedShardCount is the number of independently locked shards in the
+// visited set; a power of two so the shard index is a cheap mask.
+const visitedShardCount = 16
+
+// shardedVisited is a concurrent set of marking hashes. Claiming a hash is
+// the only cross-worker synchronization during a level expansion.
+type shardedVisited struct {
+	shards [visitedShardCount]struct {
+		mu   sync.Mutex
+		seen map[string]bool
+	}
+}
+
+func newShardedVisited() *shardedVisited {
+	v := &shardedVisited{}
+	for i := range v.shards {
+		v.shards[i].seen = make(map[string]bool)
+	}
+	return v
+}
+
+// claim records the hash and reports whether this caller was first.
+func (v *shardedVisited) claim(hash string) bool {
+	var sum byte
+	for i := 0; i < len(hash) && i < 8; i++ {
+		sum ^= hash[i]
+	}
+	shard := &v.shards[sum&(visitedShardCount-1)]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	if shard.seen[hash] {
+		return false
+	}
+	shard.seen[hash] = true
+	return true
+}
+
+// firing is one successor discovered by a worker. Workers that claim a new
+// marking also precompute its enabled transitions, keeping the expensive
+// enablement scan out of the single-threaded merge.
+type firing struct {
+	transition string
+	marking    Marking
+	hash       string
+	isNew      bool
+	enabled    []string
+}
+
+// expansion is the full result of expanding one frontier marking.
+type expansion struct {
+	hash     string
+	enabled  int
+	succ     []firing
+	overflow bool
+}
+
+// buildGraphParallel is the level-synchronous parallel counterpart of
+// BuildGraph. Each BFS level is split across workers that steal frontier
+// entries through a shared atomic cursor; expansions are then merged into
+// the graph on one goroutine. The state limit is checked between levels, so
+// the final count can overshoot by at most one level.
+func (a *Analyzer) buildGraphParallel() *Result {
+	graph := NewGraph(a.net, a.initial)
+	result := &Result{
+		Graph:     graph,
+		Bounded:   true,
+		MaxTokens: make(map[string]int),
+		ExplorationStats: ExplorationStats{
+			StatesLimit: a.maxStates,
+			TokensLimit: a.maxTokens,
+		},
+	}
+
+	visited := newShardedVisited()
+	initial := a.canonical(a.initial)
+	visited.claim(initial.Hash())
+	root := graph.AddState(initial)
+	if a.deterministic {
+		sort.Strings(root.Enabled)
+	}
+
+	frontier := []Marking{initial}
+	maxQueueSize := 1
+	totalEnabled := 0
+	statesWithEnabled := 0
+
+	for len(frontier) > 0 && graph.StateCount() < a.maxStates && !result.Truncated {
+		if a.canceled() {
+			result.Truncated = true
+			result.TruncateMsg = "canceled: " + a.ctx.Err().Error()
+			break
+		}
+		level := a.expandLevel(graph, visited, frontier)
+
+		if a.deterministic {
+			sort.Slice(level, func(i, j int) bool { return level[i].hash < level[j].hash })
+		}
+
+		// First pass: insert newly claimed states so every edge target
+		// exists regardless of which worker found it.
+		var next []Marking
+		for _, exp := range level {
+			for _, f := range exp.succ {
+				if f.isNew {
+					graph.addStateEnabled(f.marking, f.hash, f.enabled)
+					next = append(next, f.marking)
+				}
+			}
+		}
+
+		// Second pass: edges, branching stats, and overflow.
+		for _, exp := range level {
+			from := graph.States[exp.hash]
+			if exp.enabled > 0 {
+				totalEnabled += exp.enabled
+				statesWithEnabled++
+			}
+			for _, f := range exp.succ {
+				graph.AddEdge(from, graph.States[f.hash], f.transition)
+			}
+			if exp.overflow {
+				result.Bounded = false
+				result.Truncated = true
+				result.TruncateMsg = "unbounded: token count exceeded limit"
+			}
+		}
+
+		frontier = next
+		if len(frontier) > maxQueueSize {
+			maxQueueSize = len(frontier)
+		}
+		a.report(graph.StateCount(), len(frontier), false)
+	}
+
+	a.finalizeResult(result, maxQueueSize, totalEnabled, statesWithEnabled)
+
+	return result
+}
+
+// expandLevel fans one frontier level out across the configured workers.
+// The graph is read-only for the duration, so workers can use its enabled
+// lists and firing rules without locks.
+func (a *Analyzer) expandLevel(graph *Graph, visited *shardedVisited, frontier []Marking) []expansion {
+	workers := a.workers
+	if workers > len(frontier) {
+		workers = len(frontier)
+	}
+
+	var cursor atomic.Int64
+	var overflow atomic.Bool
+	perWorker := make([][]expansion, workers)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for !overflow.Load() {
+				i := int(cursor.Add(1)) - 1
+				if i >= len(frontier) {
+					return
+				}
+				marking := frontier[i]
+				state := graph.GetState(marking)
+				if state == nil {
+					continue
+				}
+
+				exp := expansion{
+					hash:    state.Hash,
+					enabled: len(state.Enabled),
+				}
+				for _, trans := range state.Enabled {
+					newMarking := graph.Fire(marking, trans)
+					if newMarking == nil {
+						continue
+					}
+					newMarking = a.canonical(newMarking)
+
+					if newMarking.Max() > a.maxTokens {
+						exp.overflow = true
+						overflow.Store(true)
+						break
+					}
+
+					f := firing{
+						transition: trans,
+						marking:    newMarking,
+						hash:       newMarking.Hash(),
+					}
+					if visited.claim(f.hash) {
+						f.isNew = true
+						f.enabled = graph.findEnabled(newMarking)
+						if a.deterministic {
+							sort.Strings(f.enabled)
+						}
+					}
+					exp.succ = append(exp.succ, f)
+				}
+				perWorker[w] = append(perWorker[w], exp)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	var level []expansion
+	for _, batch := range perWorker {
+		level = append(level, batch...)
+	}
+	return level
+}
+
+// addStateEnabled inserts a state whose enabled transitions were already
+// computed by a worker. Mirrors AddState otherwise.
+func (g *Graph) addStateEnabled(marking Marking, hash string, enabled []string) *State {
+	if existing, ok := g.States[hash]; ok {
+		return existing
+	}
+
+	state := &State{
+		ID:           len(g.States),
+		Marking:      marking.Copy(),
+		Hash:         hash,
+		Enabled:      enabled,
+		Successors:   make([]*Edge, 0),
+		Predecessors: make([]*Edge, 0),
+		IsInitial:    len(g.States) == 0,
+		Depth:        -1,
+	}
+	state.IsTerminal = len(state.Enabled) == 0
+
+	g.States[hash] = state
+	g.stateList = append(g.stateList, state)
+
+	if state.IsInitial {
+		g.Root = state
+		state.Depth = 0
+	}
+
+	return state
+}
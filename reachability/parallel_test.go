@@ -0,0 +1,118 @@
+package reachability
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Helper: n independent token streams of k tokens each, giving a state
+// space of (k+1)^n markings.
+func createStreamNet(streams, tokens int) *petri.PetriNet {
+	b := petri.Build()
+	for i := 0; i < streams; i++ {
+		src := fmt.Sprintf("src%d", i)
+		dst := fmt.Sprintf("dst%d", i)
+		t := fmt.Sprintf("move%d", i)
+		b = b.Place(src, float64(tokens)).Place(dst, 0).
+			Transition(t).
+			Arc(src, t, 1).Arc(t, dst, 1)
+	}
+	return b.Done()
+}
+
+func TestParallelMatchesSequential(t *testing.T) {
+	net := createStreamNet(4, 3)
+
+	seq := NewAnalyzer(net).Analyze()
+	par := NewAnalyzer(net).WithWorkers(4).Analyze()
+
+	if par.StateCount != seq.StateCount {
+		t.Errorf("State count: parallel %d, sequential %d", par.StateCount, seq.StateCount)
+	}
+	if par.EdgeCount != seq.EdgeCount {
+		t.Errorf("Edge count: parallel %d, sequential %d", par.EdgeCount, seq.EdgeCount)
+	}
+	if par.Bounded != seq.Bounded || par.HasDeadlock != seq.HasDeadlock || par.Live != seq.Live {
+		t.Errorf("Property mismatch: parallel %+v", par)
+	}
+	// Every sequential state exists in the parallel graph.
+	for hash := range seq.Graph.States {
+		if _, ok := par.Graph.States[hash]; !ok {
+			t.Errorf("Parallel graph missing state %s", hash)
+		}
+	}
+}
+
+func TestParallelDeterministic(t *testing.T) {
+	net := createStreamNet(3, 3)
+
+	order := func() []string {
+		result := NewAnalyzer(net).WithWorkers(4).WithDeterministic(true).BuildGraph()
+		var ids []string
+		for _, state := range result.Graph.StatesList() {
+			ids = append(ids, state.Hash)
+		}
+		return ids
+	}
+
+	first := order()
+	for run := 0; run < 5; run++ {
+		if got := order(); len(got) != len(first) {
+			t.Fatalf("Run %d: %d states vs %d", run, len(got), len(first))
+		} else {
+			for i := range got {
+				if got[i] != first[i] {
+					t.Fatalf("Run %d: state order diverges at %d", run, i)
+				}
+			}
+		}
+	}
+}
+
+func TestParallelUnbounded(t *testing.T) {
+	// A source transition with no inputs grows a place without limit.
+	net := petri.Build().
+		Place("sink", 0).
+		Transition("gen").
+		Arc("gen", "sink", 1).
+		Done()
+
+	result := NewAnalyzer(net).WithMaxTokens(50).WithWorkers(4).Analyze()
+	if result.Bounded {
+		t.Error("Generator net should be detected as unbounded")
+	}
+	if !result.Truncated {
+		t.Error("Unbounded exploration should be truncated")
+	}
+}
+
+func TestParallelStateLimit(t *testing.T) {
+	net := createStreamNet(5, 4)
+
+	result := NewAnalyzer(net).WithMaxStates(200).WithWorkers(4).Analyze()
+	if !result.Truncated {
+		t.Error("Expected truncation at the state limit")
+	}
+	// Level-synchronous merge may overshoot by one frontier, not more.
+	if result.StateCount < 200 {
+		t.Errorf("Stopped early at %d states", result.StateCount)
+	}
+}
+
+func benchmarkBuildGraph(b *testing.B, workers int) {
+	net := createStreamNet(6, 3)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analyzer := NewAnalyzer(net).WithMaxStates(20000)
+		if workers > 1 {
+			analyzer = analyzer.WithWorkers(workers)
+		}
+		analyzer.BuildGraph()
+	}
+}
+
+func BenchmarkBuildGraphSequential(b *testing.B) { benchmarkBuildGraph(b, 1) }
+func BenchmarkBuildGraphParallel4(b *testing.B)  { benchmarkBuildGraph(b, 4) }
+func BenchmarkBuildGraphParallel8(b *testing.B)  { benchmarkBuildGraph(b, 8) }
@@ -0,0 +1,133 @@
+package reachability
+
+import (
+	"container/heap"
+)
+
+// MarkingPredicate reports whether a marking satisfies a goal condition.
+// Predicates describe target states by property ("two tokens anywhere in
+// alarm places") rather than by exact marking, so unrelated places can vary
+// freely.
+type MarkingPredicate func(Marking) bool
+
+// IsReachableWhere checks if any reachable marking satisfies the predicate.
+// Exploration is breadth-first and stops at the analyzer's state limit.
+func (a *Analyzer) IsReachableWhere(pred MarkingPredicate) bool {
+	return a.PathToWhere(pred, nil) != nil
+}
+
+// PathToWhere finds a firing sequence to a predicate-satisfying marking
+// using A* search. The heuristic estimates remaining distance to a goal
+// state and must never overestimate it; pass nil for uniform-cost search.
+// Returns an empty (non-nil) sequence if the initial marking already
+// satisfies the predicate, and nil if no satisfying marking was found
+// within the state limit.
+func (a *Analyzer) PathToWhere(pred MarkingPredicate, heuristic func(Marking) int) []string {
+	if heuristic == nil {
+		heuristic = func(Marking) int { return 0 }
+	}
+
+	graph := NewGraph(a.net, a.initial)
+
+	open := &nodeHeap{}
+	heap.Init(open)
+	heap.Push(open, &searchNode{
+		marking: a.initial,
+		path:    []string{},
+		f:       heuristic(a.initial),
+	})
+
+	visited := make(map[string]bool)
+	visited[a.initial.Hash()] = true
+
+	for open.Len() > 0 && len(visited) < a.maxStates {
+		node := heap.Pop(open).(*searchNode)
+
+		if pred(node.marking) {
+			return node.path
+		}
+
+		state := graph.AddState(node.marking)
+		for _, trans := range state.Enabled {
+			newMarking := graph.Fire(node.marking, trans)
+			if newMarking == nil {
+				continue
+			}
+
+			// Skip unbounded markings
+			if newMarking.Max() > a.maxTokens {
+				continue
+			}
+
+			hash := newMarking.Hash()
+			if !visited[hash] {
+				visited[hash] = true
+				newPath := make([]string, len(node.path)+1)
+				copy(newPath, node.path)
+				newPath[len(node.path)] = trans
+				g := node.g + 1
+				heap.Push(open, &searchNode{
+					marking: newMarking,
+					path:    newPath,
+					g:       g,
+					f:       g + heuristic(newMarking),
+				})
+			}
+		}
+	}
+
+	return nil // No satisfying marking found
+}
+
+// DistanceTo builds an admissible heuristic for PathToWhere from a partial
+// target marking: the largest token deficit across the target's places.
+// Places absent from the target contribute nothing, so the heuristic guides
+// the search without constraining unrelated parts of the net.
+func DistanceTo(target Marking) func(Marking) int {
+	return func(m Marking) int {
+		max := 0
+		for place, want := range target {
+			if deficit := want - m[place]; deficit > max {
+				max = deficit
+			}
+		}
+		return max
+	}
+}
+
+// searchNode is an entry in the A* open set.
+type searchNode struct {
+	marking Marking
+	path    []string
+	g       int // Cost so far (path length)
+	f       int // g + heuristic estimate
+	index   int
+}
+
+// nodeHeap is a min-heap over f ordered for container/heap.
+type nodeHeap []*searchNode
+
+func (h nodeHeap) Len() int { return len(h) }
+
+func (h nodeHeap) Less(i, j int) bool { return h[i].f < h[j].f }
+
+func (h nodeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *nodeHeap) Push(x any) {
+	node := x.(*searchNode)
+	node.index = len(*h)
+	*h = append(*h, node)
+}
+
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := len(old)
+	node := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return node
+}
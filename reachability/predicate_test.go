@@ -0,0 +1,90 @@
+package reachability
+
+import (
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Helper: two independent token streams, so goal states for one stream
+// occur under many different markings of the other.
+func createTwoStreamNet() *petri.PetriNet {
+	return petri.Build().
+		Place("A", 3).
+		Place("B", 0).
+		Place("X", 3).
+		Place("Y", 0).
+		Transition("ab").
+		Transition("xy").
+		Arc("A", "ab", 1).
+		Arc("ab", "B", 1).
+		Arc("X", "xy", 1).
+		Arc("xy", "Y", 1).
+		Done()
+}
+
+func TestIsReachableWhere(t *testing.T) {
+	analyzer := NewAnalyzer(createTwoStreamNet())
+
+	// "B holds two tokens" is true in several markings that differ only in
+	// the unrelated X/Y stream; a predicate finds it without enumerating.
+	if !analyzer.IsReachableWhere(func(m Marking) bool { return m["B"] >= 2 }) {
+		t.Error("B >= 2 should be reachable")
+	}
+	if analyzer.IsReachableWhere(func(m Marking) bool { return m["B"] > 3 }) {
+		t.Error("B > 3 exceeds the token supply")
+	}
+}
+
+func TestPathToWhere(t *testing.T) {
+	analyzer := NewAnalyzer(createTwoStreamNet())
+
+	path := analyzer.PathToWhere(func(m Marking) bool { return m["B"] == 2 }, nil)
+	if path == nil {
+		t.Fatal("Expected a path to B == 2")
+	}
+	if len(path) != 2 {
+		t.Errorf("Shortest path fires ab twice, got %v", path)
+	}
+	ok, final := analyzer.CanFire(path)
+	if !ok || final["B"] != 2 {
+		t.Errorf("Path does not replay to the goal: %v -> %v", path, final)
+	}
+}
+
+func TestPathToWhereInitialState(t *testing.T) {
+	analyzer := NewAnalyzer(createSimpleNet())
+
+	path := analyzer.PathToWhere(func(m Marking) bool { return m["A"] == 2 }, nil)
+	if path == nil || len(path) != 0 {
+		t.Errorf("Initial marking satisfies the predicate; expected empty path, got %v", path)
+	}
+}
+
+func TestPathToWhereHeuristic(t *testing.T) {
+	analyzer := NewAnalyzer(createTwoStreamNet())
+
+	goal := Marking{"B": 3}
+	path := analyzer.PathToWhere(func(m Marking) bool { return m["B"] >= 3 }, DistanceTo(goal))
+	if len(path) != 3 {
+		t.Errorf("Guided search should find the 3-step path, got %v", path)
+	}
+
+	// The heuristic ignores places absent from the target.
+	h := DistanceTo(goal)
+	if h(Marking{"B": 1, "Y": 5}) != 2 {
+		t.Errorf("Deficit for B: got %d, want 2", h(Marking{"B": 1, "Y": 5}))
+	}
+	if h(Marking{"B": 3}) != 0 {
+		t.Error("Satisfied target should have zero distance")
+	}
+}
+
+func TestPathToWhereRespectsStateLimit(t *testing.T) {
+	analyzer := NewAnalyzer(createCyclicNet()).WithMaxStates(2)
+
+	// The goal is unreachable; the search must terminate at the limit.
+	if path := analyzer.PathToWhere(func(m Marking) bool { return m["working"] > 1 }, nil); path != nil {
+		t.Errorf("Unreachable goal returned path %v", path)
+	}
+}
@@ -1,6 +1,7 @@
 package reachability
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pflow-xyz/go-pflow/petri"
@@ -634,3 +635,96 @@ func TestCompleteAnalysisHasConfirmedDead(t *testing.T) {
 		t.Errorf("PotentiallyDead should be empty for complete analysis, got %v", result.PotentiallyDead)
 	}
 }
+
+func TestAnalyzeWithCanceledContext(t *testing.T) {
+	net := createSimpleNet()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result := NewAnalyzer(net).WithContext(ctx).Analyze()
+	if !result.Truncated {
+		t.Error("Expected truncated result under canceled context")
+	}
+	if result.TruncateMsg == "" {
+		t.Error("Expected truncation message mentioning cancellation")
+	}
+
+	// An open context explores the full space as before.
+	full := NewAnalyzer(net).WithContext(context.Background()).Analyze()
+	if full.Truncated {
+		t.Errorf("Expected complete exploration, got: %s", full.TruncateMsg)
+	}
+}
+
+func TestAnalyzerProgressCallback(t *testing.T) {
+	net := createSimpleNet()
+
+	var reports []Progress
+	result := NewAnalyzer(net).
+		WithProgress(func(p Progress) { reports = append(reports, p) }).
+		Analyze()
+
+	if len(reports) == 0 {
+		t.Fatal("Expected at least the final progress report")
+	}
+	final := reports[len(reports)-1]
+	if !final.Done {
+		t.Error("Expected last report to be marked Done")
+	}
+	if final.Percent != 100 {
+		t.Errorf("Expected final percent 100, got %f", final.Percent)
+	}
+	if final.StatesExplored != result.StateCount {
+		t.Errorf("Final report has %d states, analysis found %d",
+			final.StatesExplored, result.StateCount)
+	}
+}
+
+func TestCapacityBlocksTransition(t *testing.T) {
+	net := petri.Build().
+		Place("src", 5).
+		PlaceWithCapacity("buf", 0, 2).
+		Overflow("buf", petri.OverflowBlock).
+		Transition("fill").
+		Arc("src", "fill", 1).
+		Arc("fill", "buf", 1).
+		Done()
+
+	result := NewAnalyzer(net).Analyze()
+
+	// buf holds at most 2, so exploration stops at (3, 2).
+	if result.MaxTokens["buf"] != 2 {
+		t.Errorf("Expected buf capped at 2, got %d", result.MaxTokens["buf"])
+	}
+	if result.StateCount != 3 {
+		t.Errorf("Expected 3 states (buf=0,1,2), got %d", result.StateCount)
+	}
+	if !result.HasDeadlock {
+		t.Error("Expected a deadlock once buf is full")
+	}
+}
+
+func TestCapacityDiscardOverflow(t *testing.T) {
+	net := petri.Build().
+		Place("src", 5).
+		PlaceWithCapacity("buf", 0, 2).
+		Overflow("buf", petri.OverflowDiscard).
+		Transition("fill").
+		Arc("src", "fill", 1).
+		Arc("fill", "buf", 1).
+		Done()
+
+	result := NewAnalyzer(net).Analyze()
+
+	// Overflow is dropped, so src drains fully while buf sits at 2.
+	if result.MaxTokens["buf"] != 2 {
+		t.Errorf("Expected buf capped at 2, got %d", result.MaxTokens["buf"])
+	}
+	if result.StateCount != 6 {
+		t.Errorf("Expected 6 states (src=5..0), got %d", result.StateCount)
+	}
+	final := result.Graph.GetState(Marking{"src": 0, "buf": 2})
+	if final == nil {
+		t.Error("Expected src to drain fully under discard policy")
+	}
+}
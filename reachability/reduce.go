@@ -0,0 +1,460 @@
+package reachability
+
+import (
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// ReductionResult holds a structurally reduced net together with the
+// mapping from surviving nodes back to the originals they absorbed.
+type ReductionResult struct {
+	// Net is the reduced net. Surviving nodes keep their original names.
+	Net *petri.PetriNet
+
+	// PlaceOrigins maps each surviving place to the original places it
+	// represents (itself plus any fused into it).
+	PlaceOrigins map[string][]string
+
+	// TransitionOrigins maps each surviving transition to the original
+	// transitions it represents.
+	TransitionOrigins map[string][]string
+
+	// RemovedPlaces and RemovedTransitions list nodes dropped as
+	// structurally irrelevant: self-loop identities and dead branches.
+	RemovedPlaces      []string
+	RemovedTransitions []string
+
+	// Rounds is the number of passes until no rule applied.
+	Rounds int
+}
+
+// Reduce applies classic structural reduction rules to a fixpoint:
+// fusion of series places and series transitions, removal of self-loop
+// identities, and pruning of branches that can never fire. The rules
+// preserve boundedness, liveness of the surviving transitions, and deadlock
+// behavior, so analysis of the reduced net answers the same questions on a
+// much smaller state space. Generated nets (constraint encodings, mined
+// models) typically shrink dramatically.
+//
+// Rules only apply to plain weight-1 arcs away from inhibitors; anything
+// irregular is left untouched. Nets with guards or marking-dependent arc
+// weights are returned unreduced: the rules reason about static weights
+// only, and rebuilding the net would silently drop the expressions. The same
+// escape applies to nets with enforced capacities, since fusing places would
+// change which firings a full place blocks or truncates.
+func Reduce(net *petri.PetriNet) *ReductionResult {
+	if net.HasDynamics() || net.EnforcesCapacities() {
+		return identityReduction(net)
+	}
+
+	w := newWorkNet(net)
+
+	rounds := 0
+	for {
+		changed := w.pruneDeadBranches()
+		changed = w.removeSelfLoops() || changed
+		changed = w.fuseSeriesPlaces() || changed
+		changed = w.fuseSeriesTransitions() || changed
+		rounds++
+		if !changed {
+			break
+		}
+	}
+
+	result := &ReductionResult{
+		Net:                w.build(net),
+		PlaceOrigins:       w.placeOrigins,
+		TransitionOrigins:  w.transOrigins,
+		RemovedPlaces:      w.removedPlaces,
+		RemovedTransitions: w.removedTrans,
+		Rounds:             rounds,
+	}
+	sort.Strings(result.RemovedPlaces)
+	sort.Strings(result.RemovedTransitions)
+	return result
+}
+
+// identityReduction wraps the original net in a no-op result, used when the
+// net carries semantics the reduction rules cannot preserve.
+func identityReduction(net *petri.PetriNet) *ReductionResult {
+	result := &ReductionResult{
+		Net:               net,
+		PlaceOrigins:      make(map[string][]string, len(net.Places)),
+		TransitionOrigins: make(map[string][]string, len(net.Transitions)),
+	}
+	for name := range net.Places {
+		result.PlaceOrigins[name] = []string{name}
+	}
+	for name := range net.Transitions {
+		result.TransitionOrigins[name] = []string{name}
+	}
+	return result
+}
+
+// OriginalTransitions expands reduced transition names to the original
+// names they stand for, for reporting results against the source model.
+func (r *ReductionResult) OriginalTransitions(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if origins, ok := r.TransitionOrigins[name]; ok {
+			out = append(out, origins...)
+		} else {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// OriginalPlaces expands reduced place names to original names.
+func (r *ReductionResult) OriginalPlaces(names []string) []string {
+	var out []string
+	for _, name := range names {
+		if origins, ok := r.PlaceOrigins[name]; ok {
+			out = append(out, origins...)
+		} else {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// AnalyzeReduced reduces the net, analyzes the reduction, and maps reported
+// transition names back to the original model. Use it in place of Analyze
+// when the net carries a lot of generated series structure.
+func (a *Analyzer) AnalyzeReduced() (*Result, *ReductionResult) {
+	reduction := Reduce(a.net)
+
+	analyzer := NewAnalyzer(reduction.Net).
+		WithMaxStates(a.maxStates).
+		WithMaxTokens(a.maxTokens)
+	if a.workers > 1 {
+		analyzer = analyzer.WithWorkers(a.workers).WithDeterministic(a.deterministic)
+	}
+	result := analyzer.Analyze()
+
+	// Report against original node names. A merged transition that is dead
+	// (or fired) stands for all transitions fused into it.
+	result.DeadTrans = expandNonEmpty(reduction, result.DeadTrans)
+	result.ConfirmedDead = expandNonEmpty(reduction, result.ConfirmedDead)
+	result.PotentiallyDead = expandNonEmpty(reduction, result.PotentiallyDead)
+	result.FiredTransitions = expandNonEmpty(reduction, result.FiredTransitions)
+
+	return result, reduction
+}
+
+func expandNonEmpty(r *ReductionResult, names []string) []string {
+	if len(names) == 0 {
+		return names
+	}
+	return r.OriginalTransitions(names)
+}
+
+// workArc mirrors petri.Arc with a scalar weight.
+type workArc struct {
+	source, target string
+	weight         float64
+	inhibit        bool
+}
+
+// workNet is the mutable representation the rules operate on.
+type workNet struct {
+	tokens        map[string]float64 // surviving places -> initial tokens
+	trans         map[string]bool    // surviving transitions
+	arcs          []workArc
+	placeOrigins  map[string][]string
+	transOrigins  map[string][]string
+	removedPlaces []string
+	removedTrans  []string
+}
+
+func newWorkNet(net *petri.PetriNet) *workNet {
+	w := &workNet{
+		tokens:       make(map[string]float64, len(net.Places)),
+		trans:        make(map[string]bool, len(net.Transitions)),
+		placeOrigins: make(map[string][]string, len(net.Places)),
+		transOrigins: make(map[string][]string, len(net.Transitions)),
+	}
+	for name, place := range net.Places {
+		w.tokens[name] = place.GetTokenCount()
+		w.placeOrigins[name] = []string{name}
+	}
+	for name := range net.Transitions {
+		w.trans[name] = true
+		w.transOrigins[name] = []string{name}
+	}
+	for _, arc := range net.Arcs {
+		w.arcs = append(w.arcs, workArc{arc.Source, arc.Target, arc.GetWeightSum(), arc.InhibitTransition})
+	}
+	return w
+}
+
+// sortedPlaces and sortedTransitions give the rules a deterministic scan
+// order so reductions are reproducible.
+func (w *workNet) sortedPlaces() []string {
+	names := make([]string, 0, len(w.tokens))
+	for name := range w.tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (w *workNet) sortedTransitions() []string {
+	names := make([]string, 0, len(w.trans))
+	for name := range w.trans {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// arcsIn returns non-inhibitor arcs into the node; arcsOut out of it.
+func (w *workNet) arcsIn(node string) []int {
+	var idx []int
+	for i, arc := range w.arcs {
+		if arc.target == node && !arc.inhibit {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+func (w *workNet) arcsOut(node string) []int {
+	var idx []int
+	for i, arc := range w.arcs {
+		if arc.source == node {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// hasInhibitorInput reports whether an inhibitor arc guards the transition;
+// such transitions are excluded from fusion so the guard is never lost.
+func (w *workNet) hasInhibitorInput(t string) bool {
+	for _, arc := range w.arcs {
+		if arc.inhibit && arc.target == t {
+			return true
+		}
+	}
+	return false
+}
+
+// touchesInhibitor reports whether any inhibitor arc reads the place.
+func (w *workNet) touchesInhibitor(place string) bool {
+	for _, arc := range w.arcs {
+		if arc.inhibit && arc.source == place {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *workNet) dropArcs(drop map[int]bool) {
+	kept := w.arcs[:0]
+	for i, arc := range w.arcs {
+		if !drop[i] {
+			kept = append(kept, arc)
+		}
+	}
+	w.arcs = kept
+}
+
+// fuseSeriesTransitions merges t2 into t1 when a buffer place p sits
+// between them in series: t1 -> p -> t2, with p empty, weight-1 arcs, and
+// p / t2 having no other connections. The merged transition produces t2's
+// outputs directly.
+func (w *workNet) fuseSeriesTransitions() bool {
+	for _, p := range w.sortedPlaces() {
+		if w.tokens[p] != 0 || w.touchesInhibitor(p) {
+			continue
+		}
+		in := w.arcsIn(p)
+		out := w.arcsOut(p)
+		if len(in) != 1 || len(out) != 1 {
+			continue
+		}
+		inArc, outArc := w.arcs[in[0]], w.arcs[out[0]]
+		if inArc.weight != 1 || outArc.weight != 1 || outArc.inhibit {
+			continue
+		}
+		t1, t2 := inArc.source, outArc.target
+		if t1 == t2 || !w.trans[t1] || !w.trans[t2] {
+			continue
+		}
+		// t2 must be enabled exactly when p is marked.
+		if len(w.arcsIn(t2)) != 1 || w.hasInhibitorInput(t2) {
+			continue
+		}
+
+		// Redirect t2's outputs to t1, then drop p and t2.
+		drop := map[int]bool{in[0]: true, out[0]: true}
+		for _, i := range w.arcsOut(t2) {
+			w.arcs[i].source = t1
+		}
+		w.dropArcs(drop)
+		delete(w.tokens, p)
+		delete(w.trans, t2)
+		w.transOrigins[t1] = append(w.transOrigins[t1], w.transOrigins[t2]...)
+		delete(w.transOrigins, t2)
+		w.removedPlaces = append(w.removedPlaces, w.placeOrigins[p]...)
+		delete(w.placeOrigins, p)
+		return true
+	}
+	return false
+}
+
+// fuseSeriesPlaces merges p2 into p1 when a transition t sits between them
+// in series: p1 -> t -> p2, with weight-1 arcs, t having no other
+// connections, p1's only consumer being t, and p2's only producer being t.
+func (w *workNet) fuseSeriesPlaces() bool {
+	for _, t := range w.sortedTransitions() {
+		in := w.arcsIn(t)
+		out := w.arcsOut(t)
+		if len(in) != 1 || len(out) != 1 {
+			continue
+		}
+		inArc, outArc := w.arcs[in[0]], w.arcs[out[0]]
+		if inArc.weight != 1 || outArc.weight != 1 || w.hasInhibitorInput(t) {
+			continue
+		}
+		p1, p2 := inArc.source, outArc.target
+		if p1 == p2 {
+			continue
+		}
+		if _, ok := w.tokens[p1]; !ok {
+			continue
+		}
+		if _, ok := w.tokens[p2]; !ok {
+			continue
+		}
+		if w.touchesInhibitor(p1) || w.touchesInhibitor(p2) {
+			continue
+		}
+		if len(w.arcsOut(p1)) != 1 || len(w.arcsIn(p2)) != 1 {
+			continue
+		}
+
+		// Merge p2 into p1: tokens add, p2's consumers move to p1.
+		drop := map[int]bool{in[0]: true, out[0]: true}
+		for _, i := range w.arcsOut(p2) {
+			w.arcs[i].source = p1
+		}
+		w.dropArcs(drop)
+		w.tokens[p1] += w.tokens[p2]
+		delete(w.tokens, p2)
+		delete(w.trans, t)
+		w.placeOrigins[p1] = append(w.placeOrigins[p1], w.placeOrigins[p2]...)
+		delete(w.placeOrigins, p2)
+		w.removedTrans = append(w.removedTrans, w.transOrigins[t]...)
+		delete(w.transOrigins, t)
+		return true
+	}
+	return false
+}
+
+// removeSelfLoops drops identity structure: a transition whose only arcs
+// form a self-loop with one place changes nothing when it fires, and a
+// marked self-loop place whose only role is enabling such loops never
+// constrains anything.
+func (w *workNet) removeSelfLoops() bool {
+	changed := false
+	for _, t := range w.sortedTransitions() {
+		in := w.arcsIn(t)
+		out := w.arcsOut(t)
+		if len(in) != 1 || len(out) != 1 {
+			continue
+		}
+		inArc, outArc := w.arcs[in[0]], w.arcs[out[0]]
+		if inArc.source != outArc.target || inArc.weight != outArc.weight || w.hasInhibitorInput(t) {
+			continue
+		}
+		p := inArc.source
+		if w.tokens[p] < inArc.weight {
+			// Never enabled; dead branch pruning handles it.
+			continue
+		}
+
+		// The transition is an identity: firing returns the marking.
+		w.dropArcs(map[int]bool{in[0]: true, out[0]: true})
+		delete(w.trans, t)
+		w.removedTrans = append(w.removedTrans, w.transOrigins[t]...)
+		delete(w.transOrigins, t)
+
+		// Drop the loop place too if the loop was its only connection.
+		if len(w.arcsIn(p)) == 0 && len(w.arcsOut(p)) == 0 && !w.touchesInhibitor(p) {
+			delete(w.tokens, p)
+			w.removedPlaces = append(w.removedPlaces, w.placeOrigins[p]...)
+			delete(w.placeOrigins, p)
+		}
+		changed = true
+	}
+	return changed
+}
+
+// pruneDeadBranches removes transitions that can never fire because an
+// input place is empty and has no producers, then places that end up with
+// no connections and no tokens.
+func (w *workNet) pruneDeadBranches() bool {
+	changed := false
+	for {
+		removed := false
+		for _, t := range w.sortedTransitions() {
+			for _, i := range w.arcsIn(t) {
+				p := w.arcs[i].source
+				if w.tokens[p] == 0 && len(w.arcsIn(p)) == 0 {
+					// Drop every arc touching t, inhibitors included.
+					drop := make(map[int]bool)
+					for j, arc := range w.arcs {
+						if arc.target == t || arc.source == t {
+							drop[j] = true
+						}
+					}
+					w.dropArcs(drop)
+					delete(w.trans, t)
+					w.removedTrans = append(w.removedTrans, w.transOrigins[t]...)
+					delete(w.transOrigins, t)
+					removed = true
+					changed = true
+					break
+				}
+			}
+		}
+		if !removed {
+			break
+		}
+	}
+
+	// Disconnected empty places are unreachable garbage.
+	for _, p := range w.sortedPlaces() {
+		if w.tokens[p] == 0 && len(w.arcsIn(p)) == 0 && len(w.arcsOut(p)) == 0 && !w.touchesInhibitor(p) {
+			delete(w.tokens, p)
+			w.removedPlaces = append(w.removedPlaces, w.placeOrigins[p]...)
+			delete(w.placeOrigins, p)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// build assembles the reduced petri net, keeping positions and labels from
+// the source model for surviving nodes.
+func (w *workNet) build(src *petri.PetriNet) *petri.PetriNet {
+	net := petri.NewPetriNet()
+	for _, name := range w.sortedPlaces() {
+		orig := src.Places[name]
+		place := net.AddPlace(name, w.tokens[name], orig.Capacity, orig.X, orig.Y, orig.LabelText)
+		place.Overflow = orig.Overflow
+	}
+	for _, name := range w.sortedTransitions() {
+		orig := src.Transitions[name]
+		net.AddTransition(name, orig.Role, orig.X, orig.Y, orig.LabelText)
+	}
+	for _, arc := range w.arcs {
+		net.AddArc(arc.source, arc.target, arc.weight, arc.inhibit)
+	}
+	return net
+}
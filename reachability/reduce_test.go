@@ -0,0 +1,258 @@
+package reachability
+
+import (
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestReduceChain(t *testing.T) {
+	net := petri.Build().
+		Chain(10, "start", "t1", "m1", "t2", "m2", "t3", "end").
+		Done()
+
+	reduction := Reduce(net)
+
+	if len(reduction.Net.Places) >= len(net.Places) {
+		t.Errorf("Chain should shrink: %d places from %d", len(reduction.Net.Places), len(net.Places))
+	}
+	if len(reduction.Net.Transitions) >= len(net.Transitions) {
+		t.Errorf("Chain should fuse transitions: %d from %d", len(reduction.Net.Transitions), len(net.Transitions))
+	}
+
+	// Every original node is accounted for: either absorbed into a
+	// survivor or reported as removed.
+	covered := make(map[string]bool)
+	for _, origins := range reduction.PlaceOrigins {
+		for _, name := range origins {
+			covered[name] = true
+		}
+	}
+	for _, name := range reduction.RemovedPlaces {
+		covered[name] = true
+	}
+	for name := range net.Places {
+		if !covered[name] {
+			t.Errorf("Place %s lost without mapping", name)
+		}
+	}
+
+	// The reduction preserves the analysis verdict.
+	full := NewAnalyzer(net).Analyze()
+	reduced := NewAnalyzer(reduction.Net).Analyze()
+	if full.Bounded != reduced.Bounded || full.HasDeadlock != reduced.HasDeadlock {
+		t.Errorf("Verdict changed: full %+v, reduced %+v", full.Bounded, reduced.Bounded)
+	}
+	if reduced.StateCount > full.StateCount {
+		t.Errorf("Reduced state space grew: %d > %d", reduced.StateCount, full.StateCount)
+	}
+}
+
+func TestReduceSelfLoop(t *testing.T) {
+	// A resource lock that is always returned: pure identity structure.
+	net := petri.Build().
+		Place("work", 1).
+		Place("lock", 1).
+		Transition("spin").
+		Arc("lock", "spin", 1).
+		Arc("spin", "lock", 1).
+		Done()
+
+	reduction := Reduce(net)
+
+	if _, ok := reduction.Net.Transitions["spin"]; ok {
+		t.Error("Identity self-loop should be removed")
+	}
+	found := false
+	for _, name := range reduction.RemovedTransitions {
+		if name == "spin" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Removed transitions: %v", reduction.RemovedTransitions)
+	}
+}
+
+func TestReduceDeadBranch(t *testing.T) {
+	net := petri.Build().
+		Place("a", 1).Place("b", 0).
+		Place("never", 0).Place("unreached", 0).
+		Transition("go").Transition("dead").
+		Arc("a", "go", 1).Arc("go", "b", 1).
+		Arc("never", "dead", 1).Arc("dead", "unreached", 1).
+		Done()
+
+	reduction := Reduce(net)
+
+	if _, ok := reduction.Net.Transitions["dead"]; ok {
+		t.Error("Transition with an unfillable input should be pruned")
+	}
+	if _, ok := reduction.Net.Places["never"]; ok {
+		t.Error("Disconnected empty place should be pruned")
+	}
+}
+
+func TestReduceKeepsInhibitors(t *testing.T) {
+	net := petri.Build().
+		Place("buffer", 0).Place("done", 0).Place("input", 3).
+		Transition("fill").Transition("flush").
+		Arc("input", "fill", 1).Arc("fill", "buffer", 1).
+		Arc("buffer", "flush", 1).Arc("flush", "done", 1).
+		InhibitorArc("done", "fill", 1).
+		Done()
+
+	reduction := Reduce(net)
+
+	// The guarded transition and the place it reads must both survive.
+	if _, ok := reduction.Net.Transitions["fill"]; !ok {
+		t.Error("Inhibitor-guarded transition must not be fused")
+	}
+	if _, ok := reduction.Net.Places["done"]; !ok {
+		t.Error("Place read by an inhibitor must survive")
+	}
+	inhibitors := 0
+	for _, arc := range reduction.Net.Arcs {
+		if arc.InhibitTransition {
+			inhibitors++
+		}
+	}
+	if inhibitors != 1 {
+		t.Errorf("Inhibitor arcs: %d", inhibitors)
+	}
+}
+
+func TestAnalyzeReduced(t *testing.T) {
+	net := petri.Build().
+		Chain(5, "start", "t1", "mid", "t2", "end").
+		Place("never", 0).
+		Transition("dead").
+		Arc("never", "dead", 1).
+		Done()
+
+	result, reduction := NewAnalyzer(net).AnalyzeReduced()
+
+	if !result.Bounded {
+		t.Error("Chain is bounded")
+	}
+	if reduction.Rounds < 1 {
+		t.Error("Expected at least one reduction round")
+	}
+	// The pruned transition never appears as dead in the result; it is
+	// reported as removed instead.
+	for _, name := range reduction.RemovedTransitions {
+		if name == "dead" {
+			return
+		}
+	}
+	t.Errorf("Expected dead in removed transitions: %v", reduction.RemovedTransitions)
+}
+
+func TestOriginalTransitionsMapping(t *testing.T) {
+	net := petri.Build().
+		Place("p0", 1).
+		Place("p1", 0).
+		Place("p2", 0).
+		Transition("a").Transition("b").
+		Arc("p0", "a", 1).Arc("a", "p1", 1).
+		Arc("p1", "b", 1).Arc("b", "p2", 1).
+		Done()
+
+	reduction := Reduce(net)
+
+	var survivors []string
+	for name := range reduction.Net.Transitions {
+		survivors = append(survivors, name)
+	}
+	expanded := reduction.OriginalTransitions(survivors)
+	total := len(expanded) + len(reduction.RemovedTransitions)
+	if total != len(net.Transitions) {
+		t.Errorf("Mapping covers %d of %d transitions", total, len(net.Transitions))
+	}
+}
+
+// TestReduceSkipsGuardedNets checks that a net with guards is returned
+// unreduced: the rules reason about static weights only, and fusing nodes
+// would drop the guard. AnalyzeReduced must answer exactly what Analyze does.
+func TestReduceSkipsGuardedNets(t *testing.T) {
+	build := func() *petri.PetriNet {
+		net := petri.Build().
+			Chain(3, "pool", "take", "done", "archive", "stored").
+			Done()
+		if err := net.SetGuard("take", "done < 2"); err != nil {
+			t.Fatalf("SetGuard failed: %v", err)
+		}
+		return net
+	}
+
+	reduction := Reduce(build())
+	if len(reduction.Net.Places) != 3 || len(reduction.Net.Transitions) != 2 {
+		t.Errorf("Guarded net should be unreduced: %d places, %d transitions",
+			len(reduction.Net.Places), len(reduction.Net.Transitions))
+	}
+	if reduction.Net.Transitions["take"].Guard == "" {
+		t.Error("Guard lost in reduction")
+	}
+	if len(reduction.RemovedPlaces) != 0 || len(reduction.RemovedTransitions) != 0 {
+		t.Errorf("Nothing should be removed: %v / %v",
+			reduction.RemovedPlaces, reduction.RemovedTransitions)
+	}
+
+	full := NewAnalyzer(build()).Analyze()
+	reduced, _ := NewAnalyzer(build()).AnalyzeReduced()
+	if full.StateCount != reduced.StateCount || full.HasDeadlock != reduced.HasDeadlock {
+		t.Errorf("AnalyzeReduced diverged: %d states / deadlock=%v vs %d / %v",
+			reduced.StateCount, reduced.HasDeadlock, full.StateCount, full.HasDeadlock)
+	}
+}
+
+// TestReduceSkipsExpressionArcs covers the arc-expression flavor of the
+// same escape.
+func TestReduceSkipsExpressionArcs(t *testing.T) {
+	net := petri.Build().
+		Chain(4, "pool", "drain", "done", "archive", "stored").
+		Done()
+	if err := net.SetArcExpression("pool", "drain", "pool"); err != nil {
+		t.Fatalf("SetArcExpression failed: %v", err)
+	}
+
+	reduction := Reduce(net)
+	if len(reduction.Net.Transitions) != 2 {
+		t.Errorf("Expression-bearing net should be unreduced: %d transitions",
+			len(reduction.Net.Transitions))
+	}
+	for _, arc := range reduction.Net.Arcs {
+		if arc.Source == "pool" && arc.Target == "drain" && arc.Expression == "" {
+			t.Error("Arc expression lost in reduction")
+		}
+	}
+}
+
+// TestReduceSkipsEnforcedCapacities checks that a net whose places enforce
+// capacity is returned unreduced: fusing the bounded buffer away would stop
+// the net from blocking at capacity.
+func TestReduceSkipsEnforcedCapacities(t *testing.T) {
+	net := petri.Build().
+		Place("start", 2).
+		PlaceWithCapacity("mid", 0, 1).
+		Place("end", 0).
+		Transition("t1").Transition("t2").
+		Arc("start", "t1", 1).Arc("t1", "mid", 1).
+		Arc("mid", "t2", 1).Arc("t2", "end", 1).
+		Overflow("mid", petri.OverflowBlock).
+		Done()
+
+	reduction := Reduce(net)
+	if len(reduction.Net.Places) != 3 {
+		t.Errorf("Capacity-enforcing net should be unreduced: %d places", len(reduction.Net.Places))
+	}
+	if reduction.Net.Places["mid"].Overflow != petri.OverflowBlock {
+		t.Error("Overflow policy lost in reduction")
+	}
+
+	full := NewAnalyzer(net).Analyze()
+	reduced := NewAnalyzer(reduction.Net).Analyze()
+	if full.StateCount != reduced.StateCount {
+		t.Errorf("State count changed: %d vs %d", reduced.StateCount, full.StateCount)
+	}
+}
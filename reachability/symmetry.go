@@ -0,0 +1,280 @@
+package reachability
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Symmetries describes structurally interchangeable nodes in a Petri net.
+// Places (or transitions) in the same group have identical connectivity up to
+// renaming, e.g. the rows of a board game or a pool of identical servers.
+type Symmetries struct {
+	// PlaceGroups contains groups of two or more fully interchangeable
+	// places: every permutation of a group extends to a verified net
+	// automorphism, so quotient exploration over these groups is exact.
+	PlaceGroups [][]string
+	// TransitionGroups contains groups of transitions with matching
+	// refinement colors. Unlike PlaceGroups these are candidates only —
+	// they are reported for inspection but not used for reduction.
+	TransitionGroups [][]string
+
+	placeGroup map[string]int // place -> index into PlaceGroups
+}
+
+// HasSymmetry returns true if any symmetric group was found.
+func (s *Symmetries) HasSymmetry() bool {
+	return len(s.PlaceGroups) > 0 || len(s.TransitionGroups) > 0
+}
+
+// GroupOf returns the symmetry group containing the given place, or nil.
+func (s *Symmetries) GroupOf(place string) []string {
+	if idx, ok := s.placeGroup[place]; ok {
+		return s.PlaceGroups[idx]
+	}
+	return nil
+}
+
+// DetectSymmetries finds groups of interchangeable places and transitions.
+// Iterative color refinement on the net's bipartite structure proposes
+// candidate groups: two nodes share a color only if every refinement round
+// assigns them the same signature (initial marking, arc weights, and
+// neighbor colors). Matching colors are necessary but not sufficient for a
+// net automorphism, so each candidate place group is then verified — a
+// place stays grouped with the others only when swapping the two extends
+// to an automorphism — and groups are split to the verified subsets.
+func DetectSymmetries(net *petri.PetriNet) *Symmetries {
+	// Initial colors: places by (initial tokens, capacity, overflow policy),
+	// transitions by role. The overflow policy is part of the color because
+	// exploration enforces it: a blocking place and an ignoring one behave
+	// differently at capacity even with identical connectivity.
+	placeColor := make(map[string]string)
+	for name, p := range net.Places {
+		placeColor[name] = fmt.Sprintf("P|%v|%v|%d", p.Initial, p.Capacity, p.Overflow)
+	}
+	transColor := make(map[string]string)
+	for name, t := range net.Transitions {
+		transColor[name] = "T|" + t.Role
+	}
+
+	// Refine until colors stabilize. Each round recomputes a node's color from
+	// its old color plus the multiset of (edge direction, weight, neighbor color).
+	for round := 0; round < len(placeColor)+len(transColor); round++ {
+		newPlace := refineColors(net, placeColor, transColor, true)
+		newTrans := refineColors(net, placeColor, transColor, false)
+		if colorClassCount(newPlace) == colorClassCount(placeColor) &&
+			colorClassCount(newTrans) == colorClassCount(transColor) {
+			placeColor, transColor = newPlace, newTrans
+			break
+		}
+		placeColor, transColor = newPlace, newTrans
+	}
+
+	sym := &Symmetries{placeGroup: make(map[string]int)}
+	for _, group := range groupByColor(placeColor) {
+		if len(group) <= 1 {
+			continue
+		}
+		for _, verified := range verifyPlaceGroup(net, group) {
+			for _, p := range verified {
+				sym.placeGroup[p] = len(sym.PlaceGroups)
+			}
+			sym.PlaceGroups = append(sym.PlaceGroups, verified)
+		}
+	}
+	for _, group := range groupByColor(transColor) {
+		if len(group) > 1 {
+			sym.TransitionGroups = append(sym.TransitionGroups, group)
+		}
+	}
+	return sym
+}
+
+// verifyPlaceGroup splits a candidate group into subsets whose member
+// swaps are verified net automorphisms. Transpositions with a fixed anchor
+// generate the full symmetric group on a subset, and automorphisms compose,
+// so every permutation within a returned subset is a net automorphism.
+func verifyPlaceGroup(net *petri.PetriNet, group []string) [][]string {
+	var verified [][]string
+	rest := group
+	for len(rest) > 1 {
+		anchor := rest[0]
+		subset := []string{anchor}
+		var remaining []string
+		for _, p := range rest[1:] {
+			if swapIsAutomorphism(net, anchor, p) {
+				subset = append(subset, p)
+			} else {
+				remaining = append(remaining, p)
+			}
+		}
+		if len(subset) > 1 {
+			verified = append(verified, subset)
+		}
+		rest = remaining
+	}
+	return verified
+}
+
+// swapIsAutomorphism reports whether exchanging two places extends to a net
+// automorphism. Every arc has exactly one transition endpoint, so a
+// transition bijection preserving each transition's incident-arc signature
+// preserves all arcs; such a bijection exists exactly when the signature
+// multisets before and after the renaming coincide. Nets with guards or
+// arc expressions are never verified, since place names inside expressions
+// are not tracked by the renaming.
+func swapIsAutomorphism(net *petri.PetriNet, p, q string) bool {
+	if net.HasDynamics() {
+		return false
+	}
+	rename := func(name string) string {
+		switch name {
+		case p:
+			return q
+		case q:
+			return p
+		}
+		return name
+	}
+	original := make(map[string]int)
+	renamed := make(map[string]int)
+	for name, t := range net.Transitions {
+		original[transitionSignature(net, name, t.Role, nil)]++
+		renamed[transitionSignature(net, name, t.Role, rename)]++
+	}
+	for sig, count := range original {
+		if renamed[sig] != count {
+			return false
+		}
+	}
+	return true
+}
+
+// transitionSignature serializes a transition's role and incident arcs,
+// optionally renaming place endpoints.
+func transitionSignature(net *petri.PetriNet, name, role string, rename func(string) string) string {
+	var edges []string
+	for _, arc := range net.Arcs {
+		var place, dir string
+		switch {
+		case arc.Source == name:
+			place, dir = arc.Target, "out"
+		case arc.Target == name:
+			place, dir = arc.Source, "in"
+		default:
+			continue
+		}
+		if rename != nil {
+			place = rename(place)
+		}
+		edges = append(edges, fmt.Sprintf("%s:%.4g:%v:%s", dir, arc.GetWeightSum(), arc.InhibitTransition, place))
+	}
+	sort.Strings(edges)
+	return "T|" + role + "#" + strings.Join(edges, ";")
+}
+
+// refineColors computes one refinement round for places (forPlaces=true)
+// or transitions (forPlaces=false).
+func refineColors(net *petri.PetriNet, placeColor, transColor map[string]string, forPlaces bool) map[string]string {
+	result := make(map[string]string)
+	var names []string
+	if forPlaces {
+		for name := range net.Places {
+			names = append(names, name)
+		}
+	} else {
+		for name := range net.Transitions {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		var edges []string
+		for _, arc := range net.Arcs {
+			var neighbor, dir string
+			switch {
+			case arc.Source == name:
+				neighbor, dir = arc.Target, "out"
+			case arc.Target == name:
+				neighbor, dir = arc.Source, "in"
+			default:
+				continue
+			}
+			nc, ok := placeColor[neighbor]
+			if !ok {
+				nc = transColor[neighbor]
+			}
+			edges = append(edges, fmt.Sprintf("%s:%.4g:%v:%s", dir, arc.GetWeightSum(), arc.InhibitTransition, nc))
+		}
+		sort.Strings(edges)
+		old := transColor[name]
+		if forPlaces {
+			old = placeColor[name]
+		}
+		result[name] = old + "#" + strings.Join(edges, ";")
+	}
+	return result
+}
+
+// colorClassCount returns the number of distinct colors.
+func colorClassCount(colors map[string]string) int {
+	seen := make(map[string]bool)
+	for _, c := range colors {
+		seen[c] = true
+	}
+	return len(seen)
+}
+
+// groupByColor groups node names sharing a color, each group sorted by name.
+func groupByColor(colors map[string]string) [][]string {
+	byColor := make(map[string][]string)
+	for name, c := range colors {
+		byColor[c] = append(byColor[c], name)
+	}
+	var keys []string
+	for c := range byColor {
+		keys = append(keys, c)
+	}
+	sort.Strings(keys)
+	var groups [][]string
+	for _, c := range keys {
+		group := byColor[c]
+		sort.Strings(group)
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// CanonicalMarking maps a marking to a canonical representative of its
+// symmetry orbit: within each symmetric place group the token counts are
+// sorted and reassigned to the group's places in name order. Exploring
+// canonical markings yields the quotient reachability graph.
+func (s *Symmetries) CanonicalMarking(m Marking) Marking {
+	if len(s.PlaceGroups) == 0 {
+		return m
+	}
+	result := m.Copy()
+	for _, group := range s.PlaceGroups {
+		counts := make([]int, len(group))
+		for i, p := range group {
+			counts[i] = m[p]
+		}
+		sort.Ints(counts)
+		for i, p := range group {
+			result[p] = counts[i]
+		}
+	}
+	return result
+}
+
+// WithSymmetryReduction enables quotient-graph exploration: markings related
+// by a verified place symmetry are identified, which can shrink the state
+// space exponentially for nets with many identical components. Only place
+// groups whose permutations are confirmed net automorphisms participate,
+// so the quotient preserves reachability properties exactly.
+func (a *Analyzer) WithSymmetryReduction() *Analyzer {
+	a.symmetries = DetectSymmetries(a.net)
+	return a
+}
@@ -0,0 +1,117 @@
+package reachability
+
+import (
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Helper: pool of identical servers fed from a shared queue
+func createSymmetricServerNet() *petri.PetriNet {
+	b := petri.Build().Place("queue", 3).Place("done", 0)
+	for _, s := range []string{"s1", "s2", "s3"} {
+		b.Place(s, 1).
+			Transition("work_"+s).
+			Arc("queue", "work_"+s, 1).
+			Arc(s, "work_"+s, 1).
+			Arc("work_"+s, "done", 1)
+	}
+	return b.Done()
+}
+
+func TestDetectSymmetries(t *testing.T) {
+	net := createSymmetricServerNet()
+	sym := DetectSymmetries(net)
+
+	if !sym.HasSymmetry() {
+		t.Fatal("Expected symmetry in identical server pool")
+	}
+	if len(sym.PlaceGroups) != 1 || len(sym.PlaceGroups[0]) != 3 {
+		t.Errorf("Expected one group of 3 symmetric places, got %v", sym.PlaceGroups)
+	}
+	if len(sym.TransitionGroups) != 1 || len(sym.TransitionGroups[0]) != 3 {
+		t.Errorf("Expected one group of 3 symmetric transitions, got %v", sym.TransitionGroups)
+	}
+}
+
+func TestDetectSymmetriesAsymmetric(t *testing.T) {
+	net := createSimpleNet()
+	sym := DetectSymmetries(net)
+
+	if sym.HasSymmetry() {
+		t.Errorf("Expected no symmetry in A->B net, got %v / %v", sym.PlaceGroups, sym.TransitionGroups)
+	}
+}
+
+func TestSymmetryReductionShrinksStateSpace(t *testing.T) {
+	net := createSymmetricServerNet()
+
+	full := NewAnalyzer(net).Analyze()
+	reduced := NewAnalyzer(net).WithSymmetryReduction().Analyze()
+
+	if reduced.StateCount >= full.StateCount {
+		t.Errorf("Expected quotient graph smaller than full graph: %d >= %d",
+			reduced.StateCount, full.StateCount)
+	}
+	// Deadlock-freedom must be preserved by the quotient
+	if full.HasDeadlock != reduced.HasDeadlock {
+		t.Errorf("Quotient changed deadlock result: full=%v reduced=%v",
+			full.HasDeadlock, reduced.HasDeadlock)
+	}
+}
+
+func TestCanonicalMarking(t *testing.T) {
+	net := createSymmetricServerNet()
+	sym := DetectSymmetries(net)
+
+	m1 := Marking{"queue": 1, "done": 0, "s1": 0, "s2": 1, "s3": 1}
+	m2 := Marking{"queue": 1, "done": 0, "s1": 1, "s2": 0, "s3": 1}
+
+	if !sym.CanonicalMarking(m1).Equals(sym.CanonicalMarking(m2)) {
+		t.Error("Symmetric markings should canonicalize identically")
+	}
+}
+
+func TestDetectSymmetriesRejectsNonAutomorphicGroups(t *testing.T) {
+	// A directed 3-cycle: color refinement gives all places one color, but
+	// only rotations are automorphisms. Sorting counts within the group
+	// would identify markings no automorphism relates (e.g. [2 1 0] and
+	// [2 0 1]), so no place group may survive verification.
+	net := petri.Build().
+		Place("p1", 1).Place("p2", 1).Place("p3", 1).
+		Transition("t1").Transition("t2").Transition("t3").
+		Arc("p1", "t1", 1).Arc("t1", "p2", 1).
+		Arc("p2", "t2", 1).Arc("t2", "p3", 1).
+		Arc("p3", "t3", 1).Arc("t3", "p1", 1).
+		Done()
+
+	sym := DetectSymmetries(net)
+	if len(sym.PlaceGroups) != 0 {
+		t.Errorf("Expected no verified place groups in a directed cycle, got %v", sym.PlaceGroups)
+	}
+}
+
+// TestDetectSymmetriesRespectsOverflowPolicy checks that places identical
+// except for their overflow policy are not grouped: exploration enforces the
+// policy, so a blocking place and an ignoring one are not interchangeable.
+func TestDetectSymmetriesRespectsOverflowPolicy(t *testing.T) {
+	b := petri.Build().Place("queue", 3).Place("done", 0)
+	for _, s := range []string{"s1", "s2", "s3"} {
+		b.PlaceWithCapacity(s, 1, 2).
+			Transition("work_"+s).
+			Arc("queue", "work_"+s, 1).
+			Arc(s, "work_"+s, 1).
+			Arc("work_"+s, "done", 1)
+	}
+	net := b.Overflow("s1", petri.OverflowBlock).Done()
+
+	sym := DetectSymmetries(net)
+
+	if len(sym.PlaceGroups) != 1 {
+		t.Fatalf("Expected one place group, got %v", sym.PlaceGroups)
+	}
+	group := sym.PlaceGroups[0]
+	if len(group) != 2 || group[0] != "s2" || group[1] != "s3" {
+		t.Errorf("Expected blocking s1 excluded, got group %v", group)
+	}
+}
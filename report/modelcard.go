@@ -0,0 +1,205 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/learn"
+	"github.com/pflow-xyz/go-pflow/mining"
+)
+
+// KindModelCard identifies model card payloads in the report envelope.
+const KindModelCard = "model-card"
+
+// ModelCard is a governance artifact for a mined and fitted model: where
+// the data came from, how well the model fits, and what it should (not) be
+// used for. Store it alongside the model for review.
+type ModelCard struct {
+	Name      string    `json:"name"`
+	Version   string    `json:"version,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Data provenance.
+	DataSource string    `json:"dataSource,omitempty"`
+	Cases      int       `json:"cases"`
+	Events     int       `json:"events"`
+	DateFrom   time.Time `json:"dateFrom,omitempty"`
+	DateTo     time.Time `json:"dateTo,omitempty"`
+
+	// Discovery and fit metrics.
+	Discovery   *DiscoverySummary   `json:"discovery,omitempty"`
+	Conformance *ConformanceSummary `json:"conformance,omitempty"`
+	Fit         *FitSummary         `json:"fit,omitempty"`
+
+	// Governance notes.
+	IntendedUse string   `json:"intendedUse,omitempty"`
+	Limitations []string `json:"limitations,omitempty"`
+}
+
+// NewModelCard starts a card; chain the With* methods and finish with
+// Report() or Markdown().
+func NewModelCard(name string) *ModelCard {
+	return &ModelCard{
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// WithVersion records the model version.
+func (c *ModelCard) WithVersion(version string) *ModelCard {
+	c.Version = version
+	return c
+}
+
+// WithLog records data provenance: source description, volume and date
+// range.
+func (c *ModelCard) WithLog(log *eventlog.EventLog, source string) *ModelCard {
+	c.DataSource = source
+	c.Cases = log.NumCases()
+	c.Events = log.NumEvents()
+	for _, trace := range log.GetTraces() {
+		if len(trace.Events) == 0 {
+			continue
+		}
+		start, end := trace.StartTime(), trace.EndTime()
+		if c.DateFrom.IsZero() || start.Before(c.DateFrom) {
+			c.DateFrom = start
+		}
+		if end.After(c.DateTo) {
+			c.DateTo = end
+		}
+	}
+	return c
+}
+
+// WithDiscovery records how the model was discovered. Low coverage adds an
+// automatic limitation.
+func (c *ModelCard) WithDiscovery(result *mining.DiscoveryResult) *ModelCard {
+	summary := DiscoverySummary{
+		Method:          result.Method,
+		NumVariants:     result.NumVariants,
+		MostCommonCount: result.MostCommonCount,
+		CoveragePercent: result.CoveragePercent,
+	}
+	if result.Net != nil {
+		summary.Places = len(result.Net.Places)
+		summary.Transitions = len(result.Net.Transitions)
+	}
+	c.Discovery = &summary
+	if result.CoveragePercent < 80 {
+		c.Limitations = append(c.Limitations, fmt.Sprintf(
+			"Model covers only %.0f%% of observed cases; rare variants are not represented.",
+			result.CoveragePercent))
+	}
+	return c
+}
+
+// WithConformance records replay fitness against the source log.
+func (c *ModelCard) WithConformance(result *mining.ConformanceResult) *ModelCard {
+	c.Conformance = &ConformanceSummary{
+		Fitness:         result.Fitness,
+		FittingTraces:   result.FittingTraces,
+		TotalTraces:     result.TotalTraces,
+		FittingPercent:  result.FittingPercent,
+		AvgTraceFitness: result.AvgTraceFitness,
+		MissingTokens:   result.MissingTokens,
+		RemainingTokens: result.RemainingTokens,
+	}
+	return c
+}
+
+// WithFit records rate fitting metrics. Non-convergence adds an automatic
+// limitation.
+func (c *ModelCard) WithFit(result *learn.FitResult) *ModelCard {
+	c.Fit = &FitSummary{
+		Params:      result.Params,
+		InitialLoss: result.InitialLoss,
+		FinalLoss:   result.FinalLoss,
+		Iterations:  result.Iterations,
+		Converged:   result.Converged,
+	}
+	if !result.Converged {
+		c.Limitations = append(c.Limitations,
+			"Rate fitting did not converge; simulated timings may be unreliable.")
+	}
+	return c
+}
+
+// WithIntendedUse documents what the model is meant for.
+func (c *ModelCard) WithIntendedUse(use string) *ModelCard {
+	c.IntendedUse = use
+	return c
+}
+
+// AddLimitation appends a known limitation.
+func (c *ModelCard) AddLimitation(limitation string) *ModelCard {
+	c.Limitations = append(c.Limitations, limitation)
+	return c
+}
+
+// Report wraps the card in the versioned envelope.
+func (c *ModelCard) Report() (*Report, error) {
+	return New(KindModelCard, c.Name, c)
+}
+
+// ModelCardPayload decodes the payload of a model card report.
+func (r *Report) ModelCardPayload() (*ModelCard, error) {
+	var c ModelCard
+	return &c, r.decode(KindModelCard, &c)
+}
+
+// Markdown renders the card for human review.
+func (c *ModelCard) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Model Card: %s\n\n", c.Name)
+	if c.Version != "" {
+		fmt.Fprintf(&b, "Version %s, ", c.Version)
+	}
+	fmt.Fprintf(&b, "generated %s\n\n", c.CreatedAt.Format("2006-01-02"))
+
+	b.WriteString("## Data\n\n")
+	if c.DataSource != "" {
+		fmt.Fprintf(&b, "- Source: %s\n", c.DataSource)
+	}
+	fmt.Fprintf(&b, "- %d cases, %d events\n", c.Cases, c.Events)
+	if !c.DateFrom.IsZero() {
+		fmt.Fprintf(&b, "- Date range: %s to %s\n",
+			c.DateFrom.Format("2006-01-02"), c.DateTo.Format("2006-01-02"))
+	}
+
+	if c.Discovery != nil {
+		b.WriteString("\n## Discovery\n\n")
+		fmt.Fprintf(&b, "- Method: %s\n", c.Discovery.Method)
+		fmt.Fprintf(&b, "- Coverage: %.1f%% of cases (%d variants observed)\n",
+			c.Discovery.CoveragePercent, c.Discovery.NumVariants)
+		fmt.Fprintf(&b, "- Size: %d places, %d transitions\n",
+			c.Discovery.Places, c.Discovery.Transitions)
+	}
+	if c.Conformance != nil {
+		b.WriteString("\n## Conformance\n\n")
+		fmt.Fprintf(&b, "- Fitness: %.3f\n", c.Conformance.Fitness)
+		fmt.Fprintf(&b, "- Fitting traces: %d/%d (%.1f%%)\n",
+			c.Conformance.FittingTraces, c.Conformance.TotalTraces, c.Conformance.FittingPercent)
+	}
+	if c.Fit != nil {
+		b.WriteString("\n## Rate Fitting\n\n")
+		fmt.Fprintf(&b, "- Loss: %.4f -> %.4f over %d iterations\n",
+			c.Fit.InitialLoss, c.Fit.FinalLoss, c.Fit.Iterations)
+		fmt.Fprintf(&b, "- Converged: %t\n", c.Fit.Converged)
+	}
+
+	if c.IntendedUse != "" {
+		b.WriteString("\n## Intended Use\n\n")
+		b.WriteString(c.IntendedUse)
+		b.WriteString("\n")
+	}
+	if len(c.Limitations) > 0 {
+		b.WriteString("\n## Known Limitations\n\n")
+		for _, l := range c.Limitations {
+			fmt.Fprintf(&b, "- %s\n", l)
+		}
+	}
+	return b.String()
+}
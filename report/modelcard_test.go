@@ -0,0 +1,99 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/eventlog"
+	"github.com/pflow-xyz/go-pflow/mining"
+)
+
+func createCardLog() *eventlog.EventLog {
+	log := eventlog.NewEventLog()
+	baseTime := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		caseID := string(rune('a' + i))
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "open", Timestamp: baseTime.AddDate(0, 0, i)})
+		log.AddEvent(eventlog.Event{CaseID: caseID, Activity: "close", Timestamp: baseTime.AddDate(0, 0, i).Add(time.Hour)})
+	}
+	log.SortTraces()
+	return log
+}
+
+func TestModelCardBuild(t *testing.T) {
+	log := createCardLog()
+	discovery, err := mining.Discover(log, "heuristic")
+	if err != nil {
+		t.Fatalf("Discover: %v", err)
+	}
+	conformance := mining.CheckConformance(log, discovery.Net)
+
+	card := NewModelCard("incident-response").
+		WithVersion("v2").
+		WithLog(log, "prod incident tracker, March 2024 export").
+		WithDiscovery(discovery).
+		WithConformance(conformance).
+		WithIntendedUse("Capacity planning for the on-call rotation.").
+		AddLimitation("Excludes security incidents, which follow a separate process.")
+
+	if card.Cases != 5 || card.Events != 10 {
+		t.Errorf("Provenance: %d cases, %d events", card.Cases, card.Events)
+	}
+	if card.DateFrom.Day() != 1 || card.DateTo.Day() != 5 {
+		t.Errorf("Date range: %v to %v", card.DateFrom, card.DateTo)
+	}
+	if card.Discovery == nil || card.Discovery.Method != "heuristic" {
+		t.Error("Discovery metrics missing")
+	}
+	if card.Conformance == nil {
+		t.Error("Conformance metrics missing")
+	}
+}
+
+func TestModelCardEnvelope(t *testing.T) {
+	card := NewModelCard("test").AddLimitation("toy data")
+	r, err := card.Report()
+	if err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if r.Kind != KindModelCard || r.Source != "test" {
+		t.Errorf("Bad envelope: %+v", r)
+	}
+
+	data, _ := r.Marshal()
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	decoded, err := parsed.ModelCardPayload()
+	if err != nil {
+		t.Fatalf("ModelCardPayload: %v", err)
+	}
+	if len(decoded.Limitations) != 1 || decoded.Limitations[0] != "toy data" {
+		t.Errorf("Round trip lost limitations: %+v", decoded.Limitations)
+	}
+}
+
+func TestModelCardMarkdown(t *testing.T) {
+	log := createCardLog()
+	discovery, _ := mining.Discover(log, "heuristic")
+
+	md := NewModelCard("incident-response").
+		WithLog(log, "tracker export").
+		WithDiscovery(discovery).
+		WithIntendedUse("Planning only.").
+		Markdown()
+
+	for _, want := range []string{
+		"# Model Card: incident-response",
+		"## Data",
+		"tracker export",
+		"## Discovery",
+		"## Intended Use",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q", want)
+		}
+	}
+}
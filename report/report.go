@@ -0,0 +1,221 @@
+// Package report standardizes analysis results into a versioned,
+// machine-readable JSON envelope. CI systems and UIs consume these instead
+// of scraping the human-oriented String/Print output of the analysis
+// packages.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/learn"
+	"github.com/pflow-xyz/go-pflow/mining"
+	"github.com/pflow-xyz/go-pflow/reachability"
+)
+
+// SchemaVersion identifies the envelope schema. Bump the major version on
+// breaking payload changes; consumers should reject majors they don't know.
+const SchemaVersion = "1.0.0"
+
+// Report kinds, one per analysis type.
+const (
+	KindReachability = "reachability"
+	KindConformance  = "conformance"
+	KindDiscovery    = "discovery"
+	KindFit          = "fit"
+)
+
+// Report is the envelope wrapping one analysis result.
+type Report struct {
+	Schema      string          `json:"schema"`  // "go-pflow/report"
+	Version     string          `json:"version"` // SchemaVersion at write time
+	Kind        string          `json:"kind"`    // payload discriminator
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Source      string          `json:"source,omitempty"` // model or log name, if any
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// ReachabilitySummary is the machine-readable form of reachability.Result.
+// The state graph itself is omitted; this carries the verdicts.
+type ReachabilitySummary struct {
+	StateCount  int            `json:"stateCount"`
+	EdgeCount   int            `json:"edgeCount"`
+	Bounded     bool           `json:"bounded"`
+	MaxTokens   map[string]int `json:"maxTokens,omitempty"`
+	HasDeadlock bool           `json:"hasDeadlock"`
+	Deadlocks   int            `json:"deadlocks"`
+	HasCycle    bool           `json:"hasCycle"`
+	Live        bool           `json:"live"`
+	DeadTrans   []string       `json:"deadTransitions,omitempty"`
+	Truncated   bool           `json:"truncated"`
+	IsComplete  bool           `json:"isComplete"`
+}
+
+// ConformanceSummary is the machine-readable form of mining.ConformanceResult.
+type ConformanceSummary struct {
+	Fitness         float64 `json:"fitness"`
+	FittingTraces   int     `json:"fittingTraces"`
+	TotalTraces     int     `json:"totalTraces"`
+	FittingPercent  float64 `json:"fittingPercent"`
+	AvgTraceFitness float64 `json:"avgTraceFitness"`
+	MissingTokens   int     `json:"missingTokens"`
+	RemainingTokens int     `json:"remainingTokens"`
+}
+
+// DiscoverySummary is the machine-readable form of mining.DiscoveryResult.
+// The discovered net travels separately (e.g. as JSON-LD via the petri
+// package); this carries the discovery metrics.
+type DiscoverySummary struct {
+	Method          string  `json:"method"`
+	Places          int     `json:"places"`
+	Transitions     int     `json:"transitions"`
+	NumVariants     int     `json:"numVariants"`
+	MostCommonCount int     `json:"mostCommonCount"`
+	CoveragePercent float64 `json:"coveragePercent"`
+}
+
+// FitSummary is the machine-readable form of learn.FitResult.
+type FitSummary struct {
+	Params      []float64 `json:"params"`
+	InitialLoss float64   `json:"initialLoss"`
+	FinalLoss   float64   `json:"finalLoss"`
+	Iterations  int       `json:"iterations"`
+	Converged   bool      `json:"converged"`
+}
+
+// New wraps a payload in a versioned envelope. Most callers use the
+// From* constructors instead.
+func New(kind, source string, payload interface{}) (*Report, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s payload: %w", kind, err)
+	}
+	return &Report{
+		Schema:      "go-pflow/report",
+		Version:     SchemaVersion,
+		Kind:        kind,
+		GeneratedAt: time.Now().UTC(),
+		Source:      source,
+		Payload:     data,
+	}, nil
+}
+
+// FromReachability wraps a reachability result.
+func FromReachability(result *reachability.Result, source string) (*Report, error) {
+	return New(KindReachability, source, ReachabilitySummary{
+		StateCount:  result.StateCount,
+		EdgeCount:   result.EdgeCount,
+		Bounded:     result.Bounded,
+		MaxTokens:   result.MaxTokens,
+		HasDeadlock: result.HasDeadlock,
+		Deadlocks:   len(result.Deadlocks),
+		HasCycle:    result.HasCycle,
+		Live:        result.Live,
+		DeadTrans:   result.DeadTrans,
+		Truncated:   result.Truncated,
+		IsComplete:  result.IsComplete,
+	})
+}
+
+// FromConformance wraps a conformance checking result.
+func FromConformance(result *mining.ConformanceResult, source string) (*Report, error) {
+	return New(KindConformance, source, ConformanceSummary{
+		Fitness:         result.Fitness,
+		FittingTraces:   result.FittingTraces,
+		TotalTraces:     result.TotalTraces,
+		FittingPercent:  result.FittingPercent,
+		AvgTraceFitness: result.AvgTraceFitness,
+		MissingTokens:   result.MissingTokens,
+		RemainingTokens: result.RemainingTokens,
+	})
+}
+
+// FromDiscovery wraps a process discovery result.
+func FromDiscovery(result *mining.DiscoveryResult, source string) (*Report, error) {
+	summary := DiscoverySummary{
+		Method:          result.Method,
+		NumVariants:     result.NumVariants,
+		MostCommonCount: result.MostCommonCount,
+		CoveragePercent: result.CoveragePercent,
+	}
+	if result.Net != nil {
+		summary.Places = len(result.Net.Places)
+		summary.Transitions = len(result.Net.Transitions)
+	}
+	return New(KindDiscovery, source, summary)
+}
+
+// FromFit wraps a parameter fitting result.
+func FromFit(result *learn.FitResult, source string) (*Report, error) {
+	return New(KindFit, source, FitSummary{
+		Params:      result.Params,
+		InitialLoss: result.InitialLoss,
+		FinalLoss:   result.FinalLoss,
+		Iterations:  result.Iterations,
+		Converged:   result.Converged,
+	})
+}
+
+// Marshal serializes the envelope with indentation for stable diffs.
+func (r *Report) Marshal() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Parse reads an envelope, rejecting schema majors this version doesn't
+// understand. The payload stays raw; decode it with the typed accessors.
+func Parse(data []byte) (*Report, error) {
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %w", err)
+	}
+	if r.Schema != "go-pflow/report" {
+		return nil, fmt.Errorf("unknown report schema %q", r.Schema)
+	}
+	if major(r.Version) != major(SchemaVersion) {
+		return nil, fmt.Errorf("unsupported report version %q (expected %s.x)",
+			r.Version, major(SchemaVersion))
+	}
+	return &r, nil
+}
+
+// major extracts the major component of a semantic version string.
+func major(version string) string {
+	for i := 0; i < len(version); i++ {
+		if version[i] == '.' {
+			return version[:i]
+		}
+	}
+	return version
+}
+
+// Reachability decodes the payload of a reachability report.
+func (r *Report) Reachability() (*ReachabilitySummary, error) {
+	var s ReachabilitySummary
+	return &s, r.decode(KindReachability, &s)
+}
+
+// Conformance decodes the payload of a conformance report.
+func (r *Report) Conformance() (*ConformanceSummary, error) {
+	var s ConformanceSummary
+	return &s, r.decode(KindConformance, &s)
+}
+
+// Discovery decodes the payload of a discovery report.
+func (r *Report) Discovery() (*DiscoverySummary, error) {
+	var s DiscoverySummary
+	return &s, r.decode(KindDiscovery, &s)
+}
+
+// Fit decodes the payload of a fit report.
+func (r *Report) Fit() (*FitSummary, error) {
+	var s FitSummary
+	return &s, r.decode(KindFit, &s)
+}
+
+func (r *Report) decode(kind string, into interface{}) error {
+	if r.Kind != kind {
+		return fmt.Errorf("report is %q, not %q", r.Kind, kind)
+	}
+	return json.Unmarshal(r.Payload, into)
+}
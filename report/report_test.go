@@ -0,0 +1,58 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/reachability"
+)
+
+func TestReachabilityRoundTrip(t *testing.T) {
+	net := petri.Build().
+		Chain(1, "start", "t1", "middle", "t2", "end").
+		Done()
+	result := reachability.NewAnalyzer(net).WithMaxStates(100).Analyze()
+
+	r, err := FromReachability(result, "chain")
+	if err != nil {
+		t.Fatalf("FromReachability: %v", err)
+	}
+	if r.Kind != KindReachability || r.Version != SchemaVersion || r.Source != "chain" {
+		t.Errorf("Bad envelope: %+v", r)
+	}
+
+	data, err := r.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	summary, err := parsed.Reachability()
+	if err != nil {
+		t.Fatalf("Reachability: %v", err)
+	}
+	if summary.StateCount != result.StateCount || summary.Bounded != result.Bounded {
+		t.Errorf("Round trip mismatch: %+v vs %+v", summary, result)
+	}
+
+	// Decoding as the wrong kind fails loudly.
+	if _, err := parsed.Fit(); err == nil {
+		t.Error("Expected kind mismatch error")
+	}
+}
+
+func TestParseRejectsUnknownVersions(t *testing.T) {
+	if _, err := Parse([]byte(`{"schema":"go-pflow/report","version":"9.0.0","kind":"fit","payload":{}}`)); err == nil {
+		t.Error("Expected version rejection")
+	}
+	if _, err := Parse([]byte(`{"schema":"other","version":"1.0.0","kind":"fit","payload":{}}`)); err == nil {
+		t.Error("Expected schema rejection")
+	}
+	// Same major, newer minor is accepted.
+	if _, err := Parse([]byte(`{"schema":"go-pflow/report","version":"1.9.0","kind":"fit","payload":{}}`)); err != nil {
+		t.Errorf("Minor version bump should parse: %v", err)
+	}
+}
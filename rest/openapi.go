@@ -0,0 +1,224 @@
+package rest
+
+import (
+	"fmt"
+	"sort"
+)
+
+// OpenAPISpec generates an OpenAPI 3 document for the registered models.
+// Each model contributes its own instance paths, a marking schema with one
+// integer property per place, and a transition path parameter constrained
+// to the model's transition names.
+func (s *Server) OpenAPISpec() map[string]any {
+	paths := map[string]any{
+		"/models": map[string]any{
+			"get": operation("listModels", "List registered models",
+				jsonResponse("200", "Model names", schemaRef("ModelList"))),
+		},
+	}
+	schemas := map[string]any{
+		"ModelList": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"models": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			},
+		},
+		"Error": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"error": map[string]any{"type": "string"},
+			},
+		},
+		"SimulationRequest": map[string]any{
+			"type":     "object",
+			"required": []any{"tEnd"},
+			"properties": map[string]any{
+				"tStart":  map[string]any{"type": "number"},
+				"tEnd":    map[string]any{"type": "number"},
+				"samples": map[string]any{"type": "integer"},
+				"rates":   map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "number"}},
+				"initial": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "number"}},
+			},
+		},
+		"SimulationResult": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"t":          map[string]any{"type": "array", "items": map[string]any{"type": "number"}},
+				"states":     map[string]any{"type": "array", "items": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "number"}}},
+				"finalState": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "number"}},
+			},
+		},
+	}
+
+	names := make([]string, 0, len(s.models))
+	for name := range s.models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	allTransitions := make(map[string]bool)
+	for _, name := range names {
+		model := s.models[name]
+		markingSchema := map[string]any{"type": "object", "properties": map[string]any{}}
+		for place := range model.Places {
+			markingSchema["properties"].(map[string]any)[place] = map[string]any{"type": "integer", "minimum": 0}
+		}
+		for t := range model.Transitions {
+			allTransitions[t] = true
+		}
+
+		instanceSchema := fmt.Sprintf("%sInstance", title(name))
+		schemas[fmt.Sprintf("%sMarking", title(name))] = markingSchema
+		schemas[instanceSchema] = map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"id":                 map[string]any{"type": "string"},
+				"modelName":          map[string]any{"type": "string"},
+				"version":            map[string]any{"type": "integer"},
+				"marking":            schemaRef(fmt.Sprintf("%sMarking", title(name))),
+				"enabledTransitions": map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+			},
+		}
+
+		paths[fmt.Sprintf("/models/%s/simulate", name)] = map[string]any{
+			"post": map[string]any{
+				"operationId": fmt.Sprintf("simulate%s", title(name)),
+				"summary":     fmt.Sprintf("Run an ODE simulation of %s", name),
+				"requestBody": map[string]any{
+					"required": true,
+					"content": map[string]any{
+						"application/json": map[string]any{"schema": schemaRef("SimulationRequest")},
+					},
+				},
+				"responses": mergeResponses(
+					jsonResponse("200", "Sampled trajectory", schemaRef("SimulationResult")),
+					jsonResponse("400", "Invalid simulation request", schemaRef("Error")),
+				),
+			},
+		}
+
+		paths[fmt.Sprintf("/models/%s/instances", name)] = map[string]any{
+			"post": operation(
+				fmt.Sprintf("create%sInstance", title(name)),
+				fmt.Sprintf("Create a %s instance", name),
+				jsonResponse("201", "Created instance", schemaRef(instanceSchema))),
+			"get": operation(
+				fmt.Sprintf("list%sInstances", title(name)),
+				fmt.Sprintf("List %s instances", name),
+				jsonResponse("200", "Instance page", map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"items": map[string]any{"type": "array", "items": schemaRef(instanceSchema)},
+						"total": map[string]any{"type": "integer"},
+						"page":  map[string]any{"type": "integer"},
+					},
+				})),
+		}
+	}
+
+	transitions := make([]string, 0, len(allTransitions))
+	for t := range allTransitions {
+		transitions = append(transitions, t)
+	}
+	sort.Strings(transitions)
+	paths["/instances/{id}/transitions/{t}"] = map[string]any{
+		"post": map[string]any{
+			"operationId": "fireTransition",
+			"summary":     "Fire a transition on an instance",
+			"parameters": []any{
+				pathParam("id", map[string]any{"type": "string"}),
+				pathParam("t", map[string]any{"type": "string", "enum": transitions}),
+			},
+			"responses": mergeResponses(
+				jsonResponse("200", "Updated instance", map[string]any{"type": "object"}),
+				jsonResponse("409", "Transition not enabled", schemaRef("Error")),
+			),
+		},
+	}
+
+	paths["/instances/{id}"] = map[string]any{
+		"get": map[string]any{
+			"operationId": "getInstance",
+			"summary":     "Get an instance by ID",
+			"parameters":  []any{pathParam("id", map[string]any{"type": "string"})},
+			"responses": mergeResponses(
+				jsonResponse("200", "Instance", map[string]any{"type": "object"}),
+				jsonResponse("404", "Unknown instance", schemaRef("Error")),
+			),
+		},
+		"delete": map[string]any{
+			"operationId": "deleteInstance",
+			"summary":     "Delete an instance",
+			"parameters":  []any{pathParam("id", map[string]any{"type": "string"})},
+			"responses": map[string]any{
+				"204": map[string]any{"description": "Deleted"},
+			},
+		},
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "go-pflow model API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+}
+
+func operation(id, summary string, responses map[string]any) map[string]any {
+	return map[string]any{
+		"operationId": id,
+		"summary":     summary,
+		"responses":   responses,
+	}
+}
+
+func jsonResponse(status, description string, schema map[string]any) map[string]any {
+	return map[string]any{
+		status: map[string]any{
+			"description": description,
+			"content": map[string]any{
+				"application/json": map[string]any{"schema": schema},
+			},
+		},
+	}
+}
+
+func mergeResponses(responses ...map[string]any) map[string]any {
+	merged := make(map[string]any)
+	for _, r := range responses {
+		for k, v := range r {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+func pathParam(name string, schema map[string]any) map[string]any {
+	return map[string]any{
+		"name":     name,
+		"in":       "path",
+		"required": true,
+		"schema":   schema,
+	}
+}
+
+func schemaRef(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+// title upper-cases the first byte for schema and operation names.
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	b := []byte(s)
+	if b[0] >= 'a' && b[0] <= 'z' {
+		b[0] -= 'a' - 'A'
+	}
+	return string(b)
+}
@@ -0,0 +1,247 @@
+// Package rest exposes Petri net models over a plain HTTP/JSON API for
+// consumers that do not speak GraphQL. The endpoints are generated from the
+// model definitions and mirror the GraphQL operations: create instance,
+// fire transition, inspect state. An OpenAPI 3 document describing the
+// generated API is served at /openapi.json.
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/graphql"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Server is a REST HTTP server for Petri net models. It reuses the
+// graphql.Store abstraction so both APIs share one persistence layer.
+type Server struct {
+	models map[string]*petri.PetriNet
+	stores map[string]graphql.Store
+	mux    *http.ServeMux
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithModel registers a Petri net model and its instance store.
+func WithModel(name string, model *petri.PetriNet, store graphql.Store) Option {
+	return func(s *Server) {
+		s.models[name] = model
+		s.stores[name] = store
+	}
+}
+
+// NewServer creates a REST server with the given options.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		models: make(map[string]*petri.PetriNet),
+		stores: make(map[string]graphql.Store),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /models", s.handleListModels)
+	mux.HandleFunc("GET /models/{name}", s.handleGetModel)
+	mux.HandleFunc("POST /models/{name}/instances", s.handleCreateInstance)
+	mux.HandleFunc("GET /models/{name}/instances", s.handleListInstances)
+	mux.HandleFunc("GET /instances/{id}", s.handleGetInstance)
+	mux.HandleFunc("DELETE /instances/{id}", s.handleDeleteInstance)
+	mux.HandleFunc("POST /instances/{id}/transitions/{t}", s.handleFire)
+	mux.HandleFunc("POST /models/{name}/simulate", s.handleSimulate)
+	mux.HandleFunc("GET /openapi.json", s.handleOpenAPI)
+	s.mux = mux
+	return s
+}
+
+// Handler returns the HTTP handler with all generated routes.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleListModels(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(s.models))
+	for name := range s.models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	writeJSON(w, http.StatusOK, map[string]any{"models": names})
+}
+
+func (s *Server) handleGetModel(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	model, ok := s.models[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown model %q", name))
+		return
+	}
+	places := make(map[string]float64, len(model.Places))
+	for id, place := range model.Places {
+		places[id] = place.GetTokenCount()
+	}
+	transitions := make([]string, 0, len(model.Transitions))
+	for id := range model.Transitions {
+		transitions = append(transitions, id)
+	}
+	sort.Strings(transitions)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"name":        name,
+		"places":      places,
+		"transitions": transitions,
+	})
+}
+
+func (s *Server) handleCreateInstance(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	store, ok := s.stores[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown model %q", name))
+		return
+	}
+	id, err := store.Create(r.Context(), name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	instance, err := store.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, instance)
+}
+
+func (s *Server) handleListInstances(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	store, ok := s.stores[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown model %q", name))
+		return
+	}
+	filter := graphql.InstanceFilter{ModelName: name}
+	filter.Place = r.URL.Query().Get("place")
+	fmt.Sscanf(r.URL.Query().Get("page"), "%d", &filter.Page)
+	fmt.Sscanf(r.URL.Query().Get("per_page"), "%d", &filter.PerPage)
+
+	instances, total, err := store.List(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items": instances,
+		"total": total,
+		"page":  filter.Page,
+	})
+}
+
+func (s *Server) handleGetInstance(w http.ResponseWriter, r *http.Request) {
+	instance, _, err := s.findInstance(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, instance)
+}
+
+func (s *Server) handleDeleteInstance(w http.ResponseWriter, r *http.Request) {
+	_, store, err := s.findInstance(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err := store.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleFire(w http.ResponseWriter, r *http.Request) {
+	instance, store, err := s.findInstance(r)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	transition := r.PathValue("t")
+
+	var bindings map[string]any
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&bindings); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %v", err))
+			return
+		}
+	}
+
+	updated, err := store.Fire(r.Context(), instance.ID, transition, bindings)
+	if err != nil {
+		// Disabled or unknown transitions are client errors, not server
+		// faults.
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) handleSimulate(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	model, ok := s.models[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown model %q", name))
+		return
+	}
+
+	var req graphql.SimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %v", err))
+		return
+	}
+
+	result, err := graphql.SimulateModel(model, req)
+	if err != nil {
+		// Bad time spans or unknown place/transition names are client
+		// errors.
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// findInstance locates an instance by ID across all registered stores.
+func (s *Server) findInstance(r *http.Request) (*graphql.Instance, graphql.Store, error) {
+	id := r.PathValue("id")
+	names := make([]string, 0, len(s.stores))
+	for name := range s.stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if instance, err := s.stores[name].Get(r.Context(), id); err == nil && instance != nil {
+			return instance, s.stores[name], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("unknown instance %q", id)
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.OpenAPISpec())
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
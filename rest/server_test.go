@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/eventsource"
+	"github.com/pflow-xyz/go-pflow/graphql"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func approvalServer(t *testing.T) *Server {
+	t.Helper()
+	model := petri.NewPetriNet()
+	model.AddPlace("pending", 1, nil, 0, 0, nil)
+	model.AddPlace("approved", 0, nil, 100, 0, nil)
+	model.AddTransition("approve", "", 50, 0, nil)
+	model.AddArc("pending", "approve", 1, false)
+	model.AddArc("approve", "approved", 1, false)
+
+	eventStore := eventsource.NewMemoryStore()
+	t.Cleanup(func() { eventStore.Close() })
+	store := graphql.NewEventSourceStore(eventStore, model, "approval")
+
+	return NewServer(WithModel("approval", model, store))
+}
+
+func doJSON(t *testing.T, srv *Server, method, path string, body string) (int, map[string]any) {
+	t.Helper()
+	var reader *strings.Reader
+	if body == "" {
+		reader = strings.NewReader("")
+	} else {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	var decoded map[string]any
+	if rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &decoded); err != nil {
+			t.Fatalf("%s %s: invalid JSON response: %v", method, path, err)
+		}
+	}
+	return rec.Code, decoded
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	srv := approvalServer(t)
+
+	code, created := doJSON(t, srv, http.MethodPost, "/models/approval/instances", "")
+	if code != http.StatusCreated {
+		t.Fatalf("Create: status %d (%v)", code, created)
+	}
+	id, _ := created["id"].(string)
+	if id == "" {
+		t.Fatal("Create returned no ID")
+	}
+
+	code, instance := doJSON(t, srv, http.MethodGet, "/instances/"+id, "")
+	if code != http.StatusOK {
+		t.Fatalf("Get: status %d", code)
+	}
+	marking, _ := instance["marking"].(map[string]any)
+	if marking["pending"] != float64(1) {
+		t.Errorf("Initial marking: %v", marking)
+	}
+
+	code, fired := doJSON(t, srv, http.MethodPost, "/instances/"+id+"/transitions/approve", "")
+	if code != http.StatusOK {
+		t.Fatalf("Fire: status %d (%v)", code, fired)
+	}
+	marking, _ = fired["marking"].(map[string]any)
+	if marking["approved"] != float64(1) {
+		t.Errorf("Marking after fire: %v", marking)
+	}
+
+	// Firing again must fail: pending is empty.
+	code, _ = doJSON(t, srv, http.MethodPost, "/instances/"+id+"/transitions/approve", "")
+	if code != http.StatusConflict {
+		t.Errorf("Refire: expected 409, got %d", code)
+	}
+}
+
+func TestListEndpoints(t *testing.T) {
+	srv := approvalServer(t)
+	doJSON(t, srv, http.MethodPost, "/models/approval/instances", "")
+	doJSON(t, srv, http.MethodPost, "/models/approval/instances", "")
+
+	code, models := doJSON(t, srv, http.MethodGet, "/models", "")
+	if code != http.StatusOK {
+		t.Fatalf("List models: status %d", code)
+	}
+	names, _ := models["models"].([]any)
+	if len(names) != 1 || names[0] != "approval" {
+		t.Errorf("Models: %v", names)
+	}
+
+	code, page := doJSON(t, srv, http.MethodGet, "/models/approval/instances", "")
+	if code != http.StatusOK {
+		t.Fatalf("List instances: status %d", code)
+	}
+	if page["total"] != float64(2) {
+		t.Errorf("Expected 2 instances, got %v", page["total"])
+	}
+}
+
+func TestUnknownModelAndInstance(t *testing.T) {
+	srv := approvalServer(t)
+
+	code, _ := doJSON(t, srv, http.MethodPost, "/models/nope/instances", "")
+	if code != http.StatusNotFound {
+		t.Errorf("Unknown model: expected 404, got %d", code)
+	}
+	code, _ = doJSON(t, srv, http.MethodGet, "/instances/missing", "")
+	if code != http.StatusNotFound {
+		t.Errorf("Unknown instance: expected 404, got %d", code)
+	}
+}
+
+func TestSimulateEndpoint(t *testing.T) {
+	srv := approvalServer(t)
+
+	code, result := doJSON(t, srv, http.MethodPost, "/models/approval/simulate", `{"tEnd": 10}`)
+	if code != http.StatusOK {
+		t.Fatalf("Simulate: status %d (%v)", code, result)
+	}
+	final, _ := result["finalState"].(map[string]any)
+	if approved, _ := final["approved"].(float64); approved < 0.9 {
+		t.Errorf("Final state: %v", final)
+	}
+
+	code, _ = doJSON(t, srv, http.MethodPost, "/models/approval/simulate", `{"tEnd": 0}`)
+	if code != http.StatusBadRequest {
+		t.Errorf("Empty span: expected 400, got %d", code)
+	}
+	code, _ = doJSON(t, srv, http.MethodPost, "/models/nope/simulate", `{"tEnd": 10}`)
+	if code != http.StatusNotFound {
+		t.Errorf("Unknown model: expected 404, got %d", code)
+	}
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	srv := approvalServer(t)
+
+	code, spec := doJSON(t, srv, http.MethodGet, "/openapi.json", "")
+	if code != http.StatusOK {
+		t.Fatalf("OpenAPI: status %d", code)
+	}
+	if spec["openapi"] != "3.0.3" {
+		t.Errorf("Version: %v", spec["openapi"])
+	}
+	paths, _ := spec["paths"].(map[string]any)
+	for _, want := range []string{
+		"/models",
+		"/models/approval/instances",
+		"/models/approval/simulate",
+		"/instances/{id}",
+		"/instances/{id}/transitions/{t}",
+	} {
+		if _, ok := paths[want]; !ok {
+			t.Errorf("Spec missing path %s", want)
+		}
+	}
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	marking, ok := schemas["ApprovalMarking"].(map[string]any)
+	if !ok {
+		t.Fatal("Spec missing marking schema")
+	}
+	props := marking["properties"].(map[string]any)
+	if _, ok := props["pending"]; !ok {
+		t.Error("Marking schema missing place property")
+	}
+}
@@ -0,0 +1,349 @@
+// Package scenarios runs what-if simulation sweeps over a base model.
+// A Runner takes a net with baseline rates and initial marking, a grid of
+// parameter values over rates and/or initial tokens, and a set of named
+// metrics; it simulates every combination in parallel and collects the
+// results into a comparable table. The example CLIs each reinvent this
+// loop — define the grid once and read the table instead.
+package scenarios
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/plotter"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// Metric computes a named number from a simulation result.
+type Metric struct {
+	Name string
+	Fn   func(sol *solver.Solution) float64
+}
+
+// FinalPlace returns a metric reporting the final token count of a place.
+func FinalPlace(place string) Metric {
+	return Metric{
+		Name: place,
+		Fn: func(sol *solver.Solution) float64 {
+			return sol.GetFinalState()[place]
+		},
+	}
+}
+
+// PeakPlace returns a metric reporting the maximum token count a place
+// reaches over the trajectory.
+func PeakPlace(place string) Metric {
+	return Metric{
+		Name: "peak_" + place,
+		Fn: func(sol *solver.Solution) float64 {
+			peak := math.Inf(-1)
+			for _, v := range sol.GetVariable(place) {
+				if v > peak {
+					peak = v
+				}
+			}
+			return peak
+		},
+	}
+}
+
+// axis is one swept parameter: a rate or an initial token count.
+type axis struct {
+	name   string // transition or place label
+	isRate bool
+	values []float64
+}
+
+// Runner configures and executes a scenario sweep.
+type Runner struct {
+	net     *petri.PetriNet
+	state   map[string]float64
+	rates   map[string]float64
+	tspan   [2]float64
+	opts    *solver.Options
+	axes    []axis
+	metrics []Metric
+	workers int
+}
+
+// New creates a scenario runner with baseline state and rates.
+func New(net *petri.PetriNet, state, rates map[string]float64) *Runner {
+	return &Runner{
+		net:     net,
+		state:   net.SetState(state),
+		rates:   net.SetRates(rates),
+		tspan:   [2]float64{0, 10},
+		opts:    solver.DefaultOptions(),
+		workers: runtime.NumCPU(),
+	}
+}
+
+// WithTimeSpan sets the simulation time span.
+func (r *Runner) WithTimeSpan(t0, tf float64) *Runner {
+	r.tspan = [2]float64{t0, tf}
+	return r
+}
+
+// WithOptions sets the solver options.
+func (r *Runner) WithOptions(opts *solver.Options) *Runner {
+	r.opts = opts
+	return r
+}
+
+// WithWorkers sets the number of parallel simulations.
+func (r *Runner) WithWorkers(n int) *Runner {
+	if n > 0 {
+		r.workers = n
+	}
+	return r
+}
+
+// VaryRate sweeps a transition rate over the given values.
+func (r *Runner) VaryRate(transition string, values []float64) *Runner {
+	r.axes = append(r.axes, axis{name: transition, isRate: true, values: values})
+	return r
+}
+
+// VaryRateRange sweeps a transition rate over evenly spaced values.
+func (r *Runner) VaryRateRange(transition string, min, max float64, steps int) *Runner {
+	return r.VaryRate(transition, linspace(min, max, steps))
+}
+
+// VaryInitial sweeps a place's initial token count over the given values.
+func (r *Runner) VaryInitial(place string, values []float64) *Runner {
+	r.axes = append(r.axes, axis{name: place, isRate: false, values: values})
+	return r
+}
+
+// VaryInitialRange sweeps a place's initial tokens over evenly spaced values.
+func (r *Runner) VaryInitialRange(place string, min, max float64, steps int) *Runner {
+	return r.VaryInitial(place, linspace(min, max, steps))
+}
+
+// Metric adds a named metric computed for every scenario.
+func (r *Runner) Metric(name string, fn func(sol *solver.Solution) float64) *Runner {
+	r.metrics = append(r.metrics, Metric{Name: name, Fn: fn})
+	return r
+}
+
+// Metrics adds pre-built metrics such as FinalPlace or PeakPlace.
+func (r *Runner) Metrics(metrics ...Metric) *Runner {
+	r.metrics = append(r.metrics, metrics...)
+	return r
+}
+
+// Scenario is one grid point: the parameter overrides applied and the
+// metrics measured there.
+type Scenario struct {
+	Rates   map[string]float64 // rate overrides (empty entries mean baseline)
+	Initial map[string]float64 // initial-token overrides
+	Metrics map[string]float64
+}
+
+// Label renders the scenario's parameter settings compactly.
+func (s *Scenario) Label() string {
+	var parts []string
+	for _, k := range sortedKeys(s.Rates) {
+		parts = append(parts, fmt.Sprintf("%s=%g", k, s.Rates[k]))
+	}
+	for _, k := range sortedKeys(s.Initial) {
+		parts = append(parts, fmt.Sprintf("%s0=%g", k, s.Initial[k]))
+	}
+	if len(parts) == 0 {
+		return "baseline"
+	}
+	return strings.Join(parts, " ")
+}
+
+// Table holds all scenarios of a sweep, in grid order.
+type Table struct {
+	Scenarios   []*Scenario
+	MetricNames []string
+}
+
+// Run simulates every combination in the grid and returns the result table.
+// It fails if no metrics were configured.
+func (r *Runner) Run() (*Table, error) {
+	if len(r.metrics) == 0 {
+		return nil, fmt.Errorf("no metrics configured; use Metric or Metrics")
+	}
+	for _, ax := range r.axes {
+		if ax.isRate {
+			if _, ok := r.net.Transitions[ax.name]; !ok {
+				return nil, fmt.Errorf("swept transition %q not in net", ax.name)
+			}
+		} else if _, ok := r.net.Places[ax.name]; !ok {
+			return nil, fmt.Errorf("swept place %q not in net", ax.name)
+		}
+	}
+
+	total := 1
+	for _, ax := range r.axes {
+		total *= len(ax.values)
+	}
+
+	table := &Table{Scenarios: make([]*Scenario, total)}
+	for _, m := range r.metrics {
+		table.MetricNames = append(table.MetricNames, m.Name)
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, r.workers)
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(idx int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			table.Scenarios[idx] = r.runOne(idx)
+		}(i)
+	}
+	wg.Wait()
+	return table, nil
+}
+
+// runOne decodes grid index idx into parameter overrides and simulates.
+func (r *Runner) runOne(idx int) *Scenario {
+	sc := &Scenario{
+		Rates:   make(map[string]float64),
+		Initial: make(map[string]float64),
+		Metrics: make(map[string]float64),
+	}
+
+	state := solver.CopyState(r.state)
+	rates := solver.CopyState(r.rates)
+	rem := idx
+	for _, ax := range r.axes {
+		v := ax.values[rem%len(ax.values)]
+		rem /= len(ax.values)
+		if ax.isRate {
+			sc.Rates[ax.name] = v
+			rates[ax.name] = v
+		} else {
+			sc.Initial[ax.name] = v
+			state[ax.name] = v
+		}
+	}
+
+	prob := solver.NewProblem(r.net, state, r.tspan, rates)
+	sol := solver.Solve(prob, solver.Tsit5(), r.opts)
+	for _, m := range r.metrics {
+		sc.Metrics[m.Name] = m.Fn(sol)
+	}
+	return sc
+}
+
+// Best returns the scenario with the highest value of a metric.
+func (t *Table) Best(metric string) *Scenario {
+	var best *Scenario
+	bestVal := math.Inf(-1)
+	for _, sc := range t.Scenarios {
+		if v, ok := sc.Metrics[metric]; ok && v > bestVal {
+			bestVal = v
+			best = sc
+		}
+	}
+	return best
+}
+
+// Worst returns the scenario with the lowest value of a metric.
+func (t *Table) Worst(metric string) *Scenario {
+	var worst *Scenario
+	worstVal := math.Inf(1)
+	for _, sc := range t.Scenarios {
+		if v, ok := sc.Metrics[metric]; ok && v < worstVal {
+			worstVal = v
+			worst = sc
+		}
+	}
+	return worst
+}
+
+// SortBy reorders scenarios by a metric, descending.
+func (t *Table) SortBy(metric string) *Table {
+	sort.SliceStable(t.Scenarios, func(i, j int) bool {
+		return t.Scenarios[i].Metrics[metric] > t.Scenarios[j].Metrics[metric]
+	})
+	return t
+}
+
+// String renders the table as aligned text, one scenario per row.
+func (t *Table) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%-32s", "scenario")
+	for _, name := range t.MetricNames {
+		fmt.Fprintf(&sb, " %14s", name)
+	}
+	sb.WriteByte('\n')
+	for _, sc := range t.Scenarios {
+		fmt.Fprintf(&sb, "%-32s", sc.Label())
+		for _, name := range t.MetricNames {
+			fmt.Fprintf(&sb, " %14.4f", sc.Metrics[name])
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// PlotMetric renders a metric against one swept parameter as an SVG line
+// plot. Scenarios sharing a parameter value are averaged, so the plot stays
+// readable when other axes are swept too.
+func (t *Table) PlotMetric(metric, param string, width, height float64) (string, error) {
+	sums := make(map[float64]float64)
+	counts := make(map[float64]int)
+	for _, sc := range t.Scenarios {
+		v, ok := sc.Rates[param]
+		if !ok {
+			v, ok = sc.Initial[param]
+		}
+		if !ok {
+			return "", fmt.Errorf("parameter %q was not swept", param)
+		}
+		sums[v] += sc.Metrics[metric]
+		counts[v]++
+	}
+
+	xs := make([]float64, 0, len(sums))
+	for v := range sums {
+		xs = append(xs, v)
+	}
+	sort.Float64s(xs)
+	ys := make([]float64, len(xs))
+	for i, x := range xs {
+		ys[i] = sums[x] / float64(counts[x])
+	}
+
+	p := plotter.NewSVGPlotter(width, height).
+		SetTitle(metric+" vs "+param).
+		SetXLabel(param).
+		SetYLabel(metric).
+		AddSeries(xs, ys, metric, "")
+	return p.Render(), nil
+}
+
+// linspace returns steps evenly spaced values from min to max inclusive.
+func linspace(min, max float64, steps int) []float64 {
+	if steps <= 1 {
+		return []float64{min}
+	}
+	values := make([]float64, steps)
+	for i := range values {
+		values[i] = min + (max-min)*float64(i)/float64(steps-1)
+	}
+	return values
+}
+
+// sortedKeys returns map keys in alphabetical order.
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
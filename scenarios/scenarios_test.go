@@ -0,0 +1,106 @@
+package scenarios
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func sirNet() (*petri.PetriNet, map[string]float64) {
+	return petri.Build().SIR(999, 1, 0).WithRates(1.0)
+}
+
+func TestRunGrid(t *testing.T) {
+	net, rates := sirNet()
+	table, err := New(net, nil, rates).
+		WithTimeSpan(0, 10).
+		VaryRate("infect", []float64{0.5, 1.0}).
+		VaryInitial("I", []float64{1, 5}).
+		Metrics(FinalPlace("R"), PeakPlace("I")).
+		Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(table.Scenarios) != 4 {
+		t.Fatalf("Expected 4 scenarios, got %d", len(table.Scenarios))
+	}
+	for _, sc := range table.Scenarios {
+		if len(sc.Metrics) != 2 {
+			t.Errorf("Scenario %q missing metrics: %v", sc.Label(), sc.Metrics)
+		}
+		if sc.Metrics["peak_I"] < sc.Initial["I"] {
+			t.Errorf("Peak infected below initial in %q", sc.Label())
+		}
+	}
+
+	// More infection pressure means more recovered at the end.
+	best := table.Best("R")
+	if best.Rates["infect"] != 1.0 {
+		t.Errorf("Expected best R at infect=1.0, got %v", best.Rates)
+	}
+	worst := table.Worst("R")
+	if worst.Rates["infect"] != 0.5 {
+		t.Errorf("Expected worst R at infect=0.5, got %v", worst.Rates)
+	}
+}
+
+func TestTableFormatting(t *testing.T) {
+	net, rates := sirNet()
+	table, err := New(net, nil, rates).
+		VaryRateRange("infect", 0.5, 1.5, 3).
+		Metrics(FinalPlace("R")).
+		Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	text := table.SortBy("R").String()
+	if !strings.Contains(text, "scenario") || !strings.Contains(text, "infect=") {
+		t.Errorf("Unexpected table output:\n%s", text)
+	}
+
+	// Sorted descending by R
+	first := table.Scenarios[0].Metrics["R"]
+	last := table.Scenarios[len(table.Scenarios)-1].Metrics["R"]
+	if first < last {
+		t.Errorf("Expected descending sort, got %f before %f", first, last)
+	}
+}
+
+func TestPlotMetric(t *testing.T) {
+	net, rates := sirNet()
+	table, err := New(net, nil, rates).
+		VaryRateRange("infect", 0.5, 1.5, 3).
+		Metrics(FinalPlace("R")).
+		Run()
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	svg, err := table.PlotMetric("R", "infect", 640, 480)
+	if err != nil {
+		t.Fatalf("PlotMetric failed: %v", err)
+	}
+	if !strings.Contains(svg, "<svg") {
+		t.Error("Expected SVG output")
+	}
+
+	if _, err := table.PlotMetric("R", "recover", 640, 480); err == nil {
+		t.Error("Expected error for unswept parameter")
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	net, rates := sirNet()
+	if _, err := New(net, nil, rates).Run(); err == nil {
+		t.Error("Expected error without metrics")
+	}
+	if _, err := New(net, nil, rates).
+		VaryRate("bogus", []float64{1}).
+		Metrics(FinalPlace("R")).
+		Run(); err == nil {
+		t.Error("Expected error for unknown transition")
+	}
+}
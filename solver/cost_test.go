@@ -0,0 +1,56 @@
+package solver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestAccumulateCost(t *testing.T) {
+	net := petri.Build().
+		Place("A", 10).Place("B", 0).
+		Transition("move").
+		Arc("A", "move", 1).Arc("move", "B", 1).
+		FiringCost("move", 2).
+		HoldingCost("A", 0.5).
+		Done()
+	rates := map[string]float64{"move": 0.1}
+
+	prob := NewProblem(net, net.SetState(nil), [2]float64{0, 50}, rates)
+	sol := Solve(prob, Tsit5(), DefaultOptions())
+	report := AccumulateCost(prob, sol)
+
+	// Firing cost: 2 per token moved; the throughput is A's depletion.
+	moved := 10 - sol.GetFinalState()["A"]
+	if moved < 5 {
+		t.Fatalf("Expected most of A to convert, got %f moved", moved)
+	}
+	if math.Abs(report.Firing["move"]-2*moved)/(2*moved) > 0.05 {
+		t.Errorf("Firing cost = %f, want ~%f", report.Firing["move"], 2*moved)
+	}
+
+	// Holding cost: 0.5 per token-second integrated over A's decay
+	// A(t) = 10*exp(-0.1t), so the integral over [0,50] is ~99.3.
+	wantHolding := 0.5 * 10 / 0.1 * (1 - math.Exp(-0.1*50))
+	if math.Abs(report.Holding["A"]-wantHolding)/wantHolding > 0.05 {
+		t.Errorf("Holding cost = %f, want ~%f", report.Holding["A"], wantHolding)
+	}
+
+	// B has no holding cost annotation, so it does not appear.
+	if _, ok := report.Holding["B"]; ok {
+		t.Error("Unannotated place should not appear in the report")
+	}
+	if math.Abs(report.Total-(report.Firing["move"]+report.Holding["A"])) > 1e-9 {
+		t.Errorf("Total %f does not match components", report.Total)
+	}
+}
+
+func TestAccumulateCostEmptySolution(t *testing.T) {
+	net := petri.Build().Place("A", 1).Transition("t").Arc("A", "t", 1).Done()
+	prob := NewProblem(net, net.SetState(nil), [2]float64{0, 1}, nil)
+	report := AccumulateCost(prob, nil)
+	if report.Total != 0 {
+		t.Errorf("Expected zero cost for nil solution, got %f", report.Total)
+	}
+}
@@ -0,0 +1,112 @@
+package solver
+
+import "sort"
+
+// Dense output: evaluating a Solution between its accepted steps.
+//
+// Adaptive solvers place steps where the dynamics demand them, which is
+// rarely where plots or loss functions want samples. At evaluates the
+// continuous extension of the Runge-Kutta solution — a cubic Hermite
+// interpolant through the accepted points and the derivatives the solver
+// already evaluated there — so resampling a coarse trajectory does not
+// degrade to piecewise-linear accuracy.
+
+// At evaluates the solution at an arbitrary time. Between steps it uses
+// cubic Hermite interpolation when solver derivatives are available, and
+// linear interpolation otherwise (e.g. for a Solution restored from
+// serialized form). Times outside the trajectory clamp to its endpoints.
+func (s *Solution) At(t float64) map[string]float64 {
+	if len(s.T) == 0 {
+		return nil
+	}
+	if t <= s.T[0] {
+		return CopyState(s.U[0])
+	}
+	last := len(s.T) - 1
+	if t >= s.T[last] {
+		return CopyState(s.U[last])
+	}
+
+	// Index of the first time point strictly after t; the segment is [i-1, i].
+	i := sort.SearchFloat64s(s.T, t)
+	if s.T[i] == t {
+		return CopyState(s.U[i])
+	}
+
+	h := s.T[i] - s.T[i-1]
+	theta := (t - s.T[i-1]) / h
+
+	dense := len(s.du) == len(s.T)
+	out := make(map[string]float64, len(s.StateLabels))
+	for j, label := range s.StateLabels {
+		u0 := s.U[i-1][label]
+		u1 := s.U[i][label]
+		if !dense {
+			out[label] = u0 + theta*(u1-u0)
+			continue
+		}
+		out[label] = hermite(u0, u1, s.du[i-1][j], s.du[i][j], theta, h)
+	}
+	return out
+}
+
+// GetVariableAt evaluates a single state variable at an arbitrary time.
+// Like GetVariable, index is either an int into StateLabels or a place label.
+func (s *Solution) GetVariableAt(index interface{}, t float64) float64 {
+	var label string
+	switch v := index.(type) {
+	case int:
+		if v < 0 || v >= len(s.StateLabels) {
+			return 0
+		}
+		label = s.StateLabels[v]
+	case string:
+		label = v
+	default:
+		return 0
+	}
+	return s.At(t)[label]
+}
+
+// Resample evaluates the solution on an arbitrary time grid and returns
+// the result as a new Solution. The grid must be sorted ascending.
+func (s *Solution) Resample(times []float64) *Solution {
+	out := &Solution{
+		T:           append([]float64(nil), times...),
+		U:           make([]map[string]float64, len(times)),
+		StateLabels: s.StateLabels,
+	}
+	for i, t := range times {
+		out.U[i] = s.At(t)
+	}
+	return out
+}
+
+// ResampleUniform evaluates the solution at n uniformly spaced times
+// spanning its trajectory. Handy for plotting coarse adaptive runs.
+func (s *Solution) ResampleUniform(n int) *Solution {
+	if len(s.T) == 0 || n < 2 {
+		return s.Resample(s.T[:min(len(s.T), 1)])
+	}
+	t0 := s.T[0]
+	tf := s.T[len(s.T)-1]
+	times := make([]float64, n)
+	dt := (tf - t0) / float64(n-1)
+	for i := range times {
+		times[i] = t0 + float64(i)*dt
+	}
+	return s.Resample(times)
+}
+
+// hermite evaluates the cubic Hermite interpolant on a segment of width h
+// with endpoint values u0, u1 and endpoint derivatives d0, d1 at
+// normalized position theta in [0, 1].
+func hermite(u0, u1, d0, d1, theta, h float64) float64 {
+	t2 := theta * theta
+	t3 := t2 * theta
+	h00 := 2*t3 - 3*t2 + 1
+	h10 := t3 - 2*t2 + theta
+	h01 := -2*t3 + 3*t2
+	h11 := t3 - t2
+	return h00*u0 + h10*h*d0 + h01*u1 + h11*h*d1
+}
@@ -3,6 +3,7 @@
 package solver
 
 import (
+	"context"
 	"math"
 
 	"github.com/pflow-xyz/go-pflow/petri"
@@ -14,9 +15,10 @@ type ODEFunc func(t float64, u map[string]float64) map[string]float64
 
 // transitionEntry holds pre-indexed arc data for vectorized ODE evaluation.
 type transitionEntry struct {
-	rate    float64
-	inputs  []arcEntry // place_index → transition (input arcs)
-	outputs []arcEntry // transition → place_index (output arcs)
+	rate     float64
+	schedule *RateSchedule // optional time-varying rate; overrides rate
+	inputs   []arcEntry    // place_index → transition (input arcs)
+	outputs  []arcEntry    // transition → place_index (output arcs)
 }
 
 type arcEntry struct {
@@ -34,7 +36,8 @@ type Problem struct {
 	Tspan       [2]float64         // Time span [t0, tf]
 	Rates       map[string]float64 // Transition rates
 	F           ODEFunc            // Derivative function (HashMap-based, for backward compat)
-	stateLabels []string           // Ordered list of state variable labels
+	schedules   map[string]*RateSchedule
+	stateLabels []string // Ordered list of state variable labels
 	// Vectorized internals for fast Solve()
 	stateIndex map[string]int
 	vecU0      []float64
@@ -48,7 +51,7 @@ func NewProblem(net *petri.PetriNet, initialState map[string]float64, tspan [2]f
 		U0:    initialState,
 		Tspan: tspan,
 		Rates: rates,
-		F:     buildODEFunction(net, rates),
+		F:     buildODEFunction(net, rates, nil),
 	}
 	prob.stateLabels = make([]string, 0, len(initialState))
 	for k := range initialState {
@@ -64,13 +67,14 @@ func NewProblem(net *petri.PetriNet, initialState map[string]float64, tspan [2]f
 	for i, label := range prob.stateLabels {
 		prob.vecU0[i] = initialState[label]
 	}
-	prob.vecF = buildVecODEFunction(net, rates, prob.stateIndex, n)
+	prob.vecF = buildVecODEFunction(net, rates, nil, prob.stateIndex, n)
 	return prob
 }
 
 // buildODEFunction constructs the ODE derivative function for a Petri net
 // using mass-action kinetics. Retained for backward compatibility (equilibrium, implicit).
-func buildODEFunction(net *petri.PetriNet, rates map[string]float64) ODEFunc {
+// schedules, when non-nil, supplies time-varying rates for the transitions it covers.
+func buildODEFunction(net *petri.PetriNet, rates map[string]float64, schedules map[string]*RateSchedule) ODEFunc {
 	return func(t float64, u map[string]float64) map[string]float64 {
 		du := make(map[string]float64)
 
@@ -80,10 +84,38 @@ func buildODEFunction(net *petri.PetriNet, rates map[string]float64) ODEFunc {
 		}
 
 		// For each transition, compute flux and update derivatives
-		for transLabel := range net.Transitions {
+		for transLabel, trans := range net.Transitions {
 			rate := rates[transLabel]
+			if sched, ok := schedules[transLabel]; ok {
+				rate = sched.At(t)
+			}
 			flux := rate
 
+			// A failing (or erroring) guard contributes no flux.
+			if trans.Guard != "" {
+				if ok, err := net.GuardSatisfied(transLabel, u); err != nil || !ok {
+					continue
+				}
+			}
+
+			// A full blocking output place stalls the whole transition,
+			// mirroring the discrete semantics.
+			blocked := false
+			for _, arc := range net.Arcs {
+				if arc.Source != transLabel {
+					continue
+				}
+				if p, ok := net.Places[arc.Target]; ok && p.Overflow == petri.OverflowBlock {
+					if c := p.GetCapacity(); c > 0 && u[arc.Target] >= c {
+						blocked = true
+						break
+					}
+				}
+			}
+			if blocked {
+				continue
+			}
+
 			// Compute flux using simplified mass-action kinetics
 			for _, arc := range net.Arcs {
 				if arc.Target == transLabel {
@@ -102,14 +134,23 @@ func buildODEFunction(net *petri.PetriNet, rates map[string]float64) ODEFunc {
 			if flux > 0 {
 				for _, arc := range net.Arcs {
 					weight := arc.GetWeightSum()
+					if arc.Expression != "" {
+						if w, err := arc.EvalWeight(u); err == nil {
+							weight = w
+						}
+					}
 					if arc.Target == transLabel {
 						// Input arc - consume tokens
 						if _, ok := net.Places[arc.Source]; ok {
 							du[arc.Source] -= flux * weight
 						}
 					} else if arc.Source == transLabel {
-						// Output arc - produce tokens
-						if _, ok := net.Places[arc.Target]; ok {
+						// Output arc - produce tokens. A discard-policy
+						// place saturates: inflow past capacity is lost.
+						if p, ok := net.Places[arc.Target]; ok {
+							if c := p.GetCapacity(); c > 0 && p.Overflow == petri.OverflowDiscard && u[arc.Target] >= c {
+								continue
+							}
 							du[arc.Target] += flux * weight
 						}
 					}
@@ -123,7 +164,30 @@ func buildODEFunction(net *petri.PetriNet, rates map[string]float64) ODEFunc {
 // buildVecODEFunction constructs a vectorized ODE derivative function with pre-indexed arcs.
 // This replaces map lookups with array indexing and pre-groups arcs by transition,
 // reducing per-call cost from O(T*A) to O(A).
-func buildVecODEFunction(net *petri.PetriNet, rates map[string]float64, stateIndex map[string]int, nPlaces int) vecODEFunc {
+func buildVecODEFunction(net *petri.PetriNet, rates map[string]float64, schedules map[string]*RateSchedule, stateIndex map[string]int, nPlaces int) vecODEFunc {
+	// Guards, weight expressions, and capacity saturation depend on the
+	// current marking, so they cannot be pre-indexed; fall back to the
+	// map-based function through an adapter for nets that use them.
+	if net.HasDynamics() || net.EnforcesCapacities() {
+		labels := make([]string, nPlaces)
+		for label, idx := range stateIndex {
+			labels[idx] = label
+		}
+		f := buildODEFunction(net, rates, schedules)
+		return func(t float64, u []float64) []float64 {
+			um := make(map[string]float64, nPlaces)
+			for i, label := range labels {
+				um[label] = u[i]
+			}
+			dm := f(t, um)
+			du := make([]float64, nPlaces)
+			for i, label := range labels {
+				du[i] = dm[label]
+			}
+			return du
+		}
+	}
+
 	// Pre-group arcs by transition: O(A) construction
 	inputMap := make(map[string][]arcEntry)
 	outputMap := make(map[string][]arcEntry)
@@ -145,21 +209,24 @@ func buildVecODEFunction(net *petri.PetriNet, rates map[string]float64, stateInd
 	// Build compact transition table
 	transitions := make([]transitionEntry, 0, len(net.Transitions))
 	for label := range net.Transitions {
-		rate := rates[label]
 		entry := transitionEntry{
-			rate:    rate,
-			inputs:  inputMap[label],
-			outputs: outputMap[label],
+			rate:     rates[label],
+			schedule: schedules[label],
+			inputs:   inputMap[label],
+			outputs:  outputMap[label],
 		}
 		transitions = append(transitions, entry)
 	}
 
-	return func(_ float64, u []float64) []float64 {
+	return func(t float64, u []float64) []float64 {
 		du := make([]float64, nPlaces)
 
 		for i := range transitions {
 			tr := &transitions[i]
 			flux := tr.rate
+			if tr.schedule != nil {
+				flux = tr.schedule.At(t)
+			}
 
 			// Mass-action kinetics: flux = rate * product(input tokens)
 			for _, inp := range tr.inputs {
@@ -190,6 +257,12 @@ type Solution struct {
 	T           []float64            // Time points
 	U           []map[string]float64 // State at each time point
 	StateLabels []string             // Ordered list of state variable labels
+
+	// du holds the derivative at each accepted step in StateLabels order.
+	// Captured during integration at no extra cost (the first Runge-Kutta
+	// stage evaluates it anyway), it powers the cubic Hermite dense output
+	// in At. Nil for solutions restored from serialized form.
+	du [][]float64
 }
 
 // GetVariable extracts the time series for a specific state variable.
@@ -230,15 +303,30 @@ func (s *Solution) GetState(i int) map[string]float64 {
 	return s.U[i]
 }
 
+// Progress is a snapshot of an integration handed to the Options.Progress
+// callback. Percent is derived from simulation time over the requested
+// time span, so it reaches 100 only when integration covers the full span.
+type Progress struct {
+	Percent float64 // 0-100, fraction of the time span integrated
+	T       float64 // Current simulation time
+	Step    int     // Accepted steps so far
+}
+
+// ProgressFunc receives progress snapshots during integration. It is called
+// from the integration loop, so it should be cheap; expensive consumers
+// (progress bars, status streams) should hand the snapshot off asynchronously.
+type ProgressFunc func(Progress)
+
 // Options contains solver configuration parameters.
 type Options struct {
-	Dt       float64 // Initial time step
-	Dtmin    float64 // Minimum time step
-	Dtmax    float64 // Maximum time step
-	Abstol   float64 // Absolute error tolerance
-	Reltol   float64 // Relative error tolerance
-	Maxiters int     // Maximum number of iterations
-	Adaptive bool    // Use adaptive step size control
+	Dt       float64      // Initial time step
+	Dtmin    float64      // Minimum time step
+	Dtmax    float64      // Maximum time step
+	Abstol   float64      // Absolute error tolerance
+	Reltol   float64      // Relative error tolerance
+	Maxiters int          // Maximum number of iterations
+	Adaptive bool         // Use adaptive step size control
+	Progress ProgressFunc // Optional progress callback (nil = no reporting)
 }
 
 // DefaultOptions returns default solver options.
@@ -402,6 +490,14 @@ func vecToState(v []float64, labels []string) map[string]float64 {
 // Solve integrates the ODE problem using the given solver and options.
 // Internally uses vectorized (dense array) state representation for performance.
 func Solve(prob *Problem, solver *Solver, opts *Options) *Solution {
+	return SolveContext(context.Background(), prob, solver, opts)
+}
+
+// SolveContext is Solve with cooperative cancellation: when the context is
+// canceled or its deadline passes, integration stops and the trajectory
+// computed so far is returned. Check ctx.Err() to distinguish a partial
+// result from a completed run.
+func SolveContext(ctx context.Context, prob *Problem, solver *Solver, opts *Options) *Solution {
 	if solver == nil {
 		solver = Tsit5()
 	}
@@ -424,14 +520,19 @@ func Solve(prob *Problem, solver *Solver, opts *Options) *Solution {
 
 	tOut := []float64{t0}
 	uOut := [][]float64{append([]float64(nil), prob.vecU0...)}
+	var duOut [][]float64
 	tcur := t0
 	ucur := append([]float64(nil), prob.vecU0...)
 	dtcur := dt
 	nsteps := 0
+	lastPercent := -1
 
 	numStages := len(solver.C)
 
 	for tcur < tf && nsteps < maxiters {
+		if ctx.Err() != nil {
+			break
+		}
 		// Don't overshoot the final time
 		if tcur+dtcur > tf {
 			dtcur = tf - tcur
@@ -441,6 +542,13 @@ func Solve(prob *Problem, solver *Solver, opts *Options) *Solution {
 		k := make([][]float64, numStages)
 		k[0] = f(tcur, ucur)
 
+		// k[0] is the derivative at the most recently accepted point;
+		// keep it for dense output. The length guard skips re-appends
+		// after rejected steps.
+		if len(duOut) < len(tOut) {
+			duOut = append(duOut, append([]float64(nil), k[0]...))
+		}
+
 		for stage := 1; stage < numStages; stage++ {
 			tstage := tcur + solver.C[stage]*dtcur
 			ustage := append([]float64(nil), ucur...)
@@ -500,6 +608,16 @@ func Solve(prob *Problem, solver *Solver, opts *Options) *Solution {
 			uOut = append(uOut, append([]float64(nil), ucur...))
 			nsteps++
 
+			// Report progress when the integer percent advances, bounding
+			// the callback to ~100 invocations per solve.
+			if opts.Progress != nil && tf > t0 {
+				pct := (tcur - t0) / (tf - t0) * 100
+				if int(pct) > lastPercent {
+					lastPercent = int(pct)
+					opts.Progress(Progress{Percent: pct, T: tcur, Step: nsteps})
+				}
+			}
+
 			// Adapt step size for next iteration
 			if adaptive && err > 0 {
 				factor := 0.9 * math.Pow(1.0/err, 1.0/float64(solver.Order+1))
@@ -514,6 +632,12 @@ func Solve(prob *Problem, solver *Solver, opts *Options) *Solution {
 		}
 	}
 
+	// The final accepted point never starts another step, so its
+	// derivative is still missing.
+	if len(duOut) < len(tOut) {
+		duOut = append(duOut, f(tcur, ucur))
+	}
+
 	// Convert dense trajectory to state maps for backward compatibility
 	stateU := make([]map[string]float64, len(uOut))
 	for i, v := range uOut {
@@ -524,6 +648,7 @@ func Solve(prob *Problem, solver *Solver, opts *Options) *Solution {
 		T:           tOut,
 		U:           stateU,
 		StateLabels: prob.stateLabels,
+		du:          duOut,
 	}
 }
 
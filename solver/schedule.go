@@ -0,0 +1,112 @@
+package solver
+
+import (
+	"math"
+	"time"
+)
+
+// HoursPerWeek is the number of slots in an hour-of-week rate schedule.
+const HoursPerWeek = 7 * 24
+
+// RateSchedule is a piecewise-constant, hour-of-week rate function. Arrival
+// and service rates in real processes vary by hour and day — coffeeshop
+// morning rushes, weekday-only incident load — and a single constant rate
+// averages those regimes away. A schedule holds one rate per hour of the
+// week and is evaluated against simulation time, so the ODE right-hand side
+// and the stochastic clocks see the rate that applies at each moment.
+type RateSchedule struct {
+	// Hourly holds one rate per hour-of-week slot. Slot 0 is Sunday
+	// 00:00-01:00, matching time.Weekday numbering.
+	Hourly []float64
+
+	// Origin is the wall-clock time corresponding to simulation time zero.
+	// When zero, simulation time zero is taken to be slot 0 and the
+	// schedule wraps every HoursPerWeek hours.
+	Origin time.Time
+}
+
+// NewRateSchedule builds a schedule from HoursPerWeek hourly rates, slot 0
+// being Sunday 00:00. Panics if hourly has the wrong length; use the learned
+// constructors in the mining package to build schedules from event logs.
+func NewRateSchedule(hourly []float64) *RateSchedule {
+	if len(hourly) != HoursPerWeek {
+		panic("solver: rate schedule needs exactly 168 hourly rates")
+	}
+	return &RateSchedule{Hourly: hourly}
+}
+
+// ConstantRateSchedule builds a schedule holding the same rate in every
+// slot, useful as a baseline or for transitions without seasonal data.
+func ConstantRateSchedule(rate float64) *RateSchedule {
+	hourly := make([]float64, HoursPerWeek)
+	for i := range hourly {
+		hourly[i] = rate
+	}
+	return &RateSchedule{Hourly: hourly}
+}
+
+// WithOrigin anchors simulation time zero to a wall-clock time, so At maps
+// t through the origin's weekday and hour.
+func (s *RateSchedule) WithOrigin(origin time.Time) *RateSchedule {
+	s.Origin = origin
+	return s
+}
+
+// At returns the rate in effect at simulation time t (seconds).
+func (s *RateSchedule) At(t float64) float64 {
+	var slot int
+	if s.Origin.IsZero() {
+		slot = int(math.Floor(t/3600)) % HoursPerWeek
+		if slot < 0 {
+			slot += HoursPerWeek
+		}
+	} else {
+		slot = HourOfWeek(s.Origin.Add(time.Duration(t * float64(time.Second))))
+	}
+	return s.Hourly[slot]
+}
+
+// NextChange returns the first simulation time strictly after t at which
+// the schedule can change value: the next hour boundary, shifted by the
+// origin's sub-hour offset. Event-driven simulators use it to re-evaluate
+// clocks and avoid integrating across a rate discontinuity.
+func (s *RateSchedule) NextChange(t float64) float64 {
+	offset := 0.0
+	if !s.Origin.IsZero() {
+		offset = float64(s.Origin.Minute()*60+s.Origin.Second()) + float64(s.Origin.Nanosecond())*1e-9
+	}
+	next := (math.Floor((t+offset)/3600)+1)*3600 - offset
+	if next <= t {
+		next += 3600
+	}
+	return next
+}
+
+// Mean returns the average rate across all slots.
+func (s *RateSchedule) Mean() float64 {
+	if len(s.Hourly) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, r := range s.Hourly {
+		sum += r
+	}
+	return sum / float64(len(s.Hourly))
+}
+
+// HourOfWeek returns the schedule slot for a wall-clock time: weekday*24 +
+// hour, with Sunday 00:00 as slot 0.
+func HourOfWeek(t time.Time) int {
+	return int(t.Weekday())*24 + t.Hour()
+}
+
+// WithRateSchedules attaches hour-of-week schedules to the problem.
+// Scheduled transitions take their rate from the schedule at each evaluation
+// time instead of the constant in Rates; unscheduled transitions are
+// unaffected. Returns the problem for chaining.
+func (p *Problem) WithRateSchedules(schedules map[string]*RateSchedule) *Problem {
+	p.schedules = schedules
+	p.F = buildODEFunction(p.Net, p.Rates, schedules)
+	p.vecF = buildVecODEFunction(p.Net, p.Rates, schedules, p.stateIndex, len(p.stateLabels))
+	return p
+}
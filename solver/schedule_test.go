@@ -0,0 +1,105 @@
+package solver
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestRateScheduleAt(t *testing.T) {
+	hourly := make([]float64, HoursPerWeek)
+	for i := range hourly {
+		hourly[i] = float64(i)
+	}
+	s := NewRateSchedule(hourly)
+
+	// Zero origin: t=0 is slot 0, wrapping weekly.
+	if got := s.At(0); got != 0 {
+		t.Errorf("At(0) = %f, want 0", got)
+	}
+	if got := s.At(3600 * 5.5); got != 5 {
+		t.Errorf("At(5.5h) = %f, want 5", got)
+	}
+	if got := s.At(3600 * float64(HoursPerWeek+3)); got != 3 {
+		t.Errorf("Expected weekly wrap to slot 3, got %f", got)
+	}
+
+	// Anchored origin: Wednesday 2026-03-04 10:00 UTC is slot 3*24+10.
+	origin := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	s.WithOrigin(origin)
+	if got := s.At(0); got != float64(3*24+10) {
+		t.Errorf("At(0) with origin = %f, want %d", got, 3*24+10)
+	}
+	if got := s.At(3600 * 2); got != float64(3*24+12) {
+		t.Errorf("At(2h) with origin = %f, want %d", got, 3*24+12)
+	}
+}
+
+func TestRateScheduleValidation(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for wrong schedule length")
+		}
+	}()
+	NewRateSchedule([]float64{1, 2, 3})
+}
+
+func TestSolveWithRateSchedule(t *testing.T) {
+	net := petri.Build().
+		Place("A", 100).Place("B", 0).
+		Transition("move").
+		Arc("A", "move", 1).Arc("move", "B", 1).
+		Done()
+	rates := map[string]float64{"move": 1.0}
+
+	// Closed for the first two hours, then open.
+	hourly := make([]float64, HoursPerWeek)
+	for i := 2; i < HoursPerWeek; i++ {
+		hourly[i] = 0.01
+	}
+	sched := map[string]*RateSchedule{"move": NewRateSchedule(hourly)}
+
+	// Hour-scale spans need hour-scale steps; the default presets are
+	// tuned for spans of tens of time units.
+	opts := &Options{Dt: 1, Dtmin: 1e-3, Dtmax: 60, Abstol: 1e-6, Reltol: 1e-3,
+		Maxiters: 100000, Adaptive: true}
+
+	// Nothing flows while the schedule holds zero. The span ends just
+	// inside the closed window so no stage samples the opening slot.
+	closed := NewProblem(net, net.SetState(nil), [2]float64{0, 7000}, rates).
+		WithRateSchedules(sched)
+	final := Solve(closed, Tsit5(), opts).GetFinalState()
+	if final["B"] > 1e-6 {
+		t.Errorf("Expected no flow in closed hours, got B=%f", final["B"])
+	}
+
+	// Flow resumes once the open slots are reached.
+	open := NewProblem(net, net.SetState(nil), [2]float64{0, 4 * 3600}, rates).
+		WithRateSchedules(sched)
+	final = Solve(open, Tsit5(), opts).GetFinalState()
+	if final["B"] < 1 {
+		t.Errorf("Expected flow during open hours, got B=%f", final["B"])
+	}
+}
+
+func TestConstantScheduleMatchesConstantRates(t *testing.T) {
+	net := petri.Build().
+		Place("A", 50).Place("B", 0).
+		Transition("move").
+		Arc("A", "move", 1).Arc("move", "B", 1).
+		Done()
+	rates := map[string]float64{"move": 0.5}
+	tspan := [2]float64{0, 10}
+
+	plain := Solve(NewProblem(net, net.SetState(nil), tspan, rates), Tsit5(), DefaultOptions())
+	scheduled := Solve(NewProblem(net, net.SetState(nil), tspan, rates).
+		WithRateSchedules(map[string]*RateSchedule{"move": ConstantRateSchedule(0.5)}),
+		Tsit5(), DefaultOptions())
+
+	pf, sf := plain.GetFinalState(), scheduled.GetFinalState()
+	if math.Abs(pf["B"]-sf["B"]) > 1e-6 {
+		t.Errorf("Constant schedule diverged from constant rates: %f vs %f", sf["B"], pf["B"])
+	}
+}
@@ -0,0 +1,213 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// Serialization and resumable simulations.
+//
+// Long monitoring-style runs need to checkpoint: serialize the Solution
+// computed so far, and later continue integration from its final state —
+// possibly with different rates for the next segment. Both a compact
+// binary format and a JSON format are provided; they round-trip the full
+// trajectory, so a restored Solution behaves exactly like the original.
+
+// solutionMagic identifies the binary solution format; the trailing byte
+// is the format version.
+var solutionMagic = []byte{'P', 'F', 'S', 'O', 'L', 1}
+
+// MarshalBinary encodes the solution in a compact binary format: the
+// state labels once, then the trajectory as dense little-endian float64
+// rows. For long trajectories this is several times smaller than JSON.
+func (s *Solution) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(solutionMagic)
+
+	writeUint := func(v int) {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		buf.Write(b[:])
+	}
+	writeFloat := func(v float64) {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		buf.Write(b[:])
+	}
+
+	writeUint(len(s.StateLabels))
+	for _, label := range s.StateLabels {
+		writeUint(len(label))
+		buf.WriteString(label)
+	}
+
+	writeUint(len(s.T))
+	for _, t := range s.T {
+		writeFloat(t)
+	}
+	for _, state := range s.U {
+		for _, label := range s.StateLabels {
+			writeFloat(state[label])
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a solution written by MarshalBinary.
+func (s *Solution) UnmarshalBinary(data []byte) error {
+	if len(data) < len(solutionMagic) || !bytes.Equal(data[:len(solutionMagic)], solutionMagic) {
+		return fmt.Errorf("not a solution: bad magic or unsupported version")
+	}
+	data = data[len(solutionMagic):]
+
+	readUint := func() (int, error) {
+		if len(data) < 8 {
+			return 0, fmt.Errorf("truncated solution data")
+		}
+		v := binary.LittleEndian.Uint64(data[:8])
+		data = data[8:]
+		return int(v), nil
+	}
+	readFloat := func() (float64, error) {
+		v, err := readUint()
+		return math.Float64frombits(uint64(v)), err
+	}
+
+	numLabels, err := readUint()
+	if err != nil {
+		return err
+	}
+	labels := make([]string, numLabels)
+	for i := range labels {
+		n, err := readUint()
+		if err != nil {
+			return err
+		}
+		if len(data) < n {
+			return fmt.Errorf("truncated solution data")
+		}
+		labels[i] = string(data[:n])
+		data = data[n:]
+	}
+
+	numPoints, err := readUint()
+	if err != nil {
+		return err
+	}
+	tOut := make([]float64, numPoints)
+	for i := range tOut {
+		if tOut[i], err = readFloat(); err != nil {
+			return err
+		}
+	}
+	uOut := make([]map[string]float64, numPoints)
+	for i := range uOut {
+		state := make(map[string]float64, numLabels)
+		for _, label := range labels {
+			if state[label], err = readFloat(); err != nil {
+				return err
+			}
+		}
+		uOut[i] = state
+	}
+
+	s.StateLabels = labels
+	s.T = tOut
+	s.U = uOut
+	return nil
+}
+
+// jsonSolution is the wire form for JSON: labels appear once and states
+// are dense rows in label order, instead of one map per time point.
+type jsonSolution struct {
+	StateLabels []string    `json:"stateLabels"`
+	T           []float64   `json:"t"`
+	U           [][]float64 `json:"u"`
+}
+
+// MarshalJSON encodes the solution with the state labels written once and
+// the trajectory as dense rows, avoiding a key-per-place at every time point.
+func (s *Solution) MarshalJSON() ([]byte, error) {
+	out := jsonSolution{
+		StateLabels: s.StateLabels,
+		T:           s.T,
+		U:           make([][]float64, len(s.U)),
+	}
+	for i, state := range s.U {
+		row := make([]float64, len(s.StateLabels))
+		for j, label := range s.StateLabels {
+			row[j] = state[label]
+		}
+		out.U[i] = row
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a solution written by MarshalJSON.
+func (s *Solution) UnmarshalJSON(data []byte) error {
+	var in jsonSolution
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	if len(in.U) != len(in.T) {
+		return fmt.Errorf("solution has %d states for %d time points", len(in.U), len(in.T))
+	}
+
+	s.StateLabels = in.StateLabels
+	s.T = in.T
+	s.U = make([]map[string]float64, len(in.U))
+	for i, row := range in.U {
+		if len(row) != len(in.StateLabels) {
+			return fmt.Errorf("state row %d has %d values for %d labels", i, len(row), len(in.StateLabels))
+		}
+		state := make(map[string]float64, len(in.StateLabels))
+		for j, label := range in.StateLabels {
+			state[label] = row[j]
+		}
+		s.U[i] = state
+	}
+	return nil
+}
+
+// Resume builds a Problem that continues this solution: integration starts
+// from the final saved state at the final saved time and runs to tf. Rates
+// may differ from the original segment's, which is how monitoring-style
+// simulations model rate changes between checkpoints. Returns an error if
+// the solution is empty or tf does not extend it.
+func (s *Solution) Resume(net *petri.PetriNet, tf float64, rates map[string]float64) (*Problem, error) {
+	if len(s.T) == 0 {
+		return nil, fmt.Errorf("cannot resume an empty solution")
+	}
+	t0 := s.T[len(s.T)-1]
+	if tf <= t0 {
+		return nil, fmt.Errorf("resume time %g does not extend the solution (ends at %g)", tf, t0)
+	}
+	return NewProblem(net, CopyState(s.GetFinalState()), [2]float64{t0, tf}, rates), nil
+}
+
+// Append stitches a continuation onto this solution in place and returns
+// it. The continuation's first point duplicates this solution's last point
+// (that is how Resume starts), so it is dropped.
+func (s *Solution) Append(next *Solution) *Solution {
+	if next == nil || len(next.T) == 0 {
+		return s
+	}
+	start := 0
+	if len(s.T) > 0 && next.T[0] == s.T[len(s.T)-1] {
+		start = 1
+	}
+	s.T = append(s.T, next.T[start:]...)
+	s.U = append(s.U, next.U[start:]...)
+	if s.du != nil && next.du != nil {
+		s.du = append(s.du, next.du[start:]...)
+	} else {
+		s.du = nil
+	}
+	return s
+}
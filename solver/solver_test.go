@@ -1,6 +1,8 @@
 package solver
 
 import (
+	"context"
+	"encoding/json"
 	"math"
 	"testing"
 
@@ -349,7 +351,7 @@ func TestBuildODEFunction(t *testing.T) {
 	net.AddArc("convert", "B", 1.0, false)
 
 	rates := map[string]float64{"convert": 1.0}
-	f := buildODEFunction(net, rates)
+	f := buildODEFunction(net, rates, nil)
 
 	// At state A=10, B=0, flux should be 1.0*10=10
 	// dA/dt = -10, dB/dt = +10
@@ -868,3 +870,306 @@ func createBenchmarkNet() *petri.PetriNet {
 	net.AddArc("t", "B", 1.0, false)
 	return net
 }
+
+func TestSolveWithGuard(t *testing.T) {
+	// A -> t -> B, but t is gated off once A drops below 5.
+	net := petri.NewPetriNet()
+	net.AddPlace("A", 10.0, nil, 0, 0, nil)
+	net.AddPlace("B", 0.0, nil, 0, 0, nil)
+	net.AddTransition("t", "default", 0, 0, nil)
+	net.AddArc("A", "t", 1.0, false)
+	net.AddArc("t", "B", 1.0, false)
+	if err := net.SetGuard("t", "A > 5"); err != nil {
+		t.Fatalf("SetGuard failed: %v", err)
+	}
+
+	state := map[string]float64{"A": 10.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	prob := NewProblem(net, state, [2]float64{0, 20}, rates)
+
+	sol := Solve(prob, Tsit5(), DefaultOptions())
+	final := sol.GetFinalState()
+
+	// The guard binds to the rounded marking, so flow stops near A=5
+	// instead of draining to zero.
+	if final["A"] < 4.0 || final["A"] > 6.0 {
+		t.Errorf("Expected A to settle near 5 with guard, got %f", final["A"])
+	}
+	if final["B"] < 4.0 || final["B"] > 6.0 {
+		t.Errorf("Expected B to settle near 5 with guard, got %f", final["B"])
+	}
+}
+
+func TestSolveWithArcExpression(t *testing.T) {
+	// Production into B scales with the current level of A.
+	net := petri.NewPetriNet()
+	net.AddPlace("A", 10.0, nil, 0, 0, nil)
+	net.AddPlace("B", 0.0, nil, 0, 0, nil)
+	net.AddTransition("t", "default", 0, 0, nil)
+	net.AddArc("A", "t", 1.0, false)
+	net.AddArc("t", "B", 1.0, false)
+	if err := net.SetArcExpression("t", "B", "2"); err != nil {
+		t.Fatalf("SetArcExpression failed: %v", err)
+	}
+
+	state := map[string]float64{"A": 10.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	prob := NewProblem(net, state, [2]float64{0, 50}, rates)
+
+	sol := Solve(prob, Tsit5(), DefaultOptions())
+	final := sol.GetFinalState()
+
+	// Each unit consumed from A produces two units in B.
+	if final["A"] > 0.1 {
+		t.Errorf("Expected A to drain, got %f", final["A"])
+	}
+	if final["B"] < 19.0 || final["B"] > 21.0 {
+		t.Errorf("Expected B near 20 with doubled output arc, got %f", final["B"])
+	}
+}
+
+func TestSolveContextCanceled(t *testing.T) {
+	net := createBenchmarkNet()
+	state := map[string]float64{"A": 100.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	prob := NewProblem(net, state, [2]float64{0, 100}, rates)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sol := SolveContext(ctx, prob, Tsit5(), DefaultOptions())
+
+	// Only the initial state: integration stopped before the first step.
+	if len(sol.T) != 1 {
+		t.Errorf("Expected partial trajectory of 1 point, got %d", len(sol.T))
+	}
+	if sol.GetFinalState()["A"] != 100.0 {
+		t.Errorf("Expected untouched initial state, got %v", sol.GetFinalState())
+	}
+
+	// An open context behaves exactly like Solve.
+	full := SolveContext(context.Background(), prob, Tsit5(), DefaultOptions())
+	if len(full.T) <= 1 {
+		t.Error("Expected full trajectory with open context")
+	}
+}
+
+func TestSolveProgressCallback(t *testing.T) {
+	net := createBenchmarkNet()
+	state := map[string]float64{"A": 10.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	prob := NewProblem(net, state, [2]float64{0, 10}, rates)
+
+	var percents []float64
+	opts := DefaultOptions()
+	opts.Progress = func(p Progress) {
+		percents = append(percents, p.Percent)
+	}
+	Solve(prob, Tsit5(), opts)
+
+	if len(percents) == 0 {
+		t.Fatal("Expected progress callbacks during integration")
+	}
+	for i := 1; i < len(percents); i++ {
+		if percents[i] < percents[i-1] {
+			t.Errorf("Progress went backwards: %f -> %f", percents[i-1], percents[i])
+		}
+	}
+	if final := percents[len(percents)-1]; final < 99.9 {
+		t.Errorf("Expected final progress near 100, got %f", final)
+	}
+}
+
+func TestSolutionBinaryRoundTrip(t *testing.T) {
+	net := createBenchmarkNet()
+	state := map[string]float64{"A": 10.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	prob := NewProblem(net, state, [2]float64{0, 10}, rates)
+	sol := Solve(prob, Tsit5(), DefaultOptions())
+
+	data, err := sol.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	restored := &Solution{}
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if len(restored.T) != len(sol.T) {
+		t.Fatalf("Expected %d time points, got %d", len(sol.T), len(restored.T))
+	}
+	for i := range sol.T {
+		if restored.T[i] != sol.T[i] {
+			t.Fatalf("Time point %d differs: %f != %f", i, restored.T[i], sol.T[i])
+		}
+		for _, label := range sol.StateLabels {
+			if restored.U[i][label] != sol.U[i][label] {
+				t.Fatalf("State %d %q differs: %f != %f",
+					i, label, restored.U[i][label], sol.U[i][label])
+			}
+		}
+	}
+
+	if err := restored.UnmarshalBinary([]byte("garbage")); err == nil {
+		t.Error("Expected error for malformed data")
+	}
+}
+
+func TestSolutionJSONRoundTrip(t *testing.T) {
+	net := createBenchmarkNet()
+	state := map[string]float64{"A": 5.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	prob := NewProblem(net, state, [2]float64{0, 5}, rates)
+	sol := Solve(prob, Tsit5(), DefaultOptions())
+
+	data, err := json.Marshal(sol)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	restored := &Solution{}
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(restored.T) != len(sol.T) {
+		t.Fatalf("Expected %d time points, got %d", len(sol.T), len(restored.T))
+	}
+	final := restored.GetFinalState()
+	for _, label := range sol.StateLabels {
+		if final[label] != sol.GetFinalState()[label] {
+			t.Errorf("Final %q differs: %f != %f", label, final[label], sol.GetFinalState()[label])
+		}
+	}
+}
+
+func TestSolutionResume(t *testing.T) {
+	net := createBenchmarkNet()
+	state := map[string]float64{"A": 10.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+
+	// Full run for comparison.
+	full := Solve(NewProblem(net, state, [2]float64{0, 10}, rates), Tsit5(), DefaultOptions())
+
+	// Same run split across a checkpoint at t=5.
+	first := Solve(NewProblem(net, state, [2]float64{0, 5}, rates), Tsit5(), DefaultOptions())
+	prob, err := first.Resume(net, 10, rates)
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if prob.Tspan[0] != first.T[len(first.T)-1] {
+		t.Errorf("Expected resume to start at %f, got %f", first.T[len(first.T)-1], prob.Tspan[0])
+	}
+	second := Solve(prob, Tsit5(), DefaultOptions())
+	stitched := first.Append(second)
+
+	if got, want := stitched.T[len(stitched.T)-1], 10.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected stitched trajectory to end at %f, got %f", want, got)
+	}
+	for i := 1; i < len(stitched.T); i++ {
+		if stitched.T[i] <= stitched.T[i-1] {
+			t.Fatalf("Stitched time points not increasing at %d: %f <= %f",
+				i, stitched.T[i], stitched.T[i-1])
+		}
+	}
+	// The split run should land close to the uninterrupted one.
+	for _, label := range full.StateLabels {
+		if diff := math.Abs(stitched.GetFinalState()[label] - full.GetFinalState()[label]); diff > 0.01 {
+			t.Errorf("Resumed final %q off by %f from full run", label, diff)
+		}
+	}
+
+	// Resuming backwards is rejected.
+	if _, err := full.Resume(net, 5, rates); err == nil {
+		t.Error("Expected error resuming to a time before the solution ends")
+	}
+}
+
+func TestSolutionAt(t *testing.T) {
+	net := createBenchmarkNet()
+	state := map[string]float64{"A": 10.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	prob := NewProblem(net, state, [2]float64{0, 10}, rates)
+
+	// Coarse run: large steps make linear interpolation visibly wrong.
+	opts := FastOptions()
+	sol := Solve(prob, Tsit5(), opts)
+
+	// Exact decay A(t) = 10*e^-t for the single-input unit-rate net.
+	for _, tq := range []float64{0.3, 1.7, 4.2, 7.9} {
+		exact := 10.0 * math.Exp(-tq)
+		got := sol.GetVariableAt("A", tq)
+		if math.Abs(got-exact) > 0.05 {
+			t.Errorf("At t=%f: expected A≈%f, got %f", tq, exact, got)
+		}
+	}
+
+	// Stored points are reproduced exactly.
+	mid := len(sol.T) / 2
+	if got := sol.At(sol.T[mid])["A"]; got != sol.U[mid]["A"] {
+		t.Errorf("At stored point: expected %f, got %f", sol.U[mid]["A"], got)
+	}
+
+	// Out-of-range times clamp to the endpoints.
+	if got := sol.GetVariableAt("A", -5); got != sol.U[0]["A"] {
+		t.Errorf("Before t0: expected %f, got %f", sol.U[0]["A"], got)
+	}
+	if got := sol.GetVariableAt("A", 1e6); got != sol.GetFinalState()["A"] {
+		t.Errorf("After tf: expected %f, got %f", sol.GetFinalState()["A"], got)
+	}
+}
+
+func TestSolutionResample(t *testing.T) {
+	net := createBenchmarkNet()
+	state := map[string]float64{"A": 10.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	prob := NewProblem(net, state, [2]float64{0, 10}, rates)
+	sol := Solve(prob, Tsit5(), FastOptions())
+
+	grid := sol.ResampleUniform(101)
+	if len(grid.T) != 101 {
+		t.Fatalf("Expected 101 points, got %d", len(grid.T))
+	}
+	if grid.T[0] != sol.T[0] || grid.T[100] != sol.T[len(sol.T)-1] {
+		t.Errorf("Expected grid to span [%f, %f], got [%f, %f]",
+			sol.T[0], sol.T[len(sol.T)-1], grid.T[0], grid.T[100])
+	}
+	for i, tq := range grid.T {
+		if grid.U[i]["A"] != sol.At(tq)["A"] {
+			t.Fatalf("Resampled point %d disagrees with At", i)
+		}
+	}
+}
+
+func TestSolveCapacitySaturation(t *testing.T) {
+	// Blocking: a full output place stalls the transition.
+	net := petri.Build().
+		Place("A", 10).
+		PlaceWithCapacity("B", 0, 3).
+		Overflow("B", petri.OverflowBlock).
+		Transition("t").
+		Arc("A", "t", 1).
+		Arc("t", "B", 1).
+		Done()
+
+	state := map[string]float64{"A": 10.0, "B": 0.0}
+	rates := map[string]float64{"t": 1.0}
+	sol := Solve(NewProblem(net, state, [2]float64{0, 50}, rates), Tsit5(), DefaultOptions())
+	final := sol.GetFinalState()
+	if final["B"] > 3.1 {
+		t.Errorf("Expected B to stall at capacity 3, got %f", final["B"])
+	}
+	if final["A"] < 6.5 {
+		t.Errorf("Expected A to stop draining once B is full, got %f", final["A"])
+	}
+
+	// Discard: the transition keeps firing and the excess is lost.
+	net.SetOverflowPolicy("B", petri.OverflowDiscard)
+	sol = Solve(NewProblem(net, state, [2]float64{0, 50}, rates), Tsit5(), DefaultOptions())
+	final = sol.GetFinalState()
+	if final["B"] > 3.1 {
+		t.Errorf("Expected B to saturate at 3 under discard, got %f", final["B"])
+	}
+	if final["A"] > 0.5 {
+		t.Errorf("Expected A to drain fully under discard, got %f", final["A"])
+	}
+}
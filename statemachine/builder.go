@@ -82,10 +82,18 @@ func (rb *RegionBuilder) State(name string) *StateBuilder {
 }
 
 // Sub adds a substate to the current state (chainable from RegionBuilder after Initial).
+// If the current state is itself a substate, the new substate is added as its
+// sibling under the same composite, so chains like
+// Sub("hours").Initial().Sub("minutes") build a flat list of substates.
 func (rb *RegionBuilder) Sub(name string) *StateBuilder {
 	if rb.currentState == nil {
 		return nil
 	}
+	if rb.currentState.state.Parent != nil && len(rb.stateStack) > 0 {
+		parent := rb.stateStack[len(rb.stateStack)-1]
+		rb.stateStack = rb.stateStack[:len(rb.stateStack)-1]
+		return parent.Sub(name)
+	}
 	return rb.currentState.Sub(name)
 }
 
@@ -262,6 +270,13 @@ func (c *Chart) ToPetriNet() *petri.PetriNet {
 		c.createPlacesForRegion(net, regionName, region)
 	}
 
+	// Create places for extended state variables
+	for name, initial := range c.Variables {
+		if _, exists := net.Places[name]; !exists {
+			net.AddPlace(name, initial, nil, 700, 200, nil)
+		}
+	}
+
 	// Create counter places
 	for _, trans := range c.Transitions {
 		for _, action := range trans.Actions {
@@ -0,0 +1,257 @@
+package statemachine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// --- Guard expressions ---
+
+// GuardExpr compiles a small expression over the extended state into a Guard.
+// Supported forms:
+//
+//	"drinks_made > 10"
+//	"beans >= 18 && water >= 30"
+//	"mode == 1 || retries < 3"
+//
+// Each clause compares a place/variable name against a numeric literal using
+// one of: ==, !=, >, >=, <, <=. Clauses combine with && (all must hold) or
+// || (any must hold); mixing the two operators is not supported.
+// An unparseable expression yields a guard that never passes.
+func GuardExpr(expr string) Guard {
+	clauses, all, err := parseGuardExpr(expr)
+	if err != nil {
+		return func(state map[string]float64) bool { return false }
+	}
+	return func(state map[string]float64) bool {
+		if all {
+			for _, c := range clauses {
+				if !c.eval(state) {
+					return false
+				}
+			}
+			return true
+		}
+		for _, c := range clauses {
+			if c.eval(state) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// guardClause is a single comparison within a guard expression.
+type guardClause struct {
+	name  string
+	op    string
+	value float64
+}
+
+func (c guardClause) eval(state map[string]float64) bool {
+	v := state[c.name]
+	switch c.op {
+	case "==":
+		return v == c.value
+	case "!=":
+		return v != c.value
+	case ">":
+		return v > c.value
+	case ">=":
+		return v >= c.value
+	case "<":
+		return v < c.value
+	case "<=":
+		return v <= c.value
+	}
+	return false
+}
+
+// parseGuardExpr splits an expression into clauses; all=true means && semantics.
+func parseGuardExpr(expr string) ([]guardClause, bool, error) {
+	all := true
+	parts := []string{expr}
+	if strings.Contains(expr, "&&") && strings.Contains(expr, "||") {
+		return nil, false, fmt.Errorf("guard expression mixes && and ||: %q", expr)
+	}
+	if strings.Contains(expr, "&&") {
+		parts = strings.Split(expr, "&&")
+	} else if strings.Contains(expr, "||") {
+		parts = strings.Split(expr, "||")
+		all = false
+	}
+
+	var clauses []guardClause
+	for _, part := range parts {
+		clause, err := parseGuardClause(strings.TrimSpace(part))
+		if err != nil {
+			return nil, false, err
+		}
+		clauses = append(clauses, clause)
+	}
+	return clauses, all, nil
+}
+
+func parseGuardClause(s string) (guardClause, error) {
+	// Two-character operators must be checked before their one-character prefixes.
+	for _, op := range []string{">=", "<=", "==", "!=", ">", "<"} {
+		if idx := strings.Index(s, op); idx > 0 {
+			name := strings.TrimSpace(s[:idx])
+			valStr := strings.TrimSpace(s[idx+len(op):])
+			val, err := strconv.ParseFloat(valStr, 64)
+			if err != nil {
+				return guardClause{}, fmt.Errorf("guard clause %q: invalid number %q", s, valStr)
+			}
+			return guardClause{name: name, op: op, value: val}, nil
+		}
+	}
+	return guardClause{}, fmt.Errorf("guard clause %q: no comparison operator", s)
+}
+
+// IfExpr adds a guard expression to the transition (see GuardExpr).
+func (tb *TransitionBuilder) IfExpr(expr string) *TransitionBuilder {
+	return tb.If(GuardExpr(expr))
+}
+
+// --- Extended state variables ---
+
+// Variable declares an extended state variable with an initial value.
+// Variables become places in the compiled Petri net and can be read in
+// guards, modified by actions, and inspected via Machine.Counter.
+func (b *ChartBuilder) Variable(name string, initial float64) *ChartBuilder {
+	if b.chart.Variables == nil {
+		b.chart.Variables = make(map[string]float64)
+	}
+	b.chart.Variables[name] = initial
+	return b
+}
+
+// SetVariable updates an extended state variable on a running machine.
+func (m *Machine) SetVariable(name string, value float64) {
+	m.engine.SetState(map[string]float64{name: value})
+}
+
+// Variable returns the current value of an extended state variable.
+func (m *Machine) Variable(name string) float64 {
+	return m.engine.GetState()[name]
+}
+
+// --- Entry / exit actions ---
+
+// OnEntry registers an action executed whenever this state is entered.
+func (sb *StateBuilder) OnEntry(action Action) *StateBuilder {
+	sb.state.EntryActions = append(sb.state.EntryActions, action)
+	return sb
+}
+
+// OnExit registers an action executed whenever this state is exited.
+func (sb *StateBuilder) OnExit(action Action) *StateBuilder {
+	sb.state.ExitActions = append(sb.state.ExitActions, action)
+	return sb
+}
+
+// OnEntry registers an entry action on the most recent state (chainable
+// after Initial()).
+func (rb *RegionBuilder) OnEntry(action Action) *RegionBuilder {
+	if rb.currentState != nil {
+		rb.currentState.OnEntry(action)
+	}
+	return rb
+}
+
+// OnExit registers an exit action on the most recent state (chainable
+// after Initial()).
+func (rb *RegionBuilder) OnExit(action Action) *RegionBuilder {
+	if rb.currentState != nil {
+		rb.currentState.OnExit(action)
+	}
+	return rb
+}
+
+// stateForPath resolves a state path like "region:state" or
+// "region:state:substate" to its State, or nil.
+func (c *Chart) stateForPath(path StatePath) *State {
+	parts := path.Parse()
+	if len(parts) < 2 {
+		return nil
+	}
+	region, ok := c.Regions[parts[0]]
+	if !ok {
+		return nil
+	}
+	state, ok := region.States[parts[1]]
+	if !ok {
+		return nil
+	}
+	if len(parts) > 2 {
+		return state.Children[parts[2]]
+	}
+	return state
+}
+
+// --- Timed (after) transitions ---
+
+// After starts building a transition that fires automatically once the
+// source state has been active for the given duration. Timed transitions
+// are driven by Machine.Tick; they also respect guards.
+func (b *ChartBuilder) After(d time.Duration) *TransitionBuilder {
+	tb := b.When(fmt.Sprintf("after_%s", d))
+	tb.transition.After = d
+	return tb
+}
+
+// After chains a timed transition from another transition builder.
+func (tb *TransitionBuilder) After(d time.Duration) *TransitionBuilder {
+	return tb.parent.After(d)
+}
+
+// Tick advances the machine's internal clocks by elapsed time and fires any
+// timed (After) transitions whose source state has been active long enough.
+// Returns the number of transitions fired. Call this from the host's event
+// loop (or a time.Ticker) at whatever resolution the model needs.
+func (m *Machine) Tick(elapsed time.Duration) int {
+	if m.stateClocks == nil {
+		m.stateClocks = make(map[string]time.Duration)
+	}
+
+	state := m.engine.GetState()
+
+	// Advance clocks for active state places; reset inactive ones.
+	for _, mappings := range m.eventTransitions {
+		for _, mapping := range mappings {
+			if mapping.transition.After == 0 {
+				continue
+			}
+			place := m.chart.pathToPlaceName(StatePath(mapping.transition.Source))
+			if place == "" {
+				continue
+			}
+			if state[place] > 0.5 {
+				m.stateClocks[place] += elapsed
+			} else {
+				m.stateClocks[place] = 0
+			}
+		}
+	}
+
+	fired := 0
+	for event, mappings := range m.eventTransitions {
+		for _, mapping := range mappings {
+			trans := mapping.transition
+			if trans.After == 0 {
+				continue
+			}
+			place := m.chart.pathToPlaceName(StatePath(trans.Source))
+			if place == "" || m.stateClocks[place] < trans.After {
+				continue
+			}
+			if m.SendEvent(event) {
+				m.stateClocks[place] = 0
+				fired++
+			}
+		}
+	}
+	return fired
+}
@@ -0,0 +1,95 @@
+package statemachine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGuardExpr(t *testing.T) {
+	state := map[string]float64{"drinks_made": 11, "beans": 20}
+
+	if !GuardExpr("drinks_made > 10")(state) {
+		t.Error("drinks_made > 10 should pass")
+	}
+	if GuardExpr("drinks_made > 11")(state) {
+		t.Error("drinks_made > 11 should fail")
+	}
+	if !GuardExpr("drinks_made >= 11 && beans >= 18")(state) {
+		t.Error("conjunction should pass")
+	}
+	if !GuardExpr("drinks_made > 100 || beans == 20")(state) {
+		t.Error("disjunction should pass")
+	}
+	if GuardExpr("not an expression")(state) {
+		t.Error("invalid expression should never pass")
+	}
+}
+
+func TestGuardedTransitionWithVariable(t *testing.T) {
+	chart := NewChart("barista").
+		Variable("drinks_made", 0).
+		Region("state").
+		State("working").Initial().
+		State("break").
+		EndRegion().
+		When("order").In("state:working").GoTo("state:working").Do(Increment("drinks_made")).
+		When("rest").In("state:working").IfExpr("drinks_made >= 2").GoTo("state:break").
+		Build()
+
+	m := NewMachine(chart)
+
+	if m.SendEvent("rest") {
+		t.Error("Guard should block rest with drinks_made=0")
+	}
+	m.SendEvent("order")
+	m.SendEvent("order")
+	if !m.SendEvent("rest") {
+		t.Error("Guard should allow rest with drinks_made=2")
+	}
+	if m.State("state") != "break" {
+		t.Errorf("Expected break, got %s", m.State("state"))
+	}
+}
+
+func TestEntryExitActions(t *testing.T) {
+	chart := NewChart("door").
+		Region("state").
+		State("closed").Initial().OnExit(Increment("opens")).
+		State("open").OnEntry(Increment("entries")).
+		EndRegion().
+		Counter("opens").Counter("entries").
+		When("open").In("state:closed").GoTo("state:open").
+		Build()
+
+	m := NewMachine(chart)
+	m.SendEvent("open")
+
+	if m.Counter("opens") != 1 {
+		t.Errorf("Expected exit action to fire once, got %d", m.Counter("opens"))
+	}
+	if m.Counter("entries") != 1 {
+		t.Errorf("Expected entry action to fire once, got %d", m.Counter("entries"))
+	}
+}
+
+func TestAfterTransition(t *testing.T) {
+	chart := NewChart("light").
+		Region("state").
+		State("green").Initial().
+		State("yellow").
+		EndRegion().
+		After(3 * time.Second).In("state:green").GoTo("state:yellow").
+		Build()
+
+	m := NewMachine(chart)
+
+	if fired := m.Tick(2 * time.Second); fired != 0 {
+		t.Errorf("Timed transition fired too early: %d", fired)
+	}
+	if fired := m.Tick(2 * time.Second); fired != 1 {
+		t.Errorf("Expected timed transition to fire, got %d", fired)
+	}
+	if m.State("state") != "yellow" {
+		t.Errorf("Expected yellow, got %s", m.State("state"))
+	}
+}
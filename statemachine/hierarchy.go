@@ -0,0 +1,91 @@
+package statemachine
+
+import "fmt"
+
+// HistoryKind selects how a composite state remembers its active substate.
+type HistoryKind int
+
+const (
+	// HistoryNone means entering the composite activates its initial substate.
+	HistoryNone HistoryKind = iota
+	// HistoryShallow restores the directly nested substate that was active
+	// when the composite was last exited.
+	HistoryShallow
+	// HistoryDeep restores the full nested configuration. With one level of
+	// nesting this behaves like shallow history but is accepted for
+	// compatibility with statechart notation.
+	HistoryDeep
+)
+
+// History marks the current state as a shallow-history composite: re-entering
+// it resumes the substate that was active when it was last exited.
+func (sb *StateBuilder) History() *StateBuilder {
+	sb.state.HistoryMode = HistoryShallow
+	return sb
+}
+
+// DeepHistory marks the current state as a deep-history composite.
+func (sb *StateBuilder) DeepHistory() *StateBuilder {
+	sb.state.HistoryMode = HistoryDeep
+	return sb
+}
+
+// Internal marks the transition as internal: its actions execute without
+// exiting or re-entering the source state, so entry/exit actions do not run
+// and substate configuration is preserved.
+func (tb *TransitionBuilder) Internal() *TransitionBuilder {
+	tb.transition.InternalOnly = true
+	return tb
+}
+
+// initialChild returns the name of the initial substate, or "" if none.
+func (s *State) initialChild() string {
+	for name, child := range s.Children {
+		if child.Initial {
+			return name
+		}
+	}
+	// Fall back to any child so composite entry always lands somewhere.
+	for name := range s.Children {
+		return name
+	}
+	return ""
+}
+
+// enterComposite activates the appropriate substate when a transition targets
+// a composite state by its parent path (e.g. "mode:dateTime"). History
+// composites resume their recorded substate; others enter the initial one.
+func (m *Machine) enterComposite(newState map[string]float64, regionName string, state *State) {
+	if len(state.Children) == 0 {
+		return
+	}
+	child := ""
+	if state.HistoryMode != HistoryNone {
+		child = m.history[fmt.Sprintf("%s_%s", regionName, state.Name)]
+	}
+	if child == "" {
+		child = state.initialChild()
+	}
+	if child != "" {
+		newState[fmt.Sprintf("%s_%s_%s", regionName, state.Name, child)] = 1
+	}
+}
+
+// exitComposite deactivates all substates of a composite being exited and
+// records the active one for history re-entry.
+func (m *Machine) exitComposite(newState, currentState map[string]float64, regionName string, state *State) {
+	if len(state.Children) == 0 {
+		return
+	}
+	parentPlace := fmt.Sprintf("%s_%s", regionName, state.Name)
+	for childName := range state.Children {
+		childPlace := fmt.Sprintf("%s_%s_%s", regionName, state.Name, childName)
+		if currentState[childPlace] > 0.5 {
+			if m.history == nil {
+				m.history = make(map[string]string)
+			}
+			m.history[parentPlace] = childName
+		}
+		newState[childPlace] = 0
+	}
+}
@@ -0,0 +1,68 @@
+package statemachine
+
+import "testing"
+
+// buildWatchChart models a simplified F-91W style watch: a composite
+// "settings" state with substates, re-entered via history.
+func buildWatchChart() *Chart {
+	return NewChart("watch").
+		Region("mode").
+		State("time").Initial().
+		State("settings").History().
+		Sub("hours").Initial().
+		Sub("minutes").End().
+		EndRegion().
+		When("adjust").In("mode:time").GoTo("mode:settings").
+		When("next").In("mode:settings:hours").GoTo("mode:settings:minutes").
+		When("back").In("mode:settings").GoTo("mode:time").
+		Build()
+}
+
+func TestCompositeEntryActivatesInitialSubstate(t *testing.T) {
+	m := NewMachine(buildWatchChart())
+
+	m.SendEvent("adjust")
+	if m.State("mode") != "settings" {
+		t.Fatalf("Expected settings, got %s", m.State("mode"))
+	}
+	if m.Substate("mode", "settings") != "hours" {
+		t.Errorf("Expected initial substate hours, got %s", m.Substate("mode", "settings"))
+	}
+}
+
+func TestHistoryRestoresSubstate(t *testing.T) {
+	m := NewMachine(buildWatchChart())
+
+	m.SendEvent("adjust")
+	m.SendEvent("next") // now in settings:minutes
+	m.SendEvent("back") // exit composite, history records minutes
+	if m.State("mode") != "time" {
+		t.Fatalf("Expected time, got %s", m.State("mode"))
+	}
+
+	m.SendEvent("adjust") // re-enter via history
+	if m.Substate("mode", "settings") != "minutes" {
+		t.Errorf("Expected history to restore minutes, got %s", m.Substate("mode", "settings"))
+	}
+}
+
+func TestInternalTransition(t *testing.T) {
+	chart := NewChart("counter").
+		Region("state").
+		State("running").Initial().
+		State("stopped").
+		EndRegion().
+		Counter("ticks").
+		When("tick").In("state:running").GoTo("state:stopped").Internal().Do(Increment("ticks")).
+		Build()
+
+	m := NewMachine(chart)
+	m.SendEvent("tick")
+
+	if m.State("state") != "running" {
+		t.Errorf("Internal transition should not change state, got %s", m.State("state"))
+	}
+	if m.Counter("ticks") != 1 {
+		t.Errorf("Internal transition should run actions, got ticks=%d", m.Counter("ticks"))
+	}
+}
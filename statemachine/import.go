@@ -0,0 +1,298 @@
+package statemachine
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+// --- XState JSON import ---
+
+// xstateMachine mirrors the subset of the XState machine config we support.
+type xstateMachine struct {
+	ID      string                 `json:"id"`
+	Type    string                 `json:"type"` // "parallel" for orthogonal roots
+	Initial string                 `json:"initial"`
+	States  map[string]xstateState `json:"states"`
+}
+
+type xstateState struct {
+	Type    string                     `json:"type"`
+	Initial string                     `json:"initial"`
+	States  map[string]xstateState     `json:"states"`
+	On      map[string]json.RawMessage `json:"on"`
+}
+
+// xstateTarget is a transition target: either a bare string or an object
+// (possibly in a single-element array) with target/cond fields.
+type xstateTarget struct {
+	Target string `json:"target"`
+	Cond   string `json:"cond"`
+}
+
+// FromXStateJSON builds a Chart from an XState machine definition.
+// Supported features: nested states (one level), parallel machines (each
+// top-level state of a type:"parallel" machine becomes a region), event
+// transitions, and string guard conditions (compiled with GuardExpr, so
+// conds must be comparisons over extended state such as "count > 3").
+func FromXStateJSON(data []byte) (*Chart, error) {
+	var machine xstateMachine
+	if err := json.Unmarshal(data, &machine); err != nil {
+		return nil, fmt.Errorf("failed to parse XState JSON: %w", err)
+	}
+	if machine.ID == "" {
+		machine.ID = "machine"
+	}
+
+	chart := &Chart{
+		Name:    machine.ID,
+		Regions: make(map[string]*Region),
+	}
+
+	if machine.Type == "parallel" {
+		// Each top-level state is an orthogonal region.
+		for name, st := range machine.States {
+			region := buildXStateRegion(name, st.Initial, st.States)
+			chart.Regions[name] = region
+			if err := collectXStateTransitions(chart, name, "", st.States); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		regionName := machine.ID
+		region := buildXStateRegion(regionName, machine.Initial, machine.States)
+		chart.Regions[regionName] = region
+		if err := collectXStateTransitions(chart, regionName, "", machine.States); err != nil {
+			return nil, err
+		}
+	}
+
+	return chart, nil
+}
+
+// buildXStateRegion converts a flat map of XState states into a Region.
+func buildXStateRegion(name, initial string, states map[string]xstateState) *Region {
+	region := &Region{
+		Name:    name,
+		States:  make(map[string]*State),
+		Initial: initial,
+	}
+	for stateName, st := range states {
+		state := &State{
+			Name:     stateName,
+			Children: make(map[string]*State),
+			Initial:  stateName == initial,
+			IsLeaf:   len(st.States) == 0,
+		}
+		for subName, sub := range st.States {
+			state.Children[subName] = &State{
+				Name:     subName,
+				Parent:   state,
+				Children: make(map[string]*State),
+				Initial:  subName == st.Initial,
+				IsLeaf:   len(sub.States) == 0,
+			}
+		}
+		region.States[stateName] = state
+	}
+	return region
+}
+
+// collectXStateTransitions walks states (and one level of substates) and
+// appends their event transitions to the chart.
+func collectXStateTransitions(chart *Chart, region, parent string, states map[string]xstateState) error {
+	for stateName, st := range states {
+		source := region + ":" + stateName
+		if parent != "" {
+			source = region + ":" + parent + ":" + stateName
+		}
+		for event, raw := range st.On {
+			target, cond, err := parseXStateTarget(raw)
+			if err != nil {
+				return fmt.Errorf("transition %s on %q: %w", source, event, err)
+			}
+			trans := &Transition{
+				Event:  event,
+				Source: source,
+				Target: qualifyXStateTarget(region, parent, target),
+			}
+			if cond != "" {
+				trans.Guard = GuardExpr(cond)
+			}
+			chart.Transitions = append(chart.Transitions, trans)
+		}
+		if parent == "" && len(st.States) > 0 {
+			if err := collectXStateTransitions(chart, region, stateName, st.States); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseXStateTarget accepts "target", {target, cond}, or [{target, cond}].
+func parseXStateTarget(raw json.RawMessage) (target, cond string, err error) {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s, "", nil
+	}
+	var obj xstateTarget
+	if json.Unmarshal(raw, &obj) == nil && obj.Target != "" {
+		return obj.Target, obj.Cond, nil
+	}
+	var list []xstateTarget
+	if json.Unmarshal(raw, &list) == nil && len(list) > 0 {
+		return list[0].Target, list[0].Cond, nil
+	}
+	return "", "", fmt.Errorf("unsupported target %s", string(raw))
+}
+
+// qualifyXStateTarget turns an XState target name into a chart state path.
+// Sibling targets stay within the source's parent; "#id.state" and
+// "parent.child" dotted forms resolve relative to the region.
+func qualifyXStateTarget(region, parent, target string) string {
+	// Dotted path "state.sub" -> region:state:sub
+	path := target
+	for i := 0; i < len(target); i++ {
+		if target[i] == '.' {
+			path = target[:i] + ":" + target[i+1:]
+		}
+	}
+	if parent != "" && path == target {
+		// Bare sibling name inside a composite
+		return region + ":" + parent + ":" + target
+	}
+	return region + ":" + path
+}
+
+// --- SCXML import ---
+
+type scxmlRoot struct {
+	XMLName  xml.Name        `xml:"scxml"`
+	Initial  string          `xml:"initial,attr"`
+	Name     string          `xml:"name,attr"`
+	States   []scxmlState    `xml:"state"`
+	Parallel []scxmlParallel `xml:"parallel"`
+}
+
+type scxmlParallel struct {
+	ID     string       `xml:"id,attr"`
+	States []scxmlState `xml:"state"`
+}
+
+type scxmlState struct {
+	ID          string            `xml:"id,attr"`
+	Initial     string            `xml:"initial,attr"`
+	States      []scxmlState      `xml:"state"`
+	Transitions []scxmlTransition `xml:"transition"`
+}
+
+type scxmlTransition struct {
+	Event  string `xml:"event,attr"`
+	Target string `xml:"target,attr"`
+	Cond   string `xml:"cond,attr"`
+}
+
+// FromSCXML builds a Chart from an SCXML document. Top-level <state>
+// elements form one region; each <parallel> child becomes its own region.
+// Guard conditions are compiled with GuardExpr.
+func FromSCXML(data []byte) (*Chart, error) {
+	var root scxmlRoot
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse SCXML: %w", err)
+	}
+	name := root.Name
+	if name == "" {
+		name = "scxml"
+	}
+
+	chart := &Chart{
+		Name:    name,
+		Regions: make(map[string]*Region),
+	}
+
+	if len(root.States) > 0 {
+		regionName := "state"
+		chart.Regions[regionName] = buildSCXMLRegion(regionName, root.Initial, root.States)
+		collectSCXMLTransitions(chart, regionName, "", root.States)
+	}
+	for _, par := range root.Parallel {
+		// The parallel's first child state is initial unless one is named.
+		initial := ""
+		if len(par.States) > 0 {
+			initial = par.States[0].ID
+		}
+		chart.Regions[par.ID] = buildSCXMLRegion(par.ID, initial, par.States)
+		collectSCXMLTransitions(chart, par.ID, "", par.States)
+	}
+
+	return chart, nil
+}
+
+// buildSCXMLRegion converts SCXML state elements into a Region.
+func buildSCXMLRegion(name, initial string, states []scxmlState) *Region {
+	if initial == "" && len(states) > 0 {
+		initial = states[0].ID
+	}
+	region := &Region{
+		Name:    name,
+		States:  make(map[string]*State),
+		Initial: initial,
+	}
+	for _, st := range states {
+		state := &State{
+			Name:     st.ID,
+			Children: make(map[string]*State),
+			Initial:  st.ID == initial,
+			IsLeaf:   len(st.States) == 0,
+		}
+		subInitial := st.Initial
+		if subInitial == "" && len(st.States) > 0 {
+			subInitial = st.States[0].ID
+		}
+		for _, sub := range st.States {
+			state.Children[sub.ID] = &State{
+				Name:     sub.ID,
+				Parent:   state,
+				Children: make(map[string]*State),
+				Initial:  sub.ID == subInitial,
+				IsLeaf:   true,
+			}
+		}
+		region.States[st.ID] = state
+	}
+	return region
+}
+
+// collectSCXMLTransitions appends transitions for states and one level of
+// substates. Targets are resolved to siblings first, then top-level states.
+func collectSCXMLTransitions(chart *Chart, region, parent string, states []scxmlState) {
+	names := make(map[string]bool)
+	for _, st := range states {
+		names[st.ID] = true
+	}
+	for _, st := range states {
+		source := region + ":" + st.ID
+		if parent != "" {
+			source = region + ":" + parent + ":" + st.ID
+		}
+		for _, t := range st.Transitions {
+			target := region + ":" + t.Target
+			if parent != "" && names[t.Target] {
+				target = region + ":" + parent + ":" + t.Target
+			}
+			trans := &Transition{
+				Event:  t.Event,
+				Source: source,
+				Target: target,
+			}
+			if t.Cond != "" {
+				trans.Guard = GuardExpr(t.Cond)
+			}
+			chart.Transitions = append(chart.Transitions, trans)
+		}
+		if parent == "" && len(st.States) > 0 {
+			collectSCXMLTransitions(chart, region, st.ID, st.States)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package statemachine
+
+import "testing"
+
+func TestFromXStateJSON(t *testing.T) {
+	data := []byte(`{
+		"id": "light",
+		"initial": "green",
+		"states": {
+			"green":  {"on": {"TIMER": "yellow"}},
+			"yellow": {"on": {"TIMER": "red"}},
+			"red":    {"on": {"TIMER": {"target": "green"}}}
+		}
+	}`)
+
+	chart, err := FromXStateJSON(data)
+	if err != nil {
+		t.Fatalf("FromXStateJSON: %v", err)
+	}
+	if len(chart.Transitions) != 3 {
+		t.Errorf("Expected 3 transitions, got %d", len(chart.Transitions))
+	}
+
+	m := NewMachine(chart)
+	if m.State("light") != "green" {
+		t.Fatalf("Expected initial green, got %s", m.State("light"))
+	}
+	m.SendEvent("TIMER")
+	if m.State("light") != "yellow" {
+		t.Errorf("Expected yellow after TIMER, got %s", m.State("light"))
+	}
+}
+
+func TestFromXStateJSONParallel(t *testing.T) {
+	data := []byte(`{
+		"id": "watch",
+		"type": "parallel",
+		"states": {
+			"display": {
+				"initial": "time",
+				"states": {
+					"time":  {"on": {"MODE": "date"}},
+					"date":  {"on": {"MODE": "time"}}
+				}
+			},
+			"light": {
+				"initial": "off",
+				"states": {
+					"off": {"on": {"PRESS": "on"}},
+					"on":  {"on": {"RELEASE": "off"}}
+				}
+			}
+		}
+	}`)
+
+	chart, err := FromXStateJSON(data)
+	if err != nil {
+		t.Fatalf("FromXStateJSON: %v", err)
+	}
+	if len(chart.Regions) != 2 {
+		t.Fatalf("Expected 2 regions, got %d", len(chart.Regions))
+	}
+
+	m := NewMachine(chart)
+	m.SendEvent("PRESS")
+	if m.State("light") != "on" {
+		t.Errorf("Expected light on, got %s", m.State("light"))
+	}
+	if m.State("display") != "time" {
+		t.Errorf("Expected display unchanged, got %s", m.State("display"))
+	}
+}
+
+func TestFromSCXML(t *testing.T) {
+	data := []byte(`<?xml version="1.0"?>
+	<scxml name="traffic" initial="green" xmlns="http://www.w3.org/2005/07/scxml">
+		<state id="green">
+			<transition event="timer" target="yellow"/>
+		</state>
+		<state id="yellow">
+			<transition event="timer" target="red"/>
+		</state>
+		<state id="red">
+			<transition event="timer" target="green"/>
+		</state>
+	</scxml>`)
+
+	chart, err := FromSCXML(data)
+	if err != nil {
+		t.Fatalf("FromSCXML: %v", err)
+	}
+	if len(chart.Transitions) != 3 {
+		t.Errorf("Expected 3 transitions, got %d", len(chart.Transitions))
+	}
+
+	m := NewMachine(chart)
+	if m.State("state") != "green" {
+		t.Fatalf("Expected initial green, got %s", m.State("state"))
+	}
+	m.SendEvent("timer")
+	m.SendEvent("timer")
+	if m.State("state") != "red" {
+		t.Errorf("Expected red after two timers, got %s", m.State("state"))
+	}
+
+	// Importer output converts to a Petri net like hand-built charts
+	net := chart.ToPetriNet()
+	if len(net.Places) != 3 {
+		t.Errorf("Expected 3 places in converted net, got %d", len(net.Places))
+	}
+}
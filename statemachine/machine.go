@@ -3,6 +3,7 @@ package statemachine
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pflow-xyz/go-pflow/engine"
 	"github.com/pflow-xyz/go-pflow/petri"
@@ -17,6 +18,18 @@ type Machine struct {
 
 	// Event to transitions mapping
 	eventTransitions map[string][]*transitionMapping
+
+	// Time spent in each state place, for timed (After) transitions
+	stateClocks map[string]time.Duration
+
+	// Last active substate per composite place, for history re-entry
+	history map[string]string
+
+	// Run-to-completion event queue (see Dispatch/Raise)
+	queue      []string
+	deferred   []string
+	processing bool
+	conflict   ConflictPolicy
 }
 
 type transitionMapping struct {
@@ -69,15 +82,14 @@ func (m *Machine) SendEvent(event string) bool {
 
 	state := m.engine.GetState()
 
-	// Find first enabled transition for this event
-	for _, mapping := range mappings {
-		if m.isTransitionEnabled(mapping.transition, state) {
-			m.fireTransition(mapping, state)
-			return true
-		}
+	// Pick among enabled transitions per the conflict policy
+	mapping := m.selectTransition(mappings, state)
+	if mapping == nil {
+		return false
 	}
 
-	return false
+	m.fireTransition(mapping, state)
+	return true
 }
 
 // isTransitionEnabled checks if a transition can fire given current state.
@@ -110,8 +122,9 @@ func (m *Machine) fireTransition(mapping *transitionMapping, currentState map[st
 	sourcePlaceName := m.chart.pathToPlaceName(sourcePath)
 	targetPlaceName := m.chart.pathToPlaceName(targetPath)
 
-	// Check if this is a self-transition (same source and target)
-	isSelfTransition := sourcePlaceName == targetPlaceName
+	// Check if this is a self-transition (same source and target).
+	// Internal transitions never change state configuration.
+	isSelfTransition := sourcePlaceName == targetPlaceName || trans.InternalOnly
 
 	if !isSelfTransition {
 		// Deactivate source state
@@ -130,6 +143,13 @@ func (m *Machine) fireTransition(mapping *transitionMapping, currentState map[st
 				targetParent := fmt.Sprintf("%s_%s", targetParts[0], targetParts[1])
 				newState[sourceParent] = 0
 				newState[targetParent] = 1
+
+				// Exiting a composite: clear its substates and record history
+				if region, ok := m.chart.Regions[sourceParts[0]]; ok {
+					if srcState, ok := region.States[sourceParts[1]]; ok {
+						m.exitComposite(newState, currentState, sourceParts[0], srcState)
+					}
+				}
 			}
 		}
 
@@ -137,21 +157,58 @@ func (m *Machine) fireTransition(mapping *transitionMapping, currentState map[st
 		if targetPlaceName != "" {
 			newState[targetPlaceName] = 1
 		}
+
+		// Entering a composite by its parent path: activate history or
+		// initial substate
+		if len(targetParts) == 2 {
+			if region, ok := m.chart.Regions[targetParts[0]]; ok {
+				if tgtState, ok := region.States[targetParts[1]]; ok {
+					m.enterComposite(newState, targetParts[0], tgtState)
+				}
+			}
+		}
+	}
+
+	// Execute exit actions of the source state, then entry actions of the
+	// target state, around the transition's own actions (UML ordering).
+	if !isSelfTransition {
+		if src := m.chart.stateForPath(sourcePath); src != nil {
+			for _, action := range src.ExitActions {
+				m.applyAction(newState, currentState, action)
+			}
+		}
 	}
 
 	// Execute actions - accumulate with current state values
 	for _, action := range trans.Actions {
-		if inc, ok := action.(*IncrementAction); ok {
-			// For increments, add to current value
-			newState[inc.PlaceName] = currentState[inc.PlaceName] + inc.Amount
-		} else {
-			action.Apply(newState)
+		m.applyAction(newState, currentState, action)
+	}
+
+	if !isSelfTransition {
+		if tgt := m.chart.stateForPath(targetPath); tgt != nil {
+			for _, action := range tgt.EntryActions {
+				m.applyAction(newState, currentState, action)
+			}
 		}
 	}
 
 	m.engine.SetState(newState)
 }
 
+// applyAction applies an action to the pending state update. Increments
+// accumulate against the current value (or the pending one if already set).
+func (m *Machine) applyAction(newState, currentState map[string]float64, action Action) {
+	if inc, ok := action.(*IncrementAction); ok {
+		base := currentState[inc.PlaceName]
+		if v, seen := newState[inc.PlaceName]; seen {
+			base = v
+		}
+		newState[inc.PlaceName] = base + inc.Amount
+		return
+	}
+	action.Apply(newState)
+}
+
 // State returns the current active state for a region.
 func (m *Machine) State(regionName string) string {
 	state := m.engine.GetState()
@@ -0,0 +1,202 @@
+package statemachine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Snapshot captures everything a machine needs to resume after a restart:
+// the active state configuration (including counters and extended state
+// variables), the clocks driving timed transitions, and the recorded
+// history of composite states. Snapshots are plain data and marshal
+// cleanly with encoding/json.
+type Snapshot struct {
+	Chart   string                   `json:"chart"`
+	State   map[string]float64       `json:"state"`
+	Clocks  map[string]time.Duration `json:"clocks,omitempty"`
+	History map[string]string        `json:"history,omitempty"`
+}
+
+// Snapshot captures the machine's current configuration. The returned
+// snapshot owns its maps; later machine activity does not mutate it.
+func (m *Machine) Snapshot() *Snapshot {
+	snap := &Snapshot{
+		Chart: m.chart.Name,
+		State: m.engine.GetState(),
+	}
+	if len(m.stateClocks) > 0 {
+		snap.Clocks = make(map[string]time.Duration, len(m.stateClocks))
+		for place, d := range m.stateClocks {
+			snap.Clocks[place] = d
+		}
+	}
+	if len(m.history) > 0 {
+		snap.History = make(map[string]string, len(m.history))
+		for place, sub := range m.history {
+			snap.History[place] = sub
+		}
+	}
+	return snap
+}
+
+// Restore overwrites the machine's configuration with a snapshot taken from
+// a machine built on the same chart. Places absent from the snapshot reset
+// to zero, so a snapshot fully determines the resulting configuration.
+func (m *Machine) Restore(snap *Snapshot) error {
+	if snap == nil {
+		return fmt.Errorf("nil snapshot")
+	}
+	if snap.Chart != m.chart.Name {
+		return fmt.Errorf("snapshot is for chart %q, machine runs %q", snap.Chart, m.chart.Name)
+	}
+
+	current := m.engine.GetState()
+	for place := range snap.State {
+		if _, ok := current[place]; !ok {
+			return fmt.Errorf("snapshot place %q not in chart %q", place, m.chart.Name)
+		}
+	}
+
+	newState := make(map[string]float64, len(current))
+	for place := range current {
+		newState[place] = snap.State[place]
+	}
+	m.engine.SetState(newState)
+
+	m.stateClocks = nil
+	if len(snap.Clocks) > 0 {
+		m.stateClocks = make(map[string]time.Duration, len(snap.Clocks))
+		for place, d := range snap.Clocks {
+			m.stateClocks[place] = d
+		}
+	}
+	m.history = nil
+	if len(snap.History) > 0 {
+		m.history = make(map[string]string, len(snap.History))
+		for place, sub := range snap.History {
+			m.history[place] = sub
+		}
+	}
+	return nil
+}
+
+// Store persists machine snapshots keyed by entity ID. Load returns
+// (nil, nil) when no snapshot exists for the ID.
+type Store interface {
+	Save(id string, snap *Snapshot) error
+	Load(id string) (*Snapshot, error)
+	Delete(id string) error
+}
+
+// MemoryStore is an in-memory Store, useful for tests and as a reference
+// implementation. It is safe for concurrent use.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	snaps map[string]*Snapshot
+}
+
+// NewMemoryStore creates an empty in-memory snapshot store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{snaps: make(map[string]*Snapshot)}
+}
+
+// Save stores a snapshot under the given ID, replacing any previous one.
+func (s *MemoryStore) Save(id string, snap *Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snaps[id] = snap
+	return nil
+}
+
+// Load returns the snapshot for an ID, or (nil, nil) if none exists.
+func (s *MemoryStore) Load(id string) (*Snapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snaps[id], nil
+}
+
+// Delete removes the snapshot for an ID. Deleting a missing ID is a no-op.
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.snaps, id)
+	return nil
+}
+
+// Manager runs one machine per entity ID over a shared chart, rehydrating
+// from a Store so long-lived entity lifecycles (orders, devices) survive
+// process restarts. Machines stay cached in memory once loaded; call Save
+// to persist and Release to evict.
+type Manager struct {
+	chart    *Chart
+	store    Store
+	mu       sync.Mutex
+	machines map[string]*Machine
+}
+
+// NewManager creates a manager for the chart backed by the given store.
+func NewManager(chart *Chart, store Store) *Manager {
+	return &Manager{
+		chart:    chart,
+		store:    store,
+		machines: make(map[string]*Machine),
+	}
+}
+
+// Machine returns the machine for an entity, creating it on first use.
+// If the store holds a snapshot for the ID, the new machine is restored
+// from it; otherwise it starts in the chart's initial configuration.
+func (mgr *Manager) Machine(id string) (*Machine, error) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if m, ok := mgr.machines[id]; ok {
+		return m, nil
+	}
+
+	m := NewMachine(mgr.chart)
+	snap, err := mgr.store.Load(id)
+	if err != nil {
+		return nil, fmt.Errorf("loading snapshot for %s: %w", id, err)
+	}
+	if snap != nil {
+		if err := m.Restore(snap); err != nil {
+			return nil, fmt.Errorf("restoring %s: %w", id, err)
+		}
+	}
+	mgr.machines[id] = m
+	return m, nil
+}
+
+// Save snapshots an entity's machine into the store. Saving an entity the
+// manager has never seen is an error.
+func (mgr *Manager) Save(id string) error {
+	mgr.mu.Lock()
+	m, ok := mgr.machines[id]
+	mgr.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no machine for entity %s", id)
+	}
+	return mgr.store.Save(id, m.Snapshot())
+}
+
+// Release saves an entity's machine and evicts it from the in-memory
+// cache; the next Machine call rehydrates it from the store.
+func (mgr *Manager) Release(id string) error {
+	if err := mgr.Save(id); err != nil {
+		return err
+	}
+	mgr.mu.Lock()
+	delete(mgr.machines, id)
+	mgr.mu.Unlock()
+	return nil
+}
+
+// Delete removes an entity from both the cache and the store.
+func (mgr *Manager) Delete(id string) error {
+	mgr.mu.Lock()
+	delete(mgr.machines, id)
+	mgr.mu.Unlock()
+	return mgr.store.Delete(id)
+}
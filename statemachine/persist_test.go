@@ -0,0 +1,155 @@
+package statemachine
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func persistChart() *Chart {
+	return NewChart("order").
+		Region("status").
+		State("placed").Initial().
+		State("shipped").
+		State("delivered").
+		EndRegion().
+		Counter("events").
+		When("ship").In("status:placed").GoTo("status:shipped").Do(Increment("events")).
+		When("deliver").In("status:shipped").GoTo("status:delivered").Do(Increment("events")).
+		Build()
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	m := NewMachine(persistChart())
+	m.SendEvent("ship")
+
+	snap := m.Snapshot()
+	if snap.Chart != "order" {
+		t.Errorf("Expected chart name order, got %q", snap.Chart)
+	}
+
+	// Snapshots survive a JSON round trip.
+	data, err := json.Marshal(snap)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded Snapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	// A fresh machine picks up where the snapshot left off.
+	restored := NewMachine(persistChart())
+	if err := restored.Restore(&decoded); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if state := restored.State("status"); state != "shipped" {
+		t.Errorf("Expected shipped after restore, got %q", state)
+	}
+	if count := restored.Counter("events"); count != 1 {
+		t.Errorf("Expected events counter 1, got %d", count)
+	}
+	if !restored.SendEvent("deliver") {
+		t.Error("Restored machine should accept deliver")
+	}
+	if count := restored.Counter("events"); count != 2 {
+		t.Errorf("Expected events counter 2, got %d", count)
+	}
+}
+
+func TestRestoreRejectsMismatch(t *testing.T) {
+	m := NewMachine(persistChart())
+
+	if err := m.Restore(nil); err == nil {
+		t.Error("Expected error for nil snapshot")
+	}
+	if err := m.Restore(&Snapshot{Chart: "other"}); err == nil {
+		t.Error("Expected error for wrong chart name")
+	}
+	bad := m.Snapshot()
+	bad.State["no_such_place"] = 1
+	if err := m.Restore(bad); err == nil {
+		t.Error("Expected error for unknown place")
+	}
+}
+
+func TestSnapshotPreservesClocks(t *testing.T) {
+	build := func() *Chart {
+		return NewChart("timer").
+			Region("s").
+			State("waiting").Initial().
+			State("done").
+			EndRegion().
+			After(10 * time.Second).In("s:waiting").GoTo("s:done").
+			Build()
+	}
+
+	m := NewMachine(build())
+	m.Tick(6 * time.Second)
+
+	restored := NewMachine(build())
+	if err := restored.Restore(m.Snapshot()); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	// Only the remaining 4 seconds should be needed after restore.
+	if fired := restored.Tick(3 * time.Second); fired != 0 {
+		t.Errorf("Timer fired too early: %d transitions", fired)
+	}
+	if fired := restored.Tick(1 * time.Second); fired != 1 {
+		t.Errorf("Expected timer to fire after restore, got %d", fired)
+	}
+	if state := restored.State("s"); state != "done" {
+		t.Errorf("Expected done, got %q", state)
+	}
+}
+
+func TestManagerRehydration(t *testing.T) {
+	store := NewMemoryStore()
+	mgr := NewManager(persistChart(), store)
+
+	m1, err := mgr.Machine("order-1")
+	if err != nil {
+		t.Fatalf("Machine failed: %v", err)
+	}
+	m1.SendEvent("ship")
+
+	m2, err := mgr.Machine("order-2")
+	if err != nil {
+		t.Fatalf("Machine failed: %v", err)
+	}
+	if state := m2.State("status"); state != "placed" {
+		t.Errorf("Entities must not share state: got %q", state)
+	}
+
+	// Release persists and evicts; the next lookup rehydrates from the store.
+	if err := mgr.Release("order-1"); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	reloaded, err := mgr.Machine("order-1")
+	if err != nil {
+		t.Fatalf("Machine failed: %v", err)
+	}
+	if reloaded == m1 {
+		t.Error("Expected a fresh machine after release")
+	}
+	if state := reloaded.State("status"); state != "shipped" {
+		t.Errorf("Expected shipped after rehydration, got %q", state)
+	}
+
+	if err := mgr.Save("unknown"); err == nil {
+		t.Error("Expected error saving an unseen entity")
+	}
+
+	// Delete forgets the entity entirely.
+	if err := mgr.Delete("order-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	fresh, err := mgr.Machine("order-1")
+	if err != nil {
+		t.Fatalf("Machine failed: %v", err)
+	}
+	if state := fresh.State("status"); state != "placed" {
+		t.Errorf("Expected initial state after delete, got %q", state)
+	}
+}
@@ -0,0 +1,141 @@
+package statemachine
+
+// ConflictPolicy selects which transition fires when several transitions
+// for the same event are enabled at once.
+type ConflictPolicy int
+
+const (
+	// ConflictFirstMatch fires the first enabled transition in chart
+	// declaration order. This is the default and matches SendEvent's
+	// historical behavior.
+	ConflictFirstMatch ConflictPolicy = iota
+	// ConflictPriority fires the enabled transition with the highest
+	// Priority value; declaration order breaks ties.
+	ConflictPriority
+)
+
+// WithConflictPolicy sets how the machine resolves multiple enabled
+// transitions for one event. Returns the machine for chaining.
+func (m *Machine) WithConflictPolicy(policy ConflictPolicy) *Machine {
+	m.conflict = policy
+	return m
+}
+
+// selectTransition picks the enabled transition to fire per the conflict
+// policy, or nil if none is enabled.
+func (m *Machine) selectTransition(mappings []*transitionMapping, state map[string]float64) *transitionMapping {
+	var best *transitionMapping
+	for _, mapping := range mappings {
+		if !m.isTransitionEnabled(mapping.transition, state) {
+			continue
+		}
+		if m.conflict == ConflictFirstMatch {
+			return mapping
+		}
+		if best == nil || mapping.transition.Priority > best.transition.Priority {
+			best = mapping
+		}
+	}
+	return best
+}
+
+// Priority sets the transition's conflict resolution rank. Higher values
+// win when the machine uses ConflictPriority.
+func (tb *TransitionBuilder) Priority(priority int) *TransitionBuilder {
+	tb.transition.Priority = priority
+	return tb
+}
+
+// Defer marks events this state holds back: while the state is active,
+// Dispatch stashes matching events instead of dropping them and re-offers
+// them after the next state change.
+func (sb *StateBuilder) Defer(events ...string) *StateBuilder {
+	sb.state.DeferredEvents = append(sb.state.DeferredEvents, events...)
+	return sb
+}
+
+// Defer marks deferred events on the most recent state (chainable after
+// Initial()).
+func (rb *RegionBuilder) Defer(events ...string) *RegionBuilder {
+	if rb.currentState != nil {
+		rb.currentState.Defer(events...)
+	}
+	return rb
+}
+
+// Dispatch enqueues an event and processes the queue to completion: each
+// step pops the oldest event, fires at most one transition for it, and
+// re-offers deferred events after every state change. Events raised by
+// actions during processing (see Raise) join the same run. An event that
+// enables no transition is stashed if an active state defers it and
+// dropped otherwise. Returns the number of transitions fired.
+//
+// SendEvent remains the low-level synchronous path: one event, one
+// transition, no queueing or deferral.
+func (m *Machine) Dispatch(event string) int {
+	m.queue = append(m.queue, event)
+	if m.processing {
+		return 0
+	}
+	m.processing = true
+	defer func() { m.processing = false }()
+
+	fired := 0
+	for len(m.queue) > 0 {
+		next := m.queue[0]
+		m.queue = m.queue[1:]
+
+		if m.SendEvent(next) {
+			fired++
+			// The configuration changed: deferred events get another chance,
+			// ahead of anything still queued.
+			if len(m.deferred) > 0 {
+				m.queue = append(m.deferred, m.queue...)
+				m.deferred = nil
+			}
+		} else if m.isDeferred(next) {
+			m.deferred = append(m.deferred, next)
+		}
+	}
+	return fired
+}
+
+// Raise enqueues an internal event. Intended for use inside transition
+// actions: the running Dispatch processes raised events before returning,
+// giving run-to-completion semantics. Outside a Dispatch the event waits
+// for the next one.
+func (m *Machine) Raise(event string) {
+	m.queue = append(m.queue, event)
+}
+
+// isDeferred reports whether any active state defers the event.
+func (m *Machine) isDeferred(event string) bool {
+	for regionName, region := range m.chart.Regions {
+		stateName := m.State(regionName)
+		if stateName == "" {
+			continue
+		}
+		state, ok := region.States[stateName]
+		if !ok {
+			continue
+		}
+		if deferredBy(state, event) {
+			return true
+		}
+		if sub := m.Substate(regionName, stateName); sub != "" {
+			if child := state.Children[sub]; child != nil && deferredBy(child, event) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func deferredBy(state *State, event string) bool {
+	for _, deferred := range state.DeferredEvents {
+		if deferred == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package statemachine
+
+import (
+	"testing"
+)
+
+func TestDispatchRunToCompletion(t *testing.T) {
+	var m *Machine
+	chart := NewChart("cascade").
+		Region("s").
+		State("a").Initial().
+		State("b").
+		State("c").
+		EndRegion().
+		When("go").In("s:a").GoTo("s:b").
+		Do(Callback("chain", func(state map[string]float64) {
+			m.Raise("next")
+		})).
+		When("next").In("s:b").GoTo("s:c").
+		Build()
+
+	m = NewMachine(chart)
+	if fired := m.Dispatch("go"); fired != 2 {
+		t.Errorf("Expected 2 transitions in one dispatch, got %d", fired)
+	}
+	if state := m.State("s"); state != "c" {
+		t.Errorf("Expected c after run-to-completion, got %q", state)
+	}
+}
+
+func TestDispatchDefersEvents(t *testing.T) {
+	chart := NewChart("server").
+		Region("s").
+		State("busy").Initial().Defer("request").
+		State("idle").
+		State("handling").
+		EndRegion().
+		When("finish").In("s:busy").GoTo("s:idle").
+		When("request").In("s:idle").GoTo("s:handling").
+		Build()
+
+	m := NewMachine(chart)
+
+	// Busy defers requests instead of dropping them.
+	if fired := m.Dispatch("request"); fired != 0 {
+		t.Errorf("Expected deferred request to fire nothing, got %d", fired)
+	}
+	if state := m.State("s"); state != "busy" {
+		t.Errorf("Expected busy, got %q", state)
+	}
+
+	// Leaving busy re-offers the deferred request.
+	if fired := m.Dispatch("finish"); fired != 2 {
+		t.Errorf("Expected finish plus recalled request, got %d", fired)
+	}
+	if state := m.State("s"); state != "handling" {
+		t.Errorf("Expected handling, got %q", state)
+	}
+}
+
+func TestDispatchDropsUndeferredEvents(t *testing.T) {
+	chart := NewChart("drop").
+		Region("s").
+		State("a").Initial().
+		State("b").
+		EndRegion().
+		When("go").In("s:a").GoTo("s:b").
+		Build()
+
+	m := NewMachine(chart)
+	if fired := m.Dispatch("bogus"); fired != 0 {
+		t.Errorf("Expected unknown event to fire nothing, got %d", fired)
+	}
+	// The dropped event must not resurface after a state change.
+	if fired := m.Dispatch("go"); fired != 1 {
+		t.Errorf("Expected 1 transition, got %d", fired)
+	}
+}
+
+func TestConflictPolicies(t *testing.T) {
+	build := func() *Chart {
+		return NewChart("conflict").
+			Region("s").
+			State("a").Initial().
+			State("low").
+			State("high").
+			EndRegion().
+			When("go").In("s:a").GoTo("s:low").Priority(1).
+			When("go").In("s:a").GoTo("s:high").Priority(5).
+			Build()
+	}
+
+	// Default: first declared transition wins.
+	m := NewMachine(build())
+	m.SendEvent("go")
+	if state := m.State("s"); state != "low" {
+		t.Errorf("Expected first match to win by default, got %q", state)
+	}
+
+	// Priority policy: highest rank wins regardless of declaration order.
+	m = NewMachine(build()).WithConflictPolicy(ConflictPriority)
+	m.SendEvent("go")
+	if state := m.State("s"); state != "high" {
+		t.Errorf("Expected priority 5 transition to win, got %q", state)
+	}
+}
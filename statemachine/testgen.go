@@ -0,0 +1,306 @@
+package statemachine
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// CoverageGenerator explores a chart's reachable configuration graph and
+// derives event sequences that exercise every state and transition. The
+// sequences can be replayed directly or emitted as a table-driven Go test
+// (see WriteCoverageTest), so tests stay in sync with statechart changes.
+//
+// Exploration drives a real Machine via Snapshot/Restore, so guards are
+// honored: a transition whose guard never passes is reported uncovered
+// rather than silently assumed reachable.
+type CoverageGenerator struct {
+	chart      *Chart
+	maxConfigs int
+}
+
+// CoverageReport summarizes the exploration: the covering event paths and
+// what they reach.
+type CoverageReport struct {
+	Paths                [][]string // event sequences from the initial configuration
+	StatesTotal          int
+	StatesCovered        int
+	TransitionsTotal     int
+	TransitionsCovered   int
+	UncoveredStates      []string // leaf state paths never activated
+	UncoveredTransitions []string // "event: source -> target" never fired
+}
+
+// FullCoverage reports whether every state and transition was reached.
+func (r *CoverageReport) FullCoverage() bool {
+	return r.StatesCovered == r.StatesTotal && r.TransitionsCovered == r.TransitionsTotal
+}
+
+// NewCoverageGenerator creates a generator for the chart with a default
+// exploration limit of 10000 configurations.
+func NewCoverageGenerator(chart *Chart) *CoverageGenerator {
+	return &CoverageGenerator{chart: chart, maxConfigs: 10000}
+}
+
+// WithMaxConfigs limits how many distinct configurations are explored.
+// Charts with unbounded counters need a cap to terminate.
+func (g *CoverageGenerator) WithMaxConfigs(max int) *CoverageGenerator {
+	g.maxConfigs = max
+	return g
+}
+
+// Generate explores the configuration graph breadth-first and collects a
+// path for each transition the moment it is first covered. Paths that are
+// prefixes of longer collected paths are dropped.
+func (g *CoverageGenerator) Generate() *CoverageReport {
+	events := g.uniqueEvents()
+
+	type node struct {
+		snap   *Snapshot
+		events []string
+	}
+
+	m := NewMachine(g.chart)
+	root := &node{snap: m.Snapshot()}
+
+	visited := map[string]bool{configKey(root.snap): true}
+	coveredTrans := make(map[int]bool)
+	coveredStates := make(map[string]bool)
+	g.markActiveStates(root.snap, coveredStates)
+
+	var paths [][]string
+	queue := []*node{root}
+	for len(queue) > 0 && len(visited) < g.maxConfigs {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, event := range events {
+			if err := m.Restore(current.snap); err != nil {
+				continue
+			}
+			idx := g.firingIndex(m, event)
+			if idx < 0 || !m.SendEvent(event) {
+				continue
+			}
+
+			path := append(append([]string{}, current.events...), event)
+			if !coveredTrans[idx] {
+				coveredTrans[idx] = true
+				paths = append(paths, path)
+			}
+
+			snap := m.Snapshot()
+			g.markActiveStates(snap, coveredStates)
+			key := configKey(snap)
+			if !visited[key] {
+				visited[key] = true
+				queue = append(queue, &node{snap: snap, events: path})
+			}
+		}
+	}
+
+	report := &CoverageReport{
+		Paths:              prunePrefixes(paths),
+		TransitionsTotal:   len(g.chart.Transitions),
+		TransitionsCovered: len(coveredTrans),
+	}
+	for _, path := range g.leafStatePaths() {
+		report.StatesTotal++
+		if coveredStates[path] {
+			report.StatesCovered++
+		} else {
+			report.UncoveredStates = append(report.UncoveredStates, path)
+		}
+	}
+	for i, trans := range g.chart.Transitions {
+		if !coveredTrans[i] {
+			report.UncoveredTransitions = append(report.UncoveredTransitions,
+				fmt.Sprintf("%s: %s -> %s", trans.Event, trans.Source, trans.Target))
+		}
+	}
+	return report
+}
+
+// uniqueEvents lists the chart's events in declaration order.
+func (g *CoverageGenerator) uniqueEvents() []string {
+	seen := make(map[string]bool)
+	var events []string
+	for _, trans := range g.chart.Transitions {
+		if !seen[trans.Event] {
+			seen[trans.Event] = true
+			events = append(events, trans.Event)
+		}
+	}
+	return events
+}
+
+// firingIndex returns the chart index of the transition SendEvent would
+// fire for this event in the machine's current configuration, or -1.
+func (g *CoverageGenerator) firingIndex(m *Machine, event string) int {
+	mapping := m.selectTransition(m.eventTransitions[event], m.engine.GetState())
+	if mapping == nil {
+		return -1
+	}
+	for i, trans := range g.chart.Transitions {
+		if trans == mapping.transition {
+			return i
+		}
+	}
+	return -1
+}
+
+// leafStatePaths lists every leaf state as a "region:state[:sub]" path.
+func (g *CoverageGenerator) leafStatePaths() []string {
+	var paths []string
+	for regionName, region := range g.chart.Regions {
+		for stateName, state := range region.States {
+			if len(state.Children) == 0 {
+				paths = append(paths, regionName+":"+stateName)
+				continue
+			}
+			for childName := range state.Children {
+				paths = append(paths, regionName+":"+stateName+":"+childName)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// markActiveStates records which leaf state paths a configuration activates.
+func (g *CoverageGenerator) markActiveStates(snap *Snapshot, covered map[string]bool) {
+	for _, path := range g.leafStatePaths() {
+		place := strings.ReplaceAll(path, ":", "_")
+		if snap.State[place] > 0.5 {
+			covered[path] = true
+		}
+	}
+}
+
+// configKey serializes a configuration for visited-set membership.
+func configKey(snap *Snapshot) string {
+	keys := make([]string, 0, len(snap.State))
+	for k := range snap.State {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "%s=%g;", k, snap.State[k])
+	}
+	return sb.String()
+}
+
+// prunePrefixes drops paths that are strict prefixes of other paths.
+func prunePrefixes(paths [][]string) [][]string {
+	var kept [][]string
+	for i, p := range paths {
+		prefix := false
+		for j, q := range paths {
+			if i != j && len(p) <= len(q) && isPrefix(p, q) && (len(p) < len(q) || i < j) {
+				prefix = true
+				break
+			}
+		}
+		if !prefix {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func isPrefix(p, q []string) bool {
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// CoverageTestOptions configure the source emitted by WriteCoverageTest.
+// Zero values get sensible defaults.
+type CoverageTestOptions struct {
+	Package   string // package clause (default "statemachine_test")
+	TestName  string // generated test function name (default "TestChartCoverage")
+	ChartFunc string // name of a func() *statemachine.Chart in the target package (default "newChart")
+}
+
+// WriteCoverageTest emits the report's paths as a table-driven Go test.
+// Each case replays one event sequence on a fresh machine built by the
+// named chart function and asserts the final state of every region. The
+// expected finals are computed by replaying the paths now, so regenerate
+// the test whenever the chart changes.
+func WriteCoverageTest(w io.Writer, chart *Chart, report *CoverageReport, opts CoverageTestOptions) error {
+	if opts.Package == "" {
+		opts.Package = "statemachine_test"
+	}
+	if opts.TestName == "" {
+		opts.TestName = "TestChartCoverage"
+	}
+	if opts.ChartFunc == "" {
+		opts.ChartFunc = "newChart"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by statemachine.WriteCoverageTest. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&sb, "package %s\n\n", opts.Package)
+	sb.WriteString("import (\n\t\"testing\"\n\n\t\"github.com/pflow-xyz/go-pflow/statemachine\"\n)\n\n")
+	fmt.Fprintf(&sb, "func %s(t *testing.T) {\n", opts.TestName)
+	sb.WriteString("\tcases := []struct {\n\t\tname   string\n\t\tevents []string\n\t\tfinal  map[string]string\n\t}{\n")
+
+	for i, path := range report.Paths {
+		m := NewMachine(chart)
+		for _, event := range path {
+			m.SendEvent(event)
+		}
+		finals := make(map[string]string)
+		regions := make([]string, 0, len(chart.Regions))
+		for name := range chart.Regions {
+			regions = append(regions, name)
+		}
+		sort.Strings(regions)
+		for _, name := range regions {
+			finals[name] = m.FullState(name)
+		}
+
+		fmt.Fprintf(&sb, "\t\t{%q, []string{%s}, map[string]string{", fmt.Sprintf("path_%d", i+1), quoteList(path))
+		for j, name := range regions {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(&sb, "%q: %q", name, finals[name])
+		}
+		sb.WriteString("}},\n")
+	}
+
+	sb.WriteString("\t}\n\n")
+	sb.WriteString("\tfor _, tc := range cases {\n")
+	sb.WriteString("\t\tt.Run(tc.name, func(t *testing.T) {\n")
+	fmt.Fprintf(&sb, "\t\t\tm := statemachine.NewMachine(%s())\n", opts.ChartFunc)
+	sb.WriteString("\t\t\tfor _, event := range tc.events {\n")
+	sb.WriteString("\t\t\t\tif !m.SendEvent(event) {\n")
+	sb.WriteString("\t\t\t\t\tt.Fatalf(\"event %q did not fire\", event)\n")
+	sb.WriteString("\t\t\t\t}\n")
+	sb.WriteString("\t\t\t}\n")
+	sb.WriteString("\t\t\tfor region, want := range tc.final {\n")
+	sb.WriteString("\t\t\t\tif got := m.FullState(region); got != want {\n")
+	sb.WriteString("\t\t\t\t\tt.Errorf(\"region %q: got %q, want %q\", region, got, want)\n")
+	sb.WriteString("\t\t\t\t}\n")
+	sb.WriteString("\t\t\t}\n")
+	sb.WriteString("\t\t})\n")
+	sb.WriteString("\t}\n")
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func quoteList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return strings.Join(quoted, ", ")
+}
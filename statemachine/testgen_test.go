@@ -0,0 +1,115 @@
+package statemachine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateFullCoverage(t *testing.T) {
+	chart := NewChart("traffic_light").
+		Region("light").
+		State("red").Initial().
+		State("green").
+		State("yellow").
+		EndRegion().
+		When("timer").In("light:red").GoTo("light:green").
+		When("timer").In("light:green").GoTo("light:yellow").
+		When("timer").In("light:yellow").GoTo("light:red").
+		Build()
+
+	report := NewCoverageGenerator(chart).Generate()
+	if !report.FullCoverage() {
+		t.Fatalf("Expected full coverage, got %d/%d states, %d/%d transitions (uncovered: %v %v)",
+			report.StatesCovered, report.StatesTotal,
+			report.TransitionsCovered, report.TransitionsTotal,
+			report.UncoveredStates, report.UncoveredTransitions)
+	}
+
+	// The paths must replay without a dead event.
+	for _, path := range report.Paths {
+		m := NewMachine(chart)
+		for _, event := range path {
+			if !m.SendEvent(event) {
+				t.Errorf("Path %v: event %q did not fire", path, event)
+			}
+		}
+	}
+}
+
+func TestGenerateReportsUnreachable(t *testing.T) {
+	// "locked" is only reachable through a guard that never passes.
+	chart := NewChart("gate").
+		Region("s").
+		State("open").Initial().
+		State("closed").
+		State("locked").
+		EndRegion().
+		When("close").In("s:open").GoTo("s:closed").
+		When("lock").In("s:closed").GoTo("s:locked").
+		If(func(state map[string]float64) bool { return false }).
+		Build()
+
+	report := NewCoverageGenerator(chart).Generate()
+	if report.FullCoverage() {
+		t.Fatal("Expected incomplete coverage")
+	}
+	if len(report.UncoveredStates) != 1 || report.UncoveredStates[0] != "s:locked" {
+		t.Errorf("Expected s:locked uncovered, got %v", report.UncoveredStates)
+	}
+	if len(report.UncoveredTransitions) != 1 || !strings.Contains(report.UncoveredTransitions[0], "lock") {
+		t.Errorf("Expected lock transition uncovered, got %v", report.UncoveredTransitions)
+	}
+}
+
+func TestGenerateRespectsMaxConfigs(t *testing.T) {
+	// An unbounded counter makes the configuration graph infinite.
+	chart := NewChart("counter").
+		Region("s").
+		State("on").Initial().
+		EndRegion().
+		Counter("ticks").
+		When("tick").In("s:on").GoTo("s:on").Do(Increment("ticks")).
+		Build()
+
+	report := NewCoverageGenerator(chart).WithMaxConfigs(5).Generate()
+	if report.TransitionsCovered != 1 {
+		t.Errorf("Expected the tick transition covered, got %d", report.TransitionsCovered)
+	}
+}
+
+func TestWriteCoverageTest(t *testing.T) {
+	chart := NewChart("order").
+		Region("status").
+		State("placed").Initial().
+		State("shipped").
+		State("delivered").
+		EndRegion().
+		When("ship").In("status:placed").GoTo("status:shipped").
+		When("deliver").In("status:shipped").GoTo("status:delivered").
+		Build()
+
+	report := NewCoverageGenerator(chart).Generate()
+
+	var sb strings.Builder
+	err := WriteCoverageTest(&sb, chart, report, CoverageTestOptions{
+		Package:   "order_test",
+		TestName:  "TestOrderCoverage",
+		ChartFunc: "orderChart",
+	})
+	if err != nil {
+		t.Fatalf("WriteCoverageTest failed: %v", err)
+	}
+
+	src := sb.String()
+	for _, want := range []string{
+		"package order_test",
+		"func TestOrderCoverage(t *testing.T)",
+		"statemachine.NewMachine(orderChart())",
+		`"ship", "deliver"`,
+		`"status": "delivered"`,
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("Generated source missing %q:\n%s", want, src)
+		}
+	}
+}
@@ -12,16 +12,20 @@
 // analyzed using the reachability package or simulated with the engine package.
 package statemachine
 
+import "time"
+
 // State represents a state in the state machine.
 // States can be simple (leaf) or composite (containing substates).
 type State struct {
-	Name         string
-	Parent       *State            // nil for top-level states
-	Children     map[string]*State // substates (nil for leaf states)
-	Initial      bool              // is this the initial substate?
-	IsLeaf       bool              // true if no children
-	EntryActions []Action
-	ExitActions  []Action
+	Name           string
+	Parent         *State            // nil for top-level states
+	Children       map[string]*State // substates (nil for leaf states)
+	Initial        bool              // is this the initial substate?
+	IsLeaf         bool              // true if no children
+	HistoryMode    HistoryKind       // shallow/deep history for composite states
+	DeferredEvents []string          // events held back while this state is active (see Machine.Dispatch)
+	EntryActions   []Action
+	ExitActions    []Action
 }
 
 // Region represents an orthogonal region (parallel component).
@@ -34,11 +38,14 @@ type Region struct {
 
 // Transition represents a state transition triggered by an event.
 type Transition struct {
-	Event   string   // triggering event name
-	Source  string   // source state path (e.g., "mode:dateTime:default")
-	Target  string   // target state path
-	Guard   Guard    // optional precondition
-	Actions []Action // actions to execute on transition
+	Event        string        // triggering event name
+	Source       string        // source state path (e.g., "mode:dateTime:default")
+	Target       string        // target state path
+	Guard        Guard         // optional precondition
+	Actions      []Action      // actions to execute on transition
+	After        time.Duration // non-zero for timed transitions (see Machine.Tick)
+	InternalOnly bool          // internal transition: actions only, no state change
+	Priority     int           // conflict resolution rank (see ConflictPriority)
 }
 
 // Guard is a predicate that must be true for a transition to fire.
@@ -57,6 +64,7 @@ type Chart struct {
 	Name        string
 	Regions     map[string]*Region
 	Transitions []*Transition
+	Variables   map[string]float64 // extended state variables and initial values
 }
 
 // StatePath represents a hierarchical state path like "mode:dateTime:holding"
@@ -0,0 +1,53 @@
+package statemachine
+
+import "testing"
+
+func TestVerifyConversionFaithful(t *testing.T) {
+	chart := NewChart("light").
+		Region("state").
+		State("red").Initial().
+		State("green").
+		State("yellow").
+		EndRegion().
+		When("timer").In("state:red").GoTo("state:green").
+		When("timer").In("state:green").GoTo("state:yellow").
+		When("timer").In("state:yellow").GoTo("state:red").
+		Build()
+
+	report := VerifyConversion(chart)
+	if !report.OK {
+		t.Errorf("Expected faithful conversion, violations: %v", report.Violations)
+	}
+	if report.StateCount != 3 {
+		t.Errorf("Expected 3 reachable markings, got %d", report.StateCount)
+	}
+	if report.Deadlocks != 0 {
+		t.Errorf("Cyclic chart should have no deadlocks, got %d", report.Deadlocks)
+	}
+}
+
+func TestVerifyConversionParallelRegions(t *testing.T) {
+	chart := NewChart("watch").
+		Region("display").
+		State("time").Initial().
+		State("date").
+		EndRegion().
+		Region("light").
+		State("off").Initial().
+		State("on").
+		EndRegion().
+		When("mode").In("display:time").GoTo("display:date").
+		When("mode").In("display:date").GoTo("display:time").
+		When("press").In("light:off").GoTo("light:on").
+		When("release").In("light:on").GoTo("light:off").
+		Build()
+
+	report := VerifyConversion(chart)
+	if !report.OK {
+		t.Errorf("Expected faithful parallel conversion, violations: %v", report.Violations)
+	}
+	// 2 display states x 2 light states
+	if report.StateCount != 4 {
+		t.Errorf("Expected 4 reachable markings, got %d", report.StateCount)
+	}
+}
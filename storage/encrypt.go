@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+)
+
+// EncryptedStore wraps another Store and transparently encrypts values with
+// AES-GCM before they reach the backend. Keys and bucket names remain in
+// plaintext so iteration and lookups work unchanged; only values are
+// protected. Use this when persisted instance data must be encrypted at rest.
+type EncryptedStore struct {
+	inner Store
+	aead  cipher.AEAD
+}
+
+// NewEncryptedStore creates an encrypting wrapper around a Store.
+// The key must be 16, 24, or 32 bytes (AES-128/192/256).
+func NewEncryptedStore(inner Store, key []byte) (*EncryptedStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return &EncryptedStore{inner: inner, aead: aead}, nil
+}
+
+// seal encrypts a value, prefixing the random nonce.
+func (s *EncryptedStore) seal(value []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return s.aead.Seal(nonce, nonce, value, nil), nil
+}
+
+// open decrypts a nonce-prefixed value.
+func (s *EncryptedStore) open(sealed []byte) ([]byte, error) {
+	ns := s.aead.NonceSize()
+	if len(sealed) < ns {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	plain, err := s.aead.Open(nil, sealed[:ns], sealed[ns:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plain, nil
+}
+
+// Get retrieves and decrypts the value for a key.
+func (s *EncryptedStore) Get(bucket, key string) ([]byte, error) {
+	sealed, err := s.inner.Get(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(sealed)
+}
+
+// Put encrypts and stores a value under a key.
+func (s *EncryptedStore) Put(bucket, key string, value []byte) error {
+	sealed, err := s.seal(value)
+	if err != nil {
+		return err
+	}
+	return s.inner.Put(bucket, key, sealed)
+}
+
+// Delete removes a key.
+func (s *EncryptedStore) Delete(bucket, key string) error {
+	return s.inner.Delete(bucket, key)
+}
+
+// Iterate calls fn with decrypted values in ascending key order.
+// Values that fail to decrypt (e.g. written before encryption was enabled)
+// abort the iteration with an error.
+func (s *EncryptedStore) Iterate(bucket string, fn func(key string, value []byte) bool) error {
+	var iterErr error
+	err := s.inner.Iterate(bucket, func(key string, sealed []byte) bool {
+		plain, err := s.open(sealed)
+		if err != nil {
+			iterErr = fmt.Errorf("key %q: %w", key, err)
+			return false
+		}
+		return fn(key, plain)
+	})
+	if iterErr != nil {
+		return iterErr
+	}
+	return err
+}
+
+// Buckets returns the names of all buckets.
+func (s *EncryptedStore) Buckets() ([]string, error) {
+	return s.inner.Buckets()
+}
+
+// Close closes the underlying store.
+func (s *EncryptedStore) Close() error {
+	return s.inner.Close()
+}
+
+// Ensure EncryptedStore implements Store.
+var _ Store = (*EncryptedStore)(nil)
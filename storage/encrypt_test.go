@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestEncryptedStoreRoundtrip(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef") // AES-256
+	store, err := NewEncryptedStore(NewMemoryStore(), key)
+	if err != nil {
+		t.Fatalf("NewEncryptedStore: %v", err)
+	}
+
+	plaintext := []byte(`{"customer":"acme","total":42}`)
+	if err := store.Put("instances", "i1", plaintext); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, err := store.Get("instances", "i1")
+	if err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("Get: got %q, %v", got, err)
+	}
+
+	// The backend must never see plaintext
+	inner := store.inner.(*MemoryStore)
+	raw, _ := inner.Get("instances", "i1")
+	if bytes.Contains(raw, []byte("acme")) {
+		t.Error("Backend stored plaintext")
+	}
+
+	// Iterate decrypts
+	var seen []byte
+	store.Iterate("instances", func(_ string, v []byte) bool {
+		seen = v
+		return true
+	})
+	if !bytes.Equal(seen, plaintext) {
+		t.Errorf("Iterate: got %q", seen)
+	}
+}
+
+func TestEncryptedStoreBadKey(t *testing.T) {
+	if _, err := NewEncryptedStore(NewMemoryStore(), []byte("short")); err == nil {
+		t.Error("Expected error for invalid key length")
+	}
+}
+
+func TestRedactor(t *testing.T) {
+	doc := []byte(`{
+		"caseId": "c1",
+		"email": "user@example.com",
+		"attributes": {"email": "nested@example.com", "severity": "P0"},
+		"events": [{"resource": "alice", "activity": "triage"}]
+	}`)
+
+	out, err := NewRedactor("email", "resource").RedactJSON(doc)
+	if err != nil {
+		t.Fatalf("RedactJSON: %v", err)
+	}
+
+	var parsed map[string]interface{}
+	json.Unmarshal(out, &parsed)
+
+	if parsed["email"] != "[REDACTED]" {
+		t.Errorf("Top-level email not redacted: %v", parsed["email"])
+	}
+	attrs := parsed["attributes"].(map[string]interface{})
+	if attrs["email"] != "[REDACTED]" {
+		t.Errorf("Nested email not redacted: %v", attrs["email"])
+	}
+	if attrs["severity"] != "P0" {
+		t.Errorf("Unrelated field changed: %v", attrs["severity"])
+	}
+	event := parsed["events"].([]interface{})[0].(map[string]interface{})
+	if event["resource"] != "[REDACTED]" {
+		t.Errorf("Array element resource not redacted: %v", event["resource"])
+	}
+	if event["activity"] != "triage" {
+		t.Errorf("Unrelated array field changed: %v", event["activity"])
+	}
+}
+
+func TestRedactorDrop(t *testing.T) {
+	out, err := (&Redactor{Fields: []string{"ssn"}, Drop: true}).
+		RedactJSON([]byte(`{"name":"x","ssn":"123"}`))
+	if err != nil {
+		t.Fatalf("RedactJSON: %v", err)
+	}
+	var parsed map[string]interface{}
+	json.Unmarshal(out, &parsed)
+	if _, exists := parsed["ssn"]; exists {
+		t.Error("Dropped field still present")
+	}
+}
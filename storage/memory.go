@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-memory Store for testing and development.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	buckets map[string]map[string][]byte
+	closed  bool
+}
+
+// NewMemoryStore creates a new in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]map[string][]byte),
+	}
+}
+
+// Get retrieves the value for a key.
+func (s *MemoryStore) Get(bucket, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	b, ok := s.buckets[bucket]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	v, ok := b[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	result := make([]byte, len(v))
+	copy(result, v)
+	return result, nil
+}
+
+// Put stores a value under a key.
+func (s *MemoryStore) Put(bucket, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	b, ok := s.buckets[bucket]
+	if !ok {
+		b = make(map[string][]byte)
+		s.buckets[bucket] = b
+	}
+	stored := make([]byte, len(value))
+	copy(stored, value)
+	b[key] = stored
+	return nil
+}
+
+// Delete removes a key.
+func (s *MemoryStore) Delete(bucket, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if b, ok := s.buckets[bucket]; ok {
+		delete(b, key)
+	}
+	return nil
+}
+
+// Iterate calls fn for each key/value pair in ascending key order.
+func (s *MemoryStore) Iterate(bucket string, fn func(key string, value []byte) bool) error {
+	s.mu.RLock()
+	if s.closed {
+		s.mu.RUnlock()
+		return ErrStoreClosed
+	}
+	b := s.buckets[bucket]
+	keys := make([]string, 0, len(b))
+	for k := range b {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// Copy values so fn runs without holding the lock.
+	values := make([][]byte, len(keys))
+	for i, k := range keys {
+		values[i] = b[k]
+	}
+	s.mu.RUnlock()
+
+	for i, k := range keys {
+		if !fn(k, values[i]) {
+			break
+		}
+	}
+	return nil
+}
+
+// Buckets returns all bucket names in ascending order.
+func (s *MemoryStore) Buckets() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	names := make([]string, 0, len(s.buckets))
+	for name := range s.buckets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Close marks the store as closed.
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// Ensure MemoryStore implements Store.
+var _ Store = (*MemoryStore)(nil)
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Redactor removes configured fields from JSON documents before export.
+// Field matching is by name at any nesting depth, so "email" redacts
+// attributes.email as well as top-level email fields.
+type Redactor struct {
+	// Fields lists the JSON field names to redact.
+	Fields []string
+	// Replacement substitutes redacted values; defaults to "[REDACTED]".
+	Replacement string
+	// Drop removes redacted fields entirely instead of replacing them.
+	Drop bool
+}
+
+// NewRedactor creates a redactor for the given field names.
+func NewRedactor(fields ...string) *Redactor {
+	return &Redactor{Fields: fields}
+}
+
+// RedactJSON returns a copy of the JSON document with configured fields
+// replaced (or dropped). Arrays and nested objects are walked recursively.
+func (r *Redactor) RedactJSON(data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON for redaction: %w", err)
+	}
+	doc = r.redactValue(doc)
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode redacted JSON: %w", err)
+	}
+	return out, nil
+}
+
+// RedactMap redacts fields in a decoded document in place-compatible form.
+func (r *Redactor) RedactMap(doc map[string]interface{}) map[string]interface{} {
+	return r.redactValue(doc).(map[string]interface{})
+}
+
+// redactValue recursively redacts matching fields in maps and arrays.
+func (r *Redactor) redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(val))
+		for k, inner := range val {
+			if r.matches(k) {
+				if !r.Drop {
+					result[k] = r.replacement()
+				}
+				continue
+			}
+			result[k] = r.redactValue(inner)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(val))
+		for i, inner := range val {
+			result[i] = r.redactValue(inner)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+func (r *Redactor) matches(field string) bool {
+	for _, f := range r.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) replacement() string {
+	if r.Replacement != "" {
+		return r.Replacement
+	}
+	return "[REDACTED]"
+}
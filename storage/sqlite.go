@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore is a SQLite-backed Store. All buckets share one kv table
+// keyed by (bucket, key).
+type SQLiteStore struct {
+	db     *sql.DB
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewSQLiteStore opens (or creates) a SQLite-backed store.
+// dsn can be ":memory:" for in-memory or a file path.
+func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Enable WAL mode for better concurrency
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to enable WAL: %w", err)
+	}
+
+	schema := `
+		CREATE TABLE IF NOT EXISTS kv (
+			bucket TEXT NOT NULL,
+			key TEXT NOT NULL,
+			value BLOB NOT NULL,
+			PRIMARY KEY (bucket, key)
+		);
+		CREATE INDEX IF NOT EXISTS idx_kv_bucket ON kv(bucket);
+	`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate: %w", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Get retrieves the value for a key.
+func (s *SQLiteStore) Get(bucket, key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	var value []byte
+	err := s.db.QueryRow("SELECT value FROM kv WHERE bucket = ? AND key = ?", bucket, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get: %w", err)
+	}
+	return value, nil
+}
+
+// Put stores a value under a key.
+func (s *SQLiteStore) Put(bucket, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	_, err := s.db.Exec(
+		"INSERT INTO kv (bucket, key, value) VALUES (?, ?, ?) ON CONFLICT(bucket, key) DO UPDATE SET value = excluded.value",
+		bucket, key, value)
+	if err != nil {
+		return fmt.Errorf("failed to put: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a key.
+func (s *SQLiteStore) Delete(bucket, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	if _, err := s.db.Exec("DELETE FROM kv WHERE bucket = ? AND key = ?", bucket, key); err != nil {
+		return fmt.Errorf("failed to delete: %w", err)
+	}
+	return nil
+}
+
+// Iterate calls fn for each key/value pair in ascending key order.
+func (s *SQLiteStore) Iterate(bucket string, fn func(key string, value []byte) bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrStoreClosed
+	}
+	rows, err := s.db.Query("SELECT key, value FROM kv WHERE bucket = ? ORDER BY key", bucket)
+	if err != nil {
+		return fmt.Errorf("failed to iterate: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return fmt.Errorf("failed to scan: %w", err)
+		}
+		if !fn(key, value) {
+			break
+		}
+	}
+	return rows.Err()
+}
+
+// Buckets returns all bucket names in ascending order.
+func (s *SQLiteStore) Buckets() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return nil, ErrStoreClosed
+	}
+	rows, err := s.db.Query("SELECT DISTINCT bucket FROM kv ORDER BY bucket")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buckets: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// Close closes the underlying database.
+func (s *SQLiteStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.db.Close()
+}
+
+// Ensure SQLiteStore implements Store.
+var _ Store = (*SQLiteStore)(nil)
@@ -0,0 +1,37 @@
+// Package storage defines a single embedded key-value abstraction shared by
+// the persistence layers in this repository (graphql stores, workflow engine,
+// monitor snapshots, session logs). Backends implement Store once; callers
+// pick a driver at construction time and remain backend-agnostic.
+package storage
+
+import "errors"
+
+// Common errors.
+var (
+	ErrKeyNotFound = errors.New("key not found")
+	ErrStoreClosed = errors.New("store is closed")
+)
+
+// Store is a bucketed key-value store. Buckets are flat namespaces (created
+// implicitly on first Put); keys and values are opaque bytes. All methods
+// must be safe for concurrent use.
+type Store interface {
+	// Get retrieves the value for a key. Returns ErrKeyNotFound if absent.
+	Get(bucket, key string) ([]byte, error)
+
+	// Put stores a value under a key, creating the bucket if needed.
+	Put(bucket, key string, value []byte) error
+
+	// Delete removes a key. Deleting an absent key is not an error.
+	Delete(bucket, key string) error
+
+	// Iterate calls fn for each key/value pair in a bucket, in ascending
+	// key order. Returning false from fn stops the iteration early.
+	Iterate(bucket string, fn func(key string, value []byte) bool) error
+
+	// Buckets returns the names of all buckets in ascending order.
+	Buckets() ([]string, error)
+
+	// Close releases any resources held by the store.
+	Close() error
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// storeFactories builds one instance of each driver for conformance testing.
+func storeFactories(t *testing.T) map[string]Store {
+	t.Helper()
+
+	sqlite, err := NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %v", err)
+	}
+	boltStore, err := NewBoltStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewBoltStore: %v", err)
+	}
+
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"sqlite": sqlite,
+		"bolt":   boltStore,
+	}
+}
+
+func TestStoreConformance(t *testing.T) {
+	for name, store := range storeFactories(t) {
+		t.Run(name, func(t *testing.T) {
+			defer store.Close()
+
+			// Missing key
+			if _, err := store.Get("cases", "missing"); err != ErrKeyNotFound {
+				t.Errorf("Get missing: expected ErrKeyNotFound, got %v", err)
+			}
+
+			// Put / Get roundtrip
+			if err := store.Put("cases", "c1", []byte("hello")); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			v, err := store.Get("cases", "c1")
+			if err != nil || string(v) != "hello" {
+				t.Errorf("Get: got %q, %v", v, err)
+			}
+
+			// Overwrite
+			store.Put("cases", "c1", []byte("world"))
+			v, _ = store.Get("cases", "c1")
+			if string(v) != "world" {
+				t.Errorf("Overwrite: got %q", v)
+			}
+
+			// Iterate in key order
+			store.Put("cases", "c3", []byte("3"))
+			store.Put("cases", "c2", []byte("2"))
+			var keys []string
+			store.Iterate("cases", func(key string, _ []byte) bool {
+				keys = append(keys, key)
+				return true
+			})
+			if len(keys) != 3 || keys[0] != "c1" || keys[1] != "c2" || keys[2] != "c3" {
+				t.Errorf("Iterate order: got %v", keys)
+			}
+
+			// Early stop
+			count := 0
+			store.Iterate("cases", func(string, []byte) bool {
+				count++
+				return false
+			})
+			if count != 1 {
+				t.Errorf("Iterate early stop: visited %d", count)
+			}
+
+			// Buckets
+			store.Put("alerts", "a1", []byte("x"))
+			buckets, err := store.Buckets()
+			if err != nil {
+				t.Fatalf("Buckets: %v", err)
+			}
+			if len(buckets) != 2 || buckets[0] != "alerts" || buckets[1] != "cases" {
+				t.Errorf("Buckets: got %v", buckets)
+			}
+
+			// Delete (absent key is not an error)
+			if err := store.Delete("cases", "c1"); err != nil {
+				t.Errorf("Delete: %v", err)
+			}
+			if err := store.Delete("cases", "nope"); err != nil {
+				t.Errorf("Delete absent: %v", err)
+			}
+			if _, err := store.Get("cases", "c1"); err != ErrKeyNotFound {
+				t.Errorf("Get deleted: expected ErrKeyNotFound, got %v", err)
+			}
+
+			// Closed store
+			store.Close()
+			if err := store.Put("cases", "x", nil); err != ErrStoreClosed {
+				t.Errorf("Put after close: expected ErrStoreClosed, got %v", err)
+			}
+		})
+	}
+}
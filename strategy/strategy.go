@@ -0,0 +1,218 @@
+// Package strategy scores candidate moves by short ODE rollouts: fire a
+// candidate transition discretely, simulate the resulting state over a
+// small horizon, and read an objective off the final state. Chess, nim,
+// connect4, and poker each reimplement this "GetODEMove" pattern; this
+// package factors it out and adds optional beam search over multi-move
+// sequences with backtracking at dead ends.
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/reachability"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// Objective scores a simulated final state; higher is better.
+type Objective func(final map[string]float64) float64
+
+// Strategy evaluates moves on a net by hypothetical rollouts.
+type Strategy struct {
+	net       *petri.PetriNet
+	rates     map[string]float64
+	graph     *reachability.Graph // discrete firing of candidate moves
+	actions   []string
+	objective Objective
+	horizon   float64
+	opts      *solver.Options
+}
+
+// New creates a strategy for the given net and rates. Transitions missing
+// from rates default to 1.0; the objective defaults to zero and should be
+// set with WithObjective or WithObjectivePlace.
+func New(net *petri.PetriNet, rates map[string]float64) *Strategy {
+	initial := make(reachability.Marking)
+	for name, place := range net.Places {
+		initial[name] = int(place.GetTokenCount())
+	}
+
+	actions := make([]string, 0, len(net.Transitions))
+	for t := range net.Transitions {
+		actions = append(actions, t)
+	}
+	sort.Strings(actions)
+
+	return &Strategy{
+		net:       net,
+		rates:     net.SetRates(rates),
+		graph:     reachability.NewGraph(net, initial),
+		actions:   actions,
+		objective: func(final map[string]float64) float64 { return 0 },
+		horizon:   1.0,
+		opts:      solver.GameAIOptions(),
+	}
+}
+
+// WithActions restricts which transitions count as moves (e.g. only this
+// player's transitions).
+func (s *Strategy) WithActions(transitions ...string) *Strategy {
+	s.actions = append([]string(nil), transitions...)
+	sort.Strings(s.actions)
+	return s
+}
+
+// WithObjective sets the scoring function over simulated final states.
+func (s *Strategy) WithObjective(obj Objective) *Strategy {
+	s.objective = obj
+	return s
+}
+
+// WithObjectivePlace scores rollouts by the final token count of a place
+// (e.g. "solved" or "wins").
+func (s *Strategy) WithObjectivePlace(place string) *Strategy {
+	s.objective = func(final map[string]float64) float64 {
+		return final[place]
+	}
+	return s
+}
+
+// WithHorizon sets the rollout length in simulated time.
+func (s *Strategy) WithHorizon(t float64) *Strategy {
+	s.horizon = t
+	return s
+}
+
+// WithOptions sets the solver options for rollouts.
+func (s *Strategy) WithOptions(opts *solver.Options) *Strategy {
+	s.opts = opts
+	return s
+}
+
+// ScoredAction is one candidate move and its rollout score.
+type ScoredAction struct {
+	Action string
+	Score  float64
+}
+
+// rollout fires the action discretely from the marking, then simulates the
+// result over the horizon. It returns the post-move marking and the score,
+// or false if the action is not enabled.
+func (s *Strategy) rollout(marking reachability.Marking, action string) (reachability.Marking, float64, bool) {
+	next := s.graph.Fire(marking, action)
+	if next == nil {
+		return nil, 0, false
+	}
+	prob := solver.NewProblem(s.net, next.ToState(), [2]float64{0, s.horizon}, s.rates)
+	sol := solver.Solve(prob, solver.Tsit5(), s.opts)
+	return next, s.objective(sol.GetFinalState()), true
+}
+
+// ScoreActions evaluates the given candidate transitions from a state,
+// in parallel, and returns them sorted best-first. Actions that are not
+// enabled are omitted.
+func (s *Strategy) ScoreActions(state map[string]float64, actions []string) []ScoredAction {
+	marking := toMarking(state)
+	scored := make([]ScoredAction, len(actions))
+	valid := make([]bool, len(actions))
+
+	var wg sync.WaitGroup
+	for i, action := range actions {
+		wg.Add(1)
+		go func(i int, action string) {
+			defer wg.Done()
+			if _, score, ok := s.rollout(marking, action); ok {
+				scored[i] = ScoredAction{Action: action, Score: score}
+				valid[i] = true
+			}
+		}(i, action)
+	}
+	wg.Wait()
+
+	result := make([]ScoredAction, 0, len(actions))
+	for i := range scored {
+		if valid[i] {
+			result = append(result, scored[i])
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	return result
+}
+
+// BestAction returns the highest-scoring enabled move from the strategy's
+// action set, or an error when no move is enabled.
+func (s *Strategy) BestAction(state map[string]float64) (string, float64, error) {
+	scored := s.ScoreActions(state, s.actions)
+	if len(scored) == 0 {
+		return "", 0, fmt.Errorf("no enabled actions")
+	}
+	return scored[0].Action, scored[0].Score, nil
+}
+
+// beamNode is one partial move sequence under beam search.
+type beamNode struct {
+	marking reachability.Marking
+	path    []string
+	score   float64
+}
+
+// BeamSearch looks ahead depth moves, keeping the width best partial
+// sequences at each ply. Branches with no enabled moves fall out of the
+// beam but stay candidates for the final answer, so the search backtracks
+// to shallower sequences when deeper ones dead-end. It returns the best
+// sequence found and its score.
+func (s *Strategy) BeamSearch(state map[string]float64, depth, width int) ([]string, float64, error) {
+	if depth < 1 || width < 1 {
+		return nil, 0, fmt.Errorf("depth and width must be positive, got %d/%d", depth, width)
+	}
+
+	beam := []beamNode{{marking: toMarking(state), score: math.Inf(-1)}}
+	best := beamNode{score: math.Inf(-1)}
+
+	for ply := 0; ply < depth; ply++ {
+		var expanded []beamNode
+		for _, node := range beam {
+			for _, action := range s.actions {
+				next, score, ok := s.rollout(node.marking, action)
+				if !ok {
+					continue
+				}
+				path := append(append([]string(nil), node.path...), action)
+				expanded = append(expanded, beamNode{marking: next, path: path, score: score})
+			}
+		}
+		if len(expanded) == 0 {
+			break // every beam entry dead-ended; fall back to best so far
+		}
+		sort.SliceStable(expanded, func(i, j int) bool {
+			return expanded[i].score > expanded[j].score
+		})
+		if len(expanded) > width {
+			expanded = expanded[:width]
+		}
+		beam = expanded
+
+		if beam[0].score > best.score {
+			best = beam[0]
+		}
+	}
+
+	if best.path == nil {
+		return nil, 0, fmt.Errorf("no enabled actions")
+	}
+	return best.path, best.score, nil
+}
+
+// toMarking rounds a state map to an integer marking for discrete firing.
+func toMarking(state map[string]float64) reachability.Marking {
+	m := make(reachability.Marking, len(state))
+	for place, tokens := range state {
+		m[place] = int(math.Round(tokens))
+	}
+	return m
+}
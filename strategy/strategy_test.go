@@ -0,0 +1,114 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// forkNet offers a good and a bad move: "win" feeds the goal place,
+// "lose" burns the token.
+func forkNet() *petri.PetriNet {
+	return petri.Build().
+		Place("turn", 1).Place("goal", 0).Place("sink", 0).
+		Transition("win").Transition("lose").
+		Arc("turn", "win", 1).Arc("win", "goal", 1).
+		Arc("turn", "lose", 1).Arc("lose", "sink", 1).
+		Done()
+}
+
+func TestScoreActionsRanksMoves(t *testing.T) {
+	net := forkNet()
+	s := New(net, nil).WithObjectivePlace("goal")
+
+	scored := s.ScoreActions(net.SetState(nil), []string{"win", "lose"})
+	if len(scored) != 2 {
+		t.Fatalf("Expected 2 scored actions, got %d", len(scored))
+	}
+	if scored[0].Action != "win" {
+		t.Errorf("Expected win ranked first, got %q", scored[0].Action)
+	}
+	if scored[0].Score <= scored[1].Score {
+		t.Errorf("Expected win to outscore lose: %f vs %f", scored[0].Score, scored[1].Score)
+	}
+}
+
+func TestBestAction(t *testing.T) {
+	net := forkNet()
+	s := New(net, nil).WithObjectivePlace("goal")
+
+	action, score, err := s.BestAction(net.SetState(nil))
+	if err != nil {
+		t.Fatalf("BestAction failed: %v", err)
+	}
+	if action != "win" {
+		t.Errorf("Expected win, got %q", action)
+	}
+	if score < 0.9 {
+		t.Errorf("Expected score near 1, got %f", score)
+	}
+
+	// No token: nothing is enabled.
+	if _, _, err := s.BestAction(map[string]float64{"turn": 0}); err == nil {
+		t.Error("Expected error with no enabled actions")
+	}
+}
+
+func TestScoreActionsSkipsDisabled(t *testing.T) {
+	net := forkNet()
+	s := New(net, nil).WithObjectivePlace("goal")
+
+	scored := s.ScoreActions(map[string]float64{"turn": 0, "goal": 0, "sink": 0}, []string{"win", "lose"})
+	if len(scored) != 0 {
+		t.Errorf("Expected no enabled actions, got %v", scored)
+	}
+}
+
+func TestBeamSearch(t *testing.T) {
+	// Two turns: each turn token allows one move; two wins beat one.
+	net := petri.Build().
+		Place("turn", 2).Place("goal", 0).Place("sink", 0).
+		Transition("win").Transition("lose").
+		Arc("turn", "win", 1).Arc("win", "goal", 1).
+		Arc("turn", "lose", 1).Arc("lose", "sink", 1).
+		Done()
+
+	s := New(net, nil).WithObjectivePlace("goal")
+	path, score, err := s.BeamSearch(net.SetState(nil), 2, 2)
+	if err != nil {
+		t.Fatalf("BeamSearch failed: %v", err)
+	}
+	if len(path) != 2 || path[0] != "win" || path[1] != "win" {
+		t.Errorf("Expected win,win, got %v", path)
+	}
+	if score < 1.9 {
+		t.Errorf("Expected score near 2, got %f", score)
+	}
+}
+
+func TestBeamSearchBacktracksAtDeadEnd(t *testing.T) {
+	// Only one move is ever possible; asking for depth 3 should still
+	// return the single-move sequence.
+	net := forkNet()
+	s := New(net, nil).WithObjectivePlace("goal")
+
+	path, _, err := s.BeamSearch(net.SetState(nil), 3, 2)
+	if err != nil {
+		t.Fatalf("BeamSearch failed: %v", err)
+	}
+	if len(path) != 1 || path[0] != "win" {
+		t.Errorf("Expected single-move path win, got %v", path)
+	}
+}
+
+func TestBeamSearchErrors(t *testing.T) {
+	net := forkNet()
+	s := New(net, nil).WithObjectivePlace("goal")
+
+	if _, _, err := s.BeamSearch(net.SetState(nil), 0, 2); err == nil {
+		t.Error("Expected error for non-positive depth")
+	}
+	if _, _, err := s.BeamSearch(map[string]float64{"turn": 0}, 2, 2); err == nil {
+		t.Error("Expected error when nothing is enabled")
+	}
+}
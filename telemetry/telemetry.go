@@ -0,0 +1,81 @@
+// Package telemetry provides the observability seams shared by the
+// GraphQL server, the workflow engine, and the monitoring package:
+// OpenTelemetry tracers for spans around transition firing, prediction
+// runs, and store operations, and a pluggable structured logger.
+//
+// Tracing uses the OpenTelemetry API only, so spans are no-ops until the
+// application installs an SDK tracer provider via otel.SetTracerProvider.
+// Logging defaults to a no-op logger; plug in Slog (or any Logger
+// implementation) to route events to the deployment's log pipeline.
+package telemetry
+
+import (
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// scopePrefix names instrumentation scopes after the module, so spans
+// from different go-pflow packages group together in tracing backends.
+const scopePrefix = "github.com/pflow-xyz/go-pflow/"
+
+// Tracer returns the tracer for a go-pflow package (e.g. "workflow").
+// It resolves against the global provider at span-start time, so
+// installing an SDK after construction still takes effect.
+func Tracer(pkg string) trace.Tracer {
+	return otel.Tracer(scopePrefix + pkg)
+}
+
+// EndSpan records err on the span (when non-nil) and ends it. Use it
+// with defer in instrumented methods that name their error return:
+//
+//	defer func() { telemetry.EndSpan(span, err) }()
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Logger is a leveled structured logger. Keyvals are alternating
+// key/value pairs, matching the log/slog convention.
+type Logger interface {
+	Debug(msg string, keyvals ...any)
+	Info(msg string, keyvals ...any)
+	Warn(msg string, keyvals ...any)
+	Error(msg string, keyvals ...any)
+}
+
+// Nop returns a logger that discards everything. Instrumented code holds
+// one by default so logging calls never need a nil check.
+func Nop() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// Slog adapts a *slog.Logger to the Logger interface. A nil argument
+// adapts slog.Default().
+func Slog(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l}
+}
+
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s slogLogger) Debug(msg string, keyvals ...any) { s.l.Debug(msg, keyvals...) }
+func (s slogLogger) Info(msg string, keyvals ...any)  { s.l.Info(msg, keyvals...) }
+func (s slogLogger) Warn(msg string, keyvals ...any)  { s.l.Warn(msg, keyvals...) }
+func (s slogLogger) Error(msg string, keyvals ...any) { s.l.Error(msg, keyvals...) }
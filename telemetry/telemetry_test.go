@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNopLoggerIsSafe(t *testing.T) {
+	l := Nop()
+	l.Debug("debug", "k", 1)
+	l.Info("info")
+	l.Warn("warn", "k", "v")
+	l.Error("error", "err", context.Canceled)
+}
+
+func TestSlogAdapter(t *testing.T) {
+	var buf bytes.Buffer
+	l := Slog(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	})))
+
+	l.Debug("fired", "transition", "enqueue")
+	l.Warn("slow query", "ms", 120)
+
+	out := buf.String()
+	for _, want := range []string{"fired", "transition=enqueue", "slow query", "ms=120"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSlogAdapterNilUsesDefault(t *testing.T) {
+	l := Slog(nil)
+	if l == nil {
+		t.Fatal("Expected a usable logger")
+	}
+	l.Info("ok")
+}
+
+func TestTracerRecordsThroughGlobalProvider(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(prev)
+
+	_, span := Tracer("workflow").Start(context.Background(), "workflow.start_case")
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "workflow.start_case" {
+		t.Errorf("Span name = %q", spans[0].Name())
+	}
+	if scope := spans[0].InstrumentationScope().Name; scope != scopePrefix+"workflow" {
+		t.Errorf("Scope = %q", scope)
+	}
+}
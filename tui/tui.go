@@ -0,0 +1,307 @@
+// Package tui provides reusable terminal dashboard widgets — stat panels,
+// alert feeds, token bars, and progress bars — in the box-drawing style of
+// the incident and coffeeshop simulators. Widgets are driven by monitoring
+// statistics so a new simulator gets a live dashboard for free:
+//
+//	feed := tui.NewAlertFeed(5).Attach(monitor)
+//	dash := tui.NewDashboard("Order Monitor").
+//		Add(tui.MonitorStats(monitor)).
+//		Add(feed).
+//		Add(&tui.TokenBars{Title: "QUEUE DEPTH", State: state})
+//	fmt.Print(dash.Render())
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pflow-xyz/go-pflow/monitoring"
+)
+
+// defaultWidth is the inner width of a dashboard in runes.
+const defaultWidth = 64
+
+// Widget renders one dashboard section as content lines; the dashboard
+// frames them with borders.
+type Widget interface {
+	Render(width int) []string
+}
+
+// Dashboard stacks widgets inside one double-line box, separated by rules.
+type Dashboard struct {
+	Title   string
+	Width   int // inner width in runes (default 64)
+	widgets []Widget
+}
+
+// NewDashboard creates a dashboard with the given header title.
+func NewDashboard(title string) *Dashboard {
+	return &Dashboard{Title: title, Width: defaultWidth}
+}
+
+// Add appends a widget and returns the dashboard for chaining.
+func (d *Dashboard) Add(w Widget) *Dashboard {
+	d.widgets = append(d.widgets, w)
+	return d
+}
+
+// Render draws the complete dashboard.
+func (d *Dashboard) Render() string {
+	width := d.Width
+	if width <= 0 {
+		width = defaultWidth
+	}
+	rule := strings.Repeat("═", width)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "╔%s╗\n", rule)
+	b.WriteString(boxLine(center(d.Title, width), width))
+	for _, w := range d.widgets {
+		fmt.Fprintf(&b, "╠%s╣\n", rule)
+		for _, line := range w.Render(width - 4) {
+			b.WriteString(boxLine("  "+line, width))
+		}
+	}
+	fmt.Fprintf(&b, "╚%s╝\n", rule)
+	return b.String()
+}
+
+// Print writes the dashboard to stdout, typically after clearing the
+// screen with "\033[2J\033[H" for live refresh.
+func (d *Dashboard) Print() {
+	fmt.Print(d.Render())
+}
+
+// --- Stat panel ---
+
+// StatItem is one label/value pair in a stat panel.
+type StatItem struct {
+	Label string
+	Value string
+}
+
+// Stats renders label/value pairs, one per line, values right-aligned.
+type Stats struct {
+	Title string
+	Items []StatItem
+}
+
+// Render implements Widget.
+func (s *Stats) Render(width int) []string {
+	lines := make([]string, 0, len(s.Items)+1)
+	if s.Title != "" {
+		lines = append(lines, s.Title)
+	}
+	for _, item := range s.Items {
+		gap := width - runeLen(item.Label) - runeLen(item.Value)
+		if gap < 1 {
+			gap = 1
+		}
+		lines = append(lines, item.Label+strings.Repeat(" ", gap)+item.Value)
+	}
+	return lines
+}
+
+// MonitorStats builds a stat panel from a monitor's current statistics.
+func MonitorStats(m *monitoring.Monitor) *Stats {
+	stats := m.GetStatistics()
+	return &Stats{
+		Title: "📊 CASES",
+		Items: []StatItem{
+			{"Active", fmt.Sprintf("%d", stats.ActiveCases)},
+			{"Completed", fmt.Sprintf("%d", stats.CompletedCases)},
+			{"Total", fmt.Sprintf("%d", stats.TotalCases)},
+			{"Alerts", fmt.Sprintf("%d", stats.TotalAlerts)},
+		},
+	}
+}
+
+// --- Alert feed ---
+
+// AlertFeed shows the most recent alerts, newest first. Attach registers
+// it as an alert handler so it fills itself.
+type AlertFeed struct {
+	Title string
+	max   int
+
+	mu     sync.Mutex
+	alerts []monitoring.Alert
+}
+
+// NewAlertFeed creates a feed keeping the last max alerts.
+func NewAlertFeed(max int) *AlertFeed {
+	if max <= 0 {
+		max = 5
+	}
+	return &AlertFeed{Title: "🚨 RECENT ALERTS", max: max}
+}
+
+// Attach registers the feed on a monitor and returns it for chaining.
+func (f *AlertFeed) Attach(m *monitoring.Monitor) *AlertFeed {
+	m.AddAlertHandler(f.Push)
+	return f
+}
+
+// Push records one alert; safe for concurrent use.
+func (f *AlertFeed) Push(alert monitoring.Alert) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.alerts = append(f.alerts, alert)
+	if len(f.alerts) > f.max {
+		f.alerts = f.alerts[len(f.alerts)-f.max:]
+	}
+}
+
+// Render implements Widget.
+func (f *AlertFeed) Render(width int) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	lines := []string{f.Title}
+	if len(f.alerts) == 0 {
+		return append(lines, "(none)")
+	}
+	for i := len(f.alerts) - 1; i >= 0; i-- {
+		alert := f.alerts[i]
+		marker := "•"
+		switch alert.Severity {
+		case monitoring.SeverityWarning:
+			marker = "⚠"
+		case monitoring.SeverityCritical:
+			marker = "✗"
+		}
+		lines = append(lines, truncate(fmt.Sprintf("%s %s %s", marker, alert.Timestamp.Format("15:04:05"), alert.Message), width))
+	}
+	return lines
+}
+
+// --- Token bars ---
+
+// TokenBars draws one horizontal bar per place, scaled to the largest
+// value (or Max when set). Places render in sorted order.
+type TokenBars struct {
+	Title string
+	State map[string]float64
+	Max   float64 // bar scale; 0 = scale to the largest value
+}
+
+// Render implements Widget.
+func (t *TokenBars) Render(width int) []string {
+	lines := []string{}
+	if t.Title != "" {
+		lines = append(lines, t.Title)
+	}
+
+	places := make([]string, 0, len(t.State))
+	labelWidth := 0
+	scale := t.Max
+	for place, value := range t.State {
+		places = append(places, place)
+		if runeLen(place) > labelWidth {
+			labelWidth = runeLen(place)
+		}
+		if t.Max == 0 && value > scale {
+			scale = value
+		}
+	}
+	sort.Strings(places)
+	if scale <= 0 {
+		scale = 1
+	}
+
+	barWidth := width - labelWidth - 10
+	if barWidth < 5 {
+		barWidth = 5
+	}
+	for _, place := range places {
+		value := t.State[place]
+		filled := int(value / scale * float64(barWidth))
+		if filled > barWidth {
+			filled = barWidth
+		}
+		if filled < 0 {
+			filled = 0
+		}
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+		lines = append(lines, fmt.Sprintf("%-*s %s %6.1f", labelWidth, place, bar, value))
+	}
+	return lines
+}
+
+// --- Progress bar ---
+
+// Progress draws one labelled progress bar with a percentage.
+type Progress struct {
+	Label    string
+	Fraction float64 // 0..1
+}
+
+// Render implements Widget.
+func (p *Progress) Render(width int) []string {
+	fraction := p.Fraction
+	if fraction < 0 {
+		fraction = 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	barWidth := width - runeLen(p.Label) - 9
+	if barWidth < 5 {
+		barWidth = 5
+	}
+	filled := int(fraction * float64(barWidth))
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return []string{fmt.Sprintf("%s [%s] %3.0f%%", p.Label, bar, fraction*100)}
+}
+
+// --- Text panel ---
+
+// Text is a plain widget for free-form lines.
+type Text struct {
+	Lines []string
+}
+
+// Render implements Widget.
+func (t *Text) Render(width int) []string {
+	out := make([]string, len(t.Lines))
+	for i, line := range t.Lines {
+		out[i] = truncate(line, width)
+	}
+	return out
+}
+
+// --- helpers ---
+
+// boxLine pads content to the inner width and frames it with ║.
+func boxLine(content string, width int) string {
+	content = truncate(content, width)
+	return "║" + content + strings.Repeat(" ", width-runeLen(content)) + "║\n"
+}
+
+func center(s string, width int) string {
+	pad := (width - runeLen(s)) / 2
+	if pad < 0 {
+		pad = 0
+	}
+	return strings.Repeat(" ", pad) + s
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width < 1 {
+		return ""
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// runeLen measures display length in runes. Wide emoji still count as one
+// cell here, matching the approximation the simulators already used.
+func runeLen(s string) int {
+	return len([]rune(s))
+}
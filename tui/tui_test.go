@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/monitoring"
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestDashboardRendersAlignedBox(t *testing.T) {
+	dash := NewDashboard("TEST MONITOR").
+		Add(&Stats{Title: "CASES", Items: []StatItem{{"Active", "3"}, {"Completed", "7"}}}).
+		Add(&Progress{Label: "SLA", Fraction: 0.5}).
+		Add(&TokenBars{Title: "QUEUES", State: map[string]float64{"triage": 4, "done": 8}})
+
+	out := dash.Render()
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+
+	if !strings.HasPrefix(lines[0], "╔") || !strings.HasPrefix(lines[len(lines)-1], "╚") {
+		t.Errorf("Missing box borders:\n%s", out)
+	}
+	// Every line must be the same rune width for the box to align.
+	width := len([]rune(lines[0]))
+	for i, line := range lines {
+		if got := len([]rune(line)); got != width {
+			t.Errorf("Line %d width %d != %d: %q", i, got, width, line)
+		}
+	}
+	for _, want := range []string{"TEST MONITOR", "Active", "SLA", "triage", "█"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMonitorStatsAndAlertFeed(t *testing.T) {
+	net := petri.Build().
+		Place("a", 1).Place("b", 0).
+		Transition("t").
+		Arc("a", "t", 1).Arc("t", "b", 1).
+		Done()
+	config := monitoring.DefaultMonitorConfig()
+	config.EnablePredictions = false
+	config.EnableAlerts = false
+	m := monitoring.NewMonitor(net, nil, config)
+
+	feed := NewAlertFeed(2).Attach(m)
+	m.StartCase("c1", time.Now())
+
+	stats := MonitorStats(m)
+	joined := strings.Join(stats.Render(60), "\n")
+	if !strings.Contains(joined, "Active") || !strings.Contains(joined, "1") {
+		t.Errorf("Stats missing active count:\n%s", joined)
+	}
+
+	// Feed keeps only the newest alerts, newest first.
+	for _, msg := range []string{"first", "second", "third"} {
+		feed.Push(monitoring.Alert{
+			Timestamp: time.Now(),
+			Severity:  monitoring.SeverityWarning,
+			Message:   msg,
+		})
+	}
+	rendered := strings.Join(feed.Render(60), "\n")
+	if strings.Contains(rendered, "first") {
+		t.Errorf("Feed did not evict oldest alert:\n%s", rendered)
+	}
+	if !strings.Contains(rendered, "third") || !strings.Contains(rendered, "second") {
+		t.Errorf("Feed missing recent alerts:\n%s", rendered)
+	}
+	if strings.Index(rendered, "third") > strings.Index(rendered, "second") {
+		t.Errorf("Feed not newest-first:\n%s", rendered)
+	}
+}
+
+func TestTruncateLongContent(t *testing.T) {
+	text := &Text{Lines: []string{strings.Repeat("x", 200)}}
+	dash := NewDashboard("T").Add(text)
+	for _, line := range strings.Split(dash.Render(), "\n") {
+		if len([]rune(line)) > defaultWidth+2 {
+			t.Errorf("Line exceeds box width: %q", line)
+		}
+	}
+}
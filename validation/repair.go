@@ -0,0 +1,213 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// RepairSuggestion proposes a minimal model change that removes deadlocks.
+// Suggestions are evaluated against the reachability graph: each candidate
+// fix is applied to a copy of the net and re-analyzed, so Impact reflects
+// the actual number of deadlock states eliminated.
+type RepairSuggestion struct {
+	Kind            string   `json:"kind"` // "add-token", "relax-weight", "add-arc"
+	Description     string   `json:"description"`
+	Location        []string `json:"location,omitempty"` // Affected places/transitions
+	Impact          int      `json:"impact"`             // Deadlock states eliminated
+	DeadlocksBefore int      `json:"deadlocksBefore"`
+	DeadlocksAfter  int      `json:"deadlocksAfter"`
+}
+
+// repairCandidate is a fix that can be applied to a copy of the net.
+type repairCandidate struct {
+	kind        string
+	description string
+	location    []string
+	apply       func(net *petri.PetriNet)
+}
+
+// SuggestDeadlockRepairs analyzes the net's reachability graph and, if
+// deadlock states exist, proposes fixes ranked by how many deadlocks each
+// one eliminates. Candidates are derived from the token deficiencies of
+// stuck transitions: add initial tokens to a starved place, relax an arc
+// weight to what is actually available, or add a replenishing arc.
+func (v *Validator) SuggestDeadlockRepairs(maxStates int) []RepairSuggestion {
+	baseline := v.AnalyzeReachability(maxStates)
+	if len(baseline.DeadlockStates) == 0 {
+		return nil
+	}
+
+	candidates := v.collectRepairCandidates(maxStates)
+	var suggestions []RepairSuggestion
+	for _, c := range candidates {
+		modified := cloneNet(v.net)
+		c.apply(modified)
+		result := NewValidator(modified).AnalyzeReachability(maxStates)
+		impact := len(baseline.DeadlockStates) - len(result.DeadlockStates)
+		if impact <= 0 {
+			continue
+		}
+		suggestions = append(suggestions, RepairSuggestion{
+			Kind:            c.kind,
+			Description:     c.description,
+			Location:        c.location,
+			Impact:          impact,
+			DeadlocksBefore: len(baseline.DeadlockStates),
+			DeadlocksAfter:  len(result.DeadlockStates),
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Impact > suggestions[j].Impact
+	})
+	return suggestions
+}
+
+// collectRepairCandidates derives candidate fixes from transition deficiencies
+// in deadlock markings.
+func (v *Validator) collectRepairCandidates(maxStates int) []repairCandidate {
+	// Re-explore to get the deadlock markings themselves (the public result
+	// only carries their string keys).
+	deadlockMarkings := v.findDeadlockMarkings(maxStates)
+
+	var candidates []repairCandidate
+	seen := make(map[string]bool)
+	add := func(c repairCandidate) {
+		if !seen[c.description] {
+			seen[c.description] = true
+			candidates = append(candidates, c)
+		}
+	}
+
+	for _, marking := range deadlockMarkings {
+		for transName := range v.net.Transitions {
+			for _, arc := range v.net.Arcs {
+				if arc.Target != transName || arc.InhibitTransition {
+					continue
+				}
+				place, required := arc.Source, arc.GetWeightSum()
+				available := marking[place]
+				deficit := required - available
+				if deficit <= 0 {
+					continue
+				}
+
+				// Fix 1: seed the starved place with more initial tokens.
+				p, trans, n := place, transName, deficit
+				add(repairCandidate{
+					kind:        "add-token",
+					description: fmt.Sprintf("Add %.0f token(s) to place '%s' (needed by '%s')", n, p, trans),
+					location:    []string{p, trans},
+					apply: func(net *petri.PetriNet) {
+						if pl, ok := net.Places[p]; ok {
+							if len(pl.Initial) == 0 {
+								pl.Initial = []float64{n}
+							} else {
+								pl.Initial[0] += n
+							}
+						}
+					},
+				})
+
+				// Fix 2: relax the arc weight to what is actually available.
+				if available > 0 {
+					w := available
+					add(repairCandidate{
+						kind:        "relax-weight",
+						description: fmt.Sprintf("Relax arc %s → %s weight from %.0f to %.0f", p, trans, required, w),
+						location:    []string{p, trans},
+						apply: func(net *petri.PetriNet) {
+							for _, a := range net.Arcs {
+								if a.Source == p && a.Target == trans {
+									a.Weight = []float64{w}
+								}
+							}
+						},
+					})
+				}
+
+				// Fix 3: replenish the starved place from another transition
+				// that already fires (a recycling arc).
+				for otherName := range v.net.Transitions {
+					if otherName == transName || v.hasArc(otherName, place) {
+						continue
+					}
+					other := otherName
+					add(repairCandidate{
+						kind:        "add-arc",
+						description: fmt.Sprintf("Add arc %s → %s (weight 1) to replenish '%s'", other, p, p),
+						location:    []string{other, p},
+						apply: func(net *petri.PetriNet) {
+							net.AddArc(other, p, 1.0, false)
+						},
+					})
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// findDeadlockMarkings re-runs exploration and returns the deadlock markings.
+func (v *Validator) findDeadlockMarkings(maxStates int) []Marking {
+	initial := make(Marking)
+	for name, place := range v.net.Places {
+		initial[name] = place.GetTokenCount()
+	}
+
+	var deadlocks []Marking
+	queue := []Marking{initial}
+	visited := make(map[string]bool)
+
+	for len(queue) > 0 && len(visited) < maxStates {
+		current := queue[0]
+		queue = queue[1:]
+
+		key := current.String()
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		enabled := v.findEnabledTransitions(current)
+		if len(enabled) == 0 && !v.isGoalState(current) {
+			deadlocks = append(deadlocks, current)
+			continue
+		}
+		for _, trans := range enabled {
+			if next := v.fireTransition(current, trans); next != nil {
+				queue = append(queue, next)
+			}
+		}
+	}
+	return deadlocks
+}
+
+// hasArc reports whether an arc source → target already exists.
+func (v *Validator) hasArc(source, target string) bool {
+	for _, arc := range v.net.Arcs {
+		if arc.Source == source && arc.Target == target {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneNet makes a deep copy of a net so candidate fixes don't mutate the original.
+func cloneNet(net *petri.PetriNet) *petri.PetriNet {
+	clone := petri.NewPetriNet()
+	clone.Token = append([]string(nil), net.Token...)
+	for name, p := range net.Places {
+		clone.AddPlace(name, append([]float64(nil), p.Initial...),
+			append([]float64(nil), p.Capacity...), p.X, p.Y, p.LabelText)
+	}
+	for name, t := range net.Transitions {
+		clone.AddTransition(name, t.Role, t.X, t.Y, t.LabelText)
+	}
+	for _, a := range net.Arcs {
+		clone.AddArc(a.Source, a.Target, append([]float64(nil), a.Weight...), a.InhibitTransition)
+	}
+	return clone
+}
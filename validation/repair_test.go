@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// starvedLoop is a net with one known deadlock: burn needs two tokens but
+// fuel starts with one, so nothing ever fires.
+func starvedLoop(fuel float64) *petri.PetriNet {
+	return petri.Build().
+		Place("fuel", fuel).
+		Transition("burn").
+		Arc("fuel", "burn", 2).
+		Arc("burn", "fuel", 2).
+		Done()
+}
+
+func TestSuggestDeadlockRepairs(t *testing.T) {
+	const maxStates = 200
+	suggestions := NewValidator(starvedLoop(1)).SuggestDeadlockRepairs(maxStates)
+	if len(suggestions) == 0 {
+		t.Fatal("Expected repair suggestions for a starved net")
+	}
+
+	// The deficit is one token, so seeding the starved place comes first.
+	best := suggestions[0]
+	if best.Kind != "add-token" {
+		t.Errorf("Expected add-token ranked first, got %+v", best)
+	}
+	if best.DeadlocksBefore != 1 || best.DeadlocksAfter != 0 || best.Impact != 1 {
+		t.Errorf("Expected 1 deadlock fully eliminated, got %+v", best)
+	}
+	if len(best.Location) != 2 || best.Location[0] != "fuel" || best.Location[1] != "burn" {
+		t.Errorf("Unexpected location: %v", best.Location)
+	}
+
+	// Every suggestion reports a real improvement, in ranked order.
+	for i, s := range suggestions {
+		if s.Impact <= 0 {
+			t.Errorf("Suggestion %d has no impact: %+v", i, s)
+		}
+		if i > 0 && s.Impact > suggestions[i-1].Impact {
+			t.Errorf("Suggestions not ranked by impact: %+v", suggestions)
+		}
+	}
+
+	// The reported numbers match a re-analysis of the repaired net.
+	rerun := NewValidator(starvedLoop(2)).AnalyzeReachability(maxStates)
+	if len(rerun.DeadlockStates) != best.DeadlocksAfter {
+		t.Errorf("Repaired net has %d deadlocks, suggestion claimed %d",
+			len(rerun.DeadlockStates), best.DeadlocksAfter)
+	}
+	if best.DeadlocksBefore-len(rerun.DeadlockStates) != best.Impact {
+		t.Errorf("Impact %d does not match re-run (%d before, %d after)",
+			best.Impact, best.DeadlocksBefore, len(rerun.DeadlockStates))
+	}
+}
+
+func TestSuggestDeadlockRepairsNoDeadlock(t *testing.T) {
+	if got := NewValidator(starvedLoop(2)).SuggestDeadlockRepairs(200); got != nil {
+		t.Errorf("Deadlock-free net should yield no suggestions, got %+v", got)
+	}
+}
@@ -0,0 +1,207 @@
+package visualization
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// AnimateOptions configures firing sequence animation.
+type AnimateOptions struct {
+	// FrameSeconds is how long each marking stays visible. Default 1.0.
+	FrameSeconds float64
+	// Loop restarts the animation when it finishes.
+	Loop bool
+}
+
+// FiringFrames replays a firing sequence and renders one SVG per marking:
+// the initial marking first, then the marking after each fired transition.
+// An error reports the first transition that is not enabled, which makes
+// the frames usable for reachability counterexample paths — the output
+// shows exactly how far the path gets.
+func FiringFrames(net *petri.PetriNet, sequence []string) ([]string, error) {
+	marking := make(map[string]float64, len(net.Places))
+	for id, place := range net.Places {
+		marking[id] = place.GetTokenCount()
+	}
+
+	frames := make([]string, 0, len(sequence)+1)
+	frame, err := renderMarking(net, marking)
+	if err != nil {
+		return nil, err
+	}
+	frames = append(frames, frame)
+
+	for i, transition := range sequence {
+		if _, ok := net.Transitions[transition]; !ok {
+			return frames, fmt.Errorf("step %d: unknown transition %q", i+1, transition)
+		}
+		if !firingEnabled(net, transition, marking) {
+			return frames, fmt.Errorf("step %d: transition %q not enabled", i+1, transition)
+		}
+		fireMarking(net, transition, marking)
+
+		frame, err := renderMarking(net, marking)
+		if err != nil {
+			return frames, err
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// AnimateFiring renders a firing sequence as a single animated SVG: frames
+// switch at a fixed interval using SVG's native timing, so the file plays
+// in any browser without scripts.
+func AnimateFiring(net *petri.PetriNet, sequence []string, opts *AnimateOptions) (string, error) {
+	if opts == nil {
+		opts = &AnimateOptions{}
+	}
+	frameSeconds := opts.FrameSeconds
+	if frameSeconds <= 0 {
+		frameSeconds = 1.0
+	}
+
+	frames, err := FiringFrames(net, sequence)
+	if err != nil {
+		return "", err
+	}
+
+	// Measure the outer dimensions from the first frame.
+	width, height := svgDimensions(frames[0])
+	total := float64(len(frames)) * frameSeconds
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\">\n", width, height)
+	for i, frame := range frames {
+		display := "none"
+		if i == 0 {
+			display = "inline"
+		}
+		begin := float64(i) * frameSeconds
+		end := begin + frameSeconds
+
+		fmt.Fprintf(&b, "<g display=\"%s\">\n", display)
+		b.WriteString(stripSVGWrapper(frame))
+		if opts.Loop {
+			fmt.Fprintf(&b, "<set attributeName=\"display\" to=\"inline\" begin=\"%gs; anim0.begin+%gs\" dur=\"%gs\"/>\n",
+				begin, begin, frameSeconds)
+			if i == 0 {
+				// anim0 marks the loop boundary for every frame's begin list.
+				fmt.Fprintf(&b, "<set id=\"anim0\" attributeName=\"display\" to=\"none\" begin=\"%gs\" dur=\"0.001s\"/>\n", total)
+			}
+		} else {
+			fmt.Fprintf(&b, "<set attributeName=\"display\" to=\"inline\" begin=\"%gs\"/>\n", begin)
+			if i < len(frames)-1 {
+				fmt.Fprintf(&b, "<set attributeName=\"display\" to=\"none\" begin=\"%gs\"/>\n", end)
+			}
+		}
+		b.WriteString("</g>\n")
+	}
+	b.WriteString("</svg>\n")
+	return b.String(), nil
+}
+
+// SaveAnimatedSVG renders a firing animation to a file.
+func SaveAnimatedSVG(net *petri.PetriNet, sequence []string, filename string, opts *AnimateOptions) error {
+	svg, err := AnimateFiring(net, sequence, opts)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(svg), 0644)
+}
+
+// renderMarking renders the net with an explicit marking in place of the
+// initial tokens.
+func renderMarking(net *petri.PetriNet, marking map[string]float64) (string, error) {
+	saved := make(map[string][]float64, len(net.Places))
+	for id, place := range net.Places {
+		saved[id] = place.Initial
+		place.Initial = []float64{marking[id]}
+	}
+	defer func() {
+		for id, place := range net.Places {
+			place.Initial = saved[id]
+		}
+	}()
+	return RenderSVG(net)
+}
+
+// firingEnabled checks discrete enablement under the marking.
+func firingEnabled(net *petri.PetriNet, transition string, marking map[string]float64) bool {
+	for _, arc := range net.GetInputArcs(transition) {
+		weight := arc.GetWeightSum()
+		if weight < 1 {
+			weight = 1
+		}
+		if arc.InhibitTransition {
+			if marking[arc.Source] >= weight {
+				return false
+			}
+		} else if marking[arc.Source] < weight {
+			return false
+		}
+	}
+	return true
+}
+
+// fireMarking applies a transition's token moves to the marking.
+func fireMarking(net *petri.PetriNet, transition string, marking map[string]float64) {
+	for _, arc := range net.GetInputArcs(transition) {
+		if arc.InhibitTransition {
+			continue
+		}
+		weight := arc.GetWeightSum()
+		if weight < 1 {
+			weight = 1
+		}
+		marking[arc.Source] -= weight
+	}
+	for _, arc := range net.GetOutputArcs(transition) {
+		weight := arc.GetWeightSum()
+		if weight < 1 {
+			weight = 1
+		}
+		marking[arc.Target] += weight
+	}
+}
+
+// svgDimensions extracts width/height attributes from an SVG document,
+// defaulting to 800x600.
+func svgDimensions(svg string) (string, string) {
+	width, height := "800", "600"
+	if w := extractAttr(svg, "width"); w != "" {
+		width = w
+	}
+	if h := extractAttr(svg, "height"); h != "" {
+		height = h
+	}
+	return width, height
+}
+
+// extractAttr pulls the first occurrence of attr="..." from markup.
+func extractAttr(markup, attr string) string {
+	idx := strings.Index(markup, attr+"=\"")
+	if idx < 0 {
+		return ""
+	}
+	rest := markup[idx+len(attr)+2:]
+	end := strings.Index(rest, "\"")
+	if end < 0 {
+		return ""
+	}
+	return rest[:end]
+}
+
+// stripSVGWrapper removes the outer <svg> element, keeping the content for
+// embedding in another SVG.
+func stripSVGWrapper(svg string) string {
+	start := strings.Index(svg, ">")
+	end := strings.LastIndex(svg, "</svg>")
+	if start < 0 || end < 0 || start+1 > end {
+		return svg
+	}
+	return svg[start+1 : end]
+}
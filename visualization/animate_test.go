@@ -0,0 +1,94 @@
+package visualization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestFiringFrames(t *testing.T) {
+	net := petri.Build().
+		Chain(1, "start", "t1", "middle", "t2", "end").
+		Done()
+
+	frames, err := FiringFrames(net, []string{"t1", "t2"})
+	if err != nil {
+		t.Fatalf("FiringFrames: %v", err)
+	}
+	if len(frames) != 3 {
+		t.Fatalf("Expected 3 frames (initial + 2 firings), got %d", len(frames))
+	}
+	for i, frame := range frames {
+		if !strings.Contains(frame, "<svg") {
+			t.Errorf("Frame %d is not an SVG", i)
+		}
+	}
+	// Rendering must not consume the net's own marking.
+	if net.Places["start"].GetTokenCount() != 1 {
+		t.Error("FiringFrames mutated the net's initial marking")
+	}
+}
+
+func TestFiringFramesDisabledTransition(t *testing.T) {
+	net := petri.Build().
+		Chain(1, "start", "t1", "middle", "t2", "end").
+		Done()
+
+	frames, err := FiringFrames(net, []string{"t2"})
+	if err == nil {
+		t.Fatal("Expected error for disabled transition")
+	}
+	if !strings.Contains(err.Error(), "t2") {
+		t.Errorf("Error should name the transition: %v", err)
+	}
+	// The partial replay still yields the initial frame.
+	if len(frames) != 1 {
+		t.Errorf("Expected 1 frame before failure, got %d", len(frames))
+	}
+}
+
+func TestFiringFramesUnknownTransition(t *testing.T) {
+	net := petri.Build().
+		Chain(1, "start", "t1", "end").
+		Done()
+
+	if _, err := FiringFrames(net, []string{"nope"}); err == nil {
+		t.Fatal("Expected error for unknown transition")
+	}
+}
+
+func TestAnimateFiring(t *testing.T) {
+	net := petri.Build().
+		Chain(1, "start", "t1", "middle", "t2", "end").
+		Done()
+
+	svg, err := AnimateFiring(net, []string{"t1", "t2"}, nil)
+	if err != nil {
+		t.Fatalf("AnimateFiring: %v", err)
+	}
+	if !strings.HasPrefix(svg, "<svg") {
+		t.Fatal("Output is not an SVG document")
+	}
+	if got := strings.Count(svg, "<set "); got < 3 {
+		t.Errorf("Expected timing elements for each frame, found %d", got)
+	}
+	// Exactly one outer document.
+	if strings.Count(svg, "</svg>") != 1 {
+		t.Error("Frames should be embedded, not nested SVG documents")
+	}
+}
+
+func TestAnimateFiringLoop(t *testing.T) {
+	net := petri.Build().
+		Chain(1, "start", "t1", "end").
+		Done()
+
+	svg, err := AnimateFiring(net, []string{"t1"}, &AnimateOptions{FrameSeconds: 0.5, Loop: true})
+	if err != nil {
+		t.Fatalf("AnimateFiring: %v", err)
+	}
+	if !strings.Contains(svg, "anim0.begin") {
+		t.Error("Looping animation should chain frame timing off anim0")
+	}
+}
@@ -0,0 +1,204 @@
+package visualization
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+// ToDOT exports a Petri net in Graphviz DOT format: places as circles
+// (labeled with initial tokens), transitions as boxes, inhibitor arcs with
+// a dot arrowhead. Graphviz then computes its own layout, so hand-set
+// coordinates don't matter.
+func ToDOT(net *petri.PetriNet) string {
+	var b strings.Builder
+	b.WriteString("digraph petri_net {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontsize=10];\n")
+
+	for _, id := range sortedPlaceIDs(net) {
+		place := net.Places[id]
+		label := id
+		if tokens := place.GetTokenCount(); tokens > 0 {
+			label = fmt.Sprintf("%s\\n%g", id, tokens)
+		}
+		fmt.Fprintf(&b, "  %q [shape=circle, label=%q];\n", id, label)
+	}
+	for _, id := range sortedTransitionIDs(net) {
+		trans := net.Transitions[id]
+		label := id
+		if trans.LabelText != nil && *trans.LabelText != "" {
+			label = *trans.LabelText
+		}
+		fmt.Fprintf(&b, "  %q [shape=box, style=filled, fillcolor=lightgray, label=%q];\n", id, label)
+	}
+	for _, arc := range net.Arcs {
+		attrs := make([]string, 0, 2)
+		if w := arc.GetWeightSum(); w > 1 {
+			attrs = append(attrs, fmt.Sprintf("label=\"%g\"", w))
+		}
+		if arc.InhibitTransition {
+			attrs = append(attrs, "arrowhead=odot", "style=dashed")
+		}
+		suffix := ""
+		if len(attrs) > 0 {
+			suffix = " [" + strings.Join(attrs, ", ") + "]"
+		}
+		fmt.Fprintf(&b, "  %q -> %q%s;\n", arc.Source, arc.Target, suffix)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// NeedsLayout reports whether the net's coordinates are unusable for
+// rendering: all zero, or with distinct nodes stacked on the same spot.
+func NeedsLayout(net *petri.PetriNet) bool {
+	type point struct{ x, y float64 }
+	seen := make(map[point]bool)
+	allZero := true
+	check := func(x, y float64) bool {
+		if x != 0 || y != 0 {
+			allZero = false
+		}
+		p := point{x, y}
+		if seen[p] {
+			return true
+		}
+		seen[p] = true
+		return false
+	}
+	for _, place := range net.Places {
+		if check(place.X, place.Y) {
+			return true
+		}
+	}
+	for _, trans := range net.Transitions {
+		if check(trans.X, trans.Y) {
+			return true
+		}
+	}
+	return allZero && len(seen) > 1
+}
+
+// AutoLayout computes a layered (Sugiyama-style) layout and writes x/y
+// positions into the net in place: nodes are layered by graph distance from
+// the sources, then ordered within each layer by the barycenter of their
+// predecessors to reduce crossings. Generated nets with zero or overlapping
+// coordinates become legible; call it before SaveSVG, or let RenderSVG
+// apply it automatically when NeedsLayout.
+func AutoLayout(net *petri.PetriNet) {
+	nodes := append(sortedPlaceIDs(net), sortedTransitionIDs(net)...)
+	if len(nodes) == 0 {
+		return
+	}
+
+	succs := make(map[string][]string)
+	preds := make(map[string][]string)
+	for _, arc := range net.Arcs {
+		succs[arc.Source] = append(succs[arc.Source], arc.Target)
+		preds[arc.Target] = append(preds[arc.Target], arc.Source)
+	}
+
+	// Layer by BFS distance from source nodes (no predecessors). Nets
+	// with cycles and no sources start from the first node.
+	layer := make(map[string]int)
+	var queue []string
+	for _, id := range nodes {
+		if len(preds[id]) == 0 {
+			layer[id] = 0
+			queue = append(queue, id)
+		}
+	}
+	if len(queue) == 0 {
+		layer[nodes[0]] = 0
+		queue = append(queue, nodes[0])
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range succs[id] {
+			if _, visited := layer[next]; !visited {
+				layer[next] = layer[id] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	// Disconnected leftovers go to layer 0.
+	maxLayer := 0
+	for _, id := range nodes {
+		if _, ok := layer[id]; !ok {
+			layer[id] = 0
+		}
+		if layer[id] > maxLayer {
+			maxLayer = layer[id]
+		}
+	}
+
+	// Order each layer, then refine by predecessor barycenters.
+	layers := make([][]string, maxLayer+1)
+	for _, id := range nodes {
+		layers[layer[id]] = append(layers[layer[id]], id)
+	}
+	position := make(map[string]int)
+	for _, ids := range layers {
+		for i, id := range ids {
+			position[id] = i
+		}
+	}
+	for sweep := 0; sweep < 4; sweep++ {
+		for l := 1; l <= maxLayer; l++ {
+			sort.SliceStable(layers[l], func(i, j int) bool {
+				return barycenter(layers[l][i], preds, position) <
+					barycenter(layers[l][j], preds, position)
+			})
+			for i, id := range layers[l] {
+				position[id] = i
+			}
+		}
+	}
+
+	const xSpacing, ySpacing, margin = 160.0, 90.0, 80.0
+	for l, ids := range layers {
+		for i, id := range ids {
+			x := margin + float64(l)*xSpacing
+			y := margin + float64(i)*ySpacing
+			if place, ok := net.Places[id]; ok {
+				place.X, place.Y = x, y
+			} else if trans, ok := net.Transitions[id]; ok {
+				trans.X, trans.Y = x, y
+			}
+		}
+	}
+}
+
+// barycenter averages the positions of a node's predecessors.
+func barycenter(id string, preds map[string][]string, position map[string]int) float64 {
+	if len(preds[id]) == 0 {
+		return float64(position[id])
+	}
+	sum := 0.0
+	for _, p := range preds[id] {
+		sum += float64(position[p])
+	}
+	return sum / float64(len(preds[id]))
+}
+
+func sortedPlaceIDs(net *petri.PetriNet) []string {
+	ids := make([]string, 0, len(net.Places))
+	for id := range net.Places {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedTransitionIDs(net *petri.PetriNet) []string {
+	ids := make([]string, 0, len(net.Transitions))
+	for id := range net.Transitions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,97 @@
+package visualization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func TestToDOT(t *testing.T) {
+	net := petri.Build().
+		Place("buffer", 3).
+		Transition("process").
+		Arc("buffer", "process", 2).
+		InhibitorArc("buffer", "process", 5).
+		Done()
+
+	dot := ToDOT(net)
+	for _, want := range []string{
+		"digraph petri_net {",
+		`"buffer" [shape=circle`,
+		`"process" [shape=box`,
+		`label="2"`,          // arc weight
+		"arrowhead=odot",     // inhibitor styling
+		`label="buffer\\n3"`, // token count in place label
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT missing %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestNeedsLayout(t *testing.T) {
+	zeroed := petri.NewPetriNet()
+	zeroed.AddPlace("a", 1.0, nil, 0, 0, nil)
+	zeroed.AddPlace("b", 0.0, nil, 0, 0, nil)
+	if !NeedsLayout(zeroed) {
+		t.Error("All-zero coordinates should need layout")
+	}
+
+	positioned := petri.NewPetriNet()
+	positioned.AddPlace("a", 1.0, nil, 100, 100, nil)
+	positioned.AddPlace("b", 0.0, nil, 300, 100, nil)
+	if NeedsLayout(positioned) {
+		t.Error("Distinct coordinates should not need layout")
+	}
+
+	stacked := petri.NewPetriNet()
+	stacked.AddPlace("a", 1.0, nil, 100, 100, nil)
+	stacked.AddPlace("b", 0.0, nil, 100, 100, nil)
+	if !NeedsLayout(stacked) {
+		t.Error("Overlapping coordinates should need layout")
+	}
+}
+
+func TestAutoLayoutLayersByDepth(t *testing.T) {
+	net := petri.NewPetriNet()
+	net.AddPlace("start", 1.0, nil, 0, 0, nil)
+	net.AddPlace("end", 0.0, nil, 0, 0, nil)
+	net.AddTransition("work", "default", 0, 0, nil)
+	net.AddArc("start", "work", 1.0, false)
+	net.AddArc("work", "end", 1.0, false)
+
+	AutoLayout(net)
+
+	start := net.Places["start"]
+	end := net.Places["end"]
+	work := net.Transitions["work"]
+	if !(start.X < work.X && work.X < end.X) {
+		t.Errorf("Layers out of order: start=%.0f work=%.0f end=%.0f",
+			start.X, work.X, end.X)
+	}
+	// No two nodes share a position anymore.
+	if NeedsLayout(net) {
+		t.Error("Layout left overlapping nodes")
+	}
+}
+
+func TestRenderSVGAppliesLayout(t *testing.T) {
+	net := petri.NewPetriNet()
+	net.AddPlace("a", 1.0, nil, 0, 0, nil)
+	net.AddPlace("b", 0.0, nil, 0, 0, nil)
+	net.AddTransition("t", "default", 0, 0, nil)
+	net.AddArc("a", "t", 1.0, false)
+	net.AddArc("t", "b", 1.0, false)
+
+	svg, err := RenderSVG(net)
+	if err != nil {
+		t.Fatalf("RenderSVG: %v", err)
+	}
+	if svg == "" {
+		t.Fatal("Empty SVG")
+	}
+	if net.Places["a"].X == 0 && net.Places["b"].X == 0 {
+		t.Error("RenderSVG should have applied automatic layout")
+	}
+}
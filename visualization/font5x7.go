@@ -0,0 +1,118 @@
+package visualization
+
+// font5x7 is a minimal 5x7 bitmap font for PNG label rendering: each glyph
+// is seven rows of five pixels, packed into the low bits of a byte with the
+// leftmost column at bit 4. Only the characters our renderers emit in labels
+// are covered; unknown runes fall back to '?'.
+var font5x7 = map[rune][7]byte{}
+
+func init() {
+	// Glyph art is authored as strings so errors are visible at a glance,
+	// then packed once at startup.
+	art := map[rune][7]string{
+		' ':  {"     ", "     ", "     ", "     ", "     ", "     ", "     "},
+		'!':  {"  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "     ", "  #  "},
+		'"':  {" # # ", " # # ", "     ", "     ", "     ", "     ", "     "},
+		'#':  {" # # ", "#####", " # # ", " # # ", " # # ", "#####", " # # "},
+		'%':  {"##   ", "##  #", "   # ", "  #  ", " #   ", "#  ##", "   ##"},
+		'&':  {" ##  ", "#  # ", "#  # ", " ##  ", "#  ##", "#  # ", " ## #"},
+		'\'': {"  #  ", "  #  ", "     ", "     ", "     ", "     ", "     "},
+		'(':  {"   # ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "   # "},
+		')':  {" #   ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", " #   "},
+		'*':  {"     ", "# # #", " ### ", "#####", " ### ", "# # #", "     "},
+		'+':  {"     ", "  #  ", "  #  ", "#####", "  #  ", "  #  ", "     "},
+		',':  {"     ", "     ", "     ", "     ", "     ", "  #  ", " #   "},
+		'-':  {"     ", "     ", "     ", "#####", "     ", "     ", "     "},
+		'.':  {"     ", "     ", "     ", "     ", "     ", "     ", "  #  "},
+		'/':  {"    #", "    #", "   # ", "  #  ", " #   ", "#    ", "#    "},
+		'0':  {" ### ", "#   #", "#  ##", "# # #", "##  #", "#   #", " ### "},
+		'1':  {"  #  ", " ##  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+		'2':  {" ### ", "#   #", "    #", "   # ", "  #  ", " #   ", "#####"},
+		'3':  {" ### ", "#   #", "    #", "  ## ", "    #", "#   #", " ### "},
+		'4':  {"   # ", "  ## ", " # # ", "#  # ", "#####", "   # ", "   # "},
+		'5':  {"#####", "#    ", "#### ", "    #", "    #", "#   #", " ### "},
+		'6':  {" ### ", "#    ", "#    ", "#### ", "#   #", "#   #", " ### "},
+		'7':  {"#####", "    #", "   # ", "  #  ", "  #  ", "  #  ", "  #  "},
+		'8':  {" ### ", "#   #", "#   #", " ### ", "#   #", "#   #", " ### "},
+		'9':  {" ### ", "#   #", "#   #", " ####", "    #", "    #", " ### "},
+		':':  {"     ", "  #  ", "     ", "     ", "     ", "  #  ", "     "},
+		';':  {"     ", "  #  ", "     ", "     ", "  #  ", " #   ", "     "},
+		'<':  {"   # ", "  #  ", " #   ", "#    ", " #   ", "  #  ", "   # "},
+		'=':  {"     ", "     ", "#####", "     ", "#####", "     ", "     "},
+		'>':  {" #   ", "  #  ", "   # ", "    #", "   # ", "  #  ", " #   "},
+		'?':  {" ### ", "#   #", "    #", "   # ", "  #  ", "     ", "  #  "},
+		'@':  {" ### ", "#   #", "# ###", "# # #", "# ###", "#    ", " ### "},
+		'A':  {" ### ", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+		'B':  {"#### ", "#   #", "#   #", "#### ", "#   #", "#   #", "#### "},
+		'C':  {" ### ", "#   #", "#    ", "#    ", "#    ", "#   #", " ### "},
+		'D':  {"#### ", "#   #", "#   #", "#   #", "#   #", "#   #", "#### "},
+		'E':  {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#####"},
+		'F':  {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#    "},
+		'G':  {" ### ", "#   #", "#    ", "# ###", "#   #", "#   #", " ####"},
+		'H':  {"#   #", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+		'I':  {" ### ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+		'J':  {"  ###", "   # ", "   # ", "   # ", "   # ", "#  # ", " ##  "},
+		'K':  {"#   #", "#  # ", "# #  ", "##   ", "# #  ", "#  # ", "#   #"},
+		'L':  {"#    ", "#    ", "#    ", "#    ", "#    ", "#    ", "#####"},
+		'M':  {"#   #", "## ##", "# # #", "# # #", "#   #", "#   #", "#   #"},
+		'N':  {"#   #", "##  #", "# # #", "#  ##", "#   #", "#   #", "#   #"},
+		'O':  {" ### ", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+		'P':  {"#### ", "#   #", "#   #", "#### ", "#    ", "#    ", "#    "},
+		'Q':  {" ### ", "#   #", "#   #", "#   #", "# # #", "#  # ", " ## #"},
+		'R':  {"#### ", "#   #", "#   #", "#### ", "# #  ", "#  # ", "#   #"},
+		'S':  {" ####", "#    ", "#    ", " ### ", "    #", "    #", "#### "},
+		'T':  {"#####", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  "},
+		'U':  {"#   #", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+		'V':  {"#   #", "#   #", "#   #", "#   #", "#   #", " # # ", "  #  "},
+		'W':  {"#   #", "#   #", "#   #", "# # #", "# # #", "# # #", " # # "},
+		'X':  {"#   #", "#   #", " # # ", "  #  ", " # # ", "#   #", "#   #"},
+		'Y':  {"#   #", "#   #", " # # ", "  #  ", "  #  ", "  #  ", "  #  "},
+		'Z':  {"#####", "    #", "   # ", "  #  ", " #   ", "#    ", "#####"},
+		'[':  {" ### ", " #   ", " #   ", " #   ", " #   ", " #   ", " ### "},
+		'\\': {"#    ", "#    ", " #   ", "  #  ", "   # ", "    #", "    #"},
+		']':  {" ### ", "   # ", "   # ", "   # ", "   # ", "   # ", " ### "},
+		'_':  {"     ", "     ", "     ", "     ", "     ", "     ", "#####"},
+		'a':  {"     ", "     ", " ### ", "    #", " ####", "#   #", " ####"},
+		'b':  {"#    ", "#    ", "#### ", "#   #", "#   #", "#   #", "#### "},
+		'c':  {"     ", "     ", " ####", "#    ", "#    ", "#    ", " ####"},
+		'd':  {"    #", "    #", " ####", "#   #", "#   #", "#   #", " ####"},
+		'e':  {"     ", "     ", " ### ", "#   #", "#####", "#    ", " ### "},
+		'f':  {"  ## ", " #   ", "###  ", " #   ", " #   ", " #   ", " #   "},
+		'g':  {"     ", " ####", "#   #", "#   #", " ####", "    #", " ### "},
+		'h':  {"#    ", "#    ", "#### ", "#   #", "#   #", "#   #", "#   #"},
+		'i':  {"  #  ", "     ", " ##  ", "  #  ", "  #  ", "  #  ", " ### "},
+		'j':  {"   # ", "     ", "  ## ", "   # ", "   # ", "#  # ", " ##  "},
+		'k':  {"#    ", "#    ", "#  # ", "# #  ", "##   ", "# #  ", "#  # "},
+		'l':  {" ##  ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+		'm':  {"     ", "     ", "## # ", "# # #", "# # #", "# # #", "# # #"},
+		'n':  {"     ", "     ", "#### ", "#   #", "#   #", "#   #", "#   #"},
+		'o':  {"     ", "     ", " ### ", "#   #", "#   #", "#   #", " ### "},
+		'p':  {"     ", "#### ", "#   #", "#   #", "#### ", "#    ", "#    "},
+		'q':  {"     ", " ####", "#   #", "#   #", " ####", "    #", "    #"},
+		'r':  {"     ", "     ", "# ## ", "##   ", "#    ", "#    ", "#    "},
+		's':  {"     ", "     ", " ####", "#    ", " ### ", "    #", "#### "},
+		't':  {" #   ", " #   ", "###  ", " #   ", " #   ", " #   ", "  ## "},
+		'u':  {"     ", "     ", "#   #", "#   #", "#   #", "#   #", " ####"},
+		'v':  {"     ", "     ", "#   #", "#   #", "#   #", " # # ", "  #  "},
+		'w':  {"     ", "     ", "#   #", "#   #", "# # #", "# # #", " # # "},
+		'x':  {"     ", "     ", "#   #", " # # ", "  #  ", " # # ", "#   #"},
+		'y':  {"     ", "#   #", "#   #", "#   #", " ####", "    #", " ### "},
+		'z':  {"     ", "     ", "#####", "   # ", "  #  ", " #   ", "#####"},
+		'{':  {"   ##", "  #  ", "  #  ", " #   ", "  #  ", "  #  ", "   ##"},
+		'|':  {"  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  "},
+		'}':  {"##   ", "  #  ", "  #  ", "   # ", "  #  ", "  #  ", "##   "},
+	}
+	for ch, rows := range art {
+		var glyph [7]byte
+		for row, line := range rows {
+			var bits byte
+			for col := 0; col < 5 && col < len(line); col++ {
+				if line[col] == '#' {
+					bits |= 1 << (4 - col)
+				}
+			}
+			glyph[row] = bits
+		}
+		font5x7[ch] = glyph
+	}
+}
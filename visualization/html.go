@@ -0,0 +1,249 @@
+package visualization
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// HTMLOptions configures interactive HTML export.
+type HTMLOptions struct {
+	Title string // page title; defaults to "Petri Net"
+
+	// Solution embeds a token animation sampled from an ODE solution;
+	// Frames caps how many samples are taken (default 100).
+	Solution *solver.Solution
+	Frames   int
+
+	// FrameMillis is the playback delay between animation frames
+	// (default 100ms).
+	FrameMillis int
+}
+
+// RenderHTML emits a self-contained interactive page for a net: pan and
+// zoom with mouse drag and wheel, hover tooltips with place/transition
+// metadata, and an optional embedded token animation from a Solution. No
+// external assets — the page works from a file:// URL.
+func RenderHTML(net *petri.PetriNet, opts *HTMLOptions) (string, error) {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+	title := opts.Title
+	if title == "" {
+		title = "Petri Net"
+	}
+
+	svgString, err := RenderSVG(net)
+	if err != nil {
+		return "", err
+	}
+
+	nodes, err := json.Marshal(nodeMetadata(net))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal node metadata: %w", err)
+	}
+
+	frames, err := json.Marshal(animationFrames(opts))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal animation frames: %w", err)
+	}
+
+	frameMillis := opts.FrameMillis
+	if frameMillis <= 0 {
+		frameMillis = 100
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", escapeXML(title))
+	b.WriteString(htmlStyle)
+	b.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", escapeXML(title))
+	b.WriteString("<div id=\"toolbar\"><button id=\"reset\">Reset view</button>")
+	b.WriteString("<span id=\"controls\" style=\"display:none\"><button id=\"play\">Play</button> <input id=\"scrub\" type=\"range\" min=\"0\" value=\"0\"> <span id=\"clock\"></span></span></div>\n")
+	b.WriteString("<div id=\"viewport\"><div id=\"canvas\">")
+	b.WriteString(svgString)
+	b.WriteString("</div></div>\n<div id=\"tooltip\"></div>\n")
+	fmt.Fprintf(&b, "<script>\nconst nodes = %s;\nconst frames = %s;\nconst frameMillis = %d;\n%s</script>\n",
+		nodes, frames, frameMillis, htmlScript)
+	b.WriteString("</body>\n</html>\n")
+	return b.String(), nil
+}
+
+// SaveHTML renders the interactive page and writes it to a file.
+func SaveHTML(net *petri.PetriNet, filename string, opts *HTMLOptions) error {
+	html, err := RenderHTML(net, opts)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(html), 0644)
+}
+
+// htmlNode is per-node metadata surfaced by the hover tooltip.
+type htmlNode struct {
+	ID     string  `json:"id"`
+	Kind   string  `json:"kind"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Tokens float64 `json:"tokens,omitempty"`
+	Label  string  `json:"label,omitempty"`
+	Role   string  `json:"role,omitempty"`
+}
+
+func nodeMetadata(net *petri.PetriNet) []htmlNode {
+	nodes := make([]htmlNode, 0, len(net.Places)+len(net.Transitions))
+	for id, place := range net.Places {
+		n := htmlNode{ID: id, Kind: "place", X: place.X, Y: place.Y, Tokens: place.GetTokenCount()}
+		if place.LabelText != nil {
+			n.Label = *place.LabelText
+		}
+		nodes = append(nodes, n)
+	}
+	for id, trans := range net.Transitions {
+		n := htmlNode{ID: id, Kind: "transition", X: trans.X, Y: trans.Y, Role: trans.Role}
+		if trans.LabelText != nil {
+			n.Label = *trans.LabelText
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// htmlFrame is one animation step: a time label and the marking.
+type htmlFrame struct {
+	T     float64            `json:"t"`
+	State map[string]float64 `json:"state"`
+}
+
+func animationFrames(opts *HTMLOptions) []htmlFrame {
+	sol := opts.Solution
+	if sol == nil || len(sol.T) == 0 {
+		return nil
+	}
+	maxFrames := opts.Frames
+	if maxFrames <= 0 {
+		maxFrames = 100
+	}
+	step := 1
+	if len(sol.T) > maxFrames {
+		step = len(sol.T) / maxFrames
+	}
+	frames := make([]htmlFrame, 0, maxFrames)
+	for i := 0; i < len(sol.T); i += step {
+		frames = append(frames, htmlFrame{T: sol.T[i], State: sol.U[i]})
+	}
+	return frames
+}
+
+const htmlStyle = `<style>
+body { font-family: sans-serif; margin: 1em; }
+#viewport { border: 1px solid #ccc; overflow: hidden; width: 100%; height: 70vh; cursor: grab; position: relative; }
+#canvas { transform-origin: 0 0; }
+#tooltip { position: fixed; display: none; background: #222; color: #fff; padding: 4px 8px; border-radius: 4px; font-size: 12px; pointer-events: none; z-index: 10; }
+#toolbar { margin-bottom: 0.5em; }
+</style>
+`
+
+const htmlScript = `
+const viewport = document.getElementById('viewport');
+const canvas = document.getElementById('canvas');
+const tooltip = document.getElementById('tooltip');
+let scale = 1, panX = 0, panY = 0, dragging = false, lastX = 0, lastY = 0;
+
+function apply() {
+  canvas.style.transform = 'translate(' + panX + 'px,' + panY + 'px) scale(' + scale + ')';
+}
+viewport.addEventListener('wheel', e => {
+  e.preventDefault();
+  const factor = e.deltaY < 0 ? 1.1 : 1 / 1.1;
+  const rect = viewport.getBoundingClientRect();
+  const mx = e.clientX - rect.left, my = e.clientY - rect.top;
+  panX = mx - (mx - panX) * factor;
+  panY = my - (my - panY) * factor;
+  scale *= factor;
+  apply();
+});
+viewport.addEventListener('mousedown', e => { dragging = true; lastX = e.clientX; lastY = e.clientY; viewport.style.cursor = 'grabbing'; });
+window.addEventListener('mouseup', () => { dragging = false; viewport.style.cursor = 'grab'; });
+window.addEventListener('mousemove', e => {
+  if (dragging) {
+    panX += e.clientX - lastX; panY += e.clientY - lastY;
+    lastX = e.clientX; lastY = e.clientY;
+    apply();
+    return;
+  }
+  // Tooltip: nearest node within 25px (model coordinates).
+  const rect = viewport.getBoundingClientRect();
+  const mx = (e.clientX - rect.left - panX) / scale;
+  const my = (e.clientY - rect.top - panY) / scale;
+  let best = null, bestD = 25 * 25;
+  for (const n of nodes) {
+    const d = (n.x - mx) * (n.x - mx) + (n.y - my) * (n.y - my);
+    if (d < bestD) { best = n; bestD = d; }
+  }
+  if (best) {
+    let text = best.kind + ' ' + best.id;
+    if (best.label) text += ' (' + best.label + ')';
+    if (best.kind === 'place') {
+      const tokens = currentState && best.id in currentState ? currentState[best.id] : best.tokens || 0;
+      text += ' — tokens: ' + (+tokens).toFixed(2);
+    }
+    if (best.role && best.role !== 'default') text += ' — role: ' + best.role;
+    tooltip.textContent = text;
+    tooltip.style.display = 'block';
+    tooltip.style.left = (e.clientX + 12) + 'px';
+    tooltip.style.top = (e.clientY + 12) + 'px';
+  } else {
+    tooltip.style.display = 'none';
+  }
+});
+document.getElementById('reset').addEventListener('click', () => { scale = 1; panX = 0; panY = 0; apply(); });
+
+// Token animation from the embedded solution, if any.
+let currentState = null;
+if (frames && frames.length > 0) {
+  const controls = document.getElementById('controls');
+  const play = document.getElementById('play');
+  const scrub = document.getElementById('scrub');
+  const clock = document.getElementById('clock');
+  controls.style.display = '';
+  scrub.max = frames.length - 1;
+  let timer = null;
+
+  function show(i) {
+    const frame = frames[i];
+    currentState = frame.state;
+    scrub.value = i;
+    clock.textContent = 't = ' + frame.t.toFixed(2);
+    // Update token count text inside place circles, matched by position.
+    const svg = canvas.querySelector('svg');
+    if (!svg) return;
+    for (const n of nodes) {
+      if (n.kind !== 'place') continue;
+      for (const el of svg.querySelectorAll('text')) {
+        const x = parseFloat(el.getAttribute('x')), y = parseFloat(el.getAttribute('y'));
+        if (Math.abs(x - n.x) < 6 && Math.abs(y - n.y) < 12 && /^[0-9.]+$/.test(el.textContent)) {
+          el.textContent = Math.round(frame.state[n.id] || 0);
+        }
+      }
+    }
+  }
+  play.addEventListener('click', () => {
+    if (timer) { clearInterval(timer); timer = null; play.textContent = 'Play'; return; }
+    play.textContent = 'Pause';
+    let i = +scrub.value;
+    timer = setInterval(() => {
+      i++;
+      if (i >= frames.length) { clearInterval(timer); timer = null; play.textContent = 'Play'; return; }
+      show(i);
+    }, frameMillis);
+  });
+  scrub.addEventListener('input', () => show(+scrub.value));
+  show(0);
+}
+apply();
+`
@@ -0,0 +1,76 @@
+package visualization
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+func TestRenderHTML(t *testing.T) {
+	net := petri.Build().
+		Chain(3, "start", "go", "end").
+		Done()
+
+	html, err := RenderHTML(net, &HTMLOptions{Title: "Demo Net"})
+	if err != nil {
+		t.Fatalf("RenderHTML: %v", err)
+	}
+
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<title>Demo Net</title>",
+		"<svg",
+		"const nodes =",
+		"addEventListener('wheel'", // pan/zoom wiring
+		"\"id\":\"start\"",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+	// Self-contained: no external scripts or stylesheets.
+	if strings.Contains(html, "http://") || strings.Contains(html, "https://") {
+		// The SVG renderer embeds pflow.xyz token URLs; only flag
+		// script/link references.
+		if strings.Contains(html, "<script src") || strings.Contains(html, "<link") {
+			t.Error("HTML references external assets")
+		}
+	}
+}
+
+func TestRenderHTMLWithAnimation(t *testing.T) {
+	net, rates := petri.Build().SIR(99, 1, 0).WithRates(1.0)
+	prob := solver.NewProblem(net, net.SetState(nil), [2]float64{0, 10}, rates)
+	sol := solver.Solve(prob, solver.Tsit5(), solver.FastOptions())
+
+	html, err := RenderHTML(net, &HTMLOptions{Solution: sol, Frames: 20})
+	if err != nil {
+		t.Fatalf("RenderHTML: %v", err)
+	}
+	if !strings.Contains(html, "const frames = [") || strings.Contains(html, "const frames = null") {
+		t.Error("Animation frames not embedded")
+	}
+	if !strings.Contains(html, "\"t\":") {
+		t.Error("Frames missing time labels")
+	}
+}
+
+func TestSaveHTML(t *testing.T) {
+	net := petri.Build().Chain(1, "a", "t", "b").Done()
+	path := filepath.Join(t.TempDir(), "net.html")
+
+	if err := SaveHTML(net, path, nil); err != nil {
+		t.Fatalf("SaveHTML: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "</html>") {
+		t.Error("Saved file incomplete")
+	}
+}
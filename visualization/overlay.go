@@ -0,0 +1,131 @@
+package visualization
+
+import (
+	"os"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+// SolutionOverlayOptions configures how a solver.Solution is mapped onto the
+// net diagram.
+type SolutionOverlayOptions struct {
+	// UseAverage colors places by time-averaged token count instead of
+	// the final state.
+	UseAverage bool
+}
+
+// SolutionHeat converts a simulation result into a heat map compatible with
+// RenderHeatmapSVG: places get their final (or time-averaged) token count,
+// transitions their cumulative mass-action flux over the trajectory, each
+// normalized to [0,1] within its node kind. rates are the transition rates
+// the problem was solved with; pass nil to skip transition intensities.
+func SolutionHeat(net *petri.PetriNet, sol *solver.Solution, rates map[string]float64, opts *SolutionOverlayOptions) map[string]float64 {
+	if opts == nil {
+		opts = &SolutionOverlayOptions{}
+	}
+	heat := make(map[string]float64)
+	if sol == nil || len(sol.T) == 0 {
+		return heat
+	}
+
+	// Place values: final state, or trapezoidal time average.
+	placeValue := make(map[string]float64, len(net.Places))
+	maxPlace := 0.0
+	for label := range net.Places {
+		var v float64
+		if opts.UseAverage {
+			v = timeAverage(sol, label)
+		} else {
+			v = sol.U[len(sol.U)-1][label]
+		}
+		// ODE trajectories can undershoot zero numerically.
+		if v < 0 {
+			v = 0
+		}
+		placeValue[label] = v
+		if v > maxPlace {
+			maxPlace = v
+		}
+	}
+	if maxPlace > 0 {
+		for label, v := range placeValue {
+			heat[label] = v / maxPlace
+		}
+	}
+
+	// Transition values: cumulative flux, integrated by trapezoid.
+	if rates == nil {
+		return heat
+	}
+	transValue := make(map[string]float64, len(net.Transitions))
+	maxTrans := 0.0
+	for label := range net.Transitions {
+		total := 0.0
+		prev := massActionFlux(net, label, rates, sol.U[0])
+		for i := 1; i < len(sol.T); i++ {
+			next := massActionFlux(net, label, rates, sol.U[i])
+			total += (prev + next) / 2 * (sol.T[i] - sol.T[i-1])
+			prev = next
+		}
+		transValue[label] = total
+		if total > maxTrans {
+			maxTrans = total
+		}
+	}
+	if maxTrans > 0 {
+		for label, v := range transValue {
+			heat[label] = v / maxTrans
+		}
+	}
+	return heat
+}
+
+// RenderSolutionSVG renders the net with simulation results overlaid as a
+// heat map: places colored by token count, transitions by cumulative flux.
+func RenderSolutionSVG(net *petri.PetriNet, sol *solver.Solution, rates map[string]float64, opts *SolutionOverlayOptions) (string, error) {
+	return RenderHeatmapSVG(net, SolutionHeat(net, sol, rates, opts))
+}
+
+// SaveSolutionSVG renders a simulation overlay and saves it to a file.
+func SaveSolutionSVG(net *petri.PetriNet, sol *solver.Solution, rates map[string]float64, filename string, opts *SolutionOverlayOptions) error {
+	svgString, err := RenderSolutionSVG(net, sol, rates, opts)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(svgString), 0644)
+}
+
+// timeAverage integrates a place's trajectory by trapezoid and divides by
+// the time span.
+func timeAverage(sol *solver.Solution, label string) float64 {
+	if len(sol.T) < 2 {
+		return sol.U[0][label]
+	}
+	total := 0.0
+	for i := 1; i < len(sol.T); i++ {
+		total += (sol.U[i-1][label] + sol.U[i][label]) / 2 * (sol.T[i] - sol.T[i-1])
+	}
+	span := sol.T[len(sol.T)-1] - sol.T[0]
+	if span <= 0 {
+		return sol.U[0][label]
+	}
+	return total / span
+}
+
+// massActionFlux mirrors the solver's simplified mass-action kinetics: the
+// rate times the product of input place states, zero if any input is empty.
+func massActionFlux(net *petri.PetriNet, transition string, rates map[string]float64, u map[string]float64) float64 {
+	flux := rates[transition]
+	for _, arc := range net.GetInputArcs(transition) {
+		if arc.InhibitTransition {
+			continue
+		}
+		state := u[arc.Source]
+		if state <= 0 {
+			return 0
+		}
+		flux *= state
+	}
+	return flux
+}
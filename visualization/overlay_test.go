@@ -0,0 +1,76 @@
+package visualization
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/solver"
+)
+
+func sirSolution() (*petri.PetriNet, map[string]float64, *solver.Solution) {
+	net, rates := petri.Build().SIR(999, 1, 0).WithRates(1.0)
+	prob := solver.NewProblem(net, net.SetState(nil), [2]float64{0, 10}, rates)
+	sol := solver.Solve(prob, solver.Tsit5(), solver.DefaultOptions())
+	return net, rates, sol
+}
+
+func TestSolutionHeat(t *testing.T) {
+	net, rates, sol := sirSolution()
+
+	heat := SolutionHeat(net, sol, rates, nil)
+	for id := range net.Places {
+		v, ok := heat[id]
+		if !ok {
+			t.Errorf("No heat for place %s", id)
+		}
+		if v < 0 || v > 1 {
+			t.Errorf("Place %s intensity out of range: %g", id, v)
+		}
+	}
+	for id := range net.Transitions {
+		if _, ok := heat[id]; !ok {
+			t.Errorf("No heat for transition %s", id)
+		}
+	}
+	// By t=10 the epidemic has burned through: recovered dominates.
+	if heat["R"] != 1 {
+		t.Errorf("Expected R to be the hottest place, got %g", heat["R"])
+	}
+}
+
+func TestSolutionHeatAverage(t *testing.T) {
+	net, rates, sol := sirSolution()
+
+	final := SolutionHeat(net, sol, rates, nil)
+	avg := SolutionHeat(net, sol, rates, &SolutionOverlayOptions{UseAverage: true})
+
+	// Averaging over the whole run keeps the susceptible pool warmer than
+	// its near-zero final value.
+	if avg["S"] <= final["S"] {
+		t.Errorf("Expected time-averaged S (%g) above final S (%g)", avg["S"], final["S"])
+	}
+}
+
+func TestSolutionHeatNilRates(t *testing.T) {
+	net, _, sol := sirSolution()
+
+	heat := SolutionHeat(net, sol, nil, nil)
+	for id := range net.Transitions {
+		if _, ok := heat[id]; ok {
+			t.Errorf("Transition %s should have no intensity without rates", id)
+		}
+	}
+}
+
+func TestRenderSolutionSVG(t *testing.T) {
+	net, rates, sol := sirSolution()
+
+	svg, err := RenderSolutionSVG(net, sol, rates, nil)
+	if err != nil {
+		t.Fatalf("RenderSolutionSVG: %v", err)
+	}
+	if !strings.Contains(svg, "class=\"heatmap\"") {
+		t.Error("Overlay group missing from SVG")
+	}
+}
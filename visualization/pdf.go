@@ -0,0 +1,244 @@
+package visualization
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/statemachine"
+	"github.com/pflow-xyz/go-pflow/workflow"
+)
+
+// SVGToPDF converts a rendered SVG document into a single-page PDF with
+// vector drawing operators and Helvetica text — pure Go, no external
+// binaries. Like SVGToPNG it covers the SVG subset our renderers emit.
+func SVGToPDF(svg string) ([]byte, error) {
+	doc, err := parseSVG(svg)
+	if err != nil {
+		return nil, err
+	}
+
+	var content bytes.Buffer
+	for _, shape := range doc.shapes {
+		writePDFShape(&content, doc, &shape)
+	}
+	return assemblePDF(doc.width, doc.height, content.Bytes()), nil
+}
+
+// SavePDF renders a Petri net to a PDF file.
+func SavePDF(net *petri.PetriNet, filename string) error {
+	svg, err := RenderSVG(net)
+	if err != nil {
+		return err
+	}
+	data, err := SVGToPDF(svg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// SaveWorkflowPDF renders a workflow diagram to a PDF file.
+func SaveWorkflowPDF(wf *workflow.Workflow, filename string, opts *WorkflowSVGOptions) error {
+	svg, err := RenderWorkflowSVG(wf, opts)
+	if err != nil {
+		return err
+	}
+	data, err := SVGToPDF(svg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// SaveStateMachinePDF renders a statechart diagram to a PDF file.
+func SaveStateMachinePDF(chart *statemachine.Chart, filename string, opts *StateMachineSVGOptions) error {
+	svg, err := RenderStateMachineSVG(chart, opts)
+	if err != nil {
+		return err
+	}
+	data, err := SVGToPDF(svg)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// pdfXY converts SVG document coordinates to PDF page coordinates, which
+// have the origin at the bottom-left.
+func pdfXY(doc *svgDoc, x, y float64) (float64, float64) {
+	return x - doc.minX, doc.height - (y - doc.minY)
+}
+
+func writePDFShape(w *bytes.Buffer, doc *svgDoc, s *svgShape) {
+	setColors := func() (fill, stroke bool) {
+		if s.fill != "" {
+			r, g, b := parseRGB(s.fill)
+			// Emulate fill-opacity by blending toward white: our overlays
+			// draw on light backgrounds and PDF graphics state dictionaries
+			// would be overkill here.
+			blend := func(c uint8) float64 {
+				return (float64(c)*s.fillOpacity + 255*(1-s.fillOpacity)) / 255
+			}
+			fmt.Fprintf(w, "%.3f %.3f %.3f rg\n", blend(r), blend(g), blend(b))
+			fill = true
+		}
+		if s.stroke != "" {
+			r, g, b := parseRGB(s.stroke)
+			fmt.Fprintf(w, "%.3f %.3f %.3f RG\n%.2f w\n",
+				float64(r)/255, float64(g)/255, float64(b)/255, s.strokeWidth)
+			stroke = true
+		}
+		return fill, stroke
+	}
+	paint := func(fill, stroke, closed bool) {
+		switch {
+		case fill && stroke:
+			w.WriteString("B\n")
+		case fill:
+			w.WriteString("f\n")
+		case stroke && closed:
+			w.WriteString("s\n")
+		case stroke:
+			w.WriteString("S\n")
+		}
+	}
+
+	switch s.kind {
+	case "circle":
+		fill, stroke := setColors()
+		if !fill && !stroke {
+			return
+		}
+		writePDFCircle(w, doc, s.cx, s.cy, s.r)
+		paint(fill, stroke, true)
+	case "rect":
+		fill, stroke := setColors()
+		if !fill && !stroke {
+			return
+		}
+		x, y := pdfXY(doc, s.x, s.y+s.h)
+		fmt.Fprintf(w, "%.2f %.2f %.2f %.2f re\n", x, y, s.w, s.h)
+		paint(fill, stroke, true)
+	case "line":
+		_, stroke := setColors()
+		if !stroke {
+			return
+		}
+		x0, y0 := pdfXY(doc, s.x1, s.y1)
+		x1, y1 := pdfXY(doc, s.x2, s.y2)
+		fmt.Fprintf(w, "%.2f %.2f m %.2f %.2f l S\n", x0, y0, x1, y1)
+		if s.arrowEnd {
+			writePDFArrow(w, doc, [][2]float64{{s.x1, s.y1}, {s.x2, s.y2}}, s.stroke)
+		}
+	case "polygon", "path":
+		if len(s.points) < 2 {
+			return
+		}
+		fill, stroke := setColors()
+		if s.kind == "path" && !s.closed {
+			fill = false
+		}
+		if !fill && !stroke {
+			return
+		}
+		for i, p := range s.points {
+			x, y := pdfXY(doc, p[0], p[1])
+			op := "l"
+			if i == 0 {
+				op = "m"
+			}
+			fmt.Fprintf(w, "%.2f %.2f %s\n", x, y, op)
+		}
+		if s.closed {
+			w.WriteString("h\n")
+		}
+		paint(fill, stroke, s.closed)
+		if s.arrowEnd && s.stroke != "" {
+			writePDFArrow(w, doc, s.points, s.stroke)
+		}
+	case "text":
+		if s.text == "" {
+			return
+		}
+		hex := s.fill
+		if hex == "" {
+			hex = "#333333"
+		}
+		r, g, b := parseRGB(hex)
+		x, y := pdfXY(doc, s.x, s.y)
+		if s.anchorMid {
+			// Approximate Helvetica advance at half the font size.
+			x -= float64(len(s.text)) * s.fontSize * 0.5 / 2
+		}
+		fmt.Fprintf(w, "BT\n%.3f %.3f %.3f rg\n/F1 %.1f Tf\n%.2f %.2f Td\n(%s) Tj\nET\n",
+			float64(r)/255, float64(g)/255, float64(b)/255,
+			s.fontSize, x, y-s.fontSize*0.35, escapePDFString(s.text))
+	}
+}
+
+// writePDFCircle approximates a circle with four Bézier arcs.
+func writePDFCircle(w *bytes.Buffer, doc *svgDoc, cx, cy, r float64) {
+	const k = 0.5523
+	x, y := pdfXY(doc, cx, cy)
+	fmt.Fprintf(w, "%.2f %.2f m\n", x+r, y)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x+r, y+r*k, x+r*k, y+r, x, y+r)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x-r*k, y+r, x-r, y+r*k, x-r, y)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x-r, y-r*k, x-r*k, y-r, x, y-r)
+	fmt.Fprintf(w, "%.2f %.2f %.2f %.2f %.2f %.2f c\n", x+r*k, y-r, x+r, y-r*k, x+r, y)
+}
+
+// writePDFArrow fills the marker-end arrowhead triangle.
+func writePDFArrow(w *bytes.Buffer, doc *svgDoc, points [][2]float64, hex string) {
+	tri := arrowheadAt(points, 8)
+	if tri == nil {
+		return
+	}
+	r, g, b := parseRGB(hex)
+	fmt.Fprintf(w, "%.3f %.3f %.3f rg\n", float64(r)/255, float64(g)/255, float64(b)/255)
+	for i, p := range tri {
+		x, y := pdfXY(doc, p[0], p[1])
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(w, "%.2f %.2f %s\n", x, y, op)
+	}
+	w.WriteString("h f\n")
+}
+
+// escapePDFString escapes characters with meaning inside PDF literals.
+func escapePDFString(s string) string {
+	r := strings.NewReplacer("\\", "\\\\", "(", "\\(", ")", "\\)")
+	return r.Replace(s)
+}
+
+// assemblePDF wraps a content stream into a minimal well-formed PDF file:
+// catalog, page tree, one page, Helvetica, and a cross-reference table.
+func assemblePDF(width, height float64, content []byte) []byte {
+	var out bytes.Buffer
+	offsets := make([]int, 0, 6)
+	addObj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	addObj("<< /Type /Catalog /Pages 2 0 R >>")
+	addObj("<< /Type /Pages /Kids [3 0 R] /Count 1 >>")
+	addObj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+		width, height))
+	addObj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	addObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+
+	xrefStart := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets)+1, xrefStart)
+	return out.Bytes()
+}
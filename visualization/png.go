@@ -0,0 +1,340 @@
+package visualization
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/statemachine"
+	"github.com/pflow-xyz/go-pflow/workflow"
+)
+
+// PNGOptions configures rasterization.
+type PNGOptions struct {
+	// Scale is pixels per SVG unit. Default 2 (suitable for slides).
+	Scale float64
+	// Background fills the canvas before drawing. Default white.
+	Background color.Color
+}
+
+// SVGToPNG rasterizes a rendered SVG document (from RenderSVG,
+// RenderWorkflowSVG, or RenderStateMachineSVG) to PNG bytes. It is a
+// pure-Go pipeline — no external binaries — covering exactly the SVG subset
+// our renderers emit.
+func SVGToPNG(svg string, opts *PNGOptions) ([]byte, error) {
+	if opts == nil {
+		opts = &PNGOptions{}
+	}
+	scale := opts.Scale
+	if scale <= 0 {
+		scale = 2
+	}
+	background := opts.Background
+	if background == nil {
+		background = color.White
+	}
+
+	doc, err := parseSVG(svg)
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := newRaster(doc, scale, background)
+	for _, shape := range doc.shapes {
+		canvas.draw(&shape)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, canvas.img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SavePNG renders a Petri net to a PNG file.
+func SavePNG(net *petri.PetriNet, filename string) error {
+	svg, err := RenderSVG(net)
+	if err != nil {
+		return err
+	}
+	data, err := SVGToPNG(svg, nil)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// SaveWorkflowPNG renders a workflow diagram to a PNG file.
+func SaveWorkflowPNG(wf *workflow.Workflow, filename string, opts *WorkflowSVGOptions) error {
+	svg, err := RenderWorkflowSVG(wf, opts)
+	if err != nil {
+		return err
+	}
+	data, err := SVGToPNG(svg, nil)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// SaveStateMachinePNG renders a statechart diagram to a PNG file.
+func SaveStateMachinePNG(chart *statemachine.Chart, filename string, opts *StateMachineSVGOptions) error {
+	svg, err := RenderStateMachineSVG(chart, opts)
+	if err != nil {
+		return err
+	}
+	data, err := SVGToPNG(svg, nil)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// raster draws the display list onto an RGBA image.
+type raster struct {
+	img   *image.RGBA
+	doc   *svgDoc
+	scale float64
+}
+
+func newRaster(doc *svgDoc, scale float64, background color.Color) *raster {
+	w := int(math.Ceil(doc.width * scale))
+	h := int(math.Ceil(doc.height * scale))
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, background)
+		}
+	}
+	return &raster{img: img, doc: doc, scale: scale}
+}
+
+// px converts document coordinates to pixels.
+func (r *raster) px(x, y float64) (float64, float64) {
+	return (x - r.doc.minX) * r.scale, (y - r.doc.minY) * r.scale
+}
+
+func (r *raster) draw(s *svgShape) {
+	switch s.kind {
+	case "circle":
+		cx, cy := r.px(s.cx, s.cy)
+		radius := s.r * r.scale
+		if s.fill != "" {
+			r.fillCircle(cx, cy, radius, s.fill, s.fillOpacity)
+		}
+		if s.stroke != "" {
+			r.strokeCircle(cx, cy, radius, s.strokeWidth*r.scale, s.stroke)
+		}
+	case "rect":
+		x0, y0 := r.px(s.x, s.y)
+		x1, y1 := r.px(s.x+s.w, s.y+s.h)
+		if s.fill != "" {
+			r.fillRect(x0, y0, x1, y1, s.fill, s.fillOpacity)
+		}
+		if s.stroke != "" {
+			sw := s.strokeWidth * r.scale
+			r.thickLine(x0, y0, x1, y0, sw, s.stroke)
+			r.thickLine(x1, y0, x1, y1, sw, s.stroke)
+			r.thickLine(x1, y1, x0, y1, sw, s.stroke)
+			r.thickLine(x0, y1, x0, y0, sw, s.stroke)
+		}
+	case "line":
+		x0, y0 := r.px(s.x1, s.y1)
+		x1, y1 := r.px(s.x2, s.y2)
+		if s.stroke != "" {
+			r.thickLine(x0, y0, x1, y1, s.strokeWidth*r.scale, s.stroke)
+		}
+		if s.arrowEnd && s.stroke != "" {
+			r.fillPolygonDoc(arrowheadAt([][2]float64{{s.x1, s.y1}, {s.x2, s.y2}}, 8), s.stroke, 1)
+		}
+	case "polygon":
+		if s.fill != "" {
+			r.fillPolygonDoc(s.points, s.fill, s.fillOpacity)
+		}
+		if s.stroke != "" {
+			r.strokePolylineDoc(s.points, s.strokeWidth, s.stroke, true)
+		}
+	case "path":
+		if s.closed && s.fill != "" {
+			r.fillPolygonDoc(s.points, s.fill, s.fillOpacity)
+		}
+		if s.stroke != "" {
+			r.strokePolylineDoc(s.points, s.strokeWidth, s.stroke, s.closed)
+		}
+		if s.arrowEnd && s.stroke != "" {
+			r.fillPolygonDoc(arrowheadAt(s.points, 8), s.stroke, 1)
+		}
+	case "text":
+		fill := s.fill
+		if fill == "" {
+			fill = "#333333"
+		}
+		r.drawText(s, fill)
+	}
+}
+
+func (r *raster) set(x, y int, hex string, opacity float64) {
+	if x < 0 || y < 0 || x >= r.img.Bounds().Dx() || y >= r.img.Bounds().Dy() {
+		return
+	}
+	red, green, blue := parseRGB(hex)
+	if opacity >= 1 {
+		r.img.SetRGBA(x, y, color.RGBA{red, green, blue, 255})
+		return
+	}
+	old := r.img.RGBAAt(x, y)
+	blend := func(a uint8, b uint8) uint8 {
+		return uint8(float64(a)*opacity + float64(b)*(1-opacity))
+	}
+	r.img.SetRGBA(x, y, color.RGBA{blend(red, old.R), blend(green, old.G), blend(blue, old.B), 255})
+}
+
+func (r *raster) fillCircle(cx, cy, radius float64, hex string, opacity float64) {
+	for y := int(cy - radius); y <= int(cy+radius)+1; y++ {
+		for x := int(cx - radius); x <= int(cx+radius)+1; x++ {
+			dx, dy := float64(x)+0.5-cx, float64(y)+0.5-cy
+			if dx*dx+dy*dy <= radius*radius {
+				r.set(x, y, hex, opacity)
+			}
+		}
+	}
+}
+
+func (r *raster) strokeCircle(cx, cy, radius, width float64, hex string) {
+	if width < 1 {
+		width = 1
+	}
+	outer := radius + width/2
+	inner := radius - width/2
+	for y := int(cy - outer); y <= int(cy+outer)+1; y++ {
+		for x := int(cx - outer); x <= int(cx+outer)+1; x++ {
+			dx, dy := float64(x)+0.5-cx, float64(y)+0.5-cy
+			d2 := dx*dx + dy*dy
+			if d2 <= outer*outer && d2 >= inner*inner {
+				r.set(x, y, hex, 1)
+			}
+		}
+	}
+}
+
+func (r *raster) fillRect(x0, y0, x1, y1 float64, hex string, opacity float64) {
+	for y := int(y0); y <= int(y1); y++ {
+		for x := int(x0); x <= int(x1); x++ {
+			r.set(x, y, hex, opacity)
+		}
+	}
+}
+
+// thickLine fills the rectangle swept by the line's width.
+func (r *raster) thickLine(x0, y0, x1, y1, width float64, hex string) {
+	if width < 1 {
+		width = 1
+	}
+	dx, dy := x1-x0, y1-y0
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		r.fillCircle(x0, y0, width/2, hex, 1)
+		return
+	}
+	px, py := -dy/length*width/2, dx/length*width/2
+	r.fillPolygonPx([][2]float64{
+		{x0 + px, y0 + py}, {x1 + px, y1 + py}, {x1 - px, y1 - py}, {x0 - px, y0 - py},
+	}, hex, 1)
+}
+
+func (r *raster) strokePolylineDoc(points [][2]float64, width float64, hex string, closed bool) {
+	for i := 1; i < len(points); i++ {
+		x0, y0 := r.px(points[i-1][0], points[i-1][1])
+		x1, y1 := r.px(points[i][0], points[i][1])
+		r.thickLine(x0, y0, x1, y1, width*r.scale, hex)
+	}
+	if closed && len(points) > 2 {
+		x0, y0 := r.px(points[len(points)-1][0], points[len(points)-1][1])
+		x1, y1 := r.px(points[0][0], points[0][1])
+		r.thickLine(x0, y0, x1, y1, width*r.scale, hex)
+	}
+}
+
+func (r *raster) fillPolygonDoc(points [][2]float64, hex string, opacity float64) {
+	px := make([][2]float64, len(points))
+	for i, p := range points {
+		px[i][0], px[i][1] = r.px(p[0], p[1])
+	}
+	r.fillPolygonPx(px, hex, opacity)
+}
+
+// fillPolygonPx scanline-fills with the even-odd rule.
+func (r *raster) fillPolygonPx(points [][2]float64, hex string, opacity float64) {
+	if len(points) < 3 {
+		return
+	}
+	minY, maxY := points[0][1], points[0][1]
+	for _, p := range points {
+		minY = math.Min(minY, p[1])
+		maxY = math.Max(maxY, p[1])
+	}
+	for y := int(minY); y <= int(maxY)+1; y++ {
+		scan := float64(y) + 0.5
+		var xs []float64
+		for i := range points {
+			a, b := points[i], points[(i+1)%len(points)]
+			if (a[1] <= scan) == (b[1] <= scan) {
+				continue
+			}
+			t := (scan - a[1]) / (b[1] - a[1])
+			xs = append(xs, a[0]+t*(b[0]-a[0]))
+		}
+		sort.Float64s(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			for x := int(xs[i]); float64(x) <= xs[i+1]; x++ {
+				r.set(x, y, hex, opacity)
+			}
+		}
+	}
+}
+
+// drawText renders a string with the embedded 5x7 bitmap font, scaled to
+// approximate the requested font size.
+func (r *raster) drawText(s *svgShape, hex string) {
+	px, py := r.px(s.x, s.y)
+	unit := s.fontSize * r.scale / 8
+	if unit < 1 {
+		unit = 1
+	}
+	advance := 6 * unit
+	width := float64(len(s.text)) * advance
+	startX := px
+	if s.anchorMid {
+		startX = px - width/2
+	}
+	topY := py - 3.5*unit
+
+	for i, ch := range s.text {
+		glyph, ok := font5x7[ch]
+		if !ok {
+			glyph = font5x7['?']
+		}
+		originX := startX + float64(i)*advance
+		for row := 0; row < 7; row++ {
+			for col := 0; col < 5; col++ {
+				if glyph[row]&(1<<(4-col)) == 0 {
+					continue
+				}
+				x0 := originX + float64(col)*unit
+				y0 := topY + float64(row)*unit
+				r.fillRect(x0, y0, x0+unit-0.01, y0+unit-0.01, hex, 1)
+			}
+		}
+	}
+}
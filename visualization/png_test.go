@@ -0,0 +1,117 @@
+package visualization
+
+import (
+	"bytes"
+	"image/png"
+	"path/filepath"
+	"testing"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+)
+
+func chainNet() *petri.PetriNet {
+	return petri.Build().
+		Chain(3, "start", "t1", "middle", "t2", "end").
+		Done()
+}
+
+func TestSVGToPNG(t *testing.T) {
+	svg, err := RenderSVG(chainNet())
+	if err != nil {
+		t.Fatalf("RenderSVG: %v", err)
+	}
+	data, err := SVGToPNG(svg, nil)
+	if err != nil {
+		t.Fatalf("SVGToPNG: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Output is not valid PNG: %v", err)
+	}
+	if img.Bounds().Dx() < 100 || img.Bounds().Dy() < 100 {
+		t.Errorf("Suspiciously small raster: %v", img.Bounds())
+	}
+	// Something must have been drawn on the white canvas.
+	drawn := false
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y && !drawn; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			if r != 0xffff || g != 0xffff || b != 0xffff {
+				drawn = true
+				break
+			}
+		}
+	}
+	if !drawn {
+		t.Error("Rasterized image is blank")
+	}
+}
+
+func TestSavePNG(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "net.png")
+	if err := SavePNG(chainNet(), path); err != nil {
+		t.Fatalf("SavePNG: %v", err)
+	}
+}
+
+func TestSVGToPDF(t *testing.T) {
+	svg, err := RenderSVG(chainNet())
+	if err != nil {
+		t.Fatalf("RenderSVG: %v", err)
+	}
+	data, err := SVGToPDF(svg)
+	if err != nil {
+		t.Fatalf("SVGToPDF: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) {
+		t.Error("Missing PDF header")
+	}
+	if !bytes.HasSuffix(bytes.TrimSpace(data), []byte("%%EOF")) {
+		t.Error("Missing PDF trailer")
+	}
+	for _, want := range []string{"/Type /Catalog", "/MediaBox", "/BaseFont /Helvetica", "stream"} {
+		if !bytes.Contains(data, []byte(want)) {
+			t.Errorf("PDF missing %q", want)
+		}
+	}
+}
+
+func TestSavePDF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "net.pdf")
+	if err := SavePDF(chainNet(), path); err != nil {
+		t.Fatalf("SavePDF: %v", err)
+	}
+}
+
+func TestParseSVGSubset(t *testing.T) {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 200 100" width="200" height="100">` +
+		`<defs><style>.place { fill: #fff; stroke: #333; stroke-width: 2; }</style>` +
+		`<marker id="m"><polygon points="0 0, 10 3.5, 0 7"/></marker></defs>` +
+		`<circle cx="50" cy="50" r="20" class="place"/>` +
+		`<line x1="70" y1="50" x2="130" y2="50" stroke="#cfcfcf" marker-end="url(#m)"/>` +
+		`<text x="50" y="80" font-size="11">start</text>` +
+		`</svg>`
+	doc, err := parseSVG(svg)
+	if err != nil {
+		t.Fatalf("parseSVG: %v", err)
+	}
+	if doc.width != 200 || doc.height != 100 {
+		t.Errorf("View box: %gx%g", doc.width, doc.height)
+	}
+	// The marker polygon lives in defs and must not be drawn.
+	if len(doc.shapes) != 3 {
+		t.Fatalf("Expected 3 shapes, got %d", len(doc.shapes))
+	}
+	circle := doc.shapes[0]
+	if circle.kind != "circle" || circle.fill != "#ffffff" || circle.stroke != "#333333" || circle.strokeWidth != 2 {
+		t.Errorf("Class styling not applied: %+v", circle)
+	}
+	line := doc.shapes[1]
+	if !line.arrowEnd || line.stroke != "#cfcfcf" {
+		t.Errorf("Line attributes: %+v", line)
+	}
+	text := doc.shapes[2]
+	if text.text != "start" || text.fontSize != 11 {
+		t.Errorf("Text attributes: %+v", text)
+	}
+}
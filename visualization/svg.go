@@ -10,8 +10,13 @@ import (
 )
 
 // RenderSVG converts a Petri net to SVG format using the pflow-xyz library.
-// Returns the SVG as a string.
+// Returns the SVG as a string. Nets without usable coordinates (all zero or
+// overlapping) get an automatic layered layout first.
 func RenderSVG(net *petri.PetriNet) (string, error) {
+	if NeedsLayout(net) {
+		AutoLayout(net)
+	}
+
 	// Convert our Petri net format to pflow-xyz JSON-LD format
 	jsonLD := convertToJSONLD(net)
 
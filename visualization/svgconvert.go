@@ -0,0 +1,316 @@
+package visualization
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// This file parses the SVG subset emitted by our own renderers (RenderSVG,
+// RenderWorkflowSVG, RenderStateMachineSVG, and the overlay helpers) into a
+// flat display list that the PNG and PDF backends share. It is not a general
+// SVG engine: it understands circle, rect, line, polygon, path (absolute
+// M/L/Q/C/Z), and text, with styling from class rules in <style> blocks and
+// inline attributes.
+
+// svgShape is one drawable element in document order.
+type svgShape struct {
+	kind        string // "circle", "rect", "line", "polygon", "path", "text"
+	cx, cy, r   float64
+	x, y, w, h  float64
+	x1, y1      float64
+	x2, y2      float64
+	points      [][2]float64 // polygon vertices / flattened path
+	closed      bool         // path ended with Z (fillable)
+	text        string
+	fontSize    float64
+	anchorMid   bool   // text-anchor: middle
+	fill        string // hex color or "" for none
+	fillOpacity float64
+	stroke      string
+	strokeWidth float64
+	arrowEnd    bool // marker-end was present; draw an arrowhead
+}
+
+// svgDoc is a parsed document: view box plus display list.
+type svgDoc struct {
+	minX, minY    float64
+	width, height float64
+	shapes        []svgShape
+}
+
+var (
+	reElement = regexp.MustCompile(`<(circle|rect|line|polygon|path|text)\b([^>]*?)(/?)>`)
+	reAttr    = regexp.MustCompile(`([a-zA-Z-]+)="([^"]*)"`)
+	reStyle   = regexp.MustCompile(`(?s)<style>(.*?)</style>`)
+	reRule    = regexp.MustCompile(`\.([\w-]+)\s*\{([^}]*)\}`)
+	reDefs    = regexp.MustCompile(`(?s)<defs>.*?</defs>`)
+	reTag     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// parseSVG builds the display list for a rendered SVG document.
+func parseSVG(svg string) (*svgDoc, error) {
+	doc := &svgDoc{width: 800, height: 600}
+
+	root := reAttr.FindAllStringSubmatch(svg[:strings.Index(svg, ">")+1], -1)
+	for _, m := range root {
+		switch m[1] {
+		case "viewBox":
+			parts := strings.Fields(m[2])
+			if len(parts) == 4 {
+				doc.minX, _ = strconv.ParseFloat(parts[0], 64)
+				doc.minY, _ = strconv.ParseFloat(parts[1], 64)
+				doc.width, _ = strconv.ParseFloat(parts[2], 64)
+				doc.height, _ = strconv.ParseFloat(parts[3], 64)
+			}
+		case "width":
+			if doc.width == 800 {
+				doc.width, _ = strconv.ParseFloat(m[2], 64)
+			}
+		case "height":
+			if doc.height == 600 {
+				doc.height, _ = strconv.ParseFloat(m[2], 64)
+			}
+		}
+	}
+
+	// Collect class rules, then drop <defs> so marker internals are not
+	// drawn as standalone shapes.
+	styles := make(map[string]map[string]string)
+	for _, styleMatch := range reStyle.FindAllStringSubmatch(svg, -1) {
+		for _, rule := range reRule.FindAllStringSubmatch(styleMatch[1], -1) {
+			props := styles[rule[1]]
+			if props == nil {
+				props = make(map[string]string)
+				styles[rule[1]] = props
+			}
+			for _, decl := range strings.Split(rule[2], ";") {
+				kv := strings.SplitN(decl, ":", 2)
+				if len(kv) == 2 {
+					props[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+				}
+			}
+		}
+	}
+	body := reDefs.ReplaceAllString(svg, "")
+
+	for _, loc := range reElement.FindAllStringSubmatchIndex(body, -1) {
+		kind := body[loc[2]:loc[3]]
+		attrText := body[loc[4]:loc[5]]
+		shape := svgShape{kind: kind, fillOpacity: 1, strokeWidth: 1, fontSize: 12}
+
+		attrs := make(map[string]string)
+		for _, m := range reAttr.FindAllStringSubmatch(attrText, -1) {
+			attrs[m[1]] = m[2]
+		}
+		// Class rules first, inline attributes override.
+		props := make(map[string]string)
+		for _, class := range strings.Fields(attrs["class"]) {
+			for k, v := range styles[class] {
+				props[k] = v
+			}
+		}
+		for k, v := range attrs {
+			props[k] = v
+		}
+		applyShapeStyle(&shape, kind, props)
+
+		switch kind {
+		case "circle":
+			shape.cx = floatProp(props, "cx")
+			shape.cy = floatProp(props, "cy")
+			shape.r = floatProp(props, "r")
+		case "rect":
+			shape.x = floatProp(props, "x")
+			shape.y = floatProp(props, "y")
+			shape.w = floatProp(props, "width")
+			shape.h = floatProp(props, "height")
+		case "line":
+			shape.x1 = floatProp(props, "x1")
+			shape.y1 = floatProp(props, "y1")
+			shape.x2 = floatProp(props, "x2")
+			shape.y2 = floatProp(props, "y2")
+		case "polygon":
+			shape.points = parsePoints(props["points"])
+			shape.closed = true
+		case "path":
+			shape.points, shape.closed = flattenPath(props["d"])
+		case "text":
+			end := strings.Index(body[loc[1]:], "</text>")
+			if end < 0 {
+				continue
+			}
+			inner := body[loc[1] : loc[1]+end]
+			shape.text = unescapeXML(reTag.ReplaceAllString(inner, ""))
+			shape.x = floatProp(props, "x")
+			shape.y = floatProp(props, "y")
+		}
+		doc.shapes = append(doc.shapes, shape)
+	}
+	return doc, nil
+}
+
+// applyShapeStyle resolves fill/stroke/size props onto the shape, with
+// per-kind defaults matching how browsers treat the same markup.
+func applyShapeStyle(shape *svgShape, kind string, props map[string]string) {
+	shape.fill = cssColor(props["fill"])
+	if _, set := props["fill"]; !set {
+		if kind == "line" || kind == "path" {
+			shape.fill = ""
+		} else {
+			shape.fill = "#000000"
+		}
+	}
+	shape.stroke = cssColor(props["stroke"])
+	if v, ok := props["stroke-width"]; ok {
+		shape.strokeWidth, _ = strconv.ParseFloat(strings.TrimSuffix(v, "px"), 64)
+	}
+	if v, ok := props["fill-opacity"]; ok {
+		shape.fillOpacity, _ = strconv.ParseFloat(v, 64)
+	}
+	if v, ok := props["font-size"]; ok {
+		shape.fontSize, _ = strconv.ParseFloat(strings.TrimSuffix(v, "px"), 64)
+	}
+	if _, ok := props["marker-end"]; ok {
+		shape.arrowEnd = true
+	}
+	shape.anchorMid = props["text-anchor"] == "middle"
+}
+
+// floatProp parses a numeric attribute, defaulting to zero.
+func floatProp(props map[string]string, key string) float64 {
+	v, _ := strconv.ParseFloat(props[key], 64)
+	return v
+}
+
+// cssColor normalizes a CSS color to #rrggbb, or "" for none/empty.
+func cssColor(v string) string {
+	v = strings.TrimSpace(v)
+	switch v {
+	case "", "none", "transparent":
+		return ""
+	case "white":
+		return "#ffffff"
+	case "black":
+		return "#000000"
+	}
+	if strings.HasPrefix(v, "#") && len(v) == 4 {
+		return fmt.Sprintf("#%c%c%c%c%c%c", v[1], v[1], v[2], v[2], v[3], v[3])
+	}
+	if strings.HasPrefix(v, "#") && len(v) == 7 {
+		return strings.ToLower(v)
+	}
+	// Unknown named color: fall back to gray so the shape stays visible.
+	return "#808080"
+}
+
+// parseRGB splits #rrggbb into components.
+func parseRGB(hex string) (uint8, uint8, uint8) {
+	if len(hex) != 7 {
+		return 0, 0, 0
+	}
+	r, _ := strconv.ParseUint(hex[1:3], 16, 8)
+	g, _ := strconv.ParseUint(hex[3:5], 16, 8)
+	b, _ := strconv.ParseUint(hex[5:7], 16, 8)
+	return uint8(r), uint8(g), uint8(b)
+}
+
+// parsePoints reads a polygon point list; commas and whitespace both
+// separate coordinates.
+func parsePoints(raw string) [][2]float64 {
+	fields := strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	points := make([][2]float64, 0, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		x, _ := strconv.ParseFloat(fields[i], 64)
+		y, _ := strconv.ParseFloat(fields[i+1], 64)
+		points = append(points, [2]float64{x, y})
+	}
+	return points
+}
+
+// flattenPath converts absolute M/L/Q/C/Z path data into a polyline,
+// subdividing Bézier segments. Returns the points and whether the path
+// closed (arrowheads use M L L Z).
+func flattenPath(d string) ([][2]float64, bool) {
+	fields := strings.FieldsFunc(d, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+	var points [][2]float64
+	closed := false
+	i := 0
+	next := func() float64 {
+		if i >= len(fields) {
+			return 0
+		}
+		v, _ := strconv.ParseFloat(fields[i], 64)
+		i++
+		return v
+	}
+	var cur [2]float64
+	for i < len(fields) {
+		cmd := fields[i]
+		i++
+		switch cmd {
+		case "M", "L":
+			cur = [2]float64{next(), next()}
+			points = append(points, cur)
+		case "Q":
+			cx, cy := next(), next()
+			ex, ey := next(), next()
+			for s := 1; s <= 16; s++ {
+				t := float64(s) / 16
+				u := 1 - t
+				x := u*u*cur[0] + 2*u*t*cx + t*t*ex
+				y := u*u*cur[1] + 2*u*t*cy + t*t*ey
+				points = append(points, [2]float64{x, y})
+			}
+			cur = [2]float64{ex, ey}
+		case "C":
+			c1x, c1y := next(), next()
+			c2x, c2y := next(), next()
+			ex, ey := next(), next()
+			for s := 1; s <= 16; s++ {
+				t := float64(s) / 16
+				u := 1 - t
+				x := u*u*u*cur[0] + 3*u*u*t*c1x + 3*u*t*t*c2x + t*t*t*ex
+				y := u*u*u*cur[1] + 3*u*u*t*c1y + 3*u*t*t*c2y + t*t*t*ey
+				points = append(points, [2]float64{x, y})
+			}
+			cur = [2]float64{ex, ey}
+		case "Z", "z":
+			closed = true
+		default:
+			// Bare number without a command letter: skip defensively.
+		}
+	}
+	return points, closed
+}
+
+// arrowheadAt builds a small triangle at the end of a polyline, oriented
+// along its final segment, approximating the SVG marker our renderers use.
+func arrowheadAt(points [][2]float64, size float64) [][2]float64 {
+	if len(points) < 2 {
+		return nil
+	}
+	tip := points[len(points)-1]
+	prev := points[len(points)-2]
+	dx, dy := tip[0]-prev[0], tip[1]-prev[1]
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return nil
+	}
+	ux, uy := dx/length, dy/length
+	bx, by := tip[0]-ux*size, tip[1]-uy*size
+	px, py := -uy*size*0.35, ux*size*0.35
+	return [][2]float64{tip, {bx + px, by + py}, {bx - px, by - py}}
+}
+
+// unescapeXML reverses the escaping applied by escapeXML.
+func unescapeXML(s string) string {
+	r := strings.NewReplacer("&lt;", "<", "&gt;", ">", "&quot;", "\"", "&apos;", "'", "&amp;", "&")
+	return r.Replace(s)
+}
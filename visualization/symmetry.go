@@ -0,0 +1,80 @@
+package visualization
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/reachability"
+)
+
+// RenderSymmetryReducedSVG renders a net with symmetric substructures
+// collapsed: each group of interchangeable places/transitions is drawn once,
+// with a multiplicity badge (e.g. "server ×4") on the representative.
+// Nets without detected symmetry render identically to RenderSVG.
+func RenderSymmetryReducedSVG(net *petri.PetriNet) (string, error) {
+	sym := reachability.DetectSymmetries(net)
+	if !sym.HasSymmetry() {
+		return RenderSVG(net)
+	}
+	return RenderSVG(reduceSymmetric(net, sym))
+}
+
+// SaveSymmetryReducedSVG renders the symmetry-reduced net to a file.
+func SaveSymmetryReducedSVG(net *petri.PetriNet, filename string) error {
+	svgString, err := RenderSymmetryReducedSVG(net)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, []byte(svgString), 0644)
+}
+
+// reduceSymmetric builds a copy of the net keeping one representative per
+// symmetric group. The representative (first name in sorted group order)
+// gets a "×N" badge; the remaining group members and their arcs are dropped.
+func reduceSymmetric(net *petri.PetriNet, sym *reachability.Symmetries) *petri.PetriNet {
+	dropped := make(map[string]bool)
+	badge := make(map[string]string)
+	for _, group := range sym.PlaceGroups {
+		badge[group[0]] = fmt.Sprintf("%s ×%d", group[0], len(group))
+		for _, name := range group[1:] {
+			dropped[name] = true
+		}
+	}
+	for _, group := range sym.TransitionGroups {
+		badge[group[0]] = fmt.Sprintf("%s ×%d", group[0], len(group))
+		for _, name := range group[1:] {
+			dropped[name] = true
+		}
+	}
+
+	reduced := petri.NewPetriNet()
+	reduced.Token = net.Token
+	for name, p := range net.Places {
+		if dropped[name] {
+			continue
+		}
+		labelText := p.LabelText
+		if b, ok := badge[name]; ok {
+			labelText = &b
+		}
+		reduced.AddPlace(name, p.Initial, p.Capacity, p.X, p.Y, labelText)
+	}
+	for name, t := range net.Transitions {
+		if dropped[name] {
+			continue
+		}
+		labelText := t.LabelText
+		if b, ok := badge[name]; ok {
+			labelText = &b
+		}
+		reduced.AddTransition(name, t.Role, t.X, t.Y, labelText)
+	}
+	for _, arc := range net.Arcs {
+		if dropped[arc.Source] || dropped[arc.Target] {
+			continue
+		}
+		reduced.AddArc(arc.Source, arc.Target, arc.Weight, arc.InhibitTransition)
+	}
+	return reduced
+}
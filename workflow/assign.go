@@ -0,0 +1,230 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// AllocationStrategy selects which eligible worker AutoAssign picks for a
+// ready task.
+type AllocationStrategy string
+
+const (
+	// AllocateRoundRobin cycles through eligible workers in ID order.
+	AllocateRoundRobin AllocationStrategy = "round-robin"
+	// AllocateLeastLoaded picks the worker with the fewest active assignments.
+	AllocateLeastLoaded AllocationStrategy = "least-loaded"
+	// AllocateSkillBased picks the worker with the highest combined
+	// proficiency across the task's required skills.
+	AllocateSkillBased AllocationStrategy = "skill-based"
+)
+
+// WithAllocation sets the strategy AutoAssign uses; the default is
+// round-robin.
+func (e *Engine) WithAllocation(strategy AllocationStrategy) *Engine {
+	e.allocation = strategy
+	return e
+}
+
+// WorkCalendar defines when a worker is available: a daily working window
+// on a set of workdays.
+type WorkCalendar struct {
+	StartHour int // First working hour (inclusive)
+	EndHour   int // Hour work stops (exclusive)
+	Workdays  map[time.Weekday]bool
+}
+
+// NewWorkCalendar creates a calendar with the given daily window. Without
+// explicit days it covers Monday through Friday.
+func NewWorkCalendar(startHour, endHour int, days ...time.Weekday) *WorkCalendar {
+	cal := &WorkCalendar{
+		StartHour: startHour,
+		EndHour:   endHour,
+		Workdays:  make(map[time.Weekday]bool),
+	}
+	if len(days) == 0 {
+		days = []time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+		}
+	}
+	for _, day := range days {
+		cal.Workdays[day] = true
+	}
+	return cal
+}
+
+// Available reports whether the calendar covers the given instant.
+func (cal *WorkCalendar) Available(t time.Time) bool {
+	if !cal.Workdays[t.Weekday()] {
+		return false
+	}
+	hour := t.Hour()
+	return hour >= cal.StartHour && hour < cal.EndHour
+}
+
+// EligibleResources returns the worker resources that can take a task
+// right now, in ID order: role candidates match, all required skills are
+// present, and the worker's calendar covers the current time.
+func (e *Engine) EligibleResources(taskID string) []string {
+	task := e.workflow.Tasks[taskID]
+	if task == nil {
+		return nil
+	}
+
+	now := e.now()
+	eligible := make([]string, 0)
+	for id, res := range e.workflow.Resources {
+		if res.Type != ResourceTypeWorker {
+			continue
+		}
+		if !hasAnyRole(res, task.CandidateRoles) {
+			continue
+		}
+		if !hasSkills(res, task.RequiredSkills) {
+			continue
+		}
+		if res.Calendar != nil && !res.Calendar.Available(now) {
+			continue
+		}
+		eligible = append(eligible, id)
+	}
+	sort.Strings(eligible)
+	return eligible
+}
+
+// AutoAssign picks an eligible worker for a ready task using the engine's
+// allocation strategy and assigns the task to it. It returns the chosen
+// worker's resource ID.
+func (e *Engine) AutoAssign(caseID, taskID string) (string, error) {
+	task := e.workflow.Tasks[taskID]
+	if task == nil {
+		return "", fmt.Errorf("task %s not found", taskID)
+	}
+
+	eligible := e.EligibleResources(taskID)
+	if len(eligible) == 0 {
+		return "", fmt.Errorf("no eligible worker for task %s", taskID)
+	}
+
+	var chosen string
+	switch e.allocation {
+	case AllocateLeastLoaded:
+		load := e.activeAssignments()
+		chosen = eligible[0]
+		for _, id := range eligible[1:] {
+			if load[id] < load[chosen] {
+				chosen = id
+			}
+		}
+
+	case AllocateSkillBased:
+		best := -1.0
+		for _, id := range eligible {
+			score := skillScore(e.workflow.Resources[id], task.RequiredSkills)
+			if score > best {
+				best = score
+				chosen = id
+			}
+		}
+
+	default: // round-robin
+		chosen = eligible[e.rrCursor%len(eligible)]
+		e.rrCursor++
+	}
+
+	if err := e.AssignTask(caseID, taskID, chosen); err != nil {
+		return "", err
+	}
+	return chosen, nil
+}
+
+// activeAssignments counts assigned or running task instances per worker
+// across all active cases.
+func (e *Engine) activeAssignments() map[string]int {
+	e.casesMu.RLock()
+	defer e.casesMu.RUnlock()
+
+	load := make(map[string]int)
+	for _, c := range e.cases {
+		for _, instance := range c.TaskInstances {
+			if instance.AssignedTo == "" {
+				continue
+			}
+			if instance.Status == TaskStatusAssigned || instance.Status == TaskStatusRunning {
+				load[instance.AssignedTo]++
+			}
+		}
+	}
+	return load
+}
+
+// hasAnyRole reports whether the worker fills one of the candidate roles.
+// An empty candidate list accepts any worker.
+func hasAnyRole(res *Resource, candidates []string) bool {
+	if len(candidates) == 0 {
+		return true
+	}
+	for _, want := range candidates {
+		for _, role := range res.Roles {
+			if role == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSkills reports whether the worker has every required skill.
+func hasSkills(res *Resource, required []string) bool {
+	for _, skill := range required {
+		if res.Skills[skill] <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// skillScore sums the worker's proficiency over the required skills.
+func skillScore(res *Resource, required []string) float64 {
+	score := 0.0
+	for _, skill := range required {
+		score += res.Skills[skill]
+	}
+	return score
+}
+
+// --- Builder sugar ---
+
+// Roles declares which roles may work this task.
+func (tb *TaskBuilder) Roles(roles ...string) *TaskBuilder {
+	tb.task.CandidateRoles = append(tb.task.CandidateRoles, roles...)
+	return tb
+}
+
+// Skills declares skills a worker must have to take this task.
+func (tb *TaskBuilder) Skills(skills ...string) *TaskBuilder {
+	tb.task.RequiredSkills = append(tb.task.RequiredSkills, skills...)
+	return tb
+}
+
+// Role adds roles this worker resource can fill.
+func (rb *ResourceBuilder) Role(roles ...string) *ResourceBuilder {
+	rb.resource.Roles = append(rb.resource.Roles, roles...)
+	return rb
+}
+
+// Skill records a proficiency (0-1) for this worker.
+func (rb *ResourceBuilder) Skill(name string, proficiency float64) *ResourceBuilder {
+	if rb.resource.Skills == nil {
+		rb.resource.Skills = make(map[string]float64)
+	}
+	rb.resource.Skills[name] = proficiency
+	return rb
+}
+
+// Calendar restricts when this worker is available.
+func (rb *ResourceBuilder) Calendar(cal *WorkCalendar) *ResourceBuilder {
+	rb.resource.Calendar = cal
+	return rb
+}
@@ -0,0 +1,142 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+)
+
+// newStaffedWorkflow builds a single-task flow with two baristas and one
+// manager available for assignment.
+func newStaffedWorkflow() *Workflow {
+	return New("coffee").
+		Task("brew").Name("Brew").Manual().Duration(2*time.Minute).
+		Roles("barista").End().
+		Resource("alice").Worker().Role("barista").
+		Skill("espresso", 0.9).Skill("latte-art", 0.4).End().
+		Resource("bob").Worker().Role("barista").
+		Skill("espresso", 0.6).Skill("latte-art", 0.8).End().
+		Resource("carol").Worker().Role("manager").End().
+		Start("brew").End("brew").
+		Build()
+}
+
+func TestEligibleResourcesFiltersByRole(t *testing.T) {
+	engine := NewEngine(newStaffedWorkflow())
+
+	eligible := engine.EligibleResources("brew")
+	if len(eligible) != 2 || eligible[0] != "alice" || eligible[1] != "bob" {
+		t.Errorf("Expected [alice bob], got %v", eligible)
+	}
+}
+
+func TestAutoAssignRoundRobin(t *testing.T) {
+	engine := NewEngine(newStaffedWorkflow())
+
+	var assigned []string
+	for i := 0; i < 4; i++ {
+		caseID := string(rune('a' + i))
+		if _, err := engine.StartCase(caseID, nil, PriorityMedium); err != nil {
+			t.Fatalf("StartCase: %v", err)
+		}
+		worker, err := engine.AutoAssign(caseID, "brew")
+		if err != nil {
+			t.Fatalf("AutoAssign: %v", err)
+		}
+		assigned = append(assigned, worker)
+	}
+
+	want := []string{"alice", "bob", "alice", "bob"}
+	for i, w := range want {
+		if assigned[i] != w {
+			t.Errorf("Assignment %d: expected %s, got %s", i, w, assigned[i])
+		}
+	}
+}
+
+func TestAutoAssignLeastLoaded(t *testing.T) {
+	engine := NewEngine(newStaffedWorkflow()).WithAllocation(AllocateLeastLoaded)
+
+	engine.StartCase("c1", nil, PriorityMedium)
+	if err := engine.AssignTask("c1", "brew", "alice"); err != nil {
+		t.Fatalf("AssignTask: %v", err)
+	}
+
+	engine.StartCase("c2", nil, PriorityMedium)
+	worker, err := engine.AutoAssign("c2", "brew")
+	if err != nil {
+		t.Fatalf("AutoAssign: %v", err)
+	}
+	if worker != "bob" {
+		t.Errorf("Expected the idle worker bob, got %s", worker)
+	}
+}
+
+func TestAutoAssignSkillBased(t *testing.T) {
+	wf := New("coffee").
+		Task("brew").Name("Brew").Manual().Duration(2*time.Minute).
+		Roles("barista").Skills("latte-art").End().
+		Resource("alice").Worker().Role("barista").
+		Skill("espresso", 0.9).Skill("latte-art", 0.4).End().
+		Resource("bob").Worker().Role("barista").
+		Skill("espresso", 0.6).Skill("latte-art", 0.8).End().
+		Start("brew").End("brew").
+		Build()
+
+	engine := NewEngine(wf).WithAllocation(AllocateSkillBased)
+	engine.StartCase("c1", nil, PriorityMedium)
+
+	worker, err := engine.AutoAssign("c1", "brew")
+	if err != nil {
+		t.Fatalf("AutoAssign: %v", err)
+	}
+	if worker != "bob" {
+		t.Errorf("Expected the stronger latte artist bob, got %s", worker)
+	}
+}
+
+func TestAutoAssignRequiresSkill(t *testing.T) {
+	wf := New("coffee").
+		Task("brew").Name("Brew").Manual().Duration(2*time.Minute).
+		Skills("cupping").End().
+		Resource("alice").Worker().Skill("espresso", 0.9).End().
+		Start("brew").End("brew").
+		Build()
+
+	engine := NewEngine(wf)
+	engine.StartCase("c1", nil, PriorityMedium)
+
+	if _, err := engine.AutoAssign("c1", "brew"); err == nil {
+		t.Error("Expected no eligible worker without the cupping skill")
+	}
+}
+
+func TestWorkCalendarGatesEligibility(t *testing.T) {
+	wf := New("coffee").
+		Task("brew").Name("Brew").Manual().Duration(2 * time.Minute).End().
+		Resource("alice").Worker().
+		Calendar(NewWorkCalendar(9, 17)).End().
+		Start("brew").End("brew").
+		Build()
+
+	// Tuesday 10:00 is inside the window; 18:00 is after hours.
+	onShift := time.Date(2026, 8, 25, 10, 0, 0, 0, time.UTC)
+	offShift := time.Date(2026, 8, 25, 18, 0, 0, 0, time.UTC)
+
+	current := onShift
+	engine := NewEngine(wf).WithTimeSource(func() time.Time { return current })
+
+	if eligible := engine.EligibleResources("brew"); len(eligible) != 1 {
+		t.Errorf("Expected alice on shift, got %v", eligible)
+	}
+
+	current = offShift
+	if eligible := engine.EligibleResources("brew"); len(eligible) != 0 {
+		t.Errorf("Expected nobody after hours, got %v", eligible)
+	}
+
+	weekend := time.Date(2026, 8, 29, 10, 0, 0, 0, time.UTC) // Saturday
+	current = weekend
+	if eligible := engine.EligibleResources("brew"); len(eligible) != 0 {
+		t.Errorf("Expected nobody on the weekend, got %v", eligible)
+	}
+}
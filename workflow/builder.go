@@ -158,6 +158,13 @@ func (tb *TaskBuilder) Timeout(d time.Duration) *TaskBuilder {
 	return tb
 }
 
+// Cost sets execution cost: a flat fee per run plus a rate per hour of work
+func (tb *TaskBuilder) Cost(perExecution, perHour float64) *TaskBuilder {
+	tb.task.CostPerExecution = perExecution
+	tb.task.CostPerHour = perHour
+	return tb
+}
+
 // Requires adds a resource requirement
 func (tb *TaskBuilder) Requires(resourceID string) *TaskBuilder {
 	tb.task.RequiredResources = append(tb.task.RequiredResources, ResourceRequirement{
@@ -695,19 +702,158 @@ func (w *Workflow) ToPetriNet() *petri.PetriNet {
 		yOffset += 50
 	}
 
-	// Create dependency arcs
+	// Create dependency arcs with gateway semantics.
+	//
+	// Each finish-to-start dependency gets an intermediate edge place
+	// edge_{from}_{to}. Split constructions route tokens from a task's
+	// completed place into its outgoing edges; join constructions collect
+	// tokens from a task's incoming edges into its ready place. This keeps
+	// AND (all), XOR (exclusive) and OR (inclusive) gateways structurally
+	// distinct instead of collapsing everything to one-transition-per-edge.
+	successors := make(map[string][]string)
+	predecessors := make(map[string][]string)
 	for _, dep := range w.Dependencies {
-		switch dep.Type {
-		case DepFinishToStart:
-			// From completed place to next ready place
-			// Add transition to move token
-			transName := fmt.Sprintf("dep_%s_to_%s", dep.FromTaskID, dep.ToTaskID)
+		if dep.Type != DepFinishToStart {
+			continue
+		}
+		successors[dep.FromTaskID] = append(successors[dep.FromTaskID], dep.ToTaskID)
+		predecessors[dep.ToTaskID] = append(predecessors[dep.ToTaskID], dep.FromTaskID)
+
+		net.AddPlace(fmt.Sprintf("edge_%s_%s", dep.FromTaskID, dep.ToTaskID), 0.0, nil, 400, yOffset, nil)
+		yOffset += 30
+	}
+
+	// Split side: route each task's completion token to its outgoing edges.
+	for from, succs := range successors {
+		switch w.Tasks[from].SplitType {
+		case SplitExclusive:
+			// XOR-split: one transition per successor, all competing for
+			// the single completion token — exactly one branch activates.
+			for _, to := range succs {
+				transName := fmt.Sprintf("split_%s_%s", from, to)
+				net.AddTransition(transName, "default", 350, yOffset, nil)
+				net.AddArc(fmt.Sprintf("%s_completed", from), transName, 1.0, false)
+				net.AddArc(transName, fmt.Sprintf("edge_%s_%s", from, to), 1.0, false)
+				yOffset += 30
+			}
+
+		case SplitInclusive:
+			// OR-split with token tracking: the split hands each branch a
+			// choice token, which a take transition turns into a live edge
+			// token or a skip transition forwards as a bypass token past
+			// the branch task. Downstream OR-joins then synchronize on one
+			// token per incoming edge — live or bypass — so they wait for
+			// exactly the activated branches.
+			transName := fmt.Sprintf("split_%s", from)
+			net.AddTransition(transName, "default", 350, yOffset, nil)
+			net.AddArc(fmt.Sprintf("%s_completed", from), transName, 1.0, false)
+			for _, to := range succs {
+				choice := fmt.Sprintf("choice_%s_%s", from, to)
+				net.AddPlace(choice, 0.0, nil, 400, yOffset, nil)
+				net.AddArc(transName, choice, 1.0, false)
+
+				take := fmt.Sprintf("take_%s_%s", from, to)
+				net.AddTransition(take, "default", 450, yOffset, nil)
+				net.AddArc(choice, take, 1.0, false)
+				net.AddArc(take, fmt.Sprintf("edge_%s_%s", from, to), 1.0, false)
+
+				skip := fmt.Sprintf("skip_%s_%s", from, to)
+				net.AddTransition(skip, "default", 450, yOffset+15, nil)
+				net.AddArc(choice, skip, 1.0, false)
+				// The bypass token skips the branch task entirely and
+				// lands on its outgoing edges, satisfying joins further
+				// downstream.
+				for _, next := range successors[to] {
+					net.AddArc(skip, fmt.Sprintf("edge_%s_%s", to, next), 1.0, false)
+				}
+				yOffset += 30
+			}
+
+		default: // SplitAll
+			// AND-split: one transition fans the completion token out to
+			// every successor edge.
+			transName := fmt.Sprintf("split_%s", from)
 			net.AddTransition(transName, "default", 350, yOffset, nil)
-			net.AddArc(fmt.Sprintf("%s_completed", dep.FromTaskID), transName, 1.0, false)
-			net.AddArc(transName, fmt.Sprintf("%s_ready", dep.ToTaskID), 1.0, false)
+			net.AddArc(fmt.Sprintf("%s_completed", from), transName, 1.0, false)
+			for _, to := range succs {
+				net.AddArc(transName, fmt.Sprintf("edge_%s_%s", from, to), 1.0, false)
+			}
+			yOffset += 30
+		}
+	}
+
+	// Join side: collect incoming edge tokens into the task's ready place.
+	for to, preds := range predecessors {
+		task := w.Tasks[to]
+		switch {
+		case task.JoinType == JoinAny && !w.inclusiveMergeAt(to):
+			// XOR-merge: each incoming edge enables the task on its own.
+			// (Inclusive merges instead synchronize below, relying on the
+			// OR-split's bypass tokens to cover skipped branches.)
+			for _, from := range preds {
+				transName := fmt.Sprintf("join_%s_%s", from, to)
+				net.AddTransition(transName, "default", 450, yOffset, nil)
+				net.AddArc(fmt.Sprintf("edge_%s_%s", from, to), transName, 1.0, false)
+				net.AddArc(transName, fmt.Sprintf("%s_ready", to), 1.0, false)
+				yOffset += 30
+			}
+
+		case task.JoinType == JoinN && task.JoinCount > 0:
+			// N-of-M join: edges feed a shared counter place; the join
+			// fires once N predecessors have completed.
+			counter := fmt.Sprintf("%s_joined", to)
+			net.AddPlace(counter, 0.0, nil, 450, yOffset, nil)
+			for _, from := range preds {
+				transName := fmt.Sprintf("join_%s_%s", from, to)
+				net.AddTransition(transName, "default", 450, yOffset, nil)
+				net.AddArc(fmt.Sprintf("edge_%s_%s", from, to), transName, 1.0, false)
+				net.AddArc(transName, counter, 1.0, false)
+				yOffset += 30
+			}
+			transName := fmt.Sprintf("join_%s", to)
+			net.AddTransition(transName, "default", 500, yOffset, nil)
+			net.AddArc(counter, transName, float64(task.JoinCount), false)
+			net.AddArc(transName, fmt.Sprintf("%s_ready", to), 1.0, false)
+			yOffset += 30
+
+		default: // JoinAll, or JoinAny behind an inclusive split (OR-join)
+			// Synchronizing join: one transition consuming one token from
+			// every incoming edge. For an OR-join this is exactly the
+			// token-tracking semantics — skipped branches contribute
+			// bypass tokens, so the join waits for activated branches
+			// only.
+			transName := fmt.Sprintf("join_%s", to)
+			net.AddTransition(transName, "default", 450, yOffset, nil)
+			for _, from := range preds {
+				net.AddArc(fmt.Sprintf("edge_%s_%s", from, to), transName, 1.0, false)
+			}
+			net.AddArc(transName, fmt.Sprintf("%s_ready", to), 1.0, false)
 			yOffset += 30
 		}
 	}
 
 	return net
 }
+
+// inclusiveMergeAt reports whether the JoinAny task merges branches of an
+// inclusive (OR) split — i.e. every predecessor receives its token from a
+// SplitInclusive task. Such merges must synchronize on all activated
+// branches; a plain XOR-merge would run the task once per branch.
+func (w *Workflow) inclusiveMergeAt(taskID string) bool {
+	found := false
+	for _, dep := range w.Dependencies {
+		if dep.Type != DepFinishToStart || dep.ToTaskID != taskID {
+			continue
+		}
+		for _, upstream := range w.Dependencies {
+			if upstream.Type != DepFinishToStart || upstream.ToTaskID != dep.FromTaskID {
+				continue
+			}
+			if w.Tasks[upstream.FromTaskID].SplitType != SplitInclusive {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
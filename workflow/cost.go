@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"fmt"
+	"time"
+)
+
+// CaseCostReport breaks down the cost accrued by one case: the sum of all
+// completed task costs, split per task. Task cost combines the task's own
+// fees (CostPerExecution, CostPerHour) with the usage cost of the
+// resources it held (Resource.CostPerUnit, CostPerHour).
+type CaseCostReport struct {
+	CaseID string
+	Total  float64
+	ByTask map[string]float64 // task ID -> accrued cost
+}
+
+// taskCost computes the cost of one task execution: the task's flat and
+// hourly fees plus the usage cost of its required resources over the work
+// duration.
+func (e *Engine) taskCost(task *Task, work time.Duration) float64 {
+	hours := work.Hours()
+	cost := task.CostPerExecution + task.CostPerHour*hours
+	for _, req := range task.RequiredResources {
+		res := e.workflow.Resources[req.ResourceID]
+		if res == nil {
+			continue
+		}
+		qty := req.Quantity
+		if qty == 0 {
+			qty = 1
+		}
+		cost += res.CostPerUnit*qty + res.CostPerHour*qty*hours
+	}
+	return cost
+}
+
+// CaseCost reports the cost accrued so far by a case, summed over its
+// completed task instances.
+func (e *Engine) CaseCost(caseID string) (*CaseCostReport, error) {
+	e.casesMu.RLock()
+	c, exists := e.cases[caseID]
+	e.casesMu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("case %s not found", caseID)
+	}
+
+	report := &CaseCostReport{
+		CaseID: caseID,
+		ByTask: make(map[string]float64),
+	}
+	for taskID, instance := range c.TaskInstances {
+		if instance.Status != TaskStatusCompleted {
+			continue
+		}
+		report.ByTask[taskID] += instance.Cost
+		report.Total += instance.Cost
+	}
+	return report, nil
+}
+
+// ActivityCosts aggregates accrued cost per task across all cases, for
+// spotting which activities dominate spend.
+func (e *Engine) ActivityCosts() map[string]float64 {
+	e.casesMu.RLock()
+	defer e.casesMu.RUnlock()
+
+	costs := make(map[string]float64)
+	for _, c := range e.cases {
+		for taskID, instance := range c.TaskInstances {
+			if instance.Status != TaskStatusCompleted {
+				continue
+			}
+			costs[taskID] += instance.Cost
+		}
+	}
+	return costs
+}
@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCaseCostBreakdown(t *testing.T) {
+	wf := New("cost_test").
+		Task("review").Cost(10, 60).RequireResource("reviewers", 2).Done().
+		Task("ship").Cost(5, 0).Done().
+		Connect("review", "ship").
+		Resource("reviewers").Capacity(3).Cost(1, 20).Done().
+		Start("review").
+		End("ship").
+		Build()
+
+	fixedTime := time.Now()
+	currentTime := &fixedTime
+	engine := NewEngine(wf).
+		WithTimeSource(func() time.Time { return *currentTime })
+
+	if _, err := engine.StartCase("case-1", nil, PriorityMedium); err != nil {
+		t.Fatalf("StartCase failed: %v", err)
+	}
+
+	// review runs for 30 minutes with 2 reviewers held.
+	if err := engine.StartTask("case-1", "review"); err != nil {
+		t.Fatalf("StartTask failed: %v", err)
+	}
+	*currentTime = fixedTime.Add(30 * time.Minute)
+	if err := engine.CompleteTask("case-1", "review", nil); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+
+	// 10 flat + 60*0.5 hourly + 2*1 per-unit + 2*20*0.5 resource-hours.
+	wantReview := 10.0 + 30.0 + 2.0 + 20.0
+	c := engine.GetCase("case-1")
+	if got := c.TaskInstances["review"].Cost; math.Abs(got-wantReview) > 1e-9 {
+		t.Errorf("Review cost = %f, want %f", got, wantReview)
+	}
+
+	// ship is instantaneous: flat fee only.
+	if err := engine.StartTask("case-1", "ship"); err != nil {
+		t.Fatalf("StartTask failed: %v", err)
+	}
+	if err := engine.CompleteTask("case-1", "ship", nil); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+
+	report, err := engine.CaseCost("case-1")
+	if err != nil {
+		t.Fatalf("CaseCost failed: %v", err)
+	}
+	if math.Abs(report.Total-(wantReview+5)) > 1e-9 {
+		t.Errorf("Case total = %f, want %f", report.Total, wantReview+5)
+	}
+	if math.Abs(report.ByTask["ship"]-5) > 1e-9 {
+		t.Errorf("Ship cost = %f, want 5", report.ByTask["ship"])
+	}
+
+	costs := engine.ActivityCosts()
+	if math.Abs(costs["review"]-wantReview) > 1e-9 {
+		t.Errorf("Activity cost for review = %f, want %f", costs["review"], wantReview)
+	}
+
+	if _, err := engine.CaseCost("missing"); err == nil {
+		t.Error("Expected error for unknown case")
+	}
+}
+
+func TestCaseCostIgnoresIncompleteTasks(t *testing.T) {
+	wf := New("cost_running").
+		Task("work").Cost(100, 0).Done().
+		Start("work").
+		End("work").
+		Build()
+
+	engine := NewEngine(wf)
+	_, _ = engine.StartCase("case-1", nil, PriorityMedium)
+	_ = engine.StartTask("case-1", "work")
+
+	report, err := engine.CaseCost("case-1")
+	if err != nil {
+		t.Fatalf("CaseCost failed: %v", err)
+	}
+	if report.Total != 0 {
+		t.Errorf("Running task should not accrue cost yet, got %f", report.Total)
+	}
+}
@@ -1,11 +1,15 @@
 package workflow
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/pflow-xyz/go-pflow/petri"
+	"github.com/pflow-xyz/go-pflow/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Engine executes workflow instances and manages their lifecycle.
@@ -27,8 +31,17 @@ type Engine struct {
 	onCaseFailed   []func(*Case, error)
 	onAlert        []func(*Alert)
 
+	// Resource allocation (see assign.go)
+	allocation AllocationStrategy
+	rrCursor   int
+
 	// Time source (for testing)
 	now func() time.Time
+
+	// Observability: spans are no-ops until an OpenTelemetry SDK provider
+	// is installed; the logger discards output until WithLogger replaces it.
+	logger telemetry.Logger
+	tracer trace.Tracer
 }
 
 // ResourcePool tracks available resources.
@@ -47,6 +60,8 @@ func NewEngine(workflow *Workflow) *Engine {
 		resources: make(map[string]*ResourcePool),
 		cases:     make(map[string]*Case),
 		now:       time.Now,
+		logger:    telemetry.Nop(),
+		tracer:    telemetry.Tracer("workflow"),
 	}
 
 	// Initialize resource pools
@@ -67,6 +82,13 @@ func (e *Engine) WithTimeSource(now func() time.Time) *Engine {
 	return e
 }
 
+// WithLogger plugs a structured logger into the engine; case and task
+// lifecycle events are logged through it.
+func (e *Engine) WithLogger(logger telemetry.Logger) *Engine {
+	e.logger = logger
+	return e
+}
+
 // OnTaskReady registers a handler for when tasks become ready.
 func (e *Engine) OnTaskReady(handler func(*Case, *TaskInstance)) *Engine {
 	e.onTaskReady = append(e.onTaskReady, handler)
@@ -110,7 +132,14 @@ func (e *Engine) OnAlert(handler func(*Alert)) *Engine {
 }
 
 // StartCase creates and starts a new workflow instance.
-func (e *Engine) StartCase(caseID string, input map[string]any, priority Priority) (*Case, error) {
+func (e *Engine) StartCase(caseID string, input map[string]any, priority Priority) (_ *Case, err error) {
+	_, span := e.tracer.Start(context.Background(), "workflow.start_case",
+		trace.WithAttributes(
+			attribute.String("workflow.id", e.workflow.ID),
+			attribute.String("case.id", caseID),
+		))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	e.casesMu.Lock()
 	defer e.casesMu.Unlock()
 
@@ -172,6 +201,8 @@ func (e *Engine) StartCase(caseID string, input map[string]any, priority Priorit
 	// Enable initial tasks
 	e.enableReadyTasks(c)
 
+	e.logger.Info("case started", "workflow", e.workflow.ID, "case", caseID, "priority", priority)
+
 	return c, nil
 }
 
@@ -342,7 +373,14 @@ func (e *Engine) AssignTask(caseID, taskID, assignee string) error {
 }
 
 // StartTask begins execution of an assigned task.
-func (e *Engine) StartTask(caseID, taskID string) error {
+func (e *Engine) StartTask(caseID, taskID string) (err error) {
+	_, span := e.tracer.Start(context.Background(), "workflow.start_task",
+		trace.WithAttributes(
+			attribute.String("case.id", caseID),
+			attribute.String("task.id", taskID),
+		))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	e.casesMu.RLock()
 	c, exists := e.cases[caseID]
 	if !exists {
@@ -360,6 +398,14 @@ func (e *Engine) StartTask(caseID, taskID string) error {
 		return fmt.Errorf("task %s cannot be started (status: %s)", taskID, instance.Status)
 	}
 
+	// Honor retry backoff
+	if instance.NextRetryAt != nil {
+		if e.now().Before(*instance.NextRetryAt) {
+			return fmt.Errorf("task %s is backing off until %s", taskID, instance.NextRetryAt.Format(time.RFC3339))
+		}
+		instance.NextRetryAt = nil
+	}
+
 	task := e.workflow.Tasks[taskID]
 
 	// If not assigned yet, acquire resources
@@ -389,11 +435,20 @@ func (e *Engine) StartTask(caseID, taskID string) error {
 		handler(c, instance)
 	}
 
+	e.logger.Debug("task started", "case", caseID, "task", taskID)
+
 	return nil
 }
 
 // CompleteTask marks a task as completed.
-func (e *Engine) CompleteTask(caseID, taskID string, output map[string]any) error {
+func (e *Engine) CompleteTask(caseID, taskID string, output map[string]any) (err error) {
+	_, span := e.tracer.Start(context.Background(), "workflow.complete_task",
+		trace.WithAttributes(
+			attribute.String("case.id", caseID),
+			attribute.String("task.id", taskID),
+		))
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	e.casesMu.RLock()
 	c, exists := e.cases[caseID]
 	if !exists {
@@ -424,6 +479,9 @@ func (e *Engine) CompleteTask(caseID, taskID string, output map[string]any) erro
 	}
 	instance.TotalDuration = now.Sub(instance.CreatedAt)
 
+	// Accrue cost: execution fee, hourly fee, and resource usage
+	instance.Cost = e.taskCost(task, instance.WorkDuration)
+
 	// Release resources
 	e.releaseResources(caseID, task.RequiredResources)
 
@@ -450,6 +508,18 @@ func (e *Engine) CompleteTask(caseID, taskID string, output map[string]any) erro
 		handler(c, instance)
 	}
 
+	e.logger.Debug("task completed", "case", caseID, "task", taskID,
+		"work_duration", instance.WorkDuration)
+
+	// A compensating case fails cleanly once its undo tasks are done;
+	// normal progression does not apply.
+	if e.finishCompensationIfDone(c) {
+		return nil
+	}
+
+	// Withdraw cancel-region members this completion triggers
+	e.applyCancelRegions(c, taskID)
+
 	// Check if case is complete
 	if e.isCaseComplete(c) {
 		e.completeCase(c)
@@ -463,6 +533,16 @@ func (e *Engine) CompleteTask(caseID, taskID string, output map[string]any) erro
 
 // FailTask marks a task as failed and handles retry/failure logic.
 func (e *Engine) FailTask(caseID, taskID string, err error) error {
+	// The task error is the point of this call; record it on the span even
+	// when the engine recovers via retry or compensation.
+	_, span := e.tracer.Start(context.Background(), "workflow.fail_task",
+		trace.WithAttributes(
+			attribute.String("case.id", caseID),
+			attribute.String("task.id", taskID),
+		))
+	span.RecordError(err)
+	defer span.End()
+
 	e.casesMu.RLock()
 	c, exists := e.cases[caseID]
 	if !exists {
@@ -488,8 +568,13 @@ func (e *Engine) FailTask(caseID, taskID string, err error) error {
 		// Release resources for retry
 		e.releaseResources(caseID, task.RequiredResources)
 
-		// Wait before retry
-		// In a real implementation, this would be scheduled
+		// Schedule the retry: StartTask refuses until the backoff elapses
+		if task.RetryDelay > 0 {
+			retryAt := now.Add(retryDelay(task, instance.RetryCount))
+			instance.NextRetryAt = &retryAt
+		}
+		e.logger.Warn("task retrying", "case", caseID, "task", taskID,
+			"attempt", instance.RetryCount, "error", err)
 		return nil
 	}
 
@@ -512,8 +597,14 @@ func (e *Engine) FailTask(caseID, taskID string, err error) error {
 		handler(c, instance, err)
 	}
 
+	e.logger.Warn("task failed", "case", caseID, "task", taskID,
+		"action", task.FailureAction, "error", err)
+
 	// Handle failure action
 	switch task.FailureAction {
+	case FailureCompensate:
+		e.startCompensation(c, taskID, err)
+
 	case FailureSkip:
 		e.removeFromCurrentTasks(c, taskID)
 		c.CompletedTasks = append(c.CompletedTasks, taskID)
@@ -649,6 +740,12 @@ func (e *Engine) completeCase(c *Case) {
 	for _, handler := range e.onCaseComplete {
 		handler(c)
 	}
+
+	if c.StartedAt != nil {
+		e.logger.Info("case completed", "case", c.ID, "duration", now.Sub(*c.StartedAt))
+	} else {
+		e.logger.Info("case completed", "case", c.ID)
+	}
 }
 
 // failCase marks a case as failed.
@@ -672,6 +769,8 @@ func (e *Engine) failCase(c *Case, err error) {
 	for _, handler := range e.onCaseFailed {
 		handler(c, err)
 	}
+
+	e.logger.Error("case failed", "case", c.ID, "error", err)
 }
 
 // CancelCase cancels a running case.
@@ -0,0 +1,93 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// startCompensation switches a case into saga mode after a task failure:
+// the compensation tasks of all completed work are enabled so the external
+// driver can run the undo steps. CurrentTasks lists them in reverse
+// completion order — last done, first undone. Once every enabled
+// compensation completes, the case fails with the original cause. With
+// nothing to undo, the case fails immediately.
+func (e *Engine) startCompensation(c *Case, failedTaskID string, cause error) {
+	c.Status = CaseStatusCompensating
+	c.compensationErr = fmt.Errorf("task %s failed: %w", failedTaskID, cause)
+	e.removeFromCurrentTasks(c, failedTaskID)
+
+	now := e.now()
+	enabled := 0
+	for i := len(c.CompletedTasks) - 1; i >= 0; i-- {
+		task := e.workflow.Tasks[c.CompletedTasks[i]]
+		if task == nil || task.CompensationTaskID == "" {
+			continue
+		}
+		comp := c.TaskInstances[task.CompensationTaskID]
+		if comp == nil || comp.Status != TaskStatusPending {
+			continue
+		}
+		comp.Status = TaskStatusReady
+		comp.ReadyAt = &now
+		c.CurrentTasks = append(c.CurrentTasks, task.CompensationTaskID)
+		enabled++
+		for _, handler := range e.onTaskReady {
+			handler(c, comp)
+		}
+	}
+
+	if enabled == 0 {
+		e.failCase(c, c.compensationErr)
+	}
+}
+
+// finishCompensationIfDone fails a compensating case once its undo tasks
+// have all completed. Reports whether the case was in compensation mode.
+func (e *Engine) finishCompensationIfDone(c *Case) bool {
+	if c.Status != CaseStatusCompensating {
+		return false
+	}
+	if len(c.CurrentTasks) == 0 {
+		e.failCase(c, c.compensationErr)
+	}
+	return true
+}
+
+// applyCancelRegions withdraws the members of any cancel region whose
+// trigger just completed. In-flight members release their resources;
+// cancelled members do not satisfy downstream dependencies, so joins past
+// a cancelled branch must use JoinAny or JoinN.
+func (e *Engine) applyCancelRegions(c *Case, completedTaskID string) {
+	now := e.now()
+	for _, region := range e.workflow.CancelRegions {
+		if region.TriggerTaskID != completedTaskID {
+			continue
+		}
+		for _, member := range region.MemberTaskIDs {
+			instance := c.TaskInstances[member]
+			if instance == nil {
+				continue
+			}
+			switch instance.Status {
+			case TaskStatusPending, TaskStatusReady, TaskStatusAssigned, TaskStatusRunning:
+				if instance.Status == TaskStatusAssigned || instance.Status == TaskStatusRunning {
+					e.releaseResources(c.ID, e.workflow.Tasks[member].RequiredResources)
+				}
+				instance.Status = TaskStatusCancelled
+				instance.CompletedAt = &now
+				e.removeFromCurrentTasks(c, member)
+			}
+		}
+	}
+}
+
+// retryDelay computes the wait before a retry attempt (1-based), applying
+// the task's backoff multiplier to its base delay.
+func retryDelay(task *Task, attempt int) time.Duration {
+	delay := task.RetryDelay
+	if task.RetryBackoff > 1 && attempt > 1 {
+		delay = time.Duration(float64(delay) * math.Pow(task.RetryBackoff, float64(attempt-1)))
+	}
+	return delay
+}
@@ -0,0 +1,172 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryWithBackoff(t *testing.T) {
+	wf := New("retry_backoff").
+		Task("sync").Automatic().RetryWithBackoff(2, time.Minute, 2).Done().
+		Start("sync").
+		End("sync").
+		Build()
+
+	base := time.Now()
+	currentTime := base
+	engine := NewEngine(wf).
+		WithTimeSource(func() time.Time { return currentTime })
+
+	_, _ = engine.StartCase("case-1", nil, PriorityMedium)
+	if err := engine.StartTask("case-1", "sync"); err != nil {
+		t.Fatalf("StartTask failed: %v", err)
+	}
+
+	// First failure: retry after the base delay.
+	if err := engine.FailTask("case-1", "sync", errors.New("boom")); err != nil {
+		t.Fatalf("FailTask failed: %v", err)
+	}
+	instance := engine.GetCase("case-1").TaskInstances["sync"]
+	if instance.Status != TaskStatusReady {
+		t.Fatalf("Expected ready for retry, got %s", instance.Status)
+	}
+	if err := engine.StartTask("case-1", "sync"); err == nil {
+		t.Error("Expected backoff to block an immediate retry")
+	}
+	currentTime = base.Add(time.Minute)
+	if err := engine.StartTask("case-1", "sync"); err != nil {
+		t.Fatalf("Retry after backoff failed: %v", err)
+	}
+
+	// Second failure: the delay doubles.
+	if err := engine.FailTask("case-1", "sync", errors.New("boom")); err != nil {
+		t.Fatalf("FailTask failed: %v", err)
+	}
+	currentTime = base.Add(2 * time.Minute)
+	if err := engine.StartTask("case-1", "sync"); err == nil {
+		t.Error("Expected doubled backoff to still block after one minute")
+	}
+	currentTime = base.Add(3 * time.Minute)
+	if err := engine.StartTask("case-1", "sync"); err != nil {
+		t.Fatalf("Retry after doubled backoff failed: %v", err)
+	}
+}
+
+func TestSagaCompensation(t *testing.T) {
+	wf := New("booking").
+		Task("book_flight").CompensateWith("cancel_flight").Done().
+		Task("book_hotel").CompensateWith("cancel_hotel").Done().
+		Task("charge_card").CompensateOnFailure().Done().
+		Task("cancel_flight").Done().
+		Task("cancel_hotel").Done().
+		Connect("book_flight", "book_hotel").
+		Connect("book_hotel", "charge_card").
+		Start("book_flight").
+		End("charge_card").
+		Build()
+
+	engine := NewEngine(wf)
+	var failedWith error
+	engine.OnCaseFailed(func(c *Case, err error) { failedWith = err })
+
+	_, _ = engine.StartCase("case-1", nil, PriorityMedium)
+	for _, id := range []string{"book_flight", "book_hotel"} {
+		if err := engine.StartTask("case-1", id); err != nil {
+			t.Fatalf("StartTask %s failed: %v", id, err)
+		}
+		if err := engine.CompleteTask("case-1", id, nil); err != nil {
+			t.Fatalf("CompleteTask %s failed: %v", id, err)
+		}
+	}
+	if err := engine.StartTask("case-1", "charge_card"); err != nil {
+		t.Fatalf("StartTask failed: %v", err)
+	}
+	if err := engine.FailTask("case-1", "charge_card", errors.New("card declined")); err != nil {
+		t.Fatalf("FailTask failed: %v", err)
+	}
+
+	c := engine.GetCase("case-1")
+	if c.Status != CaseStatusCompensating {
+		t.Fatalf("Expected compensating case, got %s", c.Status)
+	}
+	// Undo tasks enabled in reverse completion order: hotel before flight.
+	if len(c.CurrentTasks) != 2 || c.CurrentTasks[0] != "cancel_hotel" || c.CurrentTasks[1] != "cancel_flight" {
+		t.Fatalf("Expected [cancel_hotel cancel_flight], got %v", c.CurrentTasks)
+	}
+
+	// Drive the undo work; the case then fails with the original cause.
+	for _, id := range []string{"cancel_hotel", "cancel_flight"} {
+		if err := engine.StartTask("case-1", id); err != nil {
+			t.Fatalf("StartTask %s failed: %v", id, err)
+		}
+		if err := engine.CompleteTask("case-1", id, nil); err != nil {
+			t.Fatalf("CompleteTask %s failed: %v", id, err)
+		}
+	}
+	if c.Status != CaseStatusFailed {
+		t.Errorf("Expected failed case after compensation, got %s", c.Status)
+	}
+	if failedWith == nil || !strings.Contains(failedWith.Error(), "card declined") {
+		t.Errorf("Expected the original cause in the failure, got %v", failedWith)
+	}
+}
+
+func TestSagaWithNothingToUndo(t *testing.T) {
+	wf := New("no_undo").
+		Task("first").CompensateOnFailure().Done().
+		Start("first").
+		End("first").
+		Build()
+
+	engine := NewEngine(wf)
+	_, _ = engine.StartCase("case-1", nil, PriorityMedium)
+	_ = engine.StartTask("case-1", "first")
+	_ = engine.FailTask("case-1", "first", errors.New("boom"))
+
+	if got := engine.GetCase("case-1").Status; got != CaseStatusFailed {
+		t.Errorf("Expected immediate failure with nothing to undo, got %s", got)
+	}
+}
+
+func TestCancelRegion(t *testing.T) {
+	wf := New("race").
+		Task("start").Done().
+		Task("fast").Done().
+		Task("slow").Done().
+		Task("finish").WaitForAny().Done().
+		Connect("start", "fast").
+		Connect("start", "slow").
+		Connect("fast", "finish").
+		Connect("slow", "finish").
+		CancelRegion("fast", "slow").
+		Start("start").
+		End("finish").
+		Build()
+
+	engine := NewEngine(wf)
+	_, _ = engine.StartCase("case-1", nil, PriorityMedium)
+	_ = engine.StartTask("case-1", "start")
+	_ = engine.CompleteTask("case-1", "start", nil)
+
+	// fast wins the race; slow is withdrawn.
+	_ = engine.StartTask("case-1", "fast")
+	if err := engine.CompleteTask("case-1", "fast", nil); err != nil {
+		t.Fatalf("CompleteTask failed: %v", err)
+	}
+
+	c := engine.GetCase("case-1")
+	if got := c.TaskInstances["slow"].Status; got != TaskStatusCancelled {
+		t.Errorf("Expected slow cancelled, got %s", got)
+	}
+	if err := engine.StartTask("case-1", "slow"); err == nil {
+		t.Error("Expected cancelled task to refuse starting")
+	}
+
+	_ = engine.StartTask("case-1", "finish")
+	_ = engine.CompleteTask("case-1", "finish", nil)
+	if c.Status != CaseStatusCompleted {
+		t.Errorf("Expected completed case, got %s", c.Status)
+	}
+}
@@ -0,0 +1,339 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/pflow-xyz/go-pflow/plotter"
+)
+
+// SimulateOptions configures Monte Carlo workflow simulation.
+type SimulateOptions struct {
+	Seed int64 // random seed; 0 seeds from the clock
+
+	// SLA is the cycle-time target used for attainment reporting. Zero
+	// falls back to the workflow's default SLA; if neither is set,
+	// attainment is not reported.
+	SLA time.Duration
+}
+
+// SimulationReport summarizes n stochastic workflow executions: cycle-time
+// percentiles, resource utilization, and SLA attainment. Where the ODE
+// predictor gives one mean-field estimate, the Monte Carlo runs expose the
+// spread that resource contention and duration variability create.
+type SimulationReport struct {
+	Runs      int
+	Completed float64 // fraction of runs that reached the end tasks
+
+	// Cycle-time statistics over completed runs.
+	Mean time.Duration
+	P50  time.Duration
+	P90  time.Duration
+	P95  time.Duration
+	P99  time.Duration
+
+	// SLAAttainment is the fraction of all runs that completed within
+	// SLATarget; zero target disables attainment accounting.
+	SLATarget     time.Duration
+	SLAAttainment float64
+
+	// Utilization per capacity-bounded resource: busy resource-time over
+	// capacity times makespan, averaged across runs.
+	Utilization map[string]float64
+
+	cycles []float64 // completed cycle times in seconds, sorted
+}
+
+// Simulate runs n stochastic executions of a workflow and reports
+// cycle-time percentiles, resource utilization, and SLA attainment. Task
+// durations are sampled from a triangular distribution over
+// (MinDuration, EstimatedDuration, MaxDuration) when a range is set, and
+// exponentially with mean EstimatedDuration otherwise. Ready tasks wait
+// for their required resources, so contention for bounded pools shows up
+// in the cycle times. Task and dependency conditions are not evaluated;
+// every dependency is treated as finish-to-start.
+func Simulate(wf *Workflow, n int, opts *SimulateOptions) (*SimulationReport, error) {
+	if len(wf.Tasks) == 0 {
+		return nil, fmt.Errorf("workflow has no tasks")
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("need at least one run, got %d", n)
+	}
+	if opts == nil {
+		opts = &SimulateOptions{}
+	}
+	sla := opts.SLA
+	if sla == 0 && wf.SLA != nil {
+		sla = wf.SLA.Default
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	report := &SimulationReport{
+		Runs:        n,
+		SLATarget:   sla,
+		Utilization: make(map[string]float64),
+	}
+	busyTotal := make(map[string]float64)
+	capacityTime := make(map[string]float64)
+	attained := 0
+	completed := 0
+
+	for i := 0; i < n; i++ {
+		cycle, busy, ok := simulateRun(wf, rng)
+		for res, b := range busy {
+			if wf.Resources[res] == nil {
+				continue
+			}
+			busyTotal[res] += b
+			capacityTime[res] += wf.Resources[res].Capacity * cycle
+		}
+		if !ok {
+			continue
+		}
+		completed++
+		report.cycles = append(report.cycles, cycle)
+		if sla > 0 && secondsDuration(cycle) <= sla {
+			attained++
+		}
+	}
+
+	report.Completed = float64(completed) / float64(n)
+	if sla > 0 {
+		report.SLAAttainment = float64(attained) / float64(n)
+	}
+	for res, busy := range busyTotal {
+		if capacityTime[res] > 0 {
+			report.Utilization[res] = busy / capacityTime[res]
+		}
+	}
+
+	sort.Float64s(report.cycles)
+	if len(report.cycles) > 0 {
+		sum := 0.0
+		for _, c := range report.cycles {
+			sum += c
+		}
+		report.Mean = secondsDuration(sum / float64(len(report.cycles)))
+		report.P50 = secondsDuration(cycleQuantile(report.cycles, 0.50))
+		report.P90 = secondsDuration(cycleQuantile(report.cycles, 0.90))
+		report.P95 = secondsDuration(cycleQuantile(report.cycles, 0.95))
+		report.P99 = secondsDuration(cycleQuantile(report.cycles, 0.99))
+	}
+	return report, nil
+}
+
+// simulateRun executes one discrete-event pass over the workflow. Returns
+// the cycle time in seconds, per-resource busy time, and whether the run
+// reached the end tasks.
+func simulateRun(wf *Workflow, rng *rand.Rand) (float64, map[string]float64, bool) {
+	avail := make(map[string]float64, len(wf.Resources))
+	for id, res := range wf.Resources {
+		avail[id] = res.Capacity
+	}
+	busy := make(map[string]float64)
+
+	type runningTask struct {
+		id   string
+		done float64
+	}
+	var running []runningTask
+	done := make(map[string]bool, len(wf.Tasks))
+	started := make(map[string]bool, len(wf.Tasks))
+	now := 0.0
+
+	// Deterministic task order keeps seeded runs reproducible.
+	order := make([]string, 0, len(wf.Tasks))
+	for id := range wf.Tasks {
+		order = append(order, id)
+	}
+	sort.Strings(order)
+
+	maxEvents := 2*len(wf.Tasks) + 100
+	for event := 0; event < maxEvents; event++ {
+		// Start every ready task whose resources are free.
+		for _, id := range order {
+			if started[id] || !joinSatisfied(wf, id, done) {
+				continue
+			}
+			task := wf.Tasks[id]
+			if !acquire(wf, avail, task.RequiredResources) {
+				continue
+			}
+			dur := sampleTaskDuration(rng, task)
+			for _, req := range task.RequiredResources {
+				busy[req.ResourceID] += requirementQty(req) * dur
+			}
+			started[id] = true
+			running = append(running, runningTask{id: id, done: now + dur})
+		}
+
+		if len(running) == 0 {
+			// Nothing in flight: either the flow finished or it is
+			// stuck waiting on resources or unreachable joins.
+			return now, busy, endTasksDone(wf, done)
+		}
+
+		// Advance to the earliest completion.
+		earliest := 0
+		for i := 1; i < len(running); i++ {
+			if running[i].done < running[earliest].done {
+				earliest = i
+			}
+		}
+		finished := running[earliest]
+		running = append(running[:earliest], running[earliest+1:]...)
+		now = finished.done
+		done[finished.id] = true
+		release(wf, avail, wf.Tasks[finished.id].RequiredResources)
+	}
+	return now, busy, false
+}
+
+// joinSatisfied reports whether a task's incoming dependencies are met,
+// honoring its join type. Tasks with no incoming dependencies are ready
+// only if they are the start task.
+func joinSatisfied(wf *Workflow, taskID string, done map[string]bool) bool {
+	satisfied, incoming := 0, 0
+	for _, dep := range wf.Dependencies {
+		if dep.ToTaskID != taskID {
+			continue
+		}
+		incoming++
+		if done[dep.FromTaskID] {
+			satisfied++
+		}
+	}
+	if incoming == 0 {
+		return taskID == wf.StartTaskID
+	}
+	switch wf.Tasks[taskID].JoinType {
+	case JoinAny:
+		return satisfied > 0
+	case JoinN:
+		return satisfied >= wf.Tasks[taskID].JoinCount
+	default:
+		return satisfied == incoming
+	}
+}
+
+// acquire takes the required quantities from the pools, or takes nothing
+// and reports false if any pool falls short. Unknown and unbounded
+// (zero-capacity) pools never block.
+func acquire(wf *Workflow, avail map[string]float64, reqs []ResourceRequirement) bool {
+	for _, req := range reqs {
+		if res := wf.Resources[req.ResourceID]; res == nil || res.Capacity == 0 {
+			continue
+		}
+		if avail[req.ResourceID] < requirementQty(req) {
+			return false
+		}
+	}
+	for _, req := range reqs {
+		if res := wf.Resources[req.ResourceID]; res == nil || res.Capacity == 0 {
+			continue
+		}
+		avail[req.ResourceID] -= requirementQty(req)
+	}
+	return true
+}
+
+func release(wf *Workflow, avail map[string]float64, reqs []ResourceRequirement) {
+	for _, req := range reqs {
+		if res := wf.Resources[req.ResourceID]; res == nil || res.Capacity == 0 {
+			continue
+		}
+		avail[req.ResourceID] += requirementQty(req)
+	}
+}
+
+func requirementQty(req ResourceRequirement) float64 {
+	if req.Quantity == 0 {
+		return 1
+	}
+	return req.Quantity
+}
+
+// endTasksDone reports whether the workflow's exit points all completed;
+// with no declared end tasks, every task must complete.
+func endTasksDone(wf *Workflow, done map[string]bool) bool {
+	if len(wf.EndTaskIDs) == 0 {
+		return len(done) == len(wf.Tasks)
+	}
+	for _, id := range wf.EndTaskIDs {
+		if !done[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// sampleTaskDuration draws one task duration in seconds: triangular over
+// the estimate range when one is declared, exponential with the estimated
+// mean otherwise, zero for tasks with no estimate.
+func sampleTaskDuration(rng *rand.Rand, task *Task) float64 {
+	mode := task.EstimatedDuration.Seconds()
+	if task.MaxDuration > 0 {
+		lo, hi := task.MinDuration.Seconds(), task.MaxDuration.Seconds()
+		if mode < lo {
+			mode = lo
+		}
+		if mode > hi {
+			mode = hi
+		}
+		if hi <= lo {
+			return lo
+		}
+		u := rng.Float64()
+		if u < (mode-lo)/(hi-lo) {
+			return lo + math.Sqrt(u*(hi-lo)*(mode-lo))
+		}
+		return hi - math.Sqrt((1-u)*(hi-lo)*(hi-mode))
+	}
+	if mode > 0 {
+		return rng.ExpFloat64() * mode
+	}
+	return 0
+}
+
+// cycleQuantile reads the q-th quantile from a sorted sample.
+func cycleQuantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(q * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// PlotCycleTimes renders the empirical cycle-time distribution (CDF) as an
+// SVG chart, with a dashed marker at the SLA target when one is set.
+func (r *SimulationReport) PlotCycleTimes(width, height float64) string {
+	p := plotter.NewSVGPlotter(width, height).
+		SetTitle("Cycle time distribution").
+		SetXLabel("cycle time (s)").
+		SetYLabel("fraction of runs")
+
+	x := make([]float64, len(r.cycles))
+	y := make([]float64, len(r.cycles))
+	copy(x, r.cycles)
+	for i := range y {
+		y[i] = float64(i+1) / float64(len(r.cycles))
+	}
+	p.AddSeries(x, y, "cycle time", "#0000ff")
+
+	if r.SLATarget > 0 && len(r.cycles) > 0 {
+		sla := r.SLATarget.Seconds()
+		p.AddDashed([]float64{sla, sla}, []float64{0, 1}, "SLA target", "#ff0000")
+	}
+	return p.Render()
+}
@@ -0,0 +1,126 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSimulateDeterministicChain(t *testing.T) {
+	wf := New("chain").
+		Task("a").DurationRange(time.Minute, time.Minute, time.Minute).Done().
+		Task("b").DurationRange(2*time.Minute, 2*time.Minute, 2*time.Minute).Done().
+		Connect("a", "b").
+		Start("a").
+		End("b").
+		Build()
+
+	report, err := Simulate(wf, 50, &SimulateOptions{Seed: 1, SLA: 5 * time.Minute})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if report.Completed != 1 {
+		t.Errorf("Expected all runs to complete, got %f", report.Completed)
+	}
+	// Fixed durations: every run takes exactly 3 minutes.
+	if report.P50 != 3*time.Minute || report.P99 != 3*time.Minute {
+		t.Errorf("Expected 3m cycle time, got P50=%v P99=%v", report.P50, report.P99)
+	}
+	if report.SLAAttainment != 1 {
+		t.Errorf("Expected full SLA attainment, got %f", report.SLAAttainment)
+	}
+
+	// An impossible target is never attained.
+	tight, _ := Simulate(wf, 50, &SimulateOptions{Seed: 1, SLA: time.Minute})
+	if tight.SLAAttainment != 0 {
+		t.Errorf("Expected zero attainment under 1m target, got %f", tight.SLAAttainment)
+	}
+}
+
+func TestSimulateResourceContention(t *testing.T) {
+	// Two parallel one-minute tasks share a single worker, so they
+	// serialize: the cycle time is two minutes, not one.
+	wf := New("contention").
+		Task("start").Done().
+		Task("left").DurationRange(time.Minute, time.Minute, time.Minute).
+		RequireResource("worker", 1).Done().
+		Task("right").DurationRange(time.Minute, time.Minute, time.Minute).
+		RequireResource("worker", 1).Done().
+		Task("finish").Done().
+		Connect("start", "left").
+		Connect("start", "right").
+		Connect("left", "finish").
+		Connect("right", "finish").
+		Resource("worker").Capacity(1).Done().
+		Start("start").
+		End("finish").
+		Build()
+
+	report, err := Simulate(wf, 20, &SimulateOptions{Seed: 2})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if report.Completed != 1 {
+		t.Fatalf("Expected all runs to complete, got %f", report.Completed)
+	}
+	if report.P50 != 2*time.Minute {
+		t.Errorf("Expected serialized 2m cycle time, got %v", report.P50)
+	}
+	// The worker is busy the whole makespan.
+	if util := report.Utilization["worker"]; util < 0.9 {
+		t.Errorf("Expected near-full worker utilization, got %f", util)
+	}
+}
+
+func TestSimulateStochasticAttainment(t *testing.T) {
+	// Exponential durations with a target at the mean: attainment should
+	// land near 1-1/e, far from both 0 and 1.
+	wf := New("stochastic").
+		Task("work").Duration(10 * time.Minute).Done().
+		Start("work").
+		End("work").
+		Build()
+
+	report, err := Simulate(wf, 2000, &SimulateOptions{Seed: 3, SLA: 10 * time.Minute})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	if report.SLAAttainment < 0.5 || report.SLAAttainment > 0.75 {
+		t.Errorf("Expected attainment near 0.63, got %f", report.SLAAttainment)
+	}
+	if report.P90 <= report.P50 {
+		t.Errorf("Expected spread in the percentiles: P50=%v P90=%v", report.P50, report.P90)
+	}
+}
+
+func TestSimulatePlot(t *testing.T) {
+	wf := New("plot").
+		Task("work").Duration(time.Minute).Done().
+		Start("work").
+		End("work").
+		Build()
+
+	report, err := Simulate(wf, 100, &SimulateOptions{Seed: 4, SLA: time.Minute})
+	if err != nil {
+		t.Fatalf("Simulate failed: %v", err)
+	}
+	svg := report.PlotCycleTimes(640, 400)
+	if !strings.Contains(svg, "<svg") {
+		t.Error("Expected SVG output")
+	}
+	if !strings.Contains(svg, "SLA target") {
+		t.Error("Expected the SLA marker in the chart")
+	}
+}
+
+func TestSimulateValidation(t *testing.T) {
+	wf := New("empty").Build()
+	if _, err := Simulate(wf, 10, nil); err == nil {
+		t.Error("Expected error for workflow with no tasks")
+	}
+
+	wf = New("one").Task("t").Done().Start("t").End("t").Build()
+	if _, err := Simulate(wf, 0, nil); err == nil {
+		t.Error("Expected error for zero runs")
+	}
+}
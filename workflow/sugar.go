@@ -146,6 +146,22 @@ func (b *Builder) Loop(taskID, decisionID, continueID string) *Builder {
 	return b
 }
 
+// CancelRegion declares that completing the trigger task withdraws any of
+// the member tasks still pending or in flight. Racing branches ("first
+// response wins") join their shared successor with WaitForAny.
+func (b *Builder) CancelRegion(triggerID string, memberIDs ...string) *Builder {
+	for _, id := range append([]string{triggerID}, memberIDs...) {
+		if _, exists := b.workflow.Tasks[id]; !exists {
+			b.Task(id).Done()
+		}
+	}
+	b.workflow.CancelRegions = append(b.workflow.CancelRegions, &CancelRegion{
+		TriggerTaskID: triggerID,
+		MemberTaskIDs: memberIDs,
+	})
+	return b
+}
+
 // ============================================================================
 // Resource Shortcuts
 // ============================================================================
@@ -235,6 +251,33 @@ func (tb *TaskBuilder) RetryOnFailure(maxRetries int) *TaskBuilder {
 	return tb
 }
 
+// RetryWithBackoff sets retry behavior with exponential backoff: the delay
+// before attempt n is delay * multiplier^(n-1). StartTask refuses to run
+// the retry until the backoff has elapsed.
+func (tb *TaskBuilder) RetryWithBackoff(maxRetries int, delay time.Duration, multiplier float64) *TaskBuilder {
+	tb.task.MaxRetries = maxRetries
+	tb.task.RetryDelay = delay
+	tb.task.RetryBackoff = multiplier
+	tb.task.FailureAction = FailureRetry
+	return tb
+}
+
+// CompensateWith names the task that undoes this one during saga
+// compensation. Compensation tasks are ordinary tasks with no incoming
+// dependencies; they stay pending until a failure triggers the saga.
+func (tb *TaskBuilder) CompensateWith(taskID string) *TaskBuilder {
+	tb.task.CompensationTaskID = taskID
+	return tb
+}
+
+// CompensateOnFailure marks this task to trigger saga compensation when it
+// fails: completed tasks with a CompensateWith handler are undone in
+// reverse order, then the case fails with the original error.
+func (tb *TaskBuilder) CompensateOnFailure() *TaskBuilder {
+	tb.task.FailureAction = FailureCompensate
+	return tb
+}
+
 // SkipOnFailure marks task to be skipped if it fails
 func (tb *TaskBuilder) SkipOnFailure() *TaskBuilder {
 	tb.task.FailureAction = FailureSkip
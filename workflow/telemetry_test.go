@@ -0,0 +1,94 @@
+package workflow
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingLogger captures structured log entries for assertions.
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+type logEntry struct {
+	level   string
+	msg     string
+	keyvals []any
+}
+
+func (l *recordingLogger) record(level, msg string, keyvals []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, logEntry{level, msg, keyvals})
+}
+
+func (l *recordingLogger) Debug(msg string, kv ...any) { l.record("debug", msg, kv) }
+func (l *recordingLogger) Info(msg string, kv ...any)  { l.record("info", msg, kv) }
+func (l *recordingLogger) Warn(msg string, kv ...any)  { l.record("warn", msg, kv) }
+func (l *recordingLogger) Error(msg string, kv ...any) { l.record("error", msg, kv) }
+
+func (l *recordingLogger) has(level, msg string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range l.entries {
+		if e.level == level && e.msg == msg {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEngineLogsCaseLifecycle(t *testing.T) {
+	wf := New("logged").
+		AutoTask("only", "Only", time.Second).
+		Start("only").End("only").
+		Build()
+
+	logger := &recordingLogger{}
+	engine := NewEngine(wf).WithLogger(logger)
+
+	if _, err := engine.StartCase("case-1", nil, PriorityMedium); err != nil {
+		t.Fatalf("StartCase: %v", err)
+	}
+	if err := engine.StartTask("case-1", "only"); err != nil {
+		t.Fatalf("StartTask: %v", err)
+	}
+	if err := engine.CompleteTask("case-1", "only", nil); err != nil {
+		t.Fatalf("CompleteTask: %v", err)
+	}
+
+	for _, want := range []struct{ level, msg string }{
+		{"info", "case started"},
+		{"debug", "task started"},
+		{"debug", "task completed"},
+		{"info", "case completed"},
+	} {
+		if !logger.has(want.level, want.msg) {
+			t.Errorf("Missing %s-level %q entry; got %+v", want.level, want.msg, logger.entries)
+		}
+	}
+}
+
+func TestEngineLogsTaskFailure(t *testing.T) {
+	wf := New("failing").
+		AutoTask("only", "Only", time.Second).
+		Start("only").End("only").
+		Build()
+
+	logger := &recordingLogger{}
+	engine := NewEngine(wf).WithLogger(logger)
+
+	engine.StartCase("case-1", nil, PriorityMedium)
+	engine.StartTask("case-1", "only")
+	engine.FailTask("case-1", "only", errors.New("boom"))
+
+	if !logger.has("warn", "task failed") {
+		t.Errorf("Missing task failure log; got %+v", logger.entries)
+	}
+	if !logger.has("error", "case failed") {
+		t.Errorf("Missing case failure log; got %+v", logger.entries)
+	}
+}
@@ -105,15 +105,25 @@ type Task struct {
 	RequiredResources []ResourceRequirement // Resources needed to execute
 	ProducedResources []ResourceProduction  // Resources released on completion
 
+	// Staffing (see assign.go)
+	CandidateRoles []string // Roles allowed to work this task (empty = any worker)
+	RequiredSkills []string // Skills a worker must have to be assigned
+
 	// Dependencies
 	JoinType  JoinType  // How to handle multiple predecessors
 	JoinCount int       // For JoinN type
 	SplitType SplitType // How to trigger successors
 
 	// Retry/failure handling
-	MaxRetries    int           // Max retry attempts (0 = no retries)
-	RetryDelay    time.Duration // Delay between retries
-	FailureAction FailureAction // What to do on failure
+	MaxRetries         int           // Max retry attempts (0 = no retries)
+	RetryDelay         time.Duration // Delay between retries
+	RetryBackoff       float64       // Multiplier applied to RetryDelay per attempt (0 or 1 = constant)
+	FailureAction      FailureAction // What to do on failure
+	CompensationTaskID string        // Task that undoes this one during saga compensation
+
+	// Cost
+	CostPerExecution float64 // Cost charged each time the task runs
+	CostPerHour      float64 // Cost per hour of work time
 
 	// SLA
 	SLA *TaskSLA // Task-level SLA (optional)
@@ -161,6 +171,15 @@ const (
 	FailureCompensate FailureAction = "compensate" // Run compensation
 )
 
+// CancelRegion groups tasks that are withdrawn together: when the trigger
+// task completes, any member still pending or in flight is cancelled. This
+// models racing branches ("first response wins") and cleanup on shortcut
+// paths without hand-wiring cancellation edges.
+type CancelRegion struct {
+	TriggerTaskID string
+	MemberTaskIDs []string
+}
+
 // Dependency represents a connection between two tasks
 type Dependency struct {
 	FromTaskID string
@@ -190,6 +209,11 @@ type Resource struct {
 	MaxConcurrent  int           // Max concurrent users (0 = unlimited)
 	AcquireTimeout time.Duration // Max wait time to acquire
 
+	// Staffing (see assign.go)
+	Roles    []string           // Roles this worker can fill
+	Skills   map[string]float64 // Skill name -> proficiency (0-1)
+	Calendar *WorkCalendar      // Working hours (nil = always available)
+
 	// Labels for matching
 	Labels     map[string]string
 	Attributes map[string]any
@@ -252,14 +276,16 @@ type TaskInstance struct {
 	AssignedAt *time.Time
 
 	// Execution
-	RetryCount int
-	Error      string
-	Output     map[string]any // Task output data
+	RetryCount  int
+	NextRetryAt *time.Time // Earliest allowed retry start (backoff)
+	Error       string
+	Output      map[string]any // Task output data
 
 	// Metrics
 	WaitDuration  time.Duration // Time in ready state
 	WorkDuration  time.Duration // Actual execution time
 	TotalDuration time.Duration // End-to-end duration
+	Cost          float64       // Accrued cost (computed at completion)
 }
 
 // Case represents a running workflow instance
@@ -289,18 +315,23 @@ type Case struct {
 	Labels     map[string]string
 	Attributes map[string]any
 	ParentCase string // For subflows
+
+	// compensationErr holds the failure that triggered saga compensation;
+	// reported through the case-failed handlers once undo work finishes.
+	compensationErr error
 }
 
 // CaseStatus represents workflow instance lifecycle
 type CaseStatus string
 
 const (
-	CaseStatusCreated   CaseStatus = "created"
-	CaseStatusRunning   CaseStatus = "running"
-	CaseStatusCompleted CaseStatus = "completed"
-	CaseStatusFailed    CaseStatus = "failed"
-	CaseStatusCancelled CaseStatus = "cancelled"
-	CaseStatusSuspended CaseStatus = "suspended"
+	CaseStatusCreated      CaseStatus = "created"
+	CaseStatusRunning      CaseStatus = "running"
+	CaseStatusCompleted    CaseStatus = "completed"
+	CaseStatusFailed       CaseStatus = "failed"
+	CaseStatusCancelled    CaseStatus = "cancelled"
+	CaseStatusSuspended    CaseStatus = "suspended"
+	CaseStatusCompensating CaseStatus = "compensating" // Undoing completed work after a failure
 )
 
 // ExecutionContext provides runtime context for callbacks and conditions
@@ -357,10 +388,11 @@ type Workflow struct {
 	Version     string
 
 	// Structure
-	Tasks        map[string]*Task
-	Dependencies []*Dependency
-	StartTaskID  string   // Entry point
-	EndTaskIDs   []string // Exit points
+	Tasks         map[string]*Task
+	Dependencies  []*Dependency
+	StartTaskID   string          // Entry point
+	EndTaskIDs    []string        // Exit points
+	CancelRegions []*CancelRegion // Task groups withdrawn when a trigger completes
 
 	// Resources
 	Resources map[string]*Resource
@@ -6,6 +6,8 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/pflow-xyz/go-pflow/reachability"
 )
 
 func TestWorkflowBuilder(t *testing.T) {
@@ -664,6 +666,120 @@ func TestWorkflowToPetriNet(t *testing.T) {
 	}
 }
 
+func TestToPetriNetParallelGateways(t *testing.T) {
+	// A fans out to B and C (AND-split); D waits for both (AND-join).
+	wf := New("and_gateways").
+		Task("A").Done().
+		Task("B").Done().
+		Task("C").Done().
+		Task("D").JoinAll().Done().
+		Connect("A", "B").
+		Connect("A", "C").
+		Connect("B", "D").
+		Connect("C", "D").
+		Start("A").
+		End("D").
+		Build()
+
+	result := reachability.NewAnalyzer(wf.ToPetriNet()).WithMaxStates(1000).Analyze()
+	if !result.Bounded {
+		t.Fatal("AND gateway net should be bounded")
+	}
+	// The split activates both branches, and the join fires exactly once.
+	if result.MaxTokens["B_ready"] != 1 || result.MaxTokens["C_ready"] != 1 {
+		t.Errorf("Both branches should activate: B=%d C=%d",
+			result.MaxTokens["B_ready"], result.MaxTokens["C_ready"])
+	}
+	if result.MaxTokens["D_ready"] != 1 {
+		t.Errorf("AND-join should enable D exactly once, got %d", result.MaxTokens["D_ready"])
+	}
+}
+
+func TestToPetriNetExclusiveGateways(t *testing.T) {
+	// A routes to exactly one of B or C (XOR-split); D runs after
+	// whichever branch was taken (XOR-merge).
+	wf := New("xor_gateways").
+		Task("A").SplitExclusive().Done().
+		Task("B").Done().
+		Task("C").Done().
+		Task("D").JoinAny().Done().
+		Connect("A", "B").
+		Connect("A", "C").
+		Connect("B", "D").
+		Connect("C", "D").
+		Start("A").
+		End("D").
+		Build()
+
+	result := reachability.NewAnalyzer(wf.ToPetriNet()).WithMaxStates(1000).Analyze()
+	if !result.Bounded {
+		t.Fatal("XOR gateway net should be bounded")
+	}
+	// Only one branch holds a token at a time, and D runs once.
+	if result.MaxTokens["D_ready"] != 1 {
+		t.Errorf("XOR-merge should enable D exactly once, got %d", result.MaxTokens["D_ready"])
+	}
+	if result.MaxTokens["B_running"]+result.MaxTokens["C_running"] != 2 {
+		// Each branch is reachable in some run (max is per-place across
+		// all states, so both report 1).
+		t.Errorf("Each branch should be individually reachable: B=%d C=%d",
+			result.MaxTokens["B_running"], result.MaxTokens["C_running"])
+	}
+}
+
+func TestToPetriNetInclusiveGateways(t *testing.T) {
+	// A activates any subset of B and C (OR-split); D waits for exactly
+	// the activated branches (OR-join). Before gateway-aware conversion
+	// this merged as XOR and D could run twice.
+	wf := New("or_gateways").
+		Task("A").SplitInclusive().Done().
+		Task("B").Done().
+		Task("C").Done().
+		Task("D").JoinAny().Done().
+		Connect("A", "B").
+		Connect("A", "C").
+		Connect("B", "D").
+		Connect("C", "D").
+		Start("A").
+		End("D").
+		Build()
+
+	result := reachability.NewAnalyzer(wf.ToPetriNet()).WithMaxStates(1000).Analyze()
+	if !result.Bounded {
+		t.Fatal("OR gateway net should be bounded")
+	}
+	// The OR-join must fire exactly once per case whether one or both
+	// branches ran — never twice.
+	if result.MaxTokens["D_ready"] != 1 {
+		t.Errorf("OR-join should enable D exactly once, got %d", result.MaxTokens["D_ready"])
+	}
+	// Both branches remain individually reachable.
+	if result.MaxTokens["B_running"] != 1 || result.MaxTokens["C_running"] != 1 {
+		t.Errorf("Branches should be reachable: B=%d C=%d",
+			result.MaxTokens["B_running"], result.MaxTokens["C_running"])
+	}
+	// The run where both branches activate synchronizes at the join.
+	analyzer := reachability.NewAnalyzer(wf.ToPetriNet()).WithMaxStates(1000)
+	if ok, _ := analyzer.CanFire([]string{
+		"start_A", "complete_A",
+		"split_A", "take_A_B", "take_A_C",
+		"join_B", "start_B", "complete_B", "split_B",
+		"join_C", "start_C", "complete_C", "split_C",
+		"join_D",
+	}); !ok {
+		t.Error("Both-branches run should reach the OR-join")
+	}
+	// A skipped branch contributes a bypass token instead of blocking.
+	if ok, _ := analyzer.CanFire([]string{
+		"start_A", "complete_A",
+		"split_A", "take_A_B", "skip_A_C",
+		"join_B", "start_B", "complete_B", "split_B",
+		"join_D",
+	}); !ok {
+		t.Error("Single-branch run should reach the OR-join via the bypass token")
+	}
+}
+
 func TestSequenceHelper(t *testing.T) {
 	wf := New("sequence_test").
 		Task("A").Done().